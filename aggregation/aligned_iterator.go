@@ -0,0 +1,84 @@
+package aggregation
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// AlignedIterator represents the row-aligned iterator of multi fields in one series,
+// yields the values of all fields for every step on a common slot grid.
+type AlignedIterator interface {
+	// Fields returns the metas of fields, values of row are matched by index
+	Fields() []field.Meta
+	// HasNext returns if the iteration has more rows
+	HasNext() bool
+	// Next returns the row of next step, values are in order of fields
+	Next() (timeSlot int, values []float64)
+}
+
+// alignedIterator implements AlignedIterator interface based on fixed step iterator of each field
+type alignedIterator struct {
+	fields  []field.Meta
+	its     []series.FieldIterator // nil if the field has no data
+	missing float64                // value of field which has no data
+	slot    int
+	endSlot int
+	step    int
+}
+
+// NewAlignedIterator creates the row-aligned iterator which aligns the fields of series onto
+// the slot grid(start slot + n * step) in [start slot, end slot], the gaps of each field are filled based on fill policy.
+func NewAlignedIterator(it series.MultiFieldIterator, startSlot, endSlot, step int, fill FillPolicy) AlignedIterator {
+	if step <= 0 {
+		step = 1
+	}
+	fields := it.Fields()
+	aligned := &alignedIterator{
+		fields:  fields,
+		its:     make([]series.FieldIterator, len(fields)),
+		missing: math.NaN(),
+		slot:    startSlot,
+		endSlot: endSlot,
+		step:    step,
+	}
+	if fill.Type == FillValue {
+		aligned.missing = fill.Value
+	}
+	for idx, f := range fields {
+		fieldIt := it.FieldIterator(f.Name)
+		if fieldIt != nil {
+			aligned.its[idx] = NewFixedStepIterator(fieldIt, startSlot, endSlot, step, fill)
+		}
+	}
+	return aligned
+}
+
+// Fields returns the metas of fields, values of row are matched by index
+func (it *alignedIterator) Fields() []field.Meta {
+	return it.fields
+}
+
+// HasNext returns if the iteration has more rows
+func (it *alignedIterator) HasNext() bool {
+	return it.slot <= it.endSlot
+}
+
+// Next returns the row of next step, values are in order of fields
+func (it *alignedIterator) Next() (timeSlot int, values []float64) {
+	if !it.HasNext() {
+		return -1, nil
+	}
+	timeSlot = it.slot
+	it.slot += it.step
+	values = make([]float64, len(it.its))
+	for idx, fieldIt := range it.its {
+		if fieldIt == nil {
+			values[idx] = it.missing
+			continue
+		}
+		_, values[idx] = fieldIt.Next()
+	}
+	return timeSlot, values
+}
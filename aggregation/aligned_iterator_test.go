@@ -0,0 +1,86 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// newDisjointMultiFieldIterator creates the series with f1(10:1, 12:3), f2(11:2, 13:4) and f3(no data)
+func newDisjointMultiFieldIterator() series.MultiFieldIterator {
+	f1 := collections.NewFloatArray(10)
+	f1.SetValue(0, 1)
+	f1.SetValue(2, 3)
+	f2 := collections.NewFloatArray(10)
+	f2.SetValue(1, 2)
+	f2.SetValue(3, 4)
+	fields := []field.Meta{
+		{ID: 1, Name: "f1", Type: field.SumField},
+		{ID: 2, Name: "f2", Type: field.GaugeField},
+		{ID: 3, Name: "f3", Type: field.SumField},
+	}
+	return series.NewMultiFieldIterator(nil, fields, []series.FieldIterator{
+		NewFieldIterator(10, field.Sum, f1),
+		NewFieldIterator(10, field.Replace, f2),
+		nil,
+	})
+}
+
+func assertAlignedIt(t *testing.T, it AlignedIterator, slots []int, rows [][]float64) {
+	idx := 0
+	for it.HasNext() {
+		slot, values := it.Next()
+		assert.Equal(t, slots[idx], slot)
+		assert.Len(t, values, len(rows[idx]))
+		for i, v := range rows[idx] {
+			if math.IsNaN(v) {
+				assert.True(t, math.IsNaN(values[i]), "slot: %d, field: %d", slot, i)
+			} else {
+				assert.Equal(t, v, values[i], "slot: %d, field: %d", slot, i)
+			}
+		}
+		idx++
+	}
+	assert.Equal(t, len(slots), idx)
+	slot, values := it.Next()
+	assert.Equal(t, -1, slot)
+	assert.Nil(t, values)
+}
+
+func TestAlignedIterator(t *testing.T) {
+	nan := math.NaN()
+	it := NewAlignedIterator(newDisjointMultiFieldIterator(), 10, 14, 1, FillPolicy{Type: FillNull})
+	assert.Len(t, it.Fields(), 3)
+	assertAlignedIt(t, it,
+		[]int{10, 11, 12, 13, 14},
+		[][]float64{{1, nan, nan}, {nan, 2, nan}, {3, nan, nan}, {nan, 4, nan}, {nan, nan, nan}})
+
+	it = NewAlignedIterator(newDisjointMultiFieldIterator(), 10, 14, 1, FillPolicy{Type: FillPrevious})
+	assertAlignedIt(t, it,
+		[]int{10, 11, 12, 13, 14},
+		[][]float64{{1, nan, nan}, {1, 2, nan}, {3, 2, nan}, {3, 4, nan}, {3, 4, nan}})
+
+	it = NewAlignedIterator(newDisjointMultiFieldIterator(), 10, 14, 1, FillPolicy{Type: FillValue, Value: 0})
+	assertAlignedIt(t, it,
+		[]int{10, 11, 12, 13, 14},
+		[][]float64{{1, 0, 0}, {0, 2, 0}, {3, 0, 0}, {0, 4, 0}, {0, 0, 0}})
+}
+
+func TestAlignedIterator_Step(t *testing.T) {
+	nan := math.NaN()
+	// step 2 aggregates data points in same step by agg type of each field
+	it := NewAlignedIterator(newDisjointMultiFieldIterator(), 10, 13, 2, FillPolicy{})
+	assertAlignedIt(t, it,
+		[]int{10, 12},
+		[][]float64{{1, 2, nan}, {3, 4, nan}})
+	// invalid step is regarded as 1
+	it = NewAlignedIterator(newDisjointMultiFieldIterator(), 10, 11, -1, FillPolicy{})
+	assertAlignedIt(t, it,
+		[]int{10, 11},
+		[][]float64{{1, nan, nan}, {nan, 2, nan}})
+}
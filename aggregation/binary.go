@@ -1,6 +1,8 @@
 package aggregation
 
 import (
+	"math"
+
 	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -34,7 +36,7 @@ func binaryEval(binaryOp stmt.BinaryOP, left, right collections.FloatArray) coll
 	return result
 }
 
-// eval evaluates two values and returns another value
+// eval evaluates two values and returns another value, division by zero returns NaN
 func eval(binaryOp stmt.BinaryOP, left, right float64) float64 {
 	switch binaryOp {
 	case stmt.ADD:
@@ -45,7 +47,7 @@ func eval(binaryOp stmt.BinaryOP, left, right float64) float64 {
 		return left * right
 	case stmt.DIV:
 		if right == 0 {
-			return 0
+			return math.NaN()
 		}
 		return left / right
 	default:
@@ -15,7 +15,7 @@ func TestBinary_eval(t *testing.T) {
 	assert.Equal(t, float64(-2), eval(stmt.SUB, 4, 6))
 	assert.Equal(t, float64(24), eval(stmt.MUL, 4, 6))
 	assert.Equal(t, 0.5, eval(stmt.DIV, 4, 8))
-	assert.Equal(t, float64(0), eval(stmt.DIV, 4, 0))
+	assert.True(t, math.IsNaN(eval(stmt.DIV, 4, 0)))
 
 	// wrong binary operator
 	assert.Equal(t, float64(0), eval(stmt.OR, 4, 8))
@@ -105,6 +105,6 @@ func TestBinaryEval(t *testing.T) {
 	result = binaryEval(stmt.DIV, fa, fa2)
 	assert.Equal(t, 3, result.Size())
 	assert.Equal(t, 1.0, result.GetValue(0))
-	assert.Equal(t, 0.0, result.GetValue(5))
+	assert.True(t, math.IsNaN(result.GetValue(5)))
 	assert.Equal(t, 0.0, result.GetValue(8))
 }
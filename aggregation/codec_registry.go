@@ -0,0 +1,69 @@
+package aggregation
+
+import (
+	"sync"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series/field"
+)
+
+// codecKey represents the field of metric which overrides the value codec
+type codecKey struct {
+	metricID  uint32
+	fieldName field.Name
+}
+
+// CodecRegistry represents the registry of value codec of one database which is used when marshals field data,
+// the codec registered by metric/field overrides the codec registered by field type.
+type CodecRegistry struct {
+	types  map[field.Type]encoding.CodecID
+	fields map[codecKey]encoding.CodecID
+	mutex  sync.RWMutex
+}
+
+// NewCodecRegistry creates the registry of value codec, xor codec for all field types by default
+func NewCodecRegistry() *CodecRegistry {
+	return &CodecRegistry{
+		types:  make(map[field.Type]encoding.CodecID),
+		fields: make(map[codecKey]encoding.CodecID),
+	}
+}
+
+// RegisterTypeCodec registers the value codec of field type
+func (r *CodecRegistry) RegisterTypeCodec(fieldType field.Type, codec encoding.CodecID) {
+	r.mutex.Lock()
+	r.types[fieldType] = codec
+	r.mutex.Unlock()
+}
+
+// RegisterFieldCodec registers the value codec of metric's field, which overrides the codec of field type
+func (r *CodecRegistry) RegisterFieldCodec(metricID uint32, fieldName field.Name, codec encoding.CodecID) {
+	r.mutex.Lock()
+	r.fields[codecKey{metricID: metricID, fieldName: fieldName}] = codec
+	r.mutex.Unlock()
+}
+
+// UnregisterFieldCodec removes the value codec of metric's field
+func (r *CodecRegistry) UnregisterFieldCodec(metricID uint32, fieldName field.Name) {
+	r.mutex.Lock()
+	delete(r.fields, codecKey{metricID: metricID, fieldName: fieldName})
+	r.mutex.Unlock()
+}
+
+// GetCodec returns the value codec by metric/field, then by field type,
+// xor codec if not registered or registry is nil.
+func (r *CodecRegistry) GetCodec(metricID uint32, fieldName field.Name, fieldType field.Type) encoding.CodecID {
+	if r == nil {
+		return encoding.XORCodec
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if codec, ok := r.fields[codecKey{metricID: metricID, fieldName: fieldName}]; ok {
+		return codec
+	}
+	if codec, ok := r.types[fieldType]; ok {
+		return codec
+	}
+	return encoding.XORCodec
+}
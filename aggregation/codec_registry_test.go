@@ -0,0 +1,34 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestCodecRegistry_GetCodec(t *testing.T) {
+	var nilRegistry *CodecRegistry
+	assert.Equal(t, encoding.XORCodec, nilRegistry.GetCodec(1, "f", field.SumField))
+
+	r := NewCodecRegistry()
+	assert.Equal(t, encoding.XORCodec, r.GetCodec(1, "f", field.SumField))
+	assert.Equal(t, encoding.XORCodec, r.GetCodec(1, "f", field.GaugeField))
+	assert.Equal(t, encoding.XORCodec, r.GetCodec(1, "f", field.Type(0)))
+
+	r.RegisterTypeCodec(field.GaugeField, encoding.RLECodec)
+	assert.Equal(t, encoding.RLECodec, r.GetCodec(1, "f", field.GaugeField))
+	// field codec overrides type codec
+	r.RegisterFieldCodec(1, "f", encoding.DeltaCodec)
+	assert.Equal(t, encoding.DeltaCodec, r.GetCodec(1, "f", field.GaugeField))
+	// field codec is scoped by metric
+	assert.Equal(t, encoding.RLECodec, r.GetCodec(2, "f", field.GaugeField))
+	assert.Equal(t, encoding.XORCodec, r.GetCodec(2, "f", field.SumField))
+	assert.Equal(t, encoding.XORCodec, r.GetCodec(1, "f1", field.SumField))
+	r.UnregisterFieldCodec(1, "f")
+	assert.Equal(t, encoding.RLECodec, r.GetCodec(1, "f", field.GaugeField))
+	// registry is not shared
+	assert.Equal(t, encoding.XORCodec, NewCodecRegistry().GetCodec(1, "f", field.GaugeField))
+}
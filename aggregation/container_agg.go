@@ -1,20 +1,266 @@
 package aggregation
 
+import (
+	"sort"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
 //go:generate mockgen -source=./container_agg.go -destination=./container_agg_mock.go -package=aggregation
 
-// ContainerAggregator represents the aggregator's container with the aggregates of fields.
+// ContainerAggregator represents the aggregator's container with the aggregates of fields,
+// loads the data of series from storage, then aggregates the down sampling result of series into the group.
 type ContainerAggregator interface {
-	// GetFieldAggregates returns the aggregates of fields that need query.
+	// GetFieldAggregates returns the aggregates of fields that need query, aligned with the field ids of query.
 	GetFieldAggregates() FieldAggregates
+	// Aggregate aggregates the data of current scanned series into the group, then clears the loaded data.
+	Aggregate()
+	// ResultSet returns the result set of the group
+	ResultSet(tags string) series.GroupedIterator
+	// Reset resets the aggregator's context for reusing
+	Reset()
 }
 
+// containerAggregator implements ContainerAggregator interface
 type containerAggregator struct {
+	fieldAggregates FieldAggregates           // loads the data of series, aligned with field ids
+	aggTypes        [][]field.AggType         // agg types of each field, based on the functions of aggregator spec
+	aggregates      FieldAggregates           // aggregates of the group, sorted by field name
+	aggregateIdxs   []int                     // index of group aggregate for each field
+	seriesFuncs     [][]*seriesFuncAggregator // function of series for each agg type, aligned with agg types
+}
+
+// NewContainerAggregator creates the container aggregator based on the down sampling aggregator specs,
+// which are aligned with the field ids of query, the aliased fields are aggregated into same group aggregate.
+func NewContainerAggregator(
+	queryInterval timeutil.Interval,
+	ratio int,
+	queryTimeRange timeutil.TimeRange,
+	aggSpecs AggregatorSpecs,
+) ContainerAggregator {
+	fieldNames := make(map[field.Name]struct{})
+	var groupSpecs AggregatorSpecs
+	for _, aggSpec := range aggSpecs {
+		if _, ok := fieldNames[aggSpec.FieldName()]; ok {
+			continue
+		}
+		fieldNames[aggSpec.FieldName()] = struct{}{}
+		groupSpecs = append(groupSpecs, aggSpec)
+	}
+	agg := &containerAggregator{
+		fieldAggregates: NewFieldAggregates(queryInterval, ratio, queryTimeRange, true, aggSpecs),
+		aggTypes:        make([][]field.AggType, len(aggSpecs)),
+		aggregates:      NewFieldAggregates(queryInterval, 1, queryTimeRange, false, groupSpecs),
+		aggregateIdxs:   make([]int, len(aggSpecs)),
+		seriesFuncs:     make([][]*seriesFuncAggregator, len(aggSpecs)),
+	}
+	for idx, aggSpec := range aggSpecs {
+		agg.aggTypes[idx] = getAggTypes(aggSpec)
+		for aggIdx, aggregate := range agg.aggregates {
+			if aggregate.FieldName() == aggSpec.FieldName() {
+				agg.aggregateIdxs[idx] = aggIdx
+				break
+			}
+		}
+		seriesAgg, ok := agg.aggregates[agg.aggregateIdxs[idx]].(*seriesAggregator)
+		if !ok {
+			continue
+		}
+		agg.seriesFuncs[idx] = make([]*seriesFuncAggregator, len(agg.aggTypes[idx]))
+		for aggTypeIdx, aggType := range agg.aggTypes[idx] {
+			agg.seriesFuncs[idx][aggTypeIdx] = newSeriesFuncAggregator(aggType, aggSpec, seriesAgg)
+		}
+	}
+	return agg
 }
 
-func NewContainerAggregator() ContainerAggregator {
-	return &containerAggregator{}
+// getAggTypes returns the agg types of field data which are required by the functions of aggregator spec
+func getAggTypes(aggSpec AggregatorSpec) []field.AggType {
+	var aggTypes []field.AggType
+	for funcType := range aggSpec.Functions() {
+		for _, aggType := range aggSpec.GetFieldType().GetFuncFieldParams(funcType) {
+			exist := false
+			for _, t := range aggTypes {
+				if t == aggType {
+					exist = true
+					break
+				}
+			}
+			if !exist {
+				aggTypes = append(aggTypes, aggType)
+			}
+		}
+	}
+	sort.Slice(aggTypes, func(i, j int) bool {
+		return aggTypes[i] < aggTypes[j]
+	})
+	return aggTypes
 }
 
+// GetFieldAggregates returns the aggregates of fields that need query.
 func (c *containerAggregator) GetFieldAggregates() FieldAggregates {
-	panic("implement me")
+	return c.fieldAggregates
+}
+
+// Aggregate aggregates the data of current scanned series into the group, then clears the loaded data.
+func (c *containerAggregator) Aggregate() {
+	for idx, fieldAgg := range c.fieldAggregates {
+		loader, ok := fieldAgg.(*seriesAggregator)
+		if !ok {
+			continue
+		}
+		seriesAgg, ok := c.aggregates[c.aggregateIdxs[idx]].(*seriesAggregator)
+		if !ok {
+			continue
+		}
+		for aggTypeIdx, aggType := range c.aggTypes[idx] {
+			var it series.FieldIterator
+			if seriesFunc := c.seriesFuncs[idx][aggTypeIdx]; seriesFunc != nil {
+				it = seriesFunc.aggregate(loader, seriesAgg)
+			} else {
+				it = downSampling(loader, seriesAgg, aggType)
+			}
+			if it != nil {
+				seriesAgg.aggregate(seriesAgg.startTime, it)
+			}
+		}
+		loader.Reset()
+	}
+}
+
+// ResultSet returns the result set of the group
+func (c *containerAggregator) ResultSet(tags string) series.GroupedIterator {
+	return c.aggregates.ResultSet(tags)
+}
+
+// Reset resets the aggregator's context for reusing
+func (c *containerAggregator) Reset() {
+	c.fieldAggregates.Reset()
+	c.aggregates.Reset()
+}
+
+// downSampling down samples the data points loaded by loader into the time slots of series aggregator by agg type,
+// returns the field iterator of series, the partial state is aggregated into series aggregator directly.
+func downSampling(loader, seriesAgg *seriesAggregator, aggType field.AggType) series.FieldIterator {
+	if aggType.IsState() {
+		stateAgg, ok := seriesAgg.getAggregator(aggType).(*stateFieldAggregator)
+		if ok {
+			loader.forEachLoaded(func(timestamp int64, value float64) {
+				stateAgg.add(seriesAgg.slotOf(timestamp), timestamp, value)
+			})
+		}
+		return nil
+	}
+	aggFunc := aggType.AggFunc()
+	if aggType == field.Sample {
+		// sampled data points are the down sampling values of series, folded by the agg func of field type
+		aggFunc = loader.fieldType.GetAggFunc()
+		if aggFunc == nil {
+			aggFunc = field.Replace.AggFunc()
+		}
+	}
+	if aggFunc == nil {
+		return nil
+	}
+	it := &SafeFieldIterator{aggType: aggType}
+	loader.forEachLoaded(func(timestamp int64, value float64) {
+		slot := seriesAgg.slotOf(timestamp)
+		last := len(it.slots) - 1
+		if last >= 0 && it.slots[last] == slot {
+			// folds the data points of same time slot
+			it.values[last] = aggFunc.Aggregate(it.values[last], value)
+			return
+		}
+		it.slots = append(it.slots, slot)
+		it.values = append(it.values, value)
+	})
+	if len(it.slots) == 0 {
+		return nil
+	}
+	return it.Iterator()
+}
+
+// seriesFunc represents the function which is calculated based on the data points of one series in time order
+type seriesFunc interface {
+	// Aggregate aggregates the data point of series in time order
+	Aggregate(timestamp int64, value float64)
+	// ResultSet returns the result of series, index is based on the start time by step
+	ResultSet() collections.FloatArray
+	// Reset resets the function for next series
+	Reset()
+}
+
+// seriesFuncAggregator calculates the function of each series(e.g. increase of counter), because the function
+// cannot be calculated after the series are folded, then the result of series is aggregated into the group
+// by the agg func of agg type.
+type seriesFuncAggregator struct {
+	aggType   field.AggType
+	startTime int64
+	step      int64 // time range of each index of result
+	fn        seriesFunc
+}
+
+// newSeriesFuncAggregator creates the series function aggregator by agg type, returns nil if not series function
+func newSeriesFuncAggregator(
+	aggType field.AggType,
+	aggSpec AggregatorSpec,
+	seriesAgg *seriesAggregator,
+) *seriesFuncAggregator {
+	switch aggType {
+	case field.Increase:
+		// increase of tumbling windows starts from the start time of series aggregator
+		window := aggSpec.FunctionParam(function.Increase)
+		if window <= 0 {
+			window = seriesAgg.queryInterval.Int64()
+		}
+		capacity := int((seriesAgg.queryTimeRange.End-seriesAgg.startTime)/window) + 1
+		return &seriesFuncAggregator{
+			aggType:   aggType,
+			startTime: seriesAgg.startTime,
+			step:      window,
+			fn:        NewIncreaseAggregator(seriesAgg.startTime, window, capacity),
+		}
+	case field.NonNegativeDerivative:
+		// derivative between consecutive data points, the last derivative in time slot of query interval wins
+		interval := seriesAgg.queryInterval.Int64()
+		return &seriesFuncAggregator{
+			aggType:   aggType,
+			startTime: seriesAgg.startTime,
+			step:      interval,
+			fn:        NewNonNegativeDerivativeAggregator(seriesAgg.startTime, interval, seriesAgg.endSlot+1),
+		}
+	default:
+		return nil
+	}
+}
+
+// aggregate calculates the function based on the data points loaded by loader, returns the field iterator
+// of result which is based on the time slots of series aggregator.
+func (a *seriesFuncAggregator) aggregate(loader, seriesAgg *seriesAggregator) series.FieldIterator {
+	defer a.fn.Reset()
+
+	loader.forEachLoaded(a.fn.Aggregate)
+	values := a.fn.ResultSet()
+	if values.IsEmpty() {
+		return nil
+	}
+	aggFunc := a.aggType.AggFunc()
+	it := &SafeFieldIterator{aggType: a.aggType}
+	result := values.Iterator()
+	for result.HasNext() {
+		idx, value := result.Next()
+		slot := seriesAgg.slotOf(a.startTime + int64(idx)*a.step)
+		last := len(it.slots) - 1
+		if last >= 0 && it.slots[last] == slot {
+			it.values[last] = aggFunc.Aggregate(it.values[last], value)
+			continue
+		}
+		it.slots = append(it.slots, slot)
+		it.values = append(it.values, value)
+	}
+	return it.Iterator()
 }
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./container_agg.go
+
+// Package aggregation is a generated GoMock package.
+package aggregation
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	series "github.com/lindb/lindb/series"
+	reflect "reflect"
+)
+
+// MockContainerAggregator is a mock of ContainerAggregator interface
+type MockContainerAggregator struct {
+	ctrl     *gomock.Controller
+	recorder *MockContainerAggregatorMockRecorder
+}
+
+// MockContainerAggregatorMockRecorder is the mock recorder for MockContainerAggregator
+type MockContainerAggregatorMockRecorder struct {
+	mock *MockContainerAggregator
+}
+
+// NewMockContainerAggregator creates a new mock instance
+func NewMockContainerAggregator(ctrl *gomock.Controller) *MockContainerAggregator {
+	mock := &MockContainerAggregator{ctrl: ctrl}
+	mock.recorder = &MockContainerAggregatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockContainerAggregator) EXPECT() *MockContainerAggregatorMockRecorder {
+	return m.recorder
+}
+
+// GetFieldAggregates mocks base method
+func (m *MockContainerAggregator) GetFieldAggregates() FieldAggregates {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFieldAggregates")
+	ret0, _ := ret[0].(FieldAggregates)
+	return ret0
+}
+
+// GetFieldAggregates indicates an expected call of GetFieldAggregates
+func (mr *MockContainerAggregatorMockRecorder) GetFieldAggregates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFieldAggregates", reflect.TypeOf((*MockContainerAggregator)(nil).GetFieldAggregates))
+}
+
+// Aggregate mocks base method
+func (m *MockContainerAggregator) Aggregate() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Aggregate")
+}
+
+// Aggregate indicates an expected call of Aggregate
+func (mr *MockContainerAggregatorMockRecorder) Aggregate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockContainerAggregator)(nil).Aggregate))
+}
+
+// ResultSet mocks base method
+func (m *MockContainerAggregator) ResultSet(tags string) series.GroupedIterator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResultSet", tags)
+	ret0, _ := ret[0].(series.GroupedIterator)
+	return ret0
+}
+
+// ResultSet indicates an expected call of ResultSet
+func (mr *MockContainerAggregatorMockRecorder) ResultSet(tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResultSet", reflect.TypeOf((*MockContainerAggregator)(nil).ResultSet), tags)
+}
+
+// Reset mocks base method
+func (m *MockContainerAggregator) Reset() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reset")
+}
+
+// Reset indicates an expected call of Reset
+func (mr *MockContainerAggregatorMockRecorder) Reset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockContainerAggregator)(nil).Reset))
+}
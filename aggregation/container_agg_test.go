@@ -0,0 +1,113 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestContainerAggregator_Aggregate(t *testing.T) {
+	now, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	newSpec := func(fieldName field.Name, fieldType field.Type, funcTypes ...function.FuncType) AggregatorSpec {
+		aggSpec := NewDownSamplingSpec(fieldName, fieldType)
+		for _, funcType := range funcTypes {
+			aggSpec.AddFunctionType(funcType)
+		}
+		return aggSpec
+	}
+	// f is aliased by two field ids, g is gauge field without agg type of sum function
+	agg := NewContainerAggregator(timeutil.Interval(timeutil.OneMinute), 6,
+		timeutil.TimeRange{Start: now, End: now + 5*timeutil.OneMinute},
+		AggregatorSpecs{
+			newSpec("f", field.SumField, function.Sum, function.DistinctCount),
+			newSpec("g", field.GaugeField, function.Sum),
+			newSpec("f", field.SumField, function.Sum, function.DistinctCount),
+		})
+	fieldAggs := agg.GetFieldAggregates()
+	assert.Len(t, fieldAggs, 3)
+	load := func(fieldIdx int, values ...float64) {
+		// storage interval = 10s
+		block, ok := fieldAggs[fieldIdx].GetAggregateBlock(now)
+		assert.True(t, ok)
+		for slot, value := range values {
+			block.Append(slot, value)
+		}
+	}
+	// series 1
+	load(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)
+	load(1, 1, 1, 1)
+	agg.Aggregate()
+	// series 2
+	load(2, 1, 1, 1, 1, 1, 1)
+	agg.Aggregate()
+	// series 3, no data
+	agg.Aggregate()
+
+	rs := agg.ResultSet("1.1.1.1")
+	assert.Equal(t, "1.1.1.1", rs.Tags())
+	assert.True(t, rs.HasNext())
+	sIt := rs.Next()
+	assert.Equal(t, field.Name("f"), sIt.FieldName())
+	assert.True(t, sIt.HasNext())
+	startTime, it := sIt.Next()
+	assert.Equal(t, now, startTime)
+	assert.Equal(t, field.Sum, it.AggType())
+	AssertFieldIt(t, it, map[int]float64{0: 27, 1: 57})
+	assert.True(t, sIt.HasNext())
+	_, it = sIt.Next()
+	assert.Equal(t, field.DistinctCount, it.AggType())
+	_, ok := it.(series.StateFieldIterator)
+	assert.True(t, ok)
+	assertDistinctCountIt(t, it, map[int]int{0: 6, 1: 6})
+	assert.False(t, sIt.HasNext())
+	assert.True(t, rs.HasNext())
+	sIt = rs.Next()
+	assert.Equal(t, field.Name("g"), sIt.FieldName())
+	assert.False(t, sIt.HasNext())
+	assert.False(t, rs.HasNext())
+
+	// reset for next group
+	agg.Reset()
+	load(0, 1)
+	agg.Aggregate()
+	rs = agg.ResultSet("1.1.1.2")
+	assert.True(t, rs.HasNext())
+	sIt = rs.Next()
+	assert.True(t, sIt.HasNext())
+	_, it = sIt.Next()
+	AssertFieldIt(t, it, map[int]float64{0: 1})
+	assert.True(t, sIt.HasNext())
+	_, it = sIt.Next()
+	assertDistinctCountIt(t, it, map[int]int{0: 1})
+}
+
+func TestGetAggTypes(t *testing.T) {
+	aggSpec := NewDownSamplingSpec("f", field.SumField)
+	assert.Empty(t, getAggTypes(aggSpec))
+	aggSpec.AddFunctionType(function.DistinctCount)
+	aggSpec.AddFunctionType(function.Max)
+	aggSpec.AddFunctionType(function.MaxTime)
+	aggSpec.AddFunctionType(function.Sum)
+	assert.Equal(t, []field.AggType{field.Sum, field.Max, field.DistinctCount, field.MaxTime}, getAggTypes(aggSpec))
+}
+
+func TestNewSeriesFuncAggregator(t *testing.T) {
+	now, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	aggSpec := NewDownSamplingSpec("f", field.GaugeField)
+	seriesAgg := NewSeriesAggregator(timeutil.Interval(timeutil.OneMinute), 1,
+		timeutil.TimeRange{Start: now, End: now + 5*timeutil.OneMinute}, false, aggSpec).(*seriesAggregator)
+	assert.Nil(t, newSeriesFuncAggregator(field.Sum, aggSpec, seriesAgg))
+	// window of increase is query interval if not set
+	agg := newSeriesFuncAggregator(field.Increase, aggSpec, seriesAgg)
+	assert.Equal(t, timeutil.OneMinute, agg.step)
+	assert.NoError(t, aggSpec.SetFunctionParam(function.Increase, 2*timeutil.OneMinute))
+	agg = newSeriesFuncAggregator(field.Increase, aggSpec, seriesAgg)
+	assert.Equal(t, 2*timeutil.OneMinute, agg.step)
+	agg = newSeriesFuncAggregator(field.NonNegativeDerivative, aggSpec, seriesAgg)
+	assert.Equal(t, timeutil.OneMinute, agg.step)
+}
@@ -0,0 +1,145 @@
+package aggregation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+// CountAggregator represents the count aggregator for each time slot, which tracks both counts:
+// 1) count counts the present(non-null) data points, the time slot without data point is null;
+// 2) count_all counts all data points includes the filled nulls(NaN), every time slot in range has a count.
+// The partial counts are mergeable, so they can be aggregated across segments, shards and nodes.
+type CountAggregator interface {
+	// Aggregate counts the data point of time slot, null(NaN) value is only counted by count_all
+	Aggregate(slot int, value float64)
+	// Merge merges other aggregator's partial counts into current aggregator
+	Merge(other CountAggregator) error
+	// Count returns the count of present(non-null) data points of each time slot
+	Count() collections.FloatArray
+	// CountAll returns the count of all data points(includes nulls) of each time slot, 0 if no data point
+	CountAll() collections.FloatArray
+	// MarshalBinary marshals the partial counts for cross-node aggregation
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary unmarshals the partial counts, then merges them into current aggregator
+	UnmarshalBinary(data []byte) error
+	// Reset resets the aggregator for reusing
+	Reset()
+}
+
+// countAggregator implements CountAggregator interface
+type countAggregator struct {
+	present collections.FloatArray
+	all     collections.FloatArray
+}
+
+// NewCountAggregator creates the count aggregator with time slot capacity
+func NewCountAggregator(capacity int) CountAggregator {
+	return &countAggregator{
+		present: collections.NewFloatArray(capacity),
+		all:     collections.NewFloatArray(capacity),
+	}
+}
+
+// Aggregate counts the data point of time slot, null(NaN) value is only counted by count_all
+func (a *countAggregator) Aggregate(slot int, value float64) {
+	if !math.IsNaN(value) {
+		addCount(a.present, slot, 1)
+	}
+	addCount(a.all, slot, 1)
+}
+
+// Merge merges other aggregator's partial counts into current aggregator
+func (a *countAggregator) Merge(other CountAggregator) error {
+	o, ok := other.(*countAggregator)
+	if !ok {
+		return fmt.Errorf("cannot merge count aggregator with type: %T", other)
+	}
+	if a.all.Capacity() != o.all.Capacity() {
+		return fmt.Errorf("cannot merge count aggregator with different capacity: %d, %d",
+			a.all.Capacity(), o.all.Capacity())
+	}
+	mergeCount(a.present, o.present)
+	mergeCount(a.all, o.all)
+	return nil
+}
+
+// Count returns the count of present(non-null) data points of each time slot
+func (a *countAggregator) Count() collections.FloatArray {
+	return a.present
+}
+
+// CountAll returns the count of all data points(includes nulls) of each time slot, 0 if no data point
+func (a *countAggregator) CountAll() collections.FloatArray {
+	capacity := a.all.Capacity()
+	result := collections.NewFloatArray(capacity)
+	for slot := 0; slot < capacity; slot++ {
+		count := 0.0
+		if a.all.HasValue(slot) {
+			count = a.all.GetValue(slot)
+		}
+		result.SetValue(slot, count)
+	}
+	return result
+}
+
+// MarshalBinary marshals the partial counts,
+// format: present counts + all counts, counts: slot count + [slot + count]...
+func (a *countAggregator) MarshalBinary() ([]byte, error) {
+	writer := stream.NewBufferWriter(nil)
+	for _, counts := range []collections.FloatArray{a.present, a.all} {
+		writer.PutUvarint32(uint32(counts.Size()))
+		it := counts.Iterator()
+		for it.HasNext() {
+			slot, count := it.Next()
+			writer.PutUvarint32(uint32(slot))
+			writer.PutUvarint64(uint64(count))
+		}
+	}
+	return writer.Bytes()
+}
+
+// UnmarshalBinary unmarshals the partial counts, then merges them into current aggregator
+func (a *countAggregator) UnmarshalBinary(data []byte) error {
+	reader := stream.NewReader(data)
+	for _, counts := range []collections.FloatArray{a.present, a.all} {
+		size := int(reader.ReadUvarint32())
+		for i := 0; i < size; i++ {
+			slot := int(reader.ReadUvarint32())
+			count := reader.ReadUvarint64()
+			if reader.Error() != nil {
+				return reader.Error()
+			}
+			addCount(counts, slot, float64(count))
+		}
+	}
+	return reader.Error()
+}
+
+// Reset resets the aggregator for reusing
+func (a *countAggregator) Reset() {
+	a.present.Reset()
+	a.all.Reset()
+}
+
+// addCount adds the count into the count of time slot
+func addCount(counts collections.FloatArray, slot int, count float64) {
+	if slot < 0 || slot >= counts.Capacity() {
+		return
+	}
+	if counts.HasValue(slot) {
+		count += counts.GetValue(slot)
+	}
+	counts.SetValue(slot, count)
+}
+
+// mergeCount adds the counts of other into the counts
+func mergeCount(counts, other collections.FloatArray) {
+	it := other.Iterator()
+	for it.HasNext() {
+		slot, count := it.Next()
+		addCount(counts, slot, count)
+	}
+}
@@ -0,0 +1,89 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountAggregator(t *testing.T) {
+	nan := math.NaN()
+	// sparse series: slot 1 is filled null, slot 2/4 have no data point
+	agg := NewCountAggregator(5)
+	for _, p := range []struct {
+		slot  int
+		value float64
+	}{{0, 1}, {0, 2}, {1, nan}, {3, 5}, {5, 1}, {-1, 1}} {
+		agg.Aggregate(p.slot, p.value)
+	}
+	count := agg.Count()
+	assert.Equal(t, 2.0, count.GetValue(0))
+	assert.False(t, count.HasValue(1))
+	assert.False(t, count.HasValue(2))
+	assert.Equal(t, 1.0, count.GetValue(3))
+	assert.False(t, count.HasValue(4))
+
+	countAll := agg.CountAll()
+	for slot, expect := range []float64{2, 1, 0, 1, 0} {
+		assert.True(t, countAll.HasValue(slot))
+		assert.Equal(t, expect, countAll.GetValue(slot), "slot: %d", slot)
+	}
+
+	agg.Reset()
+	assert.True(t, agg.Count().IsEmpty())
+	assert.Equal(t, 0.0, agg.CountAll().GetValue(0))
+}
+
+func TestCountAggregator_Merge(t *testing.T) {
+	nan := math.NaN()
+	node1 := []float64{1, nan, 3}
+	node2 := []float64{nan, 2}
+	newAgg := func(values ...[]float64) CountAggregator {
+		agg := NewCountAggregator(3)
+		for _, vs := range values {
+			for slot, value := range vs {
+				agg.Aggregate(slot, value)
+			}
+		}
+		return agg
+	}
+	// single node full aggregation
+	expect := newAgg(node1, node2)
+	// node1 + node2, node2 + node1
+	agg1 := newAgg(node1)
+	assert.NoError(t, agg1.Merge(newAgg(node2)))
+	agg2 := newAgg(node2)
+	assert.NoError(t, agg2.Merge(newAgg(node1)))
+	for _, agg := range []CountAggregator{agg1, agg2} {
+		assert.Equal(t, expect.Count(), agg.Count())
+		assert.Equal(t, expect.CountAll(), agg.CountAll())
+	}
+	assert.Equal(t, 1.0, agg1.Count().GetValue(0))
+	assert.Equal(t, 2.0, agg1.CountAll().GetValue(0))
+
+	// different capacity
+	assert.Error(t, agg1.Merge(NewCountAggregator(10)))
+	assert.Error(t, agg1.Merge(nil))
+}
+
+func TestCountAggregator_MarshalBinary(t *testing.T) {
+	agg := NewCountAggregator(3)
+	agg.Aggregate(0, 1)
+	agg.Aggregate(0, 2)
+	agg.Aggregate(2, math.NaN())
+	data, err := agg.MarshalBinary()
+	assert.NoError(t, err)
+
+	other := NewCountAggregator(3)
+	other.Aggregate(0, 3)
+	assert.NoError(t, other.UnmarshalBinary(data))
+	assert.Equal(t, 3.0, other.Count().GetValue(0))
+	assert.False(t, other.Count().HasValue(2))
+	countAll := other.CountAll()
+	for slot, expect := range []float64{3, 0, 1} {
+		assert.Equal(t, expect, countAll.GetValue(slot), "slot: %d", slot)
+	}
+
+	assert.Error(t, other.UnmarshalBinary([]byte{1}))
+}
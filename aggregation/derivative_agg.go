@@ -0,0 +1,83 @@
+package aggregation
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// NonNegativeDerivativeAggregator represents the aggregator which calculates the per second derivative
+// between consecutive data points, negative derivative is clamped to 0 instead of being treated as counter reset,
+// which is used for the gauge that should only increase.
+type NonNegativeDerivativeAggregator interface {
+	// Aggregate calculates the derivative from previous data point, sets it into the slot which the timestamp
+	// belongs to, data points must be aggregated in time order, out of order data points are ignored.
+	Aggregate(timestamp int64, value float64)
+	// ResultSet returns the derivative of each slot
+	ResultSet() collections.FloatArray
+	// Reset resets the aggregator for reusing
+	Reset()
+}
+
+// nonNegativeDerivativeAggregator implements NonNegativeDerivativeAggregator interface,
+// slot i is [startTime+i*interval, startTime+(i+1)*interval), the last derivative in slot wins.
+type nonNegativeDerivativeAggregator struct {
+	startTime int64
+	interval  int64
+	values    collections.FloatArray
+
+	hasPrev       bool
+	prevTimestamp int64
+	prevValue     float64
+}
+
+// NewNonNegativeDerivativeAggregator creates the nonnegative derivative aggregator with start time,
+// slot interval(millis) and slot capacity
+func NewNonNegativeDerivativeAggregator(startTime, interval int64, capacity int) NonNegativeDerivativeAggregator {
+	return &nonNegativeDerivativeAggregator{
+		startTime: startTime,
+		interval:  interval,
+		values:    collections.NewFloatArray(capacity),
+	}
+}
+
+// Aggregate calculates (curr-prev)/dt(seconds) from previous data point, replaces negative with 0
+func (a *nonNegativeDerivativeAggregator) Aggregate(timestamp int64, value float64) {
+	if a.interval <= 0 || math.IsNaN(value) {
+		return
+	}
+	if a.hasPrev && timestamp <= a.prevTimestamp {
+		// out of order data point
+		return
+	}
+	hasPrev, prevTimestamp, prevValue := a.hasPrev, a.prevTimestamp, a.prevValue
+	a.hasPrev = true
+	a.prevTimestamp = timestamp
+	a.prevValue = value
+	if !hasPrev || timestamp < a.startTime {
+		return
+	}
+	slot := int((timestamp - a.startTime) / a.interval)
+	if slot >= a.values.Capacity() {
+		return
+	}
+	derivative := (value - prevValue) / (float64(timestamp-prevTimestamp) / float64(timeutil.OneSecond))
+	if derivative < 0 {
+		derivative = 0
+	}
+	a.values.SetValue(slot, derivative)
+}
+
+// ResultSet returns the derivative of each slot
+func (a *nonNegativeDerivativeAggregator) ResultSet() collections.FloatArray {
+	return a.values
+}
+
+// Reset resets the aggregator for reusing
+func (a *nonNegativeDerivativeAggregator) Reset() {
+	a.values.Reset()
+	a.hasPrev = false
+	a.prevTimestamp = 0
+	a.prevValue = 0
+}
@@ -0,0 +1,58 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestNonNegativeDerivativeAggregator(t *testing.T) {
+	agg := NewNonNegativeDerivativeAggregator(0, 10*timeutil.OneSecond, 6)
+	// first data point has no derivative
+	agg.Aggregate(5*timeutil.OneSecond, 10)
+	// (30-10)/10s
+	agg.Aggregate(15*timeutil.OneSecond, 30)
+	agg.Aggregate(12*timeutil.OneSecond, 100) // out of order
+	// decreases, clamps to 0
+	agg.Aggregate(25*timeutil.OneSecond, 20)
+	// derivative from the decreased value, not a counter reset: (40-20)/20s
+	agg.Aggregate(45*timeutil.OneSecond, 40)
+	// out of slots
+	agg.Aggregate(100*timeutil.OneSecond, 100)
+
+	rs := agg.ResultSet()
+	assert.False(t, rs.HasValue(0))
+	assert.Equal(t, 2.0, rs.GetValue(1))
+	assert.True(t, rs.HasValue(2))
+	assert.Equal(t, 0.0, rs.GetValue(2))
+	assert.False(t, rs.HasValue(3))
+	assert.Equal(t, 1.0, rs.GetValue(4))
+	assert.Equal(t, 3, rs.Size())
+
+	agg.Reset()
+	assert.True(t, agg.ResultSet().IsEmpty())
+	agg.Aggregate(5*timeutil.OneSecond, 10)
+	assert.True(t, agg.ResultSet().IsEmpty())
+
+	// invalid interval
+	agg = NewNonNegativeDerivativeAggregator(0, 0, 6)
+	agg.Aggregate(5*timeutil.OneSecond, 10)
+	agg.Aggregate(15*timeutil.OneSecond, 20)
+	assert.True(t, agg.ResultSet().IsEmpty())
+}
+
+func TestNonNegativeDerivativeAggregator_decreasing_series(t *testing.T) {
+	// decreasing gauge, nonnegative derivative clamps every negative derivative to 0,
+	// while increase treats each drop as a counter reset and counts the value as restarting from zero
+	derivative := NewNonNegativeDerivativeAggregator(0, 60*timeutil.OneSecond, 1)
+	increase := NewIncreaseAggregator(0, 60*timeutil.OneSecond, 1)
+	for idx, value := range []float64{50, 40, 30, 20} {
+		timestamp := int64(10+10*idx) * timeutil.OneSecond
+		derivative.Aggregate(timestamp, value)
+		increase.Aggregate(timestamp, value)
+	}
+	assert.Equal(t, 0.0, derivative.ResultSet().GetValue(0))
+	assert.True(t, increase.ResultSet().GetValue(0) > 0)
+}
@@ -0,0 +1,157 @@
+package aggregation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cespare/xxhash"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/hll"
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+// DistinctCountAggregator represents the approximate distinct count aggregator for each time slot,
+// which is backed by HyperLogLog sketch, the standard error of the estimate is 1.04/sqrt(2^precision),
+// e.g. default precision 14 => ≈0.81%.
+// The partial state is mergeable and serializable, so it can be aggregated across segments, shards and nodes.
+type DistinctCountAggregator interface {
+	// Aggregate adds the value into the sketch of time slot
+	Aggregate(slot int, value float64)
+	// Merge merges other aggregator's partial state into current aggregator
+	Merge(other DistinctCountAggregator) error
+	// ResultSet returns the estimated distinct count of each time slot
+	ResultSet() collections.FloatArray
+	// MarshalBinary marshals the partial state for cross-node aggregation
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary unmarshals the partial state, then merges it into current aggregator
+	UnmarshalBinary(data []byte) error
+	// Reset resets the aggregator for reusing
+	Reset()
+}
+
+// distinctCountAggregator implements DistinctCountAggregator interface
+type distinctCountAggregator struct {
+	precision uint8
+	capacity  int
+	sketches  map[int]*hll.HyperLogLog
+}
+
+// NewDistinctCountAggregator creates the distinct count aggregator with time slot capacity and sketch precision
+func NewDistinctCountAggregator(capacity int, precision uint8) (DistinctCountAggregator, error) {
+	if precision < hll.MinPrecision || precision > hll.MaxPrecision {
+		return nil, fmt.Errorf("distinct count precision must be in [%d,%d]", hll.MinPrecision, hll.MaxPrecision)
+	}
+	return &distinctCountAggregator{
+		precision: precision,
+		capacity:  capacity,
+		sketches:  make(map[int]*hll.HyperLogLog),
+	}, nil
+}
+
+// Aggregate adds the value into the sketch of time slot
+func (a *distinctCountAggregator) Aggregate(slot int, value float64) {
+	if slot < 0 || slot >= a.capacity {
+		return
+	}
+	sketch := a.getSketch(slot)
+	sketch.Add(xxhash.Sum64(float64Bytes(value)))
+}
+
+// Merge merges other aggregator's partial state into current aggregator
+func (a *distinctCountAggregator) Merge(other DistinctCountAggregator) error {
+	o, ok := other.(*distinctCountAggregator)
+	if !ok {
+		return fmt.Errorf("cannot merge distinct count aggregator with type: %T", other)
+	}
+	if a.precision != o.precision {
+		return fmt.Errorf("cannot merge distinct count aggregator with different precision: %d, %d",
+			a.precision, o.precision)
+	}
+	for slot, sketch := range o.sketches {
+		if slot < 0 || slot >= a.capacity {
+			continue
+		}
+		if err := a.getSketch(slot).Merge(sketch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResultSet returns the estimated distinct count of each time slot
+func (a *distinctCountAggregator) ResultSet() collections.FloatArray {
+	result := collections.NewFloatArray(a.capacity)
+	for slot, sketch := range a.sketches {
+		result.SetValue(slot, float64(sketch.Estimate()))
+	}
+	return result
+}
+
+// MarshalBinary marshals the partial state,
+// format: precision + sketch count + [slot + sketch length + sketch data]...
+func (a *distinctCountAggregator) MarshalBinary() ([]byte, error) {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(a.precision)
+	writer.PutUvarint32(uint32(len(a.sketches)))
+	for slot, sketch := range a.sketches {
+		data, _ := sketch.MarshalBinary()
+		writer.PutUvarint32(uint32(slot))
+		writer.PutUvarint32(uint32(len(data)))
+		writer.PutBytes(data)
+	}
+	return writer.Bytes()
+}
+
+// UnmarshalBinary unmarshals the partial state, then merges it into current aggregator
+func (a *distinctCountAggregator) UnmarshalBinary(data []byte) error {
+	reader := stream.NewReader(data)
+	precision := reader.ReadByte()
+	if precision != a.precision {
+		return fmt.Errorf("unmarshal distinct count aggregator with different precision: %d, %d",
+			a.precision, precision)
+	}
+	count := int(reader.ReadUvarint32())
+	for i := 0; i < count; i++ {
+		slot := int(reader.ReadUvarint32())
+		length := int(reader.ReadUvarint32())
+		sketchData := reader.ReadBytes(length)
+		if reader.Error() != nil {
+			return reader.Error()
+		}
+		sketch := &hll.HyperLogLog{}
+		if err := sketch.UnmarshalBinary(sketchData); err != nil {
+			return err
+		}
+		if slot < 0 || slot >= a.capacity {
+			continue
+		}
+		if err := a.getSketch(slot).Merge(sketch); err != nil {
+			return err
+		}
+	}
+	return reader.Error()
+}
+
+// Reset resets the aggregator for reusing
+func (a *distinctCountAggregator) Reset() {
+	a.sketches = make(map[int]*hll.HyperLogLog)
+}
+
+// getSketch returns the sketch of time slot, if not exist creates a new sketch
+func (a *distinctCountAggregator) getSketch(slot int) *hll.HyperLogLog {
+	sketch, ok := a.sketches[slot]
+	if !ok {
+		// precision is validated when creating aggregator
+		sketch, _ = hll.New(a.precision)
+		a.sketches[slot] = sketch
+	}
+	return sketch
+}
+
+// float64Bytes returns the bytes of float64 value for hashing
+func float64Bytes(value float64) []byte {
+	var buf [8]byte
+	stream.PutUint64(buf[:], 0, math.Float64bits(value))
+	return buf[:]
+}
@@ -0,0 +1,152 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/hll"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestNewDistinctCountAggregator(t *testing.T) {
+	agg, err := NewDistinctCountAggregator(10, hll.MaxPrecision+1)
+	assert.Error(t, err)
+	assert.Nil(t, agg)
+	agg, err = NewDistinctCountAggregator(10, hll.DefaultPrecision)
+	assert.NoError(t, err)
+	assert.NotNil(t, agg)
+	assert.True(t, agg.ResultSet().IsEmpty())
+}
+
+func TestDistinctCountAggregator_Aggregate(t *testing.T) {
+	agg, _ := NewDistinctCountAggregator(10, hll.DefaultPrecision)
+	// slot 0 => 50000 user ids, slot 5 => 100 user ids
+	for i := 0; i < 50000; i++ {
+		agg.Aggregate(0, float64(i))
+		agg.Aggregate(0, float64(i))
+	}
+	for i := 0; i < 100; i++ {
+		agg.Aggregate(5, float64(i))
+	}
+	// out of range
+	agg.Aggregate(-1, 1)
+	agg.Aggregate(10, 1)
+
+	rs := agg.ResultSet()
+	assert.Equal(t, 2, rs.Size())
+	assertDistinctCount(t, 50000, rs.GetValue(0))
+	assertDistinctCount(t, 100, rs.GetValue(5))
+
+	agg.Reset()
+	assert.True(t, agg.ResultSet().IsEmpty())
+}
+
+func TestDistinctCountAggregator_Merge(t *testing.T) {
+	agg1, _ := NewDistinctCountAggregator(10, hll.DefaultPrecision)
+	agg2, _ := NewDistinctCountAggregator(10, hll.DefaultPrecision)
+	// segment 1: [0,30000), segment 2: [20000,50000) => 50000
+	for i := 0; i < 30000; i++ {
+		agg1.Aggregate(1, float64(i))
+	}
+	for i := 20000; i < 50000; i++ {
+		agg2.Aggregate(1, float64(i))
+	}
+	agg2.Aggregate(3, 1)
+	assert.NoError(t, agg1.Merge(agg2))
+	rs := agg1.ResultSet()
+	assertDistinctCount(t, 50000, rs.GetValue(1))
+	assert.Equal(t, 1.0, rs.GetValue(3))
+
+	agg3, _ := NewDistinctCountAggregator(10, hll.MinPrecision)
+	assert.Error(t, agg1.Merge(agg3))
+	assert.Error(t, agg1.Merge(nil))
+}
+
+func TestDistinctCountAggregator_Marshal(t *testing.T) {
+	agg, _ := NewDistinctCountAggregator(10, hll.DefaultPrecision)
+	for i := 0; i < 1000; i++ {
+		agg.Aggregate(i%3, float64(i))
+	}
+	data, err := agg.MarshalBinary()
+	assert.NoError(t, err)
+
+	// cross-node aggregation
+	agg2, _ := NewDistinctCountAggregator(10, hll.DefaultPrecision)
+	for i := 1000; i < 2000; i++ {
+		agg2.Aggregate(0, float64(i))
+	}
+	assert.NoError(t, agg2.UnmarshalBinary(data))
+	rs := agg2.ResultSet()
+	assertDistinctCount(t, 1334, rs.GetValue(0))
+	assertDistinctCount(t, 333, rs.GetValue(1))
+	assertDistinctCount(t, 333, rs.GetValue(2))
+
+	// unmarshal failure
+	agg3, _ := NewDistinctCountAggregator(10, hll.MinPrecision)
+	assert.Error(t, agg3.UnmarshalBinary(data))
+	assert.Error(t, agg2.UnmarshalBinary(data[:10]))
+}
+
+func TestDistinctCount_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, err := sql.Parse("select distinct_count(userid) from events group by time(1m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	// each storage node aggregates the user ids of time slot into partial state
+	node1, _ := NewDistinctCountAggregator(10, hll.DefaultPrecision)
+	node2, _ := NewDistinctCountAggregator(10, hll.DefaultPrecision)
+	for i := 0; i < 3000; i++ {
+		node1.Aggregate(0, float64(i))
+	}
+	for i := 2000; i < 5000; i++ {
+		node2.Aggregate(0, float64(i))
+	}
+	for i := 0; i < 100; i++ {
+		node2.Aggregate(1, float64(i))
+	}
+	// broker merges the partial states of storage nodes
+	data, err := node2.MarshalBinary()
+	assert.NoError(t, err)
+	assert.NoError(t, node1.UnmarshalBinary(data))
+	state, err := node1.MarshalBinary()
+	assert.NoError(t, err)
+
+	timeSeries := series.NewMockIterator(ctrl)
+	timeSeries.EXPECT().FieldName().Return(field.Name("userid"))
+	timeSeries.EXPECT().FieldType().Return(field.SumField)
+	timeSeries.EXPECT().HasNext().Return(true)
+	timeSeries.EXPECT().Next().Return(familyTime, newStateFieldIterator(field.DistinctCount, node1.ResultSet(), state))
+	timeSeries.EXPECT().HasNext().Return(false)
+	groupedIt := series.NewMockGroupedIterator(ctrl)
+	gomock.InOrder(
+		groupedIt.EXPECT().HasNext().Return(true),
+		groupedIt.EXPECT().Next().Return(timeSeries),
+		groupedIt.EXPECT().HasNext().Return(false),
+	)
+	expression := NewExpression(timeutil.TimeRange{
+		Start: familyTime,
+		End:   familyTime + timeutil.OneHour,
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
+	expression.Eval(groupedIt)
+	values := expression.ResultSet()["distinct_count(userid)"]
+	assert.NotNil(t, values)
+	assert.Equal(t, 2, values.Size())
+	assertDistinctCount(t, 5000, values.GetValue(0))
+	assertDistinctCount(t, 100, values.GetValue(1))
+}
+
+// assertDistinctCount asserts the estimate within 3 standard errors
+func assertDistinctCount(t *testing.T, expect int, estimate float64) {
+	stdErr := 1.04 / math.Sqrt(float64(uint64(1)<<hll.DefaultPrecision))
+	delta := math.Abs(estimate-float64(expect)) / float64(expect)
+	assert.True(t, delta < 3*stdErr, "expect:%d, estimate:%f", expect, estimate)
+}
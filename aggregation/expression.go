@@ -31,21 +31,34 @@ type expression struct {
 	interval    int64
 	timeRange   timeutil.TimeRange
 	selectItems []stmt.Expr
+	nanPolicy   NaNPolicy // policy of handling NaN/Inf value computed by expression
+	// binary exprs(field op constant) evaluated by scalar transform iterator when preparing field store
+	scalarTransforms  map[*stmt.BinaryExpr]scalarTransform
+	transformedFields map[field.Name]scalarTransform
 
 	fieldStore map[field.Name]fields.Field
 	resultSet  map[string]collections.FloatArray
 }
 
-// NewExpression creates an expression
-func NewExpression(timeRange timeutil.TimeRange, interval int64, selectItems []stmt.Expr) Expression {
-	return &expression{
-		pointCount:  timeutil.CalPointCount(timeRange.Start, timeRange.End, interval) + 1,
-		interval:    interval,
-		timeRange:   timeRange,
-		selectItems: selectItems,
-		fieldStore:  make(map[field.Name]fields.Field),
-		resultSet:   make(map[string]collections.FloatArray),
+// NewExpression creates an expression, NaN/Inf value computed by expression is handled by nan policy
+func NewExpression(timeRange timeutil.TimeRange, interval int64, selectItems []stmt.Expr, nanPolicy NaNPolicy) Expression {
+	e := &expression{
+		pointCount:       timeutil.CalPointCount(timeRange.Start, timeRange.End, interval) + 1,
+		interval:         interval,
+		timeRange:        timeRange,
+		selectItems:      selectItems,
+		nanPolicy:        nanPolicy,
+		scalarTransforms: planScalarTransforms(selectItems),
+		fieldStore:       make(map[field.Name]fields.Field),
+		resultSet:        make(map[string]collections.FloatArray),
 	}
+	if len(e.scalarTransforms) > 0 {
+		e.transformedFields = make(map[field.Name]scalarTransform, len(e.scalarTransforms))
+		for _, transform := range e.scalarTransforms {
+			e.transformedFields[transform.fieldName] = transform
+		}
+	}
+	return e
 }
 
 // Eval evaluates the select item's expression
@@ -63,11 +76,12 @@ func (e *expression) Eval(timeSeries series.GroupedIterator) {
 	for _, selectItem := range e.selectItems {
 		values := e.eval(nil, selectItem)
 		if len(values) != 0 {
+			result := e.skipInvalidValues(values[0])
 			item, ok := selectItem.(*stmt.SelectItem)
 			if ok && len(item.Alias) > 0 {
-				e.resultSet[item.Alias] = values[0]
+				e.resultSet[item.Alias] = result
 			} else {
-				e.resultSet[item.Rewrite()] = values[0]
+				e.resultSet[item.Rewrite()] = result
 			}
 		}
 	}
@@ -89,7 +103,13 @@ func (e *expression) prepare(timeSeries series.GroupedIterator) {
 		fieldType := fieldSeries.FieldType()
 		f := fields.NewDynamicField(fieldType, e.timeRange.Start, e.interval, e.pointCount)
 		e.fieldStore[fieldName] = f
-		f.SetValue(fieldSeries)
+		// merges the data of adjacent segments into one continuous field iterator
+		it := NewSegmentMergedIterator(fieldSeries, e.interval)
+		if transform, ok := e.transformedFields[fieldName]; ok {
+			// field op constant, transforms the values of field when setting value
+			it = &scalarTransformSeries{Iterator: it, op: transform.op, scalar: transform.scalar}
+		}
+		f.SetValue(it)
 	}
 }
 
@@ -137,6 +157,13 @@ func (e *expression) funcCall(expr *stmt.CallExpr) []collections.FloatArray {
 		if len(paramValues) == 0 {
 			return nil
 		}
+		if _, ok := param.(*stmt.FieldExpr); !ok {
+			// aggregates over computed expression(e.g. sum(a/b)), the function consumes
+			// the values evaluated per slot, NaN/Inf(e.g. division by zero) is skipped based on nan policy
+			for idx := range paramValues {
+				paramValues[idx] = e.skipInvalidValues(paramValues[idx])
+			}
+		}
 		params = append(params, paramValues...)
 	}
 	result := function.FuncCall(expr.FuncType, params...)
@@ -148,6 +175,18 @@ func (e *expression) funcCall(expr *stmt.CallExpr) []collections.FloatArray {
 
 // binaryEval evaluates binary operator
 func (e *expression) binaryEval(expr *stmt.BinaryExpr) []collections.FloatArray {
+	if transform, ok := e.scalarTransforms[expr]; ok {
+		// the values of field are transformed when preparing
+		fieldValues, ok := e.fieldStore[transform.fieldName]
+		if !ok {
+			return nil
+		}
+		values := fieldValues.GetDefaultValues()
+		if len(values) != 1 {
+			return nil
+		}
+		return values
+	}
 	binaryOP := expr.Operator
 	if binaryOP == stmt.ADD || binaryOP == stmt.SUB || binaryOP == stmt.DIV || binaryOP == stmt.MUL {
 		left := e.eval(nil, expr.Left)
@@ -165,6 +204,36 @@ func (e *expression) binaryEval(expr *stmt.BinaryExpr) []collections.FloatArray
 	return nil
 }
 
+// skipInvalidValues returns the values without NaN/Inf which are computed by expression,
+// keeps them if nan policy is store.
+func (e *expression) skipInvalidValues(values collections.FloatArray) collections.FloatArray {
+	if values == nil || e.nanPolicy == StoreNaN || !hasInvalidValue(values) {
+		return values
+	}
+	// copy on write, because values maybe shared with field store
+	result := collections.NewFloatArray(values.Capacity())
+	it := values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		if !isInvalidValue(value) {
+			result.SetValue(idx, value)
+		}
+	}
+	result.SetSingle(values.IsSingle())
+	return result
+}
+
+// hasInvalidValue checks if values contain NaN/Inf
+func hasInvalidValue(values collections.FloatArray) bool {
+	it := values.Iterator()
+	for it.HasNext() {
+		if _, value := it.Next(); isInvalidValue(value) {
+			return true
+		}
+	}
+	return false
+}
+
 // Reset resets the expression context for reusing
 func (e *expression) Reset() {
 	for _, f := range e.fieldStore {
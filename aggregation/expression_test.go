@@ -1,12 +1,14 @@
 package aggregation
 
 import (
+	"math"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
@@ -31,7 +33,7 @@ func TestExpression_prepare(t *testing.T) {
 	expression := NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(sumSeries),
@@ -61,7 +63,7 @@ func TestExpression_prepare(t *testing.T) {
 	expression = NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	expression.Eval(nil)
 	resultSet = expression.ResultSet()
 	assert.Equal(t, 0, len(resultSet))
@@ -93,7 +95,7 @@ func TestExpression_prepare(t *testing.T) {
 	expression = NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	expression.Eval(timeSeries)
 	resultSet = expression.ResultSet()
 	assert.Equal(t, 0, len(resultSet))
@@ -113,7 +115,7 @@ func TestExpression_Paren(t *testing.T) {
 	expression := NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -145,7 +147,7 @@ func TestExpression_BinaryEval(t *testing.T) {
 	expression := NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -167,7 +169,7 @@ func TestExpression_BinaryEval(t *testing.T) {
 	expression = NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -189,7 +191,7 @@ func TestExpression_BinaryEval(t *testing.T) {
 	expression = NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -204,7 +206,7 @@ func TestExpression_BinaryEval(t *testing.T) {
 	expression = NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series2),
@@ -224,7 +226,7 @@ func TestExpression_BinaryEval(t *testing.T) {
 		Left:     &stmt.FieldExpr{Name: "f1"},
 		Operator: stmt.AND,
 		Right:    &stmt.FieldExpr{Name: "f2"},
-	}}})
+	}}}, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -249,7 +251,7 @@ func TestExpression_FuncCall_Sum(t *testing.T) {
 	expression := NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -268,7 +270,7 @@ func TestExpression_FuncCall_Sum(t *testing.T) {
 	expression = NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, query.SelectItems)
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -284,7 +286,7 @@ func TestExpression_FuncCall_Sum(t *testing.T) {
 		End:   now + timeutil.OneHour*2,
 	}, timeutil.OneMinute, []stmt.Expr{&stmt.SelectItem{Expr: &stmt.CallExpr{
 		FuncType: function.Sum,
-	}}})
+	}}}, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -301,7 +303,7 @@ func TestExpression_NotSupport_Expr(t *testing.T) {
 	expression := NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, []stmt.Expr{})
+	}, timeutil.OneMinute, []stmt.Expr{}, SkipNaN)
 	expression.Eval(nil)
 	resultSet := expression.ResultSet()
 	assert.Equal(t, 0, len(resultSet))
@@ -311,7 +313,7 @@ func TestExpression_NotSupport_Expr(t *testing.T) {
 	expression = NewExpression(timeutil.TimeRange{
 		Start: now,
 		End:   now + timeutil.OneHour*2,
-	}, timeutil.OneMinute, []stmt.Expr{&stmt.EqualsExpr{}})
+	}, timeutil.OneMinute, []stmt.Expr{&stmt.EqualsExpr{}}, SkipNaN)
 	gomock.InOrder(
 		timeSeries.EXPECT().HasNext().Return(true),
 		timeSeries.EXPECT().Next().Return(series1),
@@ -321,3 +323,87 @@ func TestExpression_NotSupport_Expr(t *testing.T) {
 	resultSet = expression.ResultSet()
 	assert.Equal(t, 0, len(resultSet))
 }
+
+func TestExpression_segment_rollover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// slot 60 of the first segment is the slot 0 of the next segment
+	fieldSeries := series.NewMockIterator(ctrl)
+	gomock.InOrder(
+		fieldSeries.EXPECT().FieldName().Return(field.Name("f")),
+		fieldSeries.EXPECT().FieldType().Return(field.SumField),
+		fieldSeries.EXPECT().HasNext().Return(true),
+		fieldSeries.EXPECT().Next().Return(familyTime, newSlotFieldIterator(field.Sum, []int{59, 60}, []float64{1, 2})),
+		fieldSeries.EXPECT().HasNext().Return(true),
+		fieldSeries.EXPECT().Next().Return(familyTime+timeutil.OneHour,
+			newSlotFieldIterator(field.Sum, []int{0, 1}, []float64{10, 11})),
+		fieldSeries.EXPECT().HasNext().Return(false),
+	)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(fieldSeries),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	q, _ := sql.Parse("select f from cpu")
+	expression := NewExpression(timeutil.TimeRange{
+		Start: familyTime,
+		End:   familyTime + timeutil.OneHour*2,
+	}, timeutil.OneMinute, q.(*stmt.Query).SelectItems, SkipNaN)
+	expression.Eval(timeSeries)
+	rs := expression.ResultSet()["f"]
+	// boundary slot is deduplicated by newest wins, no discontinuity
+	assert.Equal(t, 1.0, rs.GetValue(59))
+	assert.Equal(t, 10.0, rs.GetValue(60))
+	assert.Equal(t, 11.0, rs.GetValue(61))
+	assert.Equal(t, 3, rs.Size())
+}
+
+func TestExpression_FuncCall_BinaryExpr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// used: 20=>10, 50=>20; total: 20=>0, 50=>4
+	mockSeries := func(fieldName field.Name, v1, v2 float64) series.Iterator {
+		values := collections.NewFloatArray(31)
+		values.SetValue(0, v1)
+		values.SetValue(30, v2)
+		timeSeries := series.NewMockIterator(ctrl)
+		timeSeries.EXPECT().FieldType().Return(field.SumField)
+		timeSeries.EXPECT().FieldName().Return(fieldName)
+		timeSeries.EXPECT().HasNext().Return(true)
+		timeSeries.EXPECT().Next().Return(familyTime, NewFieldIterator(20, field.Sum, values))
+		timeSeries.EXPECT().HasNext().Return(false)
+		return timeSeries
+	}
+	q, _ := sql.Parse("select sum(used/total) from mem")
+	query := q.(*stmt.Query)
+	eval := func(nanPolicy NaNPolicy) collections.FloatArray {
+		timeSeries := series.NewMockGroupedIterator(ctrl)
+		gomock.InOrder(
+			timeSeries.EXPECT().HasNext().Return(true),
+			timeSeries.EXPECT().Next().Return(mockSeries("used", 10, 20)),
+			timeSeries.EXPECT().HasNext().Return(true),
+			timeSeries.EXPECT().Next().Return(mockSeries("total", 0, 4)),
+			timeSeries.EXPECT().HasNext().Return(false),
+		)
+		expression := NewExpression(timeutil.TimeRange{
+			Start: now,
+			End:   now + timeutil.OneHour*2,
+		}, timeutil.OneMinute, query.SelectItems, nanPolicy)
+		expression.Eval(timeSeries)
+		return expression.ResultSet()["sum(used/total)"]
+	}
+
+	// division by zero is skipped
+	value := eval(SkipNaN)
+	assert.Equal(t, 1, value.Size())
+	assert.False(t, value.HasValue(20-10))
+	assert.Equal(t, 5.0, value.GetValue(50-10))
+
+	value = eval(StoreNaN)
+	assert.Equal(t, 2, value.Size())
+	assert.True(t, math.IsNaN(value.GetValue(20-10)))
+	assert.Equal(t, 5.0, value.GetValue(50-10))
+}
@@ -0,0 +1,159 @@
+package aggregation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+// ExtremumTimeAggregator represents the aggregator which returns the timestamp at which the field reached
+// its max(max_time)/min(min_time) value for each time slot, used for finding when the peak occurred.
+// Ties pick the earliest timestamp, so the state can be merged across segments in any order.
+type ExtremumTimeAggregator interface {
+	// FuncType returns the aggregate function type(max_time/min_time)
+	FuncType() function.FuncType
+	// Aggregate aggregates the value with its timestamp into time slot
+	Aggregate(slot int, timestamp int64, value float64)
+	// Merge merges other aggregator's state(e.g. other segment) into current aggregator,
+	// keeps the timestamp of the overall extremum.
+	Merge(other ExtremumTimeAggregator) error
+	// ResultSet returns the timestamp of extremum for each time slot
+	ResultSet() collections.FloatArray
+	// MarshalBinary marshals the partial state for cross-node aggregation
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary unmarshals the partial state, then merges it into current aggregator
+	UnmarshalBinary(data []byte) error
+	// Reset resets the aggregator for reusing
+	Reset()
+}
+
+// extremumPoint represents the extremum value and the timestamp at which it occurred
+type extremumPoint struct {
+	present   bool
+	value     float64
+	timestamp int64
+}
+
+// extremumTimeAggregator implements ExtremumTimeAggregator interface,
+// better returns if the value is more extreme than current extremum.
+type extremumTimeAggregator struct {
+	funcType function.FuncType
+	better   func(value, extremum float64) bool
+	points   []extremumPoint
+}
+
+// NewMaxTimeAggregator creates the max_time aggregator with time slot capacity
+func NewMaxTimeAggregator(capacity int) ExtremumTimeAggregator {
+	return &extremumTimeAggregator{
+		funcType: function.MaxTime,
+		better:   func(value, extremum float64) bool { return value > extremum },
+		points:   make([]extremumPoint, capacity),
+	}
+}
+
+// NewMinTimeAggregator creates the min_time aggregator with time slot capacity
+func NewMinTimeAggregator(capacity int) ExtremumTimeAggregator {
+	return &extremumTimeAggregator{
+		funcType: function.MinTime,
+		better:   func(value, extremum float64) bool { return value < extremum },
+		points:   make([]extremumPoint, capacity),
+	}
+}
+
+// FuncType returns the aggregate function type
+func (a *extremumTimeAggregator) FuncType() function.FuncType {
+	return a.funcType
+}
+
+// Aggregate aggregates the value with its timestamp into time slot
+func (a *extremumTimeAggregator) Aggregate(slot int, timestamp int64, value float64) {
+	if slot < 0 || slot >= len(a.points) || math.IsNaN(value) {
+		return
+	}
+	point := &a.points[slot]
+	if !point.present ||
+		a.better(value, point.value) ||
+		(value == point.value && timestamp < point.timestamp) {
+		point.present = true
+		point.value = value
+		point.timestamp = timestamp
+	}
+}
+
+// Merge merges other aggregator's state into current aggregator
+func (a *extremumTimeAggregator) Merge(other ExtremumTimeAggregator) error {
+	o, ok := other.(*extremumTimeAggregator)
+	if !ok {
+		return fmt.Errorf("cannot merge extremum time aggregator with type: %T", other)
+	}
+	if a.funcType != o.funcType {
+		return fmt.Errorf("cannot merge %s aggregator with %s", a.funcType, o.funcType)
+	}
+	if len(a.points) != len(o.points) {
+		return fmt.Errorf("cannot merge aggregator with different capacity: %d, %d", len(a.points), len(o.points))
+	}
+	for slot, point := range o.points {
+		if point.present {
+			a.Aggregate(slot, point.timestamp, point.value)
+		}
+	}
+	return nil
+}
+
+// ResultSet returns the timestamp of extremum for each time slot
+func (a *extremumTimeAggregator) ResultSet() collections.FloatArray {
+	result := collections.NewFloatArray(len(a.points))
+	for slot, point := range a.points {
+		if point.present {
+			result.SetValue(slot, float64(point.timestamp))
+		}
+	}
+	return result
+}
+
+// MarshalBinary marshals the partial state,
+// format: point count + [slot + timestamp + value]...
+func (a *extremumTimeAggregator) MarshalBinary() ([]byte, error) {
+	count := 0
+	for _, point := range a.points {
+		if point.present {
+			count++
+		}
+	}
+	writer := stream.NewBufferWriter(nil)
+	writer.PutUvarint32(uint32(count))
+	for slot, point := range a.points {
+		if point.present {
+			writer.PutUvarint32(uint32(slot))
+			writer.PutVarint64(point.timestamp)
+			writer.PutUint64(math.Float64bits(point.value))
+		}
+	}
+	return writer.Bytes()
+}
+
+// UnmarshalBinary unmarshals the partial state, then merges it into current aggregator
+func (a *extremumTimeAggregator) UnmarshalBinary(data []byte) error {
+	reader := stream.NewReader(data)
+	count := int(reader.ReadUvarint32())
+	for i := 0; i < count; i++ {
+		slot := int(reader.ReadUvarint32())
+		timestamp := reader.ReadVarint64()
+		value := math.Float64frombits(reader.ReadUint64())
+		if reader.Error() != nil {
+			return reader.Error()
+		}
+		a.Aggregate(slot, timestamp, value)
+	}
+	return reader.Error()
+}
+
+// Reset resets the aggregator for reusing
+func (a *extremumTimeAggregator) Reset() {
+	for idx := range a.points {
+		a.points[idx] = extremumPoint{}
+	}
+}
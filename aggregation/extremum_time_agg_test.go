@@ -0,0 +1,88 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestExtremumTimeAggregator_peak(t *testing.T) {
+	// two segments(1 hour), one point per 10 minutes, peak at 10:20 of first segment,
+	// same peak value at 11:40 of second segment, which is later, so 10:20 is kept.
+	segment1, _ := timeutil.ParseTimestamp("20190410 10:00:00")
+	segment2, _ := timeutil.ParseTimestamp("20190410 11:00:00")
+	interval := 10 * timeutil.OneMinute
+	values1 := []float64{10, 50, 99, 20, 5, 30}
+	values2 := []float64{40, 1, 60, 70, 99, 8}
+
+	maxAgg1 := NewMaxTimeAggregator(1)
+	minAgg1 := NewMinTimeAggregator(1)
+	for idx, value := range values1 {
+		maxAgg1.Aggregate(0, segment1+int64(idx)*interval, value)
+		minAgg1.Aggregate(0, segment1+int64(idx)*interval, value)
+	}
+	maxAgg2 := NewMaxTimeAggregator(1)
+	minAgg2 := NewMinTimeAggregator(1)
+	for idx, value := range values2 {
+		maxAgg2.Aggregate(0, segment2+int64(idx)*interval, value)
+		minAgg2.Aggregate(0, segment2+int64(idx)*interval, value)
+	}
+	assert.Equal(t, function.MaxTime, maxAgg1.FuncType())
+	assert.Equal(t, function.MinTime, minAgg1.FuncType())
+
+	peak, _ := timeutil.ParseTimestamp("20190410 10:20:00")
+	bottom, _ := timeutil.ParseTimestamp("20190410 11:10:00")
+	// merge in both orders, ties pick the earliest
+	assert.NoError(t, maxAgg2.Merge(maxAgg1))
+	assert.Equal(t, float64(peak), maxAgg2.ResultSet().GetValue(0))
+	assert.NoError(t, maxAgg1.Merge(maxAgg2))
+	assert.Equal(t, float64(peak), maxAgg1.ResultSet().GetValue(0))
+	assert.NoError(t, minAgg1.Merge(minAgg2))
+	assert.Equal(t, float64(bottom), minAgg1.ResultSet().GetValue(0))
+
+	maxAgg1.Reset()
+	assert.True(t, maxAgg1.ResultSet().IsEmpty())
+}
+
+func TestExtremumTimeAggregator_Aggregate(t *testing.T) {
+	agg := NewMaxTimeAggregator(2)
+	agg.Aggregate(-1, 10, 1)
+	agg.Aggregate(2, 10, 1)
+	assert.True(t, agg.ResultSet().IsEmpty())
+	agg.Aggregate(1, 30, 5)
+	agg.Aggregate(1, 20, 5)
+	agg.Aggregate(1, 10, 4)
+	rs := agg.ResultSet()
+	assert.False(t, rs.HasValue(0))
+	assert.Equal(t, 20.0, rs.GetValue(1))
+}
+
+func TestExtremumTimeAggregator_Merge_fail(t *testing.T) {
+	agg := NewMaxTimeAggregator(2)
+	assert.Error(t, agg.Merge(nil))
+	assert.Error(t, agg.Merge(NewMinTimeAggregator(2)))
+	assert.Error(t, agg.Merge(NewMaxTimeAggregator(3)))
+}
+
+func TestExtremumTimeAggregator_MarshalBinary(t *testing.T) {
+	agg := NewMaxTimeAggregator(3)
+	agg.Aggregate(0, 20, 5)
+	agg.Aggregate(2, 30, 8)
+	data, err := agg.MarshalBinary()
+	assert.NoError(t, err)
+
+	other := NewMaxTimeAggregator(3)
+	other.Aggregate(0, 10, 5)
+	other.Aggregate(2, 40, 9)
+	assert.NoError(t, other.UnmarshalBinary(data))
+	rs := other.ResultSet()
+	// ties pick the earliest
+	assert.Equal(t, 10.0, rs.GetValue(0))
+	assert.False(t, rs.HasValue(1))
+	assert.Equal(t, 40.0, rs.GetValue(2))
+
+	assert.Error(t, other.UnmarshalBinary([]byte{1, 2}))
+}
@@ -1,8 +1,12 @@
 package aggregation
 
 import (
+	"fmt"
+
 	"github.com/lindb/lindb/aggregation/selector"
+	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
 )
 
 //go:generate mockgen -source=./field_agg.go -destination=./field_agg_mock.go -package=aggregation
@@ -11,6 +15,9 @@ import (
 type FieldAggregator interface {
 	// Aggregate aggregates the field series into current aggregator
 	Aggregate(it series.FieldIterator)
+	// Merge merges other field aggregator's partial state into current aggregator,
+	// returns error if other aggregator is different type or time range.
+	Merge(other FieldAggregator) error
 	// GetBlock returns series block for saving loaded data
 	GetBlock(idx int, fn newBlockFunc) (block series.Block, ok bool)
 	// ResultSet returns the result set of field aggregator
@@ -81,6 +88,11 @@ func (agg *downSamplingFieldAggregator) Aggregate(_ series.FieldIterator) {
 	// do nothing for down sampling
 }
 
+// Merge returns error, because down sampling aggregator only loads the data of storage into blocks
+func (agg *downSamplingFieldAggregator) Merge(_ FieldAggregator) error {
+	return fmt.Errorf("cannot merge down sampling field aggregator")
+}
+
 // GetBlock returns series block for saving loaded data
 func (agg *downSamplingFieldAggregator) GetBlock(idx int, fn newBlockFunc) (block series.Block, ok bool) {
 	if idx < 0 || idx >= agg.blockSize {
@@ -115,12 +127,73 @@ func (agg *downSamplingFieldAggregator) reset() {
 	}
 }
 
-// fieldAggregator implements field aggregator interface, aggregator field series based on aggregator spec
+// forEach iterates the data points loaded into blocks in time order
+func (agg *downSamplingFieldAggregator) forEach(fn func(timestamp int64, value float64)) {
+	for _, block := range agg.blocks {
+		if b, ok := block.(*downSamplingBlock); ok {
+			b.forEach(fn)
+		}
+	}
+}
+
+// downSamplingBlock implements series.Block, stores the data points of a family loaded from storage,
+// the time slot of data point is based on family start time by storage interval.
+type downSamplingBlock struct {
+	familyTime int64
+	interval   int64
+	start, end int
+	values     collections.FloatArray
+}
+
+// newDownSamplingBlock creates the block of family with start/end time slot
+func newDownSamplingBlock(familyTime, interval int64, start, end int) series.Block {
+	return &downSamplingBlock{
+		familyTime: familyTime,
+		interval:   interval,
+		start:      start,
+		end:        end,
+		values:     collections.NewFloatArray(end - start + 1),
+	}
+}
+
+// Append appends time slot and value into block, returns true if time slot is after end slot
+func (b *downSamplingBlock) Append(slot int, value float64) bool {
+	if slot > b.end {
+		return true
+	}
+	if slot < b.start {
+		return false
+	}
+	b.values.SetValue(slot-b.start, value)
+	return false
+}
+
+// Clear clears the values of block.
+func (b *downSamplingBlock) Clear() {
+	b.values.Reset()
+}
+
+// forEach iterates the data points of block with timestamp
+func (b *downSamplingBlock) forEach(fn func(timestamp int64, value float64)) {
+	if b.values.IsEmpty() {
+		return
+	}
+	it := b.values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		fn(b.familyTime+int64(b.start+idx)*b.interval, value)
+	}
+}
+
+// fieldAggregator implements field aggregator interface, aggregator field series based on aggregator spec,
+// folds the values of same time slot by the agg func of field series.
 type fieldAggregator struct {
 	segmentStartTime int64
 	start            int
 
 	selector selector.SlotSelector
+	aggType  field.AggType
+	values   collections.FloatArray
 }
 
 // NewFieldAggregator creates a field aggregator,
@@ -128,26 +201,31 @@ type fieldAggregator struct {
 // e.g. segment start time = 20190905 10:00:00, start = 10, end = 50, interval = 10 seconds,
 // real query time range {20190905 10:01:40 ~ 20190905 10:08:20}
 func NewFieldAggregator(segmentStartTime int64, selector selector.SlotSelector) FieldAggregator {
-	start, _ := selector.Range()
+	start, end := selector.Range()
 	agg := &fieldAggregator{
 		segmentStartTime: segmentStartTime,
 		start:            start,
 		selector:         selector,
+		values:           collections.NewFloatArray(end - start + 1),
 	}
 
 	return agg
 }
 
-// ResultSet returns the result set of field aggregator
+// ResultSet returns the result set of field aggregator, time slot = start slot + index of values
 func (a *fieldAggregator) ResultSet() (startTime int64, it series.FieldIterator) {
-	//its := make([]series.PrimitiveIterator, len(a.aggregateMap))
-	//idx := 0
-	//for _, agg := range a.aggregateMap {
-	//	its[idx] = agg.Iterator()
-	//	idx++
-	//}
-	//return a.segmentStartTime, newFieldIterator(a.start, its)
-	return
+	if a.values.IsEmpty() {
+		return a.segmentStartTime, nil
+	}
+	// copy data points, because aggregator can be reused after reset
+	snapshot := &SafeFieldIterator{aggType: a.aggType}
+	values := a.values.Iterator()
+	for values.HasNext() {
+		idx, value := values.Next()
+		snapshot.slots = append(snapshot.slots, a.start+idx)
+		snapshot.values = append(snapshot.values, value)
+	}
+	return a.segmentStartTime, snapshot.Iterator()
 }
 
 // GetBlock returns series block for saving loaded data
@@ -155,40 +233,72 @@ func (a *fieldAggregator) GetBlock(idx int, fn newBlockFunc) (series.Block, bool
 	return nil, false
 }
 
-// Aggregate aggregates the field series into current aggregator
+// Aggregate aggregates the data points in query time range of the field series into current aggregator
 func (a *fieldAggregator) Aggregate(it series.FieldIterator) {
-	//for it.HasNext() {
-	//	primitiveIt := it.Next()
-	//	if primitiveIt == nil {
-	//		continue
-	//	}
-	//	primitiveFieldID := primitiveIt.FieldID()
-	//	aggregator := a.getAggregator(primitiveFieldID, primitiveIt.AggType())
-	//	for primitiveIt.HasNext() {
-	//		timeSlot, value := primitiveIt.Next()
-	//		aggregator.Aggregate(timeSlot, value)
-	//	}
-	//}
+	a.aggType = it.AggType()
+	aggFunc := a.aggType.AggFunc()
+	if aggFunc == nil {
+		return
+	}
+	for it.HasNext() {
+		slot, value := it.Next()
+		if slot < 0 {
+			break
+		}
+		idx, completed := a.selector.IndexOf(slot)
+		if completed {
+			// time slots are in order, the remaining slots are out of query time range
+			break
+		}
+		if idx < 0 {
+			continue
+		}
+		a.aggregate(aggFunc, idx, value)
+	}
 }
 
-//// getAggregator returns the primitive aggregator by primitive id and function type
-//func (a *fieldAggregator) getAggregator(primitiveFieldID field.PrimitiveID, aggType field.AggType) PrimitiveAggregator {
-//	key := aggKey{
-//		primitiveID: primitiveFieldID,
-//		aggType:     aggType,
-//	}
-//	agg, ok := a.aggregateMap[key]
-//	if ok {
-//		return agg
-//	}
-//	agg = NewPrimitiveAggregator(primitiveFieldID, a.selector, aggType.AggFunc())
-//	a.aggregateMap[key] = agg
-//	return agg
-//}
+// Merge merges the values of other field aggregator, which has same segment start time and time range.
+func (a *fieldAggregator) Merge(other FieldAggregator) error {
+	o, ok := other.(*fieldAggregator)
+	if !ok {
+		return fmt.Errorf("cannot merge field aggregator with type: %T", other)
+	}
+	if a.segmentStartTime != o.segmentStartTime || a.start != o.start || a.values.Capacity() != o.values.Capacity() {
+		return fmt.Errorf("cannot merge field aggregator with different time range")
+	}
+	if o.values.IsEmpty() {
+		return nil
+	}
+	if a.values.IsEmpty() {
+		a.aggType = o.aggType
+	}
+	if a.aggType != o.aggType {
+		return fmt.Errorf("cannot merge field aggregator with different agg type: %d, %d", a.aggType, o.aggType)
+	}
+	aggFunc := a.aggType.AggFunc()
+	if aggFunc == nil {
+		return nil
+	}
+	it := o.values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		a.aggregate(aggFunc, idx, value)
+	}
+	return nil
+}
+
+// aggregate aggregates the value into the value of index
+func (a *fieldAggregator) aggregate(aggFunc field.AggFunc, idx int, value float64) {
+	if idx >= a.values.Capacity() {
+		return
+	}
+	if a.values.HasValue(idx) {
+		value = aggFunc.Aggregate(a.values.GetValue(idx), value)
+	}
+	a.values.SetValue(idx, value)
+}
 
 // reset resets the aggregate context for reusing
 func (a *fieldAggregator) reset() {
-	//for _, aggregator := range a.aggregateMap {
-	//	aggregator.reset()
-	//}
+	a.values.Reset()
 }
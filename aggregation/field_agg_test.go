@@ -7,82 +7,83 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/aggregation/selector"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 )
 
-//TODO need impl
-//func TestFieldAggregator_Aggregate(t *testing.T) {
-//	ctrl := gomock.NewController(t)
-//	defer ctrl.Finish()
-//	baseTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
-//
-//	aggSpec := NewDownSamplingSpec("f", field.SumField)
-//	aggSpec.AddFunctionType(function.Sum)
-//
-//	selector1 := selector.NewIndexSlotSelector(15, 55, 1)
-//	agg := NewFieldAggregator(baseTime, selector1)
-//	block, ok := agg.GetBlock(1, func() series.Block {
-//		return nil
-//	})
-//	assert.False(t, ok)
-//	assert.Nil(t, block)
-//	it := MockSumFieldIterator(ctrl, field.PrimitiveID(1), map[int]interface{}{
-//		5:  5.5,
-//		15: 5.6,
-//		17: 5.7,
-//		16: 5.8,
-//		56: 5.9,
-//	})
-//	agg.Aggregate(it)
-//
-//	expect := map[int]float64{
-//		15: 5.6,
-//		16: 5.8,
-//		17: 5.7,
-//	}
-//
-//	start, fieldIt := agg.ResultSet()
-//	assert.Equal(t, baseTime, start)
-//	assert.True(t, fieldIt.HasNext())
-//	AssertPrimitiveIt(t, fieldIt.Next(), expect)
-//	assert.False(t, fieldIt.HasNext())
-//
-//	it = MockSumFieldIterator(ctrl, field.PrimitiveID(1), map[int]interface{}{
-//		5:  5.5,
-//		15: 5.5,
-//		19: 5.5,
-//		16: 1.1,
-//		56: 5.5,
-//	})
-//	agg.Aggregate(it)
-//
-//	expect = map[int]float64{
-//		15: 11.1,
-//		16: 6.9,
-//		17: 5.7,
-//		19: 5.5,
-//	}
-//
-//	start, fieldIt = agg.ResultSet()
-//	assert.Equal(t, baseTime, start)
-//	assert.True(t, fieldIt.HasNext())
-//	AssertPrimitiveIt(t, fieldIt.Next(), expect)
-//	assert.False(t, fieldIt.HasNext())
-//
-//	// not match query time range case 1
-//	agg.reset()
-//	it = MockSumFieldIterator(ctrl, field.PrimitiveID(1), map[int]interface{}{
-//		4:  1.1,
-//		56: 5.5,
-//	})
-//	agg.Aggregate(it)
-//	start, fieldIt = agg.ResultSet()
-//	assert.Equal(t, baseTime, start)
-//	assert.True(t, fieldIt.HasNext())
-//	assert.False(t, fieldIt.Next().HasNext())
-//	assert.False(t, fieldIt.HasNext())
-//}
+func TestFieldAggregator_Aggregate(t *testing.T) {
+	baseTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	agg := NewFieldAggregator(baseTime, selector.NewIndexSlotSelector(15, 55, 1))
+	block, ok := agg.GetBlock(1, func() series.Block { return nil })
+	assert.False(t, ok)
+	assert.Nil(t, block)
+	// no data
+	startTime, it := agg.ResultSet()
+	assert.Equal(t, baseTime, startTime)
+	assert.Nil(t, it)
+
+	// slots out of query time range are dropped
+	agg.Aggregate(NewFieldIterator(14, field.Sum, generateFloatArray([]float64{1, 2, 3, 4})))
+	agg.Aggregate(NewFieldIterator(16, field.Sum, generateFloatArray([]float64{10})))
+	agg.Aggregate(NewFieldIterator(55, field.Sum, generateFloatArray([]float64{1, 2})))
+	startTime, it = agg.ResultSet()
+	assert.Equal(t, baseTime, startTime)
+	assert.Equal(t, field.Sum, it.AggType())
+	AssertFieldIt(t, it, map[int]float64{15: 2, 16: 13, 17: 4, 55: 1})
+
+	// folds by agg type of field series
+	agg.reset()
+	agg.Aggregate(NewFieldIterator(20, field.Max, generateFloatArray([]float64{1, 5})))
+	agg.Aggregate(NewFieldIterator(20, field.Max, generateFloatArray([]float64{3, 2})))
+	_, it = agg.ResultSet()
+	AssertFieldIt(t, it, map[int]float64{20: 3, 21: 5})
+	// unknown agg type
+	agg.reset()
+	agg.Aggregate(NewFieldIterator(20, field.AggType(100), generateFloatArray([]float64{1})))
+	_, it = agg.ResultSet()
+	assert.Nil(t, it)
+}
+
+func TestFieldAggregator_Merge(t *testing.T) {
+	newAgg := func(aggType field.AggType, values ...[]float64) FieldAggregator {
+		agg := NewFieldAggregator(10, selector.NewIndexSlotSelector(0, 10, 1))
+		for _, vs := range values {
+			agg.Aggregate(NewFieldIterator(0, aggType, generateFloatArray(vs)))
+		}
+		return agg
+	}
+	node1 := []float64{1, 5, 3}
+	node2 := []float64{4, 2}
+	for _, aggType := range []field.AggType{field.Sum, field.Count, field.Min, field.Max} {
+		// merging the partial aggregators of nodes is same as aggregating all data on single node
+		_, expect := newAgg(aggType, node1, node2).ResultSet()
+		agg := newAgg(aggType, node1)
+		assert.NoError(t, agg.Merge(newAgg(aggType, node2)))
+		_, it := agg.ResultSet()
+		assert.Equal(t, expect, it)
+		// merge is commutative
+		agg = newAgg(aggType, node2)
+		assert.NoError(t, agg.Merge(newAgg(aggType, node1)))
+		_, it = agg.ResultSet()
+		assert.Equal(t, expect, it)
+	}
+	// merge empty aggregator
+	agg := NewFieldAggregator(10, selector.NewIndexSlotSelector(0, 10, 1))
+	assert.NoError(t, agg.Merge(NewFieldAggregator(10, selector.NewIndexSlotSelector(0, 10, 1))))
+	assert.NoError(t, agg.Merge(newAgg(field.Sum, node1)))
+	_, it := agg.ResultSet()
+	AssertFieldIt(t, it, map[int]float64{0: 1, 1: 5, 2: 3})
+
+	// cannot merge
+	assert.Error(t, agg.Merge(newAgg(field.Max, node2)))
+	assert.Error(t, agg.Merge(NewFieldAggregator(20, selector.NewIndexSlotSelector(0, 10, 1))))
+	assert.Error(t, agg.Merge(NewFieldAggregator(10, selector.NewIndexSlotSelector(1, 10, 1))))
+	assert.Error(t, agg.Merge(NewFieldAggregator(10, selector.NewIndexSlotSelector(0, 20, 1))))
+	assert.Error(t, agg.Merge(NewIdentityFieldAggregator(10, selector.NewIndexSlotSelector(0, 10, 1))))
+	assert.Error(t, NewDownSamplingFieldAggregator(NewDownSamplingSpec("f", field.SumField), 2).Merge(agg))
+}
 
 func TestDownSamplingFieldAggregator(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -6,7 +6,7 @@ import (
 	"github.com/lindb/lindb/pkg/bit"
 	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/encoding"
-	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 )
@@ -16,10 +16,23 @@ type fieldIterator struct {
 	startSlot int
 	aggType   field.AggType
 	it        collections.FloatArrayIterator
+
+	// value codec when marshals field data
+	codec encoding.CodecID
+
+	// time range clipping, only emits the points whose timestamp in time range if clip is true
+	clip      bool
+	baseTime  int64
+	interval  int64
+	timeRange timeutil.TimeRange
+	// next point which is in time range, found by HasNext
+	hasPending   bool
+	pendingSlot  int
+	pendingValue float64
 }
 
-// newFieldIterator creates a field iterator
-func newFieldIterator(startSlot int, aggType field.AggType, values collections.FloatArray) series.FieldIterator {
+// NewFieldIterator creates a field iterator over the values, time slot = start slot + index of values
+func NewFieldIterator(startSlot int, aggType field.AggType, values collections.FloatArray) series.FieldIterator {
 	it := &fieldIterator{
 		startSlot: startSlot,
 		aggType:   aggType,
@@ -30,6 +43,21 @@ func newFieldIterator(startSlot int, aggType field.AggType, values collections.F
 	return it
 }
 
+// NewTypedFieldIterator creates a field iterator over the values of the field loaded from storage,
+// which only emits the points whose timestamp(base time + time slot * interval) falls inside the time range,
+// and encodes the values with the given codec when marshals field data.
+func NewTypedFieldIterator(startSlot int, aggType field.AggType, codec encoding.CodecID,
+	values collections.FloatArray, baseTime, interval int64, timeRange timeutil.TimeRange,
+) series.FieldIterator {
+	it := NewFieldIterator(startSlot, aggType, values).(*fieldIterator)
+	it.codec = codec
+	it.clip = true
+	it.baseTime = baseTime
+	it.interval = interval
+	it.timeRange = timeRange
+	return it
+}
+
 func (it *fieldIterator) AggType() field.AggType {
 	return it.aggType
 }
@@ -39,11 +67,43 @@ func (it *fieldIterator) HasNext() bool {
 	if it.it == nil {
 		return false
 	}
-	return it.it.HasNext()
+	if !it.clip {
+		return it.it.HasNext()
+	}
+	if it.hasPending {
+		return true
+	}
+	for it.it.HasNext() {
+		slot, value := it.it.Next()
+		if slot == -1 {
+			return false
+		}
+		slot += it.startSlot
+		timestamp := it.baseTime + int64(slot)*it.interval
+		if timestamp > it.timeRange.End {
+			// time slot is ordered, skip remaining points
+			it.it = nil
+			return false
+		}
+		if timestamp >= it.timeRange.Start {
+			it.hasPending = true
+			it.pendingSlot = slot
+			it.pendingValue = value
+			return true
+		}
+	}
+	return false
 }
 
 // Next returns the data point in the iteration
 func (it *fieldIterator) Next() (timeSlot int, value float64) {
+	if it.clip {
+		if !it.hasPending && !it.HasNext() {
+			return -1, 0
+		}
+		it.hasPending = false
+		return it.pendingSlot, it.pendingValue
+	}
 	if it.it == nil {
 		return -1, 0
 	}
@@ -57,15 +117,54 @@ func (it *fieldIterator) Next() (timeSlot int, value float64) {
 
 // MarshalBinary marshals the data
 func (it *fieldIterator) MarshalBinary() ([]byte, error) {
-	if it.it == nil {
+	return it.marshalWithOption(MarshalOption{})
+}
+
+// marshalWithOption marshals the data with the marshal option
+func (it *fieldIterator) marshalWithOption(option MarshalOption) ([]byte, error) {
+	if it.it == nil && !it.hasPending {
 		return nil, nil
 	}
+	return marshalFieldIterator(it.startSlot, it.codec, it, option)
+}
+
+// marshalFieldIterator marshals the remaining data of field iterator, start slot is the base slot of field data,
+// encodes the values with codec, handles NaN/Inf value based on the nan policy of marshal option.
+// the data maybe split into chained blocks by max slots limit, format see series.MarshalFieldBlocks.
+func marshalFieldIterator(startSlot int, codec encoding.CodecID, it series.FieldIterator,
+	option MarshalOption,
+) ([]byte, error) {
 	//FIXME reuse encoder???
-	encoder := encoding.TSDEncodeFunc(uint16(it.startSlot))
-	idx := it.startSlot
-	writer := stream.NewBufferWriter(nil)
+	encoder := encoding.NewTSDEncoderWithCodec(codec, uint16(startSlot))
+	blockStart := startSlot
+	idx := startSlot
+	var blocks [][]byte
+	policy := option.NaNPolicy
+	maxSlots := option.MaxSlotsPerBlock
 	for it.HasNext() {
 		slot, value := it.Next()
+		if isInvalidValue(value) {
+			switch policy {
+			case RejectNaN:
+				return nil, ErrInvalidValue
+			case SkipNaN:
+				// treat the time slot as absent
+				continue
+			}
+		}
+		if maxSlots > 0 && slot-blockStart >= maxSlots {
+			// current block is full, starts the next chained block from current slot
+			if idx > blockStart {
+				data, err := encoder.Bytes()
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, data)
+			}
+			encoder = encoding.NewTSDEncoderWithCodec(codec, uint16(slot))
+			blockStart = slot
+			idx = slot
+		}
 		for slot > idx {
 			encoder.AppendTime(bit.Zero)
 			idx++
@@ -74,16 +173,16 @@ func (it *fieldIterator) MarshalBinary() ([]byte, error) {
 		encoder.AppendValue(math.Float64bits(value))
 		idx++
 	}
-	data, err := encoder.Bytes()
-	if err != nil {
-		return nil, err
+	if idx > blockStart {
+		data, err := encoder.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, data)
 	}
-	if idx == it.startSlot {
+	if len(blocks) == 0 {
 		// maybe field data already read
 		return nil, nil
 	}
-	writer.PutByte(byte(it.AggType()))   // agg type
-	writer.PutVarint32(int32(len(data))) // length of field data
-	writer.PutBytes(data)                // field data
-	return writer.Bytes()
+	return series.MarshalFieldBlocks(it.AggType(), blocks)
 }
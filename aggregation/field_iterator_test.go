@@ -10,6 +10,7 @@ import (
 	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 )
@@ -17,7 +18,7 @@ import (
 var encodeFunc = encoding.NewTSDEncoder
 
 func TestFieldIterator(t *testing.T) {
-	it := newFieldIterator(20, field.Sum, generateFloatArray(nil))
+	it := NewFieldIterator(20, field.Sum, generateFloatArray(nil))
 	assert.False(t, it.HasNext())
 	slot, value := it.Next()
 	assert.Equal(t, -1, slot)
@@ -26,7 +27,7 @@ func TestFieldIterator(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, data)
 
-	it = newFieldIterator(20, field.Min, generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0}))
+	it = NewFieldIterator(20, field.Min, generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0}))
 
 	expect := map[int]float64{20: 0, 21: 10, 22: 10.0, 23: 100.4, 24: 50.0}
 	AssertFieldIt(t, it, expect)
@@ -41,24 +42,115 @@ func TestFieldIterator(t *testing.T) {
 	assert.Nil(t, data)
 }
 
+func TestTypedFieldIterator_TimeRange(t *testing.T) {
+	// block covers slot 10~19 => timestamp 1100~2000, query time range 1300~1600
+	values := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	timeRange := timeutil.TimeRange{Start: 1300, End: 1600}
+	it := NewTypedFieldIterator(10, field.Sum, encoding.XORCodec, generateFloatArray(values), 100, 100, timeRange)
+	assert.True(t, it.HasNext())
+	assert.True(t, it.HasNext())
+	expect := map[int]float64{12: 2, 13: 3, 14: 4, 15: 5}
+	AssertFieldIt(t, it, expect)
+	assert.False(t, it.HasNext())
+	slot, value := it.Next()
+	assert.Equal(t, -1, slot)
+	assert.Equal(t, 0.0, value)
+
+	// next without has next
+	it = NewTypedFieldIterator(10, field.Sum, encoding.XORCodec, generateFloatArray(values), 100, 100, timeRange)
+	slot, value = it.Next()
+	assert.Equal(t, 12, slot)
+	assert.Equal(t, 2.0, value)
+
+	// no point in time range
+	it = NewTypedFieldIterator(10, field.Sum, encoding.XORCodec, generateFloatArray(values), 100, 100,
+		timeutil.TimeRange{Start: 5000, End: 6000})
+	assert.False(t, it.HasNext())
+	data, err := it.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+	it = NewTypedFieldIterator(10, field.Sum, encoding.XORCodec, nil, 100, 100, timeRange)
+	assert.False(t, it.HasNext())
+
+	// marshal only in range points
+	it = NewTypedFieldIterator(10, field.Sum, encoding.XORCodec, generateFloatArray(values), 100, 100, timeRange)
+	data, err = it.MarshalBinary()
+	assert.NoError(t, err)
+	aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	fIt := series.NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
+	AssertFieldIt(t, fIt, expect)
+}
+
 func TestFieldIterator_MarshalBinary(t *testing.T) {
-	it := newFieldIterator(10, field.Sum, generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0}))
+	it := NewFieldIterator(10, field.Sum, generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0}))
 	data, err := it.MarshalBinary()
 	assert.NoError(t, err)
 	assert.True(t, len(data) > 0)
 
-	reader := stream.NewReader(data)
-	aggType := field.AggType(reader.ReadByte()) // read field agg type
+	aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
 	assert.Equal(t, field.Sum, aggType)
-	length := reader.ReadVarint32()
-	data1 := reader.ReadBytes(int(length))
 
-	fIt := series.NewFieldIterator(aggType, encoding.NewTSDDecoder(data1))
+	fIt := series.NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
 	expect := map[int]float64{10: 0, 11: 10, 12: 10.0, 13: 100.4, 14: 50.0}
 	AssertFieldIt(t, fIt, expect)
 	assert.False(t, fIt.HasNext())
 }
 
+func TestFieldIterator_MarshalBinary_chained_blocks(t *testing.T) {
+	values := collections.NewFloatArray(20)
+	expect := make(map[int]float64)
+	for i := 0; i < 20; i++ {
+		if i >= 5 && i < 13 {
+			// gap covers whole block
+			continue
+		}
+		values.SetValue(i, float64(i))
+		expect[10+i] = float64(i)
+	}
+	data, err := marshalFieldWithOption(NewFieldIterator(10, field.Sum, values), MarshalOption{MaxSlotsPerBlock: 4})
+	assert.NoError(t, err)
+
+	// slots: [10,13],[14],[23,26],[27,29]
+	aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Sum, aggType)
+	var startSlots []uint16
+	for _, block := range blocks {
+		startSlots = append(startSlots, encoding.NewTSDDecoder(block).StartTime())
+	}
+	assert.Equal(t, []uint16{10, 14, 23, 27}, startSlots)
+
+	// reads metadata of chained blocks without decoding
+	meta, err := series.ReadFieldBlockMeta(data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(10), meta.StartSlot)
+	assert.Equal(t, uint16(29), meta.EndSlot)
+	assert.Equal(t, 12, meta.SlotCount)
+	assert.Equal(t, 4, meta.Blocks)
+
+	// decodes chained blocks transparently
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(byte(field.SumField))
+	writer.PutVarint64(100)
+	writer.PutBytes(data)
+	seriesData, _ := writer.Bytes()
+	it := series.NewIterator("f", seriesData)
+	assert.True(t, it.HasNext())
+	startTime, fIt := it.Next()
+	assert.Equal(t, int64(100), startTime)
+	AssertFieldIt(t, fIt, expect)
+	assert.False(t, fIt.HasNext())
+	assert.False(t, it.HasNext())
+
+	// skips whole blocks before query range
+	it = series.NewIterator("f", seriesData)
+	_, fIt = it.Next()
+	fIt.(*series.BinaryFieldIterator).SkipBefore(24)
+	AssertFieldIt(t, fIt, map[int]float64{23: 13, 24: 14, 25: 15, 26: 16, 27: 17, 28: 18, 29: 19})
+}
+
 func TestFieldIterator_MarshalBinary_err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -74,8 +166,37 @@ func TestFieldIterator_MarshalBinary_err(t *testing.T) {
 	encoder.EXPECT().AppendTime(gomock.Any()).AnyTimes()
 	encoder.EXPECT().AppendValue(gomock.Any()).AnyTimes()
 	encoder.EXPECT().Bytes().Return(nil, fmt.Errorf("err"))
-	it := newFieldIterator(10, field.Sum, floatArray)
+	it := NewFieldIterator(10, field.Sum, floatArray)
 	data, err := it.MarshalBinary()
 	assert.Error(t, err)
 	assert.Nil(t, data)
 }
+
+func TestTypedFieldIterator_MarshalBinary(t *testing.T) {
+	floatArray := collections.NewFloatArray(100)
+	expect := make(map[int]float64)
+	for i := 0; i < 100; i++ {
+		if i == 3 {
+			continue
+		}
+		floatArray.SetValue(i, 100.0)
+		expect[10+i] = 100.0
+	}
+	for _, codec := range []encoding.CodecID{encoding.XORCodec, encoding.RLECodec, encoding.DeltaCodec} {
+		it := NewTypedFieldIterator(10, field.Sum, codec, floatArray, 0, 10, timeutil.TimeRange{Start: 0, End: 2000})
+		data, err := it.MarshalBinary()
+		assert.NoError(t, err)
+
+		aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+		assert.NoError(t, err)
+		assert.Equal(t, field.Sum, aggType)
+		decoder := encoding.NewTSDDecoder(blocks[0])
+		// encoder chooses the given codec or xor codec which is smaller
+		assert.Contains(t, []encoding.CodecID{encoding.XORCodec, codec}, decoder.Codec())
+		if codec == encoding.RLECodec {
+			// constant values
+			assert.Equal(t, encoding.RLECodec, decoder.Codec())
+		}
+		AssertFieldIt(t, series.NewFieldIterator(aggType, decoder), expect)
+	}
+}
@@ -0,0 +1,141 @@
+package aggregation
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// FillType represents the type of filling the step which has no data point
+type FillType uint8
+
+// Defines all types of filling the missing step
+const (
+	// FillNull fills NaN(null) value
+	FillNull FillType = iota
+	// FillPrevious fills the value of previous present step, NaN if no previous value
+	FillPrevious
+	// FillValue fills the constant value
+	FillValue
+)
+
+// FillPolicy represents the policy of filling the step which has no data point
+type FillPolicy struct {
+	Type  FillType
+	Value float64 // constant value for FillValue
+}
+
+// fixedStepIterator implements series.FieldIterator interface,
+// yields a value(or fill) for every step between start slot and end slot based on sparse field iterator.
+type fixedStepIterator struct {
+	src       series.FieldIterator
+	aggFunc   field.AggFunc
+	slot      int // slot of next step
+	endSlot   int
+	step      int
+	fill      FillPolicy
+	previous  float64
+	hasPeek   bool
+	peekSlot  int
+	peekValue float64
+}
+
+// NewFixedStepIterator creates the field iterator which yields a value for every step(start slot + n * step)
+// in [start slot, end slot], the data points in the same step are aggregated by the agg type of source iterator,
+// the step which has no data point is filled based on fill policy.
+func NewFixedStepIterator(src series.FieldIterator, startSlot, endSlot, step int, fill FillPolicy) series.FieldIterator {
+	if step <= 0 {
+		step = 1
+	}
+	return &fixedStepIterator{
+		src:      src,
+		aggFunc:  src.AggType().AggFunc(),
+		slot:     startSlot,
+		endSlot:  endSlot,
+		step:     step,
+		fill:     fill,
+		previous: math.NaN(),
+	}
+}
+
+// AggType returns the field's agg type for down sampling.
+func (it *fixedStepIterator) AggType() field.AggType {
+	return it.src.AggType()
+}
+
+// HasNext returns if the iteration has more steps
+func (it *fixedStepIterator) HasNext() bool {
+	return it.slot <= it.endSlot
+}
+
+// Next returns the value(or fill) of next step
+func (it *fixedStepIterator) Next() (timeSlot int, value float64) {
+	if !it.HasNext() {
+		return -1, 0
+	}
+	timeSlot = it.slot
+	it.slot += it.step
+	found := false
+	for it.peek() {
+		if it.peekSlot >= it.slot {
+			// data point belongs to next steps
+			break
+		}
+		it.hasPeek = false
+		if it.peekSlot < timeSlot {
+			// data point before start slot
+			continue
+		}
+		if !found || it.aggFunc == nil {
+			value = it.peekValue
+		} else {
+			value = it.aggFunc.Aggregate(value, it.peekValue)
+		}
+		found = true
+	}
+	if found {
+		it.previous = value
+		return timeSlot, value
+	}
+	switch it.fill.Type {
+	case FillPrevious:
+		return timeSlot, it.previous
+	case FillValue:
+		return timeSlot, it.fill.Value
+	default:
+		return timeSlot, math.NaN()
+	}
+}
+
+// MarshalBinary marshals the remaining steps
+func (it *fixedStepIterator) MarshalBinary() ([]byte, error) {
+	return it.marshalWithOption(MarshalOption{})
+}
+
+// marshalWithOption marshals the remaining steps by the marshal option
+func (it *fixedStepIterator) marshalWithOption(option MarshalOption) ([]byte, error) {
+	if !it.HasNext() {
+		return nil, nil
+	}
+	return marshalFieldIterator(it.slot, encoding.XORCodec, it, option)
+}
+
+// peek reads the next data point of source iterator if not peeked, returns false if no more data
+func (it *fixedStepIterator) peek() bool {
+	if it.hasPeek {
+		return true
+	}
+	if it.src == nil || !it.src.HasNext() {
+		return false
+	}
+	slot, value := it.src.Next()
+	if slot < 0 {
+		it.src = nil
+		return false
+	}
+	it.peekSlot, it.peekValue = slot, value
+	it.hasPeek = true
+	return true
+}
@@ -0,0 +1,103 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// newSparseFieldIterator creates the field iterator with data points 12:1, 13:2, 17:5
+func newSparseFieldIterator() series.FieldIterator {
+	floatArray := collections.NewFloatArray(10)
+	floatArray.SetValue(2, 1)
+	floatArray.SetValue(3, 2)
+	floatArray.SetValue(7, 5)
+	return NewFieldIterator(10, field.Sum, floatArray)
+}
+
+func assertFixedStepIt(t *testing.T, it series.FieldIterator, slots []int, values []float64) {
+	idx := 0
+	for it.HasNext() {
+		slot, value := it.Next()
+		assert.Equal(t, slots[idx], slot)
+		if math.IsNaN(values[idx]) {
+			assert.True(t, math.IsNaN(value), "slot: %d", slot)
+		} else {
+			assert.Equal(t, values[idx], value, "slot: %d", slot)
+		}
+		idx++
+	}
+	assert.Equal(t, len(slots), idx)
+	slot, value := it.Next()
+	assert.Equal(t, -1, slot)
+	assert.Equal(t, 0.0, value)
+}
+
+func TestFixedStepIterator_FillNull(t *testing.T) {
+	nan := math.NaN()
+	it := NewFixedStepIterator(newSparseFieldIterator(), 11, 18, 1, FillPolicy{Type: FillNull})
+	assert.Equal(t, field.Sum, it.AggType())
+	assertFixedStepIt(t, it,
+		[]int{11, 12, 13, 14, 15, 16, 17, 18},
+		[]float64{nan, 1, 2, nan, nan, nan, 5, nan})
+}
+
+func TestFixedStepIterator_FillPrevious(t *testing.T) {
+	nan := math.NaN()
+	it := NewFixedStepIterator(newSparseFieldIterator(), 11, 18, 1, FillPolicy{Type: FillPrevious})
+	assertFixedStepIt(t, it,
+		[]int{11, 12, 13, 14, 15, 16, 17, 18},
+		[]float64{nan, 1, 2, 2, 2, 2, 5, 5})
+}
+
+func TestFixedStepIterator_FillValue(t *testing.T) {
+	it := NewFixedStepIterator(newSparseFieldIterator(), 11, 18, 1, FillPolicy{Type: FillValue, Value: 0})
+	assertFixedStepIt(t, it,
+		[]int{11, 12, 13, 14, 15, 16, 17, 18},
+		[]float64{0, 1, 2, 0, 0, 0, 5, 0})
+}
+
+func TestFixedStepIterator_Step(t *testing.T) {
+	// step 2 aggregates the data points in same step: [12,13] => 1+2
+	it := NewFixedStepIterator(newSparseFieldIterator(), 10, 19, 2, FillPolicy{Type: FillValue, Value: -1})
+	assertFixedStepIt(t, it,
+		[]int{10, 12, 14, 16, 18},
+		[]float64{-1, 3, -1, 5, -1})
+	// data points before start slot/after end slot are ignored
+	it = NewFixedStepIterator(newSparseFieldIterator(), 13, 16, 3, FillPolicy{Type: FillPrevious})
+	assertFixedStepIt(t, it,
+		[]int{13, 16},
+		[]float64{2, 5})
+	// invalid step is regarded as 1
+	it = NewFixedStepIterator(newSparseFieldIterator(), 12, 13, 0, FillPolicy{})
+	assertFixedStepIt(t, it,
+		[]int{12, 13},
+		[]float64{1, 2})
+	// empty source
+	it = NewFixedStepIterator(NewFieldIterator(10, field.Sum, nil), 10, 11, 1, FillPolicy{Type: FillValue, Value: 1})
+	assertFixedStepIt(t, it,
+		[]int{10, 11},
+		[]float64{1, 1})
+}
+
+func TestFixedStepIterator_MarshalBinary(t *testing.T) {
+	it := NewFixedStepIterator(newSparseFieldIterator(), 11, 15, 1, FillPolicy{Type: FillPrevious})
+	data, err := it.MarshalBinary()
+	assert.NoError(t, err)
+	aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Sum, aggType)
+	fIt := series.NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
+	// NaN of slot 11 is skipped
+	AssertFieldIt(t, fIt, map[int]float64{12: 1, 13: 2, 14: 2, 15: 2})
+
+	data, err = it.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
@@ -5,7 +5,8 @@ import "github.com/lindb/lindb/pkg/collections"
 // FuncCall calls the function calc by function type and params
 func FuncCall(funcType FuncType, params ...collections.FloatArray) collections.FloatArray {
 	switch funcType {
-	case Sum, Min, Max, Count:
+	case Sum, Min, Max, Count, CountAll, Identity, Sample, MaxTime, MinTime, Increase, NonNegativeDerivative,
+		DistinctCount:
 		if len(params) == 0 {
 			return nil
 		}
@@ -23,6 +23,18 @@ func TestFuncCall_Sum(t *testing.T) {
 	array2 := collections.NewFloatArray(20)
 	result = FuncCall(Sum, array1, array2)
 	assert.Equal(t, array1, result)
+	result = FuncCall(Identity, array1)
+	assert.Equal(t, array1, result)
+	result = FuncCall(Sample, array1)
+	assert.Equal(t, array1, result)
+	result = FuncCall(MaxTime, array1)
+	assert.Equal(t, array1, result)
+	result = FuncCall(Increase, array1)
+	assert.Equal(t, array1, result)
+	result = FuncCall(NonNegativeDerivative, array1)
+	assert.Equal(t, array1, result)
+	result = FuncCall(DistinctCount, array1)
+	assert.Equal(t, array1, result)
 }
 
 func TestFuncCall_Avg(t *testing.T) {
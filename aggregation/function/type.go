@@ -14,6 +14,25 @@ const (
 	Stddev
 
 	Unknown
+
+	// new function types are appended after Unknown, keeps the values of existing types unchanged
+
+	// DistinctCount returns the approximate distinct count of values, which is estimated by HyperLogLog sketch
+	DistinctCount
+	// Identity passes through the raw data points without folding, used for querying raw points
+	Identity
+	// Sample passes through every Nth present data point, used for reducing points(like sparklines)
+	Sample
+	// MaxTime returns the timestamp at which the field reached its max value(argmax)
+	MaxTime
+	// MinTime returns the timestamp at which the field reached its min value(argmin)
+	MinTime
+	// Increase returns the total counter increase over a window, accounting for counter resets
+	Increase
+	// NonNegativeDerivative returns the derivative of field between consecutive data points, negatives are clamped to 0
+	NonNegativeDerivative
+	// CountAll counts all data points includes the filled nulls, the time slot without data point is counted as 0
+	CountAll
 )
 
 // String return the function's name
@@ -35,6 +54,22 @@ func (t FuncType) String() string {
 		return "histogram"
 	case Stddev:
 		return "stddev"
+	case DistinctCount:
+		return "distinct_count"
+	case Identity:
+		return "identity"
+	case Sample:
+		return "sample"
+	case MaxTime:
+		return "max_time"
+	case MinTime:
+		return "min_time"
+	case Increase:
+		return "increase"
+	case NonNegativeDerivative:
+		return "nonnegative_derivative"
+	case CountAll:
+		return "count_all"
 	default:
 		return "unknown"
 	}
@@ -15,5 +15,13 @@ func TestFuncTypeString(t *testing.T) {
 	assert.Equal(t, "replace", Replace.String())
 	assert.Equal(t, "histogram", Histogram.String())
 	assert.Equal(t, "stddev", Stddev.String())
+	assert.Equal(t, "distinct_count", DistinctCount.String())
+	assert.Equal(t, "identity", Identity.String())
+	assert.Equal(t, "sample", Sample.String())
+	assert.Equal(t, "max_time", MaxTime.String())
+	assert.Equal(t, "min_time", MinTime.String())
+	assert.Equal(t, "increase", Increase.String())
+	assert.Equal(t, "nonnegative_derivative", NonNegativeDerivative.String())
+	assert.Equal(t, "count_all", CountAll.String())
 	assert.Equal(t, "unknown", Unknown.String())
 }
@@ -1,6 +1,8 @@
 package aggregation
 
 import (
+	"fmt"
+
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 )
@@ -11,8 +13,46 @@ import (
 type GroupingAggregator interface {
 	// Aggregate aggregates the time series data
 	Aggregate(it series.GroupedIterator)
-	// ResultSet returns the result set of aggregator
-	ResultSet() []series.GroupedIterator
+	// PartialResultSet aggregates the time series of a partial result(e.g. task response of one node)
+	// into a new grouped result set, which can be merged by Merge.
+	PartialResultSet(seriesList []series.GroupedIterator) GroupedResultSet
+	// Merge merges the partial grouped result set into the groups of aggregator, returns error if merging failure.
+	Merge(rs GroupedResultSet) error
+	// ResultSet returns the result set of aggregator, merges the spilled groups if need,
+	// returns error if spilling or merging the spilled groups failure.
+	ResultSet() ([]series.GroupedIterator, error)
+}
+
+// SpillOption represents the option for spilling groups to disk, which bounds the memory of grouping aggregator
+type SpillOption struct {
+	// MaxGroups is the max num. of groups in memory, spills all in-memory groups if exceeds, 0 means never spilling
+	MaxGroups int
+	// Dir is the directory of the temporary spilled files
+	Dir string
+}
+
+// GroupedResultSet represents the partial aggregated result of groups, tag values => field aggregates
+type GroupedResultSet map[string]FieldAggregates
+
+// MergeResultSets merges the partial aggregated result sets(e.g. result sets of shards) into one result set,
+// the field aggregates of same group are merged by FieldAggregates.Merge, the group which only exists
+// in one result set passes through unchanged.
+// NOTICE: the field aggregates of input result sets are reused(merged in place), so don't use the input after merging.
+func MergeResultSets(resultSets ...GroupedResultSet) (GroupedResultSet, error) {
+	merged := make(GroupedResultSet)
+	for _, rs := range resultSets {
+		for tags, aggregates := range rs {
+			target, ok := merged[tags]
+			if !ok {
+				merged[tags] = aggregates
+				continue
+			}
+			if err := target.Merge(aggregates); err != nil {
+				return nil, fmt.Errorf("merge result set of group: %s failure: %w", tags, err)
+			}
+		}
+	}
+	return merged, nil
 }
 
 type groupingAggregator struct {
@@ -20,64 +60,106 @@ type groupingAggregator struct {
 	interval   timeutil.Interval
 	timeRange  timeutil.TimeRange
 	aggregates map[string]FieldAggregates // tag values => field aggregates
+
+	spillOption SpillOption
+	files       []string // spilled files
+	err         error    // spilling failure
 }
 
-// NewGroupingAggregator creates a grouping aggregator
+// NewGroupingAggregator creates a grouping aggregator which holds all groups in memory
 func NewGroupingAggregator(
 	interval timeutil.Interval,
 	timeRange timeutil.TimeRange,
 	aggSpecs AggregatorSpecs,
+) GroupingAggregator {
+	return NewSpillableGroupingAggregator(interval, timeRange, aggSpecs, SpillOption{})
+}
+
+// NewSpillableGroupingAggregator creates a grouping aggregator which spills the groups to disk
+// if the num. of in-memory groups exceeds the max groups of spill option.
+func NewSpillableGroupingAggregator(
+	interval timeutil.Interval,
+	timeRange timeutil.TimeRange,
+	aggSpecs AggregatorSpecs,
+	spillOption SpillOption,
 ) GroupingAggregator {
 	return &groupingAggregator{
-		aggSpecs:   aggSpecs,
-		interval:   interval,
-		timeRange:  timeRange,
-		aggregates: make(map[string]FieldAggregates),
+		aggSpecs:    aggSpecs,
+		interval:    interval,
+		timeRange:   timeRange,
+		aggregates:  make(map[string]FieldAggregates),
+		spillOption: spillOption,
 	}
 }
 
 // Aggregate aggregates the time series data
 func (ga *groupingAggregator) Aggregate(it series.GroupedIterator) {
+	if ga.err != nil {
+		// spilling failure, the result set is incomplete
+		return
+	}
 	tags := it.Tags()
+	if _, ok := ga.aggregates[tags]; !ok && ga.spillOption.MaxGroups > 0 && len(ga.aggregates) >= ga.spillOption.MaxGroups {
+		if ga.err = ga.spill(); ga.err != nil {
+			return
+		}
+	}
 	seriesAgg := ga.getAggregator(tags)
-	var sAgg SeriesAggregator
 	for it.HasNext() {
-		seriesIt := it.Next()
-		fieldName := seriesIt.FieldName()
-		fieldType := seriesIt.FieldType()
-		// 1. find field aggregator
-		sAgg = nil
-		for _, aggregator := range seriesAgg {
-			if aggregator.FieldName() == fieldName {
-				sAgg = aggregator
-				break
-			}
-		}
-		if sAgg == nil {
-			continue
+		aggregateSeries(seriesAgg, it.Next())
+	}
+}
+
+// PartialResultSet aggregates the time series of a partial result into a new grouped result set
+func (ga *groupingAggregator) PartialResultSet(seriesList []series.GroupedIterator) GroupedResultSet {
+	rs := make(GroupedResultSet)
+	for _, it := range seriesList {
+		tags := it.Tags()
+		aggregates, ok := rs[tags]
+		if !ok {
+			aggregates = ga.newAggregator()
+			rs[tags] = aggregates
 		}
-		// set field type for aggregate
-		sAgg.SetFieldType(fieldType)
-		// 2. merge the field series data
-		for seriesIt.HasNext() {
-			startTime, fieldIt := seriesIt.Next()
-			if fieldIt == nil {
-				continue
-			}
-			_, _ = sAgg.GetAggregateBlock(startTime)
-			//TODO impl
-			//if ok {
-			//fAgg.Aggregate(fieldIt)
-			//}
+		for it.HasNext() {
+			aggregateSeries(aggregates, it.Next())
 		}
 	}
+	return rs
 }
 
-// ResultSet returns the result set of aggregator
-func (ga *groupingAggregator) ResultSet() []series.GroupedIterator {
+// Merge merges the partial grouped result set into the groups of aggregator,
+// spills the groups if the num. of in-memory groups exceeds the max groups after merging.
+func (ga *groupingAggregator) Merge(rs GroupedResultSet) error {
+	if ga.err != nil {
+		return ga.err
+	}
+	merged, err := MergeResultSets(ga.aggregates, rs)
+	if err != nil {
+		ga.err = err
+		return err
+	}
+	ga.aggregates = merged
+	if ga.spillOption.MaxGroups > 0 && len(ga.aggregates) > ga.spillOption.MaxGroups {
+		ga.err = ga.spill()
+	}
+	return ga.err
+}
+
+// ResultSet returns the result set of aggregator, the spilled files are removed after merging
+func (ga *groupingAggregator) ResultSet() ([]series.GroupedIterator, error) {
+	defer ga.removeSpilledFiles()
+
+	if ga.err != nil {
+		return nil, ga.err
+	}
+	if len(ga.files) > 0 {
+		if err := ga.mergeSpilled(); err != nil {
+			return nil, err
+		}
+	}
 	length := len(ga.aggregates)
 	if length == 0 {
-		return nil
+		return nil, nil
 	}
 	seriesList := make([]series.GroupedIterator, length)
 	idx := 0
@@ -85,7 +167,7 @@ func (ga *groupingAggregator) ResultSet() []series.GroupedIterator {
 		seriesList[idx] = aggregator.ResultSet(tags)
 		idx++
 	}
-	return seriesList
+	return seriesList, nil
 }
 
 // getAggregator returns the time series aggregator by time series's tags
@@ -93,8 +175,43 @@ func (ga *groupingAggregator) getAggregator(tags string) (agg FieldAggregates) {
 	// 2. get series aggregator
 	agg, ok := ga.aggregates[tags]
 	if !ok {
-		agg = NewFieldAggregates(ga.interval, 1, ga.timeRange, false, ga.aggSpecs)
+		agg = ga.newAggregator()
 		ga.aggregates[tags] = agg
 	}
 	return
 }
+
+// newAggregator creates the field aggregates of a group
+func (ga *groupingAggregator) newAggregator() FieldAggregates {
+	return NewFieldAggregates(ga.interval, 1, ga.timeRange, false, ga.aggSpecs)
+}
+
+// aggregateSeries aggregates the field series data into the series aggregator of same field
+func aggregateSeries(agg FieldAggregates, seriesIt series.Iterator) {
+	fieldName := seriesIt.FieldName()
+	// 1. find field aggregator
+	var sAgg SeriesAggregator
+	for _, aggregator := range agg {
+		if aggregator.FieldName() == fieldName {
+			sAgg = aggregator
+			break
+		}
+	}
+	if sAgg == nil {
+		return
+	}
+	// set field type for aggregate
+	sAgg.SetFieldType(seriesIt.FieldType())
+	seriesAgg, ok := sAgg.(*seriesAggregator)
+	if !ok {
+		return
+	}
+	// 2. merge the field series data
+	for seriesIt.HasNext() {
+		startTime, fieldIt := seriesIt.Next()
+		if fieldIt == nil {
+			continue
+		}
+		seriesAgg.aggregate(startTime, fieldIt)
+	}
+}
@@ -1,86 +1,175 @@
 package aggregation
 
-//TODO need impl
-//func TestGroupByAggregator_Aggregate(t *testing.T) {
-//	ctrl := gomock.NewController(t)
-//	defer ctrl.Finish()
-//	gIt := series.NewMockGroupedIterator(ctrl)
-//	sIt := series.NewMockIterator(ctrl)
-//	fIt := series.NewMockFieldIterator(ctrl)
-//
-//	now, _ := timeutil.ParseTimestamp("20190702 19:10:00", "20060102 15:04:05")
-//	familyTime, _ := timeutil.ParseTimestamp("20190702 19:00:00", "20060102 15:04:05")
-//	agg := NewGroupingAggregator(
-//		timeutil.Interval(timeutil.OneSecond),
-//		timeutil.TimeRange{
-//			Start: now,
-//			End:   now + 3*timeutil.OneHour,
-//		},
-//		AggregatorSpecs{
-//			NewDownSamplingSpec("b", field.SumField),
-//			NewDownSamplingSpec("a", field.SumField),
-//		})
-//
-//	gomock.InOrder(
-//		gIt.EXPECT().Tags().Return("1.1.1.1"),
-//		gIt.EXPECT().HasNext().Return(true),
-//		gIt.EXPECT().Next().Return(sIt),
-//		// series it
-//		sIt.EXPECT().FieldName().Return("a"),
-//		sIt.EXPECT().FieldType().Return(field.SumField),
-//		sIt.EXPECT().HasNext().Return(true),
-//		sIt.EXPECT().Next().Return(familyTime, fIt),
-//		fIt.EXPECT().HasNext().Return(false),
-//		// series it
-//		sIt.EXPECT().HasNext().Return(true),
-//		sIt.EXPECT().Next().Return(familyTime, nil),
-//		sIt.EXPECT().HasNext().Return(false),
-//		// series it
-//		gIt.EXPECT().HasNext().Return(true),
-//		gIt.EXPECT().Next().Return(sIt),
-//		sIt.EXPECT().FieldName().Return("c"),
-//		sIt.EXPECT().FieldType().Return(field.SumField),
-//
-//		gIt.EXPECT().HasNext().Return(false),
-//	)
-//	agg.Aggregate(gIt)
-//	rs := agg.ResultSet()
-//	assert.Equal(t, 1, len(rs))
-//
-//	gomock.InOrder(
-//		gIt.EXPECT().Tags().Return("1.1.1.2"),
-//		gIt.EXPECT().HasNext().Return(true),
-//		gIt.EXPECT().Next().Return(sIt),
-//		// series it
-//		sIt.EXPECT().FieldName().Return("a"),
-//		sIt.EXPECT().FieldType().Return(field.SumField),
-//		sIt.EXPECT().HasNext().Return(true),
-//		sIt.EXPECT().Next().Return(familyTime, fIt),
-//		fIt.EXPECT().HasNext().Return(false),
-//		// series it
-//		sIt.EXPECT().HasNext().Return(true),
-//		sIt.EXPECT().Next().Return(familyTime, nil),
-//		sIt.EXPECT().HasNext().Return(false),
-//		// series it
-//		gIt.EXPECT().HasNext().Return(true),
-//		gIt.EXPECT().Next().Return(sIt),
-//		sIt.EXPECT().FieldName().Return("c"),
-//		sIt.EXPECT().FieldType().Return(field.SumField),
-//
-//		gIt.EXPECT().HasNext().Return(false),
-//	)
-//	agg.Aggregate(gIt)
-//
-//	rs = agg.ResultSet()
-//	assert.Equal(t, 2, len(rs))
-//
-//	agg = NewGroupingAggregator(
-//		timeutil.Interval(timeutil.OneSecond),
-//		timeutil.TimeRange{
-//			Start: now,
-//			End:   now + 3*timeutil.OneHour,
-//		},
-//		AggregatorSpecs{})
-//	rs = agg.ResultSet()
-//	assert.Nil(t, rs)
-//}
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+var (
+	groupTestTime, _   = timeutil.ParseTimestamp("20190702 19:00:00", "20060102 15:04:05")
+	groupTestInterval  = timeutil.Interval(10 * timeutil.OneSecond)
+	groupTestTimeRange = timeutil.TimeRange{Start: groupTestTime, End: groupTestTime + 3*timeutil.OneMinute}
+)
+
+func TestGroupingAggregator_Aggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg := NewGroupingAggregator(groupTestInterval, groupTestTimeRange, newGroupTestSpecs())
+	rs, err := agg.ResultSet()
+	assert.NoError(t, err)
+	assert.Nil(t, rs)
+
+	agg.Aggregate(newGroupTestSeries("1.1.1.1", 0, []float64{1, 2}, []float64{10}))
+	agg.Aggregate(newGroupTestSeries("1.1.1.1", 1, []float64{1, 2}, nil))
+	agg.Aggregate(newGroupTestSeries("1.1.1.2", 0, []float64{5}, []float64{20}))
+	// unknown field and nil field iterator are ignored
+	gIt := series.NewMockGroupedIterator(ctrl)
+	sIt := series.NewMockIterator(ctrl)
+	gomock.InOrder(
+		gIt.EXPECT().Tags().Return("1.1.1.2"),
+		gIt.EXPECT().HasNext().Return(true),
+		gIt.EXPECT().Next().Return(sIt),
+		sIt.EXPECT().FieldName().Return(field.Name("c")),
+		gIt.EXPECT().HasNext().Return(true),
+		gIt.EXPECT().Next().Return(sIt),
+		sIt.EXPECT().FieldName().Return(field.Name("a")),
+		sIt.EXPECT().FieldType().Return(field.SumField),
+		sIt.EXPECT().HasNext().Return(true),
+		sIt.EXPECT().Next().Return(groupTestTime, nil),
+		sIt.EXPECT().HasNext().Return(false),
+		gIt.EXPECT().HasNext().Return(false),
+	)
+	agg.Aggregate(gIt)
+
+	rs, err = agg.ResultSet()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[field.Name]map[int]float64{
+		"1.1.1.1": {"a": {0: 1, 1: 3, 2: 2}, "b": {0: 10}},
+		"1.1.1.2": {"a": {0: 5}, "b": {0: 20}},
+	}, collectGroupTestResult(t, rs))
+}
+
+// newGroupTestSpecs returns the aggregator specs of sum field a and b
+func newGroupTestSpecs() AggregatorSpecs {
+	var aggSpecs AggregatorSpecs
+	for _, fieldName := range []field.Name{"a", "b"} {
+		aggSpec := NewDownSamplingSpec(fieldName, field.SumField)
+		aggSpec.AddFunctionType(function.Sum)
+		aggSpecs = append(aggSpecs, aggSpec)
+	}
+	return aggSpecs
+}
+
+// newGroupTestSeries returns the grouped series of field a and b, the values start at the start slot
+func newGroupTestSeries(tags string, startSlot int, a, b []float64) series.GroupedIterator {
+	agg := NewFieldAggregates(groupTestInterval, 1, groupTestTimeRange, false, newGroupTestSpecs())
+	for idx, values := range [][]float64{a, b} {
+		if values != nil {
+			agg[idx].(*seriesAggregator).aggregate(groupTestTime,
+				NewFieldIterator(startSlot, field.Sum, generateFloatArray(values)))
+		}
+	}
+	return agg.ResultSet(tags)
+}
+
+// collectGroupTestResult returns the data points of each group/field in result set
+func collectGroupTestResult(t *testing.T, rs []series.GroupedIterator) map[string]map[field.Name]map[int]float64 {
+	result := make(map[string]map[field.Name]map[int]float64)
+	for _, gIt := range rs {
+		fields := make(map[field.Name]map[int]float64)
+		for gIt.HasNext() {
+			sIt := gIt.Next()
+			for sIt.HasNext() {
+				startTime, fIt := sIt.Next()
+				assert.Equal(t, groupTestTime, startTime)
+				if fIt == nil {
+					continue
+				}
+				points := make(map[int]float64)
+				for fIt.HasNext() {
+					slot, value := fIt.Next()
+					points[slot] = value
+				}
+				fields[sIt.FieldName()] = points
+			}
+		}
+		result[gIt.Tags()] = fields
+	}
+	return result
+}
+
+func TestMergeResultSets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg := NewGroupingAggregator(groupTestInterval, groupTestTimeRange, newGroupTestSpecs())
+	shard1 := agg.PartialResultSet([]series.GroupedIterator{
+		newGroupTestSeries("host1", 0, []float64{1, 2}, nil),
+		newGroupTestSeries("host2", 0, []float64{3}, nil),
+	})
+	shard2 := agg.PartialResultSet([]series.GroupedIterator{
+		newGroupTestSeries("host2", 0, []float64{4, 0, 5}, []float64{0, 6}),
+		newGroupTestSeries("host3", 3, []float64{7}, nil),
+	})
+	rs, err := MergeResultSets(shard1, shard2)
+	assert.NoError(t, err)
+	seriesList := make([]series.GroupedIterator, 0, len(rs))
+	for tags, aggregates := range rs {
+		seriesList = append(seriesList, aggregates.ResultSet(tags))
+	}
+	// partial sum of overlapping group is merged, disjoint groups pass through
+	assert.Equal(t, map[string]map[field.Name]map[int]float64{
+		"host1": {"a": {0: 1, 1: 2}},
+		"host2": {"a": {0: 7, 1: 0, 2: 5}, "b": {0: 0, 1: 6}},
+		"host3": {"a": {3: 7}},
+	}, collectGroupTestResult(t, seriesList))
+
+	rs, err = MergeResultSets()
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+
+	// merge failure
+	mockAgg := NewMockSeriesAggregator(ctrl)
+	mockAgg.EXPECT().FieldName().Return(field.Name("a")).AnyTimes()
+	rs, err = MergeResultSets(
+		agg.PartialResultSet([]series.GroupedIterator{newGroupTestSeries("host1", 0, []float64{1}, nil)}),
+		GroupedResultSet{"host1": FieldAggregates{mockAgg}},
+	)
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
+
+func TestGroupingAggregator_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg := NewGroupingAggregator(groupTestInterval, groupTestTimeRange, newGroupTestSpecs())
+	agg.Aggregate(newGroupTestSeries("host1", 0, []float64{1}, nil))
+	assert.NoError(t, agg.Merge(agg.PartialResultSet([]series.GroupedIterator{
+		newGroupTestSeries("host1", 0, []float64{2}, []float64{3}),
+		newGroupTestSeries("host2", 1, []float64{4}, nil),
+	})))
+	rs, err := agg.ResultSet()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[field.Name]map[int]float64{
+		"host1": {"a": {0: 3}, "b": {0: 3}},
+		"host2": {"a": {1: 4}},
+	}, collectGroupTestResult(t, rs))
+
+	// merge failure, the result set is failure
+	mockAgg := NewMockSeriesAggregator(ctrl)
+	mockAgg.EXPECT().FieldName().Return(field.Name("a")).AnyTimes()
+	assert.Error(t, agg.Merge(GroupedResultSet{"host1": FieldAggregates{mockAgg}}))
+	assert.Error(t, agg.Merge(nil))
+	rs, err = agg.ResultSet()
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+}
@@ -0,0 +1,147 @@
+package aggregation
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+var groupSpillLogger = logger.GetLogger("aggregation", "GroupSpill")
+
+// spill writes the result set of all in-memory groups into a temporary file, then resets the in-memory groups,
+// format: [vint(tags length) + tags + vint(field count) + [field name + vint(data length) + data]...]...,
+// data is the marshaled result set of series aggregator, which can be restored and merged by series aggregator.
+func (ga *groupingAggregator) spill() (err error) {
+	f, err := ioutil.TempFile(ga.spillOption.Dir, "group-spill-")
+	if err != nil {
+		return err
+	}
+	ga.files = append(ga.files, f.Name())
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	bufWriter := bufio.NewWriter(f)
+	writer := stream.NewBufferWriter(nil)
+	for tags, aggregates := range ga.aggregates {
+		writer.Reset()
+		putString(writer, tags)
+		writer.PutUvarint32(uint32(len(aggregates)))
+		for _, aggregator := range aggregates {
+			data, err := aggregator.ResultSet().MarshalBinary()
+			if err != nil {
+				return err
+			}
+			putString(writer, string(aggregator.FieldName()))
+			writer.PutUvarint32(uint32(len(data)))
+			writer.PutBytes(data)
+		}
+		data, err := writer.Bytes()
+		if err != nil {
+			return err
+		}
+		if _, err := bufWriter.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := bufWriter.Flush(); err != nil {
+		return err
+	}
+	ga.aggregates = make(map[string]FieldAggregates)
+	return nil
+}
+
+// mergeSpilled restores the groups of spilled files, then merges them into the in-memory groups
+func (ga *groupingAggregator) mergeSpilled() error {
+	for _, file := range ga.files {
+		if err := ga.mergeSpilledFile(file); err != nil {
+			return fmt.Errorf("merge spilled groups of file: %s failure: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// mergeSpilledFile reads the spilled groups one by one, restores and merges each group into the in-memory group
+func (ga *groupingAggregator) mergeSpilledFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	reader := bufio.NewReader(f)
+	for {
+		tags, err := readBytes(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		count, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return err
+		}
+		restored := ga.newAggregator()
+		for i := uint64(0); i < count; i++ {
+			fieldName, err := readBytes(reader)
+			if err != nil {
+				return err
+			}
+			data, err := readBytes(reader)
+			if err != nil {
+				return err
+			}
+			if len(data) == 0 {
+				continue
+			}
+			seriesIt := series.NewIterator(field.Name(fieldName), data)
+			aggregateSeries(restored, seriesIt)
+			if err := seriesIt.Error(); err != nil {
+				return err
+			}
+		}
+		if err := ga.getAggregator(string(tags)).Merge(restored); err != nil {
+			return err
+		}
+	}
+}
+
+// removeSpilledFiles removes the spilled files
+func (ga *groupingAggregator) removeSpilledFiles() {
+	for _, file := range ga.files {
+		if err := os.Remove(file); err != nil {
+			groupSpillLogger.Warn("remove spilled file failure", logger.String("file", file), logger.Error(err))
+		}
+	}
+	ga.files = nil
+}
+
+// putString writes the length and bytes of string
+func putString(writer *stream.BufferWriter, value string) {
+	writer.PutUvarint32(uint32(len(value)))
+	writer.PutBytes([]byte(value))
+}
+
+// readBytes reads the length prefixed bytes
+func readBytes(reader *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
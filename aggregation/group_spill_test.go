@@ -0,0 +1,113 @@
+package aggregation
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestGroupingAggregator_spill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill")
+	assert.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	// aggregates 100 groups, each group is written 3 times interleaved, so the group is
+	// spread across several spilled files and in-memory groups
+	aggregate := func(agg GroupingAggregator) {
+		for round := 0; round < 3; round++ {
+			for i := 0; i < 100; i++ {
+				agg.Aggregate(newGroupTestSeries(fmt.Sprintf("host-%03d", i), round%2,
+					[]float64{float64(i), float64(round)}, []float64{float64(i + round)}))
+			}
+		}
+	}
+
+	inMemory := NewGroupingAggregator(groupTestInterval, groupTestTimeRange, newGroupTestSpecs())
+	aggregate(inMemory)
+	rs, err := inMemory.ResultSet()
+	assert.NoError(t, err)
+	expect := collectGroupTestResult(t, rs)
+	assert.Len(t, expect, 100)
+	assert.Equal(t, map[int]float64{0: 10, 1: 7, 2: 1}, expect["host-005"]["a"])
+
+	spilled := NewSpillableGroupingAggregator(groupTestInterval, groupTestTimeRange, newGroupTestSpecs(),
+		SpillOption{MaxGroups: 7, Dir: dir})
+	aggregate(spilled)
+	assert.NotEmpty(t, spilled.(*groupingAggregator).files)
+	assert.LessOrEqual(t, len(spilled.(*groupingAggregator).aggregates), 7)
+	rs, err = spilled.ResultSet()
+	assert.NoError(t, err)
+	assert.Equal(t, expect, collectGroupTestResult(t, rs))
+	// spilled files are removed after merging
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestGroupingAggregator_Merge_spill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill")
+	assert.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	agg := NewSpillableGroupingAggregator(groupTestInterval, groupTestTimeRange, newGroupTestSpecs(),
+		SpillOption{MaxGroups: 1, Dir: dir})
+	assert.NoError(t, agg.Merge(agg.PartialResultSet([]series.GroupedIterator{
+		newGroupTestSeries("host-1", 0, []float64{1}, nil),
+		newGroupTestSeries("host-2", 0, []float64{2}, nil),
+	})))
+	// groups exceed the max groups after merging are spilled
+	assert.Len(t, agg.(*groupingAggregator).files, 1)
+	assert.Empty(t, agg.(*groupingAggregator).aggregates)
+	assert.NoError(t, agg.Merge(agg.PartialResultSet([]series.GroupedIterator{
+		newGroupTestSeries("host-1", 0, []float64{3}, nil),
+	})))
+	rs, err := agg.ResultSet()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[field.Name]map[int]float64{
+		"host-1": {"a": {0: 4}},
+		"host-2": {"a": {0: 2}},
+	}, collectGroupTestResult(t, rs))
+}
+
+func TestGroupingAggregator_spill_fail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill")
+	assert.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	// spill dir not exist
+	agg := NewSpillableGroupingAggregator(groupTestInterval, groupTestTimeRange, newGroupTestSpecs(),
+		SpillOption{MaxGroups: 1, Dir: filepath.Join(dir, "not_exist")})
+	agg.Aggregate(newGroupTestSeries("host-1", 0, []float64{1}, nil))
+	agg.Aggregate(newGroupTestSeries("host-2", 0, []float64{1}, nil))
+	agg.Aggregate(newGroupTestSeries("host-3", 0, []float64{1}, nil))
+	rs, err := agg.ResultSet()
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+
+	// spilled file is corrupted
+	agg = NewSpillableGroupingAggregator(groupTestInterval, groupTestTimeRange, newGroupTestSpecs(),
+		SpillOption{MaxGroups: 1, Dir: dir})
+	agg.Aggregate(newGroupTestSeries("host-1", 0, []float64{1}, nil))
+	agg.Aggregate(newGroupTestSeries("host-2", 0, []float64{1}, nil))
+	files := agg.(*groupingAggregator).files
+	assert.Len(t, files, 1)
+	assert.NoError(t, ioutil.WriteFile(files[0], []byte{10, 1, 2}, 0644))
+	rs, err = agg.ResultSet()
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+	_, err = os.Stat(files[0])
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,118 @@
+package aggregation
+
+import (
+	"fmt"
+
+	"github.com/lindb/lindb/aggregation/selector"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// identityFieldAggregator implements field aggregator interface for querying raw data points,
+// passes through every (slot, value) of field series without folding, only clips the slots out of query time range.
+type identityFieldAggregator struct {
+	segmentStartTime int64
+	selector         selector.SlotSelector
+
+	aggType field.AggType
+	slots   []int
+	values  []float64
+}
+
+// NewIdentityFieldAggregator creates a identity field aggregator,
+// selector's time range is index based on segment start time and storage interval.
+func NewIdentityFieldAggregator(segmentStartTime int64, selector selector.SlotSelector) FieldAggregator {
+	return &identityFieldAggregator{
+		segmentStartTime: segmentStartTime,
+		selector:         selector,
+	}
+}
+
+// Aggregate collects the data points in query time range of the field series
+func (a *identityFieldAggregator) Aggregate(it series.FieldIterator) {
+	a.aggType = it.AggType()
+	for it.HasNext() {
+		slot, value := it.Next()
+		if slot < 0 {
+			break
+		}
+		idx, completed := a.selector.IndexOf(slot)
+		if completed {
+			// time slots are in order, the remaining slots are out of query time range
+			break
+		}
+		if idx < 0 {
+			continue
+		}
+		a.slots = append(a.slots, slot)
+		a.values = append(a.values, value)
+	}
+}
+
+// Merge merges the raw data points of other identity aggregator which has same segment start time,
+// data points are ordered by time slot, the data point of other aggregator is used for identical time slot.
+func (a *identityFieldAggregator) Merge(other FieldAggregator) error {
+	o, ok := other.(*identityFieldAggregator)
+	if !ok {
+		return fmt.Errorf("cannot merge identity field aggregator with type: %T", other)
+	}
+	if a.segmentStartTime != o.segmentStartTime {
+		return fmt.Errorf("cannot merge identity field aggregator with different segment start time")
+	}
+	if len(o.slots) == 0 {
+		return nil
+	}
+	a.aggType = o.aggType
+	a.slots, a.values = mergePoints(a.slots, a.values, o.slots, o.values)
+	return nil
+}
+
+// GetBlock returns nil, because identity aggregator doesn't load data into block
+func (a *identityFieldAggregator) GetBlock(idx int, fn newBlockFunc) (series.Block, bool) {
+	return nil, false
+}
+
+// ResultSet returns the raw data points of field aggregator
+func (a *identityFieldAggregator) ResultSet() (startTime int64, it series.FieldIterator) {
+	if len(a.slots) == 0 {
+		return a.segmentStartTime, nil
+	}
+	// copy data points, because aggregator can be reused after reset
+	snapshot := &SafeFieldIterator{
+		aggType: a.aggType,
+		slots:   make([]int, len(a.slots)),
+		values:  make([]float64, len(a.values)),
+	}
+	copy(snapshot.slots, a.slots)
+	copy(snapshot.values, a.values)
+	return a.segmentStartTime, snapshot.Iterator()
+}
+
+// reset resets the aggregate context for reusing
+func (a *identityFieldAggregator) reset() {
+	a.slots = a.slots[:0]
+	a.values = a.values[:0]
+}
+
+// mergePoints merges two slot-sorted data points into one, the value of other is used for identical time slot
+func mergePoints(slots []int, values []float64, otherSlots []int, otherValues []float64) ([]int, []float64) {
+	mergedSlots := make([]int, 0, len(slots)+len(otherSlots))
+	mergedValues := make([]float64, 0, len(values)+len(otherValues))
+	i, j := 0, 0
+	for i < len(slots) || j < len(otherSlots) {
+		switch {
+		case j == len(otherSlots) || (i < len(slots) && slots[i] < otherSlots[j]):
+			mergedSlots = append(mergedSlots, slots[i])
+			mergedValues = append(mergedValues, values[i])
+			i++
+		default:
+			if i < len(slots) && slots[i] == otherSlots[j] {
+				i++
+			}
+			mergedSlots = append(mergedSlots, otherSlots[j])
+			mergedValues = append(mergedValues, otherValues[j])
+			j++
+		}
+	}
+	return mergedSlots, mergedValues
+}
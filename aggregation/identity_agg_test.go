@@ -0,0 +1,138 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/aggregation/selector"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestIdentityFieldAggregator_Aggregate(t *testing.T) {
+	baseTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	values := []float64{1.1, 2.2, 3.3, 4.4, 5.5, 6.6, 7.7, 8.8}
+	// encode raw data points, slots: 10~17
+	data, err := NewFieldIterator(10, field.Sum, generateFloatArray(values)).MarshalBinary()
+	assert.NoError(t, err)
+	encodedSlots, encodedValues := decodeFieldData(t, data)
+
+	// query time range covers all slots, raw output is same as encoded input
+	agg := NewIdentityFieldAggregator(baseTime, selector.NewIndexSlotSelector(0, 100, 1))
+	block, ok := agg.GetBlock(1, func() series.Block { return nil })
+	assert.False(t, ok)
+	assert.Nil(t, block)
+	agg.Aggregate(newEncodedFieldIterator(data))
+	startTime, it := agg.ResultSet()
+	assert.Equal(t, baseTime, startTime)
+	assert.Equal(t, field.Sum, it.AggType())
+	result, err := it.MarshalBinary()
+	assert.NoError(t, err)
+	slots, rawValues := decodeFieldData(t, result)
+	assert.Equal(t, encodedSlots, slots)
+	assert.Equal(t, encodedValues, rawValues)
+
+	// clips the slots out of query time range
+	agg.reset()
+	agg = NewIdentityFieldAggregator(baseTime, selector.NewIndexSlotSelector(12, 15, 1))
+	agg.Aggregate(newEncodedFieldIterator(data))
+	_, it = agg.ResultSet()
+	AssertFieldIt(t, it, map[int]float64{12: 3.3, 13: 4.4, 14: 5.5, 15: 6.6})
+
+	// reset for reusing
+	agg.reset()
+	startTime, it = agg.ResultSet()
+	assert.Equal(t, baseTime, startTime)
+	assert.Nil(t, it)
+	// no data in query time range
+	agg = NewIdentityFieldAggregator(baseTime, selector.NewIndexSlotSelector(50, 60, 1))
+	agg.Aggregate(newEncodedFieldIterator(data))
+	_, it = agg.ResultSet()
+	assert.Nil(t, it)
+}
+
+func TestIdentityFieldAggregator_ResultSet_snapshot(t *testing.T) {
+	agg := NewIdentityFieldAggregator(0, selector.NewIndexSlotSelector(0, 100, 1))
+	agg.Aggregate(NewFieldIterator(10, field.Max, generateFloatArray([]float64{1, 2})))
+	_, it := agg.ResultSet()
+	// reused aggregator doesn't change the returned result set
+	agg.reset()
+	agg.Aggregate(NewFieldIterator(10, field.Max, generateFloatArray([]float64{3, 4})))
+	AssertFieldIt(t, it, map[int]float64{10: 1, 11: 2})
+}
+
+func TestIdentityFieldAggregator_Merge(t *testing.T) {
+	agg := NewIdentityFieldAggregator(10, selector.NewIndexSlotSelector(0, 100, 1))
+	// merge empty aggregator
+	assert.NoError(t, agg.Merge(NewIdentityFieldAggregator(10, selector.NewIndexSlotSelector(0, 100, 1))))
+	_, it := agg.ResultSet()
+	assert.Nil(t, it)
+
+	agg.Aggregate(NewFieldIterator(10, field.Sum, generateFloatArray([]float64{1, 2, 3})))
+	other := NewIdentityFieldAggregator(10, selector.NewIndexSlotSelector(0, 100, 1))
+	other.Aggregate(NewFieldIterator(5, field.Sum, generateFloatArray([]float64{4, 5})))
+	other.Aggregate(NewFieldIterator(11, field.Sum, generateFloatArray([]float64{6})))
+	other.Aggregate(NewFieldIterator(20, field.Sum, generateFloatArray([]float64{7})))
+	assert.NoError(t, agg.Merge(other))
+	_, it = agg.ResultSet()
+	// data points are ordered by slot, the data point of other is used for identical slot
+	var slots []int
+	var values []float64
+	for it.HasNext() {
+		slot, value := it.Next()
+		slots = append(slots, slot)
+		values = append(values, value)
+	}
+	assert.Equal(t, []int{5, 6, 10, 11, 12, 20}, slots)
+	assert.Equal(t, []float64{4, 5, 1, 6, 3, 7}, values)
+
+	// cannot merge
+	assert.Error(t, agg.Merge(NewIdentityFieldAggregator(20, selector.NewIndexSlotSelector(0, 100, 1))))
+	assert.Error(t, agg.Merge(NewFieldAggregator(10, selector.NewIndexSlotSelector(0, 100, 1))))
+}
+
+func TestSeriesAggregator_identity(t *testing.T) {
+	aggSpec := NewDownSamplingSpec("f", field.SumField)
+	aggSpec.AddFunctionType(function.Identity)
+	assert.True(t, isIdentitySpec(aggSpec))
+
+	now, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	agg := NewSeriesAggregator(
+		timeutil.Interval(timeutil.OneSecond*10),
+		1,
+		timeutil.TimeRange{
+			Start: now + 30*timeutil.OneSecond,
+			End:   now + 3*timeutil.OneMinute,
+		},
+		false,
+		aggSpec)
+	seriesAgg := agg.(*seriesAggregator)
+	// slots before 10:00:30 and after 10:03:00 are clipped
+	seriesAgg.aggregate(now, NewFieldIterator(0, field.Sum, generateFloatArray([]float64{1, 2, 3, 4, 5})))
+	seriesAgg.aggregate(now, NewFieldIterator(17, field.Sum, generateFloatArray([]float64{6, 7, 8, 9})))
+	rs := agg.ResultSet()
+	assert.True(t, rs.HasNext())
+	startTime, it := rs.Next()
+	assert.Equal(t, now, startTime)
+	AssertFieldIt(t, it, map[int]float64{3: 4, 4: 5, 17: 6, 18: 7})
+	assert.False(t, rs.HasNext())
+
+	agg.Reset()
+	rs = agg.ResultSet()
+	assert.True(t, rs.HasNext())
+	_, it = rs.Next()
+	assert.Nil(t, it)
+
+	aggSpec.AddFunctionType(function.Sum)
+	assert.False(t, isIdentitySpec(aggSpec))
+}
+
+// newEncodedFieldIterator creates the field iterator over encoded field data
+func newEncodedFieldIterator(data []byte) series.FieldIterator {
+	aggType, blocks, _ := series.UnmarshalFieldBlocks(data)
+	return series.NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
+}
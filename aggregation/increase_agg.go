@@ -0,0 +1,126 @@
+package aggregation
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+// IncreaseAggregator represents the aggregator which calculates the total increase of a counter
+// for each window(like prometheus increase(f[5m])), counter resets are accounted for.
+type IncreaseAggregator interface {
+	// Aggregate aggregates the counter sample into the window which the timestamp belongs to,
+	// samples must be aggregated in time order, out of order samples are ignored.
+	Aggregate(timestamp int64, value float64)
+	// ResultSet returns the extrapolated increase of each window
+	ResultSet() collections.FloatArray
+	// Reset resets the aggregator for reusing
+	Reset()
+}
+
+// increaseWindow represents the counter samples state of a window
+type increaseWindow struct {
+	count          int
+	firstTimestamp int64
+	lastTimestamp  int64
+	firstValue     float64
+	lastValue      float64
+	increase       float64
+}
+
+// increaseAggregator implements IncreaseAggregator interface,
+// window i is [startTime+i*window, startTime+(i+1)*window).
+type increaseAggregator struct {
+	startTime int64
+	window    int64
+	windows   []increaseWindow
+}
+
+// NewIncreaseAggregator creates the increase aggregator with start time, window(millis) and window capacity
+func NewIncreaseAggregator(startTime, window int64, capacity int) IncreaseAggregator {
+	return &increaseAggregator{
+		startTime: startTime,
+		window:    window,
+		windows:   make([]increaseWindow, capacity),
+	}
+}
+
+// Aggregate aggregates the counter sample into the window which the timestamp belongs to,
+// a sample less than the previous one is treated as the counter restarting from zero.
+func (a *increaseAggregator) Aggregate(timestamp int64, value float64) {
+	if a.window <= 0 || timestamp < a.startTime || math.IsNaN(value) {
+		return
+	}
+	idx := int((timestamp - a.startTime) / a.window)
+	if idx >= len(a.windows) {
+		return
+	}
+	w := &a.windows[idx]
+	switch {
+	case w.count == 0:
+		w.firstTimestamp = timestamp
+		w.firstValue = value
+	case timestamp <= w.lastTimestamp:
+		// out of order sample
+		return
+	case value < w.lastValue:
+		// counter reset, restarts from zero
+		w.increase += value
+	default:
+		w.increase += value - w.lastValue
+	}
+	w.count++
+	w.lastTimestamp = timestamp
+	w.lastValue = value
+}
+
+// ResultSet returns the extrapolated increase of each window, the window with less than 2 samples has no value.
+//
+// The increase between the first and last samples is extrapolated to the window edges like prometheus:
+// if the gap between a sample and the window edge is less than 1.1 times of the average sample interval,
+// extrapolates to the edge, else only extrapolates half of the average interval(the series starts/ends in window).
+// Also the extrapolation to the start never goes below zero value of the counter.
+func (a *increaseAggregator) ResultSet() collections.FloatArray {
+	result := collections.NewFloatArray(len(a.windows))
+	for idx := range a.windows {
+		w := &a.windows[idx]
+		if w.count < 2 {
+			continue
+		}
+		windowStart := a.startTime + int64(idx)*a.window
+		windowEnd := windowStart + a.window
+		sampledInterval := float64(w.lastTimestamp - w.firstTimestamp)
+		averageInterval := sampledInterval / float64(w.count-1)
+		extrapolationThreshold := averageInterval * 1.1
+
+		durationToStart := float64(w.firstTimestamp - windowStart)
+		durationToEnd := float64(windowEnd - w.lastTimestamp)
+		if w.increase > 0 && w.firstValue >= 0 {
+			// counter cannot be negative, limits the extrapolation to the start
+			durationToZero := sampledInterval * (w.firstValue / w.increase)
+			if durationToZero < durationToStart {
+				durationToStart = durationToZero
+			}
+		}
+		extrapolatedInterval := sampledInterval
+		if durationToStart < extrapolationThreshold {
+			extrapolatedInterval += durationToStart
+		} else {
+			extrapolatedInterval += averageInterval / 2
+		}
+		if durationToEnd < extrapolationThreshold {
+			extrapolatedInterval += durationToEnd
+		} else {
+			extrapolatedInterval += averageInterval / 2
+		}
+		result.SetValue(idx, w.increase*extrapolatedInterval/sampledInterval)
+	}
+	return result
+}
+
+// Reset resets the aggregator for reusing
+func (a *increaseAggregator) Reset() {
+	for idx := range a.windows {
+		a.windows[idx] = increaseWindow{}
+	}
+}
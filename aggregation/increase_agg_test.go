@@ -0,0 +1,49 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestIncreaseAggregator_counter_reset(t *testing.T) {
+	window := 5 * timeutil.OneMinute
+	agg := NewIncreaseAggregator(0, window, 3)
+	// window 0, counter resets at 210s: 10+10+5(restarts from zero)+10=35,
+	// extrapolates 30s to both edges => 35*300/240
+	for idx, value := range []float64{10, 20, 30, 5, 15} {
+		agg.Aggregate(int64(30+60*idx)*timeutil.OneSecond, value)
+	}
+	// window 1, samples are far from edges, only extrapolates half of average interval => 20*90/60
+	agg.Aggregate(420*timeutil.OneSecond, 100)
+	agg.Aggregate(450*timeutil.OneSecond, 110)
+	agg.Aggregate(440*timeutil.OneSecond, 1) // out of order
+	agg.Aggregate(480*timeutil.OneSecond, 120)
+	// window 2, single sample
+	agg.Aggregate(700*timeutil.OneSecond, 100)
+	// out of windows
+	agg.Aggregate(-1, 100)
+	agg.Aggregate(900*timeutil.OneSecond, 100)
+
+	rs := agg.ResultSet()
+	assert.InDelta(t, 43.75, rs.GetValue(0), 1e-9)
+	assert.InDelta(t, 30.0, rs.GetValue(1), 1e-9)
+	assert.False(t, rs.HasValue(2))
+
+	agg.Reset()
+	assert.True(t, agg.ResultSet().IsEmpty())
+}
+
+func TestIncreaseAggregator_extrapolate_to_zero(t *testing.T) {
+	agg := NewIncreaseAggregator(0, 100*timeutil.OneSecond, 1)
+	// counter starts at 1, extrapolation to the start stops at zero value(10s before first sample)
+	agg.Aggregate(50*timeutil.OneSecond, 1)
+	agg.Aggregate(60*timeutil.OneSecond, 2)
+	agg.Aggregate(70*timeutil.OneSecond, 3)
+	agg.Aggregate(80*timeutil.OneSecond, 4)
+	agg.Aggregate(90*timeutil.OneSecond, 5)
+	// sampled 40s, to start 10s(zero), to end 10s => 4*60/40
+	assert.InDelta(t, 6.0, agg.ResultSet().GetValue(0), 1e-9)
+}
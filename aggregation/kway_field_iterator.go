@@ -0,0 +1,177 @@
+package aggregation
+
+import (
+	"container/heap"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// ConflictPolicy represents the policy of resolving the identical time slot when merges field iterators
+type ConflictPolicy uint8
+
+// Defines all conflict policies of merging field iterators
+const (
+	// NewestWins keeps the value of newer iterator
+	NewestWins ConflictPolicy = iota
+	// SumConflict sums the values of identical time slot
+	SumConflict
+)
+
+// peekedFieldIterator wraps field iterator which can peek the next data point
+type peekedFieldIterator struct {
+	it      series.FieldIterator
+	slot    int
+	value   float64
+	hasPeek bool
+}
+
+// peek reads the next data point if not peeked, returns false if no more data
+func (p *peekedFieldIterator) peek() bool {
+	if p.hasPeek {
+		return true
+	}
+	if p.it == nil || !p.it.HasNext() {
+		return false
+	}
+	p.slot, p.value = p.it.Next()
+	if p.slot < 0 {
+		p.it = nil
+		return false
+	}
+	p.hasPeek = true
+	return true
+}
+
+// kWayEntry represents the peeked field iterator in the min-heap, idx is the order of input(larger is newer)
+type kWayEntry struct {
+	peekedFieldIterator
+	idx int
+}
+
+// kWayHeap implements heap.Interface, ordered by time slot, then by the order of input
+type kWayHeap []*kWayEntry
+
+func (h kWayHeap) Len() int { return len(h) }
+func (h kWayHeap) Less(i, j int) bool {
+	if h[i].slot == h[j].slot {
+		return h[i].idx < h[j].idx
+	}
+	return h[i].slot < h[j].slot
+}
+func (h kWayHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *kWayHeap) Push(x interface{}) { *h = append(*h, x.(*kWayEntry)) }
+func (h *kWayHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
+
+// kWayFieldIterator implements series.FieldIterator interface,
+// merges k slot-sorted field iterators of the same field(like from k overlapping segments) using min-heap.
+type kWayFieldIterator struct {
+	aggType  field.AggType
+	heap     kWayHeap
+	conflict ConflictPolicy
+
+	lastSlot int
+}
+
+// NewKWayFieldIterator creates a field iterator which merges k slot-sorted field iterators in one ordered stream,
+// the latter iterator is regarded as the newer one, identical time slot is resolved by the conflict policy,
+// the merged time slots are strictly increasing, the slot not after the last emitted slot is dropped.
+// If only one iterator is given, returns it directly.
+func NewKWayFieldIterator(its []series.FieldIterator, conflict ConflictPolicy) series.FieldIterator {
+	var inputs []series.FieldIterator
+	for _, it := range its {
+		if it != nil {
+			inputs = append(inputs, it)
+		}
+	}
+	if len(inputs) == 1 {
+		return inputs[0]
+	}
+	it := &kWayFieldIterator{
+		conflict: conflict,
+		lastSlot: -1,
+	}
+	if len(inputs) > 0 {
+		it.aggType = inputs[0].AggType()
+	}
+	for idx, input := range inputs {
+		entry := &kWayEntry{peekedFieldIterator: peekedFieldIterator{it: input}, idx: idx}
+		if entry.peek() {
+			it.heap = append(it.heap, entry)
+		}
+	}
+	heap.Init(&it.heap)
+	return it
+}
+
+// AggType returns the field's agg type for down sampling.
+func (it *kWayFieldIterator) AggType() field.AggType {
+	return it.aggType
+}
+
+// HasNext returns if the iteration has more fields
+func (it *kWayFieldIterator) HasNext() bool {
+	// drops the slots which are not increasing
+	for len(it.heap) > 0 && it.heap[0].slot <= it.lastSlot {
+		it.advance()
+	}
+	return len(it.heap) > 0
+}
+
+// Next returns the data point in the iteration
+func (it *kWayFieldIterator) Next() (timeSlot int, value float64) {
+	if !it.HasNext() {
+		return -1, 0
+	}
+	top := it.heap[0]
+	timeSlot, value = top.slot, top.value
+	it.advance()
+	// identical time slot of other iterators, popped from older to newer
+	for len(it.heap) > 0 && it.heap[0].slot == timeSlot {
+		if it.conflict == SumConflict {
+			value += it.heap[0].value
+		} else {
+			value = it.heap[0].value
+		}
+		it.advance()
+	}
+	it.lastSlot = timeSlot
+	return
+}
+
+// advance consumes the data point of the top iterator, also skips the following data points of the iterator
+// whose slot is not after the consumed slot, removes the iterator if no more data
+func (it *kWayFieldIterator) advance() {
+	top := it.heap[0]
+	consumedSlot := top.slot
+	top.hasPeek = false
+	for top.peek() {
+		if top.slot > consumedSlot {
+			heap.Fix(&it.heap, 0)
+			return
+		}
+		top.hasPeek = false
+	}
+	heap.Pop(&it.heap)
+}
+
+// MarshalBinary marshals the remaining data of merged iterator
+func (it *kWayFieldIterator) MarshalBinary() ([]byte, error) {
+	return it.marshalWithOption(MarshalOption{})
+}
+
+// marshalWithOption marshals the remaining data of merged iterator by the marshal option
+func (it *kWayFieldIterator) marshalWithOption(option MarshalOption) ([]byte, error) {
+	if !it.HasNext() {
+		return nil, nil
+	}
+	return marshalFieldIterator(it.heap[0].slot, encoding.XORCodec, it, option)
+}
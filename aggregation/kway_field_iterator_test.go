@@ -0,0 +1,109 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// newOverlappingSegments creates 4 field iterators from overlapping segments, the latter is newer
+func newOverlappingSegments() []series.FieldIterator {
+	return []series.FieldIterator{
+		newSlotFieldIterator(field.Sum, []int{1, 2, 3, 4}, []float64{1, 2, 3, 4}),
+		newSlotFieldIterator(field.Sum, []int{3, 4, 5, 6}, []float64{30, 40, 50, 60}),
+		newSlotFieldIterator(field.Sum, []int{0, 4, 8}, []float64{0, 400, 800}),
+		newSlotFieldIterator(field.Sum, []int{2, 6, 7}, []float64{2000, 6000, 7000}),
+	}
+}
+
+func TestKWayFieldIterator_overlapping(t *testing.T) {
+	it := NewKWayFieldIterator(newOverlappingSegments(), NewestWins)
+	assert.Equal(t, field.Sum, it.AggType())
+	slots, values := readFieldIterator(it)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, slots)
+	assert.Equal(t, []float64{0, 1, 2000, 30, 400, 50, 6000, 7000, 800}, values)
+
+	slots, values = readFieldIterator(NewKWayFieldIterator(newOverlappingSegments(), SumConflict))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, slots)
+	assert.Equal(t, []float64{0, 1, 2002, 33, 444, 50, 6060, 7000, 800}, values)
+}
+
+func TestKWayFieldIterator_disjoint(t *testing.T) {
+	its := []series.FieldIterator{
+		newSlotFieldIterator(field.Max, []int{10, 11}, []float64{10, 11}),
+		newSlotFieldIterator(field.Max, []int{1, 2}, []float64{1, 2}),
+	}
+	slots, values := readFieldIterator(NewKWayFieldIterator(its, NewestWins))
+	assert.Equal(t, []int{1, 2, 10, 11}, slots)
+	assert.Equal(t, []float64{1, 2, 10, 11}, values)
+}
+
+func TestKWayFieldIterator_strictly_increasing(t *testing.T) {
+	// input has duplicated/out of order slot
+	its := []series.FieldIterator{
+		newSlotFieldIterator(field.Sum, []int{1, 3, 2, 4}, []float64{1, 3, 2, 4}),
+		newSlotFieldIterator(field.Sum, []int{3, 3, 5}, []float64{30, 31, 50}),
+		newSlotFieldIterator(field.Sum, []int{5}, []float64{500}),
+	}
+	slots, values := readFieldIterator(NewKWayFieldIterator(its, NewestWins))
+	assert.Equal(t, []int{1, 3, 4, 5}, slots)
+	assert.Equal(t, []float64{1, 30, 4, 500}, values)
+}
+
+func TestKWayFieldIterator_empty(t *testing.T) {
+	for _, its := range [][]series.FieldIterator{
+		nil,
+		{nil, nil},
+		{newSlotFieldIterator(field.Max, nil, nil), newSlotFieldIterator(field.Max, nil, nil)},
+	} {
+		it := NewKWayFieldIterator(its, SumConflict)
+		assert.False(t, it.HasNext())
+		slot, _ := it.Next()
+		assert.Equal(t, -1, slot)
+		data, err := it.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+	}
+	// empty iterator is removed from the merge
+	its := []series.FieldIterator{
+		newSlotFieldIterator(field.Max, nil, nil),
+		newSlotFieldIterator(field.Max, []int{1, 2}, []float64{1, 2}),
+	}
+	it := NewKWayFieldIterator(its, SumConflict)
+	assert.Equal(t, field.Max, it.AggType())
+	slots, values := readFieldIterator(it)
+	assert.Equal(t, []int{1, 2}, slots)
+	assert.Equal(t, []float64{1, 2}, values)
+}
+
+func TestKWayFieldIterator_single(t *testing.T) {
+	single := newSlotFieldIterator(field.Sum, []int{1, 2}, []float64{1, 2})
+	// returns the only iterator directly
+	assert.Equal(t, single, NewKWayFieldIterator([]series.FieldIterator{nil, single}, NewestWins))
+}
+
+func TestKWayFieldIterator_MarshalBinary(t *testing.T) {
+	data, err := NewKWayFieldIterator(newOverlappingSegments(), NewestWins).MarshalBinary()
+	assert.NoError(t, err)
+	slots, values := decodeFieldData(t, data)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, slots)
+	assert.Equal(t, []float64{0, 1, 2000, 30, 400, 50, 6000, 7000, 800}, values)
+}
+
+// newSlotFieldIterator creates the field iterator over the given slots and values
+func newSlotFieldIterator(aggType field.AggType, slots []int, values []float64) series.FieldIterator {
+	return (&SafeFieldIterator{aggType: aggType, slots: slots, values: values}).Iterator()
+}
+
+// readFieldIterator reads all data points of field iterator
+func readFieldIterator(it series.FieldIterator) (slots []int, values []float64) {
+	for it.HasNext() {
+		slot, value := it.Next()
+		slots = append(slots, slot)
+		values = append(values, value)
+	}
+	return
+}
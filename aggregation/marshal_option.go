@@ -0,0 +1,37 @@
+package aggregation
+
+import (
+	"github.com/lindb/lindb/series"
+)
+
+// MarshalOption represents the option of marshaling field data
+type MarshalOption struct {
+	// NaNPolicy is the policy of handling NaN/Inf value, default skip
+	NaNPolicy NaNPolicy
+	// MaxSlotsPerBlock is the max num. of time slots in one encoded field block, <= 0 means no limit.
+	// The field data is split into multiple chained blocks if exceeds the limit, each block has its own start slot,
+	// so that readers can skip whole blocks outside the query range.
+	MaxSlotsPerBlock int
+}
+
+// optionMarshaler represents the field iterator which marshals the field data based on the marshal option
+type optionMarshaler interface {
+	// marshalWithOption marshals the remaining data of field iterator with the marshal option
+	marshalWithOption(option MarshalOption) ([]byte, error)
+}
+
+// MarshalIterator marshals series data of one field with the marshal option, format see series.MarshalIterator
+func MarshalIterator(it series.Iterator, option MarshalOption) ([]byte, error) {
+	return series.MarshalIteratorWith(it, func(fIt series.FieldIterator) ([]byte, error) {
+		return marshalFieldWithOption(fIt, option)
+	})
+}
+
+// marshalFieldWithOption marshals the field iterator with the marshal option,
+// falls back to the default marshaling if field iterator doesn't support the option(e.g. partial state)
+func marshalFieldWithOption(it series.FieldIterator, option MarshalOption) ([]byte, error) {
+	if m, ok := it.(optionMarshaler); ok {
+		return m.marshalWithOption(option)
+	}
+	return it.MarshalBinary()
+}
@@ -0,0 +1,47 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestMarshalIterator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	data, err := MarshalIterator(nil, MarshalOption{})
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	mockSeries := func() series.Iterator {
+		it := series.NewMockIterator(ctrl)
+		it.EXPECT().FieldType().Return(field.SumField)
+		it.EXPECT().HasNext().Return(true)
+		it.EXPECT().Next().Return(int64(10), NewFieldIterator(10, field.Sum, generateFloatArray([]float64{1, math.NaN()})))
+		it.EXPECT().HasNext().Return(false).AnyTimes()
+		return it
+	}
+	// reject NaN of field iterator
+	data, err = MarshalIterator(mockSeries(), MarshalOption{NaNPolicy: RejectNaN})
+	assert.Equal(t, ErrInvalidValue, err)
+	assert.Nil(t, data)
+	// skip NaN of field iterator
+	data, err = MarshalIterator(mockSeries(), MarshalOption{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, data)
+	// partial state falls back to default marshaling
+	it := series.NewMockIterator(ctrl)
+	it.EXPECT().FieldType().Return(field.SumField)
+	it.EXPECT().HasNext().Return(true)
+	it.EXPECT().Next().Return(int64(10), newStateFieldIterator(field.DistinctCount, generateFloatArray([]float64{1}), []byte{1, 2}))
+	it.EXPECT().HasNext().Return(false)
+	data, err = MarshalIterator(it, MarshalOption{NaNPolicy: RejectNaN})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, data)
+}
@@ -0,0 +1,54 @@
+package aggregation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidValue represents the field value is NaN or Inf, which is rejected when marshals field data
+var ErrInvalidValue = errors.New("field value is NaN or Inf")
+
+// NaNPolicy represents the policy of handling NaN/Inf value when marshals field data
+type NaNPolicy int32
+
+// Defines all policies of handling NaN/Inf value
+const (
+	// SkipNaN skips the NaN/Inf value, treats the time slot as absent
+	SkipNaN NaNPolicy = iota
+	// RejectNaN fails the marshaling if contains NaN/Inf value
+	RejectNaN
+	// StoreNaN stores the NaN/Inf value as normal value
+	StoreNaN
+)
+
+// String returns the string value of policy
+func (p NaNPolicy) String() string {
+	switch p {
+	case RejectNaN:
+		return "reject"
+	case StoreNaN:
+		return "store"
+	default:
+		return "skip"
+	}
+}
+
+// ParseNaNPolicy parses the policy by string value(skip/reject/store), empty value means skip
+func ParseNaNPolicy(policy string) (NaNPolicy, error) {
+	switch policy {
+	case "", "skip":
+		return SkipNaN, nil
+	case "reject":
+		return RejectNaN, nil
+	case "store":
+		return StoreNaN, nil
+	default:
+		return SkipNaN, fmt.Errorf("unknown nan policy: %s", policy)
+	}
+}
+
+// isInvalidValue checks if value is NaN or Inf
+func isInvalidValue(value float64) bool {
+	return math.IsNaN(value) || math.IsInf(value, 0)
+}
@@ -0,0 +1,78 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestParseNaNPolicy(t *testing.T) {
+	for _, policy := range []NaNPolicy{SkipNaN, RejectNaN, StoreNaN} {
+		p, err := ParseNaNPolicy(policy.String())
+		assert.NoError(t, err)
+		assert.Equal(t, policy, p)
+	}
+	p, err := ParseNaNPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, SkipNaN, p)
+	_, err = ParseNaNPolicy("drop")
+	assert.Error(t, err)
+}
+
+func TestNaNPolicy_MarshalBinary(t *testing.T) {
+	values := []float64{1, math.NaN(), 3, math.Inf(1), 5}
+	// skip by default, NaN/Inf slot is absent
+	it := NewFieldIterator(10, field.Sum, generateFloatArray(values))
+	data, err := it.MarshalBinary()
+	assert.NoError(t, err)
+	slots, result := decodeFieldData(t, data)
+	assert.Equal(t, []int{10, 12, 14}, slots)
+	assert.Equal(t, []float64{1, 3, 5}, result)
+	// skip all invalid values
+	it = NewFieldIterator(10, field.Sum, generateFloatArray([]float64{math.NaN(), math.Inf(-1)}))
+	data, err = marshalFieldWithOption(it, MarshalOption{NaNPolicy: SkipNaN})
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	// reject
+	reject := MarshalOption{NaNPolicy: RejectNaN}
+	it = NewFieldIterator(10, field.Sum, generateFloatArray(values))
+	data, err = marshalFieldWithOption(it, reject)
+	assert.Equal(t, ErrInvalidValue, err)
+	assert.Nil(t, data)
+	it = NewFieldIterator(10, field.Sum, generateFloatArray([]float64{1, 3}))
+	data, err = marshalFieldWithOption(it, reject)
+	assert.NoError(t, err)
+	slots, result = decodeFieldData(t, data)
+	assert.Equal(t, []int{10, 11}, slots)
+	assert.Equal(t, []float64{1, 3}, result)
+
+	// store
+	it = NewFieldIterator(10, field.Sum, generateFloatArray(values))
+	data, err = marshalFieldWithOption(it, MarshalOption{NaNPolicy: StoreNaN})
+	assert.NoError(t, err)
+	slots, result = decodeFieldData(t, data)
+	assert.Equal(t, []int{10, 11, 12, 13, 14}, slots)
+	assert.Equal(t, 1.0, result[0])
+	assert.True(t, math.IsNaN(result[1]))
+	assert.Equal(t, 3.0, result[2])
+	assert.True(t, math.IsInf(result[3], 1))
+	assert.Equal(t, 5.0, result[4])
+}
+
+func decodeFieldData(t *testing.T, data []byte) (slots []int, values []float64) {
+	aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	fIt := series.NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
+	for fIt.HasNext() {
+		slot, value := fIt.Next()
+		slots = append(slots, slot)
+		values = append(values, value)
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package aggregation
+
+import (
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// ResampleAggType returns the agg type for re-aggregating the result of select item when resampling
+// from fine to coarse, the last value of output step is used if the select item cannot be re-aggregated.
+func ResampleAggType(selectItem stmt.Expr) field.AggType {
+	if item, ok := selectItem.(*stmt.SelectItem); ok {
+		selectItem = item.Expr
+	}
+	call, ok := selectItem.(*stmt.CallExpr)
+	if !ok {
+		return field.Replace
+	}
+	switch call.FuncType {
+	case function.Sum, function.Count, function.CountAll, function.Increase:
+		return field.Sum
+	case function.Min:
+		return field.Min
+	case function.Max:
+		return field.Max
+	default:
+		return field.Replace
+	}
+}
+
+// resampleIterator implements series.FieldIterator interface,
+// converts the time slot of fixed step iterator(based on grid unit) into the index of output step.
+type resampleIterator struct {
+	series.FieldIterator
+	step int
+}
+
+// Next returns the index of output step and the value
+func (it *resampleIterator) Next() (timeSlot int, value float64) {
+	timeSlot, value = it.FieldIterator.Next()
+	if timeSlot < 0 {
+		return timeSlot, value
+	}
+	return timeSlot / it.step, value
+}
+
+// NewResampleIterator creates the field iterator which resamples the values(time slot = (timestamp-start)/interval)
+// to the output interval, time slot of iterator = (timestamp-start)/output interval:
+// 1) coarse to fine, the output step which has no data point is filled based on fill policy;
+// 2) fine to coarse, the values in the same output step are re-aggregated by agg type.
+// NOTICE: output interval must be a multiple or divisor of interval.
+func NewResampleIterator(values series.FieldIterator, aggType field.AggType, timeRange timeutil.TimeRange,
+	interval, outputInterval int64, fill FillPolicy,
+) series.FieldIterator {
+	// the values are placed on the grid whose unit is the smaller interval
+	unit := interval
+	if outputInterval < unit {
+		unit = outputInterval
+	}
+	ratio := int(interval / unit)
+	step := int(outputInterval / unit)
+	endSlot := int((timeRange.End - timeRange.Start) / unit)
+	gridValues := collections.NewFloatArray(endSlot + 1)
+	if values != nil {
+		for values.HasNext() {
+			slot, value := values.Next()
+			if gridSlot := slot * ratio; gridSlot <= endSlot {
+				gridValues.SetValue(gridSlot, value)
+			}
+		}
+	}
+	src := NewFieldIterator(0, aggType, gridValues)
+	return &resampleIterator{
+		FieldIterator: NewFixedStepIterator(src, 0, endSlot-endSlot%step, step, fill),
+		step:          step,
+	}
+}
@@ -0,0 +1,76 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestResampleAggType(t *testing.T) {
+	assert.Equal(t, field.Sum, ResampleAggType(&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Sum}}))
+	assert.Equal(t, field.Sum, ResampleAggType(&stmt.CallExpr{FuncType: function.Count}))
+	assert.Equal(t, field.Sum, ResampleAggType(&stmt.CallExpr{FuncType: function.CountAll}))
+	assert.Equal(t, field.Min, ResampleAggType(&stmt.CallExpr{FuncType: function.Min}))
+	assert.Equal(t, field.Max, ResampleAggType(&stmt.CallExpr{FuncType: function.Max}))
+	assert.Equal(t, field.Replace, ResampleAggType(&stmt.CallExpr{FuncType: function.Avg}))
+	assert.Equal(t, field.Replace, ResampleAggType(&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "f"}}))
+}
+
+func TestResampleIterator_UpSampling(t *testing.T) {
+	nan := math.NaN()
+	timeRange := timeutil.TimeRange{Start: 0, End: 10 * timeutil.OneMinute}
+	// 5m => 1m
+	values := collections.NewFloatArray(3)
+	values.SetValue(0, 1)
+	values.SetValue(1, 2)
+	values.SetValue(2, 3)
+	it := NewResampleIterator(NewFieldIterator(0, field.Max, values), field.Max, timeRange, 5*timeutil.OneMinute, timeutil.OneMinute,
+		FillPolicy{Type: FillPrevious})
+	assertFixedStepIt(t, it,
+		[]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		[]float64{1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 3})
+	// fill null
+	values = collections.NewFloatArray(3)
+	values.SetValue(1, 2)
+	it = NewResampleIterator(NewFieldIterator(0, field.Max, values), field.Max, timeRange, 5*timeutil.OneMinute, timeutil.OneMinute,
+		FillPolicy{Type: FillNull})
+	assertFixedStepIt(t, it,
+		[]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		[]float64{nan, nan, nan, nan, nan, 2, nan, nan, nan, nan, nan})
+}
+
+func TestResampleIterator_DownSampling(t *testing.T) {
+	timeRange := timeutil.TimeRange{Start: 0, End: 10 * timeutil.OneMinute}
+	// 1m => 5m
+	values := collections.NewFloatArray(11)
+	for i := 0; i <= 10; i++ {
+		if i != 3 {
+			values.SetValue(i, float64(i+1))
+		}
+	}
+	// slot 3 is absent: 1+2+3+5, 6+7+8+9+10, 11
+	it := NewResampleIterator(NewFieldIterator(0, field.Sum, values), field.Sum, timeRange, timeutil.OneMinute, 5*timeutil.OneMinute,
+		FillPolicy{Type: FillNull})
+	assert.Equal(t, field.Sum, it.AggType())
+	assertFixedStepIt(t, it, []int{0, 1, 2}, []float64{11, 40, 11})
+	it = NewResampleIterator(NewFieldIterator(0, field.Max, values), field.Max, timeRange, timeutil.OneMinute, 5*timeutil.OneMinute,
+		FillPolicy{Type: FillNull})
+	assertFixedStepIt(t, it, []int{0, 1, 2}, []float64{5, 10, 11})
+	// empty output step is filled
+	values = collections.NewFloatArray(11)
+	values.SetValue(7, 7)
+	it = NewResampleIterator(NewFieldIterator(0, field.Sum, values), field.Sum, timeRange, timeutil.OneMinute, 5*timeutil.OneMinute,
+		FillPolicy{Type: FillValue, Value: 0})
+	assertFixedStepIt(t, it, []int{0, 1, 2}, []float64{0, 7, 0})
+	// nil values
+	it = NewResampleIterator(nil, field.Sum, timeRange, timeutil.OneMinute, 5*timeutil.OneMinute,
+		FillPolicy{Type: FillValue, Value: 1})
+	assertFixedStepIt(t, it, []int{0, 1, 2}, []float64{1, 1, 1})
+}
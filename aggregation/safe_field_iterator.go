@@ -0,0 +1,88 @@
+package aggregation
+
+import (
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// SafeFieldIterator represents a concurrent-safe field iterator, which materializes the field data into
+// an immutable snapshot once, then each reader gets its own iterator over the snapshot by Iterator(),
+// so that multiple readers(like aggregators) can read the same field data concurrently without blocking each other.
+type SafeFieldIterator struct {
+	aggType field.AggType
+	slots   []int
+	values  []float64
+}
+
+// NewSafeFieldIterator creates a concurrent-safe field iterator by reading all data of the given field iterator,
+// NOTE: the given field iterator is consumed after materializing, so it cannot be used again.
+func NewSafeFieldIterator(it series.FieldIterator) *SafeFieldIterator {
+	safeIt := &SafeFieldIterator{
+		aggType: it.AggType(),
+	}
+	for it.HasNext() {
+		slot, value := it.Next()
+		if slot < 0 {
+			break
+		}
+		safeIt.slots = append(safeIt.slots, slot)
+		safeIt.values = append(safeIt.values, value)
+	}
+	return safeIt
+}
+
+// AggType returns the field's agg type for down sampling.
+func (it *SafeFieldIterator) AggType() field.AggType {
+	return it.aggType
+}
+
+// Len returns the number of data points in the snapshot
+func (it *SafeFieldIterator) Len() int {
+	return len(it.slots)
+}
+
+// Iterator returns a new field iterator over the snapshot,
+// the returned iterator is stateful and not concurrent-safe, each reader should get its own iterator.
+func (it *SafeFieldIterator) Iterator() series.FieldIterator {
+	return &snapshotFieldIterator{snapshot: it}
+}
+
+// snapshotFieldIterator implements series.FieldIterator interface over the immutable snapshot
+type snapshotFieldIterator struct {
+	snapshot *SafeFieldIterator
+	idx      int
+}
+
+// AggType returns the field's agg type for down sampling.
+func (it *snapshotFieldIterator) AggType() field.AggType {
+	return it.snapshot.aggType
+}
+
+// HasNext returns if the iteration has more fields
+func (it *snapshotFieldIterator) HasNext() bool {
+	return it.idx < len(it.snapshot.slots)
+}
+
+// Next returns the data point in the iteration
+func (it *snapshotFieldIterator) Next() (timeSlot int, value float64) {
+	if !it.HasNext() {
+		return -1, 0
+	}
+	timeSlot, value = it.snapshot.slots[it.idx], it.snapshot.values[it.idx]
+	it.idx++
+	return
+}
+
+// MarshalBinary marshals the remaining data of snapshot
+func (it *snapshotFieldIterator) MarshalBinary() ([]byte, error) {
+	return it.marshalWithOption(MarshalOption{})
+}
+
+// marshalWithOption marshals the remaining data of snapshot by the marshal option
+func (it *snapshotFieldIterator) marshalWithOption(option MarshalOption) ([]byte, error) {
+	if !it.HasNext() {
+		return nil, nil
+	}
+	return marshalFieldIterator(it.snapshot.slots[it.idx], encoding.XORCodec, it, option)
+}
@@ -0,0 +1,79 @@
+package aggregation
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestSafeFieldIterator(t *testing.T) {
+	safeIt := NewSafeFieldIterator(NewFieldIterator(20, field.Min, generateFloatArray(nil)))
+	assert.Equal(t, field.Min, safeIt.AggType())
+	assert.Equal(t, 0, safeIt.Len())
+	it := safeIt.Iterator()
+	assert.False(t, it.HasNext())
+	slot, value := it.Next()
+	assert.Equal(t, -1, slot)
+	assert.Equal(t, 0.0, value)
+	data, err := it.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	safeIt = NewSafeFieldIterator(NewFieldIterator(20, field.Sum, generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0})))
+	assert.Equal(t, 5, safeIt.Len())
+	expect := map[int]float64{20: 0, 21: 10, 22: 10.0, 23: 100.4, 24: 50.0}
+	it = safeIt.Iterator()
+	assert.Equal(t, field.Sum, it.AggType())
+	AssertFieldIt(t, it, expect)
+	assert.False(t, it.HasNext())
+	// new iterator reads the full data again
+	AssertFieldIt(t, safeIt.Iterator(), expect)
+}
+
+func TestSafeFieldIterator_MarshalBinary(t *testing.T) {
+	safeIt := NewSafeFieldIterator(NewFieldIterator(10, field.Sum, generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0})))
+	it := safeIt.Iterator()
+	// skip first point
+	it.Next()
+	data, err := it.MarshalBinary()
+	assert.NoError(t, err)
+	assert.True(t, len(data) > 0)
+
+	aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Sum, aggType)
+	fIt := series.NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
+	AssertFieldIt(t, fIt, map[int]float64{11: 10, 12: 10.0, 13: 100.4, 14: 50.0})
+	assert.False(t, fIt.HasNext())
+}
+
+func TestSafeFieldIterator_concurrent(t *testing.T) {
+	safeIt := NewSafeFieldIterator(NewFieldIterator(10, field.Sum, generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0})))
+	expect := map[int]float64{10: 0, 11: 10, 12: 10.0, 13: 100.4, 14: 50.0}
+
+	var wait sync.WaitGroup
+	results := make([]map[int]float64, 2)
+	for i := range results {
+		wait.Add(1)
+		go func(idx int) {
+			defer wait.Done()
+			result := make(map[int]float64)
+			it := safeIt.Iterator()
+			for it.HasNext() {
+				slot, value := it.Next()
+				result[slot] = value
+			}
+			results[idx] = result
+		}(i)
+	}
+	wait.Wait()
+	// both readers see the full data
+	for _, result := range results {
+		assert.Equal(t, expect, result)
+	}
+}
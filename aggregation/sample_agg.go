@@ -0,0 +1,108 @@
+package aggregation
+
+import (
+	"fmt"
+
+	"github.com/lindb/lindb/aggregation/selector"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// sampleFieldAggregator implements field aggregator interface for sampling raw data points,
+// passes through every Nth present data point(by count) of field series in query time range, discards the rest.
+// the first data point of each window(N points) is kept for visual continuity.
+type sampleFieldAggregator struct {
+	segmentStartTime int64
+	selector         selector.SlotSelector
+	factor           int
+
+	count   int
+	aggType field.AggType
+	slots   []int
+	values  []float64
+}
+
+// NewSampleFieldAggregator creates a sample field aggregator with sampling factor,
+// factor must be positive, 1 means passes through all data points.
+func NewSampleFieldAggregator(segmentStartTime int64, selector selector.SlotSelector, factor int) FieldAggregator {
+	if factor <= 0 {
+		factor = 1
+	}
+	return &sampleFieldAggregator{
+		segmentStartTime: segmentStartTime,
+		selector:         selector,
+		factor:           factor,
+	}
+}
+
+// Aggregate samples the data points in query time range of the field series
+func (a *sampleFieldAggregator) Aggregate(it series.FieldIterator) {
+	a.aggType = it.AggType()
+	for it.HasNext() {
+		slot, value := it.Next()
+		if slot < 0 {
+			break
+		}
+		idx, completed := a.selector.IndexOf(slot)
+		if completed {
+			// time slots are in order, the remaining slots are out of query time range
+			break
+		}
+		if idx < 0 {
+			continue
+		}
+		if a.count%a.factor == 0 {
+			// keeps the first data point of window
+			a.slots = append(a.slots, slot)
+			a.values = append(a.values, value)
+		}
+		a.count++
+	}
+}
+
+// Merge merges the sampled data points of other sample aggregator which has same segment start time,
+// the sampled data points of both aggregators are kept, ordered by time slot.
+func (a *sampleFieldAggregator) Merge(other FieldAggregator) error {
+	o, ok := other.(*sampleFieldAggregator)
+	if !ok {
+		return fmt.Errorf("cannot merge sample field aggregator with type: %T", other)
+	}
+	if a.segmentStartTime != o.segmentStartTime {
+		return fmt.Errorf("cannot merge sample field aggregator with different segment start time")
+	}
+	if len(o.slots) == 0 {
+		return nil
+	}
+	a.aggType = o.aggType
+	a.count += o.count
+	a.slots, a.values = mergePoints(a.slots, a.values, o.slots, o.values)
+	return nil
+}
+
+// GetBlock returns nil, because sample aggregator doesn't load data into block
+func (a *sampleFieldAggregator) GetBlock(idx int, fn newBlockFunc) (series.Block, bool) {
+	return nil, false
+}
+
+// ResultSet returns the sampled data points of field aggregator
+func (a *sampleFieldAggregator) ResultSet() (startTime int64, it series.FieldIterator) {
+	if len(a.slots) == 0 {
+		return a.segmentStartTime, nil
+	}
+	// copy data points, because aggregator can be reused after reset
+	snapshot := &SafeFieldIterator{
+		aggType: a.aggType,
+		slots:   make([]int, len(a.slots)),
+		values:  make([]float64, len(a.values)),
+	}
+	copy(snapshot.slots, a.slots)
+	copy(snapshot.values, a.values)
+	return a.segmentStartTime, snapshot.Iterator()
+}
+
+// reset resets the aggregate context for reusing
+func (a *sampleFieldAggregator) reset() {
+	a.count = 0
+	a.slots = a.slots[:0]
+	a.values = a.values[:0]
+}
@@ -0,0 +1,74 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/selector"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestSampleFieldAggregator_Aggregate(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	agg := NewSampleFieldAggregator(10, selector.NewIndexSlotSelector(0, 200, 1), 10)
+	block, ok := agg.GetBlock(1, func() series.Block { return nil })
+	assert.False(t, ok)
+	assert.Nil(t, block)
+	agg.Aggregate(NewFieldIterator(0, field.Sum, generateFloatArray(values)))
+	startTime, it := agg.ResultSet()
+	assert.Equal(t, int64(10), startTime)
+	assert.Equal(t, field.Sum, it.AggType())
+	// point count is reduced by the factor, keeps the first point of each window
+	expect := make(map[int]float64)
+	for i := 0; i < 100; i += 10 {
+		expect[i] = float64(i)
+	}
+	AssertFieldIt(t, it, expect)
+
+	// counts the present points across field series
+	agg.reset()
+	agg.Aggregate(NewFieldIterator(0, field.Sum, generateFloatArray([]float64{1, 2, 3})))
+	agg.Aggregate(NewFieldIterator(20, field.Sum, generateFloatArray([]float64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14})))
+	_, it = agg.ResultSet()
+	AssertFieldIt(t, it, map[int]float64{0: 1, 27: 11})
+
+	// clips the slots out of query time range
+	agg = NewSampleFieldAggregator(10, selector.NewIndexSlotSelector(5, 30, 1), 3)
+	agg.Aggregate(NewFieldIterator(0, field.Sum, generateFloatArray(values)))
+	_, it = agg.ResultSet()
+	AssertFieldIt(t, it, map[int]float64{5: 5, 8: 8, 11: 11, 14: 14, 17: 17, 20: 20, 23: 23, 26: 26, 29: 29})
+
+	// no data in query time range
+	agg = NewSampleFieldAggregator(10, selector.NewIndexSlotSelector(500, 600, 1), 3)
+	agg.Aggregate(NewFieldIterator(0, field.Sum, generateFloatArray(values)))
+	_, it = agg.ResultSet()
+	assert.Nil(t, it)
+}
+
+func TestSampleFieldAggregator_Merge(t *testing.T) {
+	agg := NewSampleFieldAggregator(10, selector.NewIndexSlotSelector(0, 200, 1), 2)
+	assert.NoError(t, agg.Merge(NewSampleFieldAggregator(10, selector.NewIndexSlotSelector(0, 200, 1), 2)))
+	agg.Aggregate(NewFieldIterator(0, field.Sum, generateFloatArray([]float64{1, 2, 3})))
+	other := NewSampleFieldAggregator(10, selector.NewIndexSlotSelector(0, 200, 1), 2)
+	other.Aggregate(NewFieldIterator(5, field.Sum, generateFloatArray([]float64{4, 5, 6})))
+	assert.NoError(t, agg.Merge(other))
+	_, it := agg.ResultSet()
+	AssertFieldIt(t, it, map[int]float64{0: 1, 2: 3, 5: 4, 7: 6})
+	assert.Equal(t, 6, agg.(*sampleFieldAggregator).count)
+
+	// cannot merge
+	assert.Error(t, agg.Merge(NewSampleFieldAggregator(20, selector.NewIndexSlotSelector(0, 200, 1), 2)))
+	assert.Error(t, agg.Merge(NewFieldAggregator(10, selector.NewIndexSlotSelector(0, 200, 1))))
+}
+
+func TestSampleFieldAggregator_invalid_factor(t *testing.T) {
+	agg := NewSampleFieldAggregator(10, selector.NewIndexSlotSelector(0, 200, 1), 0)
+	agg.Aggregate(NewFieldIterator(0, field.Sum, generateFloatArray([]float64{1, 2, 3})))
+	_, it := agg.ResultSet()
+	AssertFieldIt(t, it, map[int]float64{0: 1, 1: 2, 2: 3})
+}
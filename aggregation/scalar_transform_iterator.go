@@ -0,0 +1,143 @@
+package aggregation
+
+import (
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// scalarTransformIterator implements series.FieldIterator interface,
+// which applies the arithmetic operator with a constant to each data point of field, e.g. f / 1073741824.
+type scalarTransformIterator struct {
+	src    series.FieldIterator
+	op     stmt.BinaryOP
+	scalar float64
+}
+
+// NewScalarTransformIterator creates a field iterator which evaluates (value op scalar) for each data point of src,
+// op supports +, -, *, /, division by zero returns NaN, it's cheaper than evaluating the expression over float array.
+func NewScalarTransformIterator(src series.FieldIterator, op stmt.BinaryOP, scalar float64) series.FieldIterator {
+	return &scalarTransformIterator{
+		src:    src,
+		op:     op,
+		scalar: scalar,
+	}
+}
+
+// AggType returns the field's agg type for down sampling.
+func (it *scalarTransformIterator) AggType() field.AggType {
+	return it.src.AggType()
+}
+
+// HasNext returns if the iteration has more fields
+func (it *scalarTransformIterator) HasNext() bool {
+	return it.src.HasNext()
+}
+
+// Next returns the data point in the iteration
+func (it *scalarTransformIterator) Next() (timeSlot int, value float64) {
+	timeSlot, value = it.src.Next()
+	if timeSlot < 0 {
+		return
+	}
+	return timeSlot, eval(it.op, value, it.scalar)
+}
+
+// MarshalBinary marshals the transformed data
+func (it *scalarTransformIterator) MarshalBinary() ([]byte, error) {
+	return it.marshalWithOption(MarshalOption{})
+}
+
+// marshalWithOption marshals the transformed data by the marshal option
+func (it *scalarTransformIterator) marshalWithOption(option MarshalOption) ([]byte, error) {
+	return marshalFieldWithOption(NewSafeFieldIterator(it).Iterator(), option)
+}
+
+// scalarTransformSeries implements series.Iterator interface,
+// which applies the scalar transform to each field iterator of the time series.
+type scalarTransformSeries struct {
+	series.Iterator
+	op     stmt.BinaryOP
+	scalar float64
+}
+
+// Next returns the field's iterator with scalar transform
+func (it *scalarTransformSeries) Next() (startTime int64, fieldIt series.FieldIterator) {
+	startTime, fieldIt = it.Iterator.Next()
+	if fieldIt == nil {
+		return
+	}
+	return startTime, NewScalarTransformIterator(fieldIt, it.op, it.scalar)
+}
+
+// scalarTransform represents the binary expr which is field op constant
+type scalarTransform struct {
+	fieldName field.Name
+	op        stmt.BinaryOP
+	scalar    float64
+}
+
+// planScalarTransforms finds the binary exprs which can be evaluated by scalar transform iterator,
+// the binary expr must be field op constant(or constant op field for + and *),
+// and the field cannot be referenced by other exprs, because the field values are transformed in place.
+func planScalarTransforms(selectItems []stmt.Expr) map[*stmt.BinaryExpr]scalarTransform {
+	transforms := make(map[*stmt.BinaryExpr]scalarTransform)
+	refs := make(map[field.Name]int)
+	for _, selectItem := range selectItems {
+		findScalarTransforms(selectItem, transforms, refs)
+	}
+	for expr, transform := range transforms {
+		if refs[transform.fieldName] > 1 {
+			delete(transforms, expr)
+		}
+	}
+	if len(transforms) == 0 {
+		return nil
+	}
+	return transforms
+}
+
+// findScalarTransforms walks the expr, collects the scalar transforms and the num. of references of each field
+func findScalarTransforms(expr stmt.Expr, transforms map[*stmt.BinaryExpr]scalarTransform, refs map[field.Name]int) {
+	switch ex := expr.(type) {
+	case *stmt.SelectItem:
+		findScalarTransforms(ex.Expr, transforms, refs)
+	case *stmt.ParenExpr:
+		findScalarTransforms(ex.Expr, transforms, refs)
+	case *stmt.CallExpr:
+		for _, param := range ex.Params {
+			findScalarTransforms(param, transforms, refs)
+		}
+	case *stmt.FieldExpr:
+		refs[field.Name(ex.Name)]++
+	case *stmt.BinaryExpr:
+		if transform, ok := newScalarTransform(ex); ok {
+			transforms[ex] = transform
+		}
+		findScalarTransforms(ex.Left, transforms, refs)
+		findScalarTransforms(ex.Right, transforms, refs)
+	}
+}
+
+// newScalarTransform returns the scalar transform if the binary expr is field op constant
+func newScalarTransform(expr *stmt.BinaryExpr) (scalarTransform, bool) {
+	switch expr.Operator {
+	case stmt.ADD, stmt.SUB, stmt.MUL, stmt.DIV:
+	default:
+		return scalarTransform{}, false
+	}
+	if f, ok := expr.Left.(*stmt.FieldExpr); ok {
+		if num, ok := expr.Right.(*stmt.NumberLiteral); ok {
+			return scalarTransform{fieldName: field.Name(f.Name), op: expr.Operator, scalar: num.Val}, true
+		}
+	}
+	if expr.Operator == stmt.ADD || expr.Operator == stmt.MUL {
+		// commutative, constant op field => field op constant
+		if num, ok := expr.Left.(*stmt.NumberLiteral); ok {
+			if f, ok := expr.Right.(*stmt.FieldExpr); ok {
+				return scalarTransform{fieldName: field.Name(f.Name), op: expr.Operator, scalar: num.Val}, true
+			}
+		}
+	}
+	return scalarTransform{}, false
+}
@@ -0,0 +1,128 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestScalarTransformIterator(t *testing.T) {
+	newIt := func(op stmt.BinaryOP, scalar float64) series.FieldIterator {
+		return NewScalarTransformIterator(NewFieldIterator(10, field.Sum, generateFloatArray([]float64{2, 4})), op, scalar)
+	}
+	it := newIt(stmt.ADD, 3)
+	assert.Equal(t, field.Sum, it.AggType())
+	AssertFieldIt(t, it, map[int]float64{10: 5, 11: 7})
+	AssertFieldIt(t, newIt(stmt.SUB, 3), map[int]float64{10: -1, 11: 1})
+	AssertFieldIt(t, newIt(stmt.MUL, 3), map[int]float64{10: 6, 11: 12})
+	AssertFieldIt(t, newIt(stmt.DIV, 4), map[int]float64{10: 0.5, 11: 1})
+	// bytes => GB
+	it = NewScalarTransformIterator(NewFieldIterator(10, field.Sum, generateFloatArray([]float64{2147483648})),
+		stmt.DIV, 1073741824)
+	AssertFieldIt(t, it, map[int]float64{10: 2})
+
+	// division by zero
+	it = newIt(stmt.DIV, 0)
+	for it.HasNext() {
+		_, value := it.Next()
+		assert.True(t, math.IsNaN(value))
+	}
+	// no more data
+	slot, value := it.Next()
+	assert.Equal(t, -1, slot)
+	assert.Equal(t, 0.0, value)
+}
+
+func TestScalarTransformIterator_MarshalBinary(t *testing.T) {
+	it := NewScalarTransformIterator(NewFieldIterator(10, field.Sum, generateFloatArray([]float64{2, 4})), stmt.MUL, 10)
+	data, err := it.MarshalBinary()
+	assert.NoError(t, err)
+	aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Sum, aggType)
+	fIt := series.NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
+	AssertFieldIt(t, fIt, map[int]float64{10: 20, 11: 40})
+}
+
+func TestPlanScalarTransforms(t *testing.T) {
+	plan := func(sqlStr string) map[*stmt.BinaryExpr]scalarTransform {
+		q, err := sql.Parse(sqlStr)
+		assert.NoError(t, err)
+		return planScalarTransforms(q.(*stmt.Query).SelectItems)
+	}
+	getTransform := func(transforms map[*stmt.BinaryExpr]scalarTransform) scalarTransform {
+		assert.Len(t, transforms, 1)
+		for _, transform := range transforms {
+			return transform
+		}
+		return scalarTransform{}
+	}
+	assert.Equal(t, scalarTransform{fieldName: "f", op: stmt.DIV, scalar: 1073741824},
+		getTransform(plan("select f/1073741824 from disk")))
+	assert.Equal(t, scalarTransform{fieldName: "f", op: stmt.MUL, scalar: 8},
+		getTransform(plan("select sum(8*f) as bits from disk")))
+	assert.Equal(t, scalarTransform{fieldName: "f", op: stmt.SUB, scalar: 1},
+		getTransform(plan("select (f-1), g from disk")))
+	// constant op field, only + and * are commutative
+	assert.Nil(t, plan("select 100-f from disk"))
+	assert.Nil(t, plan("select 1/f from disk"))
+	// field is referenced by other expr
+	assert.Nil(t, plan("select f/1024, f from disk"))
+	assert.Nil(t, plan("select f/1024, f*8 from disk"))
+	// not field op constant
+	assert.Nil(t, plan("select f/g, (f+g)*100 from disk"))
+	assert.Nil(t, plan("select f from disk"))
+}
+
+func TestExpression_ScalarTransform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, _ := sql.Parse("select f1/4 as f, f2*10 from cpu")
+	query := q.(*stmt.Query)
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + timeutil.OneHour*2,
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Sum)),
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "f2", field.MinField, field.Min)),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	assert.Len(t, resultSet, 2)
+	value := resultSet["f"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 50.0/4, value.GetValue(50-10))
+	value = resultSet["f2*10.00"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 500.0, value.GetValue(50-10))
+
+	// field not found
+	expression = NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + timeutil.OneHour*2,
+	}, timeutil.OneMinute, query.SelectItems, SkipNaN)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Sum)),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet = expression.ResultSet()
+	assert.Len(t, resultSet, 1)
+	assert.Nil(t, resultSet["f2*10.00"])
+}
@@ -16,8 +16,11 @@ type seriesIterator struct {
 
 // newSeriesIterator creates the time series iterator
 func newSeriesIterator(agg SeriesAggregator) series.Iterator {
-	//TODO need impl set aggs
 	it := &seriesIterator{fieldName: agg.FieldName(), fieldType: agg.GetFieldType()}
+	// down sampling aggregator only loads the data into blocks, no result set
+	if seriesAgg, ok := agg.(*seriesAggregator); ok && !seriesAgg.isDownSampling {
+		it.aggregators = seriesAgg.aggregators
+	}
 	it.len = len(it.aggregators)
 	return it
 }
@@ -0,0 +1,144 @@
+package aggregation
+
+import (
+	"sort"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// segmentFieldIterator represents the field iterator of one segment with the segment start time
+type segmentFieldIterator struct {
+	startTime int64
+	it        series.FieldIterator
+}
+
+// segmentMergedIterator implements series.Iterator, which merges the field iterators of adjacent segments
+// into one continuous field iterator per agg type.
+type segmentMergedIterator struct {
+	it        series.Iterator
+	startTime int64
+	fieldIts  []series.FieldIterator
+	idx       int
+}
+
+// NewSegmentMergedIterator creates a series iterator which merges the field iterators of adjacent segments
+// (like daily segments of the query spanning midnight) into one continuous field iterator per agg type,
+// time slots are rebased on the start time of the earliest segment by interval,
+// the identical time slot of segments' boundary is deduplicated by newest(later segment) wins.
+// NOTE: the given iterator is consumed when creating.
+func NewSegmentMergedIterator(it series.Iterator, interval int64) series.Iterator {
+	if it == nil || interval <= 0 {
+		return it
+	}
+	var segments []segmentFieldIterator
+	for it.HasNext() {
+		startTime, fieldIt := it.Next()
+		if fieldIt == nil {
+			continue
+		}
+		segments = append(segments, segmentFieldIterator{startTime: startTime, it: fieldIt})
+	}
+	merged := &segmentMergedIterator{it: it}
+	switch len(segments) {
+	case 0:
+		return merged
+	case 1:
+		// only one segment, no need to merge
+		merged.startTime = segments[0].startTime
+		merged.fieldIts = []series.FieldIterator{segments[0].it}
+		return merged
+	}
+	// keeps the iteration order for the same start time, the latter is newer
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].startTime < segments[j].startTime
+	})
+	merged.startTime = segments[0].startTime
+	var aggTypes []field.AggType
+	itsOfAggType := make(map[field.AggType][]series.FieldIterator)
+	for _, segment := range segments {
+		aggType := segment.it.AggType()
+		if _, ok := itsOfAggType[aggType]; !ok {
+			aggTypes = append(aggTypes, aggType)
+		}
+		fieldIt := segment.it
+		if offset := int((segment.startTime - merged.startTime) / interval); offset > 0 {
+			fieldIt = &rebasedFieldIterator{it: fieldIt, offset: offset}
+		}
+		itsOfAggType[aggType] = append(itsOfAggType[aggType], fieldIt)
+	}
+	for _, aggType := range aggTypes {
+		merged.fieldIts = append(merged.fieldIts, NewKWayFieldIterator(itsOfAggType[aggType], NewestWins))
+	}
+	return merged
+}
+
+// FieldName returns the field name
+func (s *segmentMergedIterator) FieldName() field.Name {
+	return s.it.FieldName()
+}
+
+// FieldType returns the field type
+func (s *segmentMergedIterator) FieldType() field.Type {
+	return s.it.FieldType()
+}
+
+// HasNext returns if the iteration has more field's iterator
+func (s *segmentMergedIterator) HasNext() bool {
+	return s.idx < len(s.fieldIts)
+}
+
+// Next returns the merged field's iterator and the start time of the earliest segment
+func (s *segmentMergedIterator) Next() (startTime int64, fieldIt series.FieldIterator) {
+	if s.idx >= len(s.fieldIts) {
+		return s.startTime, nil
+	}
+	fieldIt = s.fieldIts[s.idx]
+	s.idx++
+	return s.startTime, fieldIt
+}
+
+// MarshalBinary marshals the data
+func (s *segmentMergedIterator) MarshalBinary() ([]byte, error) {
+	return series.MarshalIterator(s)
+}
+
+// rebasedFieldIterator implements series.FieldIterator, shifts the time slots of field iterator by offset
+type rebasedFieldIterator struct {
+	it     series.FieldIterator
+	offset int
+}
+
+// AggType returns the field's agg type for down sampling.
+func (it *rebasedFieldIterator) AggType() field.AggType {
+	return it.it.AggType()
+}
+
+// HasNext returns if the iteration has more fields
+func (it *rebasedFieldIterator) HasNext() bool {
+	return it.it.HasNext()
+}
+
+// Next returns the data point with shifted time slot in the iteration
+func (it *rebasedFieldIterator) Next() (timeSlot int, value float64) {
+	timeSlot, value = it.it.Next()
+	if timeSlot < 0 {
+		return timeSlot, value
+	}
+	return timeSlot + it.offset, value
+}
+
+// MarshalBinary marshals the remaining data with shifted time slots
+func (it *rebasedFieldIterator) MarshalBinary() ([]byte, error) {
+	return it.marshalWithOption(MarshalOption{})
+}
+
+// marshalWithOption marshals the remaining data with shifted time slots by the marshal option
+func (it *rebasedFieldIterator) marshalWithOption(option MarshalOption) ([]byte, error) {
+	snapshot := NewSafeFieldIterator(it)
+	if snapshot.Len() == 0 {
+		return nil, nil
+	}
+	return marshalFieldIterator(snapshot.slots[0], encoding.XORCodec, snapshot.Iterator(), option)
+}
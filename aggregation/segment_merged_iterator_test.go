@@ -0,0 +1,128 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestSegmentMergedIterator_rollover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// two adjacent segments with interval 10, slot 10 of yesterday is slot 0 of today
+	yesterday := newSlotFieldIterator(field.Sum, []int{8, 9, 10}, []float64{8, 9, 10})
+	today := newSlotFieldIterator(field.Sum, []int{0, 1}, []float64{100, 101})
+	it := series.NewMockIterator(ctrl)
+	gomock.InOrder(
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(100), today),
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(0), yesterday),
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(200), nil),
+		it.EXPECT().HasNext().Return(false),
+	)
+	merged := NewSegmentMergedIterator(it, 10)
+	it.EXPECT().FieldName().Return(field.Name("f"))
+	it.EXPECT().FieldType().Return(field.SumField)
+	assert.Equal(t, field.Name("f"), merged.FieldName())
+	assert.Equal(t, field.SumField, merged.FieldType())
+	assert.True(t, merged.HasNext())
+	startTime, fieldIt := merged.Next()
+	assert.Equal(t, int64(0), startTime)
+	assert.Equal(t, field.Sum, fieldIt.AggType())
+	slots, values := readFieldIterator(fieldIt)
+	// boundary slot is deduplicated by newest wins
+	assert.Equal(t, []int{8, 9, 10, 11}, slots)
+	assert.Equal(t, []float64{8, 9, 100, 101}, values)
+	assert.False(t, merged.HasNext())
+	startTime, fieldIt = merged.Next()
+	assert.Equal(t, int64(0), startTime)
+	assert.Nil(t, fieldIt)
+}
+
+func TestSegmentMergedIterator_aggTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	it := series.NewMockIterator(ctrl)
+	gomock.InOrder(
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(0), newSlotFieldIterator(field.Sum, []int{1}, []float64{1})),
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(0), newSlotFieldIterator(field.Count, []int{1}, []float64{2})),
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(100), newSlotFieldIterator(field.Sum, []int{1}, []float64{3})),
+		it.EXPECT().HasNext().Return(false),
+	)
+	// merges the iterators of same agg type
+	merged := NewSegmentMergedIterator(it, 10)
+	var aggTypes []field.AggType
+	for merged.HasNext() {
+		_, fieldIt := merged.Next()
+		aggTypes = append(aggTypes, fieldIt.AggType())
+		slots, _ := readFieldIterator(fieldIt)
+		if fieldIt.AggType() == field.Sum {
+			assert.Equal(t, []int{1, 11}, slots)
+		} else {
+			assert.Equal(t, []int{1}, slots)
+		}
+	}
+	assert.Equal(t, []field.AggType{field.Sum, field.Count}, aggTypes)
+}
+
+func TestSegmentMergedIterator_empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	assert.Nil(t, NewSegmentMergedIterator(nil, 10))
+	it := series.NewMockIterator(ctrl)
+	// invalid interval, returns the iterator directly
+	assert.Equal(t, it, NewSegmentMergedIterator(it, 0))
+	it.EXPECT().HasNext().Return(false)
+	merged := NewSegmentMergedIterator(it, 10)
+	assert.False(t, merged.HasNext())
+}
+
+func TestSegmentMergedIterator_MarshalBinary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	it := series.NewMockIterator(ctrl)
+	gomock.InOrder(
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(100), newSlotFieldIterator(field.Sum, []int{0, 1}, []float64{100, 101})),
+		it.EXPECT().HasNext().Return(false),
+	)
+	merged := NewSegmentMergedIterator(it, 10)
+	_, fieldIt := merged.Next()
+	// only one segment, not rebased
+	slots, values := decodeFieldData(t, mustMarshal(t, fieldIt))
+	assert.Equal(t, []int{0, 1}, slots)
+	assert.Equal(t, []float64{100, 101}, values)
+
+	rebased := &rebasedFieldIterator{it: newSlotFieldIterator(field.Sum, []int{0, 1}, []float64{100, 101}), offset: 10}
+	slots, values = decodeFieldData(t, mustMarshal(t, rebased))
+	assert.Equal(t, []int{10, 11}, slots)
+	assert.Equal(t, []float64{100, 101}, values)
+	// no more data
+	data, err := rebased.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	it.EXPECT().FieldType().Return(field.SumField)
+	data, err = merged.MarshalBinary()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, data)
+}
+
+func mustMarshal(t *testing.T, it series.FieldIterator) []byte {
+	data, err := it.MarshalBinary()
+	assert.NoError(t, err)
+	return data
+}
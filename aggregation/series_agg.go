@@ -1,8 +1,10 @@
 package aggregation
 
 import (
+	"fmt"
 	"sort"
 
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/aggregation/selector"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
@@ -22,6 +24,23 @@ func (agg FieldAggregates) ResultSet(tags string) series.GroupedIterator {
 	return newGroupedIterator(tags, agg)
 }
 
+// Merge merges the partial state of other field aggregates into current aggregates by field name,
+// returns error if the series aggregators of same field cannot be merged.
+func (agg FieldAggregates) Merge(other FieldAggregates) error {
+	for _, otherAgg := range other {
+		for _, aggregator := range agg {
+			if aggregator.FieldName() != otherAgg.FieldName() {
+				continue
+			}
+			if err := aggregator.Merge(otherAgg); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
 // Reset resets the aggregator's context for reusing
 func (agg FieldAggregates) Reset() {
 	for _, aggregator := range agg {
@@ -62,6 +81,8 @@ type SeriesAggregator interface {
 	GetAggregateBlock(segmentStartTime int64) (series.Block, bool)
 	// ResultSet returns the result set of series aggregator
 	ResultSet() series.Iterator
+	// Merge merges other series aggregator's partial state of same field into current aggregator
+	Merge(other SeriesAggregator) error
 	// Reset resets the aggregator's context for reusing
 	Reset()
 }
@@ -71,16 +92,22 @@ type seriesAggregator struct {
 	fieldType      field.Type
 	ratio          int
 	isDownSampling bool
-	aggregator     FieldAggregator
+	aggregator     FieldAggregator // loads the data of storage into blocks for down sampling
+	aggTypes       []field.AggType
+	aggregators    []FieldAggregator // field aggregator of each agg type, aligned with agg types
 	queryInterval  timeutil.Interval
 	queryTimeRange timeutil.TimeRange
 	aggSpec        AggregatorSpec
 	calc           timeutil.Calculator
 
 	startTime int64
+	startSlot int // time slot of query start time based on start time by query interval
+	endSlot   int // time slot of query end time based on start time by query interval
 }
 
-// NewSeriesAggregator creates a series aggregator
+// NewSeriesAggregator creates a series aggregator,
+// if not down sampling, the time slot of field series is based on the family start time of query start time
+// by query interval, which spans all families of query time range.
 func NewSeriesAggregator(
 	queryInterval timeutil.Interval,
 	ratio int,
@@ -92,7 +119,6 @@ func NewSeriesAggregator(
 	segmentTime := calc.CalcSegmentTime(queryTimeRange.Start)
 	startTime := calc.CalcFamilyStartTime(segmentTime, calc.CalcFamily(queryTimeRange.Start, segmentTime))
 
-	length := calc.CalcTimeWindows(queryTimeRange.Start, queryTimeRange.End)
 	agg := &seriesAggregator{
 		fieldName:      aggSpec.FieldName(),
 		fieldType:      aggSpec.GetFieldType(),
@@ -105,14 +131,23 @@ func NewSeriesAggregator(
 		aggSpec:        aggSpec,
 	}
 	if isDownSampling {
+		length := calc.CalcTimeWindows(queryTimeRange.Start, queryTimeRange.End)
 		agg.aggregator = NewDownSamplingFieldAggregator(aggSpec, length)
-	} else {
-		//TODO need impl
-		agg.aggregator = NewFieldAggregator(startTime, selector.NewIndexSlotSelector(0, 10, 1))
+		return agg
 	}
+	// clips the slots out of query time range
+	agg.startSlot = agg.slotOf(queryTimeRange.Start)
+	agg.endSlot = agg.slotOf(queryTimeRange.End)
 	return agg
 }
 
+// isIdentitySpec checks if the aggregator spec only has identity function for querying raw data points
+func isIdentitySpec(aggSpec AggregatorSpec) bool {
+	functions := aggSpec.Functions()
+	_, ok := functions[function.Identity]
+	return ok && len(functions) == 1
+}
+
 // FieldName returns field name
 func (a *seriesAggregator) FieldName() field.Name {
 	return a.fieldName
@@ -128,37 +163,102 @@ func (a *seriesAggregator) SetFieldType(fieldType field.Type) {
 	a.fieldType = fieldType
 }
 
-// Aggregators returns all field aggregates
-//func (a *seriesAggregator) Aggregators() []FieldAggregator {
-//	return a.aggregates
-//}
-
 // ResultSet returns the result set of series aggregator
 func (a *seriesAggregator) ResultSet() series.Iterator {
-	//if len(a.aggregates) == 0 {
-	//	return nil
-	//}
 	return newSeriesIterator(a)
 }
 
+// Merge merges other series aggregator's partial state of same field into current aggregator,
+// the partial state of each agg type is merged into the field aggregator of same agg type.
+func (a *seriesAggregator) Merge(other SeriesAggregator) error {
+	o, ok := other.(*seriesAggregator)
+	if !ok {
+		return fmt.Errorf("cannot merge series aggregator with type: %T", other)
+	}
+	if a.fieldName != o.fieldName {
+		return fmt.Errorf("cannot merge series aggregator of field: %s with field: %s", a.fieldName, o.fieldName)
+	}
+	if a.fieldType == field.Unknown || a.fieldType == 0 {
+		a.fieldType = o.fieldType
+	}
+	for idx, aggType := range o.aggTypes {
+		if err := a.getAggregator(aggType).Merge(o.aggregators[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// aggregate aggregates the field series of segment into the field aggregator of agg type,
+// the time slots of field series are rebased on the start time of aggregator.
+func (a *seriesAggregator) aggregate(segmentStartTime int64, it series.FieldIterator) {
+	aggType := it.AggType()
+	if segmentStartTime != a.startTime {
+		offset := int((segmentStartTime - a.startTime) / a.queryInterval.Int64())
+		if offset < 0 || aggType.IsState() {
+			// the time slots of partial state cannot be rebased, the field series before start time is ignored
+			return
+		}
+		it = &rebasedFieldIterator{it: it, offset: offset}
+	}
+	a.getAggregator(aggType).Aggregate(it)
+}
+
+// getAggregator returns the field aggregator of agg type, if not exist creates it based on agg type and spec
+func (a *seriesAggregator) getAggregator(aggType field.AggType) FieldAggregator {
+	for idx, t := range a.aggTypes {
+		if t == aggType {
+			return a.aggregators[idx]
+		}
+	}
+	slotSelector := selector.NewIndexSlotSelector(a.startSlot, a.endSlot, 1)
+	var agg FieldAggregator
+	switch {
+	case aggType.IsState():
+		agg = newStateFieldAggregator(a.startTime, aggType, a.startSlot, a.endSlot)
+	case aggType == field.Sample:
+		// samples every Nth data point by the factor of spec, passes through all points if factor not set(broker side)
+		agg = NewSampleFieldAggregator(a.startTime, slotSelector, int(a.aggSpec.FunctionParam(function.Sample)))
+	case isIdentitySpec(a.aggSpec):
+		// raw data points query
+		agg = NewIdentityFieldAggregator(a.startTime, slotSelector)
+	default:
+		agg = NewFieldAggregator(a.startTime, slotSelector)
+	}
+	a.aggTypes = append(a.aggTypes, aggType)
+	a.aggregators = append(a.aggregators, agg)
+	return agg
+}
+
+// forEachLoaded iterates the data points loaded from storage in time order for down sampling
+func (a *seriesAggregator) forEachLoaded(fn func(timestamp int64, value float64)) {
+	if agg, ok := a.aggregator.(*downSamplingFieldAggregator); ok {
+		agg.forEach(fn)
+	}
+}
+
+// slotOf returns the time slot of timestamp based on start time by query interval
+func (a *seriesAggregator) slotOf(timestamp int64) int {
+	return int((timestamp - a.startTime) / a.queryInterval.Int64())
+}
+
 // Reset resets the aggregator's context for reusing
 func (a *seriesAggregator) Reset() {
-	a.aggregator.reset()
-	//for _, aggregator := range a.aggregates {
-	//	if aggregator == nil {
-	//		continue
-	//	}
-	//	aggregator.reset()
-	//}
+	if a.aggregator != nil {
+		a.aggregator.reset()
+	}
+	for _, aggregator := range a.aggregators {
+		aggregator.reset()
+	}
 }
 
 // GetAggregator gets field aggregator by segment start time, if not exist return (nil,false).
 func (a *seriesAggregator) GetAggregateBlock(segmentStartTime int64) (agg series.Block, ok bool) {
-	if segmentStartTime < a.startTime {
+	if a.aggregator == nil || segmentStartTime < a.startTime {
 		return
 	}
 	idx := a.calc.CalcTimeWindows(a.startTime, segmentStartTime) - 1
-	return a.aggregator.GetBlock(idx, func() series.Block {
+	agg, ok = a.aggregator.GetBlock(idx, func() series.Block {
 		storageTimeRange := &timeutil.TimeRange{
 			Start: segmentStartTime,
 			End:   a.calc.CalcFamilyEndTime(segmentStartTime),
@@ -167,6 +267,13 @@ func (a *seriesAggregator) GetAggregateBlock(segmentStartTime int64) (agg series
 		storageInterval := a.queryInterval.Int64() / int64(a.ratio)
 		startIdx := a.calc.CalcSlot(timeRange.Start, segmentStartTime, storageInterval)
 		endIdx := a.calc.CalcSlot(timeRange.End, segmentStartTime, storageInterval) + 1
-		return series.NewBlock(startIdx, endIdx)
+		return newDownSamplingBlock(segmentStartTime, storageInterval, startIdx, endIdx)
 	})
+	if ok {
+		if filter := a.aggSpec.ValueFilter(); filter != nil {
+			// drops the data points not matched by value predicate before aggregation
+			agg = series.NewFilteredBlock(agg, filter)
+		}
+	}
+	return agg, ok
 }
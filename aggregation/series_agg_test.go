@@ -3,8 +3,10 @@ package aggregation
 import (
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series/field"
 )
@@ -116,3 +118,53 @@ func TestNewFieldAggregates(t *testing.T) {
 //	rs = agg.ResultSet()
 //	assert.Nil(t, rs)
 //}
+
+func TestSeriesAggregator_Merge(t *testing.T) {
+	now, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	timeRange := timeutil.TimeRange{Start: now + 30*timeutil.OneSecond, End: now + 3*timeutil.OneMinute}
+	newAgg := func(fieldName field.Name, values ...[]float64) FieldAggregates {
+		aggSpec := NewDownSamplingSpec(fieldName, field.SumField)
+		aggSpec.AddFunctionType(function.Sum)
+		agg := NewFieldAggregates(timeutil.Interval(timeutil.OneSecond*10), 1, timeRange, false, AggregatorSpecs{aggSpec})
+		for _, vs := range values {
+			// slots before 10:00:30 are clipped
+			agg[0].(*seriesAggregator).aggregate(now, NewFieldIterator(0, field.Sum, generateFloatArray(vs)))
+		}
+		return agg
+	}
+	agg := newAgg("f", []float64{1, 2, 3, 4, 5})
+	assert.NoError(t, agg.Merge(newAgg("f", []float64{1, 1, 1, 1})))
+	// other field is ignored
+	assert.NoError(t, agg.Merge(newAgg("g", []float64{1, 1, 1, 1})))
+	rs := agg[0].ResultSet()
+	assert.True(t, rs.HasNext())
+	startTime, it := rs.Next()
+	assert.Equal(t, now, startTime)
+	AssertFieldIt(t, it, map[int]float64{3: 5, 4: 5})
+	assert.False(t, rs.HasNext())
+
+	// field series of other segment is rebased, the slots out of query time range are clipped
+	agg[0].(*seriesAggregator).aggregate(now+timeutil.OneHour, NewFieldIterator(3, field.Sum, generateFloatArray([]float64{1})))
+	agg[0].(*seriesAggregator).aggregate(now-timeutil.OneHour, NewFieldIterator(3, field.Sum, generateFloatArray([]float64{1})))
+	rs = agg[0].ResultSet()
+	assert.True(t, rs.HasNext())
+	_, it = rs.Next()
+	AssertFieldIt(t, it, map[int]float64{3: 5, 4: 5})
+
+	// cannot merge
+	assert.Error(t, agg[0].Merge(newAgg("g")[0]))
+	assert.Error(t, agg[0].Merge(NewMockSeriesAggregator(gomock.NewController(t))))
+	// partial state of other agg type is merged into own field aggregator
+	other := newAgg("f")
+	other[0].(*seriesAggregator).aggregate(now, NewFieldIterator(3, field.Max, generateFloatArray([]float64{1})))
+	assert.NoError(t, agg.Merge(other))
+	rs = agg[0].ResultSet()
+	assert.True(t, rs.HasNext())
+	_, it = rs.Next()
+	assert.Equal(t, field.Sum, it.AggType())
+	assert.True(t, rs.HasNext())
+	_, it = rs.Next()
+	assert.Equal(t, field.Max, it.AggType())
+	AssertFieldIt(t, it, map[int]float64{3: 1})
+	assert.False(t, rs.HasNext())
+}
@@ -1,7 +1,10 @@
 package aggregation
 
 import (
+	"fmt"
+
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 )
 
@@ -13,12 +16,18 @@ type AggregatorSpec interface {
 	SetFieldType(fieldType field.Type)
 	AddFunctionType(funcType function.FuncType)
 	Functions() map[function.FuncType]function.FuncType
+	SetFunctionParam(funcType function.FuncType, param int64) error
+	FunctionParam(funcType function.FuncType) int64
+	SetValueFilter(filter series.ValueFilter)
+	ValueFilter() series.ValueFilter
 }
 
 type aggregatorSpec struct {
-	fieldName field.Name
-	fieldType field.Type
-	functions map[function.FuncType]function.FuncType
+	fieldName   field.Name
+	fieldType   field.Type
+	functions   map[function.FuncType]function.FuncType
+	params      map[function.FuncType]int64 // constant param of function, like factor of sample
+	valueFilter series.ValueFilter
 }
 
 func NewAggregatorSpec(fieldName field.Name) AggregatorSpec {
@@ -58,3 +67,28 @@ func (a *aggregatorSpec) AddFunctionType(funcType function.FuncType) {
 func (a *aggregatorSpec) Functions() map[function.FuncType]function.FuncType {
 	return a.functions
 }
+
+// SetFunctionParam sets the constant param of function, returns error if the function has different param
+func (a *aggregatorSpec) SetFunctionParam(funcType function.FuncType, param int64) error {
+	if a.params == nil {
+		a.params = make(map[function.FuncType]int64)
+	}
+	if old, exist := a.params[funcType]; exist && old != param {
+		return fmt.Errorf("function[%s] of field[%s] has conflicting params: %d and %d", funcType, a.fieldName, old, param)
+	}
+	a.params[funcType] = param
+	return nil
+}
+
+// FunctionParam returns the constant param of function, returns 0 if not set
+func (a *aggregatorSpec) FunctionParam(funcType function.FuncType) int64 {
+	return a.params[funcType]
+}
+
+func (a *aggregatorSpec) SetValueFilter(filter series.ValueFilter) {
+	a.valueFilter = filter
+}
+
+func (a *aggregatorSpec) ValueFilter() series.ValueFilter {
+	return a.valueFilter
+}
@@ -26,3 +26,13 @@ func TestAggregatorSpec_AddFunctionType(t *testing.T) {
 	agg.SetFieldType(field.SumField)
 	assert.Equal(t, field.SumField, agg.GetFieldType())
 }
+
+func TestAggregatorSpec_FunctionParam(t *testing.T) {
+	agg := NewDownSamplingSpec("f1", field.SumField)
+	assert.Equal(t, int64(0), agg.FunctionParam(function.Sample))
+	assert.NoError(t, agg.SetFunctionParam(function.Sample, 3))
+	assert.NoError(t, agg.SetFunctionParam(function.Sample, 3))
+	assert.Equal(t, int64(3), agg.FunctionParam(function.Sample))
+	assert.Error(t, agg.SetFunctionParam(function.Sample, 5))
+	assert.Equal(t, int64(3), agg.FunctionParam(function.Sample))
+}
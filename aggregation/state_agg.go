@@ -0,0 +1,196 @@
+package aggregation
+
+import (
+	"fmt"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/hll"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+var stateAggLogger = logger.GetLogger("aggregation", "StateAggregator")
+
+// partialState represents the partial state of aggregator which cannot be folded by the value of time slot,
+// the state is serialized for merging across segments, shards and nodes.
+type partialState interface {
+	// ResultSet returns the final value of each time slot
+	ResultSet() collections.FloatArray
+	// MarshalBinary marshals the partial state
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary unmarshals the partial state, then merges it into current state
+	UnmarshalBinary(data []byte) error
+	// Reset resets the state for reusing
+	Reset()
+}
+
+// newPartialState creates the partial state by agg type with the time slots of query time range,
+// returns nil if not state agg type
+func newPartialState(aggType field.AggType, startSlot, endSlot int) partialState {
+	capacity := endSlot + 1
+	switch aggType {
+	case field.DistinctCount:
+		// default precision is valid
+		state, _ := NewDistinctCountAggregator(capacity, hll.DefaultPrecision)
+		return state
+	case field.MaxTime:
+		return NewMaxTimeAggregator(capacity)
+	case field.MinTime:
+		return NewMinTimeAggregator(capacity)
+	case field.CountAll:
+		return &countAllState{CountAggregator: NewCountAggregator(capacity), startSlot: startSlot}
+	default:
+		return nil
+	}
+}
+
+// stateFieldAggregator implements field aggregator interface, merges the partial states carried by state field
+// iterators(see series.StateFieldIterator), the time slot of state is based on segment start time.
+type stateFieldAggregator struct {
+	segmentStartTime int64
+	aggType          field.AggType
+	state            partialState
+}
+
+// newStateFieldAggregator creates the field aggregator which merges the partial state of agg type,
+// the time slots of query time range are [startSlot, endSlot] based on segment start time.
+func newStateFieldAggregator(segmentStartTime int64, aggType field.AggType, startSlot, endSlot int) FieldAggregator {
+	return &stateFieldAggregator{
+		segmentStartTime: segmentStartTime,
+		aggType:          aggType,
+		state:            newPartialState(aggType, startSlot, endSlot),
+	}
+}
+
+// Aggregate merges the partial state of state field iterator, the field iterator without state is ignored
+func (a *stateFieldAggregator) Aggregate(it series.FieldIterator) {
+	stateIt, ok := it.(series.StateFieldIterator)
+	if !ok || a.state == nil {
+		return
+	}
+	if err := a.state.UnmarshalBinary(stateIt.State()); err != nil {
+		stateAggLogger.Warn("merge partial state of aggregator failure, drop it",
+			logger.Any("aggType", a.aggType), logger.Error(err))
+	}
+}
+
+// add adds the data point into the partial state of time slot, which is down sampled from storage
+func (a *stateFieldAggregator) add(slot int, timestamp int64, value float64) {
+	switch state := a.state.(type) {
+	case DistinctCountAggregator:
+		state.Aggregate(slot, value)
+	case ExtremumTimeAggregator:
+		state.Aggregate(slot, timestamp, value)
+	case *countAllState:
+		state.Aggregate(slot, value)
+	}
+}
+
+// Merge merges the partial state of other state aggregator which has same agg type
+func (a *stateFieldAggregator) Merge(other FieldAggregator) error {
+	o, ok := other.(*stateFieldAggregator)
+	if !ok {
+		return fmt.Errorf("cannot merge state field aggregator with type: %T", other)
+	}
+	if a.aggType != o.aggType || a.segmentStartTime != o.segmentStartTime {
+		return fmt.Errorf("cannot merge state field aggregator with different agg type or time range")
+	}
+	if a.state == nil {
+		return nil
+	}
+	data, err := o.state.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return a.state.UnmarshalBinary(data)
+}
+
+// GetBlock returns nil, because state aggregator doesn't load data into block
+func (a *stateFieldAggregator) GetBlock(idx int, fn newBlockFunc) (series.Block, bool) {
+	return nil, false
+}
+
+// ResultSet returns the state field iterator, which iterates the final values and carries the partial state
+func (a *stateFieldAggregator) ResultSet() (startTime int64, it series.FieldIterator) {
+	if a.state == nil {
+		return a.segmentStartTime, nil
+	}
+	values := a.state.ResultSet()
+	if values.IsEmpty() {
+		return a.segmentStartTime, nil
+	}
+	state, err := a.state.MarshalBinary()
+	if err != nil {
+		stateAggLogger.Warn("marshal partial state of aggregator failure",
+			logger.Any("aggType", a.aggType), logger.Error(err))
+		return a.segmentStartTime, nil
+	}
+	return a.segmentStartTime, newStateFieldIterator(a.aggType, values, state)
+}
+
+// reset resets the aggregate context for reusing
+func (a *stateFieldAggregator) reset() {
+	if a.state != nil {
+		a.state.Reset()
+	}
+}
+
+// stateFieldIterator implements series.StateFieldIterator interface,
+// iterates the final values of time slots, marshals the partial state for merging by upstream.
+type stateFieldIterator struct {
+	aggType field.AggType
+	it      collections.FloatArrayIterator
+	state   []byte
+}
+
+// newStateFieldIterator creates the state field iterator with final values and the serialized partial state
+func newStateFieldIterator(aggType field.AggType, values collections.FloatArray, state []byte) series.FieldIterator {
+	return &stateFieldIterator{
+		aggType: aggType,
+		it:      values.Iterator(),
+		state:   state,
+	}
+}
+
+// AggType returns the agg type of partial state
+func (it *stateFieldIterator) AggType() field.AggType {
+	return it.aggType
+}
+
+// HasNext returns if the iteration has more final values
+func (it *stateFieldIterator) HasNext() bool {
+	return it.it.HasNext()
+}
+
+// Next returns the final value of time slot
+func (it *stateFieldIterator) Next() (timeSlot int, value float64) {
+	return it.it.Next()
+}
+
+// State returns the serialized partial state
+func (it *stateFieldIterator) State() []byte {
+	return it.state
+}
+
+// MarshalBinary marshals the partial state as one field block
+func (it *stateFieldIterator) MarshalBinary() ([]byte, error) {
+	return series.MarshalFieldBlocks(it.aggType, [][]byte{it.state})
+}
+
+// countAllState represents the partial state of count_all, which is the counts of count aggregator,
+// the time slots in query time range without data point are counted as 0.
+type countAllState struct {
+	CountAggregator
+	startSlot int
+}
+
+// ResultSet returns the count of all data points of each time slot in query time range
+func (s *countAllState) ResultSet() collections.FloatArray {
+	countAll := s.CountAll()
+	result := collections.NewFloatArray(countAll.Capacity())
+	for slot := s.startSlot; slot < countAll.Capacity(); slot++ {
+		result.SetValue(slot, countAll.GetValue(slot))
+	}
+	return result
+}
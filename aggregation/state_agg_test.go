@@ -0,0 +1,119 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/selector"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestNewPartialState(t *testing.T) {
+	assert.NotNil(t, newPartialState(field.DistinctCount, 0, 9))
+	assert.NotNil(t, newPartialState(field.MaxTime, 0, 9))
+	assert.NotNil(t, newPartialState(field.MinTime, 0, 9))
+	assert.NotNil(t, newPartialState(field.CountAll, 0, 9))
+	assert.Nil(t, newPartialState(field.Sum, 0, 9))
+}
+
+func TestCountAllState(t *testing.T) {
+	agg := newStateFieldAggregator(familyTime, field.CountAll, 1, 3).(*stateFieldAggregator)
+	agg.add(1, familyTime, 1)
+	agg.add(1, familyTime, 2)
+	agg.add(3, familyTime, math.NaN())
+	_, it := agg.ResultSet()
+	result := make(map[int]float64)
+	for it.HasNext() {
+		slot, value := it.Next()
+		result[slot] = value
+	}
+	// the time slots before start slot are out of query time range
+	assert.Equal(t, map[int]float64{1: 2, 2: 0, 3: 1}, result)
+}
+
+func TestStateFieldAggregator_Aggregate(t *testing.T) {
+	agg := newStateFieldAggregator(familyTime, field.DistinctCount, 0, 9).(*stateFieldAggregator)
+	_, it := agg.ResultSet()
+	assert.Nil(t, it)
+	for i := 0; i < 100; i++ {
+		agg.add(0, familyTime, float64(i))
+		agg.add(3, familyTime, float64(i%10))
+	}
+	block, ok := agg.GetBlock(0, nil)
+	assert.False(t, ok)
+	assert.Nil(t, block)
+
+	startTime, it := agg.ResultSet()
+	assert.Equal(t, familyTime, startTime)
+	assert.Equal(t, field.DistinctCount, it.AggType())
+	stateIt, ok := it.(series.StateFieldIterator)
+	assert.True(t, ok)
+	data, err := stateIt.MarshalBinary()
+	assert.NoError(t, err)
+	aggType, blocks, err := series.UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	assert.Equal(t, field.DistinctCount, aggType)
+	assert.Equal(t, [][]byte{stateIt.State()}, blocks)
+	assert.True(t, it.HasNext())
+	slot, value := it.Next()
+	assert.Equal(t, 0, slot)
+	assertDistinctCount(t, 100, value)
+	assert.True(t, it.HasNext())
+	slot, value = it.Next()
+	assert.Equal(t, 3, slot)
+	assertDistinctCount(t, 10, value)
+	assert.False(t, it.HasNext())
+
+	// merges the partial state of state field iterator
+	other := newStateFieldAggregator(familyTime, field.DistinctCount, 0, 9)
+	_, it = agg.ResultSet()
+	other.Aggregate(it)
+	// field iterator without state is ignored
+	other.Aggregate(NewFieldIterator(0, field.Sum, generateFloatArray([]float64{1000})))
+	// corrupted state is dropped
+	other.Aggregate(&stateFieldIterator{aggType: field.DistinctCount, state: []byte{1, 2, 3}})
+	_, it = other.ResultSet()
+	assertDistinctCountIt(t, it, map[int]int{0: 100, 3: 10})
+
+	agg.reset()
+	_, it = agg.ResultSet()
+	assert.Nil(t, it)
+}
+
+func TestStateFieldAggregator_Merge(t *testing.T) {
+	agg := newStateFieldAggregator(familyTime, field.DistinctCount, 0, 9)
+	other := newStateFieldAggregator(familyTime, field.DistinctCount, 0, 9).(*stateFieldAggregator)
+	for i := 0; i < 100; i++ {
+		other.add(1, familyTime, float64(i))
+	}
+	assert.NoError(t, agg.Merge(other))
+	assert.NoError(t, agg.Merge(other))
+	_, it := agg.ResultSet()
+	assertDistinctCountIt(t, it, map[int]int{1: 100})
+
+	// cannot merge
+	assert.Error(t, agg.Merge(NewFieldAggregator(familyTime, selector.NewIndexSlotSelector(0, 10, 1))))
+	assert.Error(t, agg.Merge(newStateFieldAggregator(familyTime+1, field.DistinctCount, 0, 9)))
+
+	// no partial state for agg type
+	agg = newStateFieldAggregator(familyTime, field.Sum, 0, 9)
+	agg.Aggregate(&stateFieldIterator{aggType: field.Sum})
+	assert.NoError(t, agg.Merge(newStateFieldAggregator(familyTime, field.Sum, 0, 9)))
+	_, it = agg.ResultSet()
+	assert.Nil(t, it)
+	agg.reset()
+}
+
+// assertDistinctCountIt asserts the estimates of time slots
+func assertDistinctCountIt(t *testing.T, it series.FieldIterator, expect map[int]int) {
+	count := 0
+	for it.HasNext() {
+		slot, value := it.Next()
+		assertDistinctCount(t, expect[slot], value)
+		count++
+	}
+	assert.Equal(t, len(expect), count)
+}
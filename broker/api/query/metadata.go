@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/lindb/lindb/broker/api"
@@ -33,12 +34,14 @@ type MetadataAPI struct {
 	nodeStateMachine    broker.NodeStateMachine
 	executorFactory     parallel.ExecutorFactory
 	jobManager          parallel.JobManager
+	parserConfig        sql.ParserConfig
 }
 
 // NewDatabaseAPI creates database api instance
 func NewMetadataAPI(databaseService service.DatabaseService,
 	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
 	executorFactory parallel.ExecutorFactory, jobManager parallel.JobManager,
+	parserConfig sql.ParserConfig,
 ) *MetadataAPI {
 	return &MetadataAPI{
 		databaseService:     databaseService,
@@ -46,6 +49,7 @@ func NewMetadataAPI(databaseService service.DatabaseService,
 		nodeStateMachine:    nodeStateMachine,
 		executorFactory:     executorFactory,
 		jobManager:          jobManager,
+		parserConfig:        parserConfig,
 	}
 }
 
@@ -56,7 +60,7 @@ func (d *MetadataAPI) Handle(w http.ResponseWriter, r *http.Request) {
 		api.Error(w, err)
 		return
 	}
-	metaQuery, err := parseSQLFunc(ql)
+	metaQuery, err := parseSQLFunc(ql, d.parserConfig)
 	if err != nil {
 		api.Error(w, err)
 		return
@@ -128,6 +132,10 @@ func (d *MetadataAPI) suggest(w http.ResponseWriter, database string, request *s
 				Type: f.Type.String(),
 			})
 		}
+		// fields are merged from storage nodes, sorts by name for stable output
+		sort.Slice(resultFields, func(i, j int) bool {
+			return resultFields[i].Name < resultFields[j].Name
+		})
 		api.OK(w, &models.Metadata{
 			Type:   request.Type.String(),
 			Values: resultFields,
@@ -140,9 +148,9 @@ func (d *MetadataAPI) suggest(w http.ResponseWriter, database string, request *s
 	}
 }
 
-// parseSQL parses metadata query sql
-func parseSQL(ql string) (*stmt.Metadata, error) {
-	query, err := sql.Parse(ql)
+// parseSQL parses metadata query sql with parser config
+func parseSQL(ql string, cfg sql.ParserConfig) (*stmt.Metadata, error) {
+	query, err := sql.ParseWithConfig(ql, nil, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
@@ -24,7 +25,7 @@ func TestMetadataAPI_Handle_err(t *testing.T) {
 		ctrl.Finish()
 	}()
 
-	api := NewMetadataAPI(nil, nil, nil, nil, nil)
+	api := NewMetadataAPI(nil, nil, nil, nil, nil, sql.ParserConfig{})
 
 	// case 1: database name not input
 	mock.DoRequest(t, &mock.HTTPHandler{
@@ -51,7 +52,7 @@ func TestMetadataAPI_Handle_err(t *testing.T) {
 		RequestBody:    []string{},
 	})
 	// case 4: unknown metadata type
-	parseSQLFunc = func(ql string) (*stmt.Metadata, error) {
+	parseSQLFunc = func(ql string, _ sql.ParserConfig) (*stmt.Metadata, error) {
 		return &stmt.Metadata{}, nil
 	}
 	mock.DoRequest(t, &mock.HTTPHandler{
@@ -68,7 +69,7 @@ func TestMetadataAPI_ShowDatabases(t *testing.T) {
 	defer ctrl.Finish()
 
 	databaseService := service.NewMockDatabaseService(ctrl)
-	api := NewMetadataAPI(databaseService, nil, nil, nil, nil)
+	api := NewMetadataAPI(databaseService, nil, nil, nil, nil, sql.ParserConfig{})
 
 	databaseService.EXPECT().List().Return(nil, nil)
 	mock.DoRequest(t, &mock.HTTPHandler{
@@ -115,7 +116,7 @@ func TestMetadataAPI_SuggestCommon(t *testing.T) {
 	factory.EXPECT().NewMetadataBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
 		gomock.Any(), gomock.Any(), gomock.Any()).Return(exec).AnyTimes()
 
-	api := NewMetadataAPI(nil, nil, nil, factory, nil)
+	api := NewMetadataAPI(nil, nil, nil, factory, nil, sql.ParserConfig{})
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodGet,
 		URL:            "/query/metadata?sql=show namespaces",
@@ -156,4 +157,19 @@ func TestMetadataAPI_SuggestCommon(t *testing.T) {
 		HandlerFunc:    api.Handle,
 		ExpectHTTPCode: 200,
 	})
+	// fields of storage nodes are merged, returns name and type sorted by name
+	exec.EXPECT().Execute().Return([]string{
+		string(encoding.JSONMarshal(&[]field.Meta{{Name: "usage", Type: field.GaugeField}, {Name: "count", Type: field.SumField}})),
+		string(encoding.JSONMarshal(&[]field.Meta{{Name: "count", Type: field.SumField}})),
+	}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/metadata?db=db1&sql=show fields from cpu",
+		HandlerFunc:    api.Handle,
+		ExpectHTTPCode: 200,
+		ExpectResponse: &models.Metadata{
+			Type:   stmt.Field.String(),
+			Values: []models.Field{{Name: "count", Type: "sum"}, {Name: "usage", Type: "gauge"}},
+		},
+	})
 }
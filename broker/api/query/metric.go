@@ -1,14 +1,18 @@
 package query
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/lindb/lindb/broker/api"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
 )
 
@@ -46,11 +50,41 @@ func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 		api.Error(w, err)
 		return
 	}
+	// absolute time literals of sql are interpreted in the time zone(like America/New_York), default local zone
+	tz, err := api.GetParamsFromRequest("tz", r, "", false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	var location *time.Location
+	if tz != "" {
+		if location, err = time.LoadLocation(tz); err != nil {
+			api.Error(w, err)
+			return
+		}
+	}
+	// sample_series(rate) queries only the fraction of matched series, rate in (0, 1], default no sampling
+	sampleRate, err := getSampleRate(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	// format of result set(json/csv/line), default json
+	format, err := api.GetParamsFromRequest("format", r, models.JSONFormat, false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	formatter, err := models.NewResultFormatter(format)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
 	//FIXME add timeout cfg
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
 	defer cancel()
 
-	exec := m.executorFactory.NewBrokerExecutor(ctx, db, sql,
+	exec := m.executorFactory.NewBrokerExecutor(ctx, db, sql, location, sampleRate,
 		m.replicaStateMachine, m.nodeStateMachine, m.databaseStateMachine,
 		m.jobManager)
 	exec.Execute()
@@ -69,5 +103,23 @@ func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 		api.Error(w, err)
 		return
 	}
-	api.OK(w, resultSet)
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, resultSet); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OKWithContentType(w, formatter.ContentType(), buf.Bytes())
+}
+
+// getSampleRate returns the sample rate of matched series from request, 0 means no sampling
+func getSampleRate(r *http.Request) (float64, error) {
+	rate, err := api.GetParamsFromRequest("sample_series", r, "", false)
+	if err != nil || rate == "" {
+		return 0, err
+	}
+	sampleRate, err := strconv.ParseFloat(rate, 64)
+	if err != nil || sampleRate <= 0 || sampleRate > 1 {
+		return 0, fmt.Errorf("sample_series must be in (0, 1], actual: %s", rate)
+	}
+	return sampleRate, nil
 }
@@ -19,13 +19,35 @@ func TestMetricAPI_Search(t *testing.T) {
 	defer ctrl.Finish()
 
 	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	// invalid sample rate
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&sample_series=abc",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&sample_series=1.5",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+
+	// unknown result format
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&format=xml",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+
 	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
 	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
 	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx)
 	brokerExecutor.EXPECT().Execute()
 
-	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
-		gomock.Any(), gomock.Any(),
+	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		0.01, gomock.Any(), gomock.Any(),
 		gomock.Any(), gomock.Any()).Return(brokerExecutor)
 
 	api := NewMetricAPI(nil, nil, nil, executorFactory, nil)
@@ -43,7 +65,7 @@ func TestMetricAPI_Search(t *testing.T) {
 
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodGet,
-		URL:            "/broker/state?db=test&sql=select f from cpu",
+		URL:            "/broker/state?db=test&sql=select f from cpu&tz=America/New_York&format=csv&sample_series=0.01",
 		HandlerFunc:    api.Search,
 		ExpectHTTPCode: 200,
 	})
@@ -72,13 +94,29 @@ func TestNewMetricAPI_Search_Err(t *testing.T) {
 		ExpectHTTPCode: 500,
 	})
 
+	// unknown time zone
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&tz=Unknown/Zone",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+
+	// unknown result format
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&format=xml",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+
 	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
 	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
 	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx)
 	brokerExecutor.EXPECT().Execute()
 
 	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(),
-		gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 		gomock.Any(), gomock.Any()).Return(brokerExecutor)
 
 	ch := make(chan *series.TimeSeriesEvent)
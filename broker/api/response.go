@@ -11,6 +11,11 @@ func OK(w http.ResponseWriter, a interface{}) {
 	response(w, http.StatusOK, b)
 }
 
+// OKWithContentType responses with content of given content type and set the http status code 200
+func OKWithContentType(w http.ResponseWriter, contentType string, content []byte) {
+	writeResponse(w, http.StatusOK, contentType, content)
+}
+
 // NoContent responses with empty content and set the http status code 204
 func NoContent(w http.ResponseWriter) {
 	response(w, http.StatusNoContent, nil)
@@ -29,7 +34,12 @@ func Error(w http.ResponseWriter, err error) {
 
 // response responses json body for http restful api
 func response(w http.ResponseWriter, httpCode int, content []byte) {
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	writeResponse(w, httpCode, "application/json; charset=utf-8", content)
+}
+
+// writeResponse responses body of given content type
+func writeResponse(w http.ResponseWriter, httpCode int, contentType string, content []byte) {
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(httpCode)
 	if len(content) > 0 {
 		_, _ = w.Write(content)
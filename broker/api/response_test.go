@@ -16,6 +16,14 @@ func TestOK(t *testing.T) {
 	assert.Equal(t, `"ok"`, resp.Body.String())
 }
 
+func TestOKWithContentType(t *testing.T) {
+	resp := httptest.NewRecorder()
+	OKWithContentType(resp, "text/csv; charset=utf-8", []byte("a,b\n"))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "a,b\n", resp.Body.String())
+}
+
 func TestNoContent(t *testing.T) {
 	resp := httptest.NewRecorder()
 	NoContent(resp)
@@ -13,6 +13,7 @@ import (
 	dto "github.com/prometheus/client_model/go"
 	promreporter "github.com/uber-go/tally/prometheus"
 
+	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/broker/api"
 	"github.com/lindb/lindb/broker/api/admin"
 	masterAPI "github.com/lindb/lindb/broker/api/cluster"
@@ -34,6 +35,7 @@ import (
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/server"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/strutil"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/query"
 	"github.com/lindb/lindb/replication"
@@ -148,6 +150,17 @@ func (r *runtime) Run() error {
 		r.log.Error("get host name with error", logger.Error(err))
 		hostName = "unknown"
 	}
+	strutil.SetMaxRegexCost(r.config.BrokerBase.Query.MaxRegexCost)
+	// the query limits(expr depth/default time range/buckets etc.) are passed to parser and executor factory
+	if _, err := r.config.BrokerBase.Query.GetMeasurementTimeRanges(); err != nil {
+		r.state = server.Failed
+		return err
+	}
+	if _, err := aggregation.ParseNaNPolicy(r.config.BrokerBase.Query.NaNPolicy); err != nil {
+		r.state = server.Failed
+		return err
+	}
+
 	r.node = models.Node{
 		IP:       ip,
 		Port:     r.config.BrokerBase.GRPC.Port,
@@ -352,9 +365,11 @@ func (r *runtime) buildAPIDependency() {
 		brokerStateAPI:     stateAPI.NewBrokerAPI(r.ctx, r.repo, r.stateMachines.NodeSM),
 		masterAPI:          masterAPI.NewMasterAPI(r.master),
 		metricAPI: queryAPI.NewMetricAPI(r.stateMachines.ReplicaStatusSM,
-			r.stateMachines.NodeSM, r.stateMachines.DatabaseSM, query.NewExecutorFactory(), r.srv.jobManager),
+			r.stateMachines.NodeSM, r.stateMachines.DatabaseSM, query.NewBrokerExecutorFactory(r.config.BrokerBase.Query),
+			r.srv.jobManager),
 		metadataAPI: queryAPI.NewMetadataAPI(r.srv.databaseService, r.stateMachines.ReplicaStatusSM,
-			r.stateMachines.NodeSM, query.NewExecutorFactory(), r.srv.jobManager),
+			r.stateMachines.NodeSM, query.NewExecutorFactory(), r.srv.jobManager,
+			query.NewParserConfig(r.config.BrokerBase.Query)),
 		writeAPI:         writeAPI.NewWriteAPI(r.srv.channelManager),
 		prometheusWriter: write.NewPrometheusWrite(r.srv.channelManager),
 	}
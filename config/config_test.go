@@ -3,6 +3,7 @@ package config
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -63,3 +64,36 @@ func Test_ReplicationChannel_SegmentFileSizeInBytes(t *testing.T) {
 	rc.DataSizeLimit = 10000
 	assert.Equal(t, int64(1024*1024*1024), rc.GetDataSizeLimit())
 }
+
+func TestQuery_MeasurementTimeRanges(t *testing.T) {
+	q := NewDefaultQuery()
+	assert.Equal(t, "{}", q.measurementTimeRangesTOML())
+	q.MeasurementTimeRanges = map[string]string{
+		"cpu":        "6h",
+		"system.mem": "30m",
+	}
+	assert.Equal(t, `{"cpu" = "6h", "system.mem" = "30m"}`, q.measurementTimeRangesTOML())
+	timeRanges, err := q.GetMeasurementTimeRanges()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"cpu":        6 * time.Hour,
+		"system.mem": 30 * time.Minute,
+	}, timeRanges)
+
+	// round trip
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	cfgPath := filepath.Join(testPath, "query.toml")
+	assert.Nil(t, ltoml.WriteConfig(cfgPath, q.TOML()))
+	var q2 Query
+	assert.Nil(t, ltoml.DecodeToml(cfgPath, &q2))
+	assert.Equal(t, q.MeasurementTimeRanges, q2.MeasurementTimeRanges)
+
+	// invalid duration
+	q.MeasurementTimeRanges["disk"] = "1x"
+	timeRanges, err = q.GetMeasurementTimeRanges()
+	assert.Error(t, err)
+	assert.Nil(t, timeRanges)
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/lindb/lindb/pkg/ltoml"
@@ -55,9 +57,38 @@ type StorageCluster struct {
 
 // Query represents query rpc config
 type Query struct {
-	MaxWorkers  int            `toml:"max-workers"`
-	IdleTimeout ltoml.Duration `toml:"idle-timeout"`
-	Timeout     ltoml.Duration `toml:"timeout"`
+	MaxWorkers         int            `toml:"max-workers"`
+	IdleTimeout        ltoml.Duration `toml:"idle-timeout"`
+	Timeout            ltoml.Duration `toml:"timeout"`
+	MaxPointsPerSeries int            `toml:"max-points-per-series"`
+	TruncatePoints     bool           `toml:"truncate-points"`
+	NaNPolicy          string         `toml:"nan-policy"`
+	MaxExprDepth       int            `toml:"max-expr-depth"`
+	RejectExpiredQuery bool           `toml:"reject-expired-query"`
+	// regex predicate, rejects the dangerous pattern and bounds the evaluation
+	MaxRegexCost int            `toml:"max-regex-cost"`
+	RegexTimeout ltoml.Duration `toml:"regex-timeout"`
+	// index breaker, fast-fails series search after consecutive index lookup failures
+	IndexBreakerThreshold int            `toml:"index-breaker-threshold"`
+	IndexBreakerWindow    ltoml.Duration `toml:"index-breaker-window"`
+	IndexBreakerCooldown  ltoml.Duration `toml:"index-breaker-cooldown"`
+	MaxSeriesPerQuery     int            `toml:"max-series-per-query"`
+	MaxBucketsPerQuery    int            `toml:"max-buckets-per-query"`
+	MaxSlotsPerBlock      int            `toml:"max-slots-per-block"`
+	// group by spilling, bounds the memory of grouping aggregator of storage query
+	MaxGroupsInMemory int    `toml:"max-groups-in-memory"`
+	SpillDir          string `toml:"spill-dir"`
+	// read-ahead of sequential scan, prefetches next series blocks of data file
+	ReadAheadBlocks int `toml:"read-ahead-blocks"`
+	ReadAheadBudget int `toml:"read-ahead-budget"`
+	// admission, limits the num. of concurrent data queries
+	MaxConcurrentQueries int            `toml:"max-concurrent-queries"`
+	AdmissionTimeout     ltoml.Duration `toml:"admission-timeout"`
+	// rejects the query whose estimated cost(series * fields * points) exceeds the max cost
+	MaxQueryCost int64 `toml:"max-query-cost"`
+	// default look-back window of query without start time, overrides per measurement
+	DefaultTimeRange      ltoml.Duration    `toml:"default-time-range"`
+	MeasurementTimeRanges map[string]string `toml:"measurement-time-ranges"`
 }
 
 func (q *Query) TOML() string {
@@ -71,17 +102,159 @@ func (q *Query) TOML() string {
 	idle-timeout = "%s"
 
     ## maximum timeout threshold for the task performed
-    timeout = "%s"`,
+    timeout = "%s"
+
+    ## maximum number of points returned for each field of one series, 0 means no limit
+    max-points-per-series = %d
+
+    ## truncates the exceeded points if true, else the query fails when the limit is hit
+    truncate-points = %t
+
+    ## how to handle NaN/Inf field value when encodes query result(skip/reject/store),
+    ## skip treats the point as absent, reject fails the query, store returns it as is
+    nan-policy = "%s"
+
+    ## maximum depth of condition expression tree, protects the server from deeply nested condition
+    max-expr-depth = %d
+
+    ## fails the query if its time range is outside the retention of database, else returns empty result
+    reject-expired-query = %t
+
+    ## maximum cost(num. of compiled instructions) of regex predicate of tag, rejects the pattern
+    ## with nested quantifiers on character class(like (\w+)*) or exceeding the cost, 0 means no limit
+    max-regex-cost = %d
+
+    ## maximum duration of evaluating one regex predicate against tag values, 0 means no limit
+    regex-timeout = "%s"
+
+    ## fast-fails series search with "index unavailable" after N consecutive index lookup failures within window,
+    ## until cooldown passes, 0 means disable the breaker
+    index-breaker-threshold = %d
+    index-breaker-window = "%s"
+    index-breaker-cooldown = "%s"
+
+    ## maximum number of grouped series for one storage query, fails the query if exceeded, 0 means no limit
+    max-series-per-query = %d
+
+    ## maximum number of time buckets of group by time interval over the query time range,
+    ## fails the query with a coarser interval suggested if exceeded, 0 means no limit
+    max-buckets-per-query = %d
+
+    ## maximum number of time slots in one encoded field block of query result,
+    ## splits wide field data into chained blocks if exceeded, 0 means no limit
+    max-slots-per-block = %d
+
+    ## maximum number of groups held in memory by group by aggregator of one storage query,
+    ## spills the groups into temporary files under spill dir if exceeded, 0 means never spilling,
+    ## empty spill dir means the default directory for temporary files of os
+    max-groups-in-memory = %d
+    spill-dir = "%s"
+
+    ## num. of series blocks prefetched ahead of the scanning one when scans data file sequentially,
+    ## overlaps the file I/O with decoding, bounded by the budget(bytes) ahead, 0 means disable read-ahead
+    read-ahead-blocks = %d
+    read-ahead-budget = %d
+
+    ## maximum number of concurrent data queries, a query which cannot acquire a slot
+    ## within admission timeout fails fast, 0 means no limit
+    max-concurrent-queries = %d
+    admission-timeout = "%s"
+
+    ## maximum estimated cost of data query before execution, cost = series * fields * points * predicate factor,
+    ## series is estimated by the index of shards, each regex predicate inflates the cost, 0 means no limit
+    max-query-cost = %d
+
+    ## default look-back window of the query which has no start time in where clause,
+    ## overrides it for measurement like {"cpu" = "6h"}
+    default-time-range = "%s"
+    measurement-time-ranges = %s`,
 		q.MaxWorkers,
 		q.IdleTimeout,
 		q.Timeout,
+		q.MaxPointsPerSeries,
+		q.TruncatePoints,
+		q.NaNPolicy,
+		q.MaxExprDepth,
+		q.RejectExpiredQuery,
+		q.MaxRegexCost,
+		q.RegexTimeout,
+		q.IndexBreakerThreshold,
+		q.IndexBreakerWindow,
+		q.IndexBreakerCooldown,
+		q.MaxSeriesPerQuery,
+		q.MaxBucketsPerQuery,
+		q.MaxSlotsPerBlock,
+		q.MaxGroupsInMemory,
+		q.SpillDir,
+		q.ReadAheadBlocks,
+		q.ReadAheadBudget,
+		q.MaxConcurrentQueries,
+		q.AdmissionTimeout,
+		q.MaxQueryCost,
+		q.DefaultTimeRange,
+		q.measurementTimeRangesTOML(),
 	)
 }
 
+// measurementTimeRangesTOML returns the inline table of measurement time ranges, sorted by measurement
+func (q *Query) measurementTimeRangesTOML() string {
+	measurements := make([]string, 0, len(q.MeasurementTimeRanges))
+	for measurement := range q.MeasurementTimeRanges {
+		measurements = append(measurements, measurement)
+	}
+	sort.Strings(measurements)
+	items := make([]string, len(measurements))
+	for idx, measurement := range measurements {
+		key, _ := json.Marshal(measurement)
+		value, _ := json.Marshal(q.MeasurementTimeRanges[measurement])
+		items[idx] = fmt.Sprintf("%s = %s", key, value)
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// GetMeasurementTimeRanges returns the default look-back window of each measurement,
+// returns err if the window is not a valid duration
+func (q *Query) GetMeasurementTimeRanges() (map[string]time.Duration, error) {
+	result := make(map[string]time.Duration, len(q.MeasurementTimeRanges))
+	for measurement, window := range q.MeasurementTimeRanges {
+		d, err := time.ParseDuration(window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time range of measurement: %s, error: %s", measurement, err)
+		}
+		result[measurement] = d
+	}
+	return result, nil
+}
+
 func NewDefaultQuery() *Query {
 	return &Query{
 		MaxWorkers:  30,
 		IdleTimeout: ltoml.Duration(5 * time.Second),
 		Timeout:     ltoml.Duration(30 * time.Second),
+		// default no limit, keeps truncating mode if user set the limit
+		TruncatePoints: true,
+		NaNPolicy:      "skip",
+		MaxExprDepth:   64,
+		// rejects the query outside retention with a clear error
+		RejectExpiredQuery: true,
+		// rejects the regex with nested quantifiers or more than 1000 instructions, evaluates it at most 5s
+		MaxRegexCost: 1000,
+		RegexTimeout: ltoml.Duration(5 * time.Second),
+		// opens the index breaker after 10 failures within 10s, retries after 30s
+		IndexBreakerThreshold: 10,
+		IndexBreakerWindow:    ltoml.Duration(10 * time.Second),
+		IndexBreakerCooldown:  ltoml.Duration(30 * time.Second),
+		// protects the server from group by with huge cardinality, such as group by *
+		MaxSeriesPerQuery: 100000,
+		// protects the server from group by time with tiny interval over long time range, like 7 days by 1ms
+		MaxBucketsPerQuery: 1000000,
+		// prefetches at most 4 series blocks and 1MB ahead of scanning
+		ReadAheadBlocks: 4,
+		ReadAheadBudget: 1024 * 1024,
+		// default no limit, waits at most 1s for a query slot if user set the limit
+		AdmissionTimeout: ltoml.Duration(time.Second),
+		// looks back 1 hour if query has no start time
+		DefaultTimeRange:      ltoml.Duration(time.Hour),
+		MeasurementTimeRanges: map[string]string{},
 	}
 }
@@ -5,6 +5,10 @@ import "errors"
 var (
 	ErrDatabaseNotFound = errors.New("database not found")
 	ErrShardNotFound    = errors.New("shard not found")
+	// ErrMetricNotFound represents the metric(measurement) not found when resolving metric id by name
+	ErrMetricNotFound = errors.New("metric not found")
+	// ErrFieldNotFound represents the field not found when resolving field meta by field id
+	ErrFieldNotFound = errors.New("field not found")
 
 	// ErrNotFound represents the data not found
 	ErrNotFound = errors.New("not found")
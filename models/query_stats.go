@@ -11,6 +11,7 @@ type QueryStats struct {
 	StorageNodes map[string]*StorageStats `json:"storageNodes,omitempty"`
 	Cost         int64                    `json:"cost"` // total query cost
 	ExpressCost  int64                    `json:"expressCost"`
+	Annotations  []string                 `json:"annotations,omitempty"` // annotations of query rewriting
 }
 
 // NewQueryStats creates the query stats
@@ -32,6 +33,7 @@ type StorageStats struct {
 	TotalCost             int64                 `json:"totalCost"`
 	PlanCost              int64                 `json:"planCost"`
 	TagFilterCost         int64                 `json:"tagFilterCost"`
+	TagFilterPushDown     map[string]bool       `json:"tagFilterPushDown,omitempty"`
 	Shards                map[int32]*ShardStats `json:"shards,omitempty"`
 	CollectTagValuesStats map[string]int64      `json:"collectTagValuesStats,omitempty"`
 
@@ -44,6 +46,7 @@ func NewStorageStats() *StorageStats {
 	return &StorageStats{
 		Shards:                make(map[int32]*ShardStats),
 		CollectTagValuesStats: make(map[string]int64),
+		TagFilterPushDown:     make(map[string]bool),
 		start:                 timeutil.NowNano(),
 	}
 }
@@ -69,6 +72,13 @@ func (s *StorageStats) SetTagFilterCost(cost int64) {
 	s.TagFilterCost = cost
 }
 
+// SetTagFilterPushDown sets if the tag filter is resolved by tag index lookup(pushed down) or residual filter
+func (s *StorageStats) SetTagFilterPushDown(tagFilter string, pushDown bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.TagFilterPushDown[tagFilter] = pushDown
+}
+
 // SetShardSeriesIDsSearchStats sets shard series ids search stats
 func (s *StorageStats) SetShardSeriesIDsSearchStats(shardID int32, numOfSeries uint64, seriesFilterCost int64) {
 	s.mutex.Lock()
@@ -137,15 +147,32 @@ func (s *StorageStats) SetCollectTagValuesStats(tagKey string, cost int64) {
 	s.CollectTagValuesStats[tagKey] = cost
 }
 
+// SetShardPredicateStats sets the actual matched series/cost of each tag filter in shard level(explain analyze)
+func (s *StorageStats) SetShardPredicateStats(shardID int32, predicateStats map[string]*PredicateStats) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats, ok := s.Shards[shardID]
+	if ok {
+		stats.PredicateStats = predicateStats
+	}
+}
+
+// PredicateStats represents the actual resolved stats of tag filter(leaf of condition)
+type PredicateStats struct {
+	Matched uint64 `json:"matched"` // num. of matched series
+	Cost    int64  `json:"cost"`
+}
+
 // ShardStats represents the shard level stats
 type ShardStats struct {
-	SeriesFilterCost int64             `json:"seriesFilterCost"`
-	NumOfSeries      uint64            `json:"numOfSeries"`
-	MemFilterCost    int64             `json:"memFilterCost"`
-	KVFilterCost     int64             `json:"kvFilterCost"`
-	GroupingCost     int64             `json:"groupingCost"`
-	ScanStats        map[string]*Stats `json:"scanStats,omitempty"`
-	GroupBuildStats  *Stats            `json:"groupBuildStats,omitempty"`
+	SeriesFilterCost int64                      `json:"seriesFilterCost"`
+	NumOfSeries      uint64                     `json:"numOfSeries"`
+	MemFilterCost    int64                      `json:"memFilterCost"`
+	KVFilterCost     int64                      `json:"kvFilterCost"`
+	GroupingCost     int64                      `json:"groupingCost"`
+	ScanStats        map[string]*Stats          `json:"scanStats,omitempty"`
+	GroupBuildStats  *Stats                     `json:"groupBuildStats,omitempty"`
+	PredicateStats   map[string]*PredicateStats `json:"predicateStats,omitempty"`
 }
 
 // newShardStats creates the shard level stats
@@ -18,17 +18,20 @@ func TestStorageStats(t *testing.T) {
 	stats := NewStorageStats()
 	stats.SetPlanCost(10)
 	stats.SetTagFilterCost(10)
+	stats.SetTagFilterPushDown("ip=~'1.*'", true)
 	stats.SetCollectTagValuesStats("test-1", 10)
 	stats.SetShardGroupBuildStats(10, 10)
 	stats.SetShardScanStats(10, "id", 10)
 	stats.SetShardGroupingCost(10, 10)
 	stats.SetShardKVDataFilterCost(10, 10)
 	stats.SetShardMemoryDataFilterCost(10, 10)
+	stats.SetShardPredicateStats(10, map[string]*PredicateStats{"host=a": {Matched: 1}})
 	shard, ok := stats.Shards[10]
 	assert.False(t, ok)
 	assert.Nil(t, shard)
 	assert.Equal(t, int64(10), stats.PlanCost)
 	assert.Equal(t, int64(10), stats.TagFilterCost)
+	assert.Equal(t, map[string]bool{"ip=~'1.*'": true}, stats.TagFilterPushDown)
 
 	stats.SetShardSeriesIDsSearchStats(10, 10, 10)
 	stats.SetCollectTagValuesStats("test-1", 10)
@@ -39,6 +42,7 @@ func TestStorageStats(t *testing.T) {
 	stats.SetShardGroupingCost(10, 10)
 	stats.SetShardKVDataFilterCost(10, 10)
 	stats.SetShardMemoryDataFilterCost(10, 10)
+	stats.SetShardPredicateStats(10, map[string]*PredicateStats{"host=a": {Matched: 1, Cost: 10}})
 	stats.Complete()
 	assert.True(t, stats.TotalCost > 0)
 	shard, ok = stats.Shards[10]
@@ -46,6 +50,7 @@ func TestStorageStats(t *testing.T) {
 	assert.NotNil(t, shard)
 
 	assert.Equal(t, int64(10), shard.SeriesFilterCost)
+	assert.Equal(t, map[string]*PredicateStats{"host=a": {Matched: 1, Cost: 10}}, shard.PredicateStats)
 	assert.Equal(t, int64(10), shard.MemFilterCost)
 	assert.Equal(t, int64(10), shard.KVFilterCost)
 	assert.Equal(t, int64(10), shard.GroupBuildStats.Max)
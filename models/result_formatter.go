@@ -0,0 +1,203 @@
+package models
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defines all formats of query result
+const (
+	JSONFormat = "json"
+	CSVFormat  = "csv"
+	LineFormat = "line"
+)
+
+var (
+	// measurementEscaper escapes the measurement of influxdb line protocol
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	// keyEscaper escapes the tag key/tag value/field key of influxdb line protocol
+	keyEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// ResultFormatter represents the formatter which writes the query result set in specific format
+type ResultFormatter interface {
+	// ContentType returns the http content type of the format
+	ContentType() string
+	// Format writes the result set into writer
+	Format(w io.Writer, rs *ResultSet) error
+}
+
+// NewResultFormatter returns the result formatter by format name, default json
+func NewResultFormatter(format string) (ResultFormatter, error) {
+	switch format {
+	case "", JSONFormat:
+		return &jsonFormatter{}, nil
+	case CSVFormat:
+		return &csvFormatter{}, nil
+	case LineFormat:
+		return &lineFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown result format: %s", format)
+	}
+}
+
+// jsonFormatter writes the result set as json
+type jsonFormatter struct{}
+
+// ContentType returns the http content type of json
+func (f *jsonFormatter) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+// Format writes the result set as json
+func (f *jsonFormatter) Format(w io.Writer, rs *ResultSet) error {
+	b, err := json.Marshal(rs)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// csvFormatter writes the result set as csv, one row for each timestamp of series,
+// columns are: sorted tag keys(group keys), timestamp, sorted field names.
+type csvFormatter struct{}
+
+// ContentType returns the http content type of csv
+func (f *csvFormatter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// Format writes the result set as csv
+func (f *csvFormatter) Format(w io.Writer, rs *ResultSet) error {
+	tagKeys, fieldNames := columnsOf(rs)
+	writer := csv.NewWriter(w)
+	header := make([]string, 0, len(tagKeys)+len(fieldNames)+1)
+	header = append(header, tagKeys...)
+	header = append(header, "timestamp")
+	header = append(header, fieldNames...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	row := make([]string, len(header))
+	for _, s := range rs.Series {
+		for idx, tagKey := range tagKeys {
+			row[idx] = s.Tags[tagKey]
+		}
+		for _, timestamp := range timestampsOf(s) {
+			row[len(tagKeys)] = strconv.FormatInt(timestamp, 10)
+			for idx, fieldName := range fieldNames {
+				value, ok := s.Fields[fieldName][timestamp]
+				if ok && !math.IsNaN(value) {
+					row[len(tagKeys)+1+idx] = formatValue(value)
+				} else {
+					row[len(tagKeys)+1+idx] = ""
+				}
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// lineFormatter writes the result set as influxdb line protocol, one line for each timestamp of series,
+// like: metric,tag1=value1 field1=1,field2=2 timestamp(nanosecond, default precision of influxdb).
+// NaN value is skipped because line protocol cannot represent it.
+type lineFormatter struct{}
+
+// ContentType returns the http content type of line protocol
+func (f *lineFormatter) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// Format writes the result set as line protocol
+func (f *lineFormatter) Format(w io.Writer, rs *ResultSet) error {
+	_, fieldNames := columnsOf(rs)
+	writer := bufio.NewWriter(w)
+	for _, s := range rs.Series {
+		key := measurementEscaper.Replace(rs.MetricName)
+		tagKeys := make([]string, 0, len(s.Tags))
+		for tagKey := range s.Tags {
+			tagKeys = append(tagKeys, tagKey)
+		}
+		sort.Strings(tagKeys)
+		for _, tagKey := range tagKeys {
+			key += "," + keyEscaper.Replace(tagKey) + "=" + keyEscaper.Replace(s.Tags[tagKey])
+		}
+		for _, timestamp := range timestampsOf(s) {
+			fields := ""
+			for _, fieldName := range fieldNames {
+				value, ok := s.Fields[fieldName][timestamp]
+				if !ok || math.IsNaN(value) {
+					continue
+				}
+				if fields != "" {
+					fields += ","
+				}
+				fields += keyEscaper.Replace(fieldName) + "=" + formatValue(value)
+			}
+			if fields == "" {
+				continue
+			}
+			if _, err := fmt.Fprintf(writer, "%s %s %d\n", key, fields, timestamp*int64(time.Millisecond)); err != nil {
+				return err
+			}
+		}
+	}
+	return writer.Flush()
+}
+
+// columnsOf returns the sorted tag keys and field names of all series in result set
+func columnsOf(rs *ResultSet) (tagKeys, fieldNames []string) {
+	tagKeySet := make(map[string]struct{})
+	fieldNameSet := make(map[string]struct{})
+	for _, s := range rs.Series {
+		for tagKey := range s.Tags {
+			tagKeySet[tagKey] = struct{}{}
+		}
+		for fieldName := range s.Fields {
+			fieldNameSet[fieldName] = struct{}{}
+		}
+	}
+	for tagKey := range tagKeySet {
+		tagKeys = append(tagKeys, tagKey)
+	}
+	for fieldName := range fieldNameSet {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(tagKeys)
+	sort.Strings(fieldNames)
+	return tagKeys, fieldNames
+}
+
+// timestampsOf returns the sorted timestamps of all fields in series
+func timestampsOf(s *Series) []int64 {
+	timestampSet := make(map[int64]struct{})
+	for _, points := range s.Fields {
+		for timestamp := range points {
+			timestampSet[timestamp] = struct{}{}
+		}
+	}
+	timestamps := make([]int64, 0, len(timestampSet))
+	for timestamp := range timestampSet {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	return timestamps
+}
+
+// formatValue formats the float value in shortest representation
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockResultSet() *ResultSet {
+	rs := NewResultSet()
+	rs.MetricName = "cpu load"
+	s1 := NewSeries(map[string]string{"host": "a", "ip": "1.1.1.1"})
+	points := NewPoints()
+	points.AddPoint(20, 2.5)
+	points.AddPoint(10, 1)
+	s1.AddField("f2", points)
+	points = NewPoints()
+	points.AddPoint(10, 3)
+	points.AddPoint(30, math.NaN())
+	s1.AddField("f1", points)
+	rs.AddSeries(s1)
+	s2 := NewSeries(map[string]string{"host": "b,c"})
+	points = NewPoints()
+	points.AddPoint(10, 4)
+	s2.AddField("f1", points)
+	rs.AddSeries(s2)
+	return rs
+}
+
+func TestNewResultFormatter(t *testing.T) {
+	f, err := NewResultFormatter("")
+	assert.NoError(t, err)
+	assert.Equal(t, &jsonFormatter{}, f)
+	f, err = NewResultFormatter(JSONFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json; charset=utf-8", f.ContentType())
+	f, err = NewResultFormatter(CSVFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", f.ContentType())
+	f, err = NewResultFormatter(LineFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", f.ContentType())
+	f, err = NewResultFormatter("xml")
+	assert.Error(t, err)
+	assert.Nil(t, f)
+}
+
+func TestJSONFormatter_Format(t *testing.T) {
+	rs := NewResultSet()
+	rs.MetricName = "cpu"
+	s := NewSeries(map[string]string{"host": "a"})
+	points := NewPoints()
+	points.AddPoint(10, 1)
+	s.AddField("f1", points)
+	rs.AddSeries(s)
+	f, _ := NewResultFormatter(JSONFormat)
+	var buf bytes.Buffer
+	assert.NoError(t, f.Format(&buf, rs))
+	rs1 := &ResultSet{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), rs1))
+	assert.Equal(t, rs, rs1)
+	// NaN cannot be marshaled
+	assert.Error(t, f.Format(&buf, mockResultSet()))
+}
+
+func TestCSVFormatter_Format(t *testing.T) {
+	f, _ := NewResultFormatter(CSVFormat)
+	var buf bytes.Buffer
+	assert.NoError(t, f.Format(&buf, mockResultSet()))
+	assert.Equal(t, "host,ip,timestamp,f1,f2\n"+
+		"a,1.1.1.1,10,3,1\n"+
+		"a,1.1.1.1,20,,2.5\n"+
+		"a,1.1.1.1,30,,\n"+
+		"\"b,c\",,10,4,\n", buf.String())
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b,c", "", "10", "4", ""}, records[4])
+
+	buf.Reset()
+	assert.NoError(t, f.Format(&buf, NewResultSet()))
+	assert.Equal(t, "timestamp\n", buf.String())
+}
+
+func TestLineFormatter_Format(t *testing.T) {
+	f, _ := NewResultFormatter(LineFormat)
+	var buf bytes.Buffer
+	assert.NoError(t, f.Format(&buf, mockResultSet()))
+	assert.Equal(t, "cpu\\ load,host=a,ip=1.1.1.1 f1=3,f2=1 10000000\n"+
+		"cpu\\ load,host=a,ip=1.1.1.1 f2=2.5 20000000\n"+
+		"cpu\\ load,host=b\\,c f1=4 10000000\n", buf.String())
+}
+
+func TestResultFormatter_Format_write_err(t *testing.T) {
+	for _, format := range []string{JSONFormat, CSVFormat, LineFormat} {
+		f, _ := NewResultFormatter(format)
+		rs := NewResultSet()
+		s := NewSeries(map[string]string{"host": "a"})
+		points := NewPoints()
+		points.AddPoint(10, 1)
+		s.AddField("f1", points)
+		rs.AddSeries(s)
+		assert.Error(t, f.Format(&errWriter{}, rs))
+	}
+}
+
+type errWriter struct{}
+
+func (w *errWriter) Write(p []byte) (n int, err error) {
+	return 0, fmt.Errorf("err")
+}
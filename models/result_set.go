@@ -29,6 +29,8 @@ func (rs *ResultSet) AddSeries(series *Series) {
 type Series struct {
 	Tags   map[string]string            `json:"tags,omitempty"`
 	Fields map[string]map[int64]float64 `json:"fields,omitempty"`
+	// Truncated represents the points of series are truncated because of the points limit
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // NewSeries creates a new series
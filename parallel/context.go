@@ -3,14 +3,18 @@ package parallel
 import (
 	"context"
 	"errors"
+	"math"
+	"sort"
 
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/timeutil"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -37,6 +41,8 @@ type ExecuteContext interface {
 type StorageExecuteContext interface {
 	// QueryStats returns the storage query stats
 	QueryStats() *models.StorageStats
+	// SpillOption returns the spill option of group by aggregator, which bounds the memory of grouping
+	SpillOption() aggregation.SpillOption
 }
 
 // BrokerExecuteContext represents the broker execute context
@@ -47,28 +53,56 @@ type BrokerExecuteContext interface {
 	ResultCh() chan *series.TimeSeriesEvent
 	// ResultSet returns the final result set
 	ResultSet() (*models.ResultSet, error)
+	// CompleteDistinctCount completes the execution with the exact distinct tag value count of count(distinct tag)
+	CompleteDistinctCount(count int)
+}
+
+// PointsLimit represents the limit of points returned for each field of one series
+type PointsLimit struct {
+	MaxPointsPerSeries int  // max points of each field per series, no limit if <= 0
+	Truncate           bool // truncates the exceeded points if true, else query fails
 }
 
 type brokerExecuteContext struct {
-	resultCh   chan *series.TimeSeriesEvent
-	err        error
-	query      *stmt.Query
-	expression aggregation.Expression
-	resultSet  *models.ResultSet
+	resultCh    chan *series.TimeSeriesEvent
+	err         error
+	query       *stmt.Query
+	expression  aggregation.Expression
+	resultSet   *models.ResultSet
+	pointsLimit PointsLimit
+	// agg types for re-aggregating the result of select items when resampling to output interval
+	resampleAggTypes map[string]field.AggType
 
 	stats     *models.QueryStats
 	startTime int64
 }
 
-func NewBrokerExecuteContext(startTime int64, query *stmt.Query) BrokerExecuteContext {
+func NewBrokerExecuteContext(startTime int64, query *stmt.Query, pointsLimit PointsLimit,
+	nanPolicy aggregation.NaNPolicy,
+) BrokerExecuteContext {
 	ctx := &brokerExecuteContext{
-		startTime: startTime,
-		resultCh:  make(chan *series.TimeSeriesEvent),
-		resultSet: models.NewResultSet(),
-		query:     query,
+		startTime:   startTime,
+		resultCh:    make(chan *series.TimeSeriesEvent),
+		resultSet:   models.NewResultSet(),
+		query:       query,
+		pointsLimit: pointsLimit,
 	}
 	if query != nil {
-		ctx.expression = aggregation.NewExpression(query.TimeRange, query.Interval.Int64(), query.SelectItems)
+		ctx.expression = aggregation.NewExpression(query.TimeRange, query.Interval.Int64(), query.SelectItems, nanPolicy)
+		if query.NeedResample() {
+			ctx.resampleAggTypes = make(map[string]field.AggType)
+			for _, selectItem := range query.SelectItems {
+				item, ok := selectItem.(*stmt.SelectItem)
+				if !ok {
+					continue
+				}
+				name := item.Alias
+				if len(name) == 0 {
+					name = item.Rewrite()
+				}
+				ctx.resampleAggTypes[name] = aggregation.ResampleAggType(item)
+			}
+		}
 	}
 	return ctx
 }
@@ -85,7 +119,11 @@ func (c *brokerExecuteContext) Emit(event *series.TimeSeriesEvent) {
 	groupByKeysLength := len(groupByKeys)
 	for _, ts := range event.SeriesList {
 		var tags map[string]string
-		if groupByKeysLength > 0 {
+		switch {
+		case c.query.GroupByAll:
+			// group by *, tags include tag keys expanded by storage
+			tags = tag.SplitTags(ts.Tags())
+		case groupByKeysLength > 0:
 			tagValues := tag.SplitTagValues(ts.Tags())
 			if groupByKeysLength != len(tagValues) {
 				// if tag values not match group by tag keys, ignore this time series
@@ -100,18 +138,20 @@ func (c *brokerExecuteContext) Emit(event *series.TimeSeriesEvent) {
 		timeSeries := models.NewSeries(tags)
 		c.resultSet.AddSeries(timeSeries)
 		c.expression.Eval(ts)
-		rs := c.expression.ResultSet()
-		for fieldName, values := range rs {
-			if values == nil {
-				continue
-			}
-			points := models.NewPoints()
-			it := values.Iterator()
-			for it.HasNext() {
-				slot, val := it.Next()
-				points.AddPoint(int64(slot)*c.query.Interval.Int64()+c.query.TimeRange.Start, val)
-			}
-			timeSeries.AddField(fieldName, points)
+		fieldsIt := newResultFieldIterator(tags, c.expression.ResultSet())
+		var err error
+		if c.query.NeedResample() {
+			err = c.addResampledFields(timeSeries, fieldsIt)
+		} else {
+			err = c.addAlignedFields(timeSeries, fieldsIt)
+		}
+		if err != nil {
+			c.err = err
+			c.expression.Reset()
+			return
+		}
+		if c.query.SelectTime {
+			timeSeries.AddField(stmt.TimeColumn, timeColumn(timeSeries))
 		}
 		c.expression.Reset()
 	}
@@ -120,6 +160,111 @@ func (c *brokerExecuteContext) Emit(event *series.TimeSeriesEvent) {
 	}
 }
 
+// timeColumn builds the points of time column, which outputs the timestamp of each point of fields
+func timeColumn(timeSeries *models.Series) *models.Points {
+	points := models.NewPoints()
+	for _, fieldPoints := range timeSeries.Fields {
+		for timestamp := range fieldPoints {
+			points.AddPoint(timestamp, float64(timestamp))
+		}
+	}
+	return points
+}
+
+// newResultFieldIterator creates the field iterators of one result series based on the eval result of select items,
+// the fields are sorted by name, the select item without result is ignored.
+func newResultFieldIterator(tags map[string]string, rs map[string]collections.FloatArray) series.MultiFieldIterator {
+	fieldNames := make([]string, 0, len(rs))
+	for fieldName, values := range rs {
+		if values != nil {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	sort.Strings(fieldNames)
+	fields := make([]field.Meta, len(fieldNames))
+	its := make([]series.FieldIterator, len(fieldNames))
+	for idx, fieldName := range fieldNames {
+		fields[idx] = field.Meta{Name: field.Name(fieldName)}
+		// the values are the final result of select item, replaces the value of same time slot
+		its[idx] = aggregation.NewFieldIterator(0, field.Replace, rs[fieldName])
+	}
+	return series.NewMultiFieldIterator(tags, fields, its)
+}
+
+// fill returns the fill policy of query for the step which has no data point
+func (c *brokerExecuteContext) fill() aggregation.FillPolicy {
+	return aggregation.FillPolicy{Type: aggregation.FillType(c.query.Fill.Type), Value: c.query.Fill.Value}
+}
+
+// addAlignedFields adds the points of fields into result series row by row, all fields are aligned
+// on the time slots of query interval, the slot without data point is filled based on fill policy.
+func (c *brokerExecuteContext) addAlignedFields(timeSeries *models.Series, fieldsIt series.MultiFieldIterator) error {
+	interval := c.query.Interval.Int64()
+	endSlot := timeutil.CalPointCount(c.query.TimeRange.Start, c.query.TimeRange.End, interval)
+	it := aggregation.NewAlignedIterator(fieldsIt, 0, endSlot, 1, c.fill())
+	fields := it.Fields()
+	fieldPoints := make([]*models.Points, len(fields))
+	for idx := range fields {
+		fieldPoints[idx] = models.NewPoints()
+	}
+	for it.HasNext() {
+		slot, values := it.Next()
+		for idx, val := range values {
+			if math.IsNaN(val) {
+				// slot without data point(fill null)
+				continue
+			}
+			points := fieldPoints[idx]
+			if c.isPointsLimitReached(len(points.Points)) {
+				if !c.pointsLimit.Truncate {
+					return errTooManyPoints
+				}
+				timeSeries.Truncated = true
+				continue
+			}
+			points.AddPoint(int64(slot)*interval+c.query.TimeRange.Start, val)
+		}
+	}
+	for idx, f := range fields {
+		timeSeries.AddField(string(f.Name), fieldPoints[idx])
+	}
+	return nil
+}
+
+// addResampledFields adds the points of fields into result series, the values of each field are resampled
+// to output interval.
+func (c *brokerExecuteContext) addResampledFields(timeSeries *models.Series, fieldsIt series.MultiFieldIterator) error {
+	interval := c.query.OutputInterval.Int64()
+	for _, f := range fieldsIt.Fields() {
+		fieldName := string(f.Name)
+		points := models.NewPoints()
+		it := aggregation.NewResampleIterator(fieldsIt.FieldIterator(f.Name), c.resampleAggTypes[fieldName], c.query.TimeRange,
+			c.query.Interval.Int64(), interval, c.fill())
+		for it.HasNext() {
+			if c.isPointsLimitReached(len(points.Points)) {
+				if !c.pointsLimit.Truncate {
+					return errTooManyPoints
+				}
+				timeSeries.Truncated = true
+				break
+			}
+			slot, val := it.Next()
+			if math.IsNaN(val) {
+				// output step without data point(fill null)
+				continue
+			}
+			points.AddPoint(int64(slot)*interval+c.query.TimeRange.Start, val)
+		}
+		timeSeries.AddField(fieldName, points)
+	}
+	return nil
+}
+
+// isPointsLimitReached checks if the num. of points reaches the max points per series
+func (c *brokerExecuteContext) isPointsLimitReached(numOfPoints int) bool {
+	return c.pointsLimit.MaxPointsPerSeries > 0 && numOfPoints >= c.pointsLimit.MaxPointsPerSeries
+}
+
 func (c *brokerExecuteContext) Complete(err error) {
 	if err != nil {
 		c.err = err
@@ -127,19 +272,34 @@ func (c *brokerExecuteContext) Complete(err error) {
 	}
 }
 
+// CompleteDistinctCount completes the execution with the exact distinct tag value count of count(distinct tag),
+// builds the result series with one point at query's start time.
+func (c *brokerExecuteContext) CompleteDistinctCount(count int) {
+	timeSeries := models.NewSeries(nil)
+	points := models.NewPoints()
+	points.AddPoint(c.query.TimeRange.Start, float64(count))
+	timeSeries.AddField("count", points)
+	c.resultSet.AddSeries(timeSeries)
+	close(c.resultCh)
+}
+
 func (c *brokerExecuteContext) ResultCh() chan *series.TimeSeriesEvent {
 	return c.resultCh
 }
 
 func (c *brokerExecuteContext) ResultSet() (*models.ResultSet, error) {
 	if c.err == nil {
-		c.resultSet.MetricName = c.query.MetricName
+		c.resultSet.MetricName = c.query.ResultMetricName()
 		c.resultSet.StartTime = c.query.TimeRange.Start
 		c.resultSet.EndTime = c.query.TimeRange.End
 		c.resultSet.Interval = c.query.Interval.Int64()
+		if c.query.NeedResample() {
+			c.resultSet.Interval = c.query.OutputInterval.Int64()
+		}
 	}
 	if c.stats != nil {
 		c.stats.Cost = timeutil.NowNano() - c.startTime
+		c.stats.Annotations = c.query.Annotations
 	}
 	c.resultSet.Stats = c.stats
 
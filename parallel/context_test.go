@@ -12,6 +12,7 @@ import (
 	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -27,7 +28,7 @@ func TestBrokerExecuteContext(t *testing.T) {
 	assert.NoError(t, err)
 	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
 
-	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query)
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
 	brokerCtx := ctx.(*brokerExecuteContext)
 	brokerCtx.expression = expression
 	assert.NotNil(t, brokerCtx.expression)
@@ -64,7 +65,7 @@ func TestBrokerExecuteContext_Emit_GroupBy(t *testing.T) {
 	assert.NoError(t, err)
 	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
 
-	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query)
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
 	brokerCtx := ctx.(*brokerExecuteContext)
 	brokerCtx.expression = expression
 	assert.NotNil(t, brokerCtx.expression)
@@ -90,7 +91,7 @@ func TestBrokerExecuteContext_Emit_GroupBy(t *testing.T) {
 	assert.Error(t, err)
 	assert.NotNil(t, rs.Series[0].Fields["f"])
 
-	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query)
+	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
 	brokerCtx = ctx.(*brokerExecuteContext)
 	brokerCtx.expression = expression
 	assert.NotNil(t, brokerCtx.expression)
@@ -106,10 +107,292 @@ func TestBrokerExecuteContext_Emit_GroupBy(t *testing.T) {
 	assert.Len(t, rs.Series, 0)
 }
 
+func TestBrokerExecuteContext_Emit_GroupByAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+	q, _ := sql.Parse("select f from cpu group by *")
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
+	ctx.(*brokerExecuteContext).expression = expression
+	it := series.NewMockGroupedIterator(ctrl)
+	it.EXPECT().Tags().Return("host=1.1.1.1,ip=1.1.1.2")
+	expression.EXPECT().Eval(gomock.Any())
+	values := collections.NewFloatArray(10)
+	values.SetValue(1, 10.0)
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{
+		SeriesList: []series.GroupedIterator{it},
+		Stats:      &models.QueryStats{},
+	})
+	rs, err := ctx.ResultSet()
+	ctx.Complete(nil)
+	assert.NoError(t, err)
+	assert.Len(t, rs.Series, 1)
+	assert.Equal(t, map[string]string{"host": "1.1.1.1", "ip": "1.1.1.2"}, rs.Series[0].Tags)
+}
+
+func TestBrokerExecuteContext_MetricAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+	q, _ := sql.Parse("select f from cpu_v2")
+	query := q.(*stmt.Query)
+	query.MetricAlias = "cpu"
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
+	ctx.(*brokerExecuteContext).expression = expression
+	expression.EXPECT().Eval(gomock.Any())
+	values := collections.NewFloatArray(10)
+	values.SetValue(1, 10.0)
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+	rs, err := ctx.ResultSet()
+	ctx.Complete(nil)
+	assert.NoError(t, err)
+	// lookup uses the real metric name, result reports the alias
+	assert.Equal(t, "cpu_v2", query.MetricName)
+	assert.Equal(t, "cpu", rs.MetricName)
+	assert.Len(t, rs.Series, 1)
+}
+
+func TestBrokerExecuteContext_SelectTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+	q, err := sql.Parse("select time, f from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
+	ctx.(*brokerExecuteContext).expression = expression
+	expression.EXPECT().Eval(gomock.Any())
+	values := collections.NewFloatArray(10)
+	values.SetValue(1, 10.0)
+	values.SetValue(3, 30.0)
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	start := query.TimeRange.Start
+	assert.Equal(t, map[int64]float64{
+		start + 10*timeutil.OneSecond: 10,
+		start + 30*timeutil.OneSecond: 30,
+	}, rs.Series[0].Fields["f"])
+	// time column outputs the timestamp of each point
+	assert.Equal(t, map[int64]float64{
+		start + 10*timeutil.OneSecond: float64(start + 10*timeutil.OneSecond),
+		start + 30*timeutil.OneSecond: float64(start + 30*timeutil.OneSecond),
+	}, rs.Series[0].Fields[stmt.TimeColumn])
+}
+
+func TestBrokerExecuteContext_Emit_PointsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+	q, _ := sql.Parse("select f from cpu")
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	values := collections.NewFloatArray(10)
+	for i := 0; i < 5; i++ {
+		values.SetValue(i, float64(i))
+	}
+	// case 1: truncate mode
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{MaxPointsPerSeries: 3, Truncate: true}, aggregation.SkipNaN)
+	ctx.(*brokerExecuteContext).expression = expression
+	expression.EXPECT().Eval(gomock.Any())
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.True(t, rs.Series[0].Truncated)
+	assert.Equal(t, map[int64]float64{
+		query.TimeRange.Start:                         0,
+		query.TimeRange.Start + 10*timeutil.OneSecond: 1,
+		query.TimeRange.Start + 20*timeutil.OneSecond: 2,
+	}, rs.Series[0].Fields["f"])
+	// case 2: error mode
+	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{MaxPointsPerSeries: 3}, aggregation.SkipNaN)
+	ctx.(*brokerExecuteContext).expression = expression
+	expression.EXPECT().Eval(gomock.Any())
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+	_, err = ctx.ResultSet()
+	assert.Equal(t, errTooManyPoints, err)
+	// case 3: not reach limit
+	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{MaxPointsPerSeries: 5}, aggregation.SkipNaN)
+	ctx.(*brokerExecuteContext).expression = expression
+	expression.EXPECT().Eval(gomock.Any())
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+	rs, err = ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.False(t, rs.Series[0].Truncated)
+	assert.Len(t, rs.Series[0].Fields["f"], 5)
+}
+
+func TestBrokerExecuteContext_Emit_Resample(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+	q, _ := sql.Parse("select sum(f) from cpu group by time(5m) step 1m fill(previous)")
+	query := q.(*stmt.Query)
+	query.TimeRange = timeutil.TimeRange{Start: 0, End: 10 * timeutil.OneMinute}
+	values := collections.NewFloatArray(3)
+	values.SetValue(0, 1)
+	values.SetValue(2, 3)
+	// up sampling: 5m => 1m
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
+	ctx.(*brokerExecuteContext).expression = expression
+	expression.EXPECT().Eval(gomock.Any())
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"sum(f)": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.Equal(t, timeutil.OneMinute, rs.Interval)
+	points := rs.Series[0].Fields["sum(f)"]
+	assert.Len(t, points, 11)
+	assert.Equal(t, 1.0, points[4*timeutil.OneMinute])
+	assert.Equal(t, 3.0, points[10*timeutil.OneMinute])
+
+	// down sampling: 1m => 5m, re-aggregates by sum
+	q, _ = sql.Parse("select sum(f) from cpu group by time(1m) step 5m")
+	query = q.(*stmt.Query)
+	query.TimeRange = timeutil.TimeRange{Start: 0, End: 10 * timeutil.OneMinute}
+	values = collections.NewFloatArray(11)
+	for i := 0; i < 7; i++ {
+		values.SetValue(i, float64(i))
+	}
+	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
+	ctx.(*brokerExecuteContext).expression = expression
+	expression.EXPECT().Eval(gomock.Any())
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"sum(f)": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+	rs, err = ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.Equal(t, 5*timeutil.OneMinute, rs.Interval)
+	// fill null, the output step without data point is skipped
+	assert.Equal(t, map[int64]float64{
+		0:                      10,
+		5 * timeutil.OneMinute: 11,
+	}, rs.Series[0].Fields["sum(f)"])
+}
+
+func TestBrokerExecuteContext_Emit_Fill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+	values1 := collections.NewFloatArray(4)
+	values1.SetValue(0, 1)
+	values1.SetValue(2, 3)
+	values2 := collections.NewFloatArray(4)
+	values2.SetValue(1, 2)
+	emit := func(statement string) *models.Series {
+		q, err := sql.Parse(statement)
+		assert.NoError(t, err)
+		query := q.(*stmt.Query)
+		query.TimeRange = timeutil.TimeRange{Start: 0, End: 3 * timeutil.OneMinute}
+		ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
+		ctx.(*brokerExecuteContext).expression = expression
+		expression.EXPECT().Eval(gomock.Any())
+		expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"sum(a)": values1, "sum(b)": values2})
+		expression.EXPECT().Reset()
+		ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+		rs, err := ctx.ResultSet()
+		assert.NoError(t, err)
+		return rs.Series[0]
+	}
+	// fill null, the slot without data point is skipped
+	rs := emit("select sum(a), sum(b) from cpu group by time(1m)")
+	assert.Equal(t, map[int64]float64{0: 1, 2 * timeutil.OneMinute: 3}, rs.Fields["sum(a)"])
+	assert.Equal(t, map[int64]float64{timeutil.OneMinute: 2}, rs.Fields["sum(b)"])
+	// fill previous, the fields are aligned on the same time slots
+	rs = emit("select sum(a), sum(b) from cpu group by time(1m) fill(previous)")
+	assert.Equal(t, map[int64]float64{
+		0:                      1,
+		timeutil.OneMinute:     1,
+		2 * timeutil.OneMinute: 3,
+		3 * timeutil.OneMinute: 3,
+	}, rs.Fields["sum(a)"])
+	assert.Equal(t, map[int64]float64{
+		timeutil.OneMinute:     2,
+		2 * timeutil.OneMinute: 2,
+		3 * timeutil.OneMinute: 2,
+	}, rs.Fields["sum(b)"])
+	// fill value
+	rs = emit("select sum(a), sum(b) from cpu group by time(1m) fill(0)")
+	assert.Equal(t, map[int64]float64{
+		0:                      1,
+		timeutil.OneMinute:     0,
+		2 * timeutil.OneMinute: 3,
+		3 * timeutil.OneMinute: 0,
+	}, rs.Fields["sum(a)"])
+	assert.Equal(t, map[int64]float64{
+		0:                      0,
+		timeutil.OneMinute:     2,
+		2 * timeutil.OneMinute: 0,
+		3 * timeutil.OneMinute: 0,
+	}, rs.Fields["sum(b)"])
+}
+
+func TestNewResultFieldIterator(t *testing.T) {
+	tags := map[string]string{"host": "1.1.1.1"}
+	values1 := collections.NewFloatArray(10)
+	values1.SetValue(1, 10.0)
+	values2 := collections.NewFloatArray(10)
+	values2.SetValue(3, 30.0)
+	it := newResultFieldIterator(tags, map[string]collections.FloatArray{"b": values2, "a": values1, "c": nil})
+	assert.Equal(t, tags, it.Tags())
+	// sorted by name, the select item without result is ignored
+	assert.Equal(t, []field.Meta{{Name: "a"}, {Name: "b"}}, it.Fields())
+	assert.Nil(t, it.FieldIterator("c"))
+	fieldIt := it.FieldIterator("b")
+	assert.True(t, fieldIt.HasNext())
+	slot, value := fieldIt.Next()
+	assert.Equal(t, 3, slot)
+	assert.Equal(t, 30.0, value)
+	assert.False(t, fieldIt.HasNext())
+}
+
 func TestBrokerExecuteContext_ResultSet(t *testing.T) {
-	ctx := NewBrokerExecuteContext(timeutil.NowNano(), nil)
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), nil, PointsLimit{}, aggregation.SkipNaN)
 	ctx.Complete(fmt.Errorf("err"))
 	rs, err := ctx.ResultSet()
 	assert.Error(t, err)
 	assert.NotNil(t, rs)
 }
+
+func TestBrokerExecuteContext_Annotations(t *testing.T) {
+	q, err := sql.Parse("explain select f from cpu where host='a' and host='b'")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, PointsLimit{}, aggregation.SkipNaN)
+	ctx.Emit(&series.TimeSeriesEvent{
+		Stats: models.NewQueryStats(),
+	})
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.Len(t, rs.Series, 0)
+	assert.Equal(t, query.Annotations, rs.Stats.Annotations)
+	assert.Len(t, rs.Stats.Annotations, 1)
+}
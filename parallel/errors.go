@@ -9,3 +9,5 @@ var errWrongRequest = errors.New("not found task of current node from physical p
 var errNoSendStream = errors.New("not found send stream")
 var errTaskSend = errors.New("send task request error")
 var errNoDatabase = errors.New("not found database")
+var errTooManyPoints = errors.New("too many points returned for one series")
+var errOutsideRetention = errors.New("time range outside retention")
@@ -2,6 +2,7 @@ package parallel
 
 import (
 	"context"
+	"time"
 
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
@@ -29,11 +30,15 @@ type ExecutorFactory interface {
 		request *stmt.Metadata,
 	) MetadataExecutor
 
-	// NewBrokerExecutor creates the broker executor based on params
+	// NewBrokerExecutor creates the broker executor based on params,
+	// absolute time literals of sql are interpreted in the location, nil means local zone,
+	// only the fraction(sample rate) of matched series is queried if sample rate in (0, 1).
 	NewBrokerExecutor(
 		ctx context.Context,
 		databaseName string,
 		sql string,
+		location *time.Location,
+		sampleRate float64,
 		replicaStateMachine replica.StatusStateMachine,
 		nodeStateMachine broker.NodeStateMachine,
 		databaseStateMachine database.DBStateMachine,
@@ -52,4 +57,7 @@ type ExecutorFactory interface {
 
 	// NewStorageExecuteContext creates the storage execute context in storage side
 	NewStorageExecuteContext(shardIDs []int32, query *stmt.Query) StorageExecuteContext
+
+	// EstimateCost estimates the cost of query in storage side, based on the series cardinality of shards' index
+	EstimateCost(database tsdb.Database, shardIDs []int32, query *stmt.Query) float64
 }
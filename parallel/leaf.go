@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
@@ -23,20 +25,33 @@ type leafTask struct {
 	storageService    service.StorageService
 	executorFactory   ExecutorFactory
 	taskServerFactory rpc.TaskServerFactory
+
+	rejectExpiredQuery bool            // fails the query whose time range is outside retention, else returns empty result
+	admission          *queryAdmission // limits the concurrent and the estimated cost of data queries
+	marshalOption      aggregation.MarshalOption
 }
 
-// newLeafTask creates the leaf task
+// newLeafTask creates the leaf task, the limits of data query are based on query config
 func newLeafTask(
 	currentNode models.Node,
 	storageService service.StorageService,
 	executorFactory ExecutorFactory,
 	taskServerFactory rpc.TaskServerFactory,
+	cfg config.Query,
 ) TaskProcessor {
+	// nan policy is validated when storage runtime starts
+	nanPolicy, _ := aggregation.ParseNaNPolicy(cfg.NaNPolicy)
 	return &leafTask{
-		currentNodeID:     (&currentNode).Indicator(),
-		storageService:    storageService,
-		executorFactory:   executorFactory,
-		taskServerFactory: taskServerFactory,
+		currentNodeID:      (&currentNode).Indicator(),
+		storageService:     storageService,
+		executorFactory:    executorFactory,
+		taskServerFactory:  taskServerFactory,
+		rejectExpiredQuery: cfg.RejectExpiredQuery,
+		admission:          newQueryAdmission(cfg.MaxConcurrentQueries, cfg.AdmissionTimeout.Duration(), float64(cfg.MaxQueryCost)),
+		marshalOption: aggregation.MarshalOption{
+			NaNPolicy:        nanPolicy,
+			MaxSlotsPerBlock: cfg.MaxSlotsPerBlock,
+		},
 	}
 }
 
@@ -116,13 +131,44 @@ func (p *leafTask) processDataSearch(ctx context.Context, db tsdb.Database, shar
 	}
 
 	option := db.GetOption()
+	if option.Retention != "" {
+		var retention timeutil.Interval
+		_ = retention.ValueOf(option.Retention)
+		// check query time range before index lookups, partial overlap is clipped to the retained window
+		if !clipByRetention(&query, timeutil.Now()-retention.Int64()) {
+			if p.rejectExpiredQuery {
+				return errOutsideRetention
+			}
+			// complete the task with empty result
+			return stream.Send(&pb.TaskResponse{
+				JobID:     req.JobID,
+				TaskID:    req.ParentTaskID,
+				Completed: true,
+				SendTime:  timeutil.NowNano(),
+			})
+		}
+	}
 	var interval timeutil.Interval
 	_ = interval.ValueOf(option.Interval)
 	//TODO need get storage interval by query time if has rollup config
 	timeRange, intervalRatio, queryInterval := downSamplingTimeRange(query.Interval, interval, query.TimeRange)
+	// rejects the expensive query before execution, cost is estimated by series cardinality of shards' index
+	if err := p.admission.admitCost(func() float64 {
+		return p.executorFactory.EstimateCost(db, shardIDs, &query)
+	}); err != nil {
+		return err
+	}
+	// acquire query slot before index lookups, fails fast if too many concurrent queries
+	release, err := p.admission.acquire(ctx)
+	if err != nil {
+		return err
+	}
 	// execute leaf task
 	storageExecuteCtx := p.executorFactory.NewStorageExecuteContext(shardIDs, &query)
-	queryFlow := NewStorageQueryFlow(ctx, storageExecuteCtx, &query, req, stream, db.ExecutorPool(), timeRange, queryInterval, intervalRatio)
+	queryFlow := NewStorageQueryFlow(ctx, storageExecuteCtx, &query, req, stream, db.ExecutorPool(),
+		timeRange, queryInterval, intervalRatio, p.marshalOption)
+	// releases the query slot after query flow completed(including error)
+	queryFlow.(*storageQueryFlow).onCompleted = release
 	exec := p.executorFactory.NewStorageExecutor(queryFlow, db, storageExecuteCtx)
 	exec.Execute()
 	return nil
@@ -5,14 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/rpc"
 	commonmock "github.com/lindb/lindb/rpc/pbmock/common"
 	pb "github.com/lindb/lindb/rpc/proto/common"
@@ -32,7 +37,7 @@ func TestLeafTask_Process_Fail(t *testing.T) {
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 
 	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
-	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory)
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, config.Query{})
 	// unmarshal error
 	err := processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: nil})
 	assert.Equal(t, errUnmarshalPlan, err)
@@ -90,7 +95,7 @@ func TestLeafProcessor_Process(t *testing.T) {
 	executorFactory := NewMockExecutorFactory(ctrl)
 
 	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
-	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory)
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, config.Query{})
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 	plan, _ := json.Marshal(&models.PhysicalPlan{
 		Database: "test_db",
@@ -113,6 +118,150 @@ func TestLeafProcessor_Process(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLeafTask_Process_too_many_queries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	storageService := service.NewMockStorageService(ctrl)
+	executorFactory := NewMockExecutorFactory(ctrl)
+	serverStream := commonmock.NewMockTaskService_HandleServer(ctrl)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	storageService.EXPECT().GetDatabase(gomock.Any()).Return(mockDatabase, true).AnyTimes()
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream).AnyTimes()
+	mockDatabase.EXPECT().GetOption().Return(option.DatabaseOption{Interval: "10s"}).AnyTimes()
+	mockDatabase.EXPECT().ExecutorPool().Return(&tsdb.ExecutorPool{}).AnyTimes()
+	executorFactory.EXPECT().NewStorageExecuteContext(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, config.Query{
+		MaxConcurrentQueries: 2,
+		AdmissionTimeout:     ltoml.Duration(10 * time.Millisecond),
+	})
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Database: "test_db",
+		Leafs:    []models.Leaf{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}}},
+	})
+	data := encoding.JSONMarshal(&stmt.Query{MetricName: "cpu"})
+	// 2 running queries hold the slots
+	var queryFlows []flow.StorageQueryFlow
+	exec := NewMockExecutor(ctrl)
+	exec.EXPECT().Execute().Times(2)
+	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(queryFlow flow.StorageQueryFlow, _ tsdb.Database, _ StorageExecuteContext) Executor {
+			queryFlows = append(queryFlows, queryFlow)
+			return exec
+		}).Times(2)
+	for i := 0; i < 2; i++ {
+		err := processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: data})
+		assert.NoError(t, err)
+	}
+	// 3rd query is rejected
+	err := processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: data})
+	assert.Equal(t, ErrTooManyQueries, err)
+
+	// query completes with error, releases the slot
+	serverStream.EXPECT().Send(gomock.Any()).Return(nil)
+	queryFlows[0].Complete(fmt.Errorf("err"))
+	exec.EXPECT().Execute()
+	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	err = processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: data})
+	assert.NoError(t, err)
+}
+
+func TestLeafTask_Process_query_cost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	storageService := service.NewMockStorageService(ctrl)
+	executorFactory := NewMockExecutorFactory(ctrl)
+	serverStream := commonmock.NewMockTaskService_HandleServer(ctrl)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	storageService.EXPECT().GetDatabase(gomock.Any()).Return(mockDatabase, true).AnyTimes()
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream).AnyTimes()
+	mockDatabase.EXPECT().GetOption().Return(option.DatabaseOption{Interval: "10s"}).AnyTimes()
+	mockDatabase.EXPECT().ExecutorPool().Return(&tsdb.ExecutorPool{}).AnyTimes()
+	executorFactory.EXPECT().NewStorageExecuteContext(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, config.Query{MaxQueryCost: 1000})
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Database: "test_db",
+		Leafs:    []models.Leaf{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}, ShardIDs: []int32{1, 2}}},
+	})
+	data := encoding.JSONMarshal(&stmt.Query{MetricName: "cpu"})
+	// case 1: estimated cost exceeds the max cost, rejects before execution
+	executorFactory.EXPECT().EstimateCost(mockDatabase, []int32{1, 2}, gomock.Any()).Return(float64(1001))
+	err := processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: data})
+	assert.Equal(t, ErrQueryCostTooHigh, err)
+	// case 2: estimated cost within the max cost
+	executorFactory.EXPECT().EstimateCost(mockDatabase, []int32{1, 2}, gomock.Any()).Return(float64(1000))
+	exec := NewMockExecutor(ctrl)
+	exec.EXPECT().Execute()
+	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	err = processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: data})
+	assert.NoError(t, err)
+}
+
+func TestLeafTask_Process_retention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	storageService := service.NewMockStorageService(ctrl)
+	executorFactory := NewMockExecutorFactory(ctrl)
+	serverStream := commonmock.NewMockTaskService_HandleServer(ctrl)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	storageService.EXPECT().GetDatabase(gomock.Any()).Return(mockDatabase, true).AnyTimes()
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream).AnyTimes()
+	mockDatabase.EXPECT().GetOption().Return(option.DatabaseOption{Interval: "10s", Retention: "1d"}).AnyTimes()
+	mockDatabase.EXPECT().ExecutorPool().Return(&tsdb.ExecutorPool{}).AnyTimes()
+
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory,
+		config.Query{RejectExpiredQuery: true})
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Database: "test_db",
+		Leafs:    []models.Leaf{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}}},
+	})
+	now := timeutil.Now()
+	// fully expired time range
+	expired := encoding.JSONMarshal(&stmt.Query{MetricName: "cpu", TimeRange: timeutil.TimeRange{
+		Start: now - 3*timeutil.OneDay,
+		End:   now - 2*timeutil.OneDay,
+	}})
+	err := processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: expired})
+	assert.Equal(t, errOutsideRetention, err)
+	// returns empty result if not reject
+	processor = newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, config.Query{})
+	serverStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.True(t, resp.Completed)
+		assert.Empty(t, resp.Payload)
+		assert.Empty(t, resp.ErrMsg)
+		return nil
+	})
+	err = processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: expired})
+	assert.NoError(t, err)
+
+	// partial overlap, clips the time range to retained window
+	partial := encoding.JSONMarshal(&stmt.Query{MetricName: "cpu", TimeRange: timeutil.TimeRange{
+		Start: now - 3*timeutil.OneDay,
+		End:   now,
+	}})
+	exec := NewMockExecutor(ctrl)
+	exec.EXPECT().Execute()
+	executorFactory.EXPECT().NewStorageExecuteContext(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ []int32, query *stmt.Query) StorageExecuteContext {
+			assert.True(t, query.TimeRange.Start >= now-timeutil.OneDay)
+			assert.Equal(t, now, query.TimeRange.End)
+			return nil
+		})
+	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	err = processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: partial})
+	assert.NoError(t, err)
+}
+
 func TestLeafTask_Suggest_Process(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -124,7 +273,7 @@ func TestLeafTask_Suggest_Process(t *testing.T) {
 	executorFactory.EXPECT().NewMetadataStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(exec).AnyTimes()
 
 	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
-	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory)
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, config.Query{})
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 	plan, _ := json.Marshal(&models.PhysicalPlan{
 		Database: "test_db",
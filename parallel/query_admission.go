@@ -0,0 +1,83 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTooManyQueries represents the query is rejected because no query slot is available within admission timeout
+var ErrTooManyQueries = errors.New("too many concurrent queries, no query slot available within admission timeout")
+
+// ErrQueryCostTooHigh represents the query is rejected because its estimated cost exceeds the max query cost
+var ErrQueryCostTooHigh = errors.New("estimated query cost exceeds the max query cost, narrow the time range or condition")
+
+// queryAdmission represents the admission semaphore which limits the num. of concurrent data queries,
+// protects the server from exhausting memory/cpu under load.
+type queryAdmission struct {
+	slots   chan struct{} // nil means no limit
+	timeout time.Duration
+	maxCost float64 // <= 0 means no limit
+}
+
+// newQueryAdmission creates the query admission with the max num. of concurrent data queries,
+// the timeout of waiting for a query slot and the max estimated cost of data query, limit/maxCost <= 0 means no limit.
+func newQueryAdmission(limit int, timeout time.Duration, maxCost float64) *queryAdmission {
+	a := &queryAdmission{
+		timeout: timeout,
+		maxCost: maxCost,
+	}
+	if limit > 0 {
+		a.slots = make(chan struct{}, limit)
+	}
+	return a
+}
+
+// admitCost rejects the query if its estimated cost exceeds the max cost,
+// the cost is estimated only when the max cost is set, because estimation looks up the index.
+func (a *queryAdmission) admitCost(estimateCost func() float64) error {
+	if a.maxCost <= 0 {
+		return nil
+	}
+	if estimateCost() > a.maxCost {
+		return ErrQueryCostTooHigh
+	}
+	return nil
+}
+
+// acquire acquires a query slot, waits for the timeout at most if all slots are held,
+// returns the release func which must be invoked when the query completes(including error).
+func (a *queryAdmission) acquire(ctx context.Context) (release func(), err error) {
+	if a.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case a.slots <- struct{}{}:
+		return releaseSlot(a.slots), nil
+	default:
+	}
+	if a.timeout <= 0 {
+		return nil, ErrTooManyQueries
+	}
+	timer := time.NewTimer(a.timeout)
+	defer timer.Stop()
+	select {
+	case a.slots <- struct{}{}:
+		return releaseSlot(a.slots), nil
+	case <-timer.C:
+		return nil, ErrTooManyQueries
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releaseSlot returns the func which releases the query slot only once
+func releaseSlot(slots chan struct{}) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-slots
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package parallel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryAdmission_acquire(t *testing.T) {
+	admission := newQueryAdmission(2, 10*time.Millisecond, 0)
+	release1, err := admission.acquire(context.TODO())
+	assert.NoError(t, err)
+	release2, err := admission.acquire(context.TODO())
+	assert.NoError(t, err)
+	// 3rd query is rejected when 2 slots are held
+	release, err := admission.acquire(context.TODO())
+	assert.Equal(t, ErrTooManyQueries, err)
+	assert.Nil(t, release)
+
+	// release is idempotent
+	release1()
+	release1()
+	release3, err := admission.acquire(context.TODO())
+	assert.NoError(t, err)
+	_, err = admission.acquire(context.TODO())
+	assert.Equal(t, ErrTooManyQueries, err)
+
+	// waits for the slot released within timeout
+	admission = newQueryAdmission(1, time.Minute, 0)
+	release1, err = admission.acquire(context.TODO())
+	assert.NoError(t, err)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		release1()
+	}()
+	release4, err := admission.acquire(context.TODO())
+	assert.NoError(t, err)
+	// context canceled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = admission.acquire(ctx)
+	assert.Equal(t, context.Canceled, err)
+
+	// fails fast without timeout
+	admission = newQueryAdmission(1, 0, 0)
+	_, err = admission.acquire(context.TODO())
+	assert.NoError(t, err)
+	_, err = admission.acquire(context.TODO())
+	assert.Equal(t, ErrTooManyQueries, err)
+
+	release2()
+	release3()
+	release4()
+}
+
+func TestQueryAdmission_no_limit(t *testing.T) {
+	admission := newQueryAdmission(0, 0, 0)
+	for i := 0; i < 100; i++ {
+		release, err := admission.acquire(context.TODO())
+		assert.NoError(t, err)
+		assert.NotNil(t, release)
+	}
+}
+
+func TestQueryAdmission_admitCost(t *testing.T) {
+	admission := newQueryAdmission(0, 0, 0)
+	// no limit, cost isn't estimated
+	assert.NoError(t, admission.admitCost(func() float64 {
+		panic("cost is estimated without limit")
+	}))
+	admission = newQueryAdmission(0, 0, 100)
+	assert.NoError(t, admission.admitCost(func() float64 { return 100 }))
+	assert.Equal(t, ErrQueryCostTooHigh, admission.admitCost(func() float64 { return 101 }))
+}
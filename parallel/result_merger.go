@@ -70,14 +70,18 @@ func (m *resultMerger) close() {
 	// send result set
 	if m.err != nil {
 		m.resultSet <- &series.TimeSeriesEvent{Err: m.err, Stats: m.stats}
-	} else {
-		// send all series data
-		resultSet := m.groupAgg.ResultSet()
-		if len(resultSet) > 0 {
-			m.resultSet <- &series.TimeSeriesEvent{
-				SeriesList: resultSet,
-				Stats:      m.stats,
-			}
+		return
+	}
+	// send all series data
+	resultSet, err := m.groupAgg.ResultSet()
+	if err != nil {
+		m.resultSet <- &series.TimeSeriesEvent{Err: err, Stats: m.stats}
+		return
+	}
+	if len(resultSet) > 0 {
+		m.resultSet <- &series.TimeSeriesEvent{
+			SeriesList: resultSet,
+			Stats:      m.stats,
 		}
 	}
 }
@@ -112,16 +116,25 @@ func (m *resultMerger) handleEvent(resp *pb.TaskResponse) bool {
 		m.err = err
 		return false
 	}
+	seriesList := make([]series.GroupedIterator, 0, len(tsList.TimeSeriesList))
 	for _, ts := range tsList.TimeSeriesList {
-		// if no field data, ignore this response
+		// if no field data, ignore the remaining series of this response
 		if len(ts.Fields) == 0 {
-			return true
+			break
 		}
 		fields := make(map[field.Name][]byte)
 		for k, v := range ts.Fields {
 			fields[field.Name(k)] = v
 		}
-		m.groupAgg.Aggregate(series.NewGroupedIterator(ts.Tags, fields))
+		seriesList = append(seriesList, series.NewGroupedIterator(ts.Tags, fields))
+	}
+	if len(seriesList) == 0 {
+		return true
+	}
+	// the response is the partial result of one node, merges it with the result of other nodes by group
+	if err := m.groupAgg.Merge(m.groupAgg.PartialResultSet(seriesList)); err != nil {
+		m.err = err
+		return false
 	}
 	return true
 }
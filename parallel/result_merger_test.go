@@ -2,6 +2,7 @@ package parallel
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -22,7 +23,7 @@ func TestResultMerger_Merge(t *testing.T) {
 	defer ctrl.Finish()
 
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
-	groupAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
+	groupAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}, nil)
 	ch := make(chan *series.TimeSeriesEvent)
 	merger := newResultMerger(context.TODO(), groupAgg, ch)
 	c := atomic.NewInt32(0)
@@ -43,7 +44,7 @@ func TestResultMerger_Cancel(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
-	groupAgg.EXPECT().ResultSet().Return(nil)
+	groupAgg.EXPECT().ResultSet().Return(nil, nil)
 	ch := make(chan *series.TimeSeriesEvent)
 	ctx, cancel := context.WithCancel(context.TODO())
 	merger := newResultMerger(ctx, groupAgg, ch)
@@ -81,12 +82,26 @@ func TestResultMerger_Err(t *testing.T) {
 	assert.Equal(t, int32(1), c.Load())
 }
 
+func TestResultMerger_ResultSet_Err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
+	groupAgg.EXPECT().ResultSet().Return(nil, fmt.Errorf("err"))
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	merger := newResultMerger(context.TODO(), groupAgg, ch)
+	merger.close()
+	rs := <-ch
+	assert.Error(t, rs.Err)
+}
+
 func TestResultMerger_GroupBy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
-	groupAgg.EXPECT().Aggregate(gomock.Any()).AnyTimes()
-	groupAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
+	// the series of second response has no field data, which is ignored
+	groupAgg.EXPECT().PartialResultSet(gomock.Len(1)).Return(aggregation.GroupedResultSet{})
+	groupAgg.EXPECT().Merge(gomock.Any()).Return(nil)
+	groupAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}, nil)
 	ch := make(chan *series.TimeSeriesEvent)
 	merger := newResultMerger(context.TODO(), groupAgg, ch)
 	c := atomic.NewInt32(0)
@@ -125,6 +140,24 @@ func TestResultMerger_GroupBy(t *testing.T) {
 	assert.Equal(t, int32(1), c.Load())
 }
 
+func TestResultMerger_Merge_Err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
+	groupAgg.EXPECT().PartialResultSet(gomock.Any()).Return(aggregation.GroupedResultSet{})
+	groupAgg.EXPECT().Merge(gomock.Any()).Return(fmt.Errorf("err"))
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	merger := newResultMerger(context.TODO(), groupAgg, ch)
+	seriesList := pb.TimeSeriesList{
+		TimeSeriesList: []*pb.TimeSeries{{Tags: "1.1.1.1", Fields: map[string][]byte{"f1": {}}}},
+	}
+	data, _ := seriesList.Marshal()
+	merger.merge(&pb.TaskResponse{TaskID: "taskID", Payload: data})
+	merger.close()
+	rs := <-ch
+	assert.Error(t, rs.Err)
+}
+
 func TestSuggestMerge_merge(t *testing.T) {
 	ch := make(chan []string)
 	merger := newSuggestResultMerger(ch)
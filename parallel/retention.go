@@ -0,0 +1,36 @@
+package parallel
+
+import (
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// clipByRetention clips the time range(s) of query to the retained window which starts at retentionStart,
+// returns false if the whole query time range is before the retained window.
+func clipByRetention(query *stmt.Query, retentionStart int64) bool {
+	if query.TimeRange.End < retentionStart {
+		return false
+	}
+	if query.TimeRange.Start < retentionStart {
+		query.TimeRange.Start = retentionStart
+	}
+	if len(query.TimeRanges) == 0 {
+		return true
+	}
+	var timeRanges []timeutil.TimeRange
+	for _, timeRange := range query.TimeRanges {
+		if timeRange.End < retentionStart {
+			// time bucket is expired
+			continue
+		}
+		if timeRange.Start < retentionStart {
+			timeRange.Start = retentionStart
+		}
+		timeRanges = append(timeRanges, timeRange)
+	}
+	if len(timeRanges) == 0 {
+		return false
+	}
+	query.TimeRanges = timeRanges
+	return true
+}
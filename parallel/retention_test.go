@@ -0,0 +1,38 @@
+package parallel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestClipByRetention(t *testing.T) {
+	// fully expired
+	query := &stmt.Query{TimeRange: timeutil.TimeRange{Start: 10, End: 20}}
+	assert.False(t, clipByRetention(query, 30))
+	// partial overlap
+	query = &stmt.Query{TimeRange: timeutil.TimeRange{Start: 10, End: 50}}
+	assert.True(t, clipByRetention(query, 30))
+	assert.Equal(t, timeutil.TimeRange{Start: 30, End: 50}, query.TimeRange)
+	// inside retention
+	query = &stmt.Query{TimeRange: timeutil.TimeRange{Start: 40, End: 50}}
+	assert.True(t, clipByRetention(query, 30))
+	assert.Equal(t, timeutil.TimeRange{Start: 40, End: 50}, query.TimeRange)
+	// time buckets
+	query = &stmt.Query{
+		TimeRange:  timeutil.TimeRange{Start: 10, End: 60},
+		TimeRanges: []timeutil.TimeRange{{Start: 10, End: 20}, {Start: 25, End: 35}, {Start: 50, End: 60}},
+	}
+	assert.True(t, clipByRetention(query, 30))
+	assert.Equal(t, timeutil.TimeRange{Start: 30, End: 60}, query.TimeRange)
+	assert.Equal(t, []timeutil.TimeRange{{Start: 30, End: 35}, {Start: 50, End: 60}}, query.TimeRanges)
+	// all time buckets are expired
+	query = &stmt.Query{
+		TimeRange:  timeutil.TimeRange{Start: 10, End: 60},
+		TimeRanges: []timeutil.TimeRange{{Start: 10, End: 20}},
+	}
+	assert.False(t, clipByRetention(query, 30))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
@@ -32,9 +33,8 @@ var storageQueryFlowLogger = logger.GetLogger("parallel", "storageQueryFlow")
 type storageQueryFlow struct {
 	storageExecuteCtx StorageExecuteContext
 	query             *stmt.Query
-	aggPool           chan aggregation.ContainerAggregator // use aggregator for request scope
-	pendingTasks      map[int32]Stage                      // pending task ref counter for each stage
-	taskIDSeq         atomic.Int32                         // task id gen sequence
+	pendingTasks      map[int32]Stage // pending task ref counter for each stage
+	taskIDSeq         atomic.Int32    // task id gen sequence
 	executorPool      *tsdb.ExecutorPool
 	reduceAgg         aggregation.GroupingAggregator
 	stream            pb.TaskService_HandleServer
@@ -46,14 +46,16 @@ type storageQueryFlow struct {
 	queryInterval      timeutil.Interval
 	queryIntervalRatio int
 	downSamplingSpecs  aggregation.AggregatorSpecs
+	marshalOption      aggregation.MarshalOption // option of marshaling the field data of result set
 
 	tagsMap      map[string]string   // tag value ids => tag values
 	tagValuesMap []map[uint32]string // tag value id=> tag value for each group by tag key
 	tagValues    []string
 	signal       sync.WaitGroup
 
-	mux       sync.Mutex
-	completed atomic.Bool
+	mux         sync.Mutex
+	completed   atomic.Bool
+	onCompleted func() // invoked once after query flow completed, such as releasing the query slot
 }
 
 func NewStorageQueryFlow(ctx context.Context,
@@ -65,6 +67,7 @@ func NewStorageQueryFlow(ctx context.Context,
 	queryTimeRange timeutil.TimeRange,
 	queryInterval timeutil.Interval,
 	queryIntervalRatio int,
+	marshalOption aggregation.MarshalOption,
 ) flow.StorageQueryFlow {
 	return &storageQueryFlow{
 		ctx:                ctx,
@@ -77,18 +80,16 @@ func NewStorageQueryFlow(ctx context.Context,
 		queryTimeRange:     queryTimeRange,
 		queryInterval:      queryInterval,
 		queryIntervalRatio: queryIntervalRatio,
+		marshalOption:      marshalOption,
 	}
 }
 
 func (qf *storageQueryFlow) Prepare(downSamplingSpecs aggregation.AggregatorSpecs) {
-	qf.reduceAgg = aggregation.NewGroupingAggregator(qf.queryInterval, qf.queryTimeRange, downSamplingSpecs)
-	qf.aggPool = make(chan aggregation.ContainerAggregator, 64)
+	qf.reduceAgg = aggregation.NewSpillableGroupingAggregator(qf.queryInterval, qf.queryTimeRange,
+		reduceAggSpecs(downSamplingSpecs), qf.storageExecuteCtx.SpillOption())
 	qf.downSamplingSpecs = downSamplingSpecs
 	qf.allocAgg = func(aggSpecs aggregation.AggregatorSpecs) aggregation.ContainerAggregator {
-		//return aggregation.NewFieldAggregates(qf.queryInterval, qf.queryIntervalRatio, qf.queryTimeRange,
-		//	true, aggSpecs)
-		//TODO need impl
-		return nil
+		return aggregation.NewContainerAggregator(qf.queryInterval, qf.queryIntervalRatio, qf.queryTimeRange, aggSpecs)
 	}
 
 	// for group by
@@ -101,45 +102,51 @@ func (qf *storageQueryFlow) Prepare(downSamplingSpecs aggregation.AggregatorSpec
 	}
 }
 
-func (qf *storageQueryFlow) GetAggregator(highKey uint16) (agg aggregation.ContainerAggregator) {
-	select {
-	case agg = <-qf.aggPool:
-		// reuse existing aggregator
-	default:
-		// create new field aggregator
-		agg = qf.allocAgg(qf.downSamplingSpecs)
+// reduceAggSpecs returns the aggregator specs for reducing, the aliased fields have same field name,
+// so only keeps one spec for them, then data of aliased fields will be merged into one aggregator.
+// the function params(e.g. factor of sample) are applied when aggregating the series of group, not kept for reducing.
+func reduceAggSpecs(downSamplingSpecs aggregation.AggregatorSpecs) aggregation.AggregatorSpecs {
+	fieldNames := make(map[field.Name]struct{})
+	var aggSpecs aggregation.AggregatorSpecs
+	for _, aggSpec := range downSamplingSpecs {
+		if _, ok := fieldNames[aggSpec.FieldName()]; ok {
+			continue
+		}
+		fieldNames[aggSpec.FieldName()] = struct{}{}
+		reduceSpec := aggregation.NewDownSamplingSpec(aggSpec.FieldName(), aggSpec.GetFieldType())
+		for funcType := range aggSpec.Functions() {
+			reduceSpec.AddFunctionType(funcType)
+		}
+		aggSpecs = append(aggSpecs, reduceSpec)
 	}
-	return agg
+	return aggSpecs
 }
 
-// releaseAgg releases the current field aggregator for reuse in request scope
-func (qf *storageQueryFlow) releaseAgg(agg aggregation.ContainerAggregator) {
-	// 1. reset aggregator context
-	//TODO impl
-	//agg.Reset()
-
-	// 2. try put it back into pool
-	select {
-	case qf.aggPool <- agg:
-		// aggregator went back into pool
-	default:
-		// aggregator didn't go back into pool, just discard
-	}
+// GetAggregator creates the container aggregator for loading and aggregating the series of high key,
+// the aggregator is reused for each group of the high key, which is reset after reducing.
+func (qf *storageQueryFlow) GetAggregator(_ uint16) (agg aggregation.ContainerAggregator) {
+	return qf.allocAgg(qf.downSamplingSpecs)
 }
 
 // Complete completes the query flow with error
 func (qf *storageQueryFlow) Complete(err error) {
 	if err != nil && qf.completed.CAS(false, true) {
 		// if complete with err, need send err msg directly and mark task completed
-		if err := qf.stream.Send(&pb.TaskResponse{
-			JobID:     qf.req.JobID,
-			TaskID:    qf.req.ParentTaskID,
-			Completed: true,
-			ErrMsg:    err.Error(),
-		}); err != nil {
-			storageQueryFlowLogger.Error("send storage execute result", logger.Error(err))
-		}
+		qf.sendErrMsg(err)
+	}
+}
+
+// sendErrMsg sends the err msg to upstream, then notifies the query flow completed
+func (qf *storageQueryFlow) sendErrMsg(err error) {
+	if err := qf.stream.Send(&pb.TaskResponse{
+		JobID:     qf.req.JobID,
+		TaskID:    qf.req.ParentTaskID,
+		Completed: true,
+		ErrMsg:    err.Error(),
+	}); err != nil {
+		storageQueryFlowLogger.Error("send storage execute result", logger.Error(err))
 	}
+	qf.notifyCompleted()
 }
 
 func (qf *storageQueryFlow) Scanner(task concurrent.Task) {
@@ -156,9 +163,7 @@ func (qf *storageQueryFlow) Filtering(task concurrent.Task) {
 
 func (qf *storageQueryFlow) Reduce(tags string, agg aggregation.ContainerAggregator) {
 	//NOTICE: don't do reduce operator in other goroutine, because big overhead when goroutine schedule
-	defer func() {
-		qf.releaseAgg(agg)
-	}()
+	defer agg.Reset()
 
 	if qf.completed.Load() {
 		storageQueryFlowLogger.Warn("reduce the aggregator data after storage query flow completed")
@@ -168,8 +173,7 @@ func (qf *storageQueryFlow) Reduce(tags string, agg aggregation.ContainerAggrega
 	qf.mux.Lock()
 	defer qf.mux.Unlock()
 
-	//TODO impl
-	//qf.reduceAgg.Aggregate(agg.ResultSet(tags))
+	qf.reduceAgg.Aggregate(agg.ResultSet(tags))
 }
 
 // ReduceTagValues reduces the group by tag values
@@ -197,7 +201,17 @@ func (qf *storageQueryFlow) getTagValues(tags string) string {
 			qf.tagValues[idx] = tagValueNotFound
 		}
 	}
-	tagsOfStr := tag.ConcatTagValues(qf.tagValues)
+	var tagsOfStr string
+	if qf.query.GroupByAll {
+		// group by *, tag keys are expanded by storage, so returns tag keys with values
+		tagsMap := make(map[string]string, len(qf.tagValues))
+		for idx, tagKey := range qf.query.GroupBy {
+			tagsMap[tagKey] = qf.tagValues[idx]
+		}
+		tagsOfStr = tag.Concat(tagsMap)
+	} else {
+		tagsOfStr = tag.ConcatTagValues(qf.tagValues)
+	}
 	qf.tagsMap[tags] = tagsOfStr
 	return tagsOfStr
 }
@@ -212,47 +226,10 @@ func (qf *storageQueryFlow) completeTask(taskID int32) {
 
 	if completed && qf.completed.CAS(false, true) {
 		// if all tasks of all stages completed
-		var data []byte
-		if qf.reduceAgg != nil {
-			hasGroupBy := qf.query.HasGroupBy()
-			if hasGroupBy {
-				qf.signal.Wait() // wait collect group by tag value complete
-			}
-			// 1. get reduce aggregator result set
-			groupedSeriesList := qf.reduceAgg.ResultSet()
-			// 2. build rpc response data
-			var timeSeriesList []*pb.TimeSeries
-			for _, ts := range groupedSeriesList {
-				fields := make(map[string][]byte)
-				for ts.HasNext() {
-					fieldIt := ts.Next()
-					data, err := fieldIt.MarshalBinary()
-					if err != nil || len(data) == 0 {
-						if err != nil {
-							storageQueryFlowLogger.Error("marshal iterator data", logger.Error(err))
-						}
-						continue
-					}
-
-					fields[string(fieldIt.FieldName())] = data
-				}
-				if len(fields) > 0 {
-					tags := ""
-					if hasGroupBy {
-						tags = qf.getTagValues(ts.Tags())
-					}
-					timeSeriesList = append(timeSeriesList, &pb.TimeSeries{
-						Tags:   tags,
-						Fields: fields,
-					})
-				}
-			}
-
-			seriesList := pb.TimeSeriesList{
-				TimeSeriesList: timeSeriesList,
-			}
-			// no error
-			data, _ = seriesList.Marshal()
+		data, err := qf.marshalResultSet()
+		if err != nil {
+			qf.sendErrMsg(err)
+			return
 		}
 
 		var stats []byte
@@ -270,6 +247,64 @@ func (qf *storageQueryFlow) completeTask(taskID int32) {
 		}); err != nil {
 			storageQueryFlowLogger.Error("send storage execute result", logger.Error(err))
 		}
+		qf.notifyCompleted()
+	}
+}
+
+// marshalResultSet marshals the result set of reduce aggregator as rpc response data
+func (qf *storageQueryFlow) marshalResultSet() ([]byte, error) {
+	if qf.reduceAgg == nil {
+		return nil, nil
+	}
+	hasGroupBy := qf.query.HasGroupBy()
+	if hasGroupBy {
+		qf.signal.Wait() // wait collect group by tag value complete
+	}
+	// 1. get reduce aggregator result set
+	groupedSeriesList, err := qf.reduceAgg.ResultSet()
+	if err != nil {
+		return nil, err
+	}
+	// 2. build rpc response data
+	var timeSeriesList []*pb.TimeSeries
+	for _, ts := range groupedSeriesList {
+		fields := make(map[string][]byte)
+		for ts.HasNext() {
+			fieldIt := ts.Next()
+			data, err := aggregation.MarshalIterator(fieldIt, qf.marshalOption)
+			if err != nil || len(data) == 0 {
+				if err != nil {
+					storageQueryFlowLogger.Error("marshal iterator data", logger.Error(err))
+				}
+				continue
+			}
+
+			fields[string(fieldIt.FieldName())] = data
+		}
+		if len(fields) > 0 {
+			tags := ""
+			if hasGroupBy {
+				tags = qf.getTagValues(ts.Tags())
+			}
+			timeSeriesList = append(timeSeriesList, &pb.TimeSeries{
+				Tags:   tags,
+				Fields: fields,
+			})
+		}
+	}
+
+	seriesList := pb.TimeSeriesList{
+		TimeSeriesList: timeSeriesList,
+	}
+	// no error
+	data, _ := seriesList.Marshal()
+	return data, nil
+}
+
+// notifyCompleted invokes the completed hook if set
+func (qf *storageQueryFlow) notifyCompleted() {
+	if qf.onCompleted != nil {
+		qf.onCompleted()
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"runtime"
 	"sync"
 	"testing"
@@ -13,8 +15,11 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/concurrent"
+	"github.com/lindb/lindb/pkg/stream"
 	"github.com/lindb/lindb/pkg/timeutil"
 	commonmock "github.com/lindb/lindb/rpc/pbmock/common"
 	pb "github.com/lindb/lindb/rpc/proto/common"
@@ -43,25 +48,47 @@ var testExecPool = &tsdb.ExecutorPool{
 func TestStorageQueryFlow_GetAggregator(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
+	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{}).AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
-	queryFlow := NewStorageQueryFlow(context.TODO(), nil, &stmt.Query{GroupBy: []string{"host"}},
+	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{GroupBy: []string{"host"}},
 		&pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
 	queryFlow.Prepare(nil)
 
 	agg := queryFlow.GetAggregator(1)
-	assert.Nil(t, agg)
+	assert.NotNil(t, agg)
+	agg2 := queryFlow.GetAggregator(1)
+	assert.False(t, agg == agg2)
+}
 
+func TestStorageQueryFlow_Reduce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{}).AnyTimes()
+	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
+	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{},
+		&pb.TaskRequest{}, streamHandler, testExecPool,
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
+	queryFlow.Prepare(nil)
 	qf := queryFlow.(*storageQueryFlow)
-	qf.releaseAgg(agg)
-
-	agg2 := queryFlow.GetAggregator(1)
-	assert.Nil(t, agg2)
-	assert.Equal(t, agg, agg2)
+	reduceAgg := aggregation.NewMockGroupingAggregator(ctrl)
+	qf.reduceAgg = reduceAgg
 
-	for i := 0; i < 100; i++ {
-		qf.releaseAgg(agg)
-	}
+	agg := aggregation.NewMockContainerAggregator(ctrl)
+	groupedIt := series.NewMockGroupedIterator(ctrl)
+	// case 1: reduce the result set of group, then reset aggregator for next group
+	gomock.InOrder(
+		agg.EXPECT().ResultSet("1.1.1.1").Return(groupedIt),
+		reduceAgg.EXPECT().Aggregate(groupedIt),
+		agg.EXPECT().Reset(),
+	)
+	queryFlow.Reduce("1.1.1.1", agg)
+	// case 2: reduce after query flow completed
+	qf.completed.Store(true)
+	agg.EXPECT().Reset()
+	queryFlow.Reduce("1.1.1.1", agg)
 }
 
 func TestStorageQueryFlow_Execute(t *testing.T) {
@@ -69,16 +96,17 @@ func TestStorageQueryFlow_Execute(t *testing.T) {
 	defer ctrl.Finish()
 
 	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{}).AnyTimes()
 	storageExecuteCtx.EXPECT().QueryStats().Return(models.NewStorageStats()).AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	streamHandler.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{}, &pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
 	queryFlow.Prepare(nil)
 	qf := queryFlow.(*storageQueryFlow)
 	reduceAgg := aggregation.NewMockGroupingAggregator(ctrl)
 	qf.reduceAgg = reduceAgg
-	reduceAgg.EXPECT().ResultSet().Return(nil).AnyTimes()
+	reduceAgg.EXPECT().ResultSet().Return(nil, nil).AnyTimes()
 	reduceAgg.EXPECT().Aggregate(gomock.Any()).AnyTimes()
 
 	var wait sync.WaitGroup
@@ -119,12 +147,13 @@ func TestStorageQueryFlow_completeTask(t *testing.T) {
 	defer ctrl.Finish()
 
 	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{}).AnyTimes()
 	storageExecuteCtx.EXPECT().QueryStats().Return(nil).AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	streamHandler.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{},
 		&pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
 	queryFlow.Prepare(nil)
 	qf := queryFlow.(*storageQueryFlow)
 	// case 1: test execute task after completed
@@ -135,26 +164,31 @@ func TestStorageQueryFlow_completeTask(t *testing.T) {
 
 	// case 2: test reduce result send
 	queryFlow = NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{GroupBy: []string{"host"}}, &pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
 	queryFlow.Prepare(nil)
 	qf = queryFlow.(*storageQueryFlow)
 	reduceAgg := aggregation.NewMockGroupingAggregator(ctrl)
 	qf.reduceAgg = reduceAgg
 	groupIt := series.NewMockGroupedIterator(ctrl)
-	it := series.NewMockIterator(ctrl)
-	groupIt.EXPECT().HasNext().Return(true)
+	mockSeries := func(data []byte, err error) *series.MockIterator {
+		it := series.NewMockIterator(ctrl)
+		fIt := series.NewMockFieldIterator(ctrl)
+		it.EXPECT().FieldType().Return(field.SumField)
+		it.EXPECT().HasNext().Return(true)
+		it.EXPECT().Next().Return(int64(10), fIt)
+		fIt.EXPECT().MarshalBinary().Return(data, err)
+		it.EXPECT().HasNext().Return(false).MaxTimes(1)
+		return it
+	}
 	groupIt.EXPECT().Tags().Return("1.1.1.1").AnyTimes()
-	groupIt.EXPECT().Next().Return(it)
-	it.EXPECT().MarshalBinary().Return(nil, nil)
 	groupIt.EXPECT().HasNext().Return(true)
-	it.EXPECT().MarshalBinary().Return(nil, fmt.Errorf("err"))
-	groupIt.EXPECT().Next().Return(it)
+	groupIt.EXPECT().Next().Return(mockSeries(nil, fmt.Errorf("err")))
+	it := mockSeries([]byte{1, 2, 3}, nil)
+	it.EXPECT().FieldName().Return(field.Name("f1"))
 	groupIt.EXPECT().HasNext().Return(true)
 	groupIt.EXPECT().Next().Return(it)
-	it.EXPECT().MarshalBinary().Return([]byte{1, 2, 3}, nil)
-	it.EXPECT().FieldName().Return(field.Name("f1"))
 	groupIt.EXPECT().HasNext().Return(false)
-	reduceAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{groupIt})
+	reduceAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{groupIt}, nil)
 	var wait1 sync.WaitGroup
 	wait1.Add(1)
 	queryFlow.Filtering(func() {
@@ -167,10 +201,43 @@ func TestStorageQueryFlow_completeTask(t *testing.T) {
 	time.Sleep(300 * time.Millisecond)
 }
 
+func TestStorageQueryFlow_completeTask_ResultSet_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{}).AnyTimes()
+	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
+	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{},
+		&pb.TaskRequest{}, streamHandler, testExecPool,
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
+	queryFlow.Prepare(nil)
+	qf := queryFlow.(*storageQueryFlow)
+	reduceAgg := aggregation.NewMockGroupingAggregator(ctrl)
+	qf.reduceAgg = reduceAgg
+	reduceAgg.EXPECT().ResultSet().Return(nil, fmt.Errorf("spill err"))
+	var wait sync.WaitGroup
+	wait.Add(1)
+	// err msg of reduce aggregator is sent to upstream
+	streamHandler.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.True(t, resp.Completed)
+		assert.Equal(t, "spill err", resp.ErrMsg)
+		wait.Done()
+		return nil
+	})
+	queryFlow.Filtering(func() {})
+	wait.Wait()
+}
+
 func TestStorageQueryFlow_getValues(t *testing.T) {
-	queryFlow := NewStorageQueryFlow(context.TODO(), nil, &stmt.Query{},
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{}).AnyTimes()
+	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{},
 		&pb.TaskRequest{}, nil, nil,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
 	queryFlow.Prepare(nil)
 	qf := queryFlow.(*storageQueryFlow)
 	qf.tagValues = make([]string, 2)
@@ -185,6 +252,12 @@ func TestStorageQueryFlow_getValues(t *testing.T) {
 	// case 2: get from cache
 	tags = qf.getTagValues(string(tagValueIDs))
 	assert.Equal(t, tag.ConcatTagValues([]string{"1.1.1.1", "1.1.1.2"}), tags)
+	// case 3: group by *, returns tag keys with values
+	qf.query.GroupByAll = true
+	qf.query.GroupBy = []string{"host", "ip"}
+	qf.tagsMap = make(map[string]string)
+	tags = qf.getTagValues(string(tagValueIDs))
+	assert.Equal(t, "host=1.1.1.1,ip=1.1.1.2", tags)
 }
 
 func TestStorageQueryFlow_Task_panic(t *testing.T) {
@@ -192,11 +265,12 @@ func TestStorageQueryFlow_Task_panic(t *testing.T) {
 	defer ctrl.Finish()
 
 	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{}).AnyTimes()
 	storageExecuteCtx.EXPECT().QueryStats().Return(nil).AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	streamHandler.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{}, &pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
 	queryFlow.Prepare(nil)
 	var wait sync.WaitGroup
 	wait.Add(3)
@@ -221,12 +295,118 @@ func TestStorageQueryFlow_Complete(t *testing.T) {
 	defer ctrl.Finish()
 
 	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{}).AnyTimes()
 	storageExecuteCtx.EXPECT().QueryStats().Return(nil).AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{}, &pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1, aggregation.MarshalOption{})
+	completed := 0
+	queryFlow.(*storageQueryFlow).onCompleted = func() { completed++ }
 	queryFlow.Complete(nil) // err is nil, need not send err result
 	streamHandler.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
 	queryFlow.Complete(fmt.Errorf("err")) // send err result
 	queryFlow.Complete(fmt.Errorf("err")) // no send err result
+	assert.Equal(t, 1, completed)
+}
+
+func TestStorageQueryFlow_reduceAggSpecs(t *testing.T) {
+	assert.Empty(t, reduceAggSpecs(nil))
+	usage := aggregation.NewDownSamplingSpec("usage_pct", field.SumField)
+	aliased := aggregation.NewDownSamplingSpec("usage_pct", field.SumField)
+	idle := aggregation.NewDownSamplingSpec("idle", field.SumField)
+	assert.Equal(t, aggregation.AggregatorSpecs{usage, idle},
+		reduceAggSpecs(aggregation.AggregatorSpecs{usage, aliased, idle}))
+
+	sample := aggregation.NewDownSamplingSpec("usage_pct", field.GaugeField)
+	sample.AddFunctionType(function.Sample)
+	assert.NoError(t, sample.SetFunctionParam(function.Sample, 3))
+	specs := reduceAggSpecs(aggregation.AggregatorSpecs{sample})
+	assert.Len(t, specs, 1)
+	assert.Equal(t, sample.Functions(), specs[0].Functions())
+	assert.Equal(t, int64(0), specs[0].FunctionParam(function.Sample))
+}
+
+func TestStorageQueryFlow_spill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dir, err := ioutil.TempDir("", "spill")
+	assert.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().SpillOption().Return(aggregation.SpillOption{MaxGroups: 3, Dir: dir})
+	storageExecuteCtx.EXPECT().QueryStats().Return(nil).AnyTimes()
+	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
+	familyTime, _ := timeutil.ParseTimestamp("20190702 19:00:00", "20060102 15:04:05")
+	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{GroupBy: []string{"host"}},
+		&pb.TaskRequest{}, streamHandler, testExecPool,
+		timeutil.TimeRange{Start: familyTime, End: familyTime + timeutil.OneMinute}, timeutil.Interval(10*timeutil.OneSecond), 1, aggregation.MarshalOption{})
+	aggSpec := aggregation.NewDownSamplingSpec("f", field.SumField)
+	aggSpec.AddFunctionType(function.Sum)
+	queryFlow.Prepare(aggregation.AggregatorSpecs{aggSpec})
+	qf := queryFlow.(*storageQueryFlow)
+
+	// reduces the series of 10 hosts twice, more than max groups in memory
+	hosts := make(map[uint32]string)
+	for round := 0; round < 2; round++ {
+		for i := uint32(0); i < 10; i++ {
+			hosts[i] = fmt.Sprintf("host-%d", i)
+			tags := make([]byte, 4)
+			binary.LittleEndian.PutUint32(tags, i)
+			values := collections.NewFloatArray(2)
+			values.SetValue(0, float64(i))
+			values.SetValue(1, 1)
+			fieldData, err := aggregation.NewFieldIterator(0, field.Sum, values).MarshalBinary()
+			assert.NoError(t, err)
+			writer := stream.NewBufferWriter(nil)
+			writer.PutByte(byte(field.SumField))
+			writer.PutVarint64(familyTime)
+			writer.PutBytes(fieldData)
+			data, err := writer.Bytes()
+			assert.NoError(t, err)
+			qf.reduceAgg.Aggregate(series.NewGroupedIterator(string(tags), map[field.Name][]byte{"f": data}))
+		}
+	}
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+	queryFlow.ReduceTagValues(0, hosts)
+
+	// merges the spilled groups and in-memory groups into result
+	result := make(map[string]map[int]float64)
+	var wait sync.WaitGroup
+	wait.Add(1)
+	streamHandler.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		defer wait.Done()
+		assert.Empty(t, resp.ErrMsg)
+		seriesList := pb.TimeSeriesList{}
+		assert.NoError(t, seriesList.Unmarshal(resp.Payload))
+		for _, ts := range seriesList.TimeSeriesList {
+			points := make(map[int]float64)
+			it := series.NewIterator("f", ts.Fields["f"])
+			for it.HasNext() {
+				startTime, fIt := it.Next()
+				assert.Equal(t, familyTime, startTime)
+				for fIt.HasNext() {
+					slot, value := fIt.Next()
+					points[slot] = value
+				}
+			}
+			assert.NoError(t, it.Error())
+			result[ts.Tags] = points
+		}
+		return nil
+	})
+	queryFlow.Filtering(func() {})
+	wait.Wait()
+	assert.Len(t, result, 10)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, map[int]float64{0: float64(2 * i), 1: 2}, result[fmt.Sprintf("host-%d", i)])
+	}
+	// spilled files are removed
+	files, err = ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
 }
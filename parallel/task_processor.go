@@ -3,6 +3,7 @@ package parallel
 import (
 	"context"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
@@ -31,12 +32,13 @@ type leafTaskDispatcher struct {
 	logger    *logger.Logger
 }
 
-// NewLeafTaskDispatcher creates a leaf task dispatcher
+// NewLeafTaskDispatcher creates a leaf task dispatcher, the data query is limited by query config
 func NewLeafTaskDispatcher(currentNode models.Node,
 	storageService service.StorageService,
-	executorFactory ExecutorFactory, taskServerFactory rpc.TaskServerFactory) TaskDispatcher {
+	executorFactory ExecutorFactory, taskServerFactory rpc.TaskServerFactory,
+	cfg config.Query) TaskDispatcher {
 	return &leafTaskDispatcher{
-		processor: newLeafTask(currentNode, storageService, executorFactory, taskServerFactory),
+		processor: newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, cfg),
 		logger:    logger.GetLogger("parallel", "LeafTaskDispatcher"),
 	}
 }
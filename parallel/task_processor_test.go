@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	commonmock "github.com/lindb/lindb/rpc/pbmock/common"
 	pb "github.com/lindb/lindb/rpc/proto/common"
@@ -18,7 +19,7 @@ func TestLeafTaskDispatcher_Dispatch(t *testing.T) {
 
 	server := commonmock.NewMockTaskService_HandleServer(ctrl)
 	server.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
-	leafTaskDispatcher := NewLeafTaskDispatcher(models.Node{IP: "1.1.1.1", Port: 9000}, nil, nil, nil)
+	leafTaskDispatcher := NewLeafTaskDispatcher(models.Node{IP: "1.1.1.1", Port: 9000}, nil, nil, nil, config.Query{})
 	leafTaskDispatcher.Dispatch(context.TODO(), server, &pb.TaskRequest{PhysicalPlan: []byte{1, 1, 1}})
 }
 
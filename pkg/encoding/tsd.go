@@ -3,6 +3,7 @@ package encoding
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"sync"
 
 	"github.com/lindb/lindb/pkg/bit"
@@ -49,6 +50,101 @@ type TSDEncoder interface {
 	BytesWithoutTime() ([]byte, error)
 }
 
+// CodecID represents the value codec of tsd block, the header of block records the codec id,
+// xor codec is written as bit 0, rle codec as bits 10, delta codec as bits 11.
+type CodecID uint8
+
+// Defines all value codecs of tsd block
+const (
+	// XORCodec writes every value using xor compress
+	XORCodec CodecID = iota
+	// RLECodec writes a run length after value for identical values of consecutive present slots
+	RLECodec
+	// DeltaCodec writes the delta of consecutive values using xor compress, good for counter
+	DeltaCodec
+)
+
+// Defines all format versions of tsd block
+const (
+	// TSDVersion1 is the format before value codec is supported, the block has no codec id, all values are xor compressed
+	TSDVersion1 byte = iota + 1
+	// TSDVersion2 writes the value codec id before the first time slot
+	TSDVersion2
+)
+
+// TSDVersion represents the format version of tsd block written by encoder
+const TSDVersion = TSDVersion2
+
+// maxRunLength is the max num. of repeated values in a run
+const maxRunLength = 1<<16 - 1
+
+// String returns the string value of codec
+func (c CodecID) String() string {
+	switch c {
+	case RLECodec:
+		return "rle"
+	case DeltaCodec:
+		return "delta"
+	default:
+		return "xor"
+	}
+}
+
+// writeCodecID writes the codec id of block
+func writeCodecID(writer *bit.Writer, codec CodecID) error {
+	if codec == XORCodec {
+		return writer.WriteBit(bit.Zero)
+	}
+	if err := writer.WriteBit(bit.One); err != nil {
+		return err
+	}
+	return writer.WriteBit(bit.Bit(codec == DeltaCodec))
+}
+
+// readCodecID reads the codec id of block, empty block is regarded as xor codec
+func readCodecID(reader *bit.Reader) CodecID {
+	b, err := reader.ReadBit()
+	if err != nil || b == bit.Zero {
+		return XORCodec
+	}
+	b, err = reader.ReadBit()
+	if err != nil {
+		return XORCodec
+	}
+	if b == bit.One {
+		return DeltaCodec
+	}
+	return RLECodec
+}
+
+// readBlockCodecID reads the codec id of block by format version, the block before version 2 is xor codec
+func readBlockCodecID(reader *bit.Reader, version byte) CodecID {
+	if version < TSDVersion2 {
+		return XORCodec
+	}
+	return readCodecID(reader)
+}
+
+// ErrInvalidTSDHeader represents the header of tsd block is too short
+var ErrInvalidTSDHeader = errors.New("invalid tsd block header")
+
+// tsdHeaderSize represents the size of time slot range in tsd block header
+const tsdHeaderSize = 4
+
+// DecodeTSDHeader reads the time slot range and value codec from the header of tsd block,
+// the values of block are not decoded.
+func DecodeTSDHeader(data []byte) (startTime, endTime uint16, codec CodecID, err error) {
+	if len(data) < tsdHeaderSize {
+		return 0, 0, XORCodec, ErrInvalidTSDHeader
+	}
+	startTime = binary.LittleEndian.Uint16(data[0:2])
+	endTime = binary.LittleEndian.Uint16(data[2:4])
+	buf := bufioutil.NewBuffer(data)
+	buf.SetIdx(tsdHeaderSize)
+	codec = readCodecID(bit.NewReader(buf))
+	return startTime, endTime, codec, nil
+}
+
 // TSDEncoder encodes time series data point
 type tsdEncoder struct {
 	startTime uint16
@@ -56,6 +152,7 @@ type tsdEncoder struct {
 	bitWriter *bit.Writer
 	values    *XOREncoder
 	count     uint16
+	hasMode   bool
 	err       error
 }
 
@@ -72,6 +169,7 @@ func (e *tsdEncoder) Reset() {
 	e.bitBuffer.Reset()
 	e.bitWriter.Reset(&e.bitBuffer)
 	e.values.Reset()
+	e.hasMode = false
 }
 
 // AppendTime appends time slot, marks time slot if has data point
@@ -79,6 +177,13 @@ func (e *tsdEncoder) AppendTime(slot bit.Bit) {
 	if e.err != nil {
 		return
 	}
+	if !e.hasMode {
+		// writes codec id before the first time slot
+		e.hasMode = true
+		if e.err = writeCodecID(e.bitWriter, XORCodec); e.err != nil {
+			return
+		}
+	}
 	e.err = e.bitWriter.WriteBit(slot)
 	e.count++
 }
@@ -127,6 +232,186 @@ func flush(writer *bit.Writer) error {
 	return writer.Flush()
 }
 
+// codecSampleSize is the num. of leading values of block which are used to pick the codec of adaptive encoder
+const codecSampleSize = 64
+
+// adaptiveTSDEncoder implements TSDEncoder interface, buffers the data points of block,
+// chooses the codec adaptively which has the smallest size on the leading values of block when returns binary.
+type adaptiveTSDEncoder struct {
+	startTime uint16
+	codecs    []CodecID
+	slots     []bit.Bit
+	values    []uint64
+}
+
+// NewRLETSDEncoder creates tsd encoder instance which supports run-length encoding for identical values,
+// it is suitable for the field like gauge which reports the same value for long stretches.
+func NewRLETSDEncoder(startTime uint16) TSDEncoder {
+	return &adaptiveTSDEncoder{startTime: startTime, codecs: []CodecID{XORCodec, RLECodec}}
+}
+
+// NewDeltaTSDEncoder creates tsd encoder instance which supports delta encoding for consecutive values,
+// it is suitable for the field like counter which increases steadily.
+func NewDeltaTSDEncoder(startTime uint16) TSDEncoder {
+	return &adaptiveTSDEncoder{startTime: startTime, codecs: []CodecID{XORCodec, DeltaCodec}}
+}
+
+// NewTSDEncoderWithCodec creates tsd encoder instance by codec
+func NewTSDEncoderWithCodec(codec CodecID, startTime uint16) TSDEncoder {
+	switch codec {
+	case RLECodec:
+		return NewRLETSDEncoder(startTime)
+	case DeltaCodec:
+		return NewDeltaTSDEncoder(startTime)
+	default:
+		return TSDEncodeFunc(startTime)
+	}
+}
+
+// AppendTime appends time slot, marks time slot if has data point
+func (e *adaptiveTSDEncoder) AppendTime(slot bit.Bit) {
+	e.slots = append(e.slots, slot)
+}
+
+// AppendValue appends data point value
+func (e *adaptiveTSDEncoder) AppendValue(value uint64) {
+	e.values = append(e.values, value)
+}
+
+// Reset resets the buffered data points
+func (e *adaptiveTSDEncoder) Reset() {
+	e.slots = e.slots[:0]
+	e.values = e.values[:0]
+}
+
+// Bytes returns binary which compress time series data point
+func (e *adaptiveTSDEncoder) Bytes() ([]byte, error) {
+	data, err := e.BytesWithoutTime()
+	if err != nil {
+		return nil, err
+	}
+	count := uint16(len(e.slots))
+	if count == 0 {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	writer := stream.NewBufferWriter(&buf)
+	writer.PutUInt16(e.startTime)
+	writer.PutUInt16(e.startTime + count - 1)
+	writer.PutBytes(data)
+	return writer.Bytes()
+}
+
+// BytesWithoutTime returns binary which compress time series data point without time slot range,
+// picks the codec by encoding the sample of block with each codec, then encodes the whole block with it.
+func (e *adaptiveTSDEncoder) BytesWithoutTime() ([]byte, error) {
+	slots, values := e.sample()
+	codec := XORCodec
+	var result []byte
+	for idx, c := range e.codecs {
+		data, err := encodeWithCodec(c, slots, values)
+		if err != nil {
+			return nil, err
+		}
+		if idx == 0 || len(data) < len(result) {
+			codec = c
+			result = data
+		}
+	}
+	if len(slots) == len(e.slots) {
+		// the sample is the whole block
+		return result, nil
+	}
+	return e.encode(codec)
+}
+
+// sample returns the leading data points of block which have at most codecSampleSize values
+func (e *adaptiveTSDEncoder) sample() (slots []bit.Bit, values []uint64) {
+	if len(e.values) <= codecSampleSize {
+		return e.slots, e.values
+	}
+	count := 0
+	for idx, slot := range e.slots {
+		if slot != bit.One {
+			continue
+		}
+		count++
+		if count == codecSampleSize {
+			return e.slots[:idx+1], e.values[:count]
+		}
+	}
+	return e.slots, e.values
+}
+
+// encode encodes the buffered data points with codec
+func (e *adaptiveTSDEncoder) encode(codec CodecID) ([]byte, error) {
+	return encodeWithCodec(codec, e.slots, e.values)
+}
+
+// encodeWithCodec encodes the data points with codec
+func encodeWithCodec(codec CodecID, slots []bit.Bit, values []uint64) ([]byte, error) {
+	if len(slots) == 0 {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	writer := bit.NewWriter(&buf)
+	encoder := NewXOREncoder(writer)
+	if err := writeCodecID(writer, codec); err != nil {
+		return nil, err
+	}
+	idx := 0
+	repeat := 0
+	prev := uint64(0)
+	for _, slot := range slots {
+		if err := writer.WriteBit(slot); err != nil {
+			return nil, err
+		}
+		if slot != bit.One || idx >= len(values) {
+			continue
+		}
+		value := values[idx]
+		idx++
+		if codec == DeltaCodec {
+			// delta may overflow, wraps around when decodes
+			value, prev = value-prev, value
+		}
+		if repeat > 0 {
+			// value is in the run, skip it
+			repeat--
+			continue
+		}
+		if err := encoder.Write(value); err != nil {
+			return nil, err
+		}
+		if codec != RLECodec {
+			continue
+		}
+		for idx+repeat < len(values) && values[idx+repeat] == value && repeat < maxRunLength {
+			repeat++
+		}
+		if repeat == 0 {
+			if err := writer.WriteBit(bit.Zero); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := writer.WriteBit(bit.One); err != nil {
+			return nil, err
+		}
+		if err := writer.WriteBits(uint64(repeat), 16); err != nil {
+			return nil, err
+		}
+	}
+	if err := flushFunc(writer); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ValuePredicate represents the predicate of data point value pushed down into tsd decoder,
+// the value is the raw bits of data point stored in block.
+type ValuePredicate func(value uint64) bool
+
 // TSDDecoder decodes time series compress data
 type TSDDecoder struct {
 	startTime, endTime uint16
@@ -137,6 +422,13 @@ type TSDDecoder struct {
 
 	idx uint16
 
+	codec  CodecID // value codec of block
+	repeat uint16  // remaining num. of repeated values in current run
+	prev   uint64  // previous value for delta codec
+
+	predicate ValuePredicate // value predicate pushed down, nil matches all values
+	matched   bool           // if the value of current run is matched by predicate
+
 	err error
 }
 
@@ -151,12 +443,19 @@ func NewTSDDecoder(data []byte) *TSDDecoder {
 
 // ResetWithTimeRange resets tsd data and reads the meta info from the data with time range
 func (d *TSDDecoder) ResetWithTimeRange(data []byte, start, end uint16) {
+	d.ResetWithVersion(data, start, end, TSDVersion)
+}
+
+// ResetWithVersion resets tsd data with time range, the data is written by given format version,
+// e.g. the block of sst file flushed before value codec is supported.
+func (d *TSDDecoder) ResetWithVersion(data []byte, start, end uint16, version byte) {
 	d.reset(data)
 
 	d.startTime = start
 	d.endTime = end
 
 	d.reader.Reset()
+	d.codec = readBlockCodecID(d.reader, version)
 }
 
 // Reset resets tsd data and reads the meta info from the data
@@ -168,6 +467,7 @@ func (d *TSDDecoder) Reset(data []byte) {
 	d.buf.SetIdx(4)
 
 	d.reader.Reset()
+	d.codec = readCodecID(d.reader)
 }
 
 func (d *TSDDecoder) reset(data []byte) {
@@ -181,6 +481,16 @@ func (d *TSDDecoder) reset(data []byte) {
 	}
 	d.idx = 0
 	d.err = nil
+	d.codec = XORCodec
+	d.repeat = 0
+	d.prev = 0
+	d.predicate = nil
+	d.matched = false
+}
+
+// Codec returns the value codec of block
+func (d *TSDDecoder) Codec() CodecID {
+	return d.codec
 }
 
 // Error returns decode error
@@ -241,10 +551,68 @@ func (d *TSDDecoder) Value() uint64 {
 	if d.values == nil {
 		return 0
 	}
-	if d.values.Next() {
+	if d.repeat > 0 {
+		// expands the run
+		d.repeat--
 		return d.values.Value()
 	}
-	return 0
+	if !d.values.Next() {
+		return 0
+	}
+	switch d.codec {
+	case DeltaCodec:
+		d.prev += d.values.Value()
+		return d.prev
+	case RLECodec:
+		hasRun, err := d.reader.ReadBit()
+		if err != nil {
+			d.err = err
+			return 0
+		}
+		if hasRun == bit.One {
+			repeat, err := d.reader.ReadBits(16)
+			if err != nil {
+				d.err = err
+				return 0
+			}
+			d.repeat = uint16(repeat)
+		}
+	}
+	return d.values.Value()
+}
+
+// SetPredicate sets the value predicate pushed down into decoder, which is used by NextMatched,
+// the predicate is cleared when decoder resets.
+func (d *TSDDecoder) SetPredicate(predicate ValuePredicate) {
+	d.predicate = predicate
+}
+
+// NextMatched moves to the next time slot which has value matched by predicate,
+// returns the slot and value, returns false if no more slot or decode failure.
+// For rle codec, the predicate is evaluated once per run, the repeated values of run not matched
+// are skipped without comparing; for other codecs, every value is decoded then filtered.
+func (d *TSDDecoder) NextMatched() (slot uint16, value uint64, ok bool) {
+	for d.Next() {
+		if !d.HasValue() {
+			if d.err != nil {
+				return 0, 0, false
+			}
+			continue
+		}
+		inRun := d.repeat > 0
+		value = d.Value()
+		if d.err != nil {
+			return 0, 0, false
+		}
+		if !inRun {
+			// evaluates the predicate for the head of run, or each value of non-rle codec
+			d.matched = d.predicate == nil || d.predicate(value)
+		}
+		if d.matched {
+			return d.Slot(), value, true
+		}
+	}
+	return 0, 0, false
 }
 
 // DecodeTSDTime decodes start-time-slot and end-time-slot of tsd.
@@ -1,7 +1,9 @@
 package encoding
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -79,6 +81,55 @@ func TestCodec(t *testing.T) {
 	assert.Len(t, data, 4)
 }
 
+func TestTSDDecoder_ResetWithVersion(t *testing.T) {
+	// block of version 1 has no codec id, time slot and xor compressed value are written one by one
+	var buf bytes.Buffer
+	writer := bit.NewWriter(&buf)
+	values := NewXOREncoder(writer)
+	for _, v := range []uint64{10, 100, 0, 50} {
+		if v == 0 {
+			assert.NoError(t, writer.WriteBit(bit.Zero))
+			continue
+		}
+		assert.NoError(t, writer.WriteBit(bit.One))
+		assert.NoError(t, values.Write(v))
+	}
+	assert.NoError(t, writer.Flush())
+
+	decoder := GetTSDDecoder()
+	defer ReleaseTSDDecoder(decoder)
+	decoder.ResetWithVersion(buf.Bytes(), 10, 13, TSDVersion1)
+	assert.Equal(t, XORCodec, decoder.Codec())
+	var result []uint64
+	for decoder.Next() {
+		if decoder.HasValue() {
+			result = append(result, decoder.Value())
+		}
+	}
+	assert.NoError(t, decoder.Error())
+	assert.Equal(t, []uint64{10, 100, 50}, result)
+
+	// block of current version
+	encoder := NewRLETSDEncoder(10)
+	var expect []uint64
+	for i := 0; i < 100; i++ {
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(uint64(10))
+		expect = append(expect, 10)
+	}
+	data, err := encoder.BytesWithoutTime()
+	assert.NoError(t, err)
+	decoder.ResetWithVersion(data, 10, 109, TSDVersion)
+	assert.Equal(t, RLECodec, decoder.Codec())
+	result = result[:0]
+	for decoder.Next() {
+		if decoder.HasValue() {
+			result = append(result, decoder.Value())
+		}
+	}
+	assert.Equal(t, expect, result)
+}
+
 func TestTsdEncoder_Err(t *testing.T) {
 	defer func() {
 		flushFunc = f
@@ -168,3 +219,390 @@ func TestGetTSDDecoder(t *testing.T) {
 	assert.NotNil(t, decoder)
 	ReleaseTSDDecoder(decoder)
 }
+
+func TestRLECodec(t *testing.T) {
+	encoder := NewRLETSDEncoder(10)
+	data, err := encoder.Bytes()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	// 10:1, 11:1, 12:nil, 13:1, 14:2, 15:2, 16:3
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(uint64(1))
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(uint64(1))
+	encoder.AppendTime(bit.Zero)
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(uint64(1))
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(uint64(2))
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(uint64(2))
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(uint64(3))
+
+	result := map[uint16]uint64{10: 1, 11: 1, 13: 1, 14: 2, 15: 2, 16: 3}
+	assertTSD := func(decoder *TSDDecoder) {
+		c := 0
+		for decoder.Next() {
+			if decoder.HasValue() {
+				assert.Equal(t, result[decoder.Slot()], decoder.Value())
+				c++
+			}
+		}
+		assert.Equal(t, len(result), c)
+		assert.NoError(t, decoder.Error())
+	}
+	// rle mode
+	rle, err := encoder.(*adaptiveTSDEncoder).encode(RLECodec)
+	assert.NoError(t, err)
+	decoder := NewTSDDecoder(nil)
+	decoder.ResetWithTimeRange(rle, 10, 16)
+	assert.Equal(t, RLECodec, decoder.Codec())
+	assertTSD(decoder)
+	// plain mode
+	plain, err := encoder.(*adaptiveTSDEncoder).encode(XORCodec)
+	assert.NoError(t, err)
+	decoder.ResetWithTimeRange(plain, 10, 16)
+	assert.Equal(t, XORCodec, decoder.Codec())
+	assertTSD(decoder)
+	// adaptive mode
+	data, err = encoder.Bytes()
+	assert.NoError(t, err)
+	decoder.Reset(data)
+	assert.Equal(t, uint16(10), decoder.StartTime())
+	assert.Equal(t, uint16(16), decoder.EndTime())
+	assertTSD(decoder)
+
+	encoder.Reset()
+	data, err = encoder.BytesWithoutTime()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestRLECodec_constant_compress_ratio(t *testing.T) {
+	plainEncoder := NewTSDEncoder(0)
+	rleEncoder := NewRLETSDEncoder(0)
+	for i := 0; i < 360; i++ {
+		plainEncoder.AppendTime(bit.One)
+		plainEncoder.AppendValue(math.Float64bits(99.9))
+		rleEncoder.AppendTime(bit.One)
+		rleEncoder.AppendValue(math.Float64bits(99.9))
+	}
+	plain, err := plainEncoder.Bytes()
+	assert.NoError(t, err)
+	rle, err := rleEncoder.Bytes()
+	assert.NoError(t, err)
+	// plain: 4 + (1+360+64+359)/8, rle: 4 + (2+360+64+1+16)/8
+	assert.Len(t, plain, 102)
+	assert.Len(t, rle, 60)
+	assert.True(t, float64(len(plain))/float64(len(rle)) > 1.5)
+
+	decoder := NewTSDDecoder(rle)
+	c := 0
+	for decoder.Next() {
+		if decoder.HasValue() {
+			assert.Equal(t, 99.9, math.Float64frombits(decoder.Value()))
+			c++
+		}
+	}
+	assert.Equal(t, 360, c)
+}
+
+func TestRLECodec_no_identical_values(t *testing.T) {
+	encoder := NewRLETSDEncoder(0)
+	for i := 0; i < 10; i++ {
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(uint64(i))
+	}
+	data, err := encoder.BytesWithoutTime()
+	assert.NoError(t, err)
+	// chooses plain mode which is smaller
+	decoder := NewTSDDecoder(nil)
+	decoder.ResetWithTimeRange(data, 0, 9)
+	assert.Equal(t, XORCodec, decoder.Codec())
+	for i := 0; i < 10; i++ {
+		assert.True(t, decoder.HasValueWithSlot(uint16(i)))
+		assert.Equal(t, uint64(i), decoder.Value())
+	}
+}
+
+func TestAdaptiveTSDEncoder_pick_codec_by_sample(t *testing.T) {
+	assertCodec := func(values []uint64, codec CodecID) {
+		encoder := NewRLETSDEncoder(0)
+		for _, value := range values {
+			encoder.AppendTime(bit.One)
+			encoder.AppendValue(value)
+		}
+		data, err := encoder.Bytes()
+		assert.NoError(t, err)
+		decoder := NewTSDDecoder(data)
+		assert.Equal(t, codec, decoder.Codec())
+		for idx, value := range values {
+			assert.True(t, decoder.HasValueWithSlot(uint16(idx)))
+			assert.Equal(t, value, decoder.Value())
+		}
+	}
+	var values []uint64
+	for i := 0; i < 360; i++ {
+		if i < codecSampleSize {
+			values = append(values, math.Float64bits(99.9))
+		} else {
+			values = append(values, math.Float64bits(float64(i)))
+		}
+	}
+	// leading values are constant
+	assertCodec(values, RLECodec)
+	for i := 0; i < 360; i++ {
+		if i < codecSampleSize {
+			values[i] = math.Float64bits(float64(i))
+		} else {
+			values[i] = math.Float64bits(99.9)
+		}
+	}
+	// leading values are not identical, though the remaining values are constant
+	assertCodec(values, XORCodec)
+}
+
+func TestAdaptiveTSDEncoder_sample(t *testing.T) {
+	encoder := NewRLETSDEncoder(0).(*adaptiveTSDEncoder)
+	for i := 0; i < 100; i++ {
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(uint64(i))
+		encoder.AppendTime(bit.Zero)
+	}
+	slots, values := encoder.sample()
+	assert.Len(t, slots, 2*codecSampleSize-1)
+	assert.Equal(t, bit.One, slots[len(slots)-1])
+	assert.Len(t, values, codecSampleSize)
+	// less values than sample size
+	encoder.Reset()
+	encoder.AppendTime(bit.Zero)
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(1)
+	slots, values = encoder.sample()
+	assert.Equal(t, []bit.Bit{bit.Zero, bit.One}, slots)
+	assert.Equal(t, []uint64{1}, values)
+}
+
+func TestRLETSDEncoder_Err(t *testing.T) {
+	defer func() {
+		flushFunc = f
+	}()
+	encoder := NewRLETSDEncoder(10)
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(uint64(10))
+	flushFunc = func(writer *bit.Writer) error {
+		return fmt.Errorf("err")
+	}
+	data, err := encoder.Bytes()
+	assert.Error(t, err)
+	assert.Nil(t, data)
+	data, err = encoder.BytesWithoutTime()
+	assert.Error(t, err)
+	assert.Nil(t, data)
+}
+
+func TestDeltaCodec(t *testing.T) {
+	plainEncoder := NewTSDEncoder(0)
+	deltaEncoder := NewDeltaTSDEncoder(0)
+	for i := 0; i < 360; i++ {
+		plainEncoder.AppendTime(bit.One)
+		plainEncoder.AppendValue(uint64(1000 + i*10))
+		deltaEncoder.AppendTime(bit.One)
+		deltaEncoder.AppendValue(uint64(1000 + i*10))
+	}
+	plain, err := plainEncoder.Bytes()
+	assert.NoError(t, err)
+	delta, err := deltaEncoder.Bytes()
+	assert.NoError(t, err)
+	assert.True(t, len(delta) < len(plain))
+
+	decoder := NewTSDDecoder(delta)
+	assert.Equal(t, DeltaCodec, decoder.Codec())
+	c := 0
+	for decoder.Next() {
+		if decoder.HasValue() {
+			assert.Equal(t, uint64(1000+c*10), decoder.Value())
+			c++
+		}
+	}
+	assert.Equal(t, 360, c)
+
+	// decreasing values, delta wraps around
+	encoder := NewDeltaTSDEncoder(5)
+	values := []uint64{100, 50, 0, math.MaxUint64, 10}
+	for _, v := range values {
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(v)
+	}
+	data, err := encoder.(*adaptiveTSDEncoder).encode(DeltaCodec)
+	assert.NoError(t, err)
+	decoder.ResetWithTimeRange(data, 5, 9)
+	assert.Equal(t, DeltaCodec, decoder.Codec())
+	for idx, v := range values {
+		assert.True(t, decoder.HasValueWithSlot(uint16(5+idx)))
+		assert.Equal(t, v, decoder.Value())
+	}
+}
+
+func TestNewTSDEncoderWithCodec(t *testing.T) {
+	// 10:1.5, 11:1.5, 12:nil, 13:2.5, 14:nil, 15:3.5
+	result := map[uint16]float64{10: 1.5, 11: 1.5, 13: 2.5, 15: 3.5}
+	for _, codec := range []CodecID{XORCodec, RLECodec, DeltaCodec} {
+		encoder := NewTSDEncoderWithCodec(codec, 10)
+		for slot := uint16(10); slot <= 15; slot++ {
+			value, ok := result[slot]
+			if !ok {
+				encoder.AppendTime(bit.Zero)
+				continue
+			}
+			encoder.AppendTime(bit.One)
+			encoder.AppendValue(math.Float64bits(value))
+		}
+		data, err := encoder.Bytes()
+		assert.NoError(t, err, codec.String())
+
+		decoder := NewTSDDecoder(data)
+		assert.Equal(t, uint16(10), decoder.StartTime())
+		assert.Equal(t, uint16(15), decoder.EndTime())
+		c := 0
+		for decoder.Next() {
+			if decoder.HasValue() {
+				assert.Equal(t, result[decoder.Slot()], math.Float64frombits(decoder.Value()), codec.String())
+				c++
+			}
+		}
+		assert.Equal(t, len(result), c, codec.String())
+		assert.NoError(t, decoder.Error())
+	}
+	assert.Equal(t, "xor", XORCodec.String())
+	assert.Equal(t, "rle", RLECodec.String())
+	assert.Equal(t, "delta", DeltaCodec.String())
+}
+
+func TestDecodeTSDHeader(t *testing.T) {
+	for _, codec := range []CodecID{XORCodec, RLECodec, DeltaCodec} {
+		encoder := NewTSDEncoderWithCodec(codec, 10)
+		for i := 0; i < 20; i++ {
+			encoder.AppendTime(bit.One)
+			encoder.AppendValue(uint64(i / 10))
+		}
+		data, err := encoder.Bytes()
+		assert.NoError(t, err)
+		startTime, endTime, codecID, err := DecodeTSDHeader(data)
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(10), startTime)
+		assert.Equal(t, uint16(29), endTime)
+		// same codec as decoder reads
+		assert.Equal(t, NewTSDDecoder(data).Codec(), codecID)
+	}
+	_, _, _, err := DecodeTSDHeader([]byte{1, 2})
+	assert.Equal(t, ErrInvalidTSDHeader, err)
+}
+
+func TestTSDDecoder_NextMatched(t *testing.T) {
+	encoder := NewRLETSDEncoder(10)
+	// 10:1, 11:1, 12:nil, 13:1, 14:2, 15:2, 16:3
+	for _, v := range []uint64{1, 1, 0, 1, 2, 2, 3} {
+		if v == 0 {
+			encoder.AppendTime(bit.Zero)
+			continue
+		}
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(v)
+	}
+	evaluated := 0
+	predicate := func(value uint64) bool {
+		evaluated++
+		return value >= 2
+	}
+	assertMatched := func(decoder *TSDDecoder, expect map[uint16]uint64) {
+		result := make(map[uint16]uint64)
+		for {
+			slot, value, ok := decoder.NextMatched()
+			if !ok {
+				break
+			}
+			result[slot] = value
+		}
+		assert.Equal(t, expect, result)
+		assert.NoError(t, decoder.Error())
+	}
+	matched := map[uint16]uint64{14: 2, 15: 2, 16: 3}
+	// rle codec evaluates predicate once per run
+	rle, err := encoder.(*adaptiveTSDEncoder).encode(RLECodec)
+	assert.NoError(t, err)
+	decoder := NewTSDDecoder(nil)
+	decoder.ResetWithTimeRange(rle, 10, 16)
+	decoder.SetPredicate(predicate)
+	assertMatched(decoder, matched)
+	assert.Equal(t, 3, evaluated)
+	// xor codec evaluates predicate for each value
+	evaluated = 0
+	plain, err := encoder.(*adaptiveTSDEncoder).encode(XORCodec)
+	assert.NoError(t, err)
+	decoder.ResetWithTimeRange(plain, 10, 16)
+	decoder.SetPredicate(predicate)
+	assertMatched(decoder, matched)
+	assert.Equal(t, 6, evaluated)
+	// predicate is cleared after reset, matches all values
+	decoder.ResetWithTimeRange(rle, 10, 16)
+	assertMatched(decoder, map[uint16]uint64{10: 1, 11: 1, 13: 1, 14: 2, 15: 2, 16: 3})
+	// empty decoder
+	_, _, ok := NewTSDDecoder(nil).NextMatched()
+	assert.False(t, ok)
+}
+
+func BenchmarkTSDDecoder_NextMatched(b *testing.B) {
+	// most of points are filtered out by value predicate, 1 of 60 points qualifies
+	encoder := NewRLETSDEncoder(0)
+	for i := 0; i < 3600; i++ {
+		encoder.AppendTime(bit.One)
+		if i%60 == 59 {
+			encoder.AppendValue(math.Float64bits(99.9))
+		} else {
+			encoder.AppendValue(math.Float64bits(10.0))
+		}
+	}
+	predicate := func(value uint64) bool {
+		return math.Float64frombits(value) > 90
+	}
+	for _, codec := range []CodecID{RLECodec, XORCodec} {
+		data, err := encoder.(*adaptiveTSDEncoder).encode(codec)
+		if err != nil {
+			b.Fatal(err)
+		}
+		decoder := NewTSDDecoder(nil)
+		b.Run(codec.String()+"_decode_then_filter", func(b *testing.B) {
+			evaluated := 0
+			for i := 0; i < b.N; i++ {
+				decoder.ResetWithTimeRange(data, 0, 3599)
+				for decoder.Next() {
+					if decoder.HasValue() {
+						evaluated++
+						_ = predicate(decoder.Value())
+					}
+				}
+			}
+			b.ReportMetric(float64(evaluated)/float64(b.N), "predicates/op")
+		})
+		b.Run(codec.String()+"_pushdown", func(b *testing.B) {
+			evaluated := 0
+			counted := func(value uint64) bool {
+				evaluated++
+				return predicate(value)
+			}
+			for i := 0; i < b.N; i++ {
+				decoder.ResetWithTimeRange(data, 0, 3599)
+				decoder.SetPredicate(counted)
+				for {
+					if _, _, ok := decoder.NextMatched(); !ok {
+						break
+					}
+				}
+			}
+			b.ReportMetric(float64(evaluated)/float64(b.N), "predicates/op")
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package hll
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	// MinPrecision represents the min precision of HyperLogLog sketch
+	MinPrecision = 4
+	// MaxPrecision represents the max precision of HyperLogLog sketch
+	MaxPrecision = 16
+	// DefaultPrecision represents the default precision of HyperLogLog sketch,
+	// uses 2^14 registers(16KB), the standard error is 1.04/sqrt(2^14) ≈ 0.81%.
+	DefaultPrecision = 14
+)
+
+// HyperLogLog represents an approximate distinct count sketch,
+// the standard error of the estimate is 1.04/sqrt(2^precision).
+// The sketch is mergeable, so it can be aggregated across segments and shards,
+// and it can be serialized for cross-node aggregation.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// New creates a HyperLogLog sketch with precision, precision must be in [MinPrecision, MaxPrecision]
+func New(precision uint8) (*HyperLogLog, error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, fmt.Errorf("hyperloglog precision must be in [%d,%d], but got %d",
+			MinPrecision, MaxPrecision, precision)
+	}
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}, nil
+}
+
+// Precision returns the precision of sketch
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// Add adds the 64-bit hash value of an item into sketch
+func (h *HyperLogLog) Add(hash uint64) {
+	idx := hash >> (64 - h.precision)
+	// rank is the position of the leftmost 1-bit in the remaining bits
+	rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1))) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Merge merges other sketch into current sketch, the precision of two sketches must be same
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if other == nil {
+		return nil
+	}
+	if h.precision != other.precision {
+		return fmt.Errorf("cannot merge hyperloglog with different precision: %d, %d", h.precision, other.precision)
+	}
+	for idx, rank := range other.registers {
+		if rank > h.registers[idx] {
+			h.registers[idx] = rank
+		}
+	}
+	return nil
+}
+
+// Estimate returns the estimated distinct count of items
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, rank := range h.registers {
+		sum += 1.0 / float64(uint64(1)<<rank)
+		if rank == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// small range correction, uses linear counting
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Reset resets the sketch for reusing
+func (h *HyperLogLog) Reset() {
+	for idx := range h.registers {
+		h.registers[idx] = 0
+	}
+}
+
+// MarshalBinary marshals the sketch, format: precision(1 byte) + registers(2^precision bytes)
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := make([]byte, len(h.registers)+1)
+	data[0] = h.precision
+	copy(data[1:], h.registers)
+	return data, nil
+}
+
+// UnmarshalBinary unmarshals the sketch from binary data
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("unmarshal hyperloglog with empty data")
+	}
+	precision := data[0]
+	if precision < MinPrecision || precision > MaxPrecision {
+		return fmt.Errorf("unmarshal hyperloglog with wrong precision: %d", precision)
+	}
+	if len(data) != 1<<precision+1 {
+		return fmt.Errorf("unmarshal hyperloglog with wrong data length: %d", len(data))
+	}
+	h.precision = precision
+	h.registers = make([]uint8, 1<<precision)
+	copy(h.registers, data[1:])
+	return nil
+}
+
+// alpha returns the bias correction constant by the number of registers
+func alpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
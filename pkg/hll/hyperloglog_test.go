@@ -0,0 +1,86 @@
+package hll
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cespare/xxhash"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+func TestNew(t *testing.T) {
+	h, err := New(MinPrecision - 1)
+	assert.Error(t, err)
+	assert.Nil(t, h)
+	h, err = New(MaxPrecision + 1)
+	assert.Error(t, err)
+	assert.Nil(t, h)
+	h, err = New(DefaultPrecision)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(DefaultPrecision), h.Precision())
+	assert.Equal(t, uint64(0), h.Estimate())
+}
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	for _, cardinality := range []int{10, 1000, 100000} {
+		h, _ := New(DefaultPrecision)
+		// add every item twice, duplicate items cannot change the estimate
+		for i := 0; i < cardinality; i++ {
+			h.Add(hash(i))
+			h.Add(hash(i))
+		}
+		assertEstimate(t, cardinality, h.Estimate())
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	h1, _ := New(DefaultPrecision)
+	h2, _ := New(DefaultPrecision)
+	// [0,60000) and [40000,100000) => 100000 distinct items
+	for i := 0; i < 60000; i++ {
+		h1.Add(hash(i))
+	}
+	for i := 40000; i < 100000; i++ {
+		h2.Add(hash(i))
+	}
+	assert.NoError(t, h1.Merge(h2))
+	assert.NoError(t, h1.Merge(nil))
+	assertEstimate(t, 100000, h1.Estimate())
+
+	h3, _ := New(DefaultPrecision - 1)
+	assert.Error(t, h1.Merge(h3))
+
+	h1.Reset()
+	assert.Equal(t, uint64(0), h1.Estimate())
+}
+
+func TestHyperLogLog_Marshal(t *testing.T) {
+	h, _ := New(MinPrecision)
+	for i := 0; i < 100; i++ {
+		h.Add(hash(i))
+	}
+	data, err := h.MarshalBinary()
+	assert.NoError(t, err)
+	h2 := &HyperLogLog{}
+	assert.NoError(t, h2.UnmarshalBinary(data))
+	assert.Equal(t, h, h2)
+
+	assert.Error(t, h2.UnmarshalBinary(nil))
+	assert.Error(t, h2.UnmarshalBinary([]byte{MaxPrecision + 1}))
+	assert.Error(t, h2.UnmarshalBinary([]byte{MinPrecision, 1, 2}))
+}
+
+func hash(i int) uint64 {
+	var buf [8]byte
+	stream.PutUint64(buf[:], 0, uint64(i))
+	return xxhash.Sum64(buf[:])
+}
+
+// assertEstimate asserts the estimate within 3 standard errors
+func assertEstimate(t *testing.T, expect int, estimate uint64) {
+	stdErr := 1.04 / math.Sqrt(float64(uint64(1)<<DefaultPrecision))
+	delta := math.Abs(float64(estimate)-float64(expect)) / float64(expect)
+	assert.True(t, delta < 3*stdErr, "expect:%d, estimate:%d", expect, estimate)
+}
@@ -2,8 +2,11 @@ package option
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/tag"
 )
 
 // DatabaseOption represents a database option include shard ids and shard's option
@@ -18,6 +21,16 @@ type DatabaseOption struct {
 	Behind string `toml:"behind" json:"behind,omitempty"` // allowed timestamp write behind
 	Ahead  string `toml:"ahead" json:"ahead,omitempty"`   // allowed timestamp write ahead
 
+	Retention string `toml:"retention" json:"retention,omitempty"` // data retention(like 30d), empty means never expired
+
+	// merge policy of field(field name=>aggregate/last/sum/reject) when two values land in the same time slot
+	MergePolicies map[string]string `toml:"mergePolicies" json:"mergePolicies,omitempty"`
+	// conflict policy of metric(metric name=>strict/coerce/version) when field is written as another field type
+	ConflictPolicies map[string]string `toml:"conflictPolicies" json:"conflictPolicies,omitempty"`
+
+	// hasher of tags for series id assignment(xxhash/grouping:k1,k2), cannot be changed after database has data
+	TagsHasher string `toml:"tagsHasher" json:"tagsHasher,omitempty"`
+
 	Index FlusherOption `toml:"index" json:"index,omitempty"` // index flusher option
 	Data  FlusherOption `toml:"data" json:"data,omitempty"`   // data flusher data
 }
@@ -44,6 +57,22 @@ func (e DatabaseOption) Validate() error {
 	if err := validateInterval(e.Behind, false); err != nil {
 		return err
 	}
+	if err := validateInterval(e.Retention, false); err != nil {
+		return err
+	}
+	for _, policy := range e.MergePolicies {
+		if _, err := field.ParseMergePolicy(policy); err != nil {
+			return err
+		}
+	}
+	for _, policy := range e.ConflictPolicies {
+		if _, err := field.ParseConflictPolicy(policy); err != nil {
+			return err
+		}
+	}
+	if _, err := tag.NewHasher(e.TagsHasher); err != nil {
+		return err
+	}
 	var interval timeutil.Interval
 	_ = interval.ValueOf(e.Interval)
 	for _, intervalStr := range e.Rollup {
@@ -56,6 +85,48 @@ func (e DatabaseOption) Validate() error {
 	return nil
 }
 
+// GetMergePolicies returns the merge policy of each field, the field with invalid policy is ignored
+func (e DatabaseOption) GetMergePolicies() map[field.Name]field.MergePolicy {
+	if len(e.MergePolicies) == 0 {
+		return nil
+	}
+	policies := make(map[field.Name]field.MergePolicy, len(e.MergePolicies))
+	for fieldName, policyStr := range e.MergePolicies {
+		if policy, err := field.ParseMergePolicy(policyStr); err == nil {
+			policies[field.Name(fieldName)] = policy
+		}
+	}
+	return policies
+}
+
+// GetConflictPolicies returns the field type conflict policy of each metric, the metric with invalid policy is ignored
+func (e DatabaseOption) GetConflictPolicies() map[string]field.ConflictPolicy {
+	if len(e.ConflictPolicies) == 0 {
+		return nil
+	}
+	policies := make(map[string]field.ConflictPolicy, len(e.ConflictPolicies))
+	for metricName, policyStr := range e.ConflictPolicies {
+		if policy, err := field.ParseConflictPolicy(policyStr); err == nil {
+			policies[metricName] = policy
+		}
+	}
+	return policies
+}
+
+// GetTagsHasher returns the tags hasher for series id assignment, returns the default hasher if invalid
+func (e DatabaseOption) GetTagsHasher() tag.Hasher {
+	hasher, err := tag.NewHasher(e.TagsHasher)
+	if err != nil {
+		return tag.NewXXHasher()
+	}
+	return hasher
+}
+
+// IsSameTagsHasher returns if the tags hasher of other option is same as current option
+func (e DatabaseOption) IsSameTagsHasher(other DatabaseOption) bool {
+	return reflect.DeepEqual(e.GetTagsHasher(), other.GetTagsHasher())
+}
+
 // validateInterval checks interval string if valid
 func validateInterval(intervalStr string, require bool) error {
 	if !require && intervalStr == "" {
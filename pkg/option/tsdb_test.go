@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/tag"
 )
 
 func Test_DatabaseOption_Validate(t *testing.T) {
@@ -27,4 +30,45 @@ func Test_DatabaseOption_Validate(t *testing.T) {
 	assert.NotNil(t, databaseOption.Validate())
 	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"20s", "1m", "1h"}, Behind: "10h", Ahead: "1h"}
 	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Retention: "aa"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Retention: "30d"}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", MergePolicies: map[string]string{"f1": "max"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", MergePolicies: map[string]string{"f1": "last", "f2": "reject"}}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", ConflictPolicies: map[string]string{"cpu": "drop"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", ConflictPolicies: map[string]string{"cpu": "coerce", "mem": "version"}}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", TagsHasher: "md5"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", TagsHasher: "grouping:host"}
+	assert.Nil(t, databaseOption.Validate())
+}
+
+func Test_DatabaseOption_GetTagsHasher(t *testing.T) {
+	assert.Equal(t, tag.NewXXHasher(), DatabaseOption{}.GetTagsHasher())
+	assert.Equal(t, tag.NewXXHasher(), DatabaseOption{TagsHasher: "md5"}.GetTagsHasher())
+	assert.Equal(t, tag.NewGroupingHasher("host"), DatabaseOption{TagsHasher: "grouping:host"}.GetTagsHasher())
+
+	assert.True(t, DatabaseOption{}.IsSameTagsHasher(DatabaseOption{TagsHasher: tag.XXHasherName}))
+	assert.True(t, DatabaseOption{TagsHasher: "grouping:host"}.IsSameTagsHasher(DatabaseOption{TagsHasher: "grouping: host"}))
+	assert.False(t, DatabaseOption{}.IsSameTagsHasher(DatabaseOption{TagsHasher: "grouping:host"}))
+	assert.False(t, DatabaseOption{TagsHasher: "grouping:host"}.IsSameTagsHasher(DatabaseOption{TagsHasher: "grouping:app"}))
+}
+
+func Test_DatabaseOption_GetMergePolicies(t *testing.T) {
+	assert.Nil(t, DatabaseOption{}.GetMergePolicies())
+	databaseOption := DatabaseOption{MergePolicies: map[string]string{"f1": "last", "f2": "sum", "f3": "max"}}
+	assert.Equal(t, map[field.Name]field.MergePolicy{"f1": field.LastWriteWins, "f2": field.SumMerge},
+		databaseOption.GetMergePolicies())
+}
+
+func Test_DatabaseOption_GetConflictPolicies(t *testing.T) {
+	assert.Nil(t, DatabaseOption{}.GetConflictPolicies())
+	databaseOption := DatabaseOption{ConflictPolicies: map[string]string{"cpu": "coerce", "mem": "version", "disk": "drop"}}
+	assert.Equal(t, map[string]field.ConflictPolicy{"cpu": field.CoerceConflict, "mem": field.VersionConflict},
+		databaseOption.GetConflictPolicies())
 }
@@ -0,0 +1,41 @@
+package strutil
+
+import "strings"
+
+// GlobMatch reports whether value matches the glob pattern, '*' matches any sequence of characters(including empty),
+// other characters are matched literally. Backtracks to the last '*' on mismatch,
+// so the time complexity is O(len(pattern)*len(value)) in the worst case.
+func GlobMatch(pattern, value string) bool {
+	p, v := 0, 0
+	// position of last '*' in pattern, and the position of value when matching the '*'
+	star, starMatch := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star = p
+			starMatch = v
+			p++
+		case p < len(pattern) && pattern[p] == value[v]:
+			p++
+			v++
+		case star >= 0:
+			// backtracks, lets the last '*' match one more character
+			starMatch++
+			p = star + 1
+			v = starMatch
+		default:
+			return false
+		}
+	}
+	// remaining pattern must be all '*'
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// HasInnerWildcard checks if the glob pattern has '*' except the leading and trailing ones,
+// like a*b, which cannot be matched by prefix/suffix/contains.
+func HasInnerWildcard(pattern string) bool {
+	return strings.Contains(strings.Trim(pattern, "*"), "*")
+}
@@ -0,0 +1,61 @@
+package strutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		value   string
+		match   bool
+	}{
+		{"", "", true},
+		{"", "a", false},
+		{"abc", "abc", true},
+		{"abc", "abd", false},
+		{"*", "", true},
+		{"*", "abc", true},
+		{"**", "abc", true},
+		{"a*", "a", true},
+		{"a*", "abc", true},
+		{"a*", "ba", false},
+		{"*c", "abc", true},
+		{"*c", "cb", false},
+		{"*b*", "abc", true},
+		{"*b*", "ac", false},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "acb", false},
+		{"a*b*c", "abcc", true},
+		{"a*b*c", "abcd", false},
+		{"a**c", "abc", true},
+		{"a**c", "ac", true},
+		{"a*a", "aaa", true},
+		{"a*a", "aa", true},
+		{"a*a", "a", false},
+		{"a*a", "aab", false},
+		{"*a*a*", "bab", false},
+		{"*a*a*", "babab", true},
+		{"*ab", "aab", true},
+		{"*ab*ab", "abxabab", true},
+		{"a*b", "abbb", true},
+		{"a*ba", "abba", true},
+		{"a*ba", "abbab", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.match, GlobMatch(c.pattern, c.value), "pattern: %s, value: %s", c.pattern, c.value)
+	}
+}
+
+func TestHasInnerWildcard(t *testing.T) {
+	assert.False(t, HasInnerWildcard(""))
+	assert.False(t, HasInnerWildcard("*"))
+	assert.False(t, HasInnerWildcard("**"))
+	assert.False(t, HasInnerWildcard("*abc*"))
+	assert.False(t, HasInnerWildcard("**abc"))
+	assert.True(t, HasInnerWildcard("a*b"))
+	assert.True(t, HasInnerWildcard("*a**b*"))
+}
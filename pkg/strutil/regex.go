@@ -0,0 +1,129 @@
+package strutil
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+// DefaultMaxRegexCost represents the default max cost(num. of compiled instructions) of regex predicate
+const DefaultMaxRegexCost = 1000
+
+// DefaultRegexTimeout represents the default timeout of evaluating regex predicate against tag values
+const DefaultRegexTimeout = 5 * time.Second
+
+// ErrRegexTooComplex represents the regex predicate is rejected by the cost estimator before compilation
+var ErrRegexTooComplex = errors.New("regex is too complex")
+
+// regexCheckInterval is the num. of evaluated values between two deadline checks
+const regexCheckInterval = 256
+
+var (
+	maxRegexCost = atomic.NewInt32(DefaultMaxRegexCost)
+	regexTimeout = atomic.NewDuration(DefaultRegexTimeout)
+)
+
+// SetMaxRegexCost sets the max cost of regex predicate, cost <= 0 means no limit
+func SetMaxRegexCost(cost int) {
+	if cost < 0 {
+		cost = 0
+	}
+	maxRegexCost.Store(int32(cost))
+}
+
+// SetRegexTimeout sets the timeout of evaluating one regex predicate against tag values, timeout <= 0 means no limit
+func SetRegexTimeout(timeout time.Duration) {
+	if timeout < 0 {
+		timeout = 0
+	}
+	regexTimeout.Store(timeout)
+}
+
+// CheckRegexCost estimates the cost of regex before compilation, returns ErrRegexTooComplex if
+// it has nested quantifiers on character class(like (\w+)*) or the num. of compiled instructions exceeds the max cost.
+func CheckRegexCost(pattern string) error {
+	limit := int(maxRegexCost.Load())
+	if limit <= 0 {
+		return nil
+	}
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return err
+	}
+	if hasNestedQuantifier(re, false) {
+		return fmt.Errorf("%w, nested quantifiers on character class, pattern: %s", ErrRegexTooComplex, pattern)
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return err
+	}
+	if cost := len(prog.Inst); cost > limit {
+		return fmt.Errorf("%w, cost: %d exceeds limit: %d, pattern: %s", ErrRegexTooComplex, cost, limit, pattern)
+	}
+	return nil
+}
+
+// hasNestedQuantifier checks if the quantifier(*, +, {n,}) is nested in another unbounded quantifier,
+// and its operand matches a character class or any char.
+func hasNestedQuantifier(re *syntax.Regexp, inQuantifier bool) bool {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus, syntax.OpRepeat:
+		if inQuantifier && matchesCharSet(re.Sub[0]) {
+			return true
+		}
+		unbounded := re.Op != syntax.OpRepeat || re.Max == -1
+		return hasNestedQuantifier(re.Sub[0], inQuantifier || unbounded)
+	default:
+		for _, sub := range re.Sub {
+			if hasNestedQuantifier(sub, inQuantifier) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matchesCharSet checks if the regex matches a character class or any char
+func matchesCharSet(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return true
+	default:
+		for _, sub := range re.Sub {
+			if matchesCharSet(sub) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RegexDeadline represents the deadline of evaluating one regex predicate against many values
+type RegexDeadline struct {
+	deadline time.Time
+	count    int
+}
+
+// NewRegexDeadline creates the deadline of regex evaluation based on the regex timeout
+func NewRegexDeadline() *RegexDeadline {
+	d := &RegexDeadline{}
+	if timeout := regexTimeout.Load(); timeout > 0 {
+		d.deadline = time.Now().Add(timeout)
+	}
+	return d
+}
+
+// Exceeded returns if the evaluation exceeds the deadline, checks the clock every regexCheckInterval values
+func (d *RegexDeadline) Exceeded() bool {
+	if d.deadline.IsZero() {
+		return false
+	}
+	d.count++
+	if d.count%regexCheckInterval != 0 {
+		return false
+	}
+	return time.Now().After(d.deadline)
+}
@@ -0,0 +1,57 @@
+package strutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRegexCost(t *testing.T) {
+	defer SetMaxRegexCost(DefaultMaxRegexCost)
+
+	// normal patterns pass
+	for _, pattern := range []string{"^a.*$", `b2[0-9]+`, "(a+)+", "host-(1|2|3)", `(\w+\.){3}`} {
+		assert.NoError(t, CheckRegexCost(pattern), pattern)
+	}
+	// pathological patterns with nested quantifiers are rejected
+	for _, pattern := range []string{`(\w+\s?)*$`, "(.*)*", "([a-z]+)+x", "((a|.)+){2,}"} {
+		err := CheckRegexCost(pattern)
+		assert.True(t, errors.Is(err, ErrRegexTooComplex), pattern)
+	}
+	// exceeds cost limit
+	SetMaxRegexCost(100)
+	err := CheckRegexCost("[a-z]{200}")
+	assert.True(t, errors.Is(err, ErrRegexTooComplex))
+	assert.NoError(t, CheckRegexCost("[a-z]{20}"))
+	// invalid pattern
+	err = CheckRegexCost("a(b")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrRegexTooComplex))
+	// no limit
+	SetMaxRegexCost(-1)
+	assert.NoError(t, CheckRegexCost("(.*)*"))
+	assert.NoError(t, CheckRegexCost("[a-z]{200}"))
+}
+
+func TestRegexDeadline(t *testing.T) {
+	defer SetRegexTimeout(DefaultRegexTimeout)
+
+	SetRegexTimeout(time.Nanosecond)
+	deadline := NewRegexDeadline()
+	time.Sleep(time.Millisecond)
+	exceeded := false
+	// checks the clock every regexCheckInterval values
+	for i := 0; i < regexCheckInterval; i++ {
+		exceeded = deadline.Exceeded()
+	}
+	assert.True(t, exceeded)
+
+	// no limit
+	SetRegexTimeout(-1)
+	deadline = NewRegexDeadline()
+	for i := 0; i < regexCheckInterval; i++ {
+		assert.False(t, deadline.Exceeded())
+	}
+}
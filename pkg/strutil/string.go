@@ -9,8 +9,11 @@ import (
 // GetStringValue aggregation format function name
 func GetStringValue(rawString string) string {
 	if len(rawString) > 0 {
-		if (strings.HasPrefix(rawString, "'") && strings.HasSuffix(rawString, "'")) ||
-			(strings.HasPrefix(rawString, "\"") && strings.HasSuffix(rawString, "\"")) {
+		if len(rawString) > 1 && strings.HasPrefix(rawString, "'") && strings.HasSuffix(rawString, "'") {
+			// single quote in single-quoted string is escaped by doubling it(SQL-style), like 'it''s'
+			return strings.ReplaceAll(rawString[1:len(rawString)-1], "''", "'")
+		}
+		if strings.HasPrefix(rawString, "\"") && strings.HasSuffix(rawString, "\"") {
 			return rawString[1 : len(rawString)-1]
 		}
 		return rawString
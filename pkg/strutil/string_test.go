@@ -12,6 +12,13 @@ func Test_GetStringValue(t *testing.T) {
 	assert.Equal(t, "'sum", GetStringValue("'sum"))
 	assert.Equal(t, "sum", GetStringValue("\"sum\""))
 	assert.Equal(t, "", GetStringValue(""))
+	// single quote escaped by doubling it
+	assert.Equal(t, "it's-web", GetStringValue("'it''s-web'"))
+	assert.Equal(t, "'", GetStringValue("''''"))
+	assert.Equal(t, "", GetStringValue("''"))
+	assert.Equal(t, "'", GetStringValue("'"))
+	// backslash is not an escape character
+	assert.Equal(t, `a\d`, GetStringValue(`'a\d'`))
 }
 
 func Test_ByteSlice2String(t *testing.T) {
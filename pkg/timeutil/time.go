@@ -24,10 +24,13 @@ const (
 	OneMonth = 31 * OneDay
 	// OneYear is the number of millisecond for a year
 	OneYear = 365 * OneDay
-	//TODO ????
-	dataTimeFormat1 = "20060102 15:04:05"
-	dataTimeFormat2 = "2006-01-02 15:04:05"
-	dataTimeFormat3 = "2006/01/02 15:04:05"
+	// date time formats of time literal, fractional seconds are optional(like 20060102 15:04:05.123),
+	// the timestamp keeps millisecond precision, digits beyond millisecond are truncated.
+	dataTimeFormat1 = "20060102 15:04:05.999"
+	dataTimeFormat2 = "2006-01-02 15:04:05.999"
+	dataTimeFormat3 = "2006/01/02 15:04:05.999"
+	// rfc3339DateLen is the length of date part of RFC3339 timestamp(2006-01-02), followed by 'T'
+	rfc3339DateLen = 10
 )
 
 // FormatTimestamp returns timestamp format based on layout
@@ -38,11 +41,20 @@ func FormatTimestamp(timestamp int64, layout string) string {
 
 // ParseTimestamp parses timestamp str value based on layout using local zone
 func ParseTimestamp(timestampStr string, layout ...string) (int64, error) {
+	return ParseTimestampInLocation(timestampStr, time.Local, layout...)
+}
+
+// ParseTimestampInLocation parses timestamp str value based on layout using the given zone,
+// if layout not set, detects the format by shape, RFC3339 timestamp(like 2019-04-10T00:00:00+08:00)
+// uses the offset/zone of timestamp instead of the given zone.
+func ParseTimestampInLocation(timestampStr string, location *time.Location, layout ...string) (int64, error) {
 	var format string
 	if len(layout) > 0 {
 		format = layout[0]
 	} else {
 		switch {
+		case isRFC3339(timestampStr):
+			format = time.RFC3339
 		case strings.Index(timestampStr, "-") > 0:
 			format = dataTimeFormat2
 		case strings.Index(timestampStr, "/") > 0:
@@ -51,13 +63,18 @@ func ParseTimestamp(timestampStr string, layout ...string) (int64, error) {
 			format = dataTimeFormat1
 		}
 	}
-	tm, err := parseTimeFunc(format, timestampStr, time.Local)
+	tm, err := parseTimeFunc(format, timestampStr, location)
 	if err != nil {
 		return 0, err
 	}
 	return tm.UnixNano() / 1000000, nil
 }
 
+// isRFC3339 checks if timestamp str is RFC3339 format, like 2019-04-10T00:00:00Z
+func isRFC3339(timestampStr string) bool {
+	return len(timestampStr) > rfc3339DateLen && timestampStr[rfc3339DateLen] == 'T'
+}
+
 // Now returns t as a Unix time, the number of millisecond elapsed
 // since January 1, 1970 UTC. The result does not depend on the
 // location associated with t.
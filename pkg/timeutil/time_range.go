@@ -1,5 +1,7 @@
 package timeutil
 
+import "sort"
+
 // TimeRange represents time range with start/end timestamp
 type TimeRange struct {
 	Start int64 `json:"start"`
@@ -34,3 +36,27 @@ func (r *TimeRange) Intersect(o *TimeRange) *TimeRange {
 	}
 	return result
 }
+
+// MergeTimeRanges merges the overlapping time ranges, returns the disjoint time ranges sorted by start time
+func MergeTimeRanges(timeRanges []TimeRange) []TimeRange {
+	if len(timeRanges) <= 1 {
+		return timeRanges
+	}
+	sorted := make([]TimeRange, len(timeRanges))
+	copy(sorted, timeRanges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+	result := []TimeRange{sorted[0]}
+	for _, timeRange := range sorted[1:] {
+		last := &result[len(result)-1]
+		if timeRange.Start > last.End {
+			result = append(result, timeRange)
+			continue
+		}
+		if timeRange.End > last.End {
+			last.End = timeRange.End
+		}
+	}
+	return result
+}
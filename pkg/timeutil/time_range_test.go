@@ -45,3 +45,14 @@ func TestTimeRange_Intersect(t *testing.T) {
 	assert.True(t, timeRange.Intersect(&TimeRange{Start: 500, End: 7000}).IsEmpty())
 	assert.True(t, timeRange.Intersect(&TimeRange{Start: 5000, End: 700}).IsEmpty())
 }
+
+func TestMergeTimeRanges(t *testing.T) {
+	assert.Nil(t, MergeTimeRanges(nil))
+	assert.Equal(t, []TimeRange{{Start: 10, End: 100}}, MergeTimeRanges([]TimeRange{{Start: 10, End: 100}}))
+	// disjoint
+	assert.Equal(t, []TimeRange{{Start: 10, End: 20}, {Start: 30, End: 40}},
+		MergeTimeRanges([]TimeRange{{Start: 30, End: 40}, {Start: 10, End: 20}}))
+	// overlapping and contained
+	assert.Equal(t, []TimeRange{{Start: 10, End: 50}, {Start: 60, End: 70}},
+		MergeTimeRanges([]TimeRange{{Start: 30, End: 50}, {Start: 60, End: 70}, {Start: 10, End: 30}, {Start: 35, End: 40}}))
+}
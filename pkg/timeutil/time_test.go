@@ -35,6 +35,45 @@ func Test_ParseTimestamp(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestParseTimestampInLocation(t *testing.T) {
+	utc, err := ParseTimestampInLocation(date, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2019, 12, 12, 10, 11, 10, 0, time.UTC).UnixNano()/1000000, utc)
+	east8, err := ParseTimestampInLocation(date, time.FixedZone("CST", 8*3600))
+	assert.NoError(t, err)
+	assert.Equal(t, 8*OneHour, utc-east8)
+}
+
+func TestParseTimestamp_fractionalSeconds(t *testing.T) {
+	base := time.Date(2019, 12, 12, 10, 11, 10, 0, time.UTC).UnixNano() / 1000000
+	for value, expect := range map[string]int64{
+		"20191212 10:11:10.123":         base + 123,
+		"2019-12-12 10:11:10.5":         base + 500,
+		"2019/12/12 10:11:10.001":       base + 1,
+		"20191212 10:11:10.9999":        base + 999, // truncated to millisecond
+		"2019-12-12T10:11:10.123Z":      base + 123,
+		"2019-12-12T18:11:10.123+08:00": base + 123,
+	} {
+		timestamp, err := ParseTimestampInLocation(value, time.UTC)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expect, timestamp, value)
+	}
+	_, err := ParseTimestampInLocation("20191212 10:11:10.", time.UTC)
+	assert.Error(t, err)
+}
+
+func TestParseTimestamp_RFC3339(t *testing.T) {
+	utc, err := ParseTimestamp("2019-12-12T10:11:10Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2019, 12, 12, 10, 11, 10, 0, time.UTC).UnixNano()/1000000, utc)
+	// offset of timestamp takes precedence over the given zone
+	east8, err := ParseTimestampInLocation("2019-12-12T10:11:10+08:00", time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 8*OneHour, utc-east8)
+	_, err = ParseTimestamp("2019-12-12T10:11:10")
+	assert.Error(t, err)
+}
+
 func TestCalPointCount(t *testing.T) {
 	time1, _ := ParseTimestamp(date)
 	assert.Equal(t, 1, CalPointCount(time1, time1, 10*OneSecond))
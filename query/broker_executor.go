@@ -2,7 +2,10 @@ package query
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
@@ -15,13 +18,17 @@ import (
 type brokerExecutor struct {
 	database string
 	sql      string
-	query    *stmt.Query
+	location *time.Location
+	// sampleRate is the fraction of matched series to query(sample_series), 0 means no sampling
+	sampleRate float64
+	query      *stmt.Query
 
 	replicaStateMachine  replica.StatusStateMachine
 	nodeStateMachine     broker.NodeStateMachine
 	databaseStateMachine database.DBStateMachine
 
 	jobManager parallel.JobManager
+	cfg        brokerQueryConfig
 
 	ctx context.Context
 
@@ -29,17 +36,20 @@ type brokerExecutor struct {
 }
 
 // newBrokerExecutor creates the execution which executes the job of parallel query
-func newBrokerExecutor(ctx context.Context, database string, sql string,
+func newBrokerExecutor(ctx context.Context, database string, sql string, location *time.Location, sampleRate float64,
 	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
 	databaseStateMachine database.DBStateMachine,
-	jobManager parallel.JobManager) parallel.BrokerExecutor {
+	jobManager parallel.JobManager, cfg brokerQueryConfig) parallel.BrokerExecutor {
 	exec := &brokerExecutor{
 		sql:                  sql,
+		location:             location,
+		sampleRate:           sampleRate,
 		database:             database,
 		replicaStateMachine:  replicaStateMachine,
 		nodeStateMachine:     nodeStateMachine,
 		databaseStateMachine: databaseStateMachine,
 		jobManager:           jobManager,
+		cfg:                  cfg,
 		ctx:                  ctx,
 	}
 	return exec
@@ -54,7 +64,7 @@ func (e *brokerExecutor) Execute() {
 
 	databaseCfg, ok := e.databaseStateMachine.GetDatabaseCfg(e.database)
 	if !ok {
-		e.executeCtx = parallel.NewBrokerExecuteContext(startTime, nil)
+		e.executeCtx = parallel.NewBrokerExecuteContext(startTime, nil, e.cfg.pointsLimit, e.cfg.nanPolicy)
 		e.executeCtx.Complete(errDatabaseNotExist)
 		return
 	}
@@ -62,7 +72,7 @@ func (e *brokerExecutor) Execute() {
 	//FIXME need using storage's replica state ???
 	storageNodes := e.replicaStateMachine.GetQueryableReplicas(e.database)
 	brokerNodes := e.nodeStateMachine.GetActiveNodes()
-	plan := newBrokerPlan(e.sql, databaseCfg, storageNodes, e.nodeStateMachine.GetCurrentNode(), brokerNodes)
+	plan := newBrokerPlan(e.sql, e.location, e.cfg, databaseCfg, storageNodes, e.nodeStateMachine.GetCurrentNode(), brokerNodes)
 
 	var err error
 	if len(storageNodes) == 0 {
@@ -73,7 +83,7 @@ func (e *brokerExecutor) Execute() {
 
 	// maybe plan doesn't execute(query statement is nil), because storage nodes is empty
 	brokerPlan := plan.(*brokerPlan)
-	e.executeCtx = parallel.NewBrokerExecuteContext(startTime, brokerPlan.query)
+	e.executeCtx = parallel.NewBrokerExecuteContext(startTime, brokerPlan.query, e.cfg.pointsLimit, e.cfg.nanPolicy)
 
 	if err != nil {
 		e.executeCtx.Complete(err)
@@ -82,6 +92,12 @@ func (e *brokerExecutor) Execute() {
 
 	brokerPlan.physicalPlan.Database = e.database
 	e.query = brokerPlan.query
+	e.query.SampleRate = e.sampleRate
+
+	if e.query.CountDistinct != "" {
+		e.executeCountDistinct()
+		return
+	}
 
 	if err := e.jobManager.SubmitJob(parallel.NewJobContext(e.ctx,
 		e.executeCtx.ResultCh(), brokerPlan.physicalPlan, e.query),
@@ -91,6 +107,20 @@ func (e *brokerExecutor) Execute() {
 	}
 }
 
+// executeCountDistinct executes count(distinct tag), gets the distinct tag values of matched series
+// from index of all storage nodes, then counts them, no data need to be read.
+func (e *brokerExecutor) executeCountDistinct() {
+	exec := newMetadataBrokerExecutor(e.ctx, e.database, e.query.CountDistinctRequest(),
+		e.nodeStateMachine, e.replicaStateMachine, e.jobManager)
+	tagValues, err := exec.Execute()
+	if err != nil && !errors.Is(err, constants.ErrNotFound) {
+		e.executeCtx.Complete(err)
+		return
+	}
+	// no series matches the condition, the count is 0
+	e.executeCtx.CompleteDistinctCount(len(tagValues))
+}
+
 func (e *brokerExecutor) ExecuteContext() parallel.BrokerExecuteContext {
 	return e.executeCtx
 }
@@ -8,12 +8,14 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestBrokerExecutor_Execute(t *testing.T) {
@@ -29,8 +31,8 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 	jobManager := parallel.NewMockJobManager(ctrl)
 
 	// case 1: database not found
-	exec := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu", nil, 0,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, brokerQueryConfig{})
 	dbStateMachine.EXPECT().GetDatabaseCfg("test_db").Return(models.Database{}, false)
 	exec.Execute()
 	assert.NotNil(t, exec.ExecuteContext())
@@ -38,8 +40,8 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 	// case 2: storage nodes not exist
 	dbStateMachine.EXPECT().GetDatabaseCfg("test_db").
 		Return(models.Database{Option: option.DatabaseOption{Interval: "10s"}}, true).AnyTimes()
-	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu", nil, 0,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, brokerQueryConfig{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(nil)
 	exec.Execute()
 	assert.NotNil(t, exec.ExecuteContext())
@@ -57,24 +59,91 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 		currentNode,
 		generateBrokerActiveNode("1.1.1.4", 8000),
 	}
-	exec = newBrokerExecutor(context.TODO(), "test_db", "select f fro",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f fro", nil, 0,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, brokerQueryConfig{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	exec.Execute()
 
-	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu", nil, 0,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, brokerQueryConfig{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	jobManager.EXPECT().SubmitJob(gomock.Any())
 	exec.Execute()
 
 	// submit job error
-	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu", nil, 0,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, brokerQueryConfig{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	jobManager.EXPECT().SubmitJob(gomock.Any()).Return(errors.New("submit job error"))
 	exec.Execute()
 }
+
+func TestBrokerExecutor_Execute_CountDistinct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+
+	nodeStateMachine := broker.NewMockNodeStateMachine(ctrl)
+	dbStateMachine := database.NewMockDBStateMachine(ctrl)
+	nodeStateMachine.EXPECT().GetCurrentNode().Return(currentNode.Node).AnyTimes()
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(nil).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").
+		Return(map[string][]int32{"1.1.1.1:9000": {1, 2}, "1.1.1.2:9000": {3}}).AnyTimes()
+	dbStateMachine.EXPECT().GetDatabaseCfg("test_db").
+		Return(models.Database{Option: option.DatabaseOption{Interval: "10s"}}, true).AnyTimes()
+	jobManager := parallel.NewMockJobManager(ctrl)
+
+	sql := "select count(distinct host) from cpu where region='sh'"
+	// case 1: submit metadata job err
+	exec := newBrokerExecutor(context.TODO(), "test_db", sql, nil, 0,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, brokerQueryConfig{})
+	jobManager.EXPECT().SubmitMetadataJob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("submit job error"))
+	exec.Execute()
+	_, err := exec.ExecuteContext().ResultSet()
+	assert.Error(t, err)
+
+	// case 2: counts distinct tag values of all storage nodes
+	exec = newBrokerExecutor(context.TODO(), "test_db", sql, nil, 0,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, brokerQueryConfig{})
+	jobManager.EXPECT().SubmitMetadataJob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.PhysicalPlan, request *stmt.Metadata, resultCh chan []string) error {
+			assert.Equal(t, stmt.TagValue, request.Type)
+			assert.Equal(t, "host", request.TagKey)
+			go func() {
+				resultCh <- []string{"1.1.1.1", "1.1.1.2"}
+				resultCh <- []string{"1.1.1.2", "1.1.1.3"}
+				close(resultCh)
+			}()
+			return nil
+		})
+	exec.Execute()
+	exeCtx := exec.ExecuteContext()
+	for range exeCtx.ResultCh() {
+		t.Fatal("no time series event for count distinct")
+	}
+	rs, err := exeCtx.ResultSet()
+	assert.NoError(t, err)
+	assert.Equal(t, "cpu", rs.MetricName)
+	assert.Len(t, rs.Series, 1)
+	assert.Equal(t, map[int64]float64{rs.StartTime: 3}, rs.Series[0].Fields["count"])
+
+	// case 3: no series matches the condition, counts 0
+	exec = newBrokerExecutor(context.TODO(), "test_db",
+		"select count(distinct host) from cpu where region='sh' and time>now()-1h", nil, 0,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, brokerQueryConfig{})
+	jobManager.EXPECT().SubmitMetadataJob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.PhysicalPlan, request *stmt.Metadata, resultCh chan []string) error {
+			assert.False(t, request.TimeRange.IsEmpty())
+			return constants.ErrNotFound
+		})
+	exec.Execute()
+	rs, err = exec.ExecuteContext().ResultSet()
+	assert.NoError(t, err)
+	assert.Len(t, rs.Series, 1)
+	assert.Equal(t, map[int64]float64{rs.StartTime: 0}, rs.Series[0].Fields["count"])
+}
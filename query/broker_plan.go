@@ -1,6 +1,8 @@
 package query
 
 import (
+	"time"
+
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql"
@@ -10,6 +12,8 @@ import (
 // brokerPlan represents the broker execute plan
 type brokerPlan struct {
 	sql               string
+	location          *time.Location // zone for interpreting absolute time literals of sql
+	cfg               brokerQueryConfig
 	query             *stmt.Query
 	storageNodes      map[string][]int32
 	currentBrokerNode models.Node
@@ -21,10 +25,12 @@ type brokerPlan struct {
 }
 
 // newBrokerPlan creates broker execute plan
-func newBrokerPlan(sql string, databaseCfg models.Database, storageNodes map[string][]int32,
-	currentBrokerNode models.Node, brokerNodes []models.ActiveNode) Plan {
+func newBrokerPlan(sql string, location *time.Location, cfg brokerQueryConfig, databaseCfg models.Database,
+	storageNodes map[string][]int32, currentBrokerNode models.Node, brokerNodes []models.ActiveNode) Plan {
 	return &brokerPlan{
 		sql:               sql,
+		location:          location,
+		cfg:               cfg,
 		databaseCfg:       databaseCfg,
 		storageNodes:      storageNodes,
 		currentBrokerNode: currentBrokerNode,
@@ -45,7 +51,7 @@ func (p *brokerPlan) Plan() error {
 		return errNoAvailableStorageNode
 	}
 
-	query, err := sql.Parse(p.sql)
+	query, err := sql.ParseWithConfig(p.sql, p.location, p.cfg.parser)
 	if err != nil {
 		return err
 	}
@@ -60,8 +66,19 @@ func (p *brokerPlan) Plan() error {
 		p.query.Interval = interval
 	}
 	intervalVal := int64(p.query.Interval)
+	if outputInterval := int64(p.query.OutputInterval); outputInterval > 0 &&
+		outputInterval%intervalVal != 0 && intervalVal%outputInterval != 0 {
+		return errInvalidOutputInterval
+	}
 	p.query.TimeRange.Start = timeutil.Truncate(p.query.TimeRange.Start, intervalVal)
 	p.query.TimeRange.End = timeutil.Truncate(p.query.TimeRange.End, intervalVal)
+	for idx := range p.query.TimeRanges {
+		p.query.TimeRanges[idx].Start = timeutil.Truncate(p.query.TimeRanges[idx].Start, intervalVal)
+		p.query.TimeRanges[idx].End = timeutil.Truncate(p.query.TimeRanges[idx].End, intervalVal)
+	}
+	if err := checkBucketLimit(p.query, p.cfg.maxBuckets); err != nil {
+		return err
+	}
 
 	root := p.currentBrokerNode
 
@@ -90,7 +107,7 @@ func (p *brokerPlan) Plan() error {
 
 // buildIntermediateNodes builds intermediate nodes if need
 func (p *brokerPlan) buildIntermediateNodes() {
-	if len(p.query.GroupBy) == 0 {
+	if !p.query.HasGroupBy() {
 		return
 	}
 	if len(p.brokerNodes) == 0 {
@@ -1,23 +1,33 @@
 package query
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/metadb"
 )
 
 func TestBrokerPlan_Wrong_Case(t *testing.T) {
-	plan := newBrokerPlan("sql", models.Database{}, nil, models.Node{}, nil)
+	plan := newBrokerPlan("sql", nil, brokerQueryConfig{}, models.Database{}, nil, models.Node{}, nil)
 	// storage nodes cannot be empty
 	err := plan.Plan()
 	assert.Equal(t, errNoAvailableStorageNode, err)
 
 	storageNodes := map[string][]int32{"1.1.1.1:8000": {1, 2, 4}}
 	// wrong sql
-	plan = newBrokerPlan("sql", models.Database{}, storageNodes, models.Node{}, nil)
+	plan = newBrokerPlan("sql", nil, brokerQueryConfig{}, models.Database{}, storageNodes, models.Node{}, nil)
 	err = plan.Plan()
 	assert.NotNil(t, err)
 }
@@ -26,18 +36,48 @@ func TestBrokerPlan_wrong_database_interval(t *testing.T) {
 	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}, "1.1.1.2:9000": {3, 5, 6}}
 	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
 	// no group sql
-	plan := newBrokerPlan("select f from cpu",
+	plan := newBrokerPlan("select f from cpu", nil, brokerQueryConfig{},
 		models.Database{Option: option.DatabaseOption{Interval: "s"}},
 		storageNodes, currentNode.Node, nil)
 	err := plan.Plan()
 	assert.Error(t, err)
 }
 
+func TestBrokerPlan_invalid_output_interval(t *testing.T) {
+	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}, "1.1.1.2:9000": {3, 5, 6}}
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+	plan := newBrokerPlan("select f from cpu group by time(5m) step 2m", nil, brokerQueryConfig{},
+		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
+		storageNodes, currentNode.Node, nil)
+	err := plan.Plan()
+	assert.Equal(t, errInvalidOutputInterval, err)
+}
+
+func TestBrokerPlan_max_buckets(t *testing.T) {
+	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}, "1.1.1.2:9000": {3, 5, 6}}
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+	// 1 hour group by 1m => 60 buckets
+	sql := "select f from cpu where time>='20190410 00:00:00' and time<'20190410 01:00:00' group by time(1m)"
+	newPlan := func(maxBuckets int) Plan {
+		return newBrokerPlan(sql, time.UTC, brokerQueryConfig{maxBuckets: maxBuckets},
+			models.Database{Option: option.DatabaseOption{Interval: "10s"}},
+			storageNodes, currentNode.Node, nil)
+	}
+	// just under the limit
+	assert.NoError(t, newPlan(60).Plan())
+	// exceeds the limit, suggests coarser interval
+	err := newPlan(59).Plan()
+	assert.True(t, errors.Is(err, ErrTooManyBuckets))
+	assert.Contains(t, err.Error(), "at least 1m1.017s")
+	// no limit
+	assert.NoError(t, newPlan(0).Plan())
+}
+
 func TestBrokerPlan_No_GroupBy(t *testing.T) {
 	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}, "1.1.1.2:9000": {3, 5, 6}}
 	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
 	// no group sql
-	plan := newBrokerPlan("select f from cpu",
+	plan := newBrokerPlan("select f from cpu", nil, brokerQueryConfig{},
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes, currentNode.Node, nil)
 	err := plan.Plan()
@@ -78,6 +118,8 @@ func TestBrokerPlan_GroupBy(t *testing.T) {
 	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
 	plan := newBrokerPlan(
 		"select f from cpu group by host",
+		nil,
+		brokerQueryConfig{},
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -117,6 +159,8 @@ func TestBrokerPlan_GroupBy_Less_StorageNodes(t *testing.T) {
 	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
 	plan := newBrokerPlan(
 		"select f from cpu group by host",
+		nil,
+		brokerQueryConfig{},
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -155,6 +199,8 @@ func TestBrokerPlan_GroupBy_Same_Broker(t *testing.T) {
 	// current node = active node
 	plan := newBrokerPlan(
 		"select f from cpu group by host",
+		nil,
+		brokerQueryConfig{},
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -184,6 +230,8 @@ func TestBrokerPlan_GroupBy_No_Broker(t *testing.T) {
 	// only one storage node
 	plan := newBrokerPlan(
 		"select f from cpu group by host",
+		nil,
+		brokerQueryConfig{},
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -213,6 +261,8 @@ func TestBrokerPlan_GroupBy_One_StorageNode(t *testing.T) {
 	// only one storage node
 	plan := newBrokerPlan(
 		"select f from cpu group by host",
+		nil,
+		brokerQueryConfig{},
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -243,3 +293,49 @@ func TestBrokerPlan_GroupBy_One_StorageNode(t *testing.T) {
 func generateBrokerActiveNode(ip string, port int) models.ActiveNode {
 	return models.ActiveNode{Node: models.Node{IP: ip, Port: uint16(port)}}
 }
+
+func TestBrokerPlan_raw_query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return([]field.Meta{{ID: 10, Type: field.SumField}}, nil).AnyTimes()
+
+	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}, "1.1.1.2:9000": {3, 5, 6}}
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+	// plans the query in broker, then in storage with the query sent by broker
+	storagePlan := func(sql string) (*stmt.Query, *storageExecutePlan) {
+		plan := newBrokerPlan(sql, nil, brokerQueryConfig{},
+			models.Database{Option: option.DatabaseOption{Interval: "10s"}},
+			storageNodes, currentNode.Node, nil)
+		assert.NoError(t, plan.Plan())
+		brokerQuery := plan.(*brokerPlan).query
+		// interval of database is filled if no interval, which is the slot of data points
+		assert.True(t, brokerQuery.Interval >= timeutil.Interval(10*timeutil.OneSecond), sql)
+		query := &stmt.Query{}
+		assert.NoError(t, encoding.JSONUnmarshal(encoding.JSONMarshal(brokerQuery), query))
+		sPlan := newStorageExecutePlan("ns", metadata, query)
+		assert.NoError(t, sPlan.Plan())
+		return query, sPlan.(*storageExecutePlan)
+	}
+	// select bare field without interval queries raw data points
+	query, sPlan := storagePlan("select f from cpu where host='a'")
+	assert.True(t, query.Raw)
+	spec := aggregation.NewDownSamplingSpec("f", field.SumField)
+	spec.AddFunctionType(function.Identity)
+	assert.Equal(t, map[field.ID]aggregation.AggregatorSpec{field.ID(10): spec}, sPlan.fields)
+
+	// down sampling by database interval if function call or interval
+	for _, sql := range []string{"select sum(f) from cpu", "select f from cpu group by time(1m)", "select f+1 from cpu"} {
+		query, sPlan = storagePlan(sql)
+		assert.False(t, query.Raw, sql)
+		spec = aggregation.NewDownSamplingSpec("f", field.SumField)
+		spec.AddFunctionType(function.Sum)
+		assert.Equal(t, map[field.ID]aggregation.AggregatorSpec{field.ID(10): spec}, sPlan.fields, sql)
+	}
+}
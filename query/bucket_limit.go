@@ -0,0 +1,39 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// ErrTooManyBuckets represents the num. of time buckets of group by time interval exceeds the max bucket limit of query
+var ErrTooManyBuckets = errors.New("too many time buckets for query, exceeds max bucket limit")
+
+// checkBucketLimit checks the num. of time buckets of query based on interval and time range(s),
+// returns ErrTooManyBuckets with the minimum interval suggested if exceeds max bucket limit(<= 0 means no limit).
+func checkBucketLimit(query *stmt.Query, maxBuckets int) error {
+	limit := int64(maxBuckets)
+	interval := int64(query.Interval)
+	if limit <= 0 || interval <= 0 {
+		return nil
+	}
+	timeRanges := query.TimeRanges
+	if len(timeRanges) == 0 {
+		timeRanges = []timeutil.TimeRange{query.TimeRange}
+	}
+	var buckets, duration int64
+	for _, timeRange := range timeRanges {
+		buckets += int64(timeutil.CalPointCount(timeRange.Start, timeRange.End, interval))
+		duration += timeRange.End - timeRange.Start
+	}
+	if buckets <= limit {
+		return nil
+	}
+	// num. of buckets of min interval: ceil(duration/interval) <= limit
+	minInterval := (duration + limit - 1) / limit
+	return fmt.Errorf("%w, buckets: %d, limit: %d, use a coarser interval of group by time, at least %s",
+		ErrTooManyBuckets, buckets, limit, time.Duration(minInterval)*time.Millisecond)
+}
@@ -1,6 +1,11 @@
 package query
 
 import (
+	"fmt"
+
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -9,17 +14,23 @@ import (
 type storageExecuteContext struct {
 	query    *stmt.Query
 	shardIDs []int32
+	metricID uint32
 
 	tagFilterResult map[string]*tagFilterResult
 
 	stats *models.StorageStats // storage query stats track for explain query
+
+	cfg storageQueryConfig
+
+	groupedSeries atomic.Int32 // num. of grouped series for checking max series limit
 }
 
 // newStorageExecuteContext creates storage execute context
-func newStorageExecuteContext(shardIDs []int32, query *stmt.Query) *storageExecuteContext {
+func newStorageExecuteContext(shardIDs []int32, query *stmt.Query, cfg storageQueryConfig) *storageExecuteContext {
 	ctx := &storageExecuteContext{
 		query:    query,
 		shardIDs: shardIDs,
+		cfg:      cfg,
 	}
 	if query.Explain {
 		// if explain query, create storage query stats
@@ -36,6 +47,22 @@ func (ctx *storageExecuteContext) QueryStats() *models.StorageStats {
 	return ctx.stats
 }
 
+// SpillOption returns the spill option of group by aggregator
+func (ctx *storageExecuteContext) SpillOption() aggregation.SpillOption {
+	return ctx.cfg.spillOption
+}
+
+// addGroupedSeries adds the num. of grouped series, returns ErrTooManySeries if exceeds max series limit.
+// NOTICE: counted by each series ids container, so the same group in different containers is counted repeatedly.
+func (ctx *storageExecuteContext) addGroupedSeries(count int) error {
+	total := ctx.groupedSeries.Add(int32(count))
+	limit := int32(ctx.cfg.maxSeries)
+	if limit > 0 && total > limit {
+		return fmt.Errorf("%w, grouped series: %d, limit: %d", ErrTooManySeries, total, limit)
+	}
+	return nil
+}
+
 // setTagFilterResult sets tag filter result
 func (ctx *storageExecuteContext) setTagFilterResult(tagFilterResult map[string]*tagFilterResult) {
 	ctx.tagFilterResult = tagFilterResult
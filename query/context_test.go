@@ -5,11 +5,19 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestStorageExecuteContext(t *testing.T) {
-	ctx := newStorageExecuteContext(nil, &stmt.Query{Explain: true})
+	ctx := newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{})
 	ctx.setTagFilterResult(nil)
 	assert.NotNil(t, ctx.QueryStats())
+	assert.Equal(t, aggregation.SpillOption{}, ctx.SpillOption())
+}
+
+func TestStorageExecuteContext_SpillOption(t *testing.T) {
+	spillOption := aggregation.SpillOption{MaxGroups: 100, Dir: "/tmp/spill"}
+	ctx := newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{spillOption: spillOption})
+	assert.Equal(t, spillOption, ctx.SpillOption())
 }
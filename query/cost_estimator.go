@@ -0,0 +1,127 @@
+package query
+
+import (
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+)
+
+//go:generate mockgen -source ./cost_estimator.go -destination=./cost_estimator_mock.go -package query
+
+const (
+	// defaultCostInterval is the interval for calculating the num. of points if query without interval
+	defaultCostInterval = 10 * timeutil.OneSecond
+	// regexCostFactor is the extra cost factor of each regex predicate, because it matches all tag values under tag key
+	regexCostFactor = 10
+)
+
+// CardinalityEstimator represents the estimator of series cardinality
+type CardinalityEstimator interface {
+	// EstimateSeries estimates the num. of series under metric which match the tag filter condition
+	EstimateSeries(namespace, metricName string, condition stmt.Expr) uint64
+}
+
+// shardCardinalityEstimator implements CardinalityEstimator interface,
+// sums the series estimated by index of each shard.
+type shardCardinalityEstimator struct {
+	database tsdb.Database
+	shardIDs []int32
+}
+
+// newShardCardinalityEstimator creates the cardinality estimator based on index of shards
+func newShardCardinalityEstimator(database tsdb.Database, shardIDs []int32) CardinalityEstimator {
+	return &shardCardinalityEstimator{
+		database: database,
+		shardIDs: shardIDs,
+	}
+}
+
+// EstimateSeries estimates the num. of series under metric which match the tag filter condition
+func (e *shardCardinalityEstimator) EstimateSeries(namespace, metricName string, condition stmt.Expr) uint64 {
+	seriesCount := uint64(0)
+	for _, shardID := range e.shardIDs {
+		shard, ok := e.database.GetShard(shardID)
+		if !ok {
+			continue
+		}
+		seriesCount += shard.IndexDatabase().EstimateSeries(namespace, metricName, condition)
+	}
+	return seriesCount
+}
+
+// CostEstimator represents the estimator which calculates a comparable cost of query before execution,
+// the cost is used for prioritizing or rejecting query(admission control).
+type CostEstimator interface {
+	// EstimateCost estimates the cost of query
+	EstimateCost(query *stmt.Query) float64
+}
+
+// costEstimator implements CostEstimator interface
+type costEstimator struct {
+	cardinality CardinalityEstimator
+}
+
+// NewCostEstimator creates the cost estimator based on series cardinality estimator
+func NewCostEstimator(cardinality CardinalityEstimator) CostEstimator {
+	return &costEstimator{cardinality: cardinality}
+}
+
+// EstimateCost estimates the cost of query, cost = series * fields * points * predicate factor,
+// 1) series is the estimated series cardinality which match the condition
+// 2) fields is the num. of select fields
+// 3) points is the num. of points for each field based on time range width and interval
+// 4) predicate factor inflates the cost if condition has expensive regex predicate
+func (e *costEstimator) EstimateCost(query *stmt.Query) float64 {
+	seriesCount := e.cardinality.EstimateSeries(query.Namespace, query.MetricName, query.Condition)
+	if seriesCount == 0 {
+		seriesCount = 1
+	}
+	fields := len(query.FieldNames)
+	if fields == 0 {
+		fields = 1
+	}
+	return float64(seriesCount) * float64(fields) * float64(estimatePoints(query)) * predicateFactor(query.Condition)
+}
+
+// estimatePoints returns the num. of points for each field in query time range(s)
+func estimatePoints(query *stmt.Query) int64 {
+	interval := query.Interval.Int64()
+	if interval <= 0 {
+		interval = defaultCostInterval
+	}
+	points := int64(0)
+	for _, timeRange := range query.GetTimeRanges() {
+		if timeRange.End < timeRange.Start {
+			continue
+		}
+		points += (timeRange.End-timeRange.Start)/interval + 1
+	}
+	if points == 0 {
+		points = 1
+	}
+	return points
+}
+
+// predicateFactor returns the cost factor of condition, each regex predicate adds regexCostFactor
+func predicateFactor(condition stmt.Expr) float64 {
+	factor := 1.0
+	if condition == nil {
+		return factor
+	}
+	stack := []stmt.Expr{condition}
+	for len(stack) > 0 {
+		expr := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch e := expr.(type) {
+		case *stmt.RegexExpr:
+			factor += regexCostFactor
+		case *stmt.ParenExpr:
+			stack = append(stack, e.Expr)
+		case *stmt.NotExpr:
+			stack = append(stack, e.Expr)
+		case *stmt.BinaryExpr:
+			stack = append(stack, e.Left, e.Right)
+		}
+	}
+	return factor
+}
@@ -0,0 +1,81 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/indexdb"
+)
+
+func TestCostEstimator_EstimateCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cardinality := NewMockCardinalityEstimator(ctrl)
+	cardinality.EXPECT().EstimateSeries(gomock.Any(), "cpu", gomock.Any()).Return(uint64(10)).AnyTimes()
+	cardinality.EXPECT().EstimateSeries(gomock.Any(), "disk", gomock.Any()).Return(uint64(10000)).AnyTimes()
+	cardinality.EXPECT().EstimateSeries(gomock.Any(), "empty", gomock.Any()).Return(uint64(0)).AnyTimes()
+	estimator := NewCostEstimator(cardinality)
+
+	cost := func(sqlStr string) float64 {
+		q, err := sql.Parse(sqlStr)
+		assert.NoError(t, err)
+		return estimator.EstimateCost(q.(*stmt.Query))
+	}
+	cheap := cost("select f from cpu where time>now()-1h and time<now()")
+	// more series
+	assert.True(t, cost("select f from disk where time>now()-1h and time<now()") > cheap)
+	// more fields
+	assert.True(t, cost("select f,f1,f2 from cpu where time>now()-1h and time<now()") > cheap)
+	// wider time range
+	assert.True(t, cost("select f from cpu where time>now()-1d and time<now()") > cheap)
+	// smaller interval
+	assert.True(t, cost("select f from cpu where time>now()-1h and time<now() group by time(1s)") > cheap)
+	assert.True(t, cost("select f from cpu where time>now()-1h and time<now() group by time(1m)") < cheap)
+	// regex predicate inflates the cost
+	equals := cost("select f from cpu where host='a' and time>now()-1h and time<now()")
+	regex := cost("select f from cpu where host=~'a.*' and time>now()-1h and time<now()")
+	assert.True(t, regex > equals)
+	assert.True(t, cost("select f from cpu where (host=~'a.*' or ip=~'b.*') and time>now()-1h and time<now()") > regex)
+	assert.True(t, cost("select f from cpu where not (host=~'a.*') and time>now()-1h and time<now()") > equals)
+	// no series found, cost is at least same as one series
+	assert.True(t, cost("select f from empty where time>now()-1h and time<now()") > 0)
+}
+
+func TestCostEstimator_estimatePoints(t *testing.T) {
+	q := &stmt.Query{}
+	assert.Equal(t, int64(1), estimatePoints(q))
+	q.TimeRange.Start = 100
+	assert.Equal(t, int64(1), estimatePoints(q))
+}
+
+func TestShardCardinalityEstimator_EstimateSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := tsdb.NewMockDatabase(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	index := indexdb.NewMockIndexDatabase(ctrl)
+	db.EXPECT().GetShard(int32(1)).Return(shard, true).AnyTimes()
+	db.EXPECT().GetShard(int32(2)).Return(shard, true).AnyTimes()
+	db.EXPECT().GetShard(int32(3)).Return(nil, false).AnyTimes()
+	shard.EXPECT().IndexDatabase().Return(index).AnyTimes()
+	condition := &stmt.EqualsExpr{Key: "host", Value: "a"}
+	index.EXPECT().EstimateSeries("ns", "cpu", condition).Return(uint64(10)).AnyTimes()
+
+	// sums the series of shards, skips the shard not exist
+	estimator := newShardCardinalityEstimator(db, []int32{1, 2, 3})
+	assert.Equal(t, uint64(20), estimator.EstimateSeries("ns", "cpu", condition))
+	assert.Zero(t, newShardCardinalityEstimator(db, nil).EstimateSeries("ns", "cpu", condition))
+
+	// factory estimates the cost by series of shards
+	cost := NewExecutorFactory().EstimateCost(db, []int32{1, 2}, &stmt.Query{
+		Namespace: "ns", MetricName: "cpu", Condition: condition, FieldNames: []string{"f"},
+	})
+	assert.Equal(t, float64(20), cost)
+}
@@ -2,7 +2,13 @@ package query
 
 import (
 	"errors"
+
+	"github.com/lindb/lindb/constants"
 )
 
+// ErrMetricNotFound represents the metric of query not found, matches constants.ErrMetricNotFound by errors.Is
+var ErrMetricNotFound = constants.ErrMetricNotFound
+
 var errNoAvailableStorageNode = errors.New("no available storage node for server")
 var errDatabaseNotExist = errors.New("database not exist")
+var errInvalidOutputInterval = errors.New("output step must be a multiple or divisor of interval")
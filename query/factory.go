@@ -2,7 +2,9 @@ package query
 
 import (
 	"context"
+	"time"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
@@ -13,13 +15,32 @@ import (
 )
 
 // executorFactory implements parallel.ExecutorFactory
-type executorFactory struct{}
+type executorFactory struct {
+	brokerCfg  brokerQueryConfig
+	storageCfg storageQueryConfig
+}
 
-// NewExecutorFactory creates executor factory
+// NewExecutorFactory creates executor factory with default query config
 func NewExecutorFactory() parallel.ExecutorFactory {
 	return &executorFactory{}
 }
 
+// NewBrokerExecutorFactory creates executor factory for broker side,
+// which parses sql and limits the points returned for each series based on query config.
+func NewBrokerExecutorFactory(cfg config.Query) parallel.ExecutorFactory {
+	return &executorFactory{
+		brokerCfg: newBrokerQueryConfig(cfg),
+	}
+}
+
+// NewStorageExecutorFactory creates executor factory for storage side,
+// which limits the series/groups of query and guards the series search by index breaker based on query config.
+func NewStorageExecutorFactory(cfg config.Query) parallel.ExecutorFactory {
+	return &executorFactory{
+		storageCfg: newStorageQueryConfig(cfg),
+	}
+}
+
 // NewStorageExecutor creates storage executor
 func (*executorFactory) NewStorageExecutor(
 	queryFlow flow.StorageQueryFlow,
@@ -30,27 +51,29 @@ func (*executorFactory) NewStorageExecutor(
 }
 
 // NewMetadataStorageExecutor creates the metadata executor in storage side
-func (*executorFactory) NewMetadataStorageExecutor(
+func (f *executorFactory) NewMetadataStorageExecutor(
 	database tsdb.Database,
 	shardIDs []int32,
 	request *stmt.Metadata,
 ) parallel.MetadataExecutor {
-	return newMetadataStorageExecutor(database, shardIDs, request)
+	return newMetadataStorageExecutor(database, shardIDs, request, f.storageCfg.indexBreaker)
 }
 
 // NewStorageExecutor creates broker executor
-func (*executorFactory) NewBrokerExecutor(
+func (f *executorFactory) NewBrokerExecutor(
 	ctx context.Context,
 	databaseName string,
 	sql string,
+	location *time.Location,
+	sampleRate float64,
 	replicaStateMachine replica.StatusStateMachine,
 	nodeStateMachine broker.NodeStateMachine,
 	databaseStateMachine database.DBStateMachine,
 	jobManager parallel.JobManager,
 ) parallel.BrokerExecutor {
-	return newBrokerExecutor(ctx, databaseName, sql,
+	return newBrokerExecutor(ctx, databaseName, sql, location, sampleRate,
 		replicaStateMachine, nodeStateMachine, databaseStateMachine,
-		jobManager)
+		jobManager, f.brokerCfg)
 }
 
 // NewMetadataBrokerExecutor creates the metadata executor in broker side
@@ -66,6 +89,11 @@ func (*executorFactory) NewMetadataBrokerExecutor(
 }
 
 // NewStorageExecuteContext creates the storage execute context in storage side
-func (*executorFactory) NewStorageExecuteContext(shardIDs []int32, query *stmt.Query) parallel.StorageExecuteContext {
-	return newStorageExecuteContext(shardIDs, query)
+func (f *executorFactory) NewStorageExecuteContext(shardIDs []int32, query *stmt.Query) parallel.StorageExecuteContext {
+	return newStorageExecuteContext(shardIDs, query, f.storageCfg)
+}
+
+// EstimateCost estimates the cost of query in storage side, based on the series cardinality of shards' index
+func (*executorFactory) EstimateCost(database tsdb.Database, shardIDs []int32, query *stmt.Query) float64 {
+	return NewCostEstimator(newShardCardinalityEstimator(database, shardIDs)).EstimateCost(query)
 }
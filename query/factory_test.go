@@ -3,10 +3,16 @@ package query
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 )
@@ -17,14 +23,61 @@ func TestExecutorFactory_NewExecutor(t *testing.T) {
 
 	factory := NewExecutorFactory()
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
-	assert.NotNil(t, factory.NewStorageExecutor(nil, mockDatabase, newStorageExecuteContext(nil, &stmt.Query{})))
+	assert.NotNil(t, factory.NewStorageExecutor(nil, mockDatabase, newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{})))
 	assert.NotNil(t, factory.NewBrokerExecutor(
-		context.TODO(), "db", "sql", nil, nil, nil, nil))
+		context.TODO(), "db", "sql", nil, 0, nil, nil, nil, nil))
 	assert.NotNil(t, factory.NewMetadataStorageExecutor(nil, nil, nil))
+	assert.Nil(t, factory.(*executorFactory).storageCfg.indexBreaker)
 	assert.NotNil(t, factory.NewMetadataBrokerExecutor(
 		context.TODO(), "db", nil, nil, nil, nil))
 }
 
+func TestNewBrokerExecutorFactory(t *testing.T) {
+	factory := NewBrokerExecutorFactory(config.Query{
+		MaxPointsPerSeries:    100,
+		TruncatePoints:        true,
+		NaNPolicy:             "store",
+		MaxExprDepth:          10,
+		MaxBucketsPerQuery:    -1,
+		DefaultTimeRange:      ltoml.Duration(time.Minute),
+		MeasurementTimeRanges: map[string]string{"cpu": "1h", "mem": "1x"},
+	})
+	expect := brokerQueryConfig{
+		parser: sql.ParserConfig{
+			MaxExprDepth:     10,
+			DefaultTimeRange: time.Minute,
+		},
+		pointsLimit: parallel.PointsLimit{MaxPointsPerSeries: 100, Truncate: true},
+		nanPolicy:   aggregation.StoreNaN,
+	}
+	assert.Equal(t, expect, factory.(*executorFactory).brokerCfg)
+	exec := factory.NewBrokerExecutor(context.TODO(), "db", "sql", nil, 0, nil, nil, nil, nil)
+	assert.Equal(t, expect, exec.(*brokerExecutor).cfg)
+
+	assert.Equal(t, sql.ParserConfig{MeasurementTimeRanges: map[string]time.Duration{"cpu": time.Hour}},
+		NewParserConfig(config.Query{MeasurementTimeRanges: map[string]string{"cpu": "1h"}}))
+}
+
+func TestNewStorageExecutorFactory(t *testing.T) {
+	factory := NewStorageExecutorFactory(config.Query{
+		MaxSeriesPerQuery:     -1,
+		MaxGroupsInMemory:     100,
+		SpillDir:              "/tmp/spill",
+		IndexBreakerThreshold: 2,
+	})
+	ctx := factory.NewStorageExecuteContext(nil, &stmt.Query{}).(*storageExecuteContext)
+	assert.Zero(t, ctx.cfg.maxSeries)
+	assert.Equal(t, aggregation.SpillOption{MaxGroups: 100, Dir: "/tmp/spill"}, ctx.SpillOption())
+	assert.NotNil(t, ctx.cfg.indexBreaker)
+	// index breaker is shared by all queries
+	exec := factory.NewMetadataStorageExecutor(nil, nil, nil)
+	assert.Equal(t, ctx.cfg.indexBreaker, exec.(*metadataStorageExecutor).indexBreaker)
+	// negative max groups means never spilling
+	factory = NewStorageExecutorFactory(config.Query{MaxGroupsInMemory: -1, SpillDir: "/tmp/spill"})
+	ctx = factory.NewStorageExecuteContext(nil, &stmt.Query{}).(*storageExecuteContext)
+	assert.Equal(t, aggregation.SpillOption{Dir: "/tmp/spill"}, ctx.SpillOption())
+}
+
 func TestNewExecutorFactory_NewContext(t *testing.T) {
 	factory := NewExecutorFactory()
 	assert.NotNil(t, factory.NewStorageExecuteContext(nil, &stmt.Query{}))
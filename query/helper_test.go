@@ -22,9 +22,10 @@ func newMockDatabase(ctrl *gomock.Controller) *tsdb.MockDatabase {
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadataIndex := metadb.NewMockMetadataDatabase(ctrl)
 	metadata.EXPECT().MetadataDatabase().Return(metadataIndex).AnyTimes()
+	mockSchemaCache(metadata, metadataIndex)
 	metadataIndex.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(10), nil).AnyTimes()
-	metadataIndex.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(field.Meta{ID: 10, Type: field.SumField}, nil).AnyTimes()
+	metadataIndex.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]field.Meta{{ID: 10, Type: field.SumField}}, nil).AnyTimes()
 
 	mockedDatabase := tsdb.NewMockDatabase(ctrl)
 	mockedDatabase.EXPECT().GetShard(gomock.Any()).Return(shard, true).AnyTimes()
@@ -32,3 +33,12 @@ func newMockDatabase(ctrl *gomock.Controller) *tsdb.MockDatabase {
 	mockedDatabase.EXPECT().NumOfShards().Return(3).AnyTimes()
 	return mockedDatabase
 }
+
+// mockSchemaCache mocks the schema cache of metadata, creates a new schema cache for each call,
+// so that the metadata reading can be asserted by metadata database.
+func mockSchemaCache(metadata *metadb.MockMetadata, metadataDB *metadb.MockMetadataDatabase) {
+	metadataDB.EXPECT().GetSchemaVersion(gomock.Any(), gomock.Any()).Return(uint64(0)).AnyTimes()
+	metadata.EXPECT().SchemaCache().DoAndReturn(func() metadb.SchemaCache {
+		return metadb.NewSchemaCache(metadataDB)
+	}).AnyTimes()
+}
@@ -0,0 +1,165 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// ErrIndexUnavailable represents the series search is fast-failed because index breaker is open
+var ErrIndexUnavailable = errors.New("index unavailable, too many index lookup failures")
+
+// BreakerState represents the state of index circuit breaker
+type BreakerState int
+
+// Defines all states of index circuit breaker
+const (
+	// BreakerClosed passes through all index lookups
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fast-fails all index lookups until cooldown passes
+	BreakerOpen
+	// BreakerHalfOpen lets one trial lookup pass through after cooldown,
+	// closes the breaker if trial success, else opens it again
+	BreakerHalfOpen
+)
+
+// String returns the string value of breaker state
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// indexBreaker represents the circuit breaker of index lookup,
+// opens after threshold consecutive failures within window, fast-fails the lookup until cooldown passes.
+type indexBreaker struct {
+	threshold int   // 0 means disable breaker
+	window    int64 // millis
+	cooldown  int64 // millis
+
+	state        BreakerState
+	failures     int
+	firstFailure int64
+	openedAt     int64
+	now          func() int64
+
+	mutex sync.Mutex
+}
+
+// newIndexBreaker creates the index breaker, threshold <= 0 disables the breaker
+func newIndexBreaker(threshold int, window, cooldown time.Duration) *indexBreaker {
+	b := &indexBreaker{now: timeutil.Now}
+	b.setPolicy(threshold, window, cooldown)
+	return b
+}
+
+// setPolicy sets the policy of breaker, then resets it to closed
+func (b *indexBreaker) setPolicy(threshold int, window, cooldown time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.threshold = threshold
+	b.window = window.Milliseconds()
+	b.cooldown = cooldown.Milliseconds()
+	b.reset()
+}
+
+// State returns the current state of breaker
+func (b *indexBreaker) State() BreakerState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state
+}
+
+// search searches series ids through the breaker, fast-fails if index is degraded, nil breaker passes through
+func (b *indexBreaker) search(seriesSearch SeriesSearch) (*roaring.Bitmap, error) {
+	if b == nil {
+		return seriesSearch.Search()
+	}
+	if err := b.Allow(); err != nil {
+		return nil, fmt.Errorf("series search: %w", err)
+	}
+	seriesIDs, err := seriesSearch.Search()
+	b.Record(err)
+	if err != nil {
+		return nil, err
+	}
+	return seriesIDs, nil
+}
+
+// Allow checks if the index lookup can pass through, returns ErrIndexUnavailable if breaker is open
+func (b *indexBreaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now()-b.openedAt < b.cooldown {
+			return ErrIndexUnavailable
+		}
+		// cooldown passed, lets one trial lookup pass through
+		b.state = BreakerHalfOpen
+		return nil
+	case BreakerHalfOpen:
+		// trial lookup is running
+		return ErrIndexUnavailable
+	default:
+		return nil
+	}
+}
+
+// Record records the result of index lookup which is allowed by breaker, not found isn't regarded as failure
+func (b *indexBreaker) Record(err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.threshold <= 0 {
+		return
+	}
+	if err == nil || errors.Is(err, constants.ErrNotFound) {
+		b.reset()
+		return
+	}
+	now := b.now()
+	if b.state == BreakerHalfOpen {
+		// trial lookup failure
+		b.open(now)
+		return
+	}
+	if b.failures == 0 || now-b.firstFailure > b.window {
+		// starts a new window
+		b.failures = 0
+		b.firstFailure = now
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.open(now)
+	}
+}
+
+// open opens the breaker
+func (b *indexBreaker) open(now int64) {
+	b.state = BreakerOpen
+	b.openedAt = now
+	b.failures = 0
+}
+
+// reset resets the breaker to closed
+func (b *indexBreaker) reset() {
+	b.state = BreakerClosed
+	b.failures = 0
+	b.firstFailure = 0
+	b.openedAt = 0
+}
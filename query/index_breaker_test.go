@@ -0,0 +1,107 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestIndexBreaker(t *testing.T) {
+	now := int64(0)
+	b := newIndexBreaker(3, 10*time.Second, 30*time.Second)
+	b.now = func() int64 { return now }
+	assert.Equal(t, BreakerClosed, b.State())
+
+	// failures out of window
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, b.Allow())
+		b.Record(fmt.Errorf("err"))
+		now += 6 * time.Second.Milliseconds()
+	}
+	assert.Equal(t, BreakerClosed, b.State())
+	// success/not found resets the consecutive failures
+	b.Record(fmt.Errorf("err"))
+	b.Record(constants.ErrNotFound)
+	b.Record(fmt.Errorf("err"))
+	b.Record(nil)
+	assert.Equal(t, BreakerClosed, b.State())
+
+	// trips after N failures
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, b.Allow())
+		b.Record(fmt.Errorf("err"))
+	}
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.Equal(t, ErrIndexUnavailable, b.Allow())
+	now += 29 * time.Second.Milliseconds()
+	assert.Equal(t, ErrIndexUnavailable, b.Allow())
+
+	// trial failure after cooldown, opens again
+	now += time.Second.Milliseconds()
+	assert.NoError(t, b.Allow())
+	assert.Equal(t, BreakerHalfOpen, b.State())
+	assert.Equal(t, ErrIndexUnavailable, b.Allow())
+	b.Record(fmt.Errorf("err"))
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.Equal(t, ErrIndexUnavailable, b.Allow())
+
+	// recovers after cooldown
+	now += 30 * time.Second.Milliseconds()
+	assert.NoError(t, b.Allow())
+	b.Record(nil)
+	assert.Equal(t, BreakerClosed, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+func TestIndexBreaker_disable(t *testing.T) {
+	b := newIndexBreaker(0, time.Second, time.Second)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, b.Allow())
+		b.Record(fmt.Errorf("err"))
+	}
+	assert.Equal(t, BreakerClosed, b.State())
+}
+
+func TestBreakerState_String(t *testing.T) {
+	assert.Equal(t, "closed", BreakerClosed.String())
+	assert.Equal(t, "open", BreakerOpen.String())
+	assert.Equal(t, "half-open", BreakerHalfOpen.String())
+}
+
+func TestSeriesSearch_Search_breaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	breaker := newIndexBreaker(2, time.Minute, time.Hour)
+
+	filter := series.NewMockFilter(ctrl)
+	filter.EXPECT().GetSeriesIDsByTagValueIDs(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("err")).Times(2)
+	expr := &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"}
+	filterResult := map[string]*tagFilterResult{expr.Rewrite(): {tagKey: 1, tagValueIDs: roaring.BitmapOf(1)}}
+	for i := 0; i < 2; i++ {
+		_, err := breaker.search(newSeriesSearch(filter, filterResult, expr))
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrIndexUnavailable))
+	}
+	assert.Equal(t, BreakerOpen, breaker.State())
+	// fast-fails without index lookup
+	_, err := breaker.search(newSeriesSearch(filter, filterResult, expr))
+	assert.True(t, errors.Is(err, ErrIndexUnavailable))
+
+	// nil breaker passes through
+	var noBreaker *indexBreaker
+	filter.EXPECT().GetSeriesIDsByTagValueIDs(gomock.Any(), gomock.Any()).Return(roaring.BitmapOf(1), nil)
+	seriesIDs, err := noBreaker.search(newSeriesSearch(filter, filterResult, expr))
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1), seriesIDs)
+}
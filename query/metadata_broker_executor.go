@@ -69,6 +69,11 @@ func (e *metadataBrokerExecutor) submitJob(physicalPlan *models.PhysicalPlan, re
 		result = append(result, value)
 	}
 	sort.Strings(result)
+	// each storage node applies the limit, so the merged result needs to be limited again
+	limit := e.request.Limit
+	if e.request.Type != stmt.Field && limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
 	return
 }
 
@@ -51,4 +51,17 @@ func TestMetadataBrokerExecutor_Execute(t *testing.T) {
 	rs, err = e.submitJob(nil, resultCh)
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"a", "b", "d"}, rs)
+
+	// merged result over limit
+	jobManager.EXPECT().SubmitMetadataJob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	e.request = &stmt.Metadata{Type: stmt.TagKey, Limit: 2}
+	resultCh = make(chan []string)
+	go func() {
+		resultCh <- []string{"b", "d"}
+		resultCh <- []string{"a", "c"}
+		close(resultCh)
+	}()
+	rs, err = e.submitJob(nil, resultCh)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, rs)
 }
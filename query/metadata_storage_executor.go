@@ -1,6 +1,8 @@
 package query
 
 import (
+	"github.com/lindb/roaring"
+
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/parallel"
 	"github.com/lindb/lindb/pkg/encoding"
@@ -10,19 +12,21 @@ import (
 
 // metadataStorageExecutor represents the executor which executes metric metadata suggest in storage side
 type metadataStorageExecutor struct {
-	database tsdb.Database
-	request  *stmt.Metadata
-	shardIDs []int32
+	database     tsdb.Database
+	request      *stmt.Metadata
+	shardIDs     []int32
+	indexBreaker *indexBreaker
 }
 
 // newMetadataStorageExecutor creates a metadata suggest executor in storage side
 func newMetadataStorageExecutor(database tsdb.Database, shardIDs []int32,
-	request *stmt.Metadata,
+	request *stmt.Metadata, indexBreaker *indexBreaker,
 ) parallel.MetadataExecutor {
 	return &metadataStorageExecutor{
-		database: database,
-		request:  request,
-		shardIDs: shardIDs,
+		database:     database,
+		request:      request,
+		shardIDs:     shardIDs,
+		indexBreaker: indexBreaker,
 	}
 }
 
@@ -35,6 +39,10 @@ func (e *metadataStorageExecutor) Execute() (result []string, err error) {
 	case stmt.Namespace:
 		return e.database.Metadata().MetadataDatabase().SuggestNamespace(req.Prefix, limit)
 	case stmt.Metric:
+		if req.Prefix == "" {
+			// show measurements without prefix lists the measurement names
+			return e.database.Metadata().MetadataDatabase().GetMetricNames(req.Namespace, limit)
+		}
 		return e.database.Metadata().MetadataDatabase().SuggestMetrics(req.Namespace, req.Prefix, limit)
 	case stmt.TagKey:
 		return e.database.Metadata().MetadataDatabase().SuggestTagKeys(req.Namespace, req.MetricName, req.Prefix, limit)
@@ -50,58 +58,107 @@ func (e *metadataStorageExecutor) Execute() (result []string, err error) {
 		if err != nil {
 			return nil, err
 		}
-		if req.Condition == nil {
-			// if not tag filter condition, just get tag value by tag key
+		if req.Condition == nil && req.TimeRange.IsEmpty() {
+			// if not tag filter condition and time range, just get tag value by tag key
 			result = e.database.Metadata().TagMetadata().SuggestTagValues(tagKeyID, req.Prefix, limit)
-		} else {
-			// 1. do tag filter
-			tagSearch := newTagSearchFunc(req.Namespace, req.MetricName,
-				req.Condition, e.database.Metadata())
-			tagFilterResult, err := tagSearch.Filter()
+			return result, nil
+		}
+		return e.collectTagValues(tagKeyID)
+	}
+	return result, nil
+}
+
+// collectTagValues collects the tag values of the series which match the tag filter condition,
+// if the time range of request is not empty, only the series which have data in time range match.
+func (e *metadataStorageExecutor) collectTagValues(tagKeyID uint32) (result []string, err error) {
+	req := e.request
+	var tagFilterResult map[string]*tagFilterResult
+	if req.Condition != nil {
+		// 1. do tag filter
+		tagSearch := newTagSearchFunc(req.Namespace, req.MetricName,
+			req.Condition, e.database.Metadata())
+		tagFilterResult, err = tagSearch.Filter()
+		if err != nil {
+			return nil, err
+		}
+		if len(tagFilterResult) == 0 {
+			// filter not match, return not found
+			return nil, constants.ErrNotFound
+		}
+	}
+	var metricID uint32
+	if !req.TimeRange.IsEmpty() {
+		metricID, err = e.database.Metadata().MetadataDatabase().GetMetricID(req.Namespace, req.MetricName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	groupByTagKeyIDs := []uint32{tagKeyID}
+	// get shard by given query shard id list
+	for _, shardID := range e.shardIDs {
+		shard, ok := e.database.GetShard(shardID)
+		// if shard exist, do series search
+		if !ok {
+			continue
+		}
+		seriesIDs, err := e.searchSeriesIDs(shard, metricID, tagKeyID, tagFilterResult)
+		if err != nil {
+			return nil, err
+		}
+		if seriesIDs.IsEmpty() {
+			continue
+		}
+		// get grouping based on tag keys and series ids
+		gCtx, err := shard.IndexDatabase().GetGroupingContext(groupByTagKeyIDs, seriesIDs)
+		if err != nil {
+			return nil, err
+		}
+		highKeys := seriesIDs.GetHighKeys()
+		for i, highKey := range highKeys {
+			// get tag value ids
+			tagValueIDs := gCtx.ScanTagValueIDs(highKey, seriesIDs.GetContainerAtIndex(i))
+			tagValues := make(map[uint32]string)
+			// get tag value
+			err = e.database.Metadata().TagMetadata().CollectTagValues(tagKeyID, tagValueIDs[0], tagValues)
 			if err != nil {
 				return nil, err
 			}
-			if len(tagFilterResult) == 0 {
-				// filter not match, return not found
-				return nil, constants.ErrNotFound
-			}
-			groupByTagKeyIDs := []uint32{tagKeyID}
-			// get shard by given query shard id list
-			for _, shardID := range e.shardIDs {
-				shard, ok := e.database.GetShard(shardID)
-				// if shard exist, do series search
-				if ok {
-					// if get tag filter result do series ids searching
-					seriesSearch := newSeriesSearchFunc(shard.IndexDatabase(), tagFilterResult, req.Condition)
-					seriesIDs, err := seriesSearch.Search()
-					if err != nil {
-						return nil, err
-					}
-					// get grouping based on tag keys and series ids
-					gCtx, err := shard.IndexDatabase().GetGroupingContext(groupByTagKeyIDs, seriesIDs)
-					if err != nil {
-						return nil, err
-					}
-					highKeys := seriesIDs.GetHighKeys()
-					for i, highKey := range highKeys {
-						// get tag value ids
-						tagValueIDs := gCtx.ScanTagValueIDs(highKey, seriesIDs.GetContainerAtIndex(i))
-						tagValues := make(map[uint32]string)
-						// get tag value
-						err = e.database.Metadata().TagMetadata().CollectTagValues(tagKeyID, tagValueIDs[0], tagValues)
-						if err != nil {
-							return nil, err
-						}
-						for _, tagValue := range tagValues {
-							result = append(result, tagValue)
-							if len(result) >= limit {
-								return result, nil
-							}
-						}
-					}
+			for _, tagValue := range tagValues {
+				result = append(result, tagValue)
+				if len(result) >= req.Limit {
+					return result, nil
 				}
 			}
 		}
 	}
 	return result, nil
 }
+
+// searchSeriesIDs returns the series ids of shard which match the tag filter condition,
+// and have data in time range of request if not empty.
+func (e *metadataStorageExecutor) searchSeriesIDs(shard tsdb.Shard, metricID, tagKeyID uint32,
+	tagFilterResult map[string]*tagFilterResult,
+) (*roaring.Bitmap, error) {
+	req := e.request
+	var seriesIDs *roaring.Bitmap
+	if req.Condition != nil {
+		// if get tag filter result do series ids searching
+		seriesSearch := newSeriesSearchFunc(shard.IndexDatabase(), tagFilterResult, req.Condition)
+		ids, err := e.indexBreaker.search(seriesSearch)
+		if err != nil {
+			return nil, err
+		}
+		seriesIDs = ids
+	}
+	if req.TimeRange.IsEmpty() {
+		return seriesIDs, nil
+	}
+	activeSeriesIDs, err := shard.GetSeriesIDsForTagInTimeRange(metricID, tagKeyID, req.TimeRange)
+	if err != nil {
+		return nil, err
+	}
+	if seriesIDs == nil {
+		return activeSeriesIDs, nil
+	}
+	return roaring.FastAnd(seriesIDs, activeSeriesIDs), nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/sql/stmt"
@@ -31,7 +32,7 @@ func TestMetadataStorageExecutor_Execute(t *testing.T) {
 	// case 1: suggest namespace
 	exec := newMetadataStorageExecutor(db, nil, &stmt.Metadata{
 		Type: stmt.Namespace,
-	})
+	}, nil)
 	metadataIndex.EXPECT().SuggestNamespace(gomock.Any(), gomock.Any()).Return([]string{"a"}, nil)
 	result, err := exec.Execute()
 	assert.NoError(t, err)
@@ -39,17 +40,28 @@ func TestMetadataStorageExecutor_Execute(t *testing.T) {
 
 	// case 2: suggest metric name
 	exec = newMetadataStorageExecutor(db, nil, &stmt.Metadata{
-		Type: stmt.Metric,
-	})
+		Type:   stmt.Metric,
+		Prefix: "a",
+	}, nil)
 	metadataIndex.EXPECT().SuggestMetrics(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"a"}, nil)
 	result, err = exec.Execute()
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"a"}, result)
+	// list metric names without prefix
+	exec = newMetadataStorageExecutor(db, nil, &stmt.Metadata{
+		Namespace: "ns",
+		Type:      stmt.Metric,
+		Limit:     10,
+	}, nil)
+	metadataIndex.EXPECT().GetMetricNames("ns", 10).Return([]string{"a", "b"}, nil)
+	result, err = exec.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, result)
 
 	// case 3: suggest tag keys
 	exec = newMetadataStorageExecutor(db, nil, &stmt.Metadata{
 		Type: stmt.TagKey,
-	})
+	}, nil)
 	metadataIndex.EXPECT().SuggestTagKeys(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"a"}, nil)
 	result, err = exec.Execute()
 	assert.NoError(t, err)
@@ -57,7 +69,7 @@ func TestMetadataStorageExecutor_Execute(t *testing.T) {
 	// case 4: get fields err
 	exec = newMetadataStorageExecutor(db, nil, &stmt.Metadata{
 		Type: stmt.Field,
-	})
+	}, nil)
 	metadataIndex.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	result, err = exec.Execute()
 	assert.Error(t, err)
@@ -66,7 +78,7 @@ func TestMetadataStorageExecutor_Execute(t *testing.T) {
 	// case 5: get fields
 	exec = newMetadataStorageExecutor(db, nil, &stmt.Metadata{
 		Type: stmt.Field,
-	})
+	}, nil)
 	metadataIndex.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).Return([]field.Meta{{ID: 10}}, nil)
 	result, err = exec.Execute()
 	assert.NoError(t, err)
@@ -75,7 +87,7 @@ func TestMetadataStorageExecutor_Execute(t *testing.T) {
 	// case 6: suggest tag values
 	exec = newMetadataStorageExecutor(db, []int32{1, 2}, &stmt.Metadata{
 		Type: stmt.TagValue,
-	})
+	}, nil)
 	metadataIndex.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(2), nil)
 
 	tagMeta := metadb.NewMockTagMetadata(ctrl)
@@ -89,7 +101,7 @@ func TestMetadataStorageExecutor_Execute(t *testing.T) {
 	// case 7: suggest tag values err
 	exec = newMetadataStorageExecutor(db, []int32{1, 2}, &stmt.Metadata{
 		Type: stmt.TagValue,
-	})
+	}, nil)
 	metadataIndex.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(0), fmt.Errorf("err"))
 
 	result, err = exec.Execute()
@@ -123,7 +135,7 @@ func TestMetadataStorageExecutor_Execute_With_Tag_Condition(t *testing.T) {
 		Type:      stmt.TagValue,
 		Condition: &stmt.EqualsExpr{},
 		Limit:     2,
-	})
+	}, nil)
 	tagSearch.EXPECT().Filter().Return(nil, fmt.Errorf("err"))
 	_, err := exec.Execute()
 	assert.Error(t, err)
@@ -181,3 +193,139 @@ func TestMetadataStorageExecutor_Execute_With_Tag_Condition(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 }
+
+func TestMetadataStorageExecutor_Execute_CountDistinct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newTagSearchFunc = newTagSearch
+		newSeriesSearchFunc = newSeriesSearch
+
+		ctrl.Finish()
+	}()
+
+	db := tsdb.NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	metadataIndex := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataIndex).AnyTimes()
+	metadataIndex.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(2), nil)
+	tagSearch := NewMockTagSearch(ctrl)
+	newTagSearchFunc = func(namespace, metricName string, condition stmt.Expr, metadata metadb.Metadata) TagSearch {
+		return tagSearch
+	}
+	tagSearch.EXPECT().Filter().Return(map[string]*tagFilterResult{"region=sh": {}}, nil)
+	shard := tsdb.NewMockShard(ctrl)
+	db.EXPECT().GetShard(int32(1)).Return(shard, true)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	seriesSearch := NewMockSeriesSearch(ctrl)
+	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult, condition stmt.Expr) SeriesSearch {
+		return seriesSearch
+	}
+	// matched series 1~5 span three distinct host values
+	seriesIDs := roaring.BitmapOf(1, 2, 3, 4, 5)
+	seriesSearch.EXPECT().Search().Return(seriesIDs, nil)
+	gCtx := series.NewMockGroupingContext(ctrl)
+	indexDB.EXPECT().GetGroupingContext([]uint32{2}, seriesIDs).Return(gCtx, nil)
+	gCtx.EXPECT().ScanTagValueIDs(gomock.Any(), gomock.Any()).Return([]*roaring.Bitmap{roaring.BitmapOf(1, 2, 3)})
+	tagMeta := metadb.NewMockTagMetadata(ctrl)
+	metadata.EXPECT().TagMetadata().Return(tagMeta).AnyTimes()
+	tagMeta.EXPECT().CollectTagValues(uint32(2), roaring.BitmapOf(1, 2, 3), gomock.Any()).
+		DoAndReturn(func(tagKeyID uint32,
+			tagValueIDs *roaring.Bitmap,
+			tagValues map[uint32]string,
+		) error {
+			tagValues[1] = "1.1.1.1"
+			tagValues[2] = "1.1.1.2"
+			tagValues[3] = "1.1.1.3"
+			return nil
+		})
+
+	query := &stmt.Query{
+		Namespace:     "ns",
+		MetricName:    "cpu",
+		Condition:     &stmt.EqualsExpr{Key: "region", Value: "sh"},
+		CountDistinct: "host",
+	}
+	exec := newMetadataStorageExecutor(db, []int32{1}, query.CountDistinctRequest(), nil)
+	result, err := exec.Execute()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1.1.1.1", "1.1.1.2", "1.1.1.3"}, result)
+}
+
+func TestMetadataStorageExecutor_Execute_CountDistinct_TimeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newTagSearchFunc = newTagSearch
+		newSeriesSearchFunc = newSeriesSearch
+
+		ctrl.Finish()
+	}()
+
+	db := tsdb.NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	metadataIndex := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataIndex).AnyTimes()
+	metadataIndex.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(2), nil).AnyTimes()
+	tagMeta := metadb.NewMockTagMetadata(ctrl)
+	metadata.EXPECT().TagMetadata().Return(tagMeta).AnyTimes()
+	shard := tsdb.NewMockShard(ctrl)
+	db.EXPECT().GetShard(int32(1)).Return(shard, true).AnyTimes()
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	tagSearch := NewMockTagSearch(ctrl)
+	newTagSearchFunc = func(namespace, metricName string, condition stmt.Expr, metadata metadb.Metadata) TagSearch {
+		return tagSearch
+	}
+	seriesSearch := NewMockSeriesSearch(ctrl)
+	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult, condition stmt.Expr) SeriesSearch {
+		return seriesSearch
+	}
+	timeRange := timeutil.TimeRange{Start: 10, End: 20}
+	query := &stmt.Query{
+		Namespace:     "ns",
+		MetricName:    "cpu",
+		Condition:     &stmt.EqualsExpr{Key: "region", Value: "sh"},
+		CountDistinct: "host",
+		TimeRange:     timeRange,
+	}
+	exec := newMetadataStorageExecutor(db, []int32{1}, query.CountDistinctRequest(), nil)
+
+	// case 1: get metric id err
+	tagSearch.EXPECT().Filter().Return(map[string]*tagFilterResult{"region=sh": {}}, nil).AnyTimes()
+	metadataIndex.EXPECT().GetMetricID("ns", "cpu").Return(uint32(0), fmt.Errorf("err"))
+	_, err := exec.Execute()
+	assert.Error(t, err)
+	metadataIndex.EXPECT().GetMetricID("ns", "cpu").Return(uint32(5), nil).AnyTimes()
+	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3, 4, 5), nil).AnyTimes()
+	// case 2: get series ids in time range err
+	shard.EXPECT().GetSeriesIDsForTagInTimeRange(uint32(5), uint32(2), timeRange).Return(nil, fmt.Errorf("err"))
+	_, err = exec.Execute()
+	assert.Error(t, err)
+	// case 3: no matched series has data in time range
+	shard.EXPECT().GetSeriesIDsForTagInTimeRange(uint32(5), uint32(2), timeRange).Return(roaring.BitmapOf(6, 7), nil)
+	result, err := exec.Execute()
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	// case 4: only the matched series which have data in time range are counted
+	shard.EXPECT().GetSeriesIDsForTagInTimeRange(uint32(5), uint32(2), timeRange).Return(roaring.BitmapOf(2, 3, 7), nil)
+	gCtx := series.NewMockGroupingContext(ctrl)
+	indexDB.EXPECT().GetGroupingContext([]uint32{2}, roaring.BitmapOf(2, 3)).Return(gCtx, nil)
+	gCtx.EXPECT().ScanTagValueIDs(uint16(0), gomock.Any()).Return([]*roaring.Bitmap{roaring.BitmapOf(1)})
+	tagMeta.EXPECT().CollectTagValues(uint32(2), roaring.BitmapOf(1), gomock.Any()).
+		DoAndReturn(func(tagKeyID uint32, tagValueIDs *roaring.Bitmap, tagValues map[uint32]string) error {
+			tagValues[1] = "1.1.1.1"
+			return nil
+		})
+	result, err = exec.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.1.1.1"}, result)
+	// case 5: no condition, all series of tag key which have data in time range
+	query.Condition = nil
+	exec = newMetadataStorageExecutor(db, []int32{1}, query.CountDistinctRequest(), nil)
+	shard.EXPECT().GetSeriesIDsForTagInTimeRange(uint32(5), uint32(2), timeRange).Return(roaring.New(), nil)
+	result, err = exec.Execute()
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,71 @@
+package query
+
+import (
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/sql"
+)
+
+// brokerQueryConfig represents the config of query in broker side, built from query config
+type brokerQueryConfig struct {
+	parser      sql.ParserConfig
+	maxBuckets  int // max num. of time buckets of group by time interval over query time range, 0 means no limit
+	pointsLimit parallel.PointsLimit
+	nanPolicy   aggregation.NaNPolicy
+}
+
+// storageQueryConfig represents the config of query in storage side, built from query config
+type storageQueryConfig struct {
+	maxSeries    int                     // max num. of grouped series for one storage query, 0 means no limit
+	spillOption  aggregation.SpillOption // spill option of group by aggregator
+	indexBreaker *indexBreaker           // breaker of series search, shared by all queries, nil means disable
+}
+
+// NewParserConfig creates the parser config from query config,
+// the invalid time range of measurement is ignored, which is validated when server starts.
+func NewParserConfig(cfg config.Query) sql.ParserConfig {
+	measurementTimeRanges, _ := cfg.GetMeasurementTimeRanges()
+	return sql.ParserConfig{
+		MaxExprDepth:          cfg.MaxExprDepth,
+		DefaultTimeRange:      cfg.DefaultTimeRange.Duration(),
+		MeasurementTimeRanges: measurementTimeRanges,
+	}
+}
+
+// newBrokerQueryConfig creates the config of query in broker side,
+// the invalid nan policy is regarded as skip, which is validated when server starts.
+func newBrokerQueryConfig(cfg config.Query) brokerQueryConfig {
+	nanPolicy, _ := aggregation.ParseNaNPolicy(cfg.NaNPolicy)
+	maxBuckets := cfg.MaxBucketsPerQuery
+	if maxBuckets < 0 {
+		maxBuckets = 0
+	}
+	return brokerQueryConfig{
+		parser:     NewParserConfig(cfg),
+		maxBuckets: maxBuckets,
+		pointsLimit: parallel.PointsLimit{
+			MaxPointsPerSeries: cfg.MaxPointsPerSeries,
+			Truncate:           cfg.TruncatePoints,
+		},
+		nanPolicy: nanPolicy,
+	}
+}
+
+// newStorageQueryConfig creates the config of query in storage side
+func newStorageQueryConfig(cfg config.Query) storageQueryConfig {
+	maxSeries := cfg.MaxSeriesPerQuery
+	if maxSeries < 0 {
+		maxSeries = 0
+	}
+	maxGroups := cfg.MaxGroupsInMemory
+	if maxGroups < 0 {
+		maxGroups = 0
+	}
+	return storageQueryConfig{
+		maxSeries:   maxSeries,
+		spillOption: aggregation.SpillOption{MaxGroups: maxGroups, Dir: cfg.SpillDir},
+		indexBreaker: newIndexBreaker(cfg.IndexBreakerThreshold,
+			cfg.IndexBreakerWindow.Duration(), cfg.IndexBreakerCooldown.Duration()),
+	}
+}
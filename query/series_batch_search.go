@@ -0,0 +1,74 @@
+package query
+
+import (
+	"sync"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/pkg/concurrent"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+//go:generate mockgen -source ./series_batch_search.go -destination=./series_batch_search_mock.go -package=query
+
+// SeriesBatchSearch represents a series search for multi metrics(measurements)
+type SeriesBatchSearch interface {
+	// BatchSearch searches series ids of each metric base on query condition concurrently,
+	// returns the first error if any search fail, else returns metric id => series ids
+	BatchSearch(metricIDs []uint32, query *stmt.Query) (map[uint32]*roaring.Bitmap, error)
+}
+
+// seriesBatchSearch implements SeriesBatchSearch interface,
+// runs the series search of each metric in the shared worker pool.
+type seriesBatchSearch struct {
+	filter        series.Filter
+	filterResults map[uint32]map[string]*tagFilterResult // metric id => tag filter result of condition
+	pool          concurrent.Pool
+}
+
+// newSeriesBatchSearch creates a series batch search using the tag filter result of each metric
+func newSeriesBatchSearch(
+	filter series.Filter,
+	filterResults map[uint32]map[string]*tagFilterResult,
+	pool concurrent.Pool,
+) SeriesBatchSearch {
+	return &seriesBatchSearch{
+		filter:        filter,
+		filterResults: filterResults,
+		pool:          pool,
+	}
+}
+
+// BatchSearch searches series ids of each metric base on query condition concurrently,
+// returns the first error if any search fail, else returns metric id => series ids
+func (s *seriesBatchSearch) BatchSearch(metricIDs []uint32, query *stmt.Query) (map[uint32]*roaring.Bitmap, error) {
+	var (
+		wait     sync.WaitGroup
+		mutex    sync.Mutex
+		firstErr error
+	)
+	result := make(map[uint32]*roaring.Bitmap, len(metricIDs))
+	wait.Add(len(metricIDs))
+	for idx := range metricIDs {
+		metricID := metricIDs[idx]
+		s.pool.Submit(func() {
+			defer wait.Done()
+			seriesIDs, err := newSeriesSearchFunc(s.filter, s.filterResults[metricID], query.Condition).Search()
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			result[metricID] = seriesIDs
+		})
+	}
+	wait.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
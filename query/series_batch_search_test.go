@@ -0,0 +1,59 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/concurrent"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSeriesBatchSearch_BatchSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pool := concurrent.NewPool("batch-search-test", 2, time.Second)
+	defer func() {
+		pool.Stop()
+		ctrl.Finish()
+	}()
+
+	mockFilter := series.NewMockFilter(ctrl)
+	expr := (&stmt.EqualsExpr{Key: "ip", Value: "1.1.1.1"}).Rewrite()
+	// tag key/value ids are different for each metric
+	filterResults := map[uint32]map[string]*tagFilterResult{
+		10: {expr: {tagKey: 1, tagValueIDs: roaring.BitmapOf(1)}},
+		20: {expr: {tagKey: 5, tagValueIDs: roaring.BitmapOf(8)}},
+	}
+	q, _ := sql.Parse("select f from cpu where ip='1.1.1.1'")
+	query := q.(*stmt.Query)
+
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(1)).Return(roaring.BitmapOf(1, 2), nil)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(5), roaring.BitmapOf(8)).Return(roaring.BitmapOf(3), nil)
+	search := newSeriesBatchSearch(mockFilter, filterResults, pool)
+	result, err := search.BatchSearch([]uint32{10, 20}, query)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assertSeriesIDs(t, roaring.BitmapOf(1, 2), result[10])
+	assertSeriesIDs(t, roaring.BitmapOf(3), result[20])
+
+	// search fail
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(1)).Return(roaring.BitmapOf(1, 2), nil)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(5), roaring.BitmapOf(8)).Return(nil, fmt.Errorf("err"))
+	result, err = search.BatchSearch([]uint32{10, 20}, query)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	// tag filter result not found for metric
+	result, err = search.BatchSearch([]uint32{30}, query)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	// empty metric ids
+	result, err = search.BatchSearch(nil, query)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
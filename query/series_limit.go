@@ -0,0 +1,8 @@
+package query
+
+import (
+	"errors"
+)
+
+// ErrTooManySeries represents the num. of grouped series exceeds the max series limit of query
+var ErrTooManySeries = errors.New("too many series for query, exceeds max series limit, narrow the group by or condition")
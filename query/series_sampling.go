@@ -0,0 +1,37 @@
+package query
+
+import (
+	"math"
+
+	"github.com/lindb/roaring"
+)
+
+// sampleSeriesIDs selects the fraction(sample rate) of series ids for sample_series,
+// the series id is selected if its hash is less than the threshold of sample rate,
+// so that the repeated queries select the same series, returns the series ids if sample rate not in (0, 1).
+func sampleSeriesIDs(seriesIDs *roaring.Bitmap, sampleRate float64) *roaring.Bitmap {
+	if sampleRate <= 0 || sampleRate >= 1 {
+		return seriesIDs
+	}
+	threshold := uint64(sampleRate * math.MaxUint32)
+	result := roaring.New()
+	it := seriesIDs.Iterator()
+	for it.HasNext() {
+		seriesID := it.Next()
+		if uint64(hashSeriesID(seriesID)) < threshold {
+			result.Add(seriesID)
+		}
+	}
+	return result
+}
+
+// hashSeriesID mixes the bits of series id(murmur3 finalizer), so that the sequential series ids are spread evenly
+func hashSeriesID(seriesID uint32) uint32 {
+	h := seriesID
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
@@ -0,0 +1,34 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleSeriesIDs(t *testing.T) {
+	seriesIDs := roaring.New()
+	seriesIDs.AddRange(0, 100000)
+	// no sampling
+	assert.Equal(t, seriesIDs, sampleSeriesIDs(seriesIDs, 0))
+	assert.Equal(t, seriesIDs, sampleSeriesIDs(seriesIDs, 1))
+	assert.Equal(t, seriesIDs, sampleSeriesIDs(seriesIDs, -0.1))
+
+	sample := sampleSeriesIDs(seriesIDs, 0.01)
+	// deterministic
+	assert.Equal(t, sample, sampleSeriesIDs(seriesIDs, 0.01))
+	assert.Equal(t, sample, sampleSeriesIDs(seriesIDs.Clone(), 0.01))
+	// approximately 1% of series
+	assert.InDelta(t, 1000, sample.GetCardinality(), 150)
+	// sample of subset is the subset of sample
+	subset := roaring.New()
+	subset.AddRange(0, 50000)
+	assert.True(t, roaring.And(sample, subset).Equals(sampleSeriesIDs(subset, 0.01)))
+	// larger rate selects more series, includes the series selected by smaller rate
+	sample2 := sampleSeriesIDs(seriesIDs, 0.1)
+	assert.InDelta(t, 10000, sample2.GetCardinality(), 500)
+	assert.Equal(t, sample.GetCardinality(), roaring.And(sample, sample2).GetCardinality())
+	// empty
+	assert.True(t, sampleSeriesIDs(roaring.New(), 0.5).IsEmpty())
+}
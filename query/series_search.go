@@ -1,9 +1,13 @@
 package query
 
 import (
+	"fmt"
+
 	"github.com/lindb/roaring"
 
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -12,7 +16,8 @@ import (
 
 // SeriesSearch represents a series search by condition expression
 type SeriesSearch interface {
-	// Search searches series ids base on condition, if search fail return nil, else return series ids
+	// Search searches series ids base on condition, if search fail return nil, else return series ids,
+	// returns non-nil empty series ids if no series matched, so that caller can distinguish it from failure.
 	Search() (*roaring.Bitmap, error)
 }
 
@@ -25,6 +30,10 @@ type seriesSearch struct {
 
 	filter series.Filter
 
+	// analyze tracks the actual matched series/cost of each tag filter for explain analyze
+	analyze        bool
+	predicateStats map[string]*models.PredicateStats
+
 	err error
 }
 
@@ -37,6 +46,21 @@ func newSeriesSearch(filter series.Filter, filterResult map[string]*tagFilterRes
 	}
 }
 
+// newAnalyzedSeriesSearch creates a series search which tracks the actual matched series/cost of each tag filter
+func newAnalyzedSeriesSearch(filter series.Filter, filterResult map[string]*tagFilterResult,
+	condition stmt.Expr,
+) *seriesSearch {
+	s := newSeriesSearch(filter, filterResult, condition).(*seriesSearch)
+	s.analyze = true
+	s.predicateStats = make(map[string]*models.PredicateStats)
+	return s
+}
+
+// PredicateStats returns the actual matched series/cost of each tag filter, keyed by rewritten tag filter
+func (s *seriesSearch) PredicateStats() map[string]*models.PredicateStats {
+	return s.predicateStats
+}
+
 // Search searches series ids base on condition, if search fail return nil, else return series ids
 func (s *seriesSearch) Search() (*roaring.Bitmap, error) {
 	_, seriesIDs := s.findSeriesIDsByExpr(s.condition)
@@ -56,11 +80,18 @@ func (s *seriesSearch) findSeriesIDsByExpr(condition stmt.Expr) (uint32, *roarin
 	}
 	switch expr := condition.(type) {
 	case stmt.TagFilter:
+		start := timeutil.NowNano()
 		tagKey, seriesIDs, err := s.getSeriesIDsByExpr(expr)
 		if err != nil {
-			s.err = err
+			s.err = fmt.Errorf("find series ids by %s: %w", expr.Rewrite(), err)
 			return tagKey, roaring.New() // create a empty series ids for parent expr
 		}
+		if s.analyze {
+			s.predicateStats[expr.Rewrite()] = &models.PredicateStats{
+				Matched: seriesIDs.GetCardinality(),
+				Cost:    timeutil.NowNano() - start,
+			}
+		}
 		return tagKey, seriesIDs
 	case *stmt.ParenExpr:
 		return s.findSeriesIDsByExpr(expr.Expr)
@@ -68,14 +99,16 @@ func (s *seriesSearch) findSeriesIDsByExpr(condition stmt.Expr) (uint32, *roarin
 		// get filter series ids
 		tagKey, matchResult := s.findSeriesIDsByExpr(expr.Expr)
 		// get all series ids for tag key
-		all, err := s.filter.GetSeriesIDsForTag(tagKey)
+		all, err := s.getAllSeriesIDs(tagKey, expr.Expr)
 		if err != nil {
 			s.err = err
 			return tagKey, roaring.New() // create a empty series ids for parent expr
 		}
-		// do and not got series ids not in 'a' list
-		all.AndNot(matchResult)
-		return 0, all
+		// do and not got series ids not in 'a' list,
+		// clone the series ids of tag key, because it maybe shared by other negation on the same tag key
+		result := all.Clone()
+		result.AndNot(matchResult)
+		return 0, result
 	case *stmt.BinaryExpr:
 		_, left := s.findSeriesIDsByExpr(expr.Left)
 		_, right := s.findSeriesIDsByExpr(expr.Right)
@@ -89,15 +122,104 @@ func (s *seriesSearch) findSeriesIDsByExpr(condition stmt.Expr) (uint32, *roarin
 	return 0, roaring.New() // create a empty series ids for parent expr
 }
 
+// getAllSeriesIDs returns the series ids which the negated expr is based on,
+// tag = null(not tag != null) is based on all series of metric, others are based on all series of tag key.
+func (s *seriesSearch) getAllSeriesIDs(tagKey uint32, negated stmt.Expr) (*roaring.Bitmap, error) {
+	if exists, ok := unwrapParen(negated).(*stmt.ExistsExpr); ok {
+		if result, ok := s.filterResult[exists.Rewrite()]; ok {
+			all, err := s.filter.GetSeriesIDsForMetric(result.namespace, result.metricName)
+			if err != nil {
+				return nil, fmt.Errorf("get series ids of metric(%s): %w", result.metricName, err)
+			}
+			return all, nil
+		}
+	}
+	all, err := s.filter.GetSeriesIDsForTag(tagKey)
+	if err != nil {
+		return nil, fmt.Errorf("get series ids of tag key(%d): %w", tagKey, err)
+	}
+	return all, nil
+}
+
+// unwrapParen returns the expr in parens
+func unwrapParen(expr stmt.Expr) stmt.Expr {
+	for {
+		paren, ok := expr.(*stmt.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.Expr
+	}
+}
+
 // getTagKeyID returns the tag key id by tag key
 func (s *seriesSearch) getSeriesIDsByExpr(expr stmt.Expr) (uint32, *roaring.Bitmap, error) {
 	tagValues, ok := s.filterResult[expr.Rewrite()]
 	if !ok {
 		return 0, nil, constants.ErrNotFound
 	}
+	if _, ok := expr.(*stmt.ExistsExpr); ok {
+		if tagValues.tagNotFound {
+			// no series has the tag
+			return tagValues.tagKey, roaring.New(), nil
+		}
+		// tag existence matches all series of tag key,
+		// clone the series ids of tag key, because parent expr modifies it
+		seriesIDs, err := s.filter.GetSeriesIDsForTag(tagValues.tagKey)
+		if err != nil {
+			return 0, nil, err
+		}
+		if seriesIDs == nil {
+			return tagValues.tagKey, roaring.New(), nil
+		}
+		return tagValues.tagKey, seriesIDs.Clone(), nil
+	}
+	if tagValues.tagValueIDs.IsEmpty() {
+		// empty in expr matches nothing
+		return tagValues.tagKey, roaring.New(), nil
+	}
+	if _, ok := expr.(*stmt.AllOfExpr); ok {
+		seriesIDs, err := s.getSeriesIDsOfAllValues(tagValues)
+		if err != nil {
+			return 0, nil, err
+		}
+		return tagValues.tagKey, seriesIDs, nil
+	}
 	seriesIDs, err := s.filter.GetSeriesIDsByTagValueIDs(tagValues.tagKey, tagValues.tagValueIDs)
 	if err != nil {
 		return 0, nil, err
 	}
+	if seriesIDs == nil {
+		// filter may return nil if tag values not found, use empty series ids for parent expr
+		seriesIDs = roaring.New()
+	}
 	return tagValues.tagKey, seriesIDs, nil
 }
+
+// getSeriesIDsOfAllValues returns the series ids which have all the tag values,
+// intersects the series ids of each tag value, stops if no series matched.
+func (s *seriesSearch) getSeriesIDsOfAllValues(tagValues *tagFilterResult) (*roaring.Bitmap, error) {
+	var result *roaring.Bitmap
+	it := tagValues.tagValueIDs.Iterator()
+	for it.HasNext() {
+		seriesIDs, err := s.filter.GetSeriesIDsByTagValueIDs(tagValues.tagKey, roaring.BitmapOf(it.Next()))
+		if err != nil {
+			return nil, err
+		}
+		if seriesIDs == nil {
+			return roaring.New(), nil
+		}
+		if result == nil {
+			result = seriesIDs.Clone()
+		} else {
+			result.And(seriesIDs)
+		}
+		if result.IsEmpty() {
+			break
+		}
+	}
+	if result == nil {
+		result = roaring.New()
+	}
+	return result, nil
+}
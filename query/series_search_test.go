@@ -1,6 +1,8 @@
 package query
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
@@ -42,7 +45,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
 	resultSet, err = search.Search()
 	assert.NoError(t, err)
-	assert.Equal(t, roaring.BitmapOf(40, 50), resultSet)
+	assertSeriesIDs(t, roaring.BitmapOf(40, 50), resultSet)
 	// case 4: binary expr and
 	q, _ = sql.Parse("select f from cpu " +
 		"where ip='1.1.1.1' and path='/data' and time>'20190410 00:00:00' and time<'20190410 10:00:00'")
@@ -52,7 +55,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
 	resultSet, err = search.Search()
 	assert.NoError(t, err)
-	assert.Equal(t, roaring.BitmapOf(20), resultSet)
+	assertSeriesIDs(t, roaring.BitmapOf(20), resultSet)
 	// case 5: binary expr or
 	q, _ = sql.Parse("select f from cpu " +
 		"where ip='1.1.1.1' or path='/data' and time>'20190410 00:00:00' and time<'20190410 10:00:00'")
@@ -62,7 +65,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
 	resultSet, err = search.Search()
 	assert.NoError(t, err)
-	assert.Equal(t, roaring.BitmapOf(10, 20, 30, 200), resultSet)
+	assertSeriesIDs(t, roaring.BitmapOf(10, 20, 30, 200), resultSet)
 	// case 6: paren expr
 	q, _ = sql.Parse("select f from cpu where (ip='1.1.1.1')")
 	query = q.(*stmt.Query)
@@ -84,7 +87,7 @@ func TestSeriesSearch_Search_err(t *testing.T) {
 	query := q.(*stmt.Query)
 	search := newSeriesSearch(mockFilter, make(map[string]*tagFilterResult), query.Condition)
 	resultSet, err := search.Search()
-	assert.Error(t, err)
+	assert.True(t, errors.Is(err, constants.ErrNotFound))
 	assert.Nil(t, resultSet)
 	// case 2: get series id err
 	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
@@ -96,10 +99,10 @@ func TestSeriesSearch_Search_err(t *testing.T) {
 	q, _ = sql.Parse("select f from cpu where ip!='1.1.1.1'")
 	query = q.(*stmt.Query)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(seriesIDs, nil)
-	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(nil, fmt.Errorf("err"))
+	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(nil, context.Canceled)
 	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
 	resultSet, err = search.Search()
-	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
 	assert.Nil(t, resultSet)
 	// case 4: recursion err
 	q, _ = sql.Parse("select f from cpu where ip='1.1.1.1' or ip='1.1.1.1'")
@@ -125,6 +128,150 @@ func TestSeriesSearch_Search_expr_not_match(t *testing.T) {
 	assert.Equal(t, uint64(0), resultSet.GetCardinality())
 }
 
+func TestSeriesSearch_Search_empty_vs_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+
+	q, _ := sql.Parse("select f from cpu where ip='1.1.1.1' and path='/data'")
+	query := q.(*stmt.Query)
+	// case 1: nothing matched, returns empty series ids
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(nil, nil)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), gomock.Any()).Return(roaring.BitmapOf(20), nil)
+	search := newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	resultSet, err := search.Search()
+	assert.NoError(t, err)
+	assert.NotNil(t, resultSet)
+	assert.True(t, resultSet.IsEmpty())
+	// case 2: search fail, returns nil
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(roaring.BitmapOf(20), nil)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	resultSet, err = search.Search()
+	assert.Error(t, err)
+	assert.Nil(t, resultSet)
+}
+
+func TestSeriesSearch_Search_allOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+
+	allOf := &stmt.AllOfExpr{Key: "labels", Values: []string{"gpu", "nvme"}}
+	filterResult := map[string]*tagFilterResult{
+		allOf.Rewrite(): {tagKey: 3, tagValueIDs: roaring.BitmapOf(1, 2)},
+	}
+	// gpu => 10,20,30, nvme => 20,30,40, only series having both values match
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(3), roaring.BitmapOf(1)).Return(roaring.BitmapOf(10, 20, 30), nil)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(3), roaring.BitmapOf(2)).Return(roaring.BitmapOf(20, 30, 40), nil)
+	resultSet, err := newSeriesSearch(mockFilter, filterResult, allOf).Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(20, 30), resultSet)
+	// stops if no series matched
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(3), roaring.BitmapOf(1)).Return(roaring.New(), nil)
+	resultSet, err = newSeriesSearch(mockFilter, filterResult, allOf).Search()
+	assert.NoError(t, err)
+	assert.True(t, resultSet.IsEmpty())
+	// not all of
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(3), roaring.BitmapOf(1)).Return(roaring.BitmapOf(10, 20, 30), nil)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(3), roaring.BitmapOf(2)).Return(roaring.BitmapOf(20, 30, 40), nil)
+	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(3)).Return(roaring.BitmapOf(10, 20, 30, 40, 50), nil)
+	resultSet, err = newSeriesSearch(mockFilter, filterResult, &stmt.NotExpr{Expr: allOf}).Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(10, 40, 50), resultSet)
+	// get series ids err
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(3), roaring.BitmapOf(1)).Return(nil, fmt.Errorf("err"))
+	resultSet, err = newSeriesSearch(mockFilter, filterResult, allOf).Search()
+	assert.Error(t, err)
+	assert.Nil(t, resultSet)
+}
+
+func TestSeriesSearch_Search_emptyIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+
+	in := &stmt.InExpr{Key: "host"}
+	filterResult := map[string]*tagFilterResult{
+		in.Rewrite(): {tagKey: 3, tagValueIDs: roaring.New()},
+	}
+	// in () matches nothing
+	resultSet, err := newSeriesSearch(mockFilter, filterResult, in).Search()
+	assert.NoError(t, err)
+	assert.NotNil(t, resultSet)
+	assert.True(t, resultSet.IsEmpty())
+	// not in () matches all series of tag key
+	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(3)).Return(roaring.BitmapOf(10, 20, 30), nil)
+	resultSet, err = newSeriesSearch(mockFilter, filterResult, &stmt.NotExpr{Expr: in}).Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(10, 20, 30), resultSet)
+}
+
+func TestSeriesSearch_Search_null(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+
+	filterResult := map[string]*tagFilterResult{
+		(&stmt.ExistsExpr{Key: "host"}).Rewrite(): {tagKey: 3, pushDown: true, namespace: "ns", metricName: "cpu"},
+	}
+	// series 10 has tag host, series 20 doesn't
+	tagSeriesIDs := roaring.BitmapOf(10)
+	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(3)).Return(tagSeriesIDs, nil).AnyTimes()
+	mockFilter.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.BitmapOf(10, 20), nil).AnyTimes()
+	// host != null, has the tag
+	q, err := sql.Parse("select f from cpu where host != null")
+	assert.NoError(t, err)
+	resultSet, err := newSeriesSearch(mockFilter, filterResult, q.(*stmt.Query).Condition).Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(10), resultSet)
+	// host = null, lacks the tag
+	q, err = sql.Parse("select f from cpu where host = null")
+	assert.NoError(t, err)
+	resultSet, err = newSeriesSearch(mockFilter, filterResult, q.(*stmt.Query).Condition).Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(20), resultSet)
+	// series ids of tag key not changed
+	assertSeriesIDs(t, roaring.BitmapOf(10), tagSeriesIDs)
+
+	// get series ids of tag key fail
+	mockFilter2 := series.NewMockFilter(ctrl)
+	mockFilter2.EXPECT().GetSeriesIDsForTag(uint32(3)).Return(nil, fmt.Errorf("err"))
+	resultSet, err = newSeriesSearch(mockFilter2, filterResult, &stmt.ExistsExpr{Key: "host"}).Search()
+	assert.Error(t, err)
+	assert.Nil(t, resultSet)
+	// get series ids of metric fail
+	mockFilter2.EXPECT().GetSeriesIDsForTag(uint32(3)).Return(nil, nil)
+	mockFilter2.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(nil, fmt.Errorf("err"))
+	resultSet, err = newSeriesSearch(mockFilter2, filterResult,
+		&stmt.NotExpr{Expr: &stmt.ParenExpr{Expr: &stmt.ExistsExpr{Key: "host"}}}).Search()
+	assert.Error(t, err)
+	assert.Nil(t, resultSet)
+}
+
+func TestSeriesSearch_Search_null_tagNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+
+	filterResult := map[string]*tagFilterResult{
+		(&stmt.ExistsExpr{Key: "host"}).Rewrite(): {pushDown: true, namespace: "ns", metricName: "cpu", tagNotFound: true},
+	}
+	mockFilter.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.BitmapOf(10, 20), nil).AnyTimes()
+	// host != null, no series has the tag
+	q, err := sql.Parse("select f from cpu where host != null")
+	assert.NoError(t, err)
+	resultSet, err := newSeriesSearch(mockFilter, filterResult, q.(*stmt.Query).Condition).Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.New(), resultSet)
+	// host = null, all series of metric lack the tag
+	q, err = sql.Parse("select f from cpu where host = null")
+	assert.NoError(t, err)
+	resultSet, err = newSeriesSearch(mockFilter, filterResult, q.(*stmt.Query).Condition).Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(10, 20), resultSet)
+}
+
 func TestSeriesSearch_Search_complex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -136,12 +283,221 @@ func TestSeriesSearch_Search_complex(t *testing.T) {
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(5)).Return(roaring.BitmapOf(1, 2), nil)
 	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7), nil)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(3), roaring.BitmapOf(4)).Return(roaring.BitmapOf(3, 5, 6, 7), nil)
-	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), roaring.BitmapOf(2)).Return(roaring.BitmapOf(7), nil)
-	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), roaring.BitmapOf(3)).Return(roaring.BitmapOf(5), nil)
+	// path='/data' or path='/home' => path in ('/data','/home')
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), roaring.BitmapOf(2, 3)).Return(roaring.BitmapOf(5, 7), nil)
 	search := newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
 	resultSet, err := search.Search()
 	assert.NoError(t, err)
-	assert.Equal(t, roaring.BitmapOf(5, 7), resultSet)
+	assertSeriesIDs(t, roaring.BitmapOf(5, 7), resultSet)
+}
+
+func TestSeriesSearch_Search_multi_not_same_tag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+	// all series ids of tag key ip, same bitmap returned for each negation
+	all := roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7)
+	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(all, nil).AnyTimes()
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(5)).
+		DoAndReturn(func(_ uint32, _ *roaring.Bitmap) (*roaring.Bitmap, error) {
+			return roaring.BitmapOf(1, 2), nil
+		}).AnyTimes()
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(6)).
+		DoAndReturn(func(_ uint32, _ *roaring.Bitmap) (*roaring.Bitmap, error) {
+			return roaring.BitmapOf(3), nil
+		}).AnyTimes()
+	filterResult := mockFilterResult()
+	filterResult[(&stmt.EqualsExpr{Key: "ip", Value: "3.3.3.3"}).Rewrite()] = &tagFilterResult{
+		tagKey:      1,
+		tagValueIDs: roaring.BitmapOf(6),
+	}
+
+	q, err := sql.Parse("select f from cpu where ip not in ('1.1.1.1','2.2.2.2') and ip!='3.3.3.3'")
+	assert.NoError(t, err)
+	search := newSeriesSearch(mockFilter, filterResult, q.(*stmt.Query).Condition)
+	resultSet, err := search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(4, 5, 6, 7), resultSet)
+
+	q, err = sql.Parse("select f from cpu where ip not in ('1.1.1.1','2.2.2.2') or ip!='3.3.3.3'")
+	assert.NoError(t, err)
+	search = newSeriesSearch(mockFilter, filterResult, q.(*stmt.Query).Condition)
+	resultSet, err = search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7), resultSet)
+	// series ids of tag key not changed
+	assertSeriesIDs(t, roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7), all)
+}
+
+func TestSeriesSearch_Search_simplified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).
+		DoAndReturn(func(_ uint32, _ *roaring.Bitmap) (*roaring.Bitmap, error) {
+			return roaring.BitmapOf(1, 2, 3), nil
+		}).AnyTimes()
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), gomock.Any()).
+		DoAndReturn(func(_ uint32, _ *roaring.Bitmap) (*roaring.Bitmap, error) {
+			return roaring.BitmapOf(2, 3, 4), nil
+		}).AnyTimes()
+
+	ip := &stmt.EqualsExpr{Key: "ip", Value: "1.1.1.1"}
+	path := &stmt.EqualsExpr{Key: "path", Value: "/data"}
+	// (ip='1.1.1.1' or ip='1.1.1.1') and path='/data' and path='/data'
+	condition := &stmt.BinaryExpr{
+		Left: &stmt.BinaryExpr{
+			Left:     &stmt.ParenExpr{Expr: &stmt.BinaryExpr{Left: ip, Operator: stmt.OR, Right: ip}},
+			Operator: stmt.AND,
+			Right:    path,
+		},
+		Operator: stmt.AND,
+		Right:    path,
+	}
+	search := newSeriesSearch(mockFilter, mockFilterResult(), condition)
+	expect, err := search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(2, 3), expect)
+
+	q, _ := sql.Parse("select f from cpu where 1=1 and (ip='1.1.1.1' or ip='1.1.1.1') and path='/data' and path='/data'")
+	query := q.(*stmt.Query)
+	assert.Equal(t, "(ip=1.1.1.1)andpath=/data", query.Condition.Rewrite())
+	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	resultSet, err := search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, expect, resultSet)
+
+	// always false
+	q, _ = sql.Parse("select f from cpu where 1=0 and ip='1.1.1.1'")
+	query = q.(*stmt.Query)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	resultSet, err = search.Search()
+	assert.NoError(t, err)
+	assert.True(t, resultSet.IsEmpty())
+}
+
+func TestSeriesSearch_Search_collapsed_in(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+	// tag value id => series ids(id*10, id*10+1)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).
+		DoAndReturn(func(_ uint32, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
+			seriesIDs := roaring.New()
+			it := tagValueIDs.Iterator()
+			for it.HasNext() {
+				id := it.Next()
+				seriesIDs.AddMany([]uint32{id * 10, id*10 + 1})
+			}
+			return seriesIDs, nil
+		}).AnyTimes()
+	filterResult := make(map[string]*tagFilterResult)
+	values := []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}
+	for idx, value := range values {
+		filterResult[(&stmt.EqualsExpr{Key: "ip", Value: value}).Rewrite()] = &tagFilterResult{
+			tagKey:      1,
+			tagValueIDs: roaring.BitmapOf(uint32(idx + 1)),
+		}
+	}
+	filterResult[(&stmt.InExpr{Key: "ip", Values: values}).Rewrite()] = &tagFilterResult{
+		tagKey:      1,
+		tagValueIDs: roaring.BitmapOf(1, 2, 3),
+	}
+
+	// (ip='1.1.1.1' or ip='2.2.2.2' or ip='3.3.3.3') without rewrite
+	condition := &stmt.ParenExpr{Expr: &stmt.BinaryExpr{
+		Left: &stmt.BinaryExpr{
+			Left:     &stmt.EqualsExpr{Key: "ip", Value: "1.1.1.1"},
+			Operator: stmt.OR,
+			Right:    &stmt.EqualsExpr{Key: "ip", Value: "2.2.2.2"},
+		},
+		Operator: stmt.OR,
+		Right:    &stmt.EqualsExpr{Key: "ip", Value: "3.3.3.3"},
+	}}
+	search := newSeriesSearch(mockFilter, filterResult, condition)
+	expect, err := search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(10, 11, 20, 21, 30, 31), expect)
+
+	q, err := sql.Parse("select f from cpu where (ip='1.1.1.1' or ip='2.2.2.2' or ip='3.3.3.3')")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.ParenExpr{Expr: &stmt.InExpr{Key: "ip", Values: values}}, query.Condition)
+	search = newSeriesSearch(mockFilter, filterResult, query.Condition)
+	resultSet, err := search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, expect, resultSet)
+}
+
+func TestSeriesSearch_Search_not_paren(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), gomock.Any()).
+		DoAndReturn(func(_ uint32, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
+			seriesIDs := roaring.New()
+			if tagValueIDs.Contains(2) {
+				// path='/data'
+				seriesIDs.AddMany([]uint32{1, 2})
+			}
+			if tagValueIDs.Contains(3) {
+				// path='/home'
+				seriesIDs.AddMany([]uint32{2, 3})
+			}
+			return seriesIDs, nil
+		}).AnyTimes()
+	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(2)).
+		DoAndReturn(func(_ uint32) (*roaring.Bitmap, error) {
+			return roaring.BitmapOf(1, 2, 3, 4, 5), nil
+		}).AnyTimes()
+
+	q, err := sql.Parse("select f from cpu where not (path='/data' or path='/home')")
+	assert.NoError(t, err)
+	search := newSeriesSearch(mockFilter, mockFilterResult(), q.(*stmt.Query).Condition)
+	notOr, err := search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(4, 5), notOr)
+
+	// not (a or b) = (not a) and (not b)
+	q, err = sql.Parse("select f from cpu where path!='/data' and path!='/home'")
+	assert.NoError(t, err)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), q.(*stmt.Query).Condition)
+	andNot, err := search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, andNot, notOr)
+
+	// not (a and b) = (not a) or (not b),
+	// builds the negated condition directly, because parser resolves the contradictory and expression to false
+	condition := &stmt.ParenExpr{Expr: &stmt.BinaryExpr{
+		Left:     &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: "path", Value: "/data"}},
+		Operator: stmt.OR,
+		Right:    &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: "path", Value: "/home"}},
+	}}
+	search = newSeriesSearch(mockFilter, mockFilterResult(), condition)
+	notAnd, err := search.Search()
+	assert.NoError(t, err)
+	assertSeriesIDs(t, roaring.BitmapOf(1, 3, 4, 5), notAnd)
+}
+
+func TestAssertSeriesIDs(t *testing.T) {
+	// same series ids, but built in different orders and container types
+	expect := roaring.BitmapOf(5, 4, 3, 2, 1, 100)
+	seriesIDs := roaring.New()
+	seriesIDs.AddRange(1, 6)
+	seriesIDs.Add(100)
+	seriesIDs.RunOptimize()
+	assertSeriesIDs(t, expect, seriesIDs)
+	other := roaring.BitmapOf(1, 2, 3, 100, 200)
+	other.AndNot(roaring.BitmapOf(200))
+	other.Or(roaring.BitmapOf(5, 4))
+	assertSeriesIDs(t, expect, other)
+	assert.False(t, expect.Equals(roaring.BitmapOf(1, 2, 3, 4, 5)))
+}
+
+// assertSeriesIDs asserts the series ids by bitmap content, not struct of bitmap,
+// because the internal containers are different after set operations.
+func assertSeriesIDs(t *testing.T, expect, seriesIDs *roaring.Bitmap) {
+	assert.True(t, expect.Equals(seriesIDs), "expect:%s, actual:%s", expect, seriesIDs)
 }
 
 func mockFilterResult() map[string]*tagFilterResult {
@@ -166,6 +522,10 @@ func mockFilterResult() map[string]*tagFilterResult {
 		tagKey:      1,
 		tagValueIDs: roaring.BitmapOf(5),
 	}
+	result[(&stmt.InExpr{Key: "path", Values: []string{"/data", "/home"}}).Rewrite()] = &tagFilterResult{
+		tagKey:      2,
+		tagValueIDs: roaring.BitmapOf(2, 3),
+	}
 	return result
 }
 
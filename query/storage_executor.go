@@ -7,6 +7,7 @@ import (
 	"github.com/lindb/roaring"
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/parallel"
@@ -53,6 +54,18 @@ type loadSeriesResult struct {
 	scanners []flow.Scanner
 }
 
+// containerQueryFlow wraps the storage query flow for loading the data of a container(high key),
+// returns the same aggregator for all data sources, so that the data of series are aggregated together.
+type containerQueryFlow struct {
+	flow.StorageQueryFlow
+	agg aggregation.ContainerAggregator
+}
+
+// GetAggregator returns the aggregator of container
+func (f *containerQueryFlow) GetAggregator(_ uint16) aggregation.ContainerAggregator {
+	return f.agg
+}
+
 // newSeriesResultScanner creates a series load result scanner.
 func newSeriesResultScanner(len int) flow.Scanner {
 	return &loadSeriesResult{
@@ -172,6 +185,7 @@ func (e *storageExecutor) Execute() {
 	e.queryFlow.Prepare(storageExecutePlan.getDownSamplingAggSpecs())
 
 	e.metricID = storageExecutePlan.metricID
+	e.ctx.metricID = e.metricID
 	e.fieldIDs = storageExecutePlan.getFieldIDs()
 	e.storageExecutePlan = storageExecutePlan
 	if e.ctx.query.HasGroupBy() {
@@ -199,7 +213,7 @@ func (e *storageExecutor) executeQuery() {
 			seriesIDs := roaring.New()
 			t := newSeriesIDsSearchTask(e.ctx, shard, seriesIDs)
 			err := t.Run()
-			if err != nil && err != constants.ErrNotFound {
+			if err != nil && !errors.Is(err, constants.ErrNotFound) {
 				// maybe series ids not found in shard, so ignore not found err
 				e.queryFlow.Complete(err)
 			}
@@ -212,7 +226,7 @@ func (e *storageExecutor) executeQuery() {
 			// 2. filter data in memory database
 			t = newMemoryDataFilterTask(e.ctx, shard, e.metricID, e.fieldIDs, seriesIDs, rs)
 			err = t.Run()
-			if err != nil && err != constants.ErrNotFound {
+			if err != nil && !errors.Is(err, constants.ErrNotFound) {
 				// maybe data not exist in memory database, so ignore not found err
 				e.queryFlow.Complete(err)
 				return
@@ -220,7 +234,7 @@ func (e *storageExecutor) executeQuery() {
 			// 3. filter data each data family in shard
 			t = newFileDataFilterTask(e.ctx, shard, e.metricID, e.fieldIDs, seriesIDs, rs)
 			err = t.Run()
-			if err != nil && err != constants.ErrNotFound {
+			if err != nil && !errors.Is(err, constants.ErrNotFound) {
 				// maybe data not exist in shard, so ignore not found err
 				e.queryFlow.Complete(err)
 				return
@@ -251,6 +265,7 @@ func (e *storageExecutor) executeQuery() {
 // executeGroupBy executes the query flow, step as below:
 // 1. grouping
 // 2. loading
+// 3. scanning and aggregating of each group
 func (e *storageExecutor) executeGroupBy(shard tsdb.Shard, rs []flow.FilterResultSet, seriesIDs *roaring.Bitmap) {
 	groupingResult := &groupingResult{}
 	var groupingCtx series.GroupingContext
@@ -263,7 +278,7 @@ func (e *storageExecutor) executeGroupBy(shard tsdb.Shard, rs []flow.FilterResul
 		}
 		t := newGroupingContextFindTask(e.ctx, shard, tagKeys, seriesIDs, groupingResult)
 		err := t.Run()
-		if err != nil && err != constants.ErrNotFound {
+		if err != nil && !errors.Is(err, constants.ErrNotFound) {
 			// maybe group by not found, so ignore not found
 			e.queryFlow.Complete(err)
 			return
@@ -284,30 +299,6 @@ func (e *storageExecutor) executeGroupBy(shard tsdb.Shard, rs []flow.FilterResul
 		containerOfSeries := seriesIDs.GetContainerAtIndex(idx)
 
 		e.queryFlow.Scanner(func() {
-			loadSeriesRS := newSeriesResultScanner(len(rs))
-			defer func() {
-				_ = loadSeriesRS.Close()
-			}()
-
-			for idx := range rs {
-				// 3.load data by grouped seriesIDs
-				t := newDataLoadTaskFunc(e.ctx, shard, e.queryFlow, rs[idx], e.fieldIDs,
-					highKey, containerOfSeries,
-					idx, loadSeriesRS.(*loadSeriesResult))
-				if err := t.Run(); err != nil {
-					e.queryFlow.Complete(err)
-					return
-				}
-			}
-			// scan metric data from storage(memory/file)
-			it := containerOfSeries.PeekableIterator()
-			for it.HasNext() {
-				loadSeriesRS.Scan(it.Next())
-			}
-		})
-
-		// grouping based on group by tag keys for each container
-		e.queryFlow.Grouping(func() {
 			defer func() {
 				groupWait.Dec()
 				if groupingCtx != nil && groupWait.Load() == 0 {
@@ -318,13 +309,42 @@ func (e *storageExecutor) executeGroupBy(shard tsdb.Shard, rs []flow.FilterResul
 				// try start collect tag values for group by query
 				e.collectGroupByTagValues()
 			}()
+			// 2. grouping based on group by tag keys for each container
 			groupedResult := &groupedSeriesResult{}
 			t := newBuildGroupTaskFunc(e.ctx, shard, groupingCtx, highKey, containerOfSeries, groupedResult)
 			if err := t.Run(); err != nil {
 				e.queryFlow.Complete(err)
 				return
 			}
+			if len(groupedResult.groupedSeries) == 0 {
+				return
+			}
 
+			// 3. load data by seriesIDs of container, all data sources load into same aggregator
+			agg := e.queryFlow.GetAggregator(highKey)
+			loadFlow := &containerQueryFlow{StorageQueryFlow: e.queryFlow, agg: agg}
+			loadSeriesRS := newSeriesResultScanner(len(rs))
+			defer func() {
+				_ = loadSeriesRS.Close()
+			}()
+			for idx := range rs {
+				t := newDataLoadTaskFunc(e.ctx, shard, loadFlow, rs[idx], e.fieldIDs,
+					highKey, containerOfSeries,
+					idx, loadSeriesRS.(*loadSeriesResult))
+				if err := t.Run(); err != nil {
+					e.queryFlow.Complete(err)
+					return
+				}
+			}
+			// 4. scan metric data from storage(memory/file) for each group,
+			// down sampling the data of each series, then aggregates them into the group
+			for tags, lowSeriesIDs := range groupedResult.groupedSeries {
+				for _, lowSeriesID := range lowSeriesIDs {
+					loadSeriesRS.Scan(lowSeriesID)
+					agg.Aggregate()
+				}
+				e.queryFlow.Reduce(tags, agg)
+			}
 		})
 	}
 }
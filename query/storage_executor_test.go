@@ -1,18 +1,26 @@
 package query
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/concurrent"
 	"github.com/lindb/lindb/pkg/timeutil"
+	commonmock "github.com/lindb/lindb/rpc/pbmock/common"
+	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
@@ -46,7 +54,7 @@ func (m *mockQueryFlow) Scanner(task concurrent.Task) {
 }
 
 func (m *mockQueryFlow) GetAggregator(_ uint16) (agg aggregation.ContainerAggregator) {
-	return nil
+	return aggregation.NewContainerAggregator(timeutil.Interval(timeutil.OneSecond), 1, timeutil.TimeRange{}, nil)
 }
 
 func (m *mockQueryFlow) Reduce(_ string, _ aggregation.ContainerAggregator) {
@@ -72,33 +80,33 @@ func TestStorageExecute_validation(t *testing.T) {
 	query := &stmt.Query{Interval: timeutil.Interval(timeutil.OneSecond)}
 
 	// case 1: query shards is empty
-	exec := newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext(nil, query))
+	exec := newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext(nil, query, storageQueryConfig{}))
 	queryFlow.EXPECT().Complete(errNoShardID)
 	exec.Execute()
 
 	// case 2: shards of engine is empty
 	mockDatabase.EXPECT().NumOfShards().Return(0)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	queryFlow.EXPECT().Complete(errNoShardInDatabase)
 	exec.Execute()
 
 	// case 3: num. of shard not match
 	mockDatabase.EXPECT().NumOfShards().Return(2)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	queryFlow.EXPECT().Complete(errShardNotMatch)
 	exec.Execute()
 
 	// case 4: shard not found
 	mockDatabase.EXPECT().NumOfShards().Return(3).AnyTimes()
 	mockDatabase.EXPECT().GetShard(gomock.Any()).Return(nil, false).MaxTimes(3)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	queryFlow.EXPECT().Complete(errShardNotFound)
 	exec.Execute()
 	// case 4: shard not match
 	mockDatabase.EXPECT().NumOfShards().Return(3).AnyTimes()
 	mockDatabase.EXPECT().GetShard(gomock.Any()).Return(nil, false)
 	mockDatabase.EXPECT().GetShard(gomock.Any()).Return(nil, true).MaxTimes(2)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	queryFlow.EXPECT().Complete(errShardNumNotMatch)
 	exec.Execute()
 
@@ -106,7 +114,7 @@ func TestStorageExecute_validation(t *testing.T) {
 	q, _ := sql.Parse("select f from cpu")
 	query = q.(*stmt.Query)
 	mockDB1 := newMockDatabase(ctrl)
-	exec = newStorageExecutor(queryFlow, mockDB1, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDB1, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	gomock.InOrder(
 		queryFlow.EXPECT().Prepare(gomock.Any()),
 		queryFlow.EXPECT().Filtering(gomock.Any()).MaxTimes(3*2), //memory db and shard
@@ -133,7 +141,7 @@ func TestStorageExecute_Plan_Fail(t *testing.T) {
 	// find metric name err
 	q, _ := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'")
 	query := q.(*stmt.Query)
-	exec := newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec := newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	queryFlow.EXPECT().Complete(fmt.Errorf("err"))
 	exec.Execute()
 }
@@ -154,12 +162,12 @@ func TestStorageExecutor_TagSearch(t *testing.T) {
 	query := q.(*stmt.Query)
 
 	// case 1: tag search err
-	exec := newStorageExecutor(qFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec := newStorageExecutor(qFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	tagSearch.EXPECT().Filter().Return(nil, fmt.Errorf("err"))
 	qFlow.EXPECT().Complete(fmt.Errorf("err"))
 	exec.Execute()
 	// case 2: tag search not result
-	exec = newStorageExecutor(qFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(qFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	tagSearch.EXPECT().Filter().Return(nil, nil)
 	qFlow.EXPECT().Complete(constants.ErrNotFound)
 	exec.Execute()
@@ -189,6 +197,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadataIndex := metadb.NewMockMetadataDatabase(ctrl)
 	metadata.EXPECT().MetadataDatabase().Return(metadataIndex).AnyTimes()
+	mockSchemaCache(metadata, metadataIndex)
 	metadataIndex.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "host").Return(uint32(10), nil)
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 
@@ -205,8 +214,8 @@ func TestStorageExecute_Execute(t *testing.T) {
 	mockDatabase.EXPECT().GetShard(int32(3)).Return(shard, true).AnyTimes()
 	mockDatabase.EXPECT().Metadata().Return(metadata).AnyTimes()
 	metadataIndex.EXPECT().GetMetricID(gomock.Any(), "cpu").Return(uint32(10), nil).AnyTimes()
-	metadataIndex.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-		Return(field.Meta{ID: 10, Type: field.SumField}, nil).AnyTimes()
+	metadataIndex.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return([]field.Meta{{ID: 10, Type: field.SumField}}, nil).AnyTimes()
 	shard.EXPECT().MemoryDatabase().Return(memDB).AnyTimes()
 	shard.EXPECT().IndexDatabase().Return(nil).AnyTimes()
 
@@ -215,7 +224,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	query := q.(*stmt.Query)
 
 	seriesSearch.EXPECT().Search().Return(nil, fmt.Errorf("err")).Times(3)
-	exec := newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec := newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	exec.Execute()
 	// case 2: normal case without filter
 	q, _ = sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'")
@@ -230,7 +239,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{filterRS}, nil).MaxTimes(3)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	exec.Execute()
 	// case 3: normal case with filter
 	q, _ = sql.Parse("select f from cpu where host='1.1.1.1' and time>'20190729 11:00:00' and time<'20190729 12:00:00'")
@@ -242,20 +251,20 @@ func TestStorageExecute_Execute(t *testing.T) {
 	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{filterRS}, nil).MaxTimes(3)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil).Times(3)
 	exec.Execute()
 	// case 4: filter data err
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{filterRS}, fmt.Errorf("err")).MaxTimes(3)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil).Times(3)
 	exec.Execute()
 	// case 5: filter result is nil
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, nil).MaxTimes(3)
 	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil).Times(3)
 	exec.Execute()
 	// case 6: filter shard data err
@@ -265,7 +274,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return([]tsdb.DataFamily{family}).MaxTimes(3)
 	family.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, fmt.Errorf("err")).MaxTimes(3)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil).Times(3)
 	exec.Execute()
 	// case 7: group by
@@ -279,7 +288,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{filterRS}, nil).MaxTimes(3)
 	index.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err")).MaxTimes(3)
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query, storageQueryConfig{}))
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil).Times(3)
 	exec.Execute()
 }
@@ -301,7 +310,7 @@ func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
 	q, _ := sql.Parse("select f from cpu group by host")
 	query := q.(*stmt.Query)
 
-	exec := newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1}, query))
+	exec := newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1}, query, storageQueryConfig{}))
 	exec1 := exec.(*storageExecutor)
 	exec1.groupByTagKeyIDs = []tag.Meta{{ID: 1, Key: "host"}}
 	exec1.tagValueIDs = make([]*roaring.Bitmap, len(exec1.groupByTagKeyIDs))
@@ -334,7 +343,7 @@ func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
 	gCtx.EXPECT().BuildGroup(gomock.Any(), gomock.Any()).Return(map[string][]uint16{"host": {1, 2, 3}})
 	rs.EXPECT().Load(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 	tagMeta.EXPECT().CollectTagValues(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
-	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1}, query))
+	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1}, query, storageQueryConfig{}))
 	exec1 = exec.(*storageExecutor)
 	exec1.groupByTagKeyIDs = []tag.Meta{{ID: 1, Key: "host"}}
 	exec1.tagValueIDs = make([]*roaring.Bitmap, len(exec1.groupByTagKeyIDs))
@@ -347,7 +356,6 @@ func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
 		return task
 	}
 	indexDB.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(gCtx, nil)
-	rs.EXPECT().Load(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 	task.EXPECT().Run().Return(fmt.Errorf("err"))
 	exec1.executeGroupBy(shard, []flow.FilterResultSet{rs}, roaring.BitmapOf(1, 2, 3))
 	newBuildGroupTaskFunc = newBuildGroupTask
@@ -373,7 +381,7 @@ func TestStorageExecutor_merge_groupBy_tagValues(t *testing.T) {
 
 	queryFlow := flow.NewMockStorageQueryFlow(ctrl)
 	queryFlow.EXPECT().Scanner(gomock.Any()).AnyTimes()
-	exec := newStorageExecutor(queryFlow, nil, newStorageExecuteContext([]int32{1}, &stmt.Query{}))
+	exec := newStorageExecutor(queryFlow, nil, newStorageExecuteContext([]int32{1}, &stmt.Query{}, storageQueryConfig{}))
 	exec1 := exec.(*storageExecutor)
 	exec1.groupByTagKeyIDs = []tag.Meta{{ID: 1}, {ID: 2}, {ID: 3}}
 	exec1.pendingForShard.Add(1)
@@ -402,3 +410,328 @@ func TestStorageExecutor_seriesResultScanner(t *testing.T) {
 	scanners.Scan(1)
 	_ = scanners.Close()
 }
+
+func TestStorageExecutor_DistinctCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, err := sql.Parse("select distinct_count(userid) from events " +
+		"where time>='20190729 10:00:00' and time<'20190729 10:05:00' group by time(1m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	fieldMeta := field.Meta{ID: 1, Name: "userid", Type: field.SumField}
+	// user ids of series, slot => series id * 1000 + slot
+	newRS := func(seriesIDs ...uint16) *pointsFilterResultSet {
+		rs := newPointsFilterResultSet("20190729 10:00:00")
+		for _, seriesID := range seriesIDs {
+			for slot := 0; slot < 12; slot++ {
+				rs.points[seriesID] = append(rs.points[seriesID], float64(int(seriesID)*1000+slot))
+			}
+		}
+		return rs
+	}
+	// series 2/3 are stored by both nodes, storage interval = 10s, query interval = 1m
+	responses := []*pb.TaskResponse{
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, newRS(1, 2, 3)),
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, newRS(2, 3, 4)),
+	}
+
+	// broker merges the partial states of storage nodes, then estimates distinct count
+	values := evalBrokerQuery(t, query, fieldMeta.Name, responses)["distinct_count(userid)"]
+	assert.NotNil(t, values)
+	// 4 series * 6 user ids of each minute, sum of user ids is much larger
+	assert.Equal(t, 2, values.Size())
+	assert.InDelta(t, 24, values.GetValue(0), 0.5)
+	assert.InDelta(t, 24, values.GetValue(1), 0.5)
+}
+
+func TestStorageExecutor_Sample(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, err := sql.Parse("select sample(f, 3) from cpu " +
+		"where time>='20190729 10:00:00' and time<'20190729 10:02:00' group by time(10s)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	fieldMeta := field.Meta{ID: 1, Name: "f", Type: field.GaugeField}
+	rs := newPointsFilterResultSet("20190729 10:00:00")
+	for slot := 0; slot < 12; slot++ {
+		rs.points[1] = append(rs.points[1], float64(slot+1))
+	}
+	resp := executeStorageQuery(t, ctrl, query, fieldMeta, 1, rs)
+
+	values := evalBrokerQuery(t, query, fieldMeta.Name, []*pb.TaskResponse{resp})["sample(f,3.00)"]
+	assert.NotNil(t, values)
+	// keeps the first data point of every 3 points
+	result := make(map[int]float64)
+	it := values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		result[idx] = value
+	}
+	assert.Equal(t, map[int]float64{0: 1, 3: 4, 6: 7, 9: 10}, result)
+}
+
+func TestStorageExecutor_ExtremumTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, err := sql.Parse("select max_time(f),min_time(f) from cpu " +
+		"where time>='20190729 10:00:00' and time<'20190729 10:02:00' group by time(1m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	fieldMeta := field.Meta{ID: 1, Name: "f", Type: field.GaugeField}
+	rs1 := newPointsFilterResultSet("20190729 10:00:00")
+	rs2 := newPointsFilterResultSet("20190729 10:00:00")
+	for slot := 0; slot < 12; slot++ {
+		rs1.points[1] = append(rs1.points[1], float64(slot))
+		rs2.points[2] = append(rs2.points[2], 20)
+	}
+	rs2.points[2][1] = 50
+	rs2.points[2][8] = -1
+	// storage interval = 10s, query interval = 1m
+	responses := []*pb.TaskResponse{
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, rs1),
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, rs2),
+	}
+
+	// broker merges the extremum of storage nodes, returns the timestamp of extremum
+	resultSet := evalBrokerQuery(t, query, fieldMeta.Name, responses)
+	timestamp := func(value string) float64 {
+		ts, _ := timeutil.ParseTimestamp(value)
+		return float64(ts)
+	}
+	maxTime := resultSet["max_time(f)"]
+	assert.NotNil(t, maxTime)
+	assert.Equal(t, 2, maxTime.Size())
+	assert.Equal(t, timestamp("20190729 10:00:10"), maxTime.GetValue(0))
+	// ties pick the earliest
+	assert.Equal(t, timestamp("20190729 10:01:00"), maxTime.GetValue(1))
+	minTime := resultSet["min_time(f)"]
+	assert.NotNil(t, minTime)
+	assert.Equal(t, 2, minTime.Size())
+	assert.Equal(t, timestamp("20190729 10:00:00"), minTime.GetValue(0))
+	assert.Equal(t, timestamp("20190729 10:01:20"), minTime.GetValue(1))
+}
+
+func TestStorageExecutor_Increase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, err := sql.Parse("select increase(f, 1m) from cpu " +
+		"where time>='20190729 10:00:00' and time<'20190729 10:02:00' group by time(1m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	fieldMeta := field.Meta{ID: 1, Name: "f", Type: field.GaugeField}
+	rs1 := newPointsFilterResultSet("20190729 10:00:00")
+	rs2 := newPointsFilterResultSet("20190729 10:00:00")
+	// counter of series 1 increases 10 per 10s, counter of series 2 resets at 10:00:30
+	rs1.points[1] = []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110}
+	rs2.points[2] = []float64{100, 110, 120, 0, 10, 20, 30, 40, 50, 60, 70, 80}
+	// storage interval = 10s, query interval = 1m
+	responses := []*pb.TaskResponse{
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, rs1),
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, rs2),
+	}
+
+	// increase of each series is extrapolated to the window edges, then summed
+	values := evalBrokerQuery(t, query, fieldMeta.Name, responses)["increase(f,60000.00)"]
+	assert.NotNil(t, values)
+	assert.Equal(t, 2, values.Size())
+	assert.InDelta(t, 60+48, values.GetValue(0), 0.001)
+	assert.InDelta(t, 60+60, values.GetValue(1), 0.001)
+}
+
+func TestStorageExecutor_NonNegativeDerivative(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, err := sql.Parse("select nonnegative_derivative(f) from cpu " +
+		"where time>='20190729 10:00:00' and time<'20190729 10:02:00' group by time(1m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	fieldMeta := field.Meta{ID: 1, Name: "f", Type: field.GaugeField}
+	rs1 := newPointsFilterResultSet("20190729 10:00:00")
+	rs2 := newPointsFilterResultSet("20190729 10:00:00")
+	// series 1 increases 10 per 10s, series 2 drops at 10:00:50
+	rs1.points[1] = []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110}
+	rs2.points[2] = []float64{100, 110, 120, 130, 140, 0, 10, 20, 30, 40, 50, 60}
+	// storage interval = 10s, query interval = 1m
+	responses := []*pb.TaskResponse{
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, rs1),
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, rs2),
+	}
+
+	// per second derivative of each series(last one in slot), negative is clamped to 0, then summed
+	values := evalBrokerQuery(t, query, fieldMeta.Name, responses)["nonnegative_derivative(f)"]
+	assert.NotNil(t, values)
+	assert.Equal(t, 2, values.Size())
+	assert.Equal(t, 1.0, values.GetValue(0))
+	assert.Equal(t, 2.0, values.GetValue(1))
+}
+
+func TestStorageExecutor_CountAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q, err := sql.Parse("select count(all f) from cpu " +
+		"where time>='20190729 10:00:00' and time<'20190729 10:03:00' group by time(1m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	fieldMeta := field.Meta{ID: 1, Name: "f", Type: field.SumField}
+	// series 1 has data points in first minute, series 2 has data points in first and third minutes
+	rs1 := newPointsFilterResultSet("20190729 10:00:00")
+	rs1.points[1] = []float64{1, 2, 3}
+	rs2 := newPointsFilterResultSet("20190729 10:00:00")
+	nan := math.NaN()
+	rs2.points[2] = []float64{1, 2, 3, 4, 5, 6, nan, nan, nan, nan, nan, nan, 1, 2, 3, 4, 5, 6}
+	// storage interval = 10s, query interval = 1m
+	responses := []*pb.TaskResponse{
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, rs1),
+		executeStorageQuery(t, ctrl, query, fieldMeta, 6, rs2),
+	}
+
+	// the counts of storage nodes are merged, the minute without data point is counted as 0,
+	// the end of query time range(10:03) is inclusive, which is also counted.
+	values := evalBrokerQuery(t, query, fieldMeta.Name, responses)["count_all(f)"]
+	assert.NotNil(t, values)
+	result := make(map[int]float64)
+	it := values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		result[idx] = value
+	}
+	assert.Equal(t, map[int]float64{0: 3 + 6, 1: 0, 2: 6, 3: 0}, result)
+}
+
+// executeStorageQuery executes the query of one field on storage, the data points of series are loaded by rs,
+// returns the task response which is sent to broker.
+func executeStorageQuery(t *testing.T, ctrl *gomock.Controller, query *stmt.Query,
+	fieldMeta field.Meta, ratio int, rs *pointsFilterResultSet,
+) *pb.TaskResponse {
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataIndex := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataIndex).AnyTimes()
+	mockSchemaCache(metadata, metadataIndex)
+	metadataIndex.EXPECT().GetMetricID(gomock.Any(), query.MetricName).Return(uint32(10), nil).AnyTimes()
+	metadataIndex.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), fieldMeta.Name).
+		Return([]field.Meta{fieldMeta}, nil).AnyTimes()
+	index := indexdb.NewMockIndexDatabase(ctrl)
+	index.EXPECT().GetSeriesIDsForMetric(gomock.Any(), gomock.Any()).Return(rs.SeriesIDs().Clone(), nil)
+	memDB := memdb.NewMockMemoryDatabase(ctrl)
+	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]flow.FilterResultSet{rs}, nil)
+	shard := tsdb.NewMockShard(ctrl)
+	shard.EXPECT().IndexDatabase().Return(index).AnyTimes()
+	shard.EXPECT().MemoryDatabase().Return(memDB).AnyTimes()
+	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil)
+	db := tsdb.NewMockDatabase(ctrl)
+	db.EXPECT().NumOfShards().Return(1).AnyTimes()
+	db.EXPECT().GetShard(int32(1)).Return(shard, true)
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+
+	result := make(chan *pb.TaskResponse, 1)
+	stream := commonmock.NewMockTaskService_HandleServer(ctrl)
+	stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		result <- resp
+		return nil
+	})
+	executorPool := &tsdb.ExecutorPool{
+		Filtering: concurrent.NewPool("test-filtering-pool", runtime.NumCPU(), time.Second*5),
+		Grouping:  concurrent.NewPool("test-grouping-pool", runtime.NumCPU(), time.Second*5),
+		Scanner:   concurrent.NewPool("test-scanner-pool", runtime.NumCPU(), time.Second*5),
+	}
+	storageCtx := newStorageExecuteContext([]int32{1}, query, storageQueryConfig{})
+	queryFlow := parallel.NewStorageQueryFlow(context.TODO(), storageCtx, query, &pb.TaskRequest{}, stream,
+		executorPool, query.TimeRange, query.Interval, ratio, aggregation.MarshalOption{})
+	newStorageExecutor(queryFlow, db, storageCtx).Execute()
+	select {
+	case resp := <-result:
+		assert.NotNil(t, resp)
+		assert.Empty(t, resp.ErrMsg)
+		return resp
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "storage query timeout")
+		return nil
+	}
+}
+
+// evalBrokerQuery merges the responses of storage nodes into one group on broker,
+// then returns the eval result of select items.
+func evalBrokerQuery(t *testing.T, query *stmt.Query, fieldName field.Name,
+	responses []*pb.TaskResponse,
+) map[string]collections.FloatArray {
+	groupAgg := aggregation.NewGroupingAggregator(query.Interval, query.TimeRange,
+		aggregation.AggregatorSpecs{aggregation.NewAggregatorSpec(fieldName)})
+	for _, resp := range responses {
+		tsList := &pb.TimeSeriesList{}
+		assert.NoError(t, tsList.Unmarshal(resp.Payload))
+		assert.Len(t, tsList.TimeSeriesList, 1)
+		ts := tsList.TimeSeriesList[0]
+		fields := make(map[field.Name][]byte)
+		for k, v := range ts.Fields {
+			fields[field.Name(k)] = v
+		}
+		groupAgg.Aggregate(series.NewGroupedIterator(ts.Tags, fields))
+	}
+	groupedSeries, err := groupAgg.ResultSet()
+	assert.NoError(t, err)
+	assert.Len(t, groupedSeries, 1)
+	expression := aggregation.NewExpression(query.TimeRange, query.Interval.Int64(), query.SelectItems, aggregation.SkipNaN)
+	expression.Eval(groupedSeries[0])
+	return expression.ResultSet()
+}
+
+// pointsFilterResultSet implements flow.FilterResultSet for testing, loads the data points of series
+type pointsFilterResultSet struct {
+	familyTime int64
+	points     map[uint16][]float64 // low series id => values of storage time slots
+}
+
+func newPointsFilterResultSet(familyTime string) *pointsFilterResultSet {
+	timestamp, _ := timeutil.ParseTimestamp(familyTime)
+	return &pointsFilterResultSet{familyTime: timestamp, points: make(map[uint16][]float64)}
+}
+
+func (rs *pointsFilterResultSet) Identifier() string {
+	return "memory"
+}
+
+func (rs *pointsFilterResultSet) SeriesIDs() *roaring.Bitmap {
+	seriesIDs := roaring.New()
+	for seriesID := range rs.points {
+		seriesIDs.Add(uint32(seriesID))
+	}
+	return seriesIDs
+}
+
+func (rs *pointsFilterResultSet) Load(queryFlow flow.StorageQueryFlow, _ []field.ID,
+	highKey uint16, _ roaring.Container,
+) flow.Scanner {
+	block, ok := queryFlow.GetAggregator(highKey).GetFieldAggregates()[0].GetAggregateBlock(rs.familyTime)
+	if !ok {
+		return nil
+	}
+	return &pointsScanner{block: block, points: rs.points}
+}
+
+// pointsScanner appends the data points of series into block when scanning, NaN means no data point of time slot
+type pointsScanner struct {
+	block  series.Block
+	points map[uint16][]float64
+}
+
+func (s *pointsScanner) Scan(lowSeriesID uint16) {
+	for slot, value := range s.points[lowSeriesID] {
+		if math.IsNaN(value) {
+			continue
+		}
+		if s.block.Append(slot, value) {
+			return
+		}
+	}
+}
+
+func (s *pointsScanner) Close() error {
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql/stmt"
@@ -15,6 +16,8 @@ import (
 
 var (
 	errEmptySelectList = errors.New("select item list is empty")
+	// errRegexOnFieldValue represents regex predicate on field value, all fields are numeric, regex only applies to tag
+	errRegexOnFieldValue = errors.New("regex predicate is not supported on numeric field value")
 )
 
 // storageExecutePlan represents a storage level execute plan for data search,
@@ -26,9 +29,10 @@ type storageExecutePlan struct {
 
 	fieldIDs []field.ID
 
-	metricID    uint32
-	fields      map[field.ID]aggregation.AggregatorSpec
-	groupByTags []tag.Meta
+	metricID     uint32
+	fields       map[field.ID]aggregation.AggregatorSpec
+	groupByTags  []tag.Meta
+	valueFilters map[string]valueFilters // field name => value predicates of field
 
 	err error
 }
@@ -36,10 +40,11 @@ type storageExecutePlan struct {
 // newStorageExecutePlan creates a storage execute plan
 func newStorageExecutePlan(namespace string, metadata metadb.Metadata, query *stmt.Query) Plan {
 	return &storageExecutePlan{
-		namespace: namespace,
-		metadata:  metadata,
-		query:     query,
-		fields:    make(map[field.ID]aggregation.AggregatorSpec),
+		namespace:    namespace,
+		metadata:     metadata,
+		query:        query,
+		fields:       make(map[field.ID]aggregation.AggregatorSpec),
+		valueFilters: make(map[string]valueFilters),
 	}
 }
 
@@ -47,10 +52,22 @@ func newStorageExecutePlan(namespace string, metadata metadb.Metadata, query *st
 func (p *storageExecutePlan) Plan() error {
 	// metric name => id, like table name
 	metricID, err := p.metadata.MetadataDatabase().GetMetricID(p.namespace, p.query.MetricName)
+	if errors.Is(err, constants.ErrNotFound) || errors.Is(err, constants.ErrMetricNotFound) {
+		return fmt.Errorf("%w: %s", ErrMetricNotFound, p.query.MetricName)
+	}
 	if err != nil {
-		return err
+		return fmt.Errorf("get metric id of %s: %w", p.query.MetricName, err)
 	}
 	p.metricID = metricID
+	// validates all tag filters before index lookups
+	if p.query.Condition != nil {
+		if err := p.query.Condition.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := p.valuePredicates(); err != nil {
+		return err
+	}
 	if err := p.groupBy(); err != nil {
 		return err
 	}
@@ -60,6 +77,9 @@ func (p *storageExecutePlan) Plan() error {
 	if p.err != nil {
 		return p.err
 	}
+	if err := p.checkValuePredicates(); err != nil {
+		return err
+	}
 	p.fieldIDs = make([]field.ID, len(p.fields))
 	idx := 0
 	for fieldID := range p.fields {
@@ -74,6 +94,93 @@ func (p *storageExecutePlan) Plan() error {
 	return nil
 }
 
+// valuePredicates splits the value predicates of fields(like f > 90) from the top level and-ed condition,
+// the remaining tag filters resolve the series, the value predicates filter the data points of series.
+// NOTICE: rewrites the condition of query, because tag filter/series search are based on it.
+func (p *storageExecutePlan) valuePredicates() error {
+	if p.query.Condition == nil {
+		return nil
+	}
+	var tagFilters []stmt.Expr
+	for _, expr := range andOperands(p.query.Condition) {
+		if regex, isRegex := expr.(*stmt.RegexExpr); isRegex {
+			if err := p.checkRegexOnField(regex); err != nil {
+				return err
+			}
+		}
+		fieldName, filter, ok := newValueFilter(expr)
+		if ok {
+			_, err := p.metadata.SchemaCache().GetAliasedFields(p.namespace, p.query.MetricName, field.Name(fieldName))
+			if err == nil {
+				p.valueFilters[fieldName] = append(p.valueFilters[fieldName], filter)
+				continue
+			}
+			if !errors.Is(err, constants.ErrNotFound) {
+				return err
+			}
+			// not a field, as tag filter
+		}
+		tagFilters = append(tagFilters, expr)
+	}
+	if len(p.valueFilters) == 0 {
+		return nil
+	}
+	var condition stmt.Expr
+	for _, tagFilter := range tagFilters {
+		if condition == nil {
+			condition = tagFilter
+			continue
+		}
+		condition = &stmt.BinaryExpr{Left: condition, Operator: stmt.AND, Right: tagFilter}
+	}
+	p.query.Condition = condition
+	return nil
+}
+
+// checkRegexOnField checks if the key of regex predicate is a field, fails the query because field value is numeric
+func (p *storageExecutePlan) checkRegexOnField(regex *stmt.RegexExpr) error {
+	_, err := p.metadata.SchemaCache().GetAliasedFields(p.namespace, p.query.MetricName, field.Name(regex.Key))
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w, field: %s", errRegexOnFieldValue, regex.Key)
+	case errors.Is(err, constants.ErrNotFound):
+		// not a field, as tag filter
+		return nil
+	default:
+		return err
+	}
+}
+
+// checkValuePredicates checks if all fields of value predicates are in select list
+func (p *storageExecutePlan) checkValuePredicates() error {
+	for fieldName := range p.valueFilters {
+		found := false
+		for _, aggSpec := range p.fields {
+			if aggSpec.FieldName() == field.Name(fieldName) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("field[%s] of value predicate must be in select list", fieldName)
+		}
+	}
+	return nil
+}
+
+// andOperands returns the operands of top level and-ed condition
+func andOperands(condition stmt.Expr) []stmt.Expr {
+	switch expr := condition.(type) {
+	case *stmt.ParenExpr:
+		return andOperands(expr.Expr)
+	case *stmt.BinaryExpr:
+		if expr.Operator == stmt.AND {
+			return append(andOperands(expr.Left), andOperands(expr.Right)...)
+		}
+	}
+	return []stmt.Expr{condition}
+}
+
 // groupByKeyIDs returns group by tag key ids
 func (p *storageExecutePlan) groupByKeyIDs() []tag.Meta {
 	return p.groupByTags
@@ -81,6 +188,11 @@ func (p *storageExecutePlan) groupByKeyIDs() []tag.Meta {
 
 // groupBy parses group by tag keys
 func (p *storageExecutePlan) groupBy() error {
+	if p.query.GroupByAll {
+		if err := p.expandGroupByAll(); err != nil {
+			return err
+		}
+	}
 	groupByTags := len(p.query.GroupBy)
 	if groupByTags == 0 {
 		return nil
@@ -88,7 +200,7 @@ func (p *storageExecutePlan) groupBy() error {
 	p.groupByTags = make([]tag.Meta, groupByTags)
 
 	for idx, tagKey := range p.query.GroupBy {
-		tagKeyID, err := p.metadata.MetadataDatabase().GetTagKeyID(p.namespace, p.query.MetricName, tagKey)
+		tagKeyID, err := p.metadata.SchemaCache().GetTagKeyID(p.namespace, p.query.MetricName, tagKey)
 		if err != nil {
 			return err
 		}
@@ -100,6 +212,36 @@ func (p *storageExecutePlan) groupBy() error {
 	return nil
 }
 
+// expandGroupByAll expands group by * to all tag keys of metric, the explicit group by tag keys are kept in front.
+// NOTICE: rewrites the group by tag keys of query, because query flow builds grouped tags based on them.
+func (p *storageExecutePlan) expandGroupByAll() error {
+	tagKeys, err := p.metadata.MetadataDatabase().GetAllTagKeys(p.namespace, p.query.MetricName)
+	if err != nil && !errors.Is(err, constants.ErrNotFound) {
+		return err
+	}
+	sort.Slice(tagKeys, func(i, j int) bool {
+		return tagKeys[i].Key < tagKeys[j].Key
+	})
+	groupBy := make([]string, 0, len(p.query.GroupBy)+len(tagKeys))
+	keys := make(map[string]struct{})
+	for _, tagKey := range p.query.GroupBy {
+		if _, ok := keys[tagKey]; ok {
+			continue
+		}
+		keys[tagKey] = struct{}{}
+		groupBy = append(groupBy, tagKey)
+	}
+	for _, tagKey := range tagKeys {
+		if _, ok := keys[tagKey.Key]; ok {
+			continue
+		}
+		keys[tagKey.Key] = struct{}{}
+		groupBy = append(groupBy, tagKey.Key)
+	}
+	p.query.GroupBy = groupBy
+	return nil
+}
+
 // getDownSamplingAggSpecs returns the down sampling aggregate specs
 func (p *storageExecutePlan) getDownSamplingAggSpecs() aggregation.AggregatorSpecs {
 	result := make(aggregation.AggregatorSpecs, len(p.fieldIDs))
@@ -146,16 +288,18 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 	case *stmt.ParenExpr:
 		p.field(nil, e.Expr)
 	case *stmt.BinaryExpr:
+		// arithmetic is evaluated per slot based on the default down sampling values of fields,
+		// then the parent function(e.g. sum(a/b)) aggregates the computed values, not the bare fields.
 		p.field(nil, e.Left)
 		p.field(nil, e.Right)
 	case *stmt.FieldExpr:
-		fieldMeta, err := p.metadata.MetadataDatabase().GetField(p.namespace, p.query.MetricName, field.Name(e.Name))
+		// aliased fields are equivalent, their data will be merged by the queried field name
+		fieldMetas, err := p.metadata.SchemaCache().GetAliasedFields(p.namespace, p.query.MetricName, field.Name(e.Name))
 		if err != nil {
 			p.err = err
 			return
 		}
-		fieldType := fieldMeta.Type
-		fieldID := fieldMeta.ID
+		fieldType := fieldMetas[0].Type
 		var funcType function.FuncType
 		// tests if has func with field
 		if parentFunc == nil {
@@ -165,6 +309,10 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 				p.err = fmt.Errorf("cannot get default down sampling func for filed type[%s]", fieldType)
 				return
 			}
+			// raw query(select bare fields without interval), query the raw data points without folding
+			if p.query.Raw {
+				funcType = function.Identity
+			}
 		} else {
 			// using use input, and check func is supported
 			if !fieldType.IsFuncSupported(parentFunc.FuncType) {
@@ -173,11 +321,45 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 			}
 			funcType = parentFunc.FuncType
 		}
-		downSampling, exist := p.fields[fieldID]
-		if !exist {
-			downSampling = aggregation.NewDownSamplingSpec(field.Name(e.Name), fieldType)
-			p.fields[fieldID] = downSampling
+		for _, fieldMeta := range fieldMetas {
+			if fieldMeta.Type != fieldType {
+				p.err = fmt.Errorf("aliased field[%s]'s type[%s] not match field type[%s]",
+					fieldMeta.Name, fieldMeta.Type, fieldType)
+				return
+			}
+			downSampling, exist := p.fields[fieldMeta.ID]
+			if !exist {
+				downSampling = aggregation.NewDownSamplingSpec(field.Name(e.Name), fieldType)
+				if filter, ok := p.valueFilters[e.Name]; ok {
+					downSampling.SetValueFilter(filter)
+				}
+				p.fields[fieldMeta.ID] = downSampling
+			}
+			downSampling.AddFunctionType(funcType)
+			if param, ok := funcParam(parentFunc); ok {
+				if err := downSampling.SetFunctionParam(funcType, param); err != nil {
+					p.err = err
+					return
+				}
+			}
+		}
+	}
+}
+
+// funcParam returns the constant param of function which is calculated by storage,
+// like factor of sample(f, N), window of increase(f, 5m)
+func funcParam(callExpr *stmt.CallExpr) (int64, bool) {
+	if callExpr == nil || len(callExpr.Params) < 2 {
+		return 0, false
+	}
+	switch callExpr.FuncType {
+	case function.Sample, function.Increase:
+		param, ok := callExpr.Params[1].(*stmt.NumberLiteral)
+		if !ok {
+			return 0, false
 		}
-		downSampling.AddFunctionType(funcType)
+		return int64(param.Val), true
+	default:
+		return 0, false
 	}
 }
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -24,13 +26,14 @@ func TestStoragePlan_Metric(t *testing.T) {
 	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
 
 	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(10), nil)
-	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(field.Meta{
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]field.Meta{{
 			ID:   10,
 			Type: field.SumField,
-		}, nil).AnyTimes()
+		}}, nil).AnyTimes()
 
 	q, _ := sql.Parse("select f from cpu")
 	query := q.(*stmt.Query)
@@ -41,7 +44,34 @@ func TestStoragePlan_Metric(t *testing.T) {
 	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(0), constants.ErrNotFound)
 	plan = newStorageExecutePlan("ns", metadata, query)
 	err = plan.Plan()
-	assert.Equal(t, constants.ErrNotFound, err)
+	assert.True(t, errors.Is(err, ErrMetricNotFound))
+	assert.True(t, errors.Is(err, constants.ErrMetricNotFound))
+
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(0), fmt.Errorf("err"))
+	plan = newStorageExecutePlan("ns", metadata, query)
+	err = plan.Plan()
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrMetricNotFound))
+
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(0), context.Canceled)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	err = plan.Plan()
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestStoragePlan_invalid_condition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(10), nil)
+
+	// validates condition before lookups
+	q, _ := sql.Parse("select f from cpu where host='a' and ip=~'a(b'")
+	plan := newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.Error(t, plan.Plan())
 }
 
 func TestStoragePlan_SelectList(t *testing.T) {
@@ -51,21 +81,22 @@ func TestStoragePlan_SelectList(t *testing.T) {
 	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
 
 	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(10), nil).AnyTimes()
-	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-		Return(field.Meta{ID: 10, Type: field.SumField}, nil).AnyTimes()
-	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("a")).
-		Return(field.Meta{ID: 11, Type: field.MinField}, nil).AnyTimes()
-	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("b")).
-		Return(field.Meta{ID: 12, Type: field.MaxField}, nil).AnyTimes()
-	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("c")).
-		Return(field.Meta{ID: 13, Type: field.HistogramField}, nil).AnyTimes()
-	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("e")).
-		Return(field.Meta{ID: 14, Type: field.HistogramField}, nil).AnyTimes()
-
-	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("no_f")).
-		Return(field.Meta{ID: 99, Type: field.HistogramField}, constants.ErrNotFound).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return([]field.Meta{{ID: 10, Type: field.SumField}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("a")).
+		Return([]field.Meta{{ID: 11, Type: field.MinField}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("b")).
+		Return([]field.Meta{{ID: 12, Type: field.MaxField}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("c")).
+		Return([]field.Meta{{ID: 13, Type: field.HistogramField}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("e")).
+		Return([]field.Meta{{ID: 14, Type: field.HistogramField}}, nil).AnyTimes()
+
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("no_f")).
+		Return(nil, constants.ErrNotFound).AnyTimes()
 
 	// error
 	query := &stmt.Query{MetricName: "cpu"}
@@ -87,6 +118,19 @@ func TestStoragePlan_SelectList(t *testing.T) {
 
 	storagePlan := plan.(*storageExecutePlan)
 	downSampling := aggregation.NewDownSamplingSpec("f", field.SumField)
+	downSampling.AddFunctionType(function.Identity)
+	assert.Equal(t, map[field.ID]aggregation.AggregatorSpec{field.ID(10): downSampling}, storagePlan.fields)
+	assert.Equal(t, []field.ID{10}, storagePlan.getFieldIDs())
+
+	// down sampling with interval
+	q, _ = sql.Parse("select f from cpu group by time(1m)")
+	query = q.(*stmt.Query)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	err = plan.Plan()
+	assert.NoError(t, err)
+
+	storagePlan = plan.(*storageExecutePlan)
+	downSampling = aggregation.NewDownSamplingSpec("f", field.SumField)
 	downSampling.AddFunctionType(function.Sum)
 	assert.Equal(t, map[field.ID]aggregation.AggregatorSpec{field.ID(10): downSampling}, storagePlan.fields)
 	assert.Equal(t, []field.ID{10}, storagePlan.getFieldIDs())
@@ -99,11 +143,11 @@ func TestStoragePlan_SelectList(t *testing.T) {
 
 	storagePlan = plan.(*storageExecutePlan)
 	downSampling1 := aggregation.NewDownSamplingSpec("a", field.MinField)
-	downSampling1.AddFunctionType(function.Min)
+	downSampling1.AddFunctionType(function.Identity)
 	downSampling2 := aggregation.NewDownSamplingSpec("b", field.MaxField)
-	downSampling2.AddFunctionType(function.Max)
+	downSampling2.AddFunctionType(function.Identity)
 	downSampling3 := aggregation.NewDownSamplingSpec("c", field.HistogramField)
-	downSampling3.AddFunctionType(function.Histogram)
+	downSampling3.AddFunctionType(function.Identity)
 	expect := map[field.ID]aggregation.AggregatorSpec{
 		field.ID(11): downSampling1,
 		field.ID(12): downSampling2,
@@ -133,6 +177,41 @@ func TestStoragePlan_SelectList(t *testing.T) {
 	}
 	assert.Equal(t, expect, storagePlan.fields)
 	assert.Equal(t, []field.ID{11, 13, 14}, storagePlan.getFieldIDs())
+
+	// aggregates over computed expression, fields of expression use default down sampling func
+	q, _ = sql.Parse("select sum(f/a) from cpu group by time(1m)")
+	query = q.(*stmt.Query)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	err = plan.Plan()
+	assert.NoError(t, err)
+	storagePlan = plan.(*storageExecutePlan)
+
+	downSampling = aggregation.NewDownSamplingSpec("f", field.SumField)
+	downSampling.AddFunctionType(function.Sum)
+	downSampling1 = aggregation.NewDownSamplingSpec("a", field.MinField)
+	downSampling1.AddFunctionType(function.Min)
+	expect = map[field.ID]aggregation.AggregatorSpec{
+		field.ID(10): downSampling,
+		field.ID(11): downSampling1,
+	}
+	assert.Equal(t, expect, storagePlan.fields)
+	assert.Equal(t, []field.ID{10, 11}, storagePlan.getFieldIDs())
+
+	// constant param of function is passed through aggregator spec
+	q, _ = sql.Parse("select sample(f, 3) from cpu group by time(1m)")
+	query = q.(*stmt.Query)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	err = plan.Plan()
+	assert.NoError(t, err)
+	storagePlan = plan.(*storageExecutePlan)
+	assert.Equal(t, int64(3), storagePlan.fields[field.ID(10)].FunctionParam(function.Sample))
+
+	// conflicting params of same function
+	q, _ = sql.Parse("select sample(f, 3),sample(f, 5) from cpu group by time(1m)")
+	query = q.(*stmt.Query)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	err = plan.Plan()
+	assert.Error(t, err)
 }
 
 func TestStorageExecutePlan_groupBy(t *testing.T) {
@@ -141,15 +220,16 @@ func TestStorageExecutePlan_groupBy(t *testing.T) {
 	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
 
 	gomock.InOrder(
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
 		metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "host").Return(uint32(10), nil),
 		metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "path").Return(uint32(11), nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-			Return(field.Meta{ID: 12, Type: field.SumField}, nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("d")).
-			Return(field.Meta{ID: 10, Type: field.SumField}, nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+			Return([]field.Meta{{ID: 12, Type: field.SumField}}, nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("d")).
+			Return([]field.Meta{{ID: 10, Type: field.SumField}}, nil),
 	)
 
 	// normal
@@ -180,12 +260,209 @@ func TestStorageExecutePlan_groupBy(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestStorageExecutePlan_groupByAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
+
+	// expands * to all tag keys of metric
+	gomock.InOrder(
+		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
+		metadataDB.EXPECT().GetAllTagKeys(gomock.Any(), "disk").
+			Return([]tag.Meta{{ID: 11, Key: "path"}, {ID: 10, Key: "host"}}, nil),
+		metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "host").Return(uint32(10), nil),
+		metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "path").Return(uint32(11), nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+			Return([]field.Meta{{ID: 12, Type: field.SumField}}, nil),
+	)
+	q, _ := sql.Parse("select f from disk group by *")
+	query := q.(*stmt.Query)
+	plan := newStorageExecutePlan("ns", metadata, query)
+	assert.NoError(t, plan.Plan())
+	storagePlan := plan.(*storageExecutePlan)
+	assert.Equal(t, []string{"host", "path"}, query.GroupBy)
+	assert.Equal(t, []tag.Meta{{ID: 10, Key: "host"}, {ID: 11, Key: "path"}}, storagePlan.groupByKeyIDs())
+
+	// explicit tag key kept in front, no duplicate
+	gomock.InOrder(
+		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
+		metadataDB.EXPECT().GetAllTagKeys(gomock.Any(), "disk").
+			Return([]tag.Meta{{ID: 11, Key: "path"}, {ID: 10, Key: "host"}}, nil),
+		metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "path").Return(uint32(11), nil),
+		metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "host").Return(uint32(10), nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+			Return([]field.Meta{{ID: 12, Type: field.SumField}}, nil),
+	)
+	q, _ = sql.Parse("select f from disk group by path,*")
+	query = q.(*stmt.Query)
+	assert.NoError(t, newStorageExecutePlan("ns", metadata, query).Plan())
+	assert.Equal(t, []string{"path", "host"}, query.GroupBy)
+
+	// metric without tag keys
+	gomock.InOrder(
+		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
+		metadataDB.EXPECT().GetAllTagKeys(gomock.Any(), "disk").Return(nil, constants.ErrNotFound),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+			Return([]field.Meta{{ID: 12, Type: field.SumField}}, nil),
+	)
+	q, _ = sql.Parse("select f from disk group by *")
+	query = q.(*stmt.Query)
+	assert.NoError(t, newStorageExecutePlan("ns", metadata, query).Plan())
+	assert.Empty(t, query.GroupBy)
+
+	// get tag keys err
+	gomock.InOrder(
+		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
+		metadataDB.EXPECT().GetAllTagKeys(gomock.Any(), "disk").Return(nil, fmt.Errorf("err")),
+	)
+	q, _ = sql.Parse("select f from disk group by *")
+	assert.Error(t, newStorageExecutePlan("ns", metadata, q.(*stmt.Query)).Plan())
+}
+
+func TestStorageExecutePlan_field_alias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
+
+	// usage is renamed to usage_pct, old data is stored under usage
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), "cpu").Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("usage_pct")).
+		Return([]field.Meta{
+			{ID: 2, Name: "usage_pct", Type: field.SumField},
+			{ID: 1, Name: "usage", Type: field.SumField},
+		}, nil)
+	q, _ := sql.Parse("select usage_pct from cpu")
+	plan := newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	err := plan.Plan()
+	assert.NoError(t, err)
+	storagePlan := plan.(*storageExecutePlan)
+	assert.Equal(t, []field.ID{1, 2}, storagePlan.getFieldIDs())
+	aggSpecs := storagePlan.getDownSamplingAggSpecs()
+	assert.Len(t, aggSpecs, 2)
+	for _, aggSpec := range aggSpecs {
+		// data of both fields are returned with the queried name
+		assert.Equal(t, field.Name("usage_pct"), aggSpec.FieldName())
+		assert.Equal(t, field.SumField, aggSpec.GetFieldType())
+	}
+
+	// aliased field type not match
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("usage_pct")).
+		Return([]field.Meta{
+			{ID: 2, Name: "usage_pct", Type: field.SumField},
+			{ID: 1, Name: "usage", Type: field.MaxField},
+		}, nil)
+	plan = newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	err = plan.Plan()
+	assert.Error(t, err)
+}
+
+func TestStorageExecutePlan_value_predicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), "cpu").Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return([]field.Meta{{ID: 1, Name: "f", Type: field.SumField}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("g")).
+		Return([]field.Meta{{ID: 2, Name: "g", Type: field.SumField}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("port")).
+		Return(nil, constants.ErrNotFound).AnyTimes()
+
+	// value predicates are split from tag filters
+	q, _ := sql.Parse("select f from cpu where host='a' and f>90 and (port>80 and f<=95)")
+	query := q.(*stmt.Query)
+	plan := newStorageExecutePlan("ns", metadata, query)
+	assert.NoError(t, plan.Plan())
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left:     &stmt.EqualsExpr{Key: "host", Value: "a"},
+		Operator: stmt.AND,
+		Right:    &stmt.GreaterExpr{Key: "port", Value: "80"},
+	}, query.Condition)
+	aggSpecs := plan.(*storageExecutePlan).getDownSamplingAggSpecs()
+	assert.Len(t, aggSpecs, 1)
+	filter := aggSpecs[0].ValueFilter()
+	assert.NotNil(t, filter)
+	// half of the points are filtered out
+	var matched []float64
+	for value := 86.0; value < 96; value++ {
+		if filter.Match(value) {
+			matched = append(matched, value)
+		}
+	}
+	assert.Equal(t, []float64{91, 92, 93, 94, 95}, matched)
+
+	// only value predicate, no tag filter
+	q, _ = sql.Parse("select f from cpu where f>90")
+	query = q.(*stmt.Query)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	assert.NoError(t, plan.Plan())
+	assert.Nil(t, query.Condition)
+	// no value predicate, condition not changed
+	q, _ = sql.Parse("select f from cpu where port>80")
+	query = q.(*stmt.Query)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	assert.NoError(t, plan.Plan())
+	assert.Equal(t, &stmt.GreaterExpr{Key: "port", Value: "80"}, query.Condition)
+	assert.Nil(t, plan.(*storageExecutePlan).getDownSamplingAggSpecs()[0].ValueFilter())
+	// field of value predicate not in select list
+	q, _ = sql.Parse("select f from cpu where g>90")
+	plan = newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.Error(t, plan.Plan())
+	// get field err
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("load")).
+		Return(nil, fmt.Errorf("err"))
+	q, _ = sql.Parse("select f from cpu where load>90")
+	plan = newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.Error(t, plan.Plan())
+}
+
+func TestStorageExecutePlan_regex_on_field(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), "cpu").Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return([]field.Meta{{ID: 1, Name: "f", Type: field.SumField}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("host")).
+		Return(nil, constants.ErrNotFound).AnyTimes()
+
+	// regex on field value fails the query
+	q, _ := sql.Parse("select f from cpu where host='a' and f=~'1.*'")
+	err := newStorageExecutePlan("ns", metadata, q.(*stmt.Query)).Plan()
+	assert.True(t, errors.Is(err, errRegexOnFieldValue))
+	// regex on tag
+	q, _ = sql.Parse("select f from cpu where host=~'a.*'")
+	query := q.(*stmt.Query)
+	assert.NoError(t, newStorageExecutePlan("ns", metadata, query).Plan())
+	assert.Equal(t, &stmt.RegexExpr{Key: "host", Regexp: "a.*"}, query.Condition)
+	// get field err
+	metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("load")).
+		Return(nil, fmt.Errorf("err"))
+	q, _ = sql.Parse("select f from cpu where load=~'1.*'")
+	err = newStorageExecutePlan("ns", metadata, q.(*stmt.Query)).Plan()
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, errRegexOnFieldValue))
+}
+
 func TestStorageExecutePlan_empty_select_item(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
 
 	gomock.InOrder(
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
@@ -201,11 +478,12 @@ func TestStorageExecutePlan_field_expr_fail(t *testing.T) {
 	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	mockSchemaCache(metadata, metadataDB)
 
 	gomock.InOrder(
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-			Return(field.Meta{ID: 10, Type: field.Unknown}, nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+			Return([]field.Meta{{ID: 10, Type: field.Unknown}}, nil),
 	)
 	q, _ := sql.Parse("select f from disk")
 	query := q.(*stmt.Query)
@@ -215,8 +493,8 @@ func TestStorageExecutePlan_field_expr_fail(t *testing.T) {
 
 	gomock.InOrder(
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-			Return(field.Meta{ID: 10, Type: field.SumField}, nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+			Return([]field.Meta{{ID: 10, Type: field.SumField}}, nil),
 	)
 	q, _ = sql.Parse("select histogram(f) from disk")
 	query = q.(*stmt.Query)
@@ -226,10 +504,10 @@ func TestStorageExecutePlan_field_expr_fail(t *testing.T) {
 
 	gomock.InOrder(
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("d")).
-			Return(field.Meta{ID: 10, Type: field.SumField}, nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-			Return(field.Meta{ID: 10, Type: field.SumField}, nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("d")).
+			Return([]field.Meta{{ID: 10, Type: field.SumField}}, nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+			Return([]field.Meta{{ID: 10, Type: field.SumField}}, nil),
 	)
 	q, _ = sql.Parse("select (d+histogram(f)+b) from disk")
 	query = q.(*stmt.Query)
@@ -239,10 +517,10 @@ func TestStorageExecutePlan_field_expr_fail(t *testing.T) {
 
 	gomock.InOrder(
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("d")).
-			Return(field.Meta{ID: 12, Type: field.SumField}, nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-			Return(field.Meta{ID: 11, Type: field.SumField}, nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("d")).
+			Return([]field.Meta{{ID: 12, Type: field.SumField}}, nil),
+		metadataDB.EXPECT().GetAliasedFields(gomock.Any(), gomock.Any(), field.Name("f")).
+			Return([]field.Meta{{ID: 11, Type: field.SumField}}, nil),
 	)
 	q, _ = sql.Parse("select (d+histogram(f)+b),e from disk")
 	query = q.(*stmt.Query)
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -8,10 +9,12 @@ import (
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 	"github.com/lindb/lindb/tsdb/metadb"
 )
@@ -133,6 +136,11 @@ func (t *tagFilterTask) Run() error {
 func (t *tagFilterTask) AfterRun() {
 	t.baseQueryTask.AfterRun()
 	t.ctx.stats.SetTagFilterCost(t.cost)
+	for tagFilter, result := range t.ctx.tagFilterResult {
+		if result != nil {
+			t.ctx.stats.SetTagFilterPushDown(tagFilter, result.pushDown)
+		}
+	}
 }
 
 // seriesIDsSearchTask represents series ids search task based on tag filtering result set
@@ -142,7 +150,8 @@ type seriesIDsSearchTask struct {
 	ctx   *storageExecuteContext
 	shard tsdb.Shard
 
-	result *roaring.Bitmap
+	result         *roaring.Bitmap
+	predicateStats map[string]*models.PredicateStats // actual matched series/cost of each tag filter if analyze
 }
 
 // newSeriesIDsSearchTask creates series ids search task
@@ -165,9 +174,21 @@ func (t *seriesIDsSearchTask) Run() (err error) {
 	condition := t.ctx.query.Condition
 	var seriesIDs *roaring.Bitmap
 	if condition != nil {
+		var filter series.Filter = t.shard.IndexDatabase()
+		if t.ctx.query.Window > 0 {
+			// trailing window, the universe of negation only includes the series which have data in the window
+			filter = newWindowFilter(t.shard, t.ctx.metricID, t.ctx.query.TimeRange)
+		}
 		// if get tag filter result do series ids searching
-		seriesSearch := newSeriesSearchFunc(t.shard.IndexDatabase(), t.ctx.tagFilterResult, t.ctx.query.Condition)
-		seriesIDs, err = seriesSearch.Search()
+		if t.ctx.query.Analyze {
+			// explain analyze, tracks the actual matched series of each tag filter
+			seriesSearch := newAnalyzedSeriesSearch(filter, t.ctx.tagFilterResult, t.ctx.query.Condition)
+			seriesIDs, err = t.ctx.cfg.indexBreaker.search(seriesSearch)
+			t.predicateStats = seriesSearch.PredicateStats()
+		} else {
+			seriesSearch := newSeriesSearchFunc(filter, t.ctx.tagFilterResult, t.ctx.query.Condition)
+			seriesIDs, err = t.ctx.cfg.indexBreaker.search(seriesSearch)
+		}
 	} else {
 		// get series ids for metric level
 		seriesIDs, err = t.shard.IndexDatabase().GetSeriesIDsForMetric(t.ctx.query.Namespace, t.ctx.query.MetricName)
@@ -177,7 +198,8 @@ func (t *seriesIDsSearchTask) Run() (err error) {
 		}
 	}
 	if err == nil && seriesIDs != nil {
-		t.result.Or(seriesIDs)
+		// sample_series, only queries the fraction of matched series before loading data
+		t.result.Or(sampleSeriesIDs(seriesIDs, t.ctx.query.SampleRate))
 	}
 	return
 }
@@ -186,6 +208,9 @@ func (t *seriesIDsSearchTask) Run() (err error) {
 func (t *seriesIDsSearchTask) AfterRun() {
 	t.baseQueryTask.AfterRun()
 	t.ctx.stats.SetShardSeriesIDsSearchStats(t.shard.ShardID(), t.result.GetCardinality(), t.cost)
+	if t.predicateStats != nil {
+		t.ctx.stats.SetShardPredicateStats(t.shard.ShardID(), t.predicateStats)
+	}
 }
 
 // memoryDataFilterTask represents memory data filter task
@@ -221,13 +246,16 @@ func newMemoryDataFilterTask(ctx *storageExecuteContext, shard tsdb.Shard,
 }
 
 // Run executes memory database data filtering based on series ids and time range
-func (t *memoryDataFilterTask) Run() error {
-	resultSet, err := t.shard.MemoryDatabase().Filter(t.metricID, t.fieldIDs, t.seriesIDs, t.ctx.query.TimeRange)
-	if err != nil {
-		return err
+func (t *memoryDataFilterTask) Run() (err error) {
+	for _, timeRange := range filterTimeRanges(t.ctx.query) {
+		var resultSet []flow.FilterResultSet
+		resultSet, err = t.shard.MemoryDatabase().Filter(t.metricID, t.fieldIDs, t.seriesIDs, timeRange)
+		if err != nil && !errors.Is(err, constants.ErrNotFound) {
+			return err
+		}
+		t.result.rs = append(t.result.rs, resultSet...)
 	}
-	t.result.rs = append(t.result.rs, resultSet...)
-	return nil
+	return err
 }
 
 // AfterRun invokes after memory data filtering, collects the memory data filtering stats
@@ -272,22 +300,42 @@ func newFileDataFilterTask(ctx *storageExecuteContext, shard tsdb.Shard,
 
 // Run executes file data filtering based on series ids and time range for each data family
 func (t *fileDataFilterTask) Run() error {
-	families := t.shard.GetDataFamilies(t.ctx.query.Interval.Type(), t.ctx.query.TimeRange)
-	if len(families) == 0 {
-		return nil
-	}
-	for idx := range families {
-		family := families[idx]
-		// execute data family search in background goroutine
-		resultSet, err := family.Filter(t.metricID, t.fieldIDs, t.seriesIDs, t.ctx.query.TimeRange)
-		if err != nil {
-			return err
+	for _, timeRange := range filterTimeRanges(t.ctx.query) {
+		families := t.shard.GetDataFamilies(t.ctx.query.Interval.Type(), timeRange)
+		for idx := range families {
+			family := families[idx]
+			// execute data family search in background goroutine
+			resultSet, err := family.Filter(t.metricID, t.fieldIDs, t.seriesIDs, timeRange)
+			if err != nil {
+				return err
+			}
+			t.result.rs = append(t.result.rs, resultSet...)
 		}
-		t.result.rs = append(t.result.rs, resultSet...)
 	}
 	return nil
 }
 
+// filterTimeRanges returns the time ranges for data filtering, if query by time buckets,
+// aligns the time buckets with data family then merges them, so that each data family is filtered only once.
+func filterTimeRanges(query *stmt.Query) []timeutil.TimeRange {
+	if len(query.TimeRanges) <= 1 {
+		return []timeutil.TimeRange{query.TimeRange}
+	}
+	calc := query.Interval.Calculator()
+	familyStartTime := func(timestamp int64) int64 {
+		segmentTime := calc.CalcSegmentTime(timestamp)
+		return calc.CalcFamilyStartTime(segmentTime, calc.CalcFamily(timestamp, segmentTime))
+	}
+	timeRanges := make([]timeutil.TimeRange, len(query.TimeRanges))
+	for idx, timeRange := range query.TimeRanges {
+		timeRanges[idx] = timeutil.TimeRange{
+			Start: familyStartTime(timeRange.Start),
+			End:   calc.CalcFamilyEndTime(familyStartTime(timeRange.End)),
+		}
+	}
+	return timeutil.MergeTimeRanges(timeRanges)
+}
+
 // AfterRun invokes after file data filtering, collects the file data filtering stats
 func (t *fileDataFilterTask) AfterRun() {
 	t.baseQueryTask.AfterRun()
@@ -379,9 +427,10 @@ func (t *buildGroupTask) Run() error {
 	if t.groupingCtx != nil {
 		// build group by data, grouped series: tags => series IDs
 		t.result.groupedSeries = t.groupingCtx.BuildGroup(t.highKey, t.container)
-	} else {
-		t.result.groupedSeries = map[string][]uint16{"": t.container.ToArray()}
+		// group by(especially group by *) maybe explode cardinality, checks max series limit
+		return t.ctx.addGroupedSeries(len(t.result.groupedSeries))
 	}
+	t.result.groupedSeries = map[string][]uint16{"": t.container.ToArray()}
 	return nil
 }
 
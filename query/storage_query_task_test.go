@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
@@ -39,11 +41,11 @@ func TestStoragePlanTask_Run(t *testing.T) {
 	plan := NewMockPlan(ctrl)
 	plan.EXPECT().Plan().Return(nil).AnyTimes()
 	// case 1: normal
-	task := newStoragePlanTask(newStorageExecuteContext(nil, &stmt.Query{}), plan)
+	task := newStoragePlanTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}), plan)
 	err := task.Run()
 	assert.NoError(t, err)
 	// case 2: explain track stats
-	task = newStoragePlanTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}), plan)
+	task = newStoragePlanTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{}), plan)
 	err = task.Run()
 	assert.NoError(t, err)
 }
@@ -53,7 +55,7 @@ func TestTagFilterTask_AfterRun(t *testing.T) {
 	defer ctrl.Finish()
 
 	tagSearch := NewMockTagSearch(ctrl)
-	task := newTagFilterTask(newStorageExecuteContext(nil, &stmt.Query{}), tagSearch)
+	task := newTagFilterTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}), tagSearch)
 	// case 1: tag filter err
 	tagSearch.EXPECT().Filter().Return(nil, fmt.Errorf("err"))
 	err := task.Run()
@@ -67,10 +69,16 @@ func TestTagFilterTask_AfterRun(t *testing.T) {
 	err = task.Run()
 	assert.NoError(t, err)
 	// case 4: explain case
-	task = newTagFilterTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}), tagSearch)
-	tagSearch.EXPECT().Filter().Return(map[string]*tagFilterResult{"test": nil}, nil)
+	ctx := newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{})
+	task = newTagFilterTask(ctx, tagSearch)
+	tagSearch.EXPECT().Filter().Return(map[string]*tagFilterResult{
+		"test":   nil,
+		"ip=~'1": {pushDown: true},
+		"ip=~.*": {pushDown: false},
+	}, nil)
 	err = task.Run()
 	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"ip=~'1": true, "ip=~.*": false}, ctx.stats.TagFilterPushDown)
 }
 
 func TestSeriesIDsSearchTask_Run(t *testing.T) {
@@ -84,7 +92,7 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 	indexDB := indexdb.NewMockIndexDatabase(ctrl)
 	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
 	result := roaring.New()
-	task := newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{}), shard, result)
+	task := newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}), shard, result)
 	// case 1: search err
 	indexDB.EXPECT().GetSeriesIDsForMetric(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	err := task.Run()
@@ -97,7 +105,7 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 	result.Clear()
 	// case 3: group by tag
 	indexDB.EXPECT().GetSeriesIDsForMetric(gomock.Any(), gomock.Any()).Return(roaring.New(), nil)
-	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{GroupBy: []string{"host"}}), shard, result)
+	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{GroupBy: []string{"host"}}, storageQueryConfig{}), shard, result)
 	err = task.Run()
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(0), result.GetCardinality())
@@ -109,7 +117,7 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 		return seriesSearch
 	}
 	seriesSearch.EXPECT().Search().Return(nil, fmt.Errorf("err"))
-	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query), shard, result)
+	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query, storageQueryConfig{}), shard, result)
 	err = task.Run()
 	assert.Error(t, err)
 	// case 5: has condition, return series ids
@@ -123,10 +131,87 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 	query = q.(*stmt.Query)
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil)
 	shard.EXPECT().ShardID().Return(int32(10))
-	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query), shard, result)
+	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query, storageQueryConfig{}), shard, result)
 	err = task.Run()
 	assert.NoError(t, err)
 	assert.Equal(t, roaring.BitmapOf(1, 2, 3), result)
+	result.Clear()
+	// case 7: sample series
+	seriesIDs := roaring.New()
+	seriesIDs.AddRange(1, 10001)
+	q, _ = sql.Parse("select f from cpu where ip<>'1.1.1.1'")
+	query = q.(*stmt.Query)
+	query.SampleRate = 0.1
+	seriesSearch.EXPECT().Search().Return(seriesIDs, nil)
+	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query, storageQueryConfig{}), shard, result)
+	err = task.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, sampleSeriesIDs(seriesIDs, 0.1), result)
+	assert.True(t, result.GetCardinality() < seriesIDs.GetCardinality())
+}
+
+func TestSeriesIDsSearchTask_Run_window(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	q, _ := sql.Parse("select f from cpu where ip<>'1.1.1.1'")
+	query := q.(*stmt.Query)
+	query.Window = timeutil.Interval(5 * timeutil.OneMinute)
+	ctx := newStorageExecuteContext(nil, query, storageQueryConfig{})
+	ctx.metricID = 10
+	ctx.tagFilterResult = map[string]*tagFilterResult{
+		"ip=1.1.1.1": {tagKey: 1, tagValueIDs: roaring.BitmapOf(1), pushDown: true},
+	}
+	// series 2 has ip 1.1.1.1, series 1 is active in query time range but not in the window
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(1)).Return(roaring.BitmapOf(2), nil)
+	shard.EXPECT().GetSeriesIDsForTagInTimeRange(uint32(10), uint32(1), query.TimeRange).Return(roaring.BitmapOf(2, 3), nil)
+	result := roaring.New()
+	task := newSeriesIDsSearchTask(ctx, shard, result)
+	err := task.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(3), result)
+}
+
+func TestSeriesIDsSearchTask_Run_analyze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	shard.EXPECT().ShardID().Return(int32(10)).AnyTimes()
+	q, _ := sql.Parse("explain analyze select f from cpu where host='a' and ip in ('1','2')")
+	query := q.(*stmt.Query)
+	assert.True(t, query.Analyze)
+	ctx := newStorageExecuteContext(nil, query, storageQueryConfig{})
+	ctx.setTagFilterResult(map[string]*tagFilterResult{
+		"host=a":      {tagKey: 1, tagValueIDs: roaring.BitmapOf(1)},
+		"ip in (1,2)": {tagKey: 2, tagValueIDs: roaring.BitmapOf(1, 2)},
+	})
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(roaring.BitmapOf(1, 2, 3, 4), nil)
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), gomock.Any()).Return(roaring.BitmapOf(3, 4, 5), nil)
+	result := roaring.New()
+	task := newSeriesIDsSearchTask(ctx, shard, result)
+	assert.NoError(t, task.Run())
+	assert.Equal(t, roaring.BitmapOf(3, 4), result)
+	// annotates the actual matched series of each tag filter
+	stats := ctx.QueryStats().Shards[10]
+	assert.Equal(t, uint64(2), stats.NumOfSeries)
+	assert.Len(t, stats.PredicateStats, 2)
+	assert.Equal(t, uint64(4), stats.PredicateStats["host=a"].Matched)
+	assert.Equal(t, uint64(3), stats.PredicateStats["ip in (1,2)"].Matched)
+
+	// explain without analyze
+	q, _ = sql.Parse("explain select f from cpu where host='a'")
+	ctx = newStorageExecuteContext(nil, q.(*stmt.Query), storageQueryConfig{})
+	ctx.setTagFilterResult(map[string]*tagFilterResult{"host=a": {tagKey: 1, tagValueIDs: roaring.BitmapOf(1)}})
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(roaring.BitmapOf(1, 2, 3, 4), nil)
+	task = newSeriesIDsSearchTask(ctx, shard, roaring.New())
+	assert.NoError(t, task.Run())
+	assert.Nil(t, ctx.QueryStats().Shards[10].PredicateStats)
 }
 
 func TestMemoryDataFilterTask_Run(t *testing.T) {
@@ -138,7 +223,7 @@ func TestMemoryDataFilterTask_Run(t *testing.T) {
 	shard.EXPECT().MemoryDatabase().Return(memDB).AnyTimes()
 	seriesIDs := roaring.BitmapOf(1, 2, 3)
 	result := &filterResultSet{}
-	task := newMemoryDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{}),
+	task := newMemoryDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}),
 		shard, 1, []field.ID{10}, seriesIDs, result)
 	// case 1: filter err
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
@@ -149,7 +234,7 @@ func TestMemoryDataFilterTask_Run(t *testing.T) {
 	err = task.Run()
 	assert.NoError(t, err)
 	// case 4: explain
-	task = newMemoryDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}),
+	task = newMemoryDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{}),
 		shard, 1, []field.ID{10}, seriesIDs, result)
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 	shard.EXPECT().ShardID().Return(int32(10))
@@ -157,6 +242,58 @@ func TestMemoryDataFilterTask_Run(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBuildGroupTask_Run_max_series(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	seriesIDs := roaring.BitmapOf(1, 2, 3)
+	groupingCtx := series.NewMockGroupingContext(ctrl)
+	groupingCtx.EXPECT().BuildGroup(gomock.Any(), gomock.Any()).
+		Return(map[string][]uint16{"a": {1}, "b": {2}}).AnyTimes()
+	ctx := newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{maxSeries: 3})
+	task := newBuildGroupTask(ctx, shard, groupingCtx, 0, seriesIDs.GetContainer(0), &groupedSeriesResult{})
+	assert.NoError(t, task.Run())
+	// 4 grouped series > 3
+	task = newBuildGroupTask(ctx, shard, groupingCtx, 1, seriesIDs.GetContainer(0), &groupedSeriesResult{})
+	assert.True(t, errors.Is(task.Run(), ErrTooManySeries))
+	// no limit
+	ctx = newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{})
+	for i := 0; i < 3; i++ {
+		task = newBuildGroupTask(ctx, shard, groupingCtx, uint16(i), seriesIDs.GetContainer(0), &groupedSeriesResult{})
+		assert.NoError(t, task.Run())
+	}
+}
+
+func TestMemoryDataFilterTask_Run_timeBuckets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	memDB := memdb.NewMockMemoryDatabase(ctrl)
+	shard.EXPECT().MemoryDatabase().Return(memDB).AnyTimes()
+	q, _ := sql.Parse("select f from cpu where time in " +
+		"('20190410 00:10:00'..'20190410 00:20:00', '20190410 00:40:00'..'20190410 00:50:00', " +
+		"'20190411 00:00:00'..'20190411 00:30:00')")
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	result := &filterResultSet{}
+	task := newMemoryDataFilterTask(newStorageExecuteContext(nil, query, storageQueryConfig{}),
+		shard, 1, []field.ID{10}, roaring.BitmapOf(1, 2, 3), result)
+	// buckets in same family filter once
+	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), timeutil.TimeRange{
+		Start: query.TimeRanges[0].Start - 10*timeutil.OneMinute,
+		End:   query.TimeRanges[0].Start - 10*timeutil.OneMinute + timeutil.OneHour - 1,
+	}).Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
+	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), timeutil.TimeRange{
+		Start: query.TimeRanges[2].Start,
+		End:   query.TimeRanges[2].Start + timeutil.OneHour - 1,
+	}).Return(nil, constants.ErrNotFound)
+	err := task.Run()
+	assert.Equal(t, constants.ErrNotFound, err)
+	assert.Len(t, result.rs, 1)
+}
+
 func TestFileDataFilterTask_Run(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -164,7 +301,7 @@ func TestFileDataFilterTask_Run(t *testing.T) {
 	shard := tsdb.NewMockShard(ctrl)
 	seriesIDs := roaring.BitmapOf(1, 2, 3)
 	result := &filterResultSet{}
-	task := newFileDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{}),
+	task := newFileDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}),
 		shard, 1, []field.ID{10}, seriesIDs, result)
 	// case 1: get empty family
 	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil)
@@ -184,7 +321,7 @@ func TestFileDataFilterTask_Run(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result.rs)
 	// case 4: explain
-	task = newFileDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}),
+	task = newFileDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{}),
 		shard, 1, []field.ID{10}, seriesIDs, result)
 	family.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
@@ -194,6 +331,40 @@ func TestFileDataFilterTask_Run(t *testing.T) {
 	assert.NotNil(t, result.rs)
 }
 
+func TestFileDataFilterTask_Run_timeBuckets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shard := tsdb.NewMockShard(ctrl)
+	q, _ := sql.Parse("select f from cpu where time in " +
+		"('20190410 00:00:00'..'20190410 01:00:00', '20190411 00:00:00'..'20190411 00:30:00')")
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	result := &filterResultSet{}
+	task := newFileDataFilterTask(newStorageExecuteContext(nil, query, storageQueryConfig{}),
+		shard, 1, []field.ID{10}, roaring.BitmapOf(1, 2, 3), result)
+	family1 := tsdb.NewMockDataFamily(ctrl)
+	family2 := tsdb.NewMockDataFamily(ctrl)
+	family3 := tsdb.NewMockDataFamily(ctrl)
+	// two disjoint buckets, union the result of each bucket
+	shard.EXPECT().GetDataFamilies(timeutil.Day, timeutil.TimeRange{
+		Start: query.TimeRanges[0].Start,
+		End:   query.TimeRanges[0].End + timeutil.OneHour - 1,
+	}).Return([]tsdb.DataFamily{family1, family2})
+	shard.EXPECT().GetDataFamilies(timeutil.Day, timeutil.TimeRange{
+		Start: query.TimeRanges[1].Start,
+		End:   query.TimeRanges[1].Start + timeutil.OneHour - 1,
+	}).Return([]tsdb.DataFamily{family3})
+	family1.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
+	family2.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	family3.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
+	err := task.Run()
+	assert.NoError(t, err)
+	assert.Len(t, result.rs, 2)
+}
+
 func TestGroupingContextFindTask_Run(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -203,7 +374,7 @@ func TestGroupingContextFindTask_Run(t *testing.T) {
 	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
 	seriesIDs := roaring.BitmapOf(1, 2, 3)
 	result := &groupingResult{}
-	task := newGroupingContextFindTask(newStorageExecuteContext(nil, &stmt.Query{}),
+	task := newGroupingContextFindTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}),
 		shard, nil, seriesIDs, result)
 	// case 1: get grouping context err
 	indexDB.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
@@ -215,7 +386,7 @@ func TestGroupingContextFindTask_Run(t *testing.T) {
 	assert.NoError(t, err)
 	// case 3: explain
 	indexDB.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(nil, nil)
-	task = newGroupingContextFindTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}),
+	task = newGroupingContextFindTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{}),
 		shard, nil, seriesIDs, result)
 	shard.EXPECT().ShardID().Return(int32(10))
 	err = task.Run()
@@ -229,7 +400,7 @@ func TestBuildGroupTask_Run(t *testing.T) {
 	shard := tsdb.NewMockShard(ctrl)
 	result := &groupedSeriesResult{}
 	seriesIDs := roaring.BitmapOf(1, 2, 3)
-	task := newBuildGroupTask(newStorageExecuteContext(nil, &stmt.Query{}),
+	task := newBuildGroupTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}),
 		shard, nil, 0, seriesIDs.GetContainer(0), result)
 	// case 1: no group
 	err := task.Run()
@@ -237,12 +408,12 @@ func TestBuildGroupTask_Run(t *testing.T) {
 	// case 2: has grouping
 	groupingCtx := series.NewMockGroupingContext(ctrl)
 	groupingCtx.EXPECT().BuildGroup(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-	task = newBuildGroupTask(newStorageExecuteContext(nil, &stmt.Query{}),
+	task = newBuildGroupTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}),
 		shard, groupingCtx, 0, seriesIDs.GetContainer(0), result)
 	err = task.Run()
 	assert.NoError(t, err)
 	// case 3: explain
-	task = newBuildGroupTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}),
+	task = newBuildGroupTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{}),
 		shard, groupingCtx, 0, seriesIDs.GetContainer(0), result)
 	shard.EXPECT().ShardID().Return(int32(10))
 	err = task.Run()
@@ -256,14 +427,14 @@ func TestDataLoadTask_Run(t *testing.T) {
 	shard := tsdb.NewMockShard(ctrl)
 	qf := flow.NewMockStorageQueryFlow(ctrl)
 	rs := flow.NewMockFilterResultSet(ctrl)
-	task := newDataLoadTask(newStorageExecuteContext(nil, &stmt.Query{}),
+	task := newDataLoadTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}),
 		shard, qf, rs, nil, 1, nil, 0, newSeriesResultScanner(1).(*loadSeriesResult))
 	rs.EXPECT().Load(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	// case 1: load data
 	err := task.Run()
 	assert.NoError(t, err)
 	// case 2: explain
-	task = newDataLoadTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}),
+	task = newDataLoadTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{}),
 		shard, qf, rs, nil, 1, nil, 0, newSeriesResultScanner(1).(*loadSeriesResult))
 	shard.EXPECT().ShardID().Return(int32(10)).AnyTimes()
 	rs.EXPECT().Identifier().Return("memory")
@@ -281,14 +452,14 @@ func TestCollectTagValuesTask_Run(t *testing.T) {
 	meta := metadb.NewMockMetadata(ctrl)
 	tagMeta := metadb.NewMockTagMetadata(ctrl)
 	meta.EXPECT().TagMetadata().Return(tagMeta).AnyTimes()
-	task := newCollectTagValuesTask(newStorageExecuteContext(nil, &stmt.Query{}),
+	task := newCollectTagValuesTask(newStorageExecuteContext(nil, &stmt.Query{}, storageQueryConfig{}),
 		meta, tag.Meta{ID: 10}, roaring.BitmapOf(1, 2), nil)
 	// case 1: collect tag values
 	tagMeta.EXPECT().CollectTagValues(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	err := task.Run()
 	assert.NoError(t, err)
 	// case 2: explain
-	task = newCollectTagValuesTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}),
+	task = newCollectTagValuesTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}, storageQueryConfig{}),
 		meta, tag.Meta{ID: 10}, roaring.BitmapOf(1, 2), nil)
 	err = task.Run()
 	assert.NoError(t, err)
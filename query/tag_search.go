@@ -1,10 +1,12 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/metadb"
 )
@@ -15,6 +17,11 @@ import (
 type tagFilterResult struct {
 	tagKey      uint32
 	tagValueIDs *roaring.Bitmap
+	pushDown    bool // if resolved by tag index lookup directly, else by residual filter which scans all tag values
+	// namespace/metric name of tag existence filter, the negation(tag = null) matches the series of metric lacking the tag
+	namespace, metricName string
+	// tag key of tag existence filter not found, no series has the tag
+	tagNotFound bool
 }
 
 // TagSearch represents the tag filtering by tag filter expr
@@ -67,20 +74,55 @@ func (s *tagSearch) findTagValueIDsByExpr(expr stmt.Expr) {
 	switch expr := expr.(type) {
 	case stmt.TagFilter:
 		tagKeyID, err := s.getTagKeyID(expr.TagKey())
+		_, exists := expr.(*stmt.ExistsExpr)
+		if exists && errors.Is(err, constants.ErrNotFound) {
+			// no series has the tag, tag != null matches nothing, tag = null matches all series of metric
+			s.result[expr.Rewrite()] = &tagFilterResult{
+				pushDown:    true,
+				namespace:   s.namespace,
+				metricName:  s.metricName,
+				tagNotFound: true,
+			}
+			return
+		}
 		if err != nil {
 			s.err = err
 			return
 		}
+		if exists {
+			// tag existence matches all series of tag key, no tag value lookup
+			s.result[expr.Rewrite()] = &tagFilterResult{
+				tagKey:     tagKeyID,
+				pushDown:   true,
+				namespace:  s.namespace,
+				metricName: s.metricName,
+			}
+			return
+		}
+		if in, ok := expr.(*stmt.InExpr); ok && len(in.Values) == 0 {
+			// empty in matches nothing, keep tag key for negation which matches all series of tag key
+			s.result[expr.Rewrite()] = &tagFilterResult{
+				tagKey:      tagKeyID,
+				tagValueIDs: roaring.New(),
+				pushDown:    true,
+			}
+			return
+		}
 		tagValueIDs, err := s.metadata.TagMetadata().FindTagValueDsByExpr(tagKeyID, expr)
 		if err != nil {
 			s.err = err
 			return
 		}
+		if allOf, ok := expr.(*stmt.AllOfExpr); ok && !containsAllValues(allOf, tagValueIDs) {
+			// series cannot have all values if some value not exist
+			return
+		}
 		if tagValueIDs != nil && !tagValueIDs.IsEmpty() {
 			// save atomic tag filter result
 			s.result[expr.Rewrite()] = &tagFilterResult{
 				tagKey:      tagKeyID,
 				tagValueIDs: tagValueIDs,
+				pushDown:    metadb.IsPushDown(expr),
 			}
 		}
 	case *stmt.ParenExpr:
@@ -98,6 +140,18 @@ func (s *tagSearch) findTagValueIDsByExpr(expr stmt.Expr) {
 	}
 }
 
+// containsAllValues checks if all distinct values of all of expr are found, each tag value has an unique id
+func containsAllValues(expr *stmt.AllOfExpr, tagValueIDs *roaring.Bitmap) bool {
+	if tagValueIDs == nil {
+		return false
+	}
+	values := make(map[string]struct{}, len(expr.Values))
+	for _, value := range expr.Values {
+		values[value] = struct{}{}
+	}
+	return tagValueIDs.GetCardinality() >= uint64(len(values))
+}
+
 // getTagKeyID returns the tag key id by tag key
 func (s *tagSearch) getTagKeyID(tagKey string) (uint32, error) {
 	tagKeyID, ok := s.tags[tagKey]
@@ -8,6 +8,7 @@ import (
 	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/metadb"
@@ -81,6 +82,124 @@ func TestTagSearch_Filter(t *testing.T) {
 	assert.Len(t, resultSet, 0)
 }
 
+func TestTagSearch_Filter_pushDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tagMeta := metadb.NewMockTagMetadata(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(1), nil).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMeta).AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	tagMeta.EXPECT().FindTagValueDsByExpr(gomock.Any(), gomock.Any()).Return(roaring.BitmapOf(1, 2, 3), nil).AnyTimes()
+
+	q, _ := sql.Parse("select f from cpu where ip=~'192.168.*' and host=~'.*-web'")
+	query := q.(*stmt.Query)
+	search := newTagSearch("ns", "cpu", query.Condition, metadata)
+	resultSet, err := search.Filter()
+	assert.NoError(t, err)
+	assert.Len(t, resultSet, 2)
+	// regex with literal prefix is resolved by tag index
+	assert.True(t, resultSet[(&stmt.RegexExpr{Key: "ip", Regexp: "192.168.*"}).Rewrite()].pushDown)
+	// regex without literal prefix needs residual filter
+	assert.False(t, resultSet[(&stmt.RegexExpr{Key: "host", Regexp: ".*-web"}).Rewrite()].pushDown)
+}
+
+func TestTagSearch_Filter_allOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tagMeta := metadb.NewMockTagMetadata(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(1), nil).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMeta).AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+
+	allOf := &stmt.AllOfExpr{Key: "labels", Values: []string{"gpu", "nvme", "gpu"}}
+	tagMeta.EXPECT().FindTagValueDsByExpr(gomock.Any(), allOf).Return(roaring.BitmapOf(1, 2), nil)
+	resultSet, err := newTagSearch("ns", "cpu", allOf, metadata).Filter()
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1, 2), resultSet[allOf.Rewrite()].tagValueIDs)
+	// some value not exist, matches nothing
+	tagMeta.EXPECT().FindTagValueDsByExpr(gomock.Any(), allOf).Return(roaring.BitmapOf(1), nil)
+	resultSet, err = newTagSearch("ns", "cpu", allOf, metadata).Filter()
+	assert.NoError(t, err)
+	assert.Empty(t, resultSet)
+	tagMeta.EXPECT().FindTagValueDsByExpr(gomock.Any(), allOf).Return(nil, nil)
+	resultSet, err = newTagSearch("ns", "cpu", allOf, metadata).Filter()
+	assert.NoError(t, err)
+	assert.Empty(t, resultSet)
+}
+
+func TestTagSearch_Filter_emptyIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tagMeta := metadb.NewMockTagMetadata(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(1), nil).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMeta).AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+
+	// empty in keeps tag key without finding tag values
+	in := &stmt.InExpr{Key: "host"}
+	resultSet, err := newTagSearch("ns", "cpu", &stmt.NotExpr{Expr: in}, metadata).Filter()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), resultSet[in.Rewrite()].tagKey)
+	assert.True(t, resultSet[in.Rewrite()].tagValueIDs.IsEmpty())
+}
+
+func TestTagSearch_Filter_exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tagMeta := metadb.NewMockTagMetadata(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadataDB.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(1), nil).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMeta).AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+
+	// tag existence keeps tag key and metric without finding tag values
+	for _, sqlStr := range []string{"select f from cpu where host != null", "select f from cpu where host = null"} {
+		q, err := sql.Parse(sqlStr)
+		assert.NoError(t, err)
+		resultSet, err := newTagSearch("ns", "cpu", q.(*stmt.Query).Condition, metadata).Filter()
+		assert.NoError(t, err)
+		assert.Equal(t, &tagFilterResult{tagKey: 1, pushDown: true, namespace: "ns", metricName: "cpu"},
+			resultSet[(&stmt.ExistsExpr{Key: "host"}).Rewrite()])
+	}
+}
+
+func TestTagSearch_Filter_exists_tagNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadataDB.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(0), constants.ErrNotFound).AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+
+	// tag key not found, no series has the tag
+	for _, sqlStr := range []string{"select f from cpu where host != null", "select f from cpu where host = null"} {
+		q, err := sql.Parse(sqlStr)
+		assert.NoError(t, err)
+		resultSet, err := newTagSearch("ns", "cpu", q.(*stmt.Query).Condition, metadata).Filter()
+		assert.NoError(t, err)
+		assert.Equal(t, &tagFilterResult{pushDown: true, namespace: "ns", metricName: "cpu", tagNotFound: true},
+			resultSet[(&stmt.ExistsExpr{Key: "host"}).Rewrite()])
+	}
+	// tag value filter still fails if tag key not found
+	q, err := sql.Parse("select f from cpu where host = 'a'")
+	assert.NoError(t, err)
+	resultSet, err := newTagSearch("ns", "cpu", q.(*stmt.Query).Condition, metadata).Filter()
+	assert.Equal(t, constants.ErrNotFound, err)
+	assert.Nil(t, resultSet)
+}
+
 func TestTagSearch_Filter_err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -148,16 +267,14 @@ func TestTagSearch_Filter_Complex(t *testing.T) {
 		Return(tagValueIDs, nil)
 	tagMeta.EXPECT().FindTagValueDsByExpr(gomock.Any(), &stmt.EqualsExpr{Key: "region", Value: "sh"}).
 		Return(tagValueIDs, nil)
-	tagMeta.EXPECT().FindTagValueDsByExpr(gomock.Any(), &stmt.EqualsExpr{Key: "path", Value: "/data"}).
-		Return(tagValueIDs, nil)
-	tagMeta.EXPECT().FindTagValueDsByExpr(gomock.Any(), &stmt.EqualsExpr{Key: "path", Value: "/home"}).
+	// path='/data' or path='/home' is collapsed into in expr
+	tagMeta.EXPECT().FindTagValueDsByExpr(gomock.Any(), &stmt.InExpr{Key: "path", Values: []string{"/data", "/home"}}).
 		Return(tagValueIDs, nil)
 	resultSet, err := search.Filter()
 	assert.NoError(t, err)
 	assert.NotNil(t, resultSet)
-	assert.Len(t, resultSet, 4)
+	assert.Len(t, resultSet, 3)
 	assert.Equal(t, tagValueIDs, resultSet[(&stmt.InExpr{Key: "ip", Values: []string{"1.1.1.1", "2.2.2.2"}}).Rewrite()].tagValueIDs)
 	assert.Equal(t, tagValueIDs, resultSet[(&stmt.EqualsExpr{Key: "region", Value: "sh"}).Rewrite()].tagValueIDs)
-	assert.Equal(t, tagValueIDs, resultSet[(&stmt.EqualsExpr{Key: "path", Value: "/data"}).Rewrite()].tagValueIDs)
-	assert.Equal(t, tagValueIDs, resultSet[(&stmt.EqualsExpr{Key: "path", Value: "/home"}).Rewrite()].tagValueIDs)
+	assert.Equal(t, tagValueIDs, resultSet[(&stmt.InExpr{Key: "path", Values: []string{"/data", "/home"}}).Rewrite()].tagValueIDs)
 }
@@ -0,0 +1,85 @@
+package query
+
+import (
+	"strconv"
+
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// compareOp represents the compare operator of value predicate
+type compareOp int
+
+// Defines all compare operators of value predicate
+const (
+	opEqual compareOp = iota + 1
+	opGreater
+	opGreaterEqual
+	opLess
+	opLessEqual
+)
+
+// compareFilter implements series.ValueFilter, compares the field value with the literal
+type compareFilter struct {
+	op    compareOp
+	value float64
+}
+
+// Match returns if the value qualifies the predicate
+func (f *compareFilter) Match(value float64) bool {
+	switch f.op {
+	case opEqual:
+		return value == f.value
+	case opGreater:
+		return value > f.value
+	case opGreaterEqual:
+		return value >= f.value
+	case opLess:
+		return value < f.value
+	case opLessEqual:
+		return value <= f.value
+	default:
+		return false
+	}
+}
+
+// valueFilters implements series.ValueFilter, matches the value if all filters are matched(and)
+type valueFilters []series.ValueFilter
+
+// Match returns if the value qualifies all predicates
+func (fs valueFilters) Match(value float64) bool {
+	for _, f := range fs {
+		if !f.Match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// newValueFilter creates the value filter by compare expr of field,
+// returns field name/value filter, if expr isn't a compare expr with numeric value return false.
+func newValueFilter(expr stmt.Expr) (fieldName string, filter series.ValueFilter, ok bool) {
+	var (
+		op    compareOp
+		value string
+	)
+	switch e := expr.(type) {
+	case *stmt.EqualsExpr:
+		fieldName, op, value = e.Key, opEqual, e.Value
+	case *stmt.GreaterExpr:
+		fieldName, op, value = e.Key, opGreater, e.Value
+	case *stmt.GreaterEqualExpr:
+		fieldName, op, value = e.Key, opGreaterEqual, e.Value
+	case *stmt.LessExpr:
+		fieldName, op, value = e.Key, opLess, e.Value
+	case *stmt.LessEqualExpr:
+		fieldName, op, value = e.Key, opLessEqual, e.Value
+	default:
+		return "", nil, false
+	}
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "", nil, false
+	}
+	return fieldName, &compareFilter{op: op, value: val}, true
+}
@@ -0,0 +1,45 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestValueFilter(t *testing.T) {
+	cases := []struct {
+		expr     stmt.Expr
+		matched  []float64
+		filtered []float64
+	}{
+		{&stmt.EqualsExpr{Key: "f", Value: "90"}, []float64{90}, []float64{89, 91}},
+		{&stmt.GreaterExpr{Key: "f", Value: "90"}, []float64{90.5, 91}, []float64{89, 90}},
+		{&stmt.GreaterEqualExpr{Key: "f", Value: "90"}, []float64{90, 91}, []float64{89}},
+		{&stmt.LessExpr{Key: "f", Value: "90"}, []float64{89}, []float64{90, 91}},
+		{&stmt.LessEqualExpr{Key: "f", Value: "-1.5"}, []float64{-1.5, -2}, []float64{0}},
+	}
+	for _, c := range cases {
+		fieldName, filter, ok := newValueFilter(c.expr)
+		assert.True(t, ok)
+		assert.Equal(t, "f", fieldName)
+		for _, value := range c.matched {
+			assert.True(t, filter.Match(value))
+		}
+		for _, value := range c.filtered {
+			assert.False(t, filter.Match(value))
+		}
+	}
+	// not compare expr or value not numeric
+	_, _, ok := newValueFilter(&stmt.InExpr{Key: "f", Values: []string{"1"}})
+	assert.False(t, ok)
+	_, _, ok = newValueFilter(&stmt.GreaterExpr{Key: "host", Value: "b"})
+	assert.False(t, ok)
+
+	assert.False(t, (&compareFilter{}).Match(1))
+	filters := valueFilters{&compareFilter{op: opGreater, value: 90}, &compareFilter{op: opLessEqual, value: 95}}
+	assert.True(t, filters.Match(95))
+	assert.False(t, filters.Match(90))
+	assert.False(t, filters.Match(96))
+}
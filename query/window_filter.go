@@ -0,0 +1,34 @@
+package query
+
+import (
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// windowFilter represents the series filter of shard for windowed query,
+// the series ids of tag key(the universe of negation) only include the series which have data in the window,
+// so that the series only has data out of the window doesn't match the negation(!=, not in etc.).
+type windowFilter struct {
+	series.Filter
+	shard     tsdb.Shard
+	metricID  uint32
+	timeRange timeutil.TimeRange
+}
+
+// newWindowFilter creates the series filter of shard for the window(sub-range of query time range)
+func newWindowFilter(shard tsdb.Shard, metricID uint32, timeRange timeutil.TimeRange) series.Filter {
+	return &windowFilter{
+		Filter:    shard.IndexDatabase(),
+		shard:     shard,
+		metricID:  metricID,
+		timeRange: timeRange,
+	}
+}
+
+// GetSeriesIDsForTag gets the series ids of tag key which have data in the window
+func (f *windowFilter) GetSeriesIDsForTag(tagKeyID uint32) (*roaring.Bitmap, error) {
+	return f.shard.GetSeriesIDsForTagInTimeRange(f.metricID, tagKeyID, f.timeRange)
+}
@@ -0,0 +1,34 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/indexdb"
+)
+
+func TestWindowFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeRange := timeutil.TimeRange{Start: 10, End: 20}
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	filter := newWindowFilter(shard, 10, timeRange)
+	// series ids of tag key in window
+	shard.EXPECT().GetSeriesIDsForTagInTimeRange(uint32(10), uint32(1), timeRange).Return(roaring.BitmapOf(2, 3), nil)
+	seriesIDs, err := filter.GetSeriesIDsForTag(1)
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(2, 3), seriesIDs)
+	// series ids of tag value from index
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(1)).Return(roaring.BitmapOf(1, 2), nil)
+	seriesIDs, err = filter.GetSeriesIDsByTagValueIDs(1, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1, 2), seriesIDs)
+}
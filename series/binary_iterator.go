@@ -61,6 +61,8 @@ type BinaryIterator struct {
 	reader    *stream.Reader
 	fieldIt   *BinaryFieldIterator
 	data      []byte
+
+	err error // field data is corrupted, stops the iteration
 }
 
 func NewIterator(fieldName field.Name, data []byte) *BinaryIterator {
@@ -73,6 +75,7 @@ func (b *BinaryIterator) Reset(fieldName field.Name, data []byte) {
 	b.fieldName = fieldName
 	b.reader.Reset(data)
 	b.fieldType = field.Type(b.reader.ReadByte())
+	b.err = nil
 }
 
 func (b *BinaryIterator) FieldName() field.Name {
@@ -84,22 +87,34 @@ func (b *BinaryIterator) FieldType() field.Type {
 }
 
 func (b *BinaryIterator) HasNext() bool {
-	return !b.reader.Empty()
+	return b.err == nil && !b.reader.Empty()
+}
+
+// Error returns the error if the field data is corrupted(e.g. checksum mismatch), the iteration is stopped
+func (b *BinaryIterator) Error() error {
+	return b.err
 }
 
 func (b *BinaryIterator) Next() (startTime int64, fieldIt FieldIterator) {
 	startTime = b.reader.ReadVarint64()
-	aggType := field.AggType(b.reader.ReadByte())
-	length := b.reader.ReadVarint32()
-	if length <= 0 {
+	aggType, blocks, err := readFieldBlocks(b.reader)
+	if err != nil {
+		b.err = fmt.Errorf("read field data of %s: %w", b.fieldName, err)
+		return startTime, nil
+	}
+	if len(blocks) == 0 {
 		return
 	}
-
-	data := b.reader.ReadBytes(int(length))
+	if aggType.IsState() {
+		// partial state of aggregator is stored in one block
+		return startTime, &binaryStateIterator{aggType: aggType, state: blocks[0]}
+	}
+	// chained blocks are decoded after the first block
 	if b.fieldIt == nil {
-		b.fieldIt = NewFieldIterator(aggType, encoding.NewTSDDecoder(data))
+		b.fieldIt = NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
+		b.fieldIt.blocks = blocks[1:]
 	} else {
-		b.fieldIt.reset(aggType, data)
+		b.fieldIt.reset(aggType, blocks[0], blocks[1:]...)
 	}
 	fieldIt = b.fieldIt
 	return
@@ -115,6 +130,7 @@ func (b *BinaryIterator) MarshalBinary() ([]byte, error) {
 type BinaryFieldIterator struct {
 	aggType field.AggType
 	tsd     *encoding.TSDDecoder
+	blocks  [][]byte // remaining chained blocks, decodes them after current block
 }
 
 // NewFieldIterator create field iterator based on binary data
@@ -126,9 +142,29 @@ func NewFieldIterator(aggType field.AggType, tsd *encoding.TSDDecoder) *BinaryFi
 	return it
 }
 
-func (it *BinaryFieldIterator) reset(aggType field.AggType, data []byte) {
+func (it *BinaryFieldIterator) reset(aggType field.AggType, data []byte, chained ...[]byte) {
 	it.aggType = aggType
 	it.tsd.Reset(data)
+	it.blocks = chained
+}
+
+// nextBlock switches the decoder to next chained block, returns false if no more block
+func (it *BinaryFieldIterator) nextBlock() bool {
+	if len(it.blocks) == 0 {
+		return false
+	}
+	it.tsd.Reset(it.blocks[0])
+	it.blocks = it.blocks[1:]
+	return true
+}
+
+// SkipBefore skips the whole chained blocks whose end slot is before the slot without decoding the values
+func (it *BinaryFieldIterator) SkipBefore(slot uint16) {
+	for it.tsd.EndTime() < slot {
+		if !it.nextBlock() {
+			return
+		}
+	}
 }
 
 func (it *BinaryFieldIterator) AggType() field.AggType {
@@ -139,12 +175,17 @@ func (it *BinaryFieldIterator) HasNext() bool {
 	if it.tsd.Error() != nil {
 		return false
 	}
-	for it.tsd.Next() {
-		if it.tsd.HasValue() {
-			return true
+	for {
+		for it.tsd.Next() {
+			if it.tsd.HasValue() {
+				return true
+			}
+		}
+		// stitches the chained blocks transparently
+		if it.tsd.Error() != nil || !it.nextBlock() {
+			return false
 		}
 	}
-	return false
 }
 
 func (it *BinaryFieldIterator) Next() (timeSlot int, value float64) {
@@ -157,3 +198,33 @@ func (it *BinaryFieldIterator) Next() (timeSlot int, value float64) {
 func (it *BinaryFieldIterator) MarshalBinary() ([]byte, error) {
 	return nil, fmt.Errorf("not support")
 }
+
+//////////////////////////////////////////////////////
+// binaryStateIterator implements StateFieldIterator
+//////////////////////////////////////////////////////
+type binaryStateIterator struct {
+	aggType field.AggType
+	state   []byte
+}
+
+func (it *binaryStateIterator) AggType() field.AggType {
+	return it.aggType
+}
+
+// HasNext returns false, the values of time slots are computed by the aggregator which merges the state
+func (it *binaryStateIterator) HasNext() bool {
+	return false
+}
+
+func (it *binaryStateIterator) Next() (timeSlot int, value float64) {
+	return -1, 0
+}
+
+// State returns the serialized partial state
+func (it *binaryStateIterator) State() []byte {
+	return it.state
+}
+
+func (it *binaryStateIterator) MarshalBinary() ([]byte, error) {
+	return MarshalFieldBlocks(it.aggType, [][]byte{it.state})
+}
@@ -1,6 +1,7 @@
 package series
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -79,10 +80,12 @@ func TestBinaryIterator(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, data, data2)
 
+	// empty field data
 	writer = stream.NewBufferWriter(nil)
 	writer.PutByte(byte(field.SumField))
 	writer.PutVarint64(10)
-	writer.PutVarint32(int32(0))
+	d, _ = MarshalFieldBlocks(field.Sum, nil)
+	writer.PutBytes(d)
 	data, _ = writer.Bytes()
 	it = NewIterator("f1", data)
 	assert.True(t, it.HasNext())
@@ -90,18 +93,73 @@ func TestBinaryIterator(t *testing.T) {
 	assert.Equal(t, int64(10), startTime)
 	assert.Nil(t, fIt)
 	assert.False(t, it.HasNext())
+	assert.NoError(t, it.Error())
 }
 
-func TestBinaryFieldIterator(t *testing.T) {
+func TestBinaryIterator_corrupted(t *testing.T) {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(byte(field.SumField))
 	d := buildFieldIterator()
-	reader := stream.NewReader(d)
-	aggType := field.AggType(reader.ReadByte())
-	length := reader.ReadVarint32()
-	data := reader.ReadBytes(int(length))
-	it := NewFieldIterator(aggType, encoding.NewTSDDecoder(data))
+	d[3] ^= 0xFF // flips a byte of block payload
+	writer.PutVarint64(10)
+	writer.PutBytes(d)
+	writer.PutVarint64(11)
+	writer.PutBytes(buildFieldIterator())
+	data, _ := writer.Bytes()
+
+	it := NewIterator("f1", data)
+	assert.True(t, it.HasNext())
+	startTime, fIt := it.Next()
+	assert.Equal(t, int64(10), startTime)
+	assert.Nil(t, fIt)
+	// stops iteration instead of returning wrong data
+	assert.False(t, it.HasNext())
+	assert.True(t, errors.Is(it.Error(), ErrFieldBlockChecksum))
+
+	// reset clears the error
+	it.Reset("f1", data[:1])
+	assert.NoError(t, it.Error())
+}
+
+func TestBinaryIterator_state(t *testing.T) {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(byte(field.SumField))
+	writer.PutVarint64(10)
+	d, _ := MarshalFieldBlocks(field.DistinctCount, [][]byte{{1, 2, 3}})
+	writer.PutBytes(d)
+	writer.PutVarint64(11)
+	writer.PutBytes(buildFieldIterator())
+	data, _ := writer.Bytes()
+
+	it := NewIterator("f1", data)
+	assert.True(t, it.HasNext())
+	startTime, fIt := it.Next()
+	assert.Equal(t, int64(10), startTime)
+	stateIt, ok := fIt.(StateFieldIterator)
+	assert.True(t, ok)
+	assert.Equal(t, field.DistinctCount, stateIt.AggType())
+	assert.Equal(t, []byte{1, 2, 3}, stateIt.State())
+	assert.False(t, stateIt.HasNext())
+	slot, _ := stateIt.Next()
+	assert.Equal(t, -1, slot)
+	stateData, err := stateIt.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, d, stateData)
+	// values of time slots are decoded after state
+	assert.True(t, it.HasNext())
+	startTime, fIt = it.Next()
+	assert.Equal(t, int64(11), startTime)
+	assertFieldIterator(t, fIt)
+	assert.False(t, it.HasNext())
+}
+
+func TestBinaryFieldIterator(t *testing.T) {
+	aggType, blocks, err := UnmarshalFieldBlocks(buildFieldIterator())
+	assert.NoError(t, err)
+	it := NewFieldIterator(aggType, encoding.NewTSDDecoder(blocks[0]))
 	assertFieldIterator(t, it)
 
-	_, err := it.MarshalBinary()
+	_, err = it.MarshalBinary()
 	assert.Error(t, err)
 }
 
@@ -115,16 +173,12 @@ func assertFieldIterator(t *testing.T, it FieldIterator) {
 }
 
 func buildFieldIterator() []byte {
-	writer := stream.NewBufferWriter(nil)
 	encoder := encoding.NewTSDEncoder(10)
 	encoder.AppendTime(bit.Zero)
 	encoder.AppendTime(bit.Zero)
 	encoder.AppendTime(bit.One)
 	encoder.AppendValue(math.Float64bits(10.0))
 	data, _ := encoder.Bytes()
-	writer.PutByte(byte(field.Sum))
-	writer.PutVarint32(int32(len(data)))
-	writer.PutBytes(data)
-	d, _ := writer.Bytes()
+	d, _ := MarshalFieldBlocks(field.Sum, [][]byte{data})
 	return d
 }
@@ -21,3 +21,7 @@ var ErrWrongFieldType = errors.New("field type is wrong")
 // ErrResetVersionUnavailable is the error returned by tsdb when
 // the immutable tagIndex has not been flushed yet.
 var ErrResetVersionUnavailable = errors.New("reset version unavailable")
+
+// ErrInvalidFieldAlias is the error returned by tsdb when
+// field alias is empty, same as field name or makes an alias chain.
+var ErrInvalidFieldAlias = errors.New("invalid field alias")
@@ -3,11 +3,13 @@ package field
 import "math"
 
 var (
-	sumAggregator     = sumAgg{aggType: Sum}
-	countAggregator   = sumAgg{aggType: Count}
-	minAggregator     = minAgg{aggType: Min}
-	maxAggregator     = maxAgg{aggType: Max}
-	replaceAggregator = replaceAgg{aggType: Replace}
+	sumAggregator        = sumAgg{aggType: Sum}
+	countAggregator      = sumAgg{aggType: Count}
+	minAggregator        = minAgg{aggType: Min}
+	maxAggregator        = maxAgg{aggType: Max}
+	replaceAggregator    = replaceAgg{aggType: Replace}
+	increaseAggregator   = sumAgg{aggType: Increase}
+	derivativeAggregator = sumAgg{aggType: NonNegativeDerivative}
 )
 
 // AggFunc returns aggregator function by given func type
@@ -23,11 +25,28 @@ func (t AggType) AggFunc() AggFunc {
 		return maxAggregator
 	case Replace:
 		return replaceAggregator
+	case Increase:
+		// increases of series are summed
+		return increaseAggregator
+	case NonNegativeDerivative:
+		// derivatives of series are summed
+		return derivativeAggregator
 	default:
 		return nil
 	}
 }
 
+// IsState returns if the agg type carries the serialized partial state of aggregator(e.g. sketch of distinct count),
+// which cannot be folded by agg func, the field data of state is merged by the aggregator of agg type.
+func (t AggType) IsState() bool {
+	switch t {
+	case DistinctCount, MaxTime, MinTime, CountAll:
+		return true
+	default:
+		return false
+	}
+}
+
 // AggFunc represents field's aggregator function for int64 or float64 value
 type AggFunc interface {
 	// Aggregate aggregates two float64 values into one
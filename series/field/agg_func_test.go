@@ -13,6 +13,20 @@ func TestGetAggFunc(t *testing.T) {
 	assert.NotNil(t, Count.AggFunc())
 	assert.NotNil(t, Replace.AggFunc())
 	assert.Nil(t, AggType(99).AggFunc())
+	assert.Nil(t, DistinctCount.AggFunc())
+	assert.Nil(t, Sample.AggFunc())
+	assert.Equal(t, Increase, Increase.AggFunc().AggType())
+	assert.Equal(t, 3.0, Increase.AggFunc().Aggregate(1, 2))
+	assert.Equal(t, NonNegativeDerivative, NonNegativeDerivative.AggFunc().AggType())
+}
+
+func TestAggType_IsState(t *testing.T) {
+	assert.True(t, DistinctCount.IsState())
+	assert.True(t, MaxTime.IsState())
+	assert.True(t, MinTime.IsState())
+	assert.True(t, CountAll.IsState())
+	assert.False(t, Sum.IsState())
+	assert.False(t, Replace.IsState())
 }
 
 func TestSumAgg(t *testing.T) {
@@ -0,0 +1,67 @@
+package field
+
+import (
+	"fmt"
+)
+
+// ConflictPolicy represents the policy of handling the write whose field type conflicts with the existing field,
+// e.g. a field is written as sum, then is written as gauge.
+type ConflictPolicy uint8
+
+// Defines all conflict policies of field type
+const (
+	// StrictConflict rejects the conflicting write(default)
+	StrictConflict ConflictPolicy = iota
+	// CoerceConflict coerces the value to the existing field type if possible, else rejects it
+	CoerceConflict
+	// VersionConflict writes the value into a new versioned field of the conflicting type
+	VersionConflict
+)
+
+// String returns the string value of conflict policy
+func (p ConflictPolicy) String() string {
+	switch p {
+	case CoerceConflict:
+		return "coerce"
+	case VersionConflict:
+		return "version"
+	default:
+		return "strict"
+	}
+}
+
+// ParseConflictPolicy parses the conflict policy by string value(strict/coerce/version), empty value means strict
+func ParseConflictPolicy(policy string) (ConflictPolicy, error) {
+	switch policy {
+	case "", "strict":
+		return StrictConflict, nil
+	case "coerce":
+		return CoerceConflict, nil
+	case "version":
+		return VersionConflict, nil
+	default:
+		return StrictConflict, fmt.Errorf("unknown conflict policy: %s", policy)
+	}
+}
+
+// CanCoerce checks if the value of field type can be written as the existing field type,
+// only the field types with single value can be coerced to each other.
+func CanCoerce(fieldType, existType Type) bool {
+	return isSingleValue(fieldType) && isSingleValue(existType)
+}
+
+// VersionedName returns the name of versioned field which keeps the values of conflicting field type,
+// e.g. field(latency) written as gauge is versioned as latency_gauge.
+func VersionedName(fieldName Name, fieldType Type) Name {
+	return Name(fmt.Sprintf("%s_%s", fieldName, fieldType))
+}
+
+// isSingleValue checks if the field type has single value for each time slot
+func isSingleValue(fieldType Type) bool {
+	switch fieldType {
+	case SumField, MinField, MaxField, GaugeField, IncreaseField:
+		return true
+	default:
+		return false
+	}
+}
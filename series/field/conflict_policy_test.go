@@ -0,0 +1,31 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictPolicy(t *testing.T) {
+	for _, policy := range []ConflictPolicy{StrictConflict, CoerceConflict, VersionConflict} {
+		p, err := ParseConflictPolicy(policy.String())
+		assert.NoError(t, err)
+		assert.Equal(t, policy, p)
+	}
+	p, err := ParseConflictPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, StrictConflict, p)
+	_, err = ParseConflictPolicy("drop")
+	assert.Error(t, err)
+}
+
+func TestCanCoerce(t *testing.T) {
+	assert.True(t, CanCoerce(GaugeField, SumField))
+	assert.True(t, CanCoerce(MaxField, MinField))
+	assert.False(t, CanCoerce(SumField, HistogramField))
+	assert.False(t, CanCoerce(SummaryField, SumField))
+}
+
+func TestVersionedName(t *testing.T) {
+	assert.Equal(t, Name("latency_gauge"), VersionedName("latency", GaugeField))
+}
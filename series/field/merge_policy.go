@@ -0,0 +1,70 @@
+package field
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSlotCollision represents the data point is rejected because the time slot already has a value
+var ErrSlotCollision = errors.New("data point already exists in the same time slot")
+
+// MergePolicy represents the policy of merging two values which land in the same time slot of a field,
+// it's applied when data arrives out of order for the slot that already has a value.
+type MergePolicy uint8
+
+// Defines all merge policies of field
+const (
+	// AggregateMerge rolls up the values by the aggregate function of field type(default)
+	AggregateMerge MergePolicy = iota
+	// LastWriteWins keeps the value which is written last
+	LastWriteWins
+	// SumMerge sums the values
+	SumMerge
+	// RejectMerge rejects the value if the time slot already has a value
+	RejectMerge
+)
+
+// String returns the string value of merge policy
+func (p MergePolicy) String() string {
+	switch p {
+	case LastWriteWins:
+		return "last"
+	case SumMerge:
+		return "sum"
+	case RejectMerge:
+		return "reject"
+	default:
+		return "aggregate"
+	}
+}
+
+// ParseMergePolicy parses the merge policy by string value(aggregate/last/sum/reject), empty value means aggregate
+func ParseMergePolicy(policy string) (MergePolicy, error) {
+	switch policy {
+	case "", "aggregate":
+		return AggregateMerge, nil
+	case "last":
+		return LastWriteWins, nil
+	case "sum":
+		return SumMerge, nil
+	case "reject":
+		return RejectMerge, nil
+	default:
+		return AggregateMerge, fmt.Errorf("unknown merge policy: %s", policy)
+	}
+}
+
+// Merge merges the old value and the new value(written later) of the same time slot,
+// RejectMerge keeps the old value, so the write path need check collision before merging.
+func (p MergePolicy) Merge(aggFunc AggFunc, oldValue, newValue float64) float64 {
+	switch p {
+	case LastWriteWins:
+		return newValue
+	case SumMerge:
+		return oldValue + newValue
+	case RejectMerge:
+		return oldValue
+	default:
+		return aggFunc.Aggregate(oldValue, newValue)
+	}
+}
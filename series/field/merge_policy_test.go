@@ -0,0 +1,26 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePolicy(t *testing.T) {
+	for _, policy := range []MergePolicy{AggregateMerge, LastWriteWins, SumMerge, RejectMerge} {
+		p, err := ParseMergePolicy(policy.String())
+		assert.NoError(t, err)
+		assert.Equal(t, policy, p)
+	}
+	p, err := ParseMergePolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, AggregateMerge, p)
+	_, err = ParseMergePolicy("max")
+	assert.Error(t, err)
+
+	aggFunc := MaxField.GetAggFunc()
+	assert.Equal(t, 5.0, AggregateMerge.Merge(aggFunc, 5, 3))
+	assert.Equal(t, 3.0, LastWriteWins.Merge(aggFunc, 5, 3))
+	assert.Equal(t, 8.0, SumMerge.Merge(aggFunc, 5, 3))
+	assert.Equal(t, 5.0, RejectMerge.Merge(aggFunc, 5, 3))
+}
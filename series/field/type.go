@@ -20,6 +20,23 @@ const (
 	Min
 	Max
 	Replace
+
+	// new agg types are appended after Replace, keeps the values of existing types unchanged
+
+	// DistinctCount carries the sketch of distinct count as partial state
+	DistinctCount
+	// Sample carries the sampled data points, which are passed through without folding
+	Sample
+	// MaxTime carries the max value and its timestamp of time slot as partial state
+	MaxTime
+	// MinTime carries the min value and its timestamp of time slot as partial state
+	MinTime
+	// Increase carries the counter increase of series, which is calculated based on the data points of series
+	Increase
+	// NonNegativeDerivative carries the derivative of series, which is calculated based on the data points of series
+	NonNegativeDerivative
+	// CountAll carries the counts of data points(includes nulls) of time slot as partial state
+	CountAll
 )
 
 // Type represents field type for LinDB support
@@ -99,28 +116,31 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 	switch t {
 	case SumField:
 		switch funcType {
-		case function.Sum, function.Min, function.Max:
+		case function.Sum, function.Min, function.Max, function.Sample, function.MaxTime, function.MinTime,
+			function.DistinctCount, function.CountAll:
 			return true
 		default:
 			return false
 		}
 	case MinField:
 		switch funcType {
-		case function.Min:
+		case function.Min, function.Sample, function.MinTime, function.DistinctCount, function.CountAll:
 			return true
 		default:
 			return false
 		}
 	case MaxField:
 		switch funcType {
-		case function.Max:
+		case function.Max, function.Sample, function.MaxTime, function.DistinctCount, function.CountAll:
 			return true
 		default:
 			return false
 		}
 	case GaugeField:
 		switch funcType {
-		case function.Sum, function.Min, function.Max, function.Replace:
+		case function.Sum, function.Min, function.Max, function.Replace, function.Sample,
+			function.MaxTime, function.MinTime, function.Increase, function.NonNegativeDerivative, function.DistinctCount,
+			function.CountAll:
 			return true
 		default:
 			return false
@@ -136,6 +156,23 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 
 // GetFuncFieldParams returns the fields for aggregator's function params.
 func (t Type) GetFuncFieldParams(funcType function.FuncType) []AggType {
+	// functions which are calculated by storage keep own agg type for any field type
+	switch funcType {
+	case function.DistinctCount:
+		return []AggType{DistinctCount}
+	case function.Sample:
+		return []AggType{Sample}
+	case function.MaxTime:
+		return []AggType{MaxTime}
+	case function.MinTime:
+		return []AggType{MinTime}
+	case function.Increase:
+		return []AggType{Increase}
+	case function.NonNegativeDerivative:
+		return []AggType{NonNegativeDerivative}
+	case function.CountAll:
+		return []AggType{CountAll}
+	}
 	switch t {
 	case SumField:
 		return getFieldParamsForSumField(funcType)
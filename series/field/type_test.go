@@ -34,12 +34,25 @@ func TestIsSupportFunc(t *testing.T) {
 	assert.True(t, SumField.IsFuncSupported(function.Sum))
 	assert.True(t, SumField.IsFuncSupported(function.Min))
 	assert.True(t, SumField.IsFuncSupported(function.Max))
+	assert.True(t, SumField.IsFuncSupported(function.Sample))
 	assert.False(t, SumField.IsFuncSupported(function.Histogram))
 
 	assert.True(t, MaxField.IsFuncSupported(function.Max))
+	assert.True(t, MaxField.IsFuncSupported(function.MaxTime))
+	assert.False(t, MaxField.IsFuncSupported(function.MinTime))
 	assert.False(t, MaxField.IsFuncSupported(function.Histogram))
 
 	assert.True(t, GaugeField.IsFuncSupported(function.Replace))
+	assert.True(t, GaugeField.IsFuncSupported(function.Sample))
+	assert.True(t, GaugeField.IsFuncSupported(function.MinTime))
+	assert.True(t, GaugeField.IsFuncSupported(function.Increase))
+	assert.False(t, SumField.IsFuncSupported(function.Increase))
+	assert.True(t, GaugeField.IsFuncSupported(function.NonNegativeDerivative))
+	assert.False(t, SumField.IsFuncSupported(function.NonNegativeDerivative))
+	assert.True(t, SumField.IsFuncSupported(function.DistinctCount))
+	assert.True(t, GaugeField.IsFuncSupported(function.DistinctCount))
+	assert.True(t, SumField.IsFuncSupported(function.CountAll))
+	assert.True(t, GaugeField.IsFuncSupported(function.CountAll))
 	assert.False(t, GaugeField.IsFuncSupported(function.Histogram))
 
 	assert.True(t, MinField.IsFuncSupported(function.Min))
@@ -61,3 +74,20 @@ func TestType_GetAggFunc(t *testing.T) {
 	assert.Equal(t, minAggregator, MinField.GetAggFunc())
 	assert.Nil(t, Unknown.GetAggFunc())
 }
+
+func TestType_GetFuncFieldParams(t *testing.T) {
+	assert.Equal(t, []AggType{Sum}, SumField.GetFuncFieldParams(function.Sum))
+	assert.Equal(t, []AggType{Max}, SumField.GetFuncFieldParams(function.Max))
+	assert.Equal(t, []AggType{Min}, MinField.GetFuncFieldParams(function.Min))
+	assert.Equal(t, []AggType{DistinctCount}, SumField.GetFuncFieldParams(function.DistinctCount))
+	assert.Equal(t, []AggType{DistinctCount}, GaugeField.GetFuncFieldParams(function.DistinctCount))
+	assert.Equal(t, []AggType{Sample}, SumField.GetFuncFieldParams(function.Sample))
+	assert.Equal(t, []AggType{Sample}, GaugeField.GetFuncFieldParams(function.Sample))
+	assert.Equal(t, []AggType{MaxTime}, SumField.GetFuncFieldParams(function.MaxTime))
+	assert.Equal(t, []AggType{MinTime}, GaugeField.GetFuncFieldParams(function.MinTime))
+	assert.Equal(t, []AggType{Increase}, GaugeField.GetFuncFieldParams(function.Increase))
+	assert.Equal(t, []AggType{NonNegativeDerivative},
+		GaugeField.GetFuncFieldParams(function.NonNegativeDerivative))
+	assert.Equal(t, []AggType{CountAll}, SumField.GetFuncFieldParams(function.CountAll))
+	assert.Nil(t, GaugeField.GetFuncFieldParams(function.Sum))
+}
@@ -0,0 +1,90 @@
+package series
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/series/field"
+)
+
+// FieldBlockVersion represents the version of field data marshaled by field iterator,
+// version 2 appends crc32 checksum after each block, so that corrupted block is detected when decoding.
+const FieldBlockVersion byte = 2
+
+// ErrInvalidFieldBlock represents the binary of field block is corrupted
+var ErrInvalidFieldBlock = errors.New("invalid field block")
+
+// ErrFieldBlockChecksum represents the checksum of field block not match, the block is corrupted
+var ErrFieldBlockChecksum = errors.New("field block checksum mismatch")
+
+// MarshalFieldBlocks marshals the chained blocks of field data,
+// format: 1byte(version) + 1byte(agg type) + [vint32(block length) + block + 4bytes(crc32 checksum of block)]...,
+// the length of block is negative if it has next block.
+func MarshalFieldBlocks(aggType field.AggType, blocks [][]byte) ([]byte, error) {
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(FieldBlockVersion)
+	writer.PutByte(byte(aggType))
+	last := len(blocks) - 1
+	for i, block := range blocks {
+		if i < last {
+			writer.PutVarint32(-int32(len(block))) // length of chained block, has next block
+		} else {
+			writer.PutVarint32(int32(len(block))) // length of last block
+		}
+		writer.PutBytes(block)
+		writer.PutUint32(crc32.ChecksumIEEE(block))
+	}
+	return writer.Bytes()
+}
+
+// UnmarshalFieldBlocks validates the version and checksum of field data marshaled by field iterator,
+// returns the agg type and chained blocks, returns ErrFieldBlockChecksum if some block is corrupted.
+func UnmarshalFieldBlocks(data []byte) (field.AggType, [][]byte, error) {
+	if len(data) == 0 {
+		return 0, nil, ErrInvalidFieldBlock
+	}
+	return readFieldBlocks(stream.NewReader(data))
+}
+
+// readFieldBlocks reads the field data from reader, stops after the last chained block
+func readFieldBlocks(reader *stream.Reader) (aggType field.AggType, blocks [][]byte, err error) {
+	return scanFieldBlocks(reader, true)
+}
+
+// scanFieldBlocks reads the chained blocks of field data from reader, validates the checksum of each block if need
+func scanFieldBlocks(reader *stream.Reader, verifyChecksum bool) (aggType field.AggType, blocks [][]byte, err error) {
+	version := reader.ReadByte()
+	aggType = field.AggType(reader.ReadByte())
+	if reader.Error() != nil {
+		return 0, nil, ErrInvalidFieldBlock
+	}
+	if version != FieldBlockVersion {
+		return 0, nil, fmt.Errorf("%w, version not match, expect: %d, actual: %d",
+			ErrInvalidFieldBlock, FieldBlockVersion, version)
+	}
+	for !reader.Empty() {
+		length := reader.ReadVarint32()
+		hasNext := length < 0
+		if hasNext {
+			length = -length
+		}
+		if length == 0 {
+			break
+		}
+		block := reader.ReadSlice(int(length))
+		checksum := reader.ReadUint32()
+		if reader.Error() != nil || len(block) != int(length) {
+			return 0, nil, ErrInvalidFieldBlock
+		}
+		if verifyChecksum && checksum != crc32.ChecksumIEEE(block) {
+			return 0, nil, ErrFieldBlockChecksum
+		}
+		blocks = append(blocks, block)
+		if !hasNext {
+			break
+		}
+	}
+	return aggType, blocks, nil
+}
@@ -0,0 +1,50 @@
+package series
+
+import (
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/series/field"
+)
+
+// FieldBlockMeta represents the metadata of field block which is marshaled by field iterator,
+// used to estimate the decode cost before reading the values.
+type FieldBlockMeta struct {
+	AggType   field.AggType
+	Codec     encoding.CodecID // value codec of the first block
+	StartSlot uint16
+	EndSlot   uint16
+	SlotCount int // num. of time slots of all chained blocks, includes the absent slots
+	Blocks    int // num. of chained blocks
+	Size      int // byte length of all chained blocks
+}
+
+// ReadFieldBlockMeta reads the metadata of field block from the headers of chained blocks without decoding the values,
+// the checksum of block is not validated, the data is the binary marshaled by field iterator, format see MarshalFieldBlocks.
+func ReadFieldBlockMeta(data []byte) (*FieldBlockMeta, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidFieldBlock
+	}
+	aggType, blocks, err := scanFieldBlocks(stream.NewReader(data), false)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, ErrInvalidFieldBlock
+	}
+	meta := &FieldBlockMeta{AggType: aggType}
+	for _, block := range blocks {
+		startSlot, endSlot, codec, err := encoding.DecodeTSDHeader(block)
+		if err != nil || endSlot < startSlot {
+			return nil, ErrInvalidFieldBlock
+		}
+		if meta.Blocks == 0 {
+			meta.Codec = codec
+			meta.StartSlot = startSlot
+		}
+		meta.EndSlot = endSlot
+		meta.SlotCount += int(endSlot-startSlot) + 1
+		meta.Blocks++
+		meta.Size += len(block)
+	}
+	return meta, nil
+}
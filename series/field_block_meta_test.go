@@ -0,0 +1,79 @@
+package series
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/bit"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestReadFieldBlockMeta(t *testing.T) {
+	encodeBlock := func(codec encoding.CodecID, startSlot uint16, count int) []byte {
+		encoder := encoding.NewTSDEncoderWithCodec(codec, startSlot)
+		for i := 0; i < count; i++ {
+			encoder.AppendTime(bit.One)
+			encoder.AppendValue(100)
+		}
+		data, _ := encoder.Bytes()
+		return data
+	}
+	block1 := encodeBlock(encoding.RLECodec, 5, 10)
+	block2 := encodeBlock(encoding.RLECodec, 15, 30)
+	data, _ := MarshalFieldBlocks(field.Sum, [][]byte{block1, block2})
+
+	meta, err := ReadFieldBlockMeta(data)
+	assert.NoError(t, err)
+	assert.Equal(t, &FieldBlockMeta{
+		AggType:   field.Sum,
+		Codec:     encoding.NewTSDDecoder(block1).Codec(),
+		StartSlot: 5,
+		EndSlot:   44,
+		SlotCount: 40,
+		Blocks:    2,
+		Size:      len(block1) + len(block2),
+	}, meta)
+
+	// checksum is not validated, the values are not read
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)-5] ^= 0xff
+	meta, err = ReadFieldBlockMeta(corrupted)
+	assert.NoError(t, err)
+	assert.Equal(t, 40, meta.SlotCount)
+	_, _, err = UnmarshalFieldBlocks(corrupted)
+	assert.Equal(t, ErrFieldBlockChecksum, err)
+
+	// single block
+	data, _ = MarshalFieldBlocks(field.Max, [][]byte{block1})
+	meta, err = ReadFieldBlockMeta(data)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Max, meta.AggType)
+	assert.Equal(t, 10, meta.SlotCount)
+	assert.Equal(t, 1, meta.Blocks)
+
+	// corrupted block
+	meta, err = ReadFieldBlockMeta(nil)
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	assert.Nil(t, meta)
+	meta, err = ReadFieldBlockMeta([]byte{byte(field.Sum)})
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	assert.Nil(t, meta)
+	meta, err = ReadFieldBlockMeta(data[:len(data)-1])
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	assert.Nil(t, meta)
+	meta, err = ReadFieldBlockMeta([]byte{FieldBlockVersion, byte(field.Sum), 4, 1, 2})
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	assert.Nil(t, meta)
+	// invalid block header
+	data, _ = MarshalFieldBlocks(field.Sum, [][]byte{{1, 2}})
+	meta, err = ReadFieldBlockMeta(data)
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	assert.Nil(t, meta)
+	// no block
+	data, _ = MarshalFieldBlocks(field.Sum, nil)
+	meta, err = ReadFieldBlockMeta(data)
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	assert.Nil(t, meta)
+}
@@ -0,0 +1,56 @@
+package series
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestMarshalFieldBlocks(t *testing.T) {
+	data, err := MarshalFieldBlocks(field.Sum, [][]byte{{1, 2, 3}, {4, 5}})
+	assert.NoError(t, err)
+	assert.Equal(t, FieldBlockVersion, data[0])
+	aggType, blocks, err := UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Sum, aggType)
+	assert.Equal(t, [][]byte{{1, 2, 3}, {4, 5}}, blocks)
+
+	// no block
+	data, err = MarshalFieldBlocks(field.Max, nil)
+	assert.NoError(t, err)
+	aggType, blocks, err = UnmarshalFieldBlocks(data)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Max, aggType)
+	assert.Empty(t, blocks)
+}
+
+func TestUnmarshalFieldBlocks_corrupted(t *testing.T) {
+	data := buildFieldIterator()
+	// flips a byte of block payload(version + agg type + 1 byte length)
+	data[3] ^= 0xFF
+	_, blocks, err := UnmarshalFieldBlocks(data)
+	assert.Equal(t, ErrFieldBlockChecksum, err)
+	assert.Nil(t, blocks)
+	// flips a byte of checksum
+	data = buildFieldIterator()
+	data[len(data)-1] ^= 0xFF
+	_, _, err = UnmarshalFieldBlocks(data)
+	assert.Equal(t, ErrFieldBlockChecksum, err)
+
+	// truncated
+	data = buildFieldIterator()
+	_, _, err = UnmarshalFieldBlocks(data[:len(data)-2])
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	_, _, err = UnmarshalFieldBlocks(nil)
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	_, _, err = UnmarshalFieldBlocks([]byte{FieldBlockVersion})
+	assert.Equal(t, ErrInvalidFieldBlock, err)
+	// version not match
+	data = buildFieldIterator()
+	data[0] = 1
+	_, _, err = UnmarshalFieldBlocks(data)
+	assert.True(t, errors.Is(err, ErrInvalidFieldBlock))
+}
@@ -26,6 +26,8 @@ type TagValueSuggester interface {
 type Filter interface {
 	// GetSeriesIDsByTagValueIDs gets series ids by tag value ids for spec metric's tag key
 	GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error)
+	// GetSeriesIDsForTagValues gets series ids which match all tag value ids of the tag keys
+	GetSeriesIDsForTagValues(tagKeyIDs []uint32, tagValueIDs []*roaring.Bitmap) (*roaring.Bitmap, error)
 	// GetSeriesIDsForTag gets series ids for spec metric's tag key
 	GetSeriesIDsForTag(tagKeyID uint32) (*roaring.Bitmap, error)
 	// GetSeriesIDsForMetric gets series ids for spec metric name
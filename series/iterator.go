@@ -42,6 +42,16 @@ type Iterator interface {
 	enc.BinaryMarshaler
 }
 
+// MultiFieldIterator represents the field iterators of one series, all fields share the tags of series
+type MultiFieldIterator interface {
+	// Tags returns the tags of series
+	Tags() map[string]string
+	// Fields returns the metas of all fields in the series
+	Fields() []field.Meta
+	// FieldIterator returns the field's iterator by field name, if not exist return nil
+	FieldIterator(fieldName field.Name) FieldIterator
+}
+
 // FieldIterator represents a field's data iterator, support multi field for one series
 type FieldIterator interface {
 	// AggType returns the field's agg type for down sampling.
@@ -53,3 +63,11 @@ type FieldIterator interface {
 	// MarshalBinary marshals the data
 	enc.BinaryMarshaler
 }
+
+// StateFieldIterator represents a field's iterator which carries the serialized partial state of aggregator,
+// like the sketch of distinct count, the state cannot be folded by value of time slot, so it is merged by aggregator.
+type StateFieldIterator interface {
+	FieldIterator
+	// State returns the serialized partial state
+	State() []byte
+}
@@ -7,6 +7,11 @@ import (
 // MarshalIterator represents marshal series data of one field.
 // format: 1byte(field type) + vint64(start time) + vint32(data length) + data
 func MarshalIterator(it Iterator) ([]byte, error) {
+	return MarshalIteratorWith(it, FieldIterator.MarshalBinary)
+}
+
+// MarshalIteratorWith marshals series data of one field, marshals the data of each field iterator by marshal func.
+func MarshalIteratorWith(it Iterator, marshal func(fIt FieldIterator) ([]byte, error)) ([]byte, error) {
 	if it == nil {
 		return nil, nil
 	}
@@ -17,15 +22,16 @@ func MarshalIterator(it Iterator) ([]byte, error) {
 		if fIt == nil {
 			continue
 		}
-		writer.PutVarint64(startTime)
-		data, err := fIt.MarshalBinary()
+		data, err := marshal(fIt)
 		if err != nil {
 			return nil, err
 		}
-		writer.PutVarint32(int32(len(data)))
-		if len(data) > 0 {
-			writer.PutBytes(data)
+		if len(data) == 0 {
+			continue
 		}
+		// field data is self-delimited by the length of chained blocks, see MarshalFieldBlocks
+		writer.PutVarint64(startTime)
+		writer.PutBytes(data)
 	}
 	return writer.Bytes()
 }
@@ -18,10 +18,7 @@ func Test_MarshalBinary(t *testing.T) {
 	writer := stream.NewBufferWriter(nil)
 	writer.PutByte(byte(field.SumField))
 	writer.PutVarint64(10)
-	writer.PutVarint32(int32(2))
 	writer.PutBytes([]byte{1, 2})
-	writer.PutVarint64(10)
-	writer.PutVarint32(int32(0))
 	data, err := writer.Bytes()
 	assert.NoError(t, err)
 
@@ -61,3 +58,38 @@ func Test_MarshalBinary(t *testing.T) {
 	_, err = MarshalIterator(it)
 	assert.Error(t, err)
 }
+
+func TestMarshalIterator_unmarshal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	it := NewMockIterator(ctrl)
+	fIt := NewMockFieldIterator(ctrl)
+	gomock.InOrder(
+		it.EXPECT().FieldType().Return(field.SumField),
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(10), fIt),
+		fIt.EXPECT().MarshalBinary().Return(buildFieldIterator(), nil),
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(11), fIt),
+		fIt.EXPECT().MarshalBinary().Return(nil, nil),
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(int64(12), fIt),
+		fIt.EXPECT().MarshalBinary().Return(buildFieldIterator(), nil),
+		it.EXPECT().HasNext().Return(false),
+	)
+	data, err := MarshalIterator(it)
+	assert.NoError(t, err)
+
+	// marshaled data can be read back by binary iterator, empty field data is skipped
+	bIt := NewIterator("f1", data)
+	assert.Equal(t, field.SumField, bIt.FieldType())
+	for _, expect := range []int64{10, 12} {
+		assert.True(t, bIt.HasNext())
+		startTime, fieldIt := bIt.Next()
+		assert.Equal(t, expect, startTime)
+		assertFieldIterator(t, fieldIt)
+	}
+	assert.False(t, bIt.HasNext())
+	assert.NoError(t, bIt.Error())
+}
@@ -0,0 +1,412 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./iterator.go
+
+// Package series is a generated GoMock package.
+package series
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	field "github.com/lindb/lindb/series/field"
+	reflect "reflect"
+)
+
+// MockGroupedIterator is a mock of GroupedIterator interface
+type MockGroupedIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupedIteratorMockRecorder
+}
+
+// MockGroupedIteratorMockRecorder is the mock recorder for MockGroupedIterator
+type MockGroupedIteratorMockRecorder struct {
+	mock *MockGroupedIterator
+}
+
+// NewMockGroupedIterator creates a new mock instance
+func NewMockGroupedIterator(ctrl *gomock.Controller) *MockGroupedIterator {
+	mock := &MockGroupedIterator{ctrl: ctrl}
+	mock.recorder = &MockGroupedIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGroupedIterator) EXPECT() *MockGroupedIteratorMockRecorder {
+	return m.recorder
+}
+
+// HasNext mocks base method
+func (m *MockGroupedIterator) HasNext() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNext")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasNext indicates an expected call of HasNext
+func (mr *MockGroupedIteratorMockRecorder) HasNext() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNext", reflect.TypeOf((*MockGroupedIterator)(nil).HasNext))
+}
+
+// Next mocks base method
+func (m *MockGroupedIterator) Next() Iterator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(Iterator)
+	return ret0
+}
+
+// Next indicates an expected call of Next
+func (mr *MockGroupedIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockGroupedIterator)(nil).Next))
+}
+
+// Tags mocks base method
+func (m *MockGroupedIterator) Tags() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Tags indicates an expected call of Tags
+func (mr *MockGroupedIteratorMockRecorder) Tags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockGroupedIterator)(nil).Tags))
+}
+
+// MockIterator is a mock of Iterator interface
+type MockIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockIteratorMockRecorder
+}
+
+// MockIteratorMockRecorder is the mock recorder for MockIterator
+type MockIteratorMockRecorder struct {
+	mock *MockIterator
+}
+
+// NewMockIterator creates a new mock instance
+func NewMockIterator(ctrl *gomock.Controller) *MockIterator {
+	mock := &MockIterator{ctrl: ctrl}
+	mock.recorder = &MockIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIterator) EXPECT() *MockIteratorMockRecorder {
+	return m.recorder
+}
+
+// FieldName mocks base method
+func (m *MockIterator) FieldName() field.Name {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FieldName")
+	ret0, _ := ret[0].(field.Name)
+	return ret0
+}
+
+// FieldName indicates an expected call of FieldName
+func (mr *MockIteratorMockRecorder) FieldName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FieldName", reflect.TypeOf((*MockIterator)(nil).FieldName))
+}
+
+// FieldType mocks base method
+func (m *MockIterator) FieldType() field.Type {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FieldType")
+	ret0, _ := ret[0].(field.Type)
+	return ret0
+}
+
+// FieldType indicates an expected call of FieldType
+func (mr *MockIteratorMockRecorder) FieldType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FieldType", reflect.TypeOf((*MockIterator)(nil).FieldType))
+}
+
+// HasNext mocks base method
+func (m *MockIterator) HasNext() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNext")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasNext indicates an expected call of HasNext
+func (mr *MockIteratorMockRecorder) HasNext() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNext", reflect.TypeOf((*MockIterator)(nil).HasNext))
+}
+
+// Next mocks base method
+func (m *MockIterator) Next() (int64, FieldIterator) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(FieldIterator)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next
+func (mr *MockIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockIterator)(nil).Next))
+}
+
+// MarshalBinary mocks base method
+func (m *MockIterator) MarshalBinary() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalBinary")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalBinary indicates an expected call of MarshalBinary
+func (mr *MockIteratorMockRecorder) MarshalBinary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalBinary", reflect.TypeOf((*MockIterator)(nil).MarshalBinary))
+}
+
+// MockMultiFieldIterator is a mock of MultiFieldIterator interface
+type MockMultiFieldIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultiFieldIteratorMockRecorder
+}
+
+// MockMultiFieldIteratorMockRecorder is the mock recorder for MockMultiFieldIterator
+type MockMultiFieldIteratorMockRecorder struct {
+	mock *MockMultiFieldIterator
+}
+
+// NewMockMultiFieldIterator creates a new mock instance
+func NewMockMultiFieldIterator(ctrl *gomock.Controller) *MockMultiFieldIterator {
+	mock := &MockMultiFieldIterator{ctrl: ctrl}
+	mock.recorder = &MockMultiFieldIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMultiFieldIterator) EXPECT() *MockMultiFieldIteratorMockRecorder {
+	return m.recorder
+}
+
+// Tags mocks base method
+func (m *MockMultiFieldIterator) Tags() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// Tags indicates an expected call of Tags
+func (mr *MockMultiFieldIteratorMockRecorder) Tags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockMultiFieldIterator)(nil).Tags))
+}
+
+// Fields mocks base method
+func (m *MockMultiFieldIterator) Fields() []field.Meta {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fields")
+	ret0, _ := ret[0].([]field.Meta)
+	return ret0
+}
+
+// Fields indicates an expected call of Fields
+func (mr *MockMultiFieldIteratorMockRecorder) Fields() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fields", reflect.TypeOf((*MockMultiFieldIterator)(nil).Fields))
+}
+
+// FieldIterator mocks base method
+func (m *MockMultiFieldIterator) FieldIterator(fieldName field.Name) FieldIterator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FieldIterator", fieldName)
+	ret0, _ := ret[0].(FieldIterator)
+	return ret0
+}
+
+// FieldIterator indicates an expected call of FieldIterator
+func (mr *MockMultiFieldIteratorMockRecorder) FieldIterator(fieldName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FieldIterator", reflect.TypeOf((*MockMultiFieldIterator)(nil).FieldIterator), fieldName)
+}
+
+// MockFieldIterator is a mock of FieldIterator interface
+type MockFieldIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockFieldIteratorMockRecorder
+}
+
+// MockFieldIteratorMockRecorder is the mock recorder for MockFieldIterator
+type MockFieldIteratorMockRecorder struct {
+	mock *MockFieldIterator
+}
+
+// NewMockFieldIterator creates a new mock instance
+func NewMockFieldIterator(ctrl *gomock.Controller) *MockFieldIterator {
+	mock := &MockFieldIterator{ctrl: ctrl}
+	mock.recorder = &MockFieldIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockFieldIterator) EXPECT() *MockFieldIteratorMockRecorder {
+	return m.recorder
+}
+
+// AggType mocks base method
+func (m *MockFieldIterator) AggType() field.AggType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggType")
+	ret0, _ := ret[0].(field.AggType)
+	return ret0
+}
+
+// AggType indicates an expected call of AggType
+func (mr *MockFieldIteratorMockRecorder) AggType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggType", reflect.TypeOf((*MockFieldIterator)(nil).AggType))
+}
+
+// HasNext mocks base method
+func (m *MockFieldIterator) HasNext() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNext")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasNext indicates an expected call of HasNext
+func (mr *MockFieldIteratorMockRecorder) HasNext() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNext", reflect.TypeOf((*MockFieldIterator)(nil).HasNext))
+}
+
+// Next mocks base method
+func (m *MockFieldIterator) Next() (int, float64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(float64)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next
+func (mr *MockFieldIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockFieldIterator)(nil).Next))
+}
+
+// MarshalBinary mocks base method
+func (m *MockFieldIterator) MarshalBinary() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalBinary")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalBinary indicates an expected call of MarshalBinary
+func (mr *MockFieldIteratorMockRecorder) MarshalBinary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalBinary", reflect.TypeOf((*MockFieldIterator)(nil).MarshalBinary))
+}
+
+// MockStateFieldIterator is a mock of StateFieldIterator interface
+type MockStateFieldIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockStateFieldIteratorMockRecorder
+}
+
+// MockStateFieldIteratorMockRecorder is the mock recorder for MockStateFieldIterator
+type MockStateFieldIteratorMockRecorder struct {
+	mock *MockStateFieldIterator
+}
+
+// NewMockStateFieldIterator creates a new mock instance
+func NewMockStateFieldIterator(ctrl *gomock.Controller) *MockStateFieldIterator {
+	mock := &MockStateFieldIterator{ctrl: ctrl}
+	mock.recorder = &MockStateFieldIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStateFieldIterator) EXPECT() *MockStateFieldIteratorMockRecorder {
+	return m.recorder
+}
+
+// AggType mocks base method
+func (m *MockStateFieldIterator) AggType() field.AggType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggType")
+	ret0, _ := ret[0].(field.AggType)
+	return ret0
+}
+
+// AggType indicates an expected call of AggType
+func (mr *MockStateFieldIteratorMockRecorder) AggType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggType", reflect.TypeOf((*MockStateFieldIterator)(nil).AggType))
+}
+
+// HasNext mocks base method
+func (m *MockStateFieldIterator) HasNext() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNext")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasNext indicates an expected call of HasNext
+func (mr *MockStateFieldIteratorMockRecorder) HasNext() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNext", reflect.TypeOf((*MockStateFieldIterator)(nil).HasNext))
+}
+
+// Next mocks base method
+func (m *MockStateFieldIterator) Next() (int, float64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(float64)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next
+func (mr *MockStateFieldIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockStateFieldIterator)(nil).Next))
+}
+
+// MarshalBinary mocks base method
+func (m *MockStateFieldIterator) MarshalBinary() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalBinary")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalBinary indicates an expected call of MarshalBinary
+func (mr *MockStateFieldIteratorMockRecorder) MarshalBinary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalBinary", reflect.TypeOf((*MockStateFieldIterator)(nil).MarshalBinary))
+}
+
+// State mocks base method
+func (m *MockStateFieldIterator) State() []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "State")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// State indicates an expected call of State
+func (mr *MockStateFieldIteratorMockRecorder) State() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockStateFieldIterator)(nil).State))
+}
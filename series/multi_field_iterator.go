@@ -0,0 +1,43 @@
+package series
+
+import (
+	"github.com/lindb/lindb/series/field"
+)
+
+// multiFieldIterator implements MultiFieldIterator interface
+type multiFieldIterator struct {
+	tags   map[string]string
+	fields []field.Meta
+	its    map[field.Name]FieldIterator
+}
+
+// NewMultiFieldIterator creates the field iterators of one series,
+// fields and iterators are matched by index, iterator is nil if the field has no data.
+func NewMultiFieldIterator(tags map[string]string, fields []field.Meta, its []FieldIterator) MultiFieldIterator {
+	m := &multiFieldIterator{
+		tags:   tags,
+		fields: fields,
+		its:    make(map[field.Name]FieldIterator, len(fields)),
+	}
+	for idx, f := range fields {
+		if idx < len(its) && its[idx] != nil {
+			m.its[f.Name] = its[idx]
+		}
+	}
+	return m
+}
+
+// Tags returns the tags of series
+func (m *multiFieldIterator) Tags() map[string]string {
+	return m.tags
+}
+
+// Fields returns the metas of all fields in the series
+func (m *multiFieldIterator) Fields() []field.Meta {
+	return m.fields
+}
+
+// FieldIterator returns the field's iterator by field name, if not exist return nil
+func (m *multiFieldIterator) FieldIterator(fieldName field.Name) FieldIterator {
+	return m.its[fieldName]
+}
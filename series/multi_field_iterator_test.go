@@ -0,0 +1,39 @@
+package series
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestMultiFieldIterator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tags := map[string]string{"host": "1.1.1.1"}
+	fields := []field.Meta{
+		{ID: 1, Name: "f1", Type: field.SumField},
+		{ID: 2, Name: "f2", Type: field.MinField},
+		{ID: 3, Name: "f3", Type: field.MaxField},
+	}
+	it1 := NewMockFieldIterator(ctrl)
+	it2 := NewMockFieldIterator(ctrl)
+	it3 := NewMockFieldIterator(ctrl)
+	it := NewMultiFieldIterator(tags, fields, []FieldIterator{it1, it2, it3})
+	assert.Equal(t, tags, it.Tags())
+	assert.Equal(t, fields, it.Fields())
+	assert.Equal(t, it1, it.FieldIterator("f1"))
+	assert.Equal(t, it2, it.FieldIterator("f2"))
+	assert.Equal(t, it3, it.FieldIterator("f3"))
+	assert.Nil(t, it.FieldIterator("f4"))
+
+	// field without data
+	it = NewMultiFieldIterator(tags, fields, []FieldIterator{it1, nil})
+	assert.Len(t, it.Fields(), 3)
+	assert.Equal(t, it1, it.FieldIterator("f1"))
+	assert.Nil(t, it.FieldIterator("f2"))
+	assert.Nil(t, it.FieldIterator("f3"))
+}
@@ -0,0 +1,95 @@
+package tag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cespare/xxhash"
+)
+
+const (
+	// XXHasherName represents the name of default tags hasher which uses xxhash of concated tags
+	XXHasherName = "xxhash"
+	// groupingHasherPrefix represents the prefix of grouping tags hasher's spec, like grouping:host,app
+	groupingHasherPrefix = "grouping:"
+)
+
+// Hasher computes the identity hash code and the locality group of tags, storage side uses the hash code
+// for getting series id, and assigns adjacent series ids to the series of same group.
+type Hasher interface {
+	// Hash returns the 64-bit identity hash code of tags, the same tags must return the same hash code
+	Hash(tags map[string]string) uint64
+	// Group returns the locality group of tags, returns 0 if the series is not grouped
+	Group(tags map[string]string) uint64
+}
+
+// NewHasher creates the tags hasher based on given spec, supports:
+// 1) empty or xxhash: default hasher, the series are not grouped;
+// 2) grouping:k1,k2: grouping hasher which clusters the series with the same values of grouping tag keys.
+func NewHasher(spec string) (Hasher, error) {
+	switch {
+	case spec == "" || spec == XXHasherName:
+		return NewXXHasher(), nil
+	case strings.HasPrefix(spec, groupingHasherPrefix):
+		var groupingKeys []string
+		for _, key := range strings.Split(strings.TrimPrefix(spec, groupingHasherPrefix), ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				groupingKeys = append(groupingKeys, key)
+			}
+		}
+		if len(groupingKeys) == 0 {
+			return nil, fmt.Errorf("grouping tag keys not found for tags hasher: %s", spec)
+		}
+		return NewGroupingHasher(groupingKeys...), nil
+	default:
+		return nil, fmt.Errorf("unknown tags hasher: %s", spec)
+	}
+}
+
+// xxHasher implements Hasher interface, computes the xxhash of concated tags, the series are not grouped
+type xxHasher struct{}
+
+// NewXXHasher creates the default tags hasher
+func NewXXHasher() Hasher {
+	return &xxHasher{}
+}
+
+// Hash returns the xxhash of concated tags
+func (h *xxHasher) Hash(tags map[string]string) uint64 {
+	return xxhash.Sum64String(Concat(tags))
+}
+
+// Group returns 0, the series are not grouped
+func (h *xxHasher) Group(_ map[string]string) uint64 {
+	return 0
+}
+
+// groupingHasher implements Hasher interface, the hash code is the xxhash of concated tags,
+// the group is computed by the values of grouping tag keys, so that the related series(e.g. same host) are clustered.
+type groupingHasher struct {
+	xxHasher
+	groupingKeys []string
+}
+
+// NewGroupingHasher creates the tags hasher which clusters the series by the values of grouping tag keys
+func NewGroupingHasher(groupingKeys ...string) Hasher {
+	return &groupingHasher{groupingKeys: groupingKeys}
+}
+
+// Group returns the xxhash of the values of grouping tag keys, returns 0 if tags has no grouping tag key
+func (h *groupingHasher) Group(tags map[string]string) uint64 {
+	var b strings.Builder
+	found := false
+	for idx, key := range h.groupingKeys {
+		if idx > 0 {
+			b.WriteString(",")
+		}
+		value, ok := tags[key]
+		found = found || ok
+		b.WriteString(value)
+	}
+	if !found {
+		return 0
+	}
+	return xxhash.Sum64String(b.String())
+}
@@ -0,0 +1,53 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/cespare/xxhash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHasher(t *testing.T) {
+	h, err := NewHasher("")
+	assert.NoError(t, err)
+	assert.Equal(t, NewXXHasher(), h)
+	h, err = NewHasher(XXHasherName)
+	assert.NoError(t, err)
+	assert.Equal(t, NewXXHasher(), h)
+	h, err = NewHasher("grouping: host, app,")
+	assert.NoError(t, err)
+	assert.Equal(t, NewGroupingHasher("host", "app"), h)
+
+	h, err = NewHasher("grouping:")
+	assert.Error(t, err)
+	assert.Nil(t, h)
+	h, err = NewHasher("md5")
+	assert.Error(t, err)
+	assert.Nil(t, h)
+}
+
+func TestXXHasher(t *testing.T) {
+	h := NewXXHasher()
+	tags := map[string]string{"host": "a", "disk": "/data"}
+	// preserves the hash of concated tags
+	assert.Equal(t, xxhash.Sum64String("disk=/data,host=a"), h.Hash(tags))
+	assert.Equal(t, xxhash.Sum64String(""), h.Hash(nil))
+	assert.Zero(t, h.Group(tags))
+}
+
+func TestGroupingHasher(t *testing.T) {
+	h := NewGroupingHasher("host")
+	tags := map[string]string{"host": "a", "disk": "/data"}
+	// full 64-bit identity hash, same as default hasher
+	assert.Equal(t, NewXXHasher().Hash(tags), h.Hash(tags))
+	assert.Equal(t, h.Hash(tags), h.Hash(map[string]string{"disk": "/data", "host": "a"}))
+	assert.NotEqual(t, h.Hash(tags), h.Hash(map[string]string{"host": "a", "disk": "/home"}))
+	// same host has same group
+	assert.Equal(t, xxhash.Sum64String("a"), h.Group(tags))
+	assert.Equal(t, h.Group(tags), h.Group(map[string]string{"host": "a", "disk": "/home"}))
+	assert.NotEqual(t, h.Group(tags), h.Group(map[string]string{"host": "b", "disk": "/data"}))
+	// missing grouping tag key
+	assert.Zero(t, h.Group(map[string]string{"disk": "/data"}))
+	assert.Equal(t, xxhash.Sum64String(",/data"),
+		NewGroupingHasher("host", "disk").Group(map[string]string{"disk": "/data"}))
+}
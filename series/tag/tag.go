@@ -48,3 +48,19 @@ func SplitTagValues(tags string) []string {
 	}
 	return strings.Split(tags, ",")
 }
+
+// SplitTags splits the string of concatenated tags(see Concat) to map-tags
+func SplitTags(tags string) map[string]string {
+	if tags == "" {
+		return nil
+	}
+	result := make(map[string]string)
+	for _, pair := range strings.Split(tags, ",") {
+		idx := strings.Index(pair, "=")
+		if idx < 0 {
+			continue
+		}
+		result[pair[:idx]] = pair[idx+1:]
+	}
+	return result
+}
@@ -29,6 +29,12 @@ func TestSplitTagValues(t *testing.T) {
 	assert.Equal(t, []string{"a", "b", ""}, SplitTagValues("a,b,"))
 }
 
+func TestSplitTags(t *testing.T) {
+	assert.Nil(t, SplitTags(""))
+	assert.Equal(t, map[string]string{"t1": "v1", "t2": "v2"}, SplitTags(Concat(map[string]string{"t2": "v2", "t1": "v1"})))
+	assert.Equal(t, map[string]string{"t1": ""}, SplitTags("t1=,t2"))
+}
+
 var _testTags = map[string]string{
 	"a": "aaaaaaaaa",
 	"b": "bbb",
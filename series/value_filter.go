@@ -0,0 +1,45 @@
+package series
+
+// ValueFilter represents the predicate of field value(like f > 90),
+// which drops the non-qualifying data points before aggregation.
+type ValueFilter interface {
+	// Match returns if the value qualifies the predicate
+	Match(value float64) bool
+}
+
+// filteredBlock represents a block which only appends the values matched by value filter
+type filteredBlock struct {
+	block  Block
+	filter ValueFilter
+}
+
+// NewFilteredBlock creates a block which drops the values not matched by value filter
+func NewFilteredBlock(block Block, filter ValueFilter) Block {
+	return &filteredBlock{
+		block:  block,
+		filter: filter,
+	}
+}
+
+// UnwrapFilteredBlock returns the underlying block and value filter if block is created by NewFilteredBlock,
+// so that the value filter can be pushed down into the decoder of field data.
+func UnwrapFilteredBlock(block Block) (Block, ValueFilter, bool) {
+	filtered, ok := block.(*filteredBlock)
+	if !ok {
+		return block, nil, false
+	}
+	return filtered.block, filtered.filter, true
+}
+
+// Append appends time slot and value into block if value matches the filter
+func (b *filteredBlock) Append(slot int, value float64) bool {
+	if !b.filter.Match(value) {
+		return false
+	}
+	return b.block.Append(slot, value)
+}
+
+// Clear clears the values of block.
+func (b *filteredBlock) Clear() {
+	b.block.Clear()
+}
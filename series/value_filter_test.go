@@ -0,0 +1,53 @@
+package series
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type greaterThan float64
+
+func (f greaterThan) Match(value float64) bool {
+	return value > float64(f)
+}
+
+func TestFilteredBlock_Append(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	block := NewMockBlock(ctrl)
+	filteredBlock := NewFilteredBlock(block, greaterThan(90))
+	// half of points are filtered out by value predicate
+	var appended []float64
+	block.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(func(slot int, value float64) bool {
+		appended = append(appended, value)
+		return false
+	}).Times(5)
+	for slot := 0; slot < 10; slot++ {
+		assert.False(t, filteredBlock.Append(slot, float64(86+slot)))
+	}
+	assert.Equal(t, []float64{91, 92, 93, 94, 95}, appended)
+
+	// out of block's time range
+	block.EXPECT().Append(11, 99.0).Return(true)
+	assert.True(t, filteredBlock.Append(11, 99.0))
+	assert.False(t, filteredBlock.Append(12, 1.0))
+
+	block.EXPECT().Clear()
+	filteredBlock.Clear()
+}
+
+func TestUnwrapFilteredBlock(t *testing.T) {
+	block := NewBlock(0, 10)
+	b, filter, ok := UnwrapFilteredBlock(block)
+	assert.False(t, ok)
+	assert.Nil(t, filter)
+	assert.Equal(t, block, b)
+
+	b, filter, ok = UnwrapFilteredBlock(NewFilteredBlock(block, greaterThan(90)))
+	assert.True(t, ok)
+	assert.Equal(t, greaterThan(90), filter)
+	assert.Equal(t, block, b)
+}
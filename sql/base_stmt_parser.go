@@ -1,7 +1,9 @@
 package sql
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/strutil"
@@ -19,9 +21,20 @@ type baseStmtParser struct {
 
 	limit int
 
+	config ParserConfig
+
 	err error
 }
 
+// checkConditionDepth checks if the depth of condition expression tree exceeds the max depth
+func (b *baseStmtParser) checkConditionDepth() error {
+	maxDepth := b.config.maxExprDepth()
+	if depth := stmt.Depth(b.condition); depth > maxDepth {
+		return fmt.Errorf("condition expression depth[%d] exceeds the max depth[%d]", depth, maxDepth)
+	}
+	return nil
+}
+
 // visitLimit visits when production limit expression is entered
 func (b *baseStmtParser) visitLimit(ctx *grammar.LimitClauseContext) {
 	if ctx.L_INT() == nil {
@@ -52,6 +65,10 @@ func (b *baseStmtParser) visitTagFilterExpr(ctx *grammar.TagFilterExprContext) {
 	switch {
 	case ctx.TagKey() != nil:
 		expr = b.createTagFilterExpr(tagKey, ctx)
+	case ctx.L_ID() != nil:
+		expr = b.createCompareTagFilterExpr(ctx)
+	case ctx.T_NOT() != nil:
+		expr = &stmt.NotExpr{Expr: &stmt.ParenExpr{}}
 	case ctx.T_OPEN_P() != nil:
 		expr = &stmt.ParenExpr{}
 	case ctx.T_AND() != nil:
@@ -69,26 +86,81 @@ func (b *baseStmtParser) visitTagValue(ctx *grammar.TagValueContext) {
 		return
 	}
 	tagFilterExpr := b.exprStack.Peek()
-	tagValue := strutil.GetStringValue(ctx.Ident().GetText())
+	var tagValue string
+	literal := ctx.Ident() == nil
+	switch {
+	case ctx.Ident() != nil:
+		if strings.EqualFold(ctx.Ident().GetText(), "null") && b.visitNullTagValue(tagFilterExpr) {
+			return
+		}
+		tagValue = getTagValue(ctx.Ident().GetText())
+	case ctx.DecNumber() != nil:
+		tagValue = ctx.DecNumber().GetText()
+	case ctx.IntNumber() != nil:
+		tagValue = ctx.IntNumber().GetText()
+	}
 	switch expr := tagFilterExpr.(type) {
 	case *stmt.NotExpr:
-		b.setTagFilterExprValue(expr.Expr, tagValue)
+		b.setTagFilterExprValue(expr.Expr, tagValue, literal)
 	case stmt.Expr:
-		b.setTagFilterExprValue(expr, tagValue)
+		b.setTagFilterExprValue(expr, tagValue, literal)
+	}
+}
+
+// visitNullTagValue replaces the tag filter compared with unquoted null by tag existence filter,
+// tag != null => exists expr(has the tag), tag = null => not exists expr(lacks the tag),
+// returns false if tag filter isn't equals/not equals, then null is treated as the string value.
+func (b *baseStmtParser) visitNullTagValue(tagFilterExpr interface{}) bool {
+	var expr stmt.Expr
+	switch e := tagFilterExpr.(type) {
+	case *stmt.EqualsExpr:
+		expr = &stmt.NotExpr{Expr: &stmt.ExistsExpr{Key: e.Key}}
+	case *stmt.NotExpr:
+		equals, ok := e.Expr.(*stmt.EqualsExpr)
+		if !ok {
+			return false
+		}
+		expr = &stmt.ExistsExpr{Key: equals.Key}
+	default:
+		return false
 	}
+	b.exprStack.Pop()
+	b.exprStack.Push(expr)
+	return true
 }
 
-// setTagFilterExprValue sets tag value for tag filter expression
-func (b *baseStmtParser) setTagFilterExprValue(expr stmt.Expr, tagValue string) {
+// getTagValue returns the string value of tag value ident,
+// unquoted boolean literal(true/false) is treated as the string value like quoted 'true'/'false'.
+func getTagValue(ident string) string {
+	if strings.EqualFold(ident, "true") || strings.EqualFold(ident, "false") {
+		return strings.ToLower(ident)
+	}
+	return strutil.GetStringValue(ident)
+}
+
+// setTagFilterExprValue sets tag value for tag filter expression,
+// literal is true if the tag value is a number literal, equals expr keeps literal flag only if both sides are numbers.
+func (b *baseStmtParser) setTagFilterExprValue(expr stmt.Expr, tagValue string, literal bool) {
 	switch e := expr.(type) {
 	case *stmt.EqualsExpr:
 		e.Value = tagValue
+		e.Literal = e.Literal && literal
 	case *stmt.LikeExpr:
 		e.Value = tagValue
 	case *stmt.RegexExpr:
 		e.Regexp = tagValue
 	case *stmt.InExpr:
 		e.Values = append(e.Values, tagValue)
+	case *stmt.AllOfExpr:
+		e.Values = append(e.Values, tagValue)
+	case *stmt.GreaterExpr:
+		e.Value = tagValue
+	case *stmt.GreaterEqualExpr:
+		e.Value = tagValue
+	case *stmt.LessExpr:
+		e.Value = tagValue
+	case *stmt.LessEqualExpr:
+		e.Value = tagValue
 	}
 }
 
@@ -98,10 +170,10 @@ func (b *baseStmtParser) createTagFilterExpr(tagKey grammar.ITagKeyContext,
 	tagKeyCtx, ok := tagKey.(*grammar.TagKeyContext)
 	var expr stmt.Expr
 	if ok {
-		tagKeyStr := strutil.GetStringValue(tagKeyCtx.Ident().GetText())
+		tagKeyStr, literal := getTagKey(tagKeyCtx)
 		switch {
 		case ctx.T_EQUAL() != nil:
-			expr = &stmt.EqualsExpr{Key: tagKeyStr}
+			expr = &stmt.EqualsExpr{Key: tagKeyStr, Literal: literal}
 		case ctx.T_LIKE() != nil:
 			if ctx.T_NOT() != nil {
 				expr = &stmt.NotExpr{Expr: &stmt.LikeExpr{Key: tagKeyStr}}
@@ -113,18 +185,50 @@ func (b *baseStmtParser) createTagFilterExpr(tagKey grammar.ITagKeyContext,
 		case ctx.T_NEQREGEXP() != nil:
 			expr = &stmt.NotExpr{Expr: &stmt.RegexExpr{Key: tagKeyStr}}
 		case ctx.T_NOTEQUAL() != nil || ctx.T_NOTEQUAL2() != nil:
-			expr = &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: tagKeyStr}}
+			expr = &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: tagKeyStr, Literal: literal}}
 		case ctx.T_IN() != nil:
+			// grammar requires at least one tag value, so empty in list(in ()) is rejected as syntax error,
+			// empty in expr built by api matches nothing(see stmt.InExpr).
 			if ctx.T_NOT() != nil {
 				expr = &stmt.NotExpr{Expr: &stmt.InExpr{Key: tagKeyStr}}
 			} else {
 				expr = &stmt.InExpr{Key: tagKeyStr}
 			}
+		case ctx.T_CONTAINS_ALL() != nil:
+			expr = &stmt.AllOfExpr{Key: tagKeyStr}
 		}
 	}
 	return expr
 }
 
+// getTagKey returns the string value of tag key, and if the tag key is a number literal(like 1 of 1=1)
+func getTagKey(ctx *grammar.TagKeyContext) (tagKey string, literal bool) {
+	switch {
+	case ctx.DecNumber() != nil:
+		return ctx.DecNumber().GetText(), true
+	case ctx.IntNumber() != nil:
+		return ctx.IntNumber().GetText(), true
+	default:
+		return strutil.GetStringValue(ctx.Ident().GetText()), false
+	}
+}
+
+// createCompareTagFilterExpr creates tag filter expr of relational operators(>, >=, <, <=),
+// tag key only supports plain identifier, because time(keyword) is used by time range expr.
+func (b *baseStmtParser) createCompareTagFilterExpr(ctx *grammar.TagFilterExprContext) stmt.Expr {
+	tagKeyStr := strutil.GetStringValue(ctx.L_ID().GetText())
+	switch {
+	case ctx.T_GREATER() != nil:
+		return &stmt.GreaterExpr{Key: tagKeyStr}
+	case ctx.T_GREATEREQUAL() != nil:
+		return &stmt.GreaterEqualExpr{Key: tagKeyStr}
+	case ctx.T_LESS() != nil:
+		return &stmt.LessExpr{Key: tagKeyStr}
+	default:
+		return &stmt.LessEqualExpr{Key: tagKeyStr}
+	}
+}
+
 // completeTagFilterExpr completes a tag filter expression for query condition
 func (b *baseStmtParser) completeTagFilterExpr() {
 	expr := b.exprStack.Pop()
@@ -143,6 +247,10 @@ func (b *baseStmtParser) completeTagFilterExpr() {
 			}
 		case *stmt.ParenExpr:
 			parentExpr.Expr = e
+		case *stmt.NotExpr:
+			if paren, ok := parentExpr.Expr.(*stmt.ParenExpr); ok {
+				paren.Expr = e
+			}
 		}
 	}
 	b.condition = e
@@ -4,11 +4,25 @@ import (
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 )
 
+// syntaxError represents the syntax error reported by lexer/parser
+type syntaxError struct {
+	msg string
+}
+
+// Error returns the message of syntax error
+func (e *syntaxError) Error() string {
+	return e.msg
+}
+
+// errorListener represents the error listener of lexer/parser which stops parse at the first syntax error,
+// ambiguity reports of prediction are ignored by default error listener.
 type errorListener struct {
-	antlr.ErrorListener
+	*antlr.DefaultErrorListener
 }
 
+// SyntaxError panics with syntax error for stopping parse at the first error,
+// the panic will be recovered as error by parser.
 func (l *errorListener) SyntaxError(recognizer antlr.Recognizer,
 	offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
-	panic(msg)
+	panic(&syntaxError{msg: msg})
 }
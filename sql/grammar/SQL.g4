@@ -14,15 +14,15 @@ statementList           : showDatabaseStmt
 showDatabaseStmt     : T_SHOW T_DATASBAES ;
 showNameSpacesStmt   : T_SHOW T_NAMESPACES (T_WHERE T_NAMESPACE T_EQUAL prefix)? limitClause?;
 showMeasurementsStmt : T_SHOW T_MEASUREMENTS (T_ON namespace)? (T_WHERE T_MEASUREMENT T_EQUAL prefix)? limitClause?;
-showFieldsStmt       : T_SHOW T_FIELDS (T_ON namespace)? fromClause;
-showTagKeysStmt      : T_SHOW T_TAG T_KEYS (T_ON namespace)? fromClause;
+showFieldsStmt       : T_SHOW (T_FIELDS | T_FIELD T_KEYS) (T_ON namespace)? fromClause;
+showTagKeysStmt      : T_SHOW T_TAG T_KEYS (T_ON namespace)? fromClause limitClause?;
 showTagValuesStmt    : T_SHOW T_TAG T_VALUES (T_ON namespace)? fromClause T_WITH T_KEY T_EQUAL withTagKey whereClause? limitClause?;
 prefix               : ident ;
 withTagKey           : ident ;
 namespace            : ident ;
 
 //data query plan
-queryStmt               : T_EXPLAIN? selectExpr (T_ON namespace)? fromClause whereClause? groupByClause? orderByClause? limitClause? T_WITH_VALUE?;
+queryStmt               : (T_EXPLAIN L_ID?)? selectExpr (T_ON namespace)? fromClause whereClause? groupByClause? orderByClause? limitClause? T_WITH_VALUE?;
 selectExpr              : T_SELECT fields;
 //select fields
 fields                  : field ( T_COMMA field )* ;
@@ -30,32 +30,35 @@ field                   : fieldExpr alias? ;
 alias                   : T_AS ident ;
 
 //from clause
-fromClause              : T_FROM metricName ;
+fromClause              : T_FROM metricName alias? ;
 
 //where clause
 whereClause             : T_WHERE conditionExpr;
 
-conditionExpr           : tagFilterExpr | tagFilterExpr T_AND timeRangeExpr | timeRangeExpr (T_AND tagFilterExpr)?;
+conditionExpr           : tagFilterExpr (T_AND timeRangeExpr)? | timeRangeExpr (T_AND tagFilterExpr)?;
 
 tagFilterExpr           :
                          T_OPEN_P tagFilterExpr T_CLOSE_P
                         | tagKey (T_EQUAL | T_LIKE | T_NOT T_LIKE | T_REGEXP | T_NEQREGEXP | T_NOTEQUAL | T_NOTEQUAL2) tagValue
-                       | tagKey (T_IN | T_NOT T_IN) T_OPEN_P tagValueList T_CLOSE_P
+                       | tagKey (T_IN | T_NOT T_IN | T_CONTAINS_ALL) T_OPEN_P tagValueList T_CLOSE_P
+                       | T_NOT T_OPEN_P tagFilterExpr T_CLOSE_P
+                       | L_ID (T_LESS | T_LESSEQUAL | T_GREATER | T_GREATEREQUAL) tagValue
                        | tagFilterExpr (T_AND | T_OR) tagFilterExpr
                        ;
 
 tagValueList           : tagValue (T_COMMA tagValue)*;
 timeRangeExpr          : timeExpr (T_AND timeExpr)? ;
-timeExpr               : T_TIME binaryOperator (nowExpr | ident) ;
+timeExpr               : T_TIME binaryOperator (nowExpr | ident) | T_TIME T_IN T_OPEN_P timeBucket (T_COMMA timeBucket)* T_CLOSE_P ;
+timeBucket             : ident T_DOT T_DOT ident ;
 
 nowExpr                 : nowFunc  durationLit? ;
 
 nowFunc                 : T_NOW T_OPEN_P exprFuncParams? T_CLOSE_P ;
 
 //group by
-groupByClause          : T_GROUP T_BY groupByKeys (T_FILL T_OPEN_P fillOption T_CLOSE_P)? havingClause? ;
+groupByClause          : T_GROUP T_BY groupByKeys (L_ID durationLit)? (T_FILL T_OPEN_P fillOption T_CLOSE_P)? havingClause? ;
 groupByKeys            : groupByKey (T_COMMA groupByKey)* ;
-groupByKey             : ident | T_TIME T_OPEN_P durationLit T_CLOSE_P ;
+groupByKey             : ident | T_TIME T_OPEN_P durationLit T_CLOSE_P | T_MUL ;
 fillOption             : T_NULL | T_PREVIOUS | L_INT | L_DEC ;
 
 orderByClause          : T_ORDER T_BY sortFields ;
@@ -103,8 +106,8 @@ intervalItem            :
                          | T_MONTH
                          | T_YEAR
                          ;
-exprFunc                : funcName T_OPEN_P exprFuncParams? T_CLOSE_P ;
-funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_COUNT | T_STDDEV | T_HISTOGRAM;
+exprFunc                : funcName T_OPEN_P (L_ID ident | exprFuncParams)? T_CLOSE_P ;
+funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_COUNT | T_STDDEV | T_HISTOGRAM | T_SAMPLE | T_MAX_TIME | T_MIN_TIME | T_INCREASE | T_NONNEGATIVE_DERIVATIVE | T_DISTINCT_COUNT;
 exprFuncParams          : funcParam (T_COMMA funcParam)* ;
 funcParam               :
                            fieldExpr
@@ -123,8 +126,8 @@ intNumber               : ('-' | '+')? L_INT ;
 decNumber               : ('-' | '+')? L_DEC ;
 limitClause             : T_LIMIT L_INT ;
 metricName              : ident ;
-tagKey                  : ident ;
-tagValue                : ident ;
+tagKey                  : ident | decNumber | intNumber ;
+tagValue                : ident | decNumber | intNumber ;
 ident                    :  (L_ID | nonReservedWords) ('.' (L_ID | nonReservedWords))* ;
 
 
@@ -190,6 +193,13 @@ nonReservedWords      :
                         | T_AVG
                         | T_STDDEV
                         | T_HISTOGRAM
+                        | T_SAMPLE
+                        | T_MAX_TIME
+                        | T_MIN_TIME
+                        | T_INCREASE
+                        | T_NONNEGATIVE_DERIVATIVE
+                        | T_DISTINCT_COUNT
+                        | T_CONTAINS_ALL
                         ;
 
 // Lexer rules
@@ -264,6 +274,13 @@ T_COUNT              : C O U N T                        ;
 T_AVG                : A V G                            ;
 T_STDDEV             : S T D D E V                      ;
 T_HISTOGRAM          : H I S T O G R A M                ;
+T_SAMPLE             : S A M P L E                      ;
+T_MAX_TIME           : M A X '_' T I M E                ;
+T_MIN_TIME           : M I N '_' T I M E                ;
+T_INCREASE           : I N C R E A S E                  ;
+T_NONNEGATIVE_DERIVATIVE : N O N N E G A T I V E '_' D E R I V A T I V E ;
+T_DISTINCT_COUNT     : D I S T I N C T '_' C O U N T    ;
+T_CONTAINS_ALL       : C O N T A I N S '_' A L L        ;
 
 //time unit
 T_SECOND             : S                                ;
@@ -301,8 +318,8 @@ T_MOD                :  '%'   ;
 
 L_ID                 : L_ID_PART ;
 L_INT                : L_DIGIT+       ;                                               // Integer
-L_DEC                : L_DIGIT+ '.' ~'.' L_DIGIT*                               // Decimal number
-                     | '.' L_DIGIT+
+L_DEC                : (L_DIGIT+ '.' ~'.' L_DIGIT* | '.' L_DIGIT+) L_EXP?         // Decimal number
+                     | L_DIGIT+ L_EXP                                          // Scientific notation
                      ;
 WS                   : BLANK+ -> skip ;                                        //Whitespace
 
@@ -316,7 +333,7 @@ fragment L_ID_PART   :
                       | ('_' | '@' | ':' | '#' | '$') ([a-zA-Z] | L_DIGIT | '_' | '@' | ':' | '#' | '$')+     // (at least one char must follow special char)
                       | '"' .*? '"'                                                                           // Quoted identifiers
                       | '`' .*? '`'                                                                           // Quoted identifiers
-                      | '\'' .*? '\''                                                                           // Quoted identifiers
+                      | '\'' (~'\'' | '\'\'')* '\''                                                           // Quoted identifiers, single quote is escaped by doubling it('')
                      ;
 
 // Support case-insensitive keywords and allowing case-sensitive identifiers
@@ -345,4 +362,5 @@ fragment V : ('v'|'V') ;
 fragment W : ('w'|'W') ;
 fragment X : ('x'|'X') ;
 fragment Y : ('y'|'Y') ;
-fragment Z : ('z'|'Z') ;
\ No newline at end of file
+fragment Z : ('z'|'Z') ;
+fragment L_EXP       : E ('+' | '-')? L_DIGIT+ ;                               // Exponent of scientific notation
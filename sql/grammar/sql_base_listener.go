@@ -349,3 +349,9 @@ func (s *BaseSQLListener) EnterNonReservedWords(ctx *NonReservedWordsContext) {}
 
 // ExitNonReservedWords is called when production nonReservedWords is exited.
 func (s *BaseSQLListener) ExitNonReservedWords(ctx *NonReservedWordsContext) {}
+
+// EnterTimeBucket is called when production timeBucket is entered.
+func (s *BaseSQLListener) EnterTimeBucket(ctx *TimeBucketContext) {}
+
+// ExitTimeBucket is called when production timeBucket is exited.
+func (s *BaseSQLListener) ExitTimeBucket(ctx *TimeBucketContext) {}
@@ -13,7 +13,7 @@ var _ = unicode.IsLetter
 
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 105, 902, 
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 112, 1025, 
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 
@@ -104,8 +104,22 @@ var serializedLexerAtn = []uint16{
 	3, 119, 3, 119, 3, 120, 3, 120, 3, 121, 3, 121, 3, 122, 3, 122, 3, 123, 
 	3, 123, 3, 124, 3, 124, 3, 125, 3, 125, 3, 126, 3, 126, 3, 127, 3, 127, 
 	3, 128, 3, 128, 3, 129, 3, 129, 3, 130, 3, 130, 3, 131, 3, 131, 3, 132, 
-	3, 132, 3, 133, 3, 133, 6, 812, 828, 836, 844, 2, 134, 3, 3, 5, 4, 7, 5, 
-	9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 
+	3, 132, 3, 133, 3, 133, 4, 134, 9, 134, 3, 134, 3, 134, 10, 134, 5, 134, 
+	906, 3, 134, 3, 134, 10, 134, 6, 134, 910, 3, 134, 13, 134, 14, 134, 913, 
+	10, 103, 5, 103, 915, 3, 103, 10, 103, 6, 103, 918, 3, 103, 13, 103, 14, 
+	103, 921, 3, 103, 4, 135, 9, 135, 3, 135, 3, 135, 3, 135, 3, 135, 3, 135, 
+	3, 135, 3, 135, 3, 107, 3, 107, 4, 136, 9, 136, 3, 136, 3, 136, 3, 136, 
+	3, 136, 3, 136, 3, 136, 3, 136, 3, 136, 3, 136, 4, 137, 9, 137, 3, 137, 
+	3, 137, 3, 137, 3, 137, 3, 137, 3, 137, 3, 137, 3, 137, 3, 137, 4, 138, 
+	9, 138, 3, 138, 3, 138, 3, 138, 3, 138, 3, 138, 3, 138, 3, 138, 3, 138, 
+	3, 138, 4, 139, 9, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 
+	3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 
+	3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 3, 139, 4, 140, 
+	9, 140, 3, 140, 3, 140, 3, 140, 3, 140, 3, 140, 3, 140, 3, 140, 3, 140, 
+	3, 140, 3, 140, 3, 140, 3, 140, 3, 140, 3, 140, 3, 140, 4, 141, 9, 141, 
+	3, 141, 3, 141, 3, 141, 3, 141, 3, 141, 3, 141, 3, 141, 3, 141, 3, 141, 
+	3, 141, 3, 141, 3, 141, 3, 141, 5, 812, 828, 836, 2, 142, 3, 3, 5, 4, 7, 
+	5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 
 	15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45, 
 	24, 47, 25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61, 32, 63, 
 	33, 65, 34, 67, 35, 69, 36, 71, 37, 73, 38, 75, 39, 77, 40, 79, 41, 81, 
@@ -120,305 +134,350 @@ var serializedLexerAtn = []uint16{
 	211, 2, 213, 2, 215, 2, 217, 2, 219, 2, 221, 2, 223, 2, 225, 2, 227, 2, 
 	229, 2, 231, 2, 233, 2, 235, 2, 237, 2, 239, 2, 241, 2, 243, 2, 245, 2, 
 	247, 2, 249, 2, 251, 2, 253, 2, 255, 2, 257, 2, 259, 2, 261, 2, 263, 2, 
-	265, 2, 3, 2, 34, 3, 2, 48, 48, 5, 2, 11, 12, 15, 15, 34, 34, 3, 2, 50, 
-	59, 4, 2, 67, 92, 99, 124, 4, 2, 48, 48, 97, 97, 6, 2, 37, 38, 60, 60, 
-	66, 66, 97, 97, 4, 2, 67, 67, 99, 99, 4, 2, 68, 68, 100, 100, 4, 2, 69, 
-	69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2, 71, 71, 103, 103, 4, 2, 72, 
-	72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2, 74, 74, 106, 106, 4, 2, 75, 
-	75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2, 77, 77, 109, 109, 4, 2, 78, 
-	78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2, 80, 80, 112, 112, 4, 2, 81, 
-	81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2, 83, 83, 115, 115, 4, 2, 84, 
-	84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2, 86, 86, 118, 118, 4, 2, 87, 
-	87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2, 89, 89, 121, 121, 4, 2, 90, 
-	90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2, 92, 92, 124, 124, 2, 893, 2, 
-	3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 
-	11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 
-	2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 
-	2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 
-	2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 
-	2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 
-	3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 2, 55, 3, 2, 2, 2, 2, 
-	57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 2, 2, 2, 63, 3, 2, 2, 2, 
-	2, 65, 3, 2, 2, 2, 2, 67, 3, 2, 2, 2, 2, 69, 3, 2, 2, 2, 2, 71, 3, 2, 2, 
-	2, 2, 73, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 77, 3, 2, 2, 2, 2, 79, 3, 2, 
-	2, 2, 2, 81, 3, 2, 2, 2, 2, 83, 3, 2, 2, 2, 2, 85, 3, 2, 2, 2, 2, 87, 3, 
-	2, 2, 2, 2, 89, 3, 2, 2, 2, 2, 91, 3, 2, 2, 2, 2, 93, 3, 2, 2, 2, 2, 95, 
-	3, 2, 2, 2, 2, 97, 3, 2, 2, 2, 2, 99, 3, 2, 2, 2, 2, 101, 3, 2, 2, 2, 2, 
-	103, 3, 2, 2, 2, 2, 105, 3, 2, 2, 2, 2, 107, 3, 2, 2, 2, 2, 109, 3, 2, 
-	2, 2, 2, 111, 3, 2, 2, 2, 2, 113, 3, 2, 2, 2, 2, 115, 3, 2, 2, 2, 2, 117, 
-	3, 2, 2, 2, 2, 119, 3, 2, 2, 2, 2, 121, 3, 2, 2, 2, 2, 123, 3, 2, 2, 2, 
-	2, 125, 3, 2, 2, 2, 2, 127, 3, 2, 2, 2, 2, 129, 3, 2, 2, 2, 2, 131, 3, 
-	2, 2, 2, 2, 133, 3, 2, 2, 2, 2, 135, 3, 2, 2, 2, 2, 137, 3, 2, 2, 2, 2, 
-	139, 3, 2, 2, 2, 2, 141, 3, 2, 2, 2, 2, 143, 3, 2, 2, 2, 2, 145, 3, 2, 
-	2, 2, 2, 147, 3, 2, 2, 2, 2, 149, 3, 2, 2, 2, 2, 151, 3, 2, 2, 2, 2, 153, 
-	3, 2, 2, 2, 2, 155, 3, 2, 2, 2, 2, 157, 3, 2, 2, 2, 2, 159, 3, 2, 2, 2, 
-	2, 161, 3, 2, 2, 2, 2, 163, 3, 2, 2, 2, 2, 165, 3, 2, 2, 2, 2, 167, 3, 
-	2, 2, 2, 2, 169, 3, 2, 2, 2, 2, 171, 3, 2, 2, 2, 2, 173, 3, 2, 2, 2, 2, 
-	175, 3, 2, 2, 2, 2, 177, 3, 2, 2, 2, 2, 179, 3, 2, 2, 2, 2, 181, 3, 2, 
-	2, 2, 2, 183, 3, 2, 2, 2, 2, 185, 3, 2, 2, 2, 2, 187, 3, 2, 2, 2, 2, 189, 
-	3, 2, 2, 2, 2, 191, 3, 2, 2, 2, 2, 193, 3, 2, 2, 2, 2, 195, 3, 2, 2, 2, 
-	2, 197, 3, 2, 2, 2, 2, 199, 3, 2, 2, 2, 2, 201, 3, 2, 2, 2, 2, 203, 3, 
-	2, 2, 2, 2, 205, 3, 2, 2, 2, 2, 207, 3, 2, 2, 2, 3, 267, 3, 2, 2, 2, 5, 
-	274, 3, 2, 2, 2, 7, 281, 3, 2, 2, 2, 9, 285, 3, 2, 2, 2, 11, 290, 3, 2, 
-	2, 2, 13, 299, 3, 2, 2, 2, 15, 304, 3, 2, 2, 2, 17, 310, 3, 2, 2, 2, 19, 
-	322, 3, 2, 2, 2, 21, 326, 3, 2, 2, 2, 23, 334, 3, 2, 2, 2, 25, 342, 3, 
-	2, 2, 2, 27, 352, 3, 2, 2, 2, 29, 357, 3, 2, 2, 2, 31, 360, 3, 2, 2, 2, 
-	33, 365, 3, 2, 2, 2, 35, 374, 3, 2, 2, 2, 37, 384, 3, 2, 2, 2, 39, 394, 
-	3, 2, 2, 2, 41, 405, 3, 2, 2, 2, 43, 410, 3, 2, 2, 2, 45, 423, 3, 2, 2, 
-	2, 47, 435, 3, 2, 2, 2, 49, 441, 3, 2, 2, 2, 51, 448, 3, 2, 2, 2, 53, 452, 
-	3, 2, 2, 2, 55, 457, 3, 2, 2, 2, 57, 462, 3, 2, 2, 2, 59, 466, 3, 2, 2, 
-	2, 61, 471, 3, 2, 2, 2, 63, 478, 3, 2, 2, 2, 65, 484, 3, 2, 2, 2, 67, 489, 
-	3, 2, 2, 2, 69, 495, 3, 2, 2, 2, 71, 501, 3, 2, 2, 2, 73, 509, 3, 2, 2, 
-	2, 75, 515, 3, 2, 2, 2, 77, 523, 3, 2, 2, 2, 79, 533, 3, 2, 2, 2, 81, 540, 
-	3, 2, 2, 2, 83, 543, 3, 2, 2, 2, 85, 547, 3, 2, 2, 2, 87, 550, 3, 2, 2, 
-	2, 89, 555, 3, 2, 2, 2, 91, 560, 3, 2, 2, 2, 93, 569, 3, 2, 2, 2, 95, 575, 
-	3, 2, 2, 2, 97, 579, 3, 2, 2, 2, 99, 584, 3, 2, 2, 2, 101, 589, 3, 2, 2, 
-	2, 103, 593, 3, 2, 2, 2, 105, 601, 3, 2, 2, 2, 107, 604, 3, 2, 2, 2, 109, 
-	610, 3, 2, 2, 2, 111, 617, 3, 2, 2, 2, 113, 620, 3, 2, 2, 2, 115, 624, 
-	3, 2, 2, 2, 117, 630, 3, 2, 2, 2, 119, 635, 3, 2, 2, 2, 121, 639, 3, 2, 
-	2, 2, 123, 642, 3, 2, 2, 2, 125, 646, 3, 2, 2, 2, 127, 654, 3, 2, 2, 2, 
-	129, 658, 3, 2, 2, 2, 131, 662, 3, 2, 2, 2, 133, 666, 3, 2, 2, 2, 135, 
-	672, 3, 2, 2, 2, 137, 676, 3, 2, 2, 2, 139, 683, 3, 2, 2, 2, 141, 693, 
-	3, 2, 2, 2, 143, 695, 3, 2, 2, 2, 145, 697, 3, 2, 2, 2, 147, 699, 3, 2, 
-	2, 2, 149, 701, 3, 2, 2, 2, 151, 703, 3, 2, 2, 2, 153, 705, 3, 2, 2, 2, 
-	155, 707, 3, 2, 2, 2, 157, 709, 3, 2, 2, 2, 159, 711, 3, 2, 2, 2, 161, 
-	713, 3, 2, 2, 2, 163, 716, 3, 2, 2, 2, 165, 719, 3, 2, 2, 2, 167, 721, 
-	3, 2, 2, 2, 169, 724, 3, 2, 2, 2, 171, 726, 3, 2, 2, 2, 173, 729, 3, 2, 
-	2, 2, 175, 732, 3, 2, 2, 2, 177, 735, 3, 2, 2, 2, 179, 737, 3, 2, 2, 2, 
-	181, 739, 3, 2, 2, 2, 183, 741, 3, 2, 2, 2, 185, 743, 3, 2, 2, 2, 187, 
-	745, 3, 2, 2, 2, 189, 747, 3, 2, 2, 2, 191, 749, 3, 2, 2, 2, 193, 751, 
-	3, 2, 2, 2, 195, 753, 3, 2, 2, 2, 197, 755, 3, 2, 2, 2, 199, 757, 3, 2, 
-	2, 2, 201, 759, 3, 2, 2, 2, 203, 762, 3, 2, 2, 2, 205, 785, 3, 2, 2, 2, 
-	207, 788, 3, 2, 2, 2, 209, 794, 3, 2, 2, 2, 211, 796, 3, 2, 2, 2, 213, 
-	848, 3, 2, 2, 2, 215, 850, 3, 2, 2, 2, 217, 852, 3, 2, 2, 2, 219, 854, 
-	3, 2, 2, 2, 221, 856, 3, 2, 2, 2, 223, 858, 3, 2, 2, 2, 225, 860, 3, 2, 
-	2, 2, 227, 862, 3, 2, 2, 2, 229, 864, 3, 2, 2, 2, 231, 866, 3, 2, 2, 2, 
-	233, 868, 3, 2, 2, 2, 235, 870, 3, 2, 2, 2, 237, 872, 3, 2, 2, 2, 239, 
-	874, 3, 2, 2, 2, 241, 876, 3, 2, 2, 2, 243, 878, 3, 2, 2, 2, 245, 880, 
-	3, 2, 2, 2, 247, 882, 3, 2, 2, 2, 249, 884, 3, 2, 2, 2, 251, 886, 3, 2, 
-	2, 2, 253, 888, 3, 2, 2, 2, 255, 890, 3, 2, 2, 2, 257, 892, 3, 2, 2, 2, 
-	259, 894, 3, 2, 2, 2, 261, 896, 3, 2, 2, 2, 263, 898, 3, 2, 2, 2, 265, 
-	900, 3, 2, 2, 2, 267, 268, 5, 219, 110, 2, 268, 269, 5, 249, 125, 2, 269, 
-	270, 5, 223, 112, 2, 270, 271, 5, 215, 108, 2, 271, 272, 5, 253, 127, 2, 
-	272, 273, 5, 223, 112, 2, 273, 4, 3, 2, 2, 2, 274, 275, 5, 255, 128, 2, 
-	275, 276, 5, 245, 123, 2, 276, 277, 5, 221, 111, 2, 277, 278, 5, 215, 108, 
-	2, 278, 279, 5, 253, 127, 2, 279, 280, 5, 223, 112, 2, 280, 6, 3, 2, 2, 
-	2, 281, 282, 5, 251, 126, 2, 282, 283, 5, 223, 112, 2, 283, 284, 5, 253, 
-	127, 2, 284, 8, 3, 2, 2, 2, 285, 286, 5, 221, 111, 2, 286, 287, 5, 249, 
-	125, 2, 287, 288, 5, 243, 122, 2, 288, 289, 5, 245, 123, 2, 289, 10, 3, 
-	2, 2, 2, 290, 291, 5, 231, 116, 2, 291, 292, 5, 241, 121, 2, 292, 293, 
-	5, 253, 127, 2, 293, 294, 5, 223, 112, 2, 294, 295, 5, 249, 125, 2, 295, 
-	296, 5, 257, 129, 2, 296, 297, 5, 215, 108, 2, 297, 298, 5, 237, 119, 2, 
-	298, 12, 3, 2, 2, 2, 299, 300, 5, 241, 121, 2, 300, 301, 5, 215, 108, 2, 
-	301, 302, 5, 239, 120, 2, 302, 303, 5, 223, 112, 2, 303, 14, 3, 2, 2, 2, 
-	304, 305, 5, 251, 126, 2, 305, 306, 5, 229, 115, 2, 306, 307, 5, 215, 108, 
-	2, 307, 308, 5, 249, 125, 2, 308, 309, 5, 221, 111, 2, 309, 16, 3, 2, 2, 
-	2, 310, 311, 5, 249, 125, 2, 311, 312, 5, 223, 112, 2, 312, 313, 5, 245, 
-	123, 2, 313, 314, 5, 237, 119, 2, 314, 315, 5, 231, 116, 2, 315, 316, 5, 
-	219, 110, 2, 316, 317, 5, 215, 108, 2, 317, 318, 5, 253, 127, 2, 318, 319, 
-	5, 231, 116, 2, 319, 320, 5, 243, 122, 2, 320, 321, 5, 241, 121, 2, 321, 
-	18, 3, 2, 2, 2, 322, 323, 5, 253, 127, 2, 323, 324, 5, 253, 127, 2, 324, 
-	325, 5, 237, 119, 2, 325, 20, 3, 2, 2, 2, 326, 327, 5, 239, 120, 2, 327, 
-	328, 5, 223, 112, 2, 328, 329, 5, 253, 127, 2, 329, 330, 5, 215, 108, 2, 
-	330, 331, 5, 253, 127, 2, 331, 332, 5, 253, 127, 2, 332, 333, 5, 237, 119, 
-	2, 333, 22, 3, 2, 2, 2, 334, 335, 5, 245, 123, 2, 335, 336, 5, 215, 108, 
-	2, 336, 337, 5, 251, 126, 2, 337, 338, 5, 253, 127, 2, 338, 339, 5, 253, 
-	127, 2, 339, 340, 5, 253, 127, 2, 340, 341, 5, 237, 119, 2, 341, 24, 3, 
-	2, 2, 2, 342, 343, 5, 225, 113, 2, 343, 344, 5, 255, 128, 2, 344, 345, 
-	5, 253, 127, 2, 345, 346, 5, 255, 128, 2, 346, 347, 5, 249, 125, 2, 347, 
-	348, 5, 223, 112, 2, 348, 349, 5, 253, 127, 2, 349, 350, 5, 253, 127, 2, 
-	350, 351, 5, 237, 119, 2, 351, 26, 3, 2, 2, 2, 352, 353, 5, 235, 118, 2, 
-	353, 354, 5, 231, 116, 2, 354, 355, 5, 237, 119, 2, 355, 356, 5, 237, 119, 
-	2, 356, 28, 3, 2, 2, 2, 357, 358, 5, 243, 122, 2, 358, 359, 5, 241, 121, 
-	2, 359, 30, 3, 2, 2, 2, 360, 361, 5, 251, 126, 2, 361, 362, 5, 229, 115, 
-	2, 362, 363, 5, 243, 122, 2, 363, 364, 5, 259, 130, 2, 364, 32, 3, 2, 2, 
-	2, 365, 366, 5, 221, 111, 2, 366, 367, 5, 215, 108, 2, 367, 368, 5, 253, 
-	127, 2, 368, 369, 5, 215, 108, 2, 369, 370, 5, 217, 109, 2, 370, 371, 5, 
-	215, 108, 2, 371, 372, 5, 251, 126, 2, 372, 373, 5, 223, 112, 2, 373, 34, 
-	3, 2, 2, 2, 374, 375, 5, 221, 111, 2, 375, 376, 5, 215, 108, 2, 376, 377, 
-	5, 253, 127, 2, 377, 378, 5, 215, 108, 2, 378, 379, 5, 217, 109, 2, 379, 
-	380, 5, 215, 108, 2, 380, 381, 5, 251, 126, 2, 381, 382, 5, 223, 112, 2, 
-	382, 383, 5, 251, 126, 2, 383, 36, 3, 2, 2, 2, 384, 385, 5, 241, 121, 2, 
-	385, 386, 5, 215, 108, 2, 386, 387, 5, 239, 120, 2, 387, 388, 5, 223, 112, 
-	2, 388, 389, 5, 251, 126, 2, 389, 390, 5, 245, 123, 2, 390, 391, 5, 215, 
-	108, 2, 391, 392, 5, 219, 110, 2, 392, 393, 5, 223, 112, 2, 393, 38, 3, 
-	2, 2, 2, 394, 395, 5, 241, 121, 2, 395, 396, 5, 215, 108, 2, 396, 397, 
-	5, 239, 120, 2, 397, 398, 5, 223, 112, 2, 398, 399, 5, 251, 126, 2, 399, 
-	400, 5, 245, 123, 2, 400, 401, 5, 215, 108, 2, 401, 402, 5, 219, 110, 2, 
-	402, 403, 5, 223, 112, 2, 403, 404, 5, 251, 126, 2, 404, 40, 3, 2, 2, 2, 
-	405, 406, 5, 241, 121, 2, 406, 407, 5, 243, 122, 2, 407, 408, 5, 221, 111, 
-	2, 408, 409, 5, 223, 112, 2, 409, 42, 3, 2, 2, 2, 410, 411, 5, 239, 120, 
-	2, 411, 412, 5, 223, 112, 2, 412, 413, 5, 215, 108, 2, 413, 414, 5, 251, 
-	126, 2, 414, 415, 5, 255, 128, 2, 415, 416, 5, 249, 125, 2, 416, 417, 5, 
-	223, 112, 2, 417, 418, 5, 239, 120, 2, 418, 419, 5, 223, 112, 2, 419, 420, 
-	5, 241, 121, 2, 420, 421, 5, 253, 127, 2, 421, 422, 5, 251, 126, 2, 422, 
-	44, 3, 2, 2, 2, 423, 424, 5, 239, 120, 2, 424, 425, 5, 223, 112, 2, 425, 
-	426, 5, 215, 108, 2, 426, 427, 5, 251, 126, 2, 427, 428, 5, 255, 128, 2, 
-	428, 429, 5, 249, 125, 2, 429, 430, 5, 223, 112, 2, 430, 431, 5, 239, 120, 
-	2, 431, 432, 5, 223, 112, 2, 432, 433, 5, 241, 121, 2, 433, 434, 5, 253, 
-	127, 2, 434, 46, 3, 2, 2, 2, 435, 436, 5, 225, 113, 2, 436, 437, 5, 231, 
-	116, 2, 437, 438, 5, 223, 112, 2, 438, 439, 5, 237, 119, 2, 439, 440, 5, 
-	221, 111, 2, 440, 48, 3, 2, 2, 2, 441, 442, 5, 225, 113, 2, 442, 443, 5, 
-	231, 116, 2, 443, 444, 5, 223, 112, 2, 444, 445, 5, 237, 119, 2, 445, 446, 
-	5, 221, 111, 2, 446, 447, 5, 251, 126, 2, 447, 50, 3, 2, 2, 2, 448, 449, 
-	5, 253, 127, 2, 449, 450, 5, 215, 108, 2, 450, 451, 5, 227, 114, 2, 451, 
-	52, 3, 2, 2, 2, 452, 453, 5, 231, 116, 2, 453, 454, 5, 241, 121, 2, 454, 
-	455, 5, 225, 113, 2, 455, 456, 5, 243, 122, 2, 456, 54, 3, 2, 2, 2, 457, 
-	458, 5, 235, 118, 2, 458, 459, 5, 223, 112, 2, 459, 460, 5, 263, 132, 2, 
-	460, 461, 5, 251, 126, 2, 461, 56, 3, 2, 2, 2, 462, 463, 5, 235, 118, 2, 
-	463, 464, 5, 223, 112, 2, 464, 465, 5, 263, 132, 2, 465, 58, 3, 2, 2, 2, 
-	466, 467, 5, 259, 130, 2, 467, 468, 5, 231, 116, 2, 468, 469, 5, 253, 127, 
-	2, 469, 470, 5, 229, 115, 2, 470, 60, 3, 2, 2, 2, 471, 472, 5, 257, 129, 
-	2, 472, 473, 5, 215, 108, 2, 473, 474, 5, 237, 119, 2, 474, 475, 5, 255, 
-	128, 2, 475, 476, 5, 223, 112, 2, 476, 477, 5, 251, 126, 2, 477, 62, 3, 
-	2, 2, 2, 478, 479, 5, 257, 129, 2, 479, 480, 5, 215, 108, 2, 480, 481, 
-	5, 237, 119, 2, 481, 482, 5, 255, 128, 2, 482, 483, 5, 223, 112, 2, 483, 
-	64, 3, 2, 2, 2, 484, 485, 5, 225, 113, 2, 485, 486, 5, 249, 125, 2, 486, 
-	487, 5, 243, 122, 2, 487, 488, 5, 239, 120, 2, 488, 66, 3, 2, 2, 2, 489, 
-	490, 5, 259, 130, 2, 490, 491, 5, 229, 115, 2, 491, 492, 5, 223, 112, 2, 
-	492, 493, 5, 249, 125, 2, 493, 494, 5, 223, 112, 2, 494, 68, 3, 2, 2, 2, 
-	495, 496, 5, 237, 119, 2, 496, 497, 5, 231, 116, 2, 497, 498, 5, 239, 120, 
-	2, 498, 499, 5, 231, 116, 2, 499, 500, 5, 253, 127, 2, 500, 70, 3, 2, 2, 
-	2, 501, 502, 5, 247, 124, 2, 502, 503, 5, 255, 128, 2, 503, 504, 5, 223, 
-	112, 2, 504, 505, 5, 249, 125, 2, 505, 506, 5, 231, 116, 2, 506, 507, 5, 
-	223, 112, 2, 507, 508, 5, 251, 126, 2, 508, 72, 3, 2, 2, 2, 509, 510, 5, 
-	247, 124, 2, 510, 511, 5, 255, 128, 2, 511, 512, 5, 223, 112, 2, 512, 513, 
-	5, 249, 125, 2, 513, 514, 5, 263, 132, 2, 514, 74, 3, 2, 2, 2, 515, 516, 
-	5, 223, 112, 2, 516, 517, 5, 261, 131, 2, 517, 518, 5, 245, 123, 2, 518, 
-	519, 5, 237, 119, 2, 519, 520, 5, 215, 108, 2, 520, 521, 5, 231, 116, 2, 
-	521, 522, 5, 241, 121, 2, 522, 76, 3, 2, 2, 2, 523, 524, 5, 259, 130, 2, 
-	524, 525, 5, 231, 116, 2, 525, 526, 5, 253, 127, 2, 526, 527, 5, 229, 115, 
-	2, 527, 528, 5, 257, 129, 2, 528, 529, 5, 215, 108, 2, 529, 530, 5, 237, 
-	119, 2, 530, 531, 5, 255, 128, 2, 531, 532, 5, 223, 112, 2, 532, 78, 3, 
-	2, 2, 2, 533, 534, 5, 251, 126, 2, 534, 535, 5, 223, 112, 2, 535, 536, 
-	5, 237, 119, 2, 536, 537, 5, 223, 112, 2, 537, 538, 5, 219, 110, 2, 538, 
-	539, 5, 253, 127, 2, 539, 80, 3, 2, 2, 2, 540, 541, 5, 215, 108, 2, 541, 
-	542, 5, 251, 126, 2, 542, 82, 3, 2, 2, 2, 543, 544, 5, 215, 108, 2, 544, 
-	545, 5, 241, 121, 2, 545, 546, 5, 221, 111, 2, 546, 84, 3, 2, 2, 2, 547, 
-	548, 5, 243, 122, 2, 548, 549, 5, 249, 125, 2, 549, 86, 3, 2, 2, 2, 550, 
-	551, 5, 225, 113, 2, 551, 552, 5, 231, 116, 2, 552, 553, 5, 237, 119, 2, 
-	553, 554, 5, 237, 119, 2, 554, 88, 3, 2, 2, 2, 555, 556, 5, 241, 121, 2, 
-	556, 557, 5, 255, 128, 2, 557, 558, 5, 237, 119, 2, 558, 559, 5, 237, 119, 
-	2, 559, 90, 3, 2, 2, 2, 560, 561, 5, 245, 123, 2, 561, 562, 5, 249, 125, 
-	2, 562, 563, 5, 223, 112, 2, 563, 564, 5, 257, 129, 2, 564, 565, 5, 231, 
-	116, 2, 565, 566, 5, 243, 122, 2, 566, 567, 5, 255, 128, 2, 567, 568, 5, 
-	251, 126, 2, 568, 92, 3, 2, 2, 2, 569, 570, 5, 243, 122, 2, 570, 571, 5, 
-	249, 125, 2, 571, 572, 5, 221, 111, 2, 572, 573, 5, 223, 112, 2, 573, 574, 
-	5, 249, 125, 2, 574, 94, 3, 2, 2, 2, 575, 576, 5, 215, 108, 2, 576, 577, 
-	5, 251, 126, 2, 577, 578, 5, 219, 110, 2, 578, 96, 3, 2, 2, 2, 579, 580, 
-	5, 221, 111, 2, 580, 581, 5, 223, 112, 2, 581, 582, 5, 251, 126, 2, 582, 
-	583, 5, 219, 110, 2, 583, 98, 3, 2, 2, 2, 584, 585, 5, 237, 119, 2, 585, 
-	586, 5, 231, 116, 2, 586, 587, 5, 235, 118, 2, 587, 588, 5, 223, 112, 2, 
-	588, 100, 3, 2, 2, 2, 589, 590, 5, 241, 121, 2, 590, 591, 5, 243, 122, 
-	2, 591, 592, 5, 253, 127, 2, 592, 102, 3, 2, 2, 2, 593, 594, 5, 217, 109, 
-	2, 594, 595, 5, 223, 112, 2, 595, 596, 5, 253, 127, 2, 596, 597, 5, 259, 
-	130, 2, 597, 598, 5, 223, 112, 2, 598, 599, 5, 223, 112, 2, 599, 600, 5, 
-	241, 121, 2, 600, 104, 3, 2, 2, 2, 601, 602, 5, 231, 116, 2, 602, 603, 
-	5, 251, 126, 2, 603, 106, 3, 2, 2, 2, 604, 605, 5, 227, 114, 2, 605, 606, 
-	5, 249, 125, 2, 606, 607, 5, 243, 122, 2, 607, 608, 5, 255, 128, 2, 608, 
-	609, 5, 245, 123, 2, 609, 108, 3, 2, 2, 2, 610, 611, 5, 229, 115, 2, 611, 
-	612, 5, 215, 108, 2, 612, 613, 5, 257, 129, 2, 613, 614, 5, 231, 116, 2, 
-	614, 615, 5, 241, 121, 2, 615, 616, 5, 227, 114, 2, 616, 110, 3, 2, 2, 
-	2, 617, 618, 5, 217, 109, 2, 618, 619, 5, 263, 132, 2, 619, 112, 3, 2, 
-	2, 2, 620, 621, 5, 225, 113, 2, 621, 622, 5, 243, 122, 2, 622, 623, 5, 
-	249, 125, 2, 623, 114, 3, 2, 2, 2, 624, 625, 5, 251, 126, 2, 625, 626, 
-	5, 253, 127, 2, 626, 627, 5, 215, 108, 2, 627, 628, 5, 253, 127, 2, 628, 
-	629, 5, 251, 126, 2, 629, 116, 3, 2, 2, 2, 630, 631, 5, 253, 127, 2, 631, 
-	632, 5, 231, 116, 2, 632, 633, 5, 239, 120, 2, 633, 634, 5, 223, 112, 2, 
-	634, 118, 3, 2, 2, 2, 635, 636, 5, 241, 121, 2, 636, 637, 5, 243, 122, 
-	2, 637, 638, 5, 259, 130, 2, 638, 120, 3, 2, 2, 2, 639, 640, 5, 231, 116, 
-	2, 640, 641, 5, 241, 121, 2, 641, 122, 3, 2, 2, 2, 642, 643, 5, 237, 119, 
-	2, 643, 644, 5, 243, 122, 2, 644, 645, 5, 227, 114, 2, 645, 124, 3, 2, 
-	2, 2, 646, 647, 5, 245, 123, 2, 647, 648, 5, 249, 125, 2, 648, 649, 5, 
-	243, 122, 2, 649, 650, 5, 225, 113, 2, 650, 651, 5, 231, 116, 2, 651, 652, 
-	5, 237, 119, 2, 652, 653, 5, 223, 112, 2, 653, 126, 3, 2, 2, 2, 654, 655, 
-	5, 251, 126, 2, 655, 656, 5, 255, 128, 2, 656, 657, 5, 239, 120, 2, 657, 
-	128, 3, 2, 2, 2, 658, 659, 5, 239, 120, 2, 659, 660, 5, 231, 116, 2, 660, 
-	661, 5, 241, 121, 2, 661, 130, 3, 2, 2, 2, 662, 663, 5, 239, 120, 2, 663, 
-	664, 5, 215, 108, 2, 664, 665, 5, 261, 131, 2, 665, 132, 3, 2, 2, 2, 666, 
-	667, 5, 219, 110, 2, 667, 668, 5, 243, 122, 2, 668, 669, 5, 255, 128, 2, 
-	669, 670, 5, 241, 121, 2, 670, 671, 5, 253, 127, 2, 671, 134, 3, 2, 2, 
-	2, 672, 673, 5, 215, 108, 2, 673, 674, 5, 257, 129, 2, 674, 675, 5, 227, 
-	114, 2, 675, 136, 3, 2, 2, 2, 676, 677, 5, 251, 126, 2, 677, 678, 5, 253, 
-	127, 2, 678, 679, 5, 221, 111, 2, 679, 680, 5, 221, 111, 2, 680, 681, 5, 
-	223, 112, 2, 681, 682, 5, 257, 129, 2, 682, 138, 3, 2, 2, 2, 683, 684, 
-	5, 229, 115, 2, 684, 685, 5, 231, 116, 2, 685, 686, 5, 251, 126, 2, 686, 
-	687, 5, 253, 127, 2, 687, 688, 5, 243, 122, 2, 688, 689, 5, 227, 114, 2, 
-	689, 690, 5, 249, 125, 2, 690, 691, 5, 215, 108, 2, 691, 692, 5, 239, 120, 
-	2, 692, 140, 3, 2, 2, 2, 693, 694, 5, 251, 126, 2, 694, 142, 3, 2, 2, 2, 
-	695, 696, 7, 111, 2, 2, 696, 144, 3, 2, 2, 2, 697, 698, 5, 229, 115, 2, 
-	698, 146, 3, 2, 2, 2, 699, 700, 5, 221, 111, 2, 700, 148, 3, 2, 2, 2, 701, 
-	702, 5, 259, 130, 2, 702, 150, 3, 2, 2, 2, 703, 704, 7, 79, 2, 2, 704, 
-	152, 3, 2, 2, 2, 705, 706, 5, 263, 132, 2, 706, 154, 3, 2, 2, 2, 707, 708, 
-	7, 48, 2, 2, 708, 156, 3, 2, 2, 2, 709, 710, 7, 60, 2, 2, 710, 158, 3, 
-	2, 2, 2, 711, 712, 7, 63, 2, 2, 712, 160, 3, 2, 2, 2, 713, 714, 7, 62, 
-	2, 2, 714, 715, 7, 64, 2, 2, 715, 162, 3, 2, 2, 2, 716, 717, 7, 35, 2, 
-	2, 717, 718, 7, 63, 2, 2, 718, 164, 3, 2, 2, 2, 719, 720, 7, 64, 2, 2, 
-	720, 166, 3, 2, 2, 2, 721, 722, 7, 64, 2, 2, 722, 723, 7, 63, 2, 2, 723, 
-	168, 3, 2, 2, 2, 724, 725, 7, 62, 2, 2, 725, 170, 3, 2, 2, 2, 726, 727, 
-	7, 62, 2, 2, 727, 728, 7, 63, 2, 2, 728, 172, 3, 2, 2, 2, 729, 730, 7, 
-	63, 2, 2, 730, 731, 7, 128, 2, 2, 731, 174, 3, 2, 2, 2, 732, 733, 7, 35, 
-	2, 2, 733, 734, 7, 128, 2, 2, 734, 176, 3, 2, 2, 2, 735, 736, 7, 46, 2, 
-	2, 736, 178, 3, 2, 2, 2, 737, 738, 7, 125, 2, 2, 738, 180, 3, 2, 2, 2, 
-	739, 740, 7, 127, 2, 2, 740, 182, 3, 2, 2, 2, 741, 742, 7, 93, 2, 2, 742, 
-	184, 3, 2, 2, 2, 743, 744, 7, 95, 2, 2, 744, 186, 3, 2, 2, 2, 745, 746, 
-	7, 42, 2, 2, 746, 188, 3, 2, 2, 2, 747, 748, 7, 43, 2, 2, 748, 190, 3, 
-	2, 2, 2, 749, 750, 7, 45, 2, 2, 750, 192, 3, 2, 2, 2, 751, 752, 7, 47, 
-	2, 2, 752, 194, 3, 2, 2, 2, 753, 754, 7, 49, 2, 2, 754, 196, 3, 2, 2, 2, 
-	755, 756, 7, 44, 2, 2, 756, 198, 3, 2, 2, 2, 757, 758, 7, 39, 2, 2, 758, 
-	200, 3, 2, 2, 2, 759, 760, 5, 213, 107, 2, 760, 202, 3, 2, 2, 2, 761, 763, 
-	5, 211, 106, 2, 762, 761, 3, 2, 2, 2, 763, 764, 3, 2, 2, 2, 764, 762, 3, 
-	2, 2, 2, 764, 765, 3, 2, 2, 2, 765, 204, 3, 2, 2, 2, 766, 768, 5, 211, 
-	106, 2, 767, 766, 3, 2, 2, 2, 768, 769, 3, 2, 2, 2, 769, 767, 3, 2, 2, 
-	2, 769, 770, 3, 2, 2, 2, 770, 771, 3, 2, 2, 2, 771, 772, 7, 48, 2, 2, 772, 
-	776, 10, 2, 2, 2, 773, 775, 5, 211, 106, 2, 774, 773, 3, 2, 2, 2, 775, 
-	778, 3, 2, 2, 2, 776, 774, 3, 2, 2, 2, 776, 777, 3, 2, 2, 2, 777, 786, 
-	3, 2, 2, 2, 778, 776, 3, 2, 2, 2, 779, 781, 7, 48, 2, 2, 780, 782, 5, 211, 
-	106, 2, 781, 780, 3, 2, 2, 2, 782, 783, 3, 2, 2, 2, 783, 781, 3, 2, 2, 
-	2, 783, 784, 3, 2, 2, 2, 784, 786, 3, 2, 2, 2, 785, 767, 3, 2, 2, 2, 785, 
-	779, 3, 2, 2, 2, 786, 206, 3, 2, 2, 2, 787, 789, 5, 209, 105, 2, 788, 787, 
-	3, 2, 2, 2, 789, 790, 3, 2, 2, 2, 790, 788, 3, 2, 2, 2, 790, 791, 3, 2, 
-	2, 2, 791, 792, 3, 2, 2, 2, 792, 793, 8, 104, 2, 2, 793, 208, 3, 2, 2, 
-	2, 794, 795, 9, 3, 2, 2, 795, 210, 3, 2, 2, 2, 796, 797, 9, 4, 2, 2, 797, 
-	212, 3, 2, 2, 2, 798, 804, 9, 5, 2, 2, 799, 803, 9, 5, 2, 2, 800, 803, 
-	5, 211, 106, 2, 801, 803, 9, 6, 2, 2, 802, 799, 3, 2, 2, 2, 802, 800, 3, 
-	2, 2, 2, 802, 801, 3, 2, 2, 2, 803, 806, 3, 2, 2, 2, 804, 802, 3, 2, 2, 
-	2, 804, 805, 3, 2, 2, 2, 805, 849, 3, 2, 2, 2, 806, 804, 3, 2, 2, 2, 807, 
-	808, 7, 38, 2, 2, 808, 812, 7, 125, 2, 2, 809, 811, 11, 2, 2, 2, 810, 809, 
-	3, 2, 2, 2, 811, 814, 3, 2, 2, 2, 812, 813, 3, 2, 2, 2, 812, 810, 3, 2, 
-	2, 2, 813, 815, 3, 2, 2, 2, 814, 812, 3, 2, 2, 2, 815, 849, 7, 127, 2, 
-	2, 816, 820, 9, 7, 2, 2, 817, 821, 9, 5, 2, 2, 818, 821, 5, 211, 106, 2, 
-	819, 821, 9, 7, 2, 2, 820, 817, 3, 2, 2, 2, 820, 818, 3, 2, 2, 2, 820, 
-	819, 3, 2, 2, 2, 821, 822, 3, 2, 2, 2, 822, 820, 3, 2, 2, 2, 822, 823, 
-	3, 2, 2, 2, 823, 849, 3, 2, 2, 2, 824, 828, 7, 36, 2, 2, 825, 827, 11, 
-	2, 2, 2, 826, 825, 3, 2, 2, 2, 827, 830, 3, 2, 2, 2, 828, 829, 3, 2, 2, 
-	2, 828, 826, 3, 2, 2, 2, 829, 831, 3, 2, 2, 2, 830, 828, 3, 2, 2, 2, 831, 
-	849, 7, 36, 2, 2, 832, 836, 7, 98, 2, 2, 833, 835, 11, 2, 2, 2, 834, 833, 
-	3, 2, 2, 2, 835, 838, 3, 2, 2, 2, 836, 837, 3, 2, 2, 2, 836, 834, 3, 2, 
-	2, 2, 837, 839, 3, 2, 2, 2, 838, 836, 3, 2, 2, 2, 839, 849, 7, 98, 2, 2, 
-	840, 844, 7, 41, 2, 2, 841, 843, 11, 2, 2, 2, 842, 841, 3, 2, 2, 2, 843, 
-	846, 3, 2, 2, 2, 844, 845, 3, 2, 2, 2, 844, 842, 3, 2, 2, 2, 845, 847, 
-	3, 2, 2, 2, 846, 844, 3, 2, 2, 2, 847, 849, 7, 41, 2, 2, 848, 798, 3, 2, 
-	2, 2, 848, 807, 3, 2, 2, 2, 848, 816, 3, 2, 2, 2, 848, 824, 3, 2, 2, 2, 
-	848, 832, 3, 2, 2, 2, 848, 840, 3, 2, 2, 2, 849, 214, 3, 2, 2, 2, 850, 
-	851, 9, 8, 2, 2, 851, 216, 3, 2, 2, 2, 852, 853, 9, 9, 2, 2, 853, 218, 
-	3, 2, 2, 2, 854, 855, 9, 10, 2, 2, 855, 220, 3, 2, 2, 2, 856, 857, 9, 11, 
-	2, 2, 857, 222, 3, 2, 2, 2, 858, 859, 9, 12, 2, 2, 859, 224, 3, 2, 2, 2, 
-	860, 861, 9, 13, 2, 2, 861, 226, 3, 2, 2, 2, 862, 863, 9, 14, 2, 2, 863, 
-	228, 3, 2, 2, 2, 864, 865, 9, 15, 2, 2, 865, 230, 3, 2, 2, 2, 866, 867, 
-	9, 16, 2, 2, 867, 232, 3, 2, 2, 2, 868, 869, 9, 17, 2, 2, 869, 234, 3, 
-	2, 2, 2, 870, 871, 9, 18, 2, 2, 871, 236, 3, 2, 2, 2, 872, 873, 9, 19, 
-	2, 2, 873, 238, 3, 2, 2, 2, 874, 875, 9, 20, 2, 2, 875, 240, 3, 2, 2, 2, 
-	876, 877, 9, 21, 2, 2, 877, 242, 3, 2, 2, 2, 878, 879, 9, 22, 2, 2, 879, 
-	244, 3, 2, 2, 2, 880, 881, 9, 23, 2, 2, 881, 246, 3, 2, 2, 2, 882, 883, 
-	9, 24, 2, 2, 883, 248, 3, 2, 2, 2, 884, 885, 9, 25, 2, 2, 885, 250, 3, 
-	2, 2, 2, 886, 887, 9, 26, 2, 2, 887, 252, 3, 2, 2, 2, 888, 889, 9, 27, 
-	2, 2, 889, 254, 3, 2, 2, 2, 890, 891, 9, 28, 2, 2, 891, 256, 3, 2, 2, 2, 
-	892, 893, 9, 29, 2, 2, 893, 258, 3, 2, 2, 2, 894, 895, 9, 30, 2, 2, 895, 
-	260, 3, 2, 2, 2, 896, 897, 9, 31, 2, 2, 897, 262, 3, 2, 2, 2, 898, 899, 
-	9, 32, 2, 2, 899, 264, 3, 2, 2, 2, 900, 901, 9, 33, 2, 2, 901, 266, 3, 
-	2, 2, 2, 18, 2, 764, 769, 776, 783, 785, 790, 802, 804, 812, 820, 822, 
-	828, 836, 844, 848, 3, 8, 2, 2,
+	265, 2, 902, 2, 924, 106, 935, 107, 946, 108, 957, 109, 968, 110, 993, 
+	111, 1010, 112, 3, 2, 36, 3, 2, 48, 48, 5, 2, 11, 12, 15, 15, 34, 34, 3, 
+	2, 50, 59, 4, 2, 67, 92, 99, 124, 4, 2, 48, 48, 97, 97, 6, 2, 37, 38, 60, 
+	60, 66, 66, 97, 97, 4, 2, 67, 67, 99, 99, 4, 2, 68, 68, 100, 100, 4, 2, 
+	69, 69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2, 71, 71, 103, 103, 4, 2, 
+	72, 72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2, 74, 74, 106, 106, 4, 2, 
+	75, 75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2, 77, 77, 109, 109, 4, 2, 
+	78, 78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2, 80, 80, 112, 112, 4, 2, 
+	81, 81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2, 83, 83, 115, 115, 4, 2, 
+	84, 84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2, 86, 86, 118, 118, 4, 2, 
+	87, 87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2, 89, 89, 121, 121, 4, 2, 
+	90, 90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2, 92, 92, 124, 124, 4, 2, 
+	45, 45, 47, 47, 3, 2, 41, 41, 2, 1021, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 
+	2, 2, 7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 
+	2, 2, 2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 
+	2, 2, 2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 
+	3, 2, 2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 
+	37, 3, 2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 
+	2, 45, 3, 2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 
+	2, 2, 53, 3, 2, 2, 2, 2, 55, 3, 2, 2, 2, 2, 57, 3, 2, 2, 2, 2, 59, 3, 2, 
+	2, 2, 2, 61, 3, 2, 2, 2, 2, 63, 3, 2, 2, 2, 2, 65, 3, 2, 2, 2, 2, 67, 3, 
+	2, 2, 2, 2, 69, 3, 2, 2, 2, 2, 71, 3, 2, 2, 2, 2, 73, 3, 2, 2, 2, 2, 75, 
+	3, 2, 2, 2, 2, 77, 3, 2, 2, 2, 2, 79, 3, 2, 2, 2, 2, 81, 3, 2, 2, 2, 2, 
+	83, 3, 2, 2, 2, 2, 85, 3, 2, 2, 2, 2, 87, 3, 2, 2, 2, 2, 89, 3, 2, 2, 2, 
+	2, 91, 3, 2, 2, 2, 2, 93, 3, 2, 2, 2, 2, 95, 3, 2, 2, 2, 2, 97, 3, 2, 2, 
+	2, 2, 99, 3, 2, 2, 2, 2, 101, 3, 2, 2, 2, 2, 103, 3, 2, 2, 2, 2, 105, 3, 
+	2, 2, 2, 2, 107, 3, 2, 2, 2, 2, 109, 3, 2, 2, 2, 2, 111, 3, 2, 2, 2, 2, 
+	113, 3, 2, 2, 2, 2, 115, 3, 2, 2, 2, 2, 117, 3, 2, 2, 2, 2, 119, 3, 2, 
+	2, 2, 2, 121, 3, 2, 2, 2, 2, 123, 3, 2, 2, 2, 2, 125, 3, 2, 2, 2, 2, 127, 
+	3, 2, 2, 2, 2, 129, 3, 2, 2, 2, 2, 131, 3, 2, 2, 2, 2, 133, 3, 2, 2, 2, 
+	2, 135, 3, 2, 2, 2, 2, 137, 3, 2, 2, 2, 2, 139, 3, 2, 2, 2, 2, 924, 3, 
+	2, 2, 2, 2, 935, 3, 2, 2, 2, 2, 946, 3, 2, 2, 2, 2, 957, 3, 2, 2, 2, 2, 
+	968, 3, 2, 2, 2, 2, 993, 3, 2, 2, 2, 2, 1010, 3, 2, 2, 2, 2, 141, 3, 2, 
+	2, 2, 2, 143, 3, 2, 2, 2, 2, 145, 3, 2, 2, 2, 2, 147, 3, 2, 2, 2, 2, 149, 
+	3, 2, 2, 2, 2, 151, 3, 2, 2, 2, 2, 153, 3, 2, 2, 2, 2, 155, 3, 2, 2, 2, 
+	2, 157, 3, 2, 2, 2, 2, 159, 3, 2, 2, 2, 2, 161, 3, 2, 2, 2, 2, 163, 3, 
+	2, 2, 2, 2, 165, 3, 2, 2, 2, 2, 167, 3, 2, 2, 2, 2, 169, 3, 2, 2, 2, 2, 
+	171, 3, 2, 2, 2, 2, 173, 3, 2, 2, 2, 2, 175, 3, 2, 2, 2, 2, 177, 3, 2, 
+	2, 2, 2, 179, 3, 2, 2, 2, 2, 181, 3, 2, 2, 2, 2, 183, 3, 2, 2, 2, 2, 185, 
+	3, 2, 2, 2, 2, 187, 3, 2, 2, 2, 2, 189, 3, 2, 2, 2, 2, 191, 3, 2, 2, 2, 
+	2, 193, 3, 2, 2, 2, 2, 195, 3, 2, 2, 2, 2, 197, 3, 2, 2, 2, 2, 199, 3, 
+	2, 2, 2, 2, 201, 3, 2, 2, 2, 2, 203, 3, 2, 2, 2, 2, 205, 3, 2, 2, 2, 2, 
+	207, 3, 2, 2, 2, 3, 267, 3, 2, 2, 2, 5, 274, 3, 2, 2, 2, 7, 281, 3, 2, 
+	2, 2, 9, 285, 3, 2, 2, 2, 11, 290, 3, 2, 2, 2, 13, 299, 3, 2, 2, 2, 15, 
+	304, 3, 2, 2, 2, 17, 310, 3, 2, 2, 2, 19, 322, 3, 2, 2, 2, 21, 326, 3, 
+	2, 2, 2, 23, 334, 3, 2, 2, 2, 25, 342, 3, 2, 2, 2, 27, 352, 3, 2, 2, 2, 
+	29, 357, 3, 2, 2, 2, 31, 360, 3, 2, 2, 2, 33, 365, 3, 2, 2, 2, 35, 374, 
+	3, 2, 2, 2, 37, 384, 3, 2, 2, 2, 39, 394, 3, 2, 2, 2, 41, 405, 3, 2, 2, 
+	2, 43, 410, 3, 2, 2, 2, 45, 423, 3, 2, 2, 2, 47, 435, 3, 2, 2, 2, 49, 441, 
+	3, 2, 2, 2, 51, 448, 3, 2, 2, 2, 53, 452, 3, 2, 2, 2, 55, 457, 3, 2, 2, 
+	2, 57, 462, 3, 2, 2, 2, 59, 466, 3, 2, 2, 2, 61, 471, 3, 2, 2, 2, 63, 478, 
+	3, 2, 2, 2, 65, 484, 3, 2, 2, 2, 67, 489, 3, 2, 2, 2, 69, 495, 3, 2, 2, 
+	2, 71, 501, 3, 2, 2, 2, 73, 509, 3, 2, 2, 2, 75, 515, 3, 2, 2, 2, 77, 523, 
+	3, 2, 2, 2, 79, 533, 3, 2, 2, 2, 81, 540, 3, 2, 2, 2, 83, 543, 3, 2, 2, 
+	2, 85, 547, 3, 2, 2, 2, 87, 550, 3, 2, 2, 2, 89, 555, 3, 2, 2, 2, 91, 560, 
+	3, 2, 2, 2, 93, 569, 3, 2, 2, 2, 95, 575, 3, 2, 2, 2, 97, 579, 3, 2, 2, 
+	2, 99, 584, 3, 2, 2, 2, 101, 589, 3, 2, 2, 2, 103, 593, 3, 2, 2, 2, 105, 
+	601, 3, 2, 2, 2, 107, 604, 3, 2, 2, 2, 109, 610, 3, 2, 2, 2, 111, 617, 
+	3, 2, 2, 2, 113, 620, 3, 2, 2, 2, 115, 624, 3, 2, 2, 2, 117, 630, 3, 2, 
+	2, 2, 119, 635, 3, 2, 2, 2, 121, 639, 3, 2, 2, 2, 123, 642, 3, 2, 2, 2, 
+	125, 646, 3, 2, 2, 2, 127, 654, 3, 2, 2, 2, 129, 658, 3, 2, 2, 2, 131, 
+	662, 3, 2, 2, 2, 133, 666, 3, 2, 2, 2, 135, 672, 3, 2, 2, 2, 137, 676, 
+	3, 2, 2, 2, 139, 683, 3, 2, 2, 2, 141, 693, 3, 2, 2, 2, 143, 695, 3, 2, 
+	2, 2, 145, 697, 3, 2, 2, 2, 147, 699, 3, 2, 2, 2, 149, 701, 3, 2, 2, 2, 
+	151, 703, 3, 2, 2, 2, 153, 705, 3, 2, 2, 2, 155, 707, 3, 2, 2, 2, 157, 
+	709, 3, 2, 2, 2, 159, 711, 3, 2, 2, 2, 161, 713, 3, 2, 2, 2, 163, 716, 
+	3, 2, 2, 2, 165, 719, 3, 2, 2, 2, 167, 721, 3, 2, 2, 2, 169, 724, 3, 2, 
+	2, 2, 171, 726, 3, 2, 2, 2, 173, 729, 3, 2, 2, 2, 175, 732, 3, 2, 2, 2, 
+	177, 735, 3, 2, 2, 2, 179, 737, 3, 2, 2, 2, 181, 739, 3, 2, 2, 2, 183, 
+	741, 3, 2, 2, 2, 185, 743, 3, 2, 2, 2, 187, 745, 3, 2, 2, 2, 189, 747, 
+	3, 2, 2, 2, 191, 749, 3, 2, 2, 2, 193, 751, 3, 2, 2, 2, 195, 753, 3, 2, 
+	2, 2, 197, 755, 3, 2, 2, 2, 199, 757, 3, 2, 2, 2, 201, 759, 3, 2, 2, 2, 
+	203, 762, 3, 2, 2, 2, 205, 785, 3, 2, 2, 2, 207, 788, 3, 2, 2, 2, 209, 
+	794, 3, 2, 2, 2, 211, 796, 3, 2, 2, 2, 213, 848, 3, 2, 2, 2, 215, 850, 
+	3, 2, 2, 2, 217, 852, 3, 2, 2, 2, 219, 854, 3, 2, 2, 2, 221, 856, 3, 2, 
+	2, 2, 223, 858, 3, 2, 2, 2, 225, 860, 3, 2, 2, 2, 227, 862, 3, 2, 2, 2, 
+	229, 864, 3, 2, 2, 2, 231, 866, 3, 2, 2, 2, 233, 868, 3, 2, 2, 2, 235, 
+	870, 3, 2, 2, 2, 237, 872, 3, 2, 2, 2, 239, 874, 3, 2, 2, 2, 241, 876, 
+	3, 2, 2, 2, 243, 878, 3, 2, 2, 2, 245, 880, 3, 2, 2, 2, 247, 882, 3, 2, 
+	2, 2, 249, 884, 3, 2, 2, 2, 251, 886, 3, 2, 2, 2, 253, 888, 3, 2, 2, 2, 
+	255, 890, 3, 2, 2, 2, 257, 892, 3, 2, 2, 2, 259, 894, 3, 2, 2, 2, 261, 
+	896, 3, 2, 2, 2, 263, 898, 3, 2, 2, 2, 265, 900, 3, 2, 2, 2, 267, 268, 
+	5, 219, 110, 2, 268, 269, 5, 249, 125, 2, 269, 270, 5, 223, 112, 2, 270, 
+	271, 5, 215, 108, 2, 271, 272, 5, 253, 127, 2, 272, 273, 5, 223, 112, 2, 
+	273, 4, 3, 2, 2, 2, 274, 275, 5, 255, 128, 2, 275, 276, 5, 245, 123, 2, 
+	276, 277, 5, 221, 111, 2, 277, 278, 5, 215, 108, 2, 278, 279, 5, 253, 127, 
+	2, 279, 280, 5, 223, 112, 2, 280, 6, 3, 2, 2, 2, 281, 282, 5, 251, 126, 
+	2, 282, 283, 5, 223, 112, 2, 283, 284, 5, 253, 127, 2, 284, 8, 3, 2, 2, 
+	2, 285, 286, 5, 221, 111, 2, 286, 287, 5, 249, 125, 2, 287, 288, 5, 243, 
+	122, 2, 288, 289, 5, 245, 123, 2, 289, 10, 3, 2, 2, 2, 290, 291, 5, 231, 
+	116, 2, 291, 292, 5, 241, 121, 2, 292, 293, 5, 253, 127, 2, 293, 294, 5, 
+	223, 112, 2, 294, 295, 5, 249, 125, 2, 295, 296, 5, 257, 129, 2, 296, 297, 
+	5, 215, 108, 2, 297, 298, 5, 237, 119, 2, 298, 12, 3, 2, 2, 2, 299, 300, 
+	5, 241, 121, 2, 300, 301, 5, 215, 108, 2, 301, 302, 5, 239, 120, 2, 302, 
+	303, 5, 223, 112, 2, 303, 14, 3, 2, 2, 2, 304, 305, 5, 251, 126, 2, 305, 
+	306, 5, 229, 115, 2, 306, 307, 5, 215, 108, 2, 307, 308, 5, 249, 125, 2, 
+	308, 309, 5, 221, 111, 2, 309, 16, 3, 2, 2, 2, 310, 311, 5, 249, 125, 2, 
+	311, 312, 5, 223, 112, 2, 312, 313, 5, 245, 123, 2, 313, 314, 5, 237, 119, 
+	2, 314, 315, 5, 231, 116, 2, 315, 316, 5, 219, 110, 2, 316, 317, 5, 215, 
+	108, 2, 317, 318, 5, 253, 127, 2, 318, 319, 5, 231, 116, 2, 319, 320, 5, 
+	243, 122, 2, 320, 321, 5, 241, 121, 2, 321, 18, 3, 2, 2, 2, 322, 323, 5, 
+	253, 127, 2, 323, 324, 5, 253, 127, 2, 324, 325, 5, 237, 119, 2, 325, 20, 
+	3, 2, 2, 2, 326, 327, 5, 239, 120, 2, 327, 328, 5, 223, 112, 2, 328, 329, 
+	5, 253, 127, 2, 329, 330, 5, 215, 108, 2, 330, 331, 5, 253, 127, 2, 331, 
+	332, 5, 253, 127, 2, 332, 333, 5, 237, 119, 2, 333, 22, 3, 2, 2, 2, 334, 
+	335, 5, 245, 123, 2, 335, 336, 5, 215, 108, 2, 336, 337, 5, 251, 126, 2, 
+	337, 338, 5, 253, 127, 2, 338, 339, 5, 253, 127, 2, 339, 340, 5, 253, 127, 
+	2, 340, 341, 5, 237, 119, 2, 341, 24, 3, 2, 2, 2, 342, 343, 5, 225, 113, 
+	2, 343, 344, 5, 255, 128, 2, 344, 345, 5, 253, 127, 2, 345, 346, 5, 255, 
+	128, 2, 346, 347, 5, 249, 125, 2, 347, 348, 5, 223, 112, 2, 348, 349, 5, 
+	253, 127, 2, 349, 350, 5, 253, 127, 2, 350, 351, 5, 237, 119, 2, 351, 26, 
+	3, 2, 2, 2, 352, 353, 5, 235, 118, 2, 353, 354, 5, 231, 116, 2, 354, 355, 
+	5, 237, 119, 2, 355, 356, 5, 237, 119, 2, 356, 28, 3, 2, 2, 2, 357, 358, 
+	5, 243, 122, 2, 358, 359, 5, 241, 121, 2, 359, 30, 3, 2, 2, 2, 360, 361, 
+	5, 251, 126, 2, 361, 362, 5, 229, 115, 2, 362, 363, 5, 243, 122, 2, 363, 
+	364, 5, 259, 130, 2, 364, 32, 3, 2, 2, 2, 365, 366, 5, 221, 111, 2, 366, 
+	367, 5, 215, 108, 2, 367, 368, 5, 253, 127, 2, 368, 369, 5, 215, 108, 2, 
+	369, 370, 5, 217, 109, 2, 370, 371, 5, 215, 108, 2, 371, 372, 5, 251, 126, 
+	2, 372, 373, 5, 223, 112, 2, 373, 34, 3, 2, 2, 2, 374, 375, 5, 221, 111, 
+	2, 375, 376, 5, 215, 108, 2, 376, 377, 5, 253, 127, 2, 377, 378, 5, 215, 
+	108, 2, 378, 379, 5, 217, 109, 2, 379, 380, 5, 215, 108, 2, 380, 381, 5, 
+	251, 126, 2, 381, 382, 5, 223, 112, 2, 382, 383, 5, 251, 126, 2, 383, 36, 
+	3, 2, 2, 2, 384, 385, 5, 241, 121, 2, 385, 386, 5, 215, 108, 2, 386, 387, 
+	5, 239, 120, 2, 387, 388, 5, 223, 112, 2, 388, 389, 5, 251, 126, 2, 389, 
+	390, 5, 245, 123, 2, 390, 391, 5, 215, 108, 2, 391, 392, 5, 219, 110, 2, 
+	392, 393, 5, 223, 112, 2, 393, 38, 3, 2, 2, 2, 394, 395, 5, 241, 121, 2, 
+	395, 396, 5, 215, 108, 2, 396, 397, 5, 239, 120, 2, 397, 398, 5, 223, 112, 
+	2, 398, 399, 5, 251, 126, 2, 399, 400, 5, 245, 123, 2, 400, 401, 5, 215, 
+	108, 2, 401, 402, 5, 219, 110, 2, 402, 403, 5, 223, 112, 2, 403, 404, 5, 
+	251, 126, 2, 404, 40, 3, 2, 2, 2, 405, 406, 5, 241, 121, 2, 406, 407, 5, 
+	243, 122, 2, 407, 408, 5, 221, 111, 2, 408, 409, 5, 223, 112, 2, 409, 42, 
+	3, 2, 2, 2, 410, 411, 5, 239, 120, 2, 411, 412, 5, 223, 112, 2, 412, 413, 
+	5, 215, 108, 2, 413, 414, 5, 251, 126, 2, 414, 415, 5, 255, 128, 2, 415, 
+	416, 5, 249, 125, 2, 416, 417, 5, 223, 112, 2, 417, 418, 5, 239, 120, 2, 
+	418, 419, 5, 223, 112, 2, 419, 420, 5, 241, 121, 2, 420, 421, 5, 253, 127, 
+	2, 421, 422, 5, 251, 126, 2, 422, 44, 3, 2, 2, 2, 423, 424, 5, 239, 120, 
+	2, 424, 425, 5, 223, 112, 2, 425, 426, 5, 215, 108, 2, 426, 427, 5, 251, 
+	126, 2, 427, 428, 5, 255, 128, 2, 428, 429, 5, 249, 125, 2, 429, 430, 5, 
+	223, 112, 2, 430, 431, 5, 239, 120, 2, 431, 432, 5, 223, 112, 2, 432, 433, 
+	5, 241, 121, 2, 433, 434, 5, 253, 127, 2, 434, 46, 3, 2, 2, 2, 435, 436, 
+	5, 225, 113, 2, 436, 437, 5, 231, 116, 2, 437, 438, 5, 223, 112, 2, 438, 
+	439, 5, 237, 119, 2, 439, 440, 5, 221, 111, 2, 440, 48, 3, 2, 2, 2, 441, 
+	442, 5, 225, 113, 2, 442, 443, 5, 231, 116, 2, 443, 444, 5, 223, 112, 2, 
+	444, 445, 5, 237, 119, 2, 445, 446, 5, 221, 111, 2, 446, 447, 5, 251, 126, 
+	2, 447, 50, 3, 2, 2, 2, 448, 449, 5, 253, 127, 2, 449, 450, 5, 215, 108, 
+	2, 450, 451, 5, 227, 114, 2, 451, 52, 3, 2, 2, 2, 452, 453, 5, 231, 116, 
+	2, 453, 454, 5, 241, 121, 2, 454, 455, 5, 225, 113, 2, 455, 456, 5, 243, 
+	122, 2, 456, 54, 3, 2, 2, 2, 457, 458, 5, 235, 118, 2, 458, 459, 5, 223, 
+	112, 2, 459, 460, 5, 263, 132, 2, 460, 461, 5, 251, 126, 2, 461, 56, 3, 
+	2, 2, 2, 462, 463, 5, 235, 118, 2, 463, 464, 5, 223, 112, 2, 464, 465, 
+	5, 263, 132, 2, 465, 58, 3, 2, 2, 2, 466, 467, 5, 259, 130, 2, 467, 468, 
+	5, 231, 116, 2, 468, 469, 5, 253, 127, 2, 469, 470, 5, 229, 115, 2, 470, 
+	60, 3, 2, 2, 2, 471, 472, 5, 257, 129, 2, 472, 473, 5, 215, 108, 2, 473, 
+	474, 5, 237, 119, 2, 474, 475, 5, 255, 128, 2, 475, 476, 5, 223, 112, 2, 
+	476, 477, 5, 251, 126, 2, 477, 62, 3, 2, 2, 2, 478, 479, 5, 257, 129, 2, 
+	479, 480, 5, 215, 108, 2, 480, 481, 5, 237, 119, 2, 481, 482, 5, 255, 128, 
+	2, 482, 483, 5, 223, 112, 2, 483, 64, 3, 2, 2, 2, 484, 485, 5, 225, 113, 
+	2, 485, 486, 5, 249, 125, 2, 486, 487, 5, 243, 122, 2, 487, 488, 5, 239, 
+	120, 2, 488, 66, 3, 2, 2, 2, 489, 490, 5, 259, 130, 2, 490, 491, 5, 229, 
+	115, 2, 491, 492, 5, 223, 112, 2, 492, 493, 5, 249, 125, 2, 493, 494, 5, 
+	223, 112, 2, 494, 68, 3, 2, 2, 2, 495, 496, 5, 237, 119, 2, 496, 497, 5, 
+	231, 116, 2, 497, 498, 5, 239, 120, 2, 498, 499, 5, 231, 116, 2, 499, 500, 
+	5, 253, 127, 2, 500, 70, 3, 2, 2, 2, 501, 502, 5, 247, 124, 2, 502, 503, 
+	5, 255, 128, 2, 503, 504, 5, 223, 112, 2, 504, 505, 5, 249, 125, 2, 505, 
+	506, 5, 231, 116, 2, 506, 507, 5, 223, 112, 2, 507, 508, 5, 251, 126, 2, 
+	508, 72, 3, 2, 2, 2, 509, 510, 5, 247, 124, 2, 510, 511, 5, 255, 128, 2, 
+	511, 512, 5, 223, 112, 2, 512, 513, 5, 249, 125, 2, 513, 514, 5, 263, 132, 
+	2, 514, 74, 3, 2, 2, 2, 515, 516, 5, 223, 112, 2, 516, 517, 5, 261, 131, 
+	2, 517, 518, 5, 245, 123, 2, 518, 519, 5, 237, 119, 2, 519, 520, 5, 215, 
+	108, 2, 520, 521, 5, 231, 116, 2, 521, 522, 5, 241, 121, 2, 522, 76, 3, 
+	2, 2, 2, 523, 524, 5, 259, 130, 2, 524, 525, 5, 231, 116, 2, 525, 526, 
+	5, 253, 127, 2, 526, 527, 5, 229, 115, 2, 527, 528, 5, 257, 129, 2, 528, 
+	529, 5, 215, 108, 2, 529, 530, 5, 237, 119, 2, 530, 531, 5, 255, 128, 2, 
+	531, 532, 5, 223, 112, 2, 532, 78, 3, 2, 2, 2, 533, 534, 5, 251, 126, 2, 
+	534, 535, 5, 223, 112, 2, 535, 536, 5, 237, 119, 2, 536, 537, 5, 223, 112, 
+	2, 537, 538, 5, 219, 110, 2, 538, 539, 5, 253, 127, 2, 539, 80, 3, 2, 2, 
+	2, 540, 541, 5, 215, 108, 2, 541, 542, 5, 251, 126, 2, 542, 82, 3, 2, 2, 
+	2, 543, 544, 5, 215, 108, 2, 544, 545, 5, 241, 121, 2, 545, 546, 5, 221, 
+	111, 2, 546, 84, 3, 2, 2, 2, 547, 548, 5, 243, 122, 2, 548, 549, 5, 249, 
+	125, 2, 549, 86, 3, 2, 2, 2, 550, 551, 5, 225, 113, 2, 551, 552, 5, 231, 
+	116, 2, 552, 553, 5, 237, 119, 2, 553, 554, 5, 237, 119, 2, 554, 88, 3, 
+	2, 2, 2, 555, 556, 5, 241, 121, 2, 556, 557, 5, 255, 128, 2, 557, 558, 
+	5, 237, 119, 2, 558, 559, 5, 237, 119, 2, 559, 90, 3, 2, 2, 2, 560, 561, 
+	5, 245, 123, 2, 561, 562, 5, 249, 125, 2, 562, 563, 5, 223, 112, 2, 563, 
+	564, 5, 257, 129, 2, 564, 565, 5, 231, 116, 2, 565, 566, 5, 243, 122, 2, 
+	566, 567, 5, 255, 128, 2, 567, 568, 5, 251, 126, 2, 568, 92, 3, 2, 2, 2, 
+	569, 570, 5, 243, 122, 2, 570, 571, 5, 249, 125, 2, 571, 572, 5, 221, 111, 
+	2, 572, 573, 5, 223, 112, 2, 573, 574, 5, 249, 125, 2, 574, 94, 3, 2, 2, 
+	2, 575, 576, 5, 215, 108, 2, 576, 577, 5, 251, 126, 2, 577, 578, 5, 219, 
+	110, 2, 578, 96, 3, 2, 2, 2, 579, 580, 5, 221, 111, 2, 580, 581, 5, 223, 
+	112, 2, 581, 582, 5, 251, 126, 2, 582, 583, 5, 219, 110, 2, 583, 98, 3, 
+	2, 2, 2, 584, 585, 5, 237, 119, 2, 585, 586, 5, 231, 116, 2, 586, 587, 
+	5, 235, 118, 2, 587, 588, 5, 223, 112, 2, 588, 100, 3, 2, 2, 2, 589, 590, 
+	5, 241, 121, 2, 590, 591, 5, 243, 122, 2, 591, 592, 5, 253, 127, 2, 592, 
+	102, 3, 2, 2, 2, 593, 594, 5, 217, 109, 2, 594, 595, 5, 223, 112, 2, 595, 
+	596, 5, 253, 127, 2, 596, 597, 5, 259, 130, 2, 597, 598, 5, 223, 112, 2, 
+	598, 599, 5, 223, 112, 2, 599, 600, 5, 241, 121, 2, 600, 104, 3, 2, 2, 
+	2, 601, 602, 5, 231, 116, 2, 602, 603, 5, 251, 126, 2, 603, 106, 3, 2, 
+	2, 2, 604, 605, 5, 227, 114, 2, 605, 606, 5, 249, 125, 2, 606, 607, 5, 
+	243, 122, 2, 607, 608, 5, 255, 128, 2, 608, 609, 5, 245, 123, 2, 609, 108, 
+	3, 2, 2, 2, 610, 611, 5, 229, 115, 2, 611, 612, 5, 215, 108, 2, 612, 613, 
+	5, 257, 129, 2, 613, 614, 5, 231, 116, 2, 614, 615, 5, 241, 121, 2, 615, 
+	616, 5, 227, 114, 2, 616, 110, 3, 2, 2, 2, 617, 618, 5, 217, 109, 2, 618, 
+	619, 5, 263, 132, 2, 619, 112, 3, 2, 2, 2, 620, 621, 5, 225, 113, 2, 621, 
+	622, 5, 243, 122, 2, 622, 623, 5, 249, 125, 2, 623, 114, 3, 2, 2, 2, 624, 
+	625, 5, 251, 126, 2, 625, 626, 5, 253, 127, 2, 626, 627, 5, 215, 108, 2, 
+	627, 628, 5, 253, 127, 2, 628, 629, 5, 251, 126, 2, 629, 116, 3, 2, 2, 
+	2, 630, 631, 5, 253, 127, 2, 631, 632, 5, 231, 116, 2, 632, 633, 5, 239, 
+	120, 2, 633, 634, 5, 223, 112, 2, 634, 118, 3, 2, 2, 2, 635, 636, 5, 241, 
+	121, 2, 636, 637, 5, 243, 122, 2, 637, 638, 5, 259, 130, 2, 638, 120, 3, 
+	2, 2, 2, 639, 640, 5, 231, 116, 2, 640, 641, 5, 241, 121, 2, 641, 122, 
+	3, 2, 2, 2, 642, 643, 5, 237, 119, 2, 643, 644, 5, 243, 122, 2, 644, 645, 
+	5, 227, 114, 2, 645, 124, 3, 2, 2, 2, 646, 647, 5, 245, 123, 2, 647, 648, 
+	5, 249, 125, 2, 648, 649, 5, 243, 122, 2, 649, 650, 5, 225, 113, 2, 650, 
+	651, 5, 231, 116, 2, 651, 652, 5, 237, 119, 2, 652, 653, 5, 223, 112, 2, 
+	653, 126, 3, 2, 2, 2, 654, 655, 5, 251, 126, 2, 655, 656, 5, 255, 128, 
+	2, 656, 657, 5, 239, 120, 2, 657, 128, 3, 2, 2, 2, 658, 659, 5, 239, 120, 
+	2, 659, 660, 5, 231, 116, 2, 660, 661, 5, 241, 121, 2, 661, 130, 3, 2, 
+	2, 2, 662, 663, 5, 239, 120, 2, 663, 664, 5, 215, 108, 2, 664, 665, 5, 
+	261, 131, 2, 665, 132, 3, 2, 2, 2, 666, 667, 5, 219, 110, 2, 667, 668, 
+	5, 243, 122, 2, 668, 669, 5, 255, 128, 2, 669, 670, 5, 241, 121, 2, 670, 
+	671, 5, 253, 127, 2, 671, 134, 3, 2, 2, 2, 672, 673, 5, 215, 108, 2, 673, 
+	674, 5, 257, 129, 2, 674, 675, 5, 227, 114, 2, 675, 136, 3, 2, 2, 2, 676, 
+	677, 5, 251, 126, 2, 677, 678, 5, 253, 127, 2, 678, 679, 5, 221, 111, 2, 
+	679, 680, 5, 221, 111, 2, 680, 681, 5, 223, 112, 2, 681, 682, 5, 257, 129, 
+	2, 682, 138, 3, 2, 2, 2, 683, 684, 5, 229, 115, 2, 684, 685, 5, 231, 116, 
+	2, 685, 686, 5, 251, 126, 2, 686, 687, 5, 253, 127, 2, 687, 688, 5, 243, 
+	122, 2, 688, 689, 5, 227, 114, 2, 689, 690, 5, 249, 125, 2, 690, 691, 5, 
+	215, 108, 2, 691, 692, 5, 239, 120, 2, 692, 140, 3, 2, 2, 2, 693, 694, 
+	5, 251, 126, 2, 694, 142, 3, 2, 2, 2, 695, 696, 7, 111, 2, 2, 696, 144, 
+	3, 2, 2, 2, 697, 698, 5, 229, 115, 2, 698, 146, 3, 2, 2, 2, 699, 700, 5, 
+	221, 111, 2, 700, 148, 3, 2, 2, 2, 701, 702, 5, 259, 130, 2, 702, 150, 
+	3, 2, 2, 2, 703, 704, 7, 79, 2, 2, 704, 152, 3, 2, 2, 2, 705, 706, 5, 263, 
+	132, 2, 706, 154, 3, 2, 2, 2, 707, 708, 7, 48, 2, 2, 708, 156, 3, 2, 2, 
+	2, 709, 710, 7, 60, 2, 2, 710, 158, 3, 2, 2, 2, 711, 712, 7, 63, 2, 2, 
+	712, 160, 3, 2, 2, 2, 713, 714, 7, 62, 2, 2, 714, 715, 7, 64, 2, 2, 715, 
+	162, 3, 2, 2, 2, 716, 717, 7, 35, 2, 2, 717, 718, 7, 63, 2, 2, 718, 164, 
+	3, 2, 2, 2, 719, 720, 7, 64, 2, 2, 720, 166, 3, 2, 2, 2, 721, 722, 7, 64, 
+	2, 2, 722, 723, 7, 63, 2, 2, 723, 168, 3, 2, 2, 2, 724, 725, 7, 62, 2, 
+	2, 725, 170, 3, 2, 2, 2, 726, 727, 7, 62, 2, 2, 727, 728, 7, 63, 2, 2, 
+	728, 172, 3, 2, 2, 2, 729, 730, 7, 63, 2, 2, 730, 731, 7, 128, 2, 2, 731, 
+	174, 3, 2, 2, 2, 732, 733, 7, 35, 2, 2, 733, 734, 7, 128, 2, 2, 734, 176, 
+	3, 2, 2, 2, 735, 736, 7, 46, 2, 2, 736, 178, 3, 2, 2, 2, 737, 738, 7, 125, 
+	2, 2, 738, 180, 3, 2, 2, 2, 739, 740, 7, 127, 2, 2, 740, 182, 3, 2, 2, 
+	2, 741, 742, 7, 93, 2, 2, 742, 184, 3, 2, 2, 2, 743, 744, 7, 95, 2, 2, 
+	744, 186, 3, 2, 2, 2, 745, 746, 7, 42, 2, 2, 746, 188, 3, 2, 2, 2, 747, 
+	748, 7, 43, 2, 2, 748, 190, 3, 2, 2, 2, 749, 750, 7, 45, 2, 2, 750, 192, 
+	3, 2, 2, 2, 751, 752, 7, 47, 2, 2, 752, 194, 3, 2, 2, 2, 753, 754, 7, 49, 
+	2, 2, 754, 196, 3, 2, 2, 2, 755, 756, 7, 44, 2, 2, 756, 198, 3, 2, 2, 2, 
+	757, 758, 7, 39, 2, 2, 758, 200, 3, 2, 2, 2, 759, 760, 5, 213, 107, 2, 
+	760, 202, 3, 2, 2, 2, 761, 763, 5, 211, 106, 2, 762, 761, 3, 2, 2, 2, 763, 
+	764, 3, 2, 2, 2, 764, 762, 3, 2, 2, 2, 764, 765, 3, 2, 2, 2, 765, 204, 
+	3, 2, 2, 2, 766, 768, 5, 211, 106, 2, 767, 766, 3, 2, 2, 2, 768, 769, 3, 
+	2, 2, 2, 769, 767, 3, 2, 2, 2, 769, 770, 3, 2, 2, 2, 770, 771, 3, 2, 2, 
+	2, 771, 772, 7, 48, 2, 2, 772, 776, 10, 2, 2, 2, 773, 775, 5, 211, 106, 
+	2, 774, 773, 3, 2, 2, 2, 775, 778, 3, 2, 2, 2, 776, 774, 3, 2, 2, 2, 776, 
+	777, 3, 2, 2, 2, 777, 916, 3, 2, 2, 2, 778, 776, 3, 2, 2, 2, 779, 781, 
+	7, 48, 2, 2, 780, 782, 5, 211, 106, 2, 781, 780, 3, 2, 2, 2, 782, 783, 
+	3, 2, 2, 2, 783, 781, 3, 2, 2, 2, 783, 784, 3, 2, 2, 2, 784, 916, 3, 2, 
+	2, 2, 785, 767, 3, 2, 2, 2, 785, 779, 3, 2, 2, 2, 785, 919, 3, 2, 2, 2, 
+	786, 206, 3, 2, 2, 2, 787, 789, 5, 209, 105, 2, 788, 787, 3, 2, 2, 2, 789, 
+	790, 3, 2, 2, 2, 790, 788, 3, 2, 2, 2, 790, 791, 3, 2, 2, 2, 791, 792, 
+	3, 2, 2, 2, 792, 793, 8, 104, 2, 2, 793, 208, 3, 2, 2, 2, 794, 795, 9, 
+	3, 2, 2, 795, 210, 3, 2, 2, 2, 796, 797, 9, 4, 2, 2, 797, 212, 3, 2, 2, 
+	2, 798, 804, 9, 5, 2, 2, 799, 803, 9, 5, 2, 2, 800, 803, 5, 211, 106, 2, 
+	801, 803, 9, 6, 2, 2, 802, 799, 3, 2, 2, 2, 802, 800, 3, 2, 2, 2, 802, 
+	801, 3, 2, 2, 2, 803, 806, 3, 2, 2, 2, 804, 802, 3, 2, 2, 2, 804, 805, 
+	3, 2, 2, 2, 805, 849, 3, 2, 2, 2, 806, 804, 3, 2, 2, 2, 807, 808, 7, 38, 
+	2, 2, 808, 812, 7, 125, 2, 2, 809, 811, 11, 2, 2, 2, 810, 809, 3, 2, 2, 
+	2, 811, 814, 3, 2, 2, 2, 812, 813, 3, 2, 2, 2, 812, 810, 3, 2, 2, 2, 813, 
+	815, 3, 2, 2, 2, 814, 812, 3, 2, 2, 2, 815, 849, 7, 127, 2, 2, 816, 820, 
+	9, 7, 2, 2, 817, 821, 9, 5, 2, 2, 818, 821, 5, 211, 106, 2, 819, 821, 9, 
+	7, 2, 2, 820, 817, 3, 2, 2, 2, 820, 818, 3, 2, 2, 2, 820, 819, 3, 2, 2, 
+	2, 821, 822, 3, 2, 2, 2, 822, 820, 3, 2, 2, 2, 822, 823, 3, 2, 2, 2, 823, 
+	849, 3, 2, 2, 2, 824, 828, 7, 36, 2, 2, 825, 827, 11, 2, 2, 2, 826, 825, 
+	3, 2, 2, 2, 827, 830, 3, 2, 2, 2, 828, 829, 3, 2, 2, 2, 828, 826, 3, 2, 
+	2, 2, 829, 831, 3, 2, 2, 2, 830, 828, 3, 2, 2, 2, 831, 849, 7, 36, 2, 2, 
+	832, 836, 7, 98, 2, 2, 833, 835, 11, 2, 2, 2, 834, 833, 3, 2, 2, 2, 835, 
+	838, 3, 2, 2, 2, 836, 837, 3, 2, 2, 2, 836, 834, 3, 2, 2, 2, 837, 839, 
+	3, 2, 2, 2, 838, 836, 3, 2, 2, 2, 839, 849, 7, 98, 2, 2, 840, 844, 7, 41, 
+	2, 2, 841, 843, 10, 35, 2, 2, 842, 841, 3, 2, 2, 2, 842, 933, 3, 2, 2, 
+	2, 843, 846, 3, 2, 2, 2, 844, 842, 3, 2, 2, 2, 844, 845, 3, 2, 2, 2, 845, 
+	847, 3, 2, 2, 2, 846, 844, 3, 2, 2, 2, 847, 849, 7, 41, 2, 2, 848, 798, 
+	3, 2, 2, 2, 848, 807, 3, 2, 2, 2, 848, 816, 3, 2, 2, 2, 848, 824, 3, 2, 
+	2, 2, 848, 832, 3, 2, 2, 2, 848, 840, 3, 2, 2, 2, 849, 214, 3, 2, 2, 2, 
+	850, 851, 9, 8, 2, 2, 851, 216, 3, 2, 2, 2, 852, 853, 9, 9, 2, 2, 853, 
+	218, 3, 2, 2, 2, 854, 855, 9, 10, 2, 2, 855, 220, 3, 2, 2, 2, 856, 857, 
+	9, 11, 2, 2, 857, 222, 3, 2, 2, 2, 858, 859, 9, 12, 2, 2, 859, 224, 3, 
+	2, 2, 2, 860, 861, 9, 13, 2, 2, 861, 226, 3, 2, 2, 2, 862, 863, 9, 14, 
+	2, 2, 863, 228, 3, 2, 2, 2, 864, 865, 9, 15, 2, 2, 865, 230, 3, 2, 2, 2, 
+	866, 867, 9, 16, 2, 2, 867, 232, 3, 2, 2, 2, 868, 869, 9, 17, 2, 2, 869, 
+	234, 3, 2, 2, 2, 870, 871, 9, 18, 2, 2, 871, 236, 3, 2, 2, 2, 872, 873, 
+	9, 19, 2, 2, 873, 238, 3, 2, 2, 2, 874, 875, 9, 20, 2, 2, 875, 240, 3, 
+	2, 2, 2, 876, 877, 9, 21, 2, 2, 877, 242, 3, 2, 2, 2, 878, 879, 9, 22, 
+	2, 2, 879, 244, 3, 2, 2, 2, 880, 881, 9, 23, 2, 2, 881, 246, 3, 2, 2, 2, 
+	882, 883, 9, 24, 2, 2, 883, 248, 3, 2, 2, 2, 884, 885, 9, 25, 2, 2, 885, 
+	250, 3, 2, 2, 2, 886, 887, 9, 26, 2, 2, 887, 252, 3, 2, 2, 2, 888, 889, 
+	9, 27, 2, 2, 889, 254, 3, 2, 2, 2, 890, 891, 9, 28, 2, 2, 891, 256, 3, 
+	2, 2, 2, 892, 893, 9, 29, 2, 2, 893, 258, 3, 2, 2, 2, 894, 895, 9, 30, 
+	2, 2, 895, 260, 3, 2, 2, 2, 896, 897, 9, 31, 2, 2, 897, 262, 3, 2, 2, 2, 
+	898, 899, 9, 32, 2, 2, 899, 264, 3, 2, 2, 2, 900, 901, 9, 33, 2, 2, 901, 
+	266, 3, 2, 2, 2, 902, 904, 3, 2, 2, 2, 904, 905, 5, 223, 112, 2, 905, 907, 
+	3, 2, 2, 2, 907, 908, 3, 2, 2, 2, 907, 906, 3, 2, 2, 2, 908, 909, 9, 34, 
+	2, 2, 909, 906, 3, 2, 2, 2, 906, 911, 3, 2, 2, 2, 911, 912, 3, 2, 2, 2, 
+	912, 910, 5, 211, 106, 2, 910, 913, 3, 2, 2, 2, 913, 911, 3, 2, 2, 2, 913, 
+	914, 3, 2, 2, 2, 914, 903, 3, 2, 2, 2, 916, 917, 3, 2, 2, 2, 916, 915, 
+	3, 2, 2, 2, 917, 915, 5, 902, 134, 2, 915, 786, 3, 2, 2, 2, 919, 920, 3, 
+	2, 2, 2, 920, 918, 5, 211, 106, 2, 918, 921, 3, 2, 2, 2, 921, 919, 3, 2, 
+	2, 2, 921, 922, 3, 2, 2, 2, 922, 923, 3, 2, 2, 2, 923, 786, 5, 902, 134, 
+	2, 924, 926, 3, 2, 2, 2, 926, 927, 5, 251, 126, 2, 927, 928, 5, 215, 108, 
+	2, 928, 929, 5, 239, 120, 2, 929, 930, 5, 245, 123, 2, 930, 931, 5, 237, 
+	119, 2, 931, 932, 5, 223, 112, 2, 932, 925, 3, 2, 2, 2, 933, 934, 7, 41, 
+	2, 2, 934, 843, 7, 41, 2, 2, 935, 937, 3, 2, 2, 2, 937, 938, 5, 239, 120, 
+	2, 938, 939, 5, 215, 108, 2, 939, 940, 5, 261, 131, 2, 940, 941, 7, 97, 
+	2, 2, 941, 942, 5, 253, 127, 2, 942, 943, 5, 231, 116, 2, 943, 944, 5, 
+	239, 120, 2, 944, 945, 5, 223, 112, 2, 945, 936, 3, 2, 2, 2, 946, 948, 
+	3, 2, 2, 2, 948, 949, 5, 239, 120, 2, 949, 950, 5, 231, 116, 2, 950, 951, 
+	5, 241, 121, 2, 951, 952, 7, 97, 2, 2, 952, 953, 5, 253, 127, 2, 953, 954, 
+	5, 231, 116, 2, 954, 955, 5, 239, 120, 2, 955, 956, 5, 223, 112, 2, 956, 
+	947, 3, 2, 2, 2, 957, 959, 3, 2, 2, 2, 959, 960, 5, 231, 116, 2, 960, 961, 
+	5, 241, 121, 2, 961, 962, 5, 219, 110, 2, 962, 963, 5, 249, 125, 2, 963, 
+	964, 5, 223, 112, 2, 964, 965, 5, 215, 108, 2, 965, 966, 5, 251, 126, 2, 
+	966, 967, 5, 223, 112, 2, 967, 958, 3, 2, 2, 2, 968, 970, 3, 2, 2, 2, 970, 
+	971, 5, 241, 121, 2, 971, 972, 5, 243, 122, 2, 972, 973, 5, 241, 121, 2, 
+	973, 974, 5, 241, 121, 2, 974, 975, 5, 223, 112, 2, 975, 976, 5, 227, 114, 
+	2, 976, 977, 5, 215, 108, 2, 977, 978, 5, 253, 127, 2, 978, 979, 5, 231, 
+	116, 2, 979, 980, 5, 257, 129, 2, 980, 981, 5, 223, 112, 2, 981, 982, 7, 
+	97, 2, 2, 982, 983, 5, 221, 111, 2, 983, 984, 5, 223, 112, 2, 984, 985, 
+	5, 249, 125, 2, 985, 986, 5, 231, 116, 2, 986, 987, 5, 257, 129, 2, 987, 
+	988, 5, 215, 108, 2, 988, 989, 5, 253, 127, 2, 989, 990, 5, 231, 116, 2, 
+	990, 991, 5, 257, 129, 2, 991, 992, 5, 223, 112, 2, 992, 969, 3, 2, 2, 
+	2, 993, 995, 3, 2, 2, 2, 995, 996, 5, 221, 111, 2, 996, 997, 5, 231, 116, 
+	2, 997, 998, 5, 251, 126, 2, 998, 999, 5, 253, 127, 2, 999, 1000, 5, 231, 
+	116, 2, 1000, 1001, 5, 241, 121, 2, 1001, 1002, 5, 219, 110, 2, 1002, 1003, 
+	5, 253, 127, 2, 1003, 1004, 7, 97, 2, 2, 1004, 1005, 5, 219, 110, 2, 1005, 
+	1006, 5, 243, 122, 2, 1006, 1007, 5, 255, 128, 2, 1007, 1008, 5, 241, 121, 
+	2, 1008, 1009, 5, 253, 127, 2, 1009, 994, 3, 2, 2, 2, 1010, 1012, 3, 2, 
+	2, 2, 1012, 1013, 5, 219, 110, 2, 1013, 1014, 5, 243, 122, 2, 1014, 1015, 
+	5, 241, 121, 2, 1015, 1016, 5, 253, 127, 2, 1016, 1017, 5, 215, 108, 2, 
+	1017, 1018, 5, 231, 116, 2, 1018, 1019, 5, 241, 121, 2, 1019, 1020, 5, 
+	251, 126, 2, 1020, 1021, 7, 97, 2, 2, 1021, 1022, 5, 215, 108, 2, 1022, 
+	1023, 5, 237, 119, 2, 1023, 1024, 5, 237, 119, 2, 1024, 1011, 3, 2, 2, 
+	2, 22, 2, 764, 769, 776, 783, 785, 790, 802, 804, 812, 820, 822, 828, 836, 
+	844, 848, 907, 913, 916, 921, 3, 8, 2, 2,
 }
 
 var lexerDeserializer = antlr.NewATNDeserializer(nil)
@@ -458,7 +517,7 @@ var lexerSymbolicNames = []string{
 	"T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", 
 	"T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", 
 	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
-	"L_DEC", "WS",
+	"L_DEC", "WS", "T_SAMPLE", "T_MAX_TIME", "T_MIN_TIME", "T_INCREASE", "T_NONNEGATIVE_DERIVATIVE", "T_DISTINCT_COUNT", "T_CONTAINS_ALL",
 }
 
 var lexerRuleNames = []string{
@@ -479,7 +538,7 @@ var lexerRuleNames = []string{
 	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
 	"L_DEC", "WS", "BLANK", "L_DIGIT", "L_ID_PART", "A", "B", "C", "D", "E", 
 	"F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", 
-	"U", "V", "W", "X", "Y", "Z",
+	"U", "V", "W", "X", "Y", "Z", "L_EXP", "T_SAMPLE", "T_MAX_TIME", "T_MIN_TIME", "T_INCREASE", "T_NONNEGATIVE_DERIVATIVE", "T_DISTINCT_COUNT", "T_CONTAINS_ALL",
 }
 
 type SQLLexer struct {
@@ -620,5 +679,12 @@ const (
 	SQLLexerL_INT = 101
 	SQLLexerL_DEC = 102
 	SQLLexerWS = 103
+	SQLLexerT_SAMPLE = 104
+	SQLLexerT_MAX_TIME = 105
+	SQLLexerT_MIN_TIME = 106
+	SQLLexerT_INCREASE = 107
+	SQLLexerT_NONNEGATIVE_DERIVATIVE = 108
+	SQLLexerT_DISTINCT_COUNT = 109
+	SQLLexerT_CONTAINS_ALL = 110
 )
 
@@ -172,6 +172,9 @@ type SQLListener interface {
 	// EnterNonReservedWords is called when entering the nonReservedWords production.
 	EnterNonReservedWords(c *NonReservedWordsContext)
 
+	// EnterTimeBucket is called when entering the timeBucket production.
+	EnterTimeBucket(c *TimeBucketContext)
+
 	// ExitStatement is called when exiting the statement production.
 	ExitStatement(c *StatementContext)
 
@@ -336,4 +339,7 @@ type SQLListener interface {
 
 	// ExitNonReservedWords is called when exiting the nonReservedWords production.
 	ExitNonReservedWords(c *NonReservedWordsContext)
+
+	// ExitTimeBucket is called when exiting the timeBucket production.
+	ExitTimeBucket(c *TimeBucketContext)
 }
@@ -16,7 +16,7 @@ var _ = strconv.Itoa
 
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 105, 511, 
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 112, 574, 
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7, 
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9, 
@@ -41,160 +41,164 @@ var parserATN = []uint16{
 	3, 14, 3, 14, 3, 14, 3, 15, 3, 15, 3, 15, 7, 15, 225, 10, 15, 12, 15, 14, 
 	15, 228, 11, 15, 3, 16, 3, 16, 5, 16, 232, 10, 16, 3, 17, 3, 17, 3, 17, 
 	3, 18, 3, 18, 3, 18, 3, 19, 3, 19, 3, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 
-	20, 3, 20, 3, 20, 3, 20, 5, 20, 251, 10, 20, 5, 20, 253, 10, 20, 3, 21, 
+	20, 3, 20, 5, 20, 249, 10, 20, 5, 20, 251, 10, 20, 5, 20, 253, 10, 20, 
 	3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 
-	21, 3, 21, 3, 21, 5, 21, 269, 10, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 
-	3, 21, 5, 21, 277, 10, 21, 3, 21, 3, 21, 3, 21, 3, 21, 5, 21, 283, 10, 
-	21, 3, 21, 3, 21, 3, 21, 7, 21, 288, 10, 21, 12, 21, 14, 21, 291, 11, 21, 
-	3, 22, 3, 22, 3, 22, 7, 22, 296, 10, 22, 12, 22, 14, 22, 299, 11, 22, 3, 
-	23, 3, 23, 3, 23, 5, 23, 304, 10, 23, 3, 24, 3, 24, 3, 24, 3, 24, 5, 24, 
-	310, 10, 24, 3, 25, 3, 25, 5, 25, 314, 10, 25, 3, 26, 3, 26, 3, 26, 5, 
-	26, 319, 10, 26, 3, 26, 3, 26, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 
-	3, 27, 3, 27, 5, 27, 331, 10, 27, 3, 27, 5, 27, 334, 10, 27, 3, 28, 3, 
-	28, 3, 28, 7, 28, 339, 10, 28, 12, 28, 14, 28, 342, 11, 28, 3, 29, 3, 29, 
-	3, 29, 3, 29, 3, 29, 3, 29, 5, 29, 350, 10, 29, 3, 30, 3, 30, 3, 31, 3, 
-	31, 3, 31, 3, 31, 3, 32, 3, 32, 7, 32, 360, 10, 32, 12, 32, 14, 32, 363, 
-	11, 32, 3, 33, 3, 33, 3, 33, 7, 33, 368, 10, 33, 12, 33, 14, 33, 371, 11, 
-	33, 3, 34, 3, 34, 3, 34, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 5, 35, 
-	382, 10, 35, 3, 35, 3, 35, 3, 35, 3, 35, 7, 35, 388, 10, 35, 12, 35, 14, 
-	35, 391, 11, 35, 3, 36, 3, 36, 3, 37, 3, 37, 3, 38, 3, 38, 3, 38, 3, 38, 
-	3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 5, 39, 409, 10, 
-	39, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 5, 40, 419, 
-	10, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 
-	3, 40, 3, 40, 3, 40, 7, 40, 433, 10, 40, 12, 40, 14, 40, 436, 11, 40, 3, 
-	41, 3, 41, 3, 41, 3, 42, 3, 42, 3, 43, 3, 43, 3, 43, 5, 43, 446, 10, 43, 
-	3, 43, 3, 43, 3, 44, 3, 44, 3, 45, 3, 45, 3, 45, 7, 45, 455, 10, 45, 12, 
-	45, 14, 45, 458, 11, 45, 3, 46, 3, 46, 5, 46, 462, 10, 46, 3, 47, 3, 47, 
-	5, 47, 466, 10, 47, 3, 47, 3, 47, 5, 47, 470, 10, 47, 3, 48, 3, 48, 3, 
-	48, 3, 48, 3, 49, 5, 49, 477, 10, 49, 3, 49, 3, 49, 3, 50, 5, 50, 482, 
+	21, 3, 21, 3, 21, 3, 21, 5, 21, 269, 10, 21, 3, 21, 3, 21, 3, 21, 3, 21, 
+	3, 21, 3, 21, 5, 21, 277, 10, 21, 3, 21, 3, 21, 3, 21, 3, 21, 5, 21, 283, 
+	10, 21, 3, 21, 3, 21, 3, 21, 7, 21, 288, 10, 21, 12, 21, 14, 21, 291, 11, 
+	21, 3, 22, 3, 22, 3, 22, 7, 22, 296, 10, 22, 12, 22, 14, 22, 299, 11, 22, 
+	3, 23, 3, 23, 3, 23, 5, 23, 304, 10, 23, 3, 24, 3, 24, 3, 24, 3, 24, 5, 
+	24, 310, 10, 24, 3, 25, 3, 25, 5, 25, 314, 10, 25, 3, 26, 3, 26, 3, 26, 
+	5, 26, 319, 10, 26, 3, 26, 3, 26, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 
+	27, 3, 27, 3, 27, 5, 27, 331, 10, 27, 3, 27, 5, 27, 334, 10, 27, 3, 28, 
+	3, 28, 3, 28, 7, 28, 339, 10, 28, 12, 28, 14, 28, 342, 11, 28, 3, 29, 3, 
+	29, 3, 29, 3, 29, 3, 29, 3, 29, 5, 29, 350, 10, 29, 3, 30, 3, 30, 3, 31, 
+	3, 31, 3, 31, 3, 31, 3, 32, 3, 32, 7, 32, 360, 10, 32, 12, 32, 14, 32, 
+	363, 11, 32, 3, 33, 3, 33, 3, 33, 7, 33, 368, 10, 33, 12, 33, 14, 33, 371, 
+	11, 33, 3, 34, 3, 34, 3, 34, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 
+	5, 35, 382, 10, 35, 3, 35, 3, 35, 3, 35, 3, 35, 7, 35, 388, 10, 35, 12, 
+	35, 14, 35, 391, 11, 35, 3, 36, 3, 36, 3, 37, 3, 37, 3, 38, 3, 38, 3, 38, 
+	3, 38, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 5, 39, 409, 
+	10, 39, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 5, 40, 
+	419, 10, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 
+	40, 3, 40, 3, 40, 3, 40, 7, 40, 433, 10, 40, 12, 40, 14, 40, 436, 11, 40, 
+	3, 41, 3, 41, 3, 41, 3, 42, 3, 42, 3, 43, 3, 43, 3, 43, 5, 43, 446, 10, 
+	43, 3, 43, 3, 43, 3, 44, 3, 44, 3, 45, 3, 45, 3, 45, 7, 45, 455, 10, 45, 
+	12, 45, 14, 45, 458, 11, 45, 3, 46, 3, 46, 5, 46, 462, 10, 46, 3, 47, 3, 
+	47, 5, 47, 466, 10, 47, 3, 47, 3, 47, 5, 47, 470, 10, 47, 3, 48, 3, 48, 
+	3, 48, 3, 48, 3, 49, 5, 49, 477, 10, 49, 3, 49, 3, 49, 3, 50, 5, 50, 482, 
 	10, 50, 3, 50, 3, 50, 3, 51, 3, 51, 3, 51, 3, 52, 3, 52, 3, 53, 3, 53, 
 	3, 54, 3, 54, 3, 55, 3, 55, 5, 55, 497, 10, 55, 3, 55, 3, 55, 3, 55, 5, 
 	55, 502, 10, 55, 7, 55, 504, 10, 55, 12, 55, 14, 55, 507, 11, 55, 3, 56, 
-	3, 56, 3, 56, 2, 5, 40, 68, 78, 57, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 
+	3, 56, 3, 56, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 5, 24, 517, 10, 24, 3, 
+	24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 7, 24, 525, 10, 24, 12, 24, 14, 
+	24, 528, 11, 24, 3, 24, 4, 57, 9, 57, 3, 57, 3, 57, 3, 57, 3, 57, 3, 57, 
+	10, 54, 5, 54, 537, 3, 54, 3, 54, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 5, 
+	21, 547, 10, 21, 3, 21, 3, 21, 3, 29, 10, 27, 5, 27, 551, 3, 27, 3, 27, 
+	10, 13, 5, 13, 555, 3, 13, 3, 43, 3, 43, 5, 53, 561, 10, 53, 3, 53, 3, 
+	53, 5, 18, 565, 10, 18, 5, 8, 567, 10, 8, 3, 8, 5, 7, 570, 10, 7, 3, 7, 
+	3, 7, 3, 21, 2, 5, 40, 68, 78, 58, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 
 	22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 
 	58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 
-	94, 96, 98, 100, 102, 104, 106, 108, 110, 2, 10, 3, 2, 43, 44, 4, 2, 46, 
-	47, 103, 104, 3, 2, 49, 50, 4, 2, 51, 51, 88, 88, 3, 2, 72, 78, 3, 2, 65, 
-	71, 3, 2, 97, 98, 11, 2, 3, 3, 7, 7, 9, 11, 15, 27, 29, 32, 34, 38, 41, 
-	55, 57, 60, 64, 78, 2, 531, 2, 112, 3, 2, 2, 2, 4, 122, 3, 2, 2, 2, 6, 
-	124, 3, 2, 2, 2, 8, 127, 3, 2, 2, 2, 10, 138, 3, 2, 2, 2, 12, 153, 3, 2, 
-	2, 2, 14, 161, 3, 2, 2, 2, 16, 170, 3, 2, 2, 2, 18, 188, 3, 2, 2, 2, 20, 
-	190, 3, 2, 2, 2, 22, 192, 3, 2, 2, 2, 24, 195, 3, 2, 2, 2, 26, 218, 3, 
-	2, 2, 2, 28, 221, 3, 2, 2, 2, 30, 229, 3, 2, 2, 2, 32, 233, 3, 2, 2, 2, 
-	34, 236, 3, 2, 2, 2, 36, 239, 3, 2, 2, 2, 38, 252, 3, 2, 2, 2, 40, 282, 
-	3, 2, 2, 2, 42, 292, 3, 2, 2, 2, 44, 300, 3, 2, 2, 2, 46, 305, 3, 2, 2, 
-	2, 48, 311, 3, 2, 2, 2, 50, 315, 3, 2, 2, 2, 52, 322, 3, 2, 2, 2, 54, 335, 
-	3, 2, 2, 2, 56, 349, 3, 2, 2, 2, 58, 351, 3, 2, 2, 2, 60, 353, 3, 2, 2, 
-	2, 62, 357, 3, 2, 2, 2, 64, 364, 3, 2, 2, 2, 66, 372, 3, 2, 2, 2, 68, 381, 
-	3, 2, 2, 2, 70, 392, 3, 2, 2, 2, 72, 394, 3, 2, 2, 2, 74, 396, 3, 2, 2, 
-	2, 76, 408, 3, 2, 2, 2, 78, 418, 3, 2, 2, 2, 80, 437, 3, 2, 2, 2, 82, 440, 
-	3, 2, 2, 2, 84, 442, 3, 2, 2, 2, 86, 449, 3, 2, 2, 2, 88, 451, 3, 2, 2, 
-	2, 90, 461, 3, 2, 2, 2, 92, 469, 3, 2, 2, 2, 94, 471, 3, 2, 2, 2, 96, 476, 
-	3, 2, 2, 2, 98, 481, 3, 2, 2, 2, 100, 485, 3, 2, 2, 2, 102, 488, 3, 2, 
-	2, 2, 104, 490, 3, 2, 2, 2, 106, 492, 3, 2, 2, 2, 108, 496, 3, 2, 2, 2, 
-	110, 508, 3, 2, 2, 2, 112, 113, 5, 4, 3, 2, 113, 114, 7, 2, 2, 3, 114, 
-	3, 3, 2, 2, 2, 115, 123, 5, 6, 4, 2, 116, 123, 5, 8, 5, 2, 117, 123, 5, 
-	10, 6, 2, 118, 123, 5, 12, 7, 2, 119, 123, 5, 14, 8, 2, 120, 123, 5, 16, 
-	9, 2, 121, 123, 5, 24, 13, 2, 122, 115, 3, 2, 2, 2, 122, 116, 3, 2, 2, 
-	2, 122, 117, 3, 2, 2, 2, 122, 118, 3, 2, 2, 2, 122, 119, 3, 2, 2, 2, 122, 
-	120, 3, 2, 2, 2, 122, 121, 3, 2, 2, 2, 123, 5, 3, 2, 2, 2, 124, 125, 7, 
-	17, 2, 2, 125, 126, 7, 19, 2, 2, 126, 7, 3, 2, 2, 2, 127, 128, 7, 17, 2, 
-	2, 128, 133, 7, 21, 2, 2, 129, 130, 7, 35, 2, 2, 130, 131, 7, 20, 2, 2, 
-	131, 132, 7, 81, 2, 2, 132, 134, 5, 18, 10, 2, 133, 129, 3, 2, 2, 2, 133, 
-	134, 3, 2, 2, 2, 134, 136, 3, 2, 2, 2, 135, 137, 5, 100, 51, 2, 136, 135, 
-	3, 2, 2, 2, 136, 137, 3, 2, 2, 2, 137, 9, 3, 2, 2, 2, 138, 139, 7, 17, 
-	2, 2, 139, 142, 7, 23, 2, 2, 140, 141, 7, 16, 2, 2, 141, 143, 5, 22, 12, 
-	2, 142, 140, 3, 2, 2, 2, 142, 143, 3, 2, 2, 2, 143, 148, 3, 2, 2, 2, 144, 
-	145, 7, 35, 2, 2, 145, 146, 7, 24, 2, 2, 146, 147, 7, 81, 2, 2, 147, 149, 
-	5, 18, 10, 2, 148, 144, 3, 2, 2, 2, 148, 149, 3, 2, 2, 2, 149, 151, 3, 
-	2, 2, 2, 150, 152, 5, 100, 51, 2, 151, 150, 3, 2, 2, 2, 151, 152, 3, 2, 
-	2, 2, 152, 11, 3, 2, 2, 2, 153, 154, 7, 17, 2, 2, 154, 157, 7, 26, 2, 2, 
-	155, 156, 7, 16, 2, 2, 156, 158, 5, 22, 12, 2, 157, 155, 3, 2, 2, 2, 157, 
-	158, 3, 2, 2, 2, 158, 159, 3, 2, 2, 2, 159, 160, 5, 34, 18, 2, 160, 13, 
-	3, 2, 2, 2, 161, 162, 7, 17, 2, 2, 162, 163, 7, 27, 2, 2, 163, 166, 7, 
-	29, 2, 2, 164, 165, 7, 16, 2, 2, 165, 167, 5, 22, 12, 2, 166, 164, 3, 2, 
-	2, 2, 166, 167, 3, 2, 2, 2, 167, 168, 3, 2, 2, 2, 168, 169, 5, 34, 18, 
-	2, 169, 15, 3, 2, 2, 2, 170, 171, 7, 17, 2, 2, 171, 172, 7, 27, 2, 2, 172, 
-	175, 7, 32, 2, 2, 173, 174, 7, 16, 2, 2, 174, 176, 5, 22, 12, 2, 175, 173, 
-	3, 2, 2, 2, 175, 176, 3, 2, 2, 2, 176, 177, 3, 2, 2, 2, 177, 178, 5, 34, 
-	18, 2, 178, 179, 7, 31, 2, 2, 179, 180, 7, 30, 2, 2, 180, 181, 7, 81, 2, 
-	2, 181, 183, 5, 20, 11, 2, 182, 184, 5, 36, 19, 2, 183, 182, 3, 2, 2, 2, 
-	183, 184, 3, 2, 2, 2, 184, 186, 3, 2, 2, 2, 185, 187, 5, 100, 51, 2, 186, 
-	185, 3, 2, 2, 2, 186, 187, 3, 2, 2, 2, 187, 17, 3, 2, 2, 2, 188, 189, 5, 
-	108, 55, 2, 189, 19, 3, 2, 2, 2, 190, 191, 5, 108, 55, 2, 191, 21, 3, 2, 
-	2, 2, 192, 193, 5, 108, 55, 2, 193, 23, 3, 2, 2, 2, 194, 196, 7, 39, 2, 
-	2, 195, 194, 3, 2, 2, 2, 195, 196, 3, 2, 2, 2, 196, 197, 3, 2, 2, 2, 197, 
-	200, 5, 26, 14, 2, 198, 199, 7, 16, 2, 2, 199, 201, 5, 22, 12, 2, 200, 
-	198, 3, 2, 2, 2, 200, 201, 3, 2, 2, 2, 201, 202, 3, 2, 2, 2, 202, 204, 
-	5, 34, 18, 2, 203, 205, 5, 36, 19, 2, 204, 203, 3, 2, 2, 2, 204, 205, 3, 
-	2, 2, 2, 205, 207, 3, 2, 2, 2, 206, 208, 5, 52, 27, 2, 207, 206, 3, 2, 
-	2, 2, 207, 208, 3, 2, 2, 2, 208, 210, 3, 2, 2, 2, 209, 211, 5, 60, 31, 
-	2, 210, 209, 3, 2, 2, 2, 210, 211, 3, 2, 2, 2, 211, 213, 3, 2, 2, 2, 212, 
-	214, 5, 100, 51, 2, 213, 212, 3, 2, 2, 2, 213, 214, 3, 2, 2, 2, 214, 216, 
-	3, 2, 2, 2, 215, 217, 7, 40, 2, 2, 216, 215, 3, 2, 2, 2, 216, 217, 3, 2, 
-	2, 2, 217, 25, 3, 2, 2, 2, 218, 219, 7, 41, 2, 2, 219, 220, 5, 28, 15, 
-	2, 220, 27, 3, 2, 2, 2, 221, 226, 5, 30, 16, 2, 222, 223, 7, 90, 2, 2, 
-	223, 225, 5, 30, 16, 2, 224, 222, 3, 2, 2, 2, 225, 228, 3, 2, 2, 2, 226, 
-	224, 3, 2, 2, 2, 226, 227, 3, 2, 2, 2, 227, 29, 3, 2, 2, 2, 228, 226, 3, 
-	2, 2, 2, 229, 231, 5, 78, 40, 2, 230, 232, 5, 32, 17, 2, 231, 230, 3, 2, 
-	2, 2, 231, 232, 3, 2, 2, 2, 232, 31, 3, 2, 2, 2, 233, 234, 7, 42, 2, 2, 
-	234, 235, 5, 108, 55, 2, 235, 33, 3, 2, 2, 2, 236, 237, 7, 34, 2, 2, 237, 
-	238, 5, 102, 52, 2, 238, 35, 3, 2, 2, 2, 239, 240, 7, 35, 2, 2, 240, 241, 
-	5, 38, 20, 2, 241, 37, 3, 2, 2, 2, 242, 253, 5, 40, 21, 2, 243, 244, 5, 
-	40, 21, 2, 244, 245, 7, 43, 2, 2, 245, 246, 5, 44, 23, 2, 246, 253, 3, 
-	2, 2, 2, 247, 250, 5, 44, 23, 2, 248, 249, 7, 43, 2, 2, 249, 251, 5, 40, 
-	21, 2, 250, 248, 3, 2, 2, 2, 250, 251, 3, 2, 2, 2, 251, 253, 3, 2, 2, 2, 
-	252, 242, 3, 2, 2, 2, 252, 243, 3, 2, 2, 2, 252, 247, 3, 2, 2, 2, 253, 
-	39, 3, 2, 2, 2, 254, 255, 8, 21, 1, 2, 255, 256, 7, 95, 2, 2, 256, 257, 
-	5, 40, 21, 2, 257, 258, 7, 96, 2, 2, 258, 283, 3, 2, 2, 2, 259, 268, 5, 
-	104, 53, 2, 260, 269, 7, 81, 2, 2, 261, 269, 7, 51, 2, 2, 262, 263, 7, 
-	52, 2, 2, 263, 269, 7, 51, 2, 2, 264, 269, 7, 88, 2, 2, 265, 269, 7, 89, 
-	2, 2, 266, 269, 7, 82, 2, 2, 267, 269, 7, 83, 2, 2, 268, 260, 3, 2, 2, 
-	2, 268, 261, 3, 2, 2, 2, 268, 262, 3, 2, 2, 2, 268, 264, 3, 2, 2, 2, 268, 
-	265, 3, 2, 2, 2, 268, 266, 3, 2, 2, 2, 268, 267, 3, 2, 2, 2, 269, 270, 
-	3, 2, 2, 2, 270, 271, 5, 106, 54, 2, 271, 283, 3, 2, 2, 2, 272, 276, 5, 
-	104, 53, 2, 273, 277, 7, 62, 2, 2, 274, 275, 7, 52, 2, 2, 275, 277, 7, 
-	62, 2, 2, 276, 273, 3, 2, 2, 2, 276, 274, 3, 2, 2, 2, 277, 278, 3, 2, 2, 
-	2, 278, 279, 7, 95, 2, 2, 279, 280, 5, 42, 22, 2, 280, 281, 7, 96, 2, 2, 
-	281, 283, 3, 2, 2, 2, 282, 254, 3, 2, 2, 2, 282, 259, 3, 2, 2, 2, 282, 
-	272, 3, 2, 2, 2, 283, 289, 3, 2, 2, 2, 284, 285, 12, 3, 2, 2, 285, 286, 
-	9, 2, 2, 2, 286, 288, 5, 40, 21, 4, 287, 284, 3, 2, 2, 2, 288, 291, 3, 
-	2, 2, 2, 289, 287, 3, 2, 2, 2, 289, 290, 3, 2, 2, 2, 290, 41, 3, 2, 2, 
-	2, 291, 289, 3, 2, 2, 2, 292, 297, 5, 106, 54, 2, 293, 294, 7, 90, 2, 2, 
-	294, 296, 5, 106, 54, 2, 295, 293, 3, 2, 2, 2, 296, 299, 3, 2, 2, 2, 297, 
-	295, 3, 2, 2, 2, 297, 298, 3, 2, 2, 2, 298, 43, 3, 2, 2, 2, 299, 297, 3, 
-	2, 2, 2, 300, 303, 5, 46, 24, 2, 301, 302, 7, 43, 2, 2, 302, 304, 5, 46, 
-	24, 2, 303, 301, 3, 2, 2, 2, 303, 304, 3, 2, 2, 2, 304, 45, 3, 2, 2, 2, 
-	305, 306, 7, 60, 2, 2, 306, 309, 5, 76, 39, 2, 307, 310, 5, 48, 25, 2, 
-	308, 310, 5, 108, 55, 2, 309, 307, 3, 2, 2, 2, 309, 308, 3, 2, 2, 2, 310, 
-	47, 3, 2, 2, 2, 311, 313, 5, 50, 26, 2, 312, 314, 5, 80, 41, 2, 313, 312, 
-	3, 2, 2, 2, 313, 314, 3, 2, 2, 2, 314, 49, 3, 2, 2, 2, 315, 316, 7, 61, 
-	2, 2, 316, 318, 7, 95, 2, 2, 317, 319, 5, 88, 45, 2, 318, 317, 3, 2, 2, 
-	2, 318, 319, 3, 2, 2, 2, 319, 320, 3, 2, 2, 2, 320, 321, 7, 96, 2, 2, 321, 
-	51, 3, 2, 2, 2, 322, 323, 7, 55, 2, 2, 323, 324, 7, 57, 2, 2, 324, 330, 
-	5, 54, 28, 2, 325, 326, 7, 45, 2, 2, 326, 327, 7, 95, 2, 2, 327, 328, 5, 
-	58, 30, 2, 328, 329, 7, 96, 2, 2, 329, 331, 3, 2, 2, 2, 330, 325, 3, 2, 
-	2, 2, 330, 331, 3, 2, 2, 2, 331, 333, 3, 2, 2, 2, 332, 334, 5, 66, 34, 
-	2, 333, 332, 3, 2, 2, 2, 333, 334, 3, 2, 2, 2, 334, 53, 3, 2, 2, 2, 335, 
-	340, 5, 56, 29, 2, 336, 337, 7, 90, 2, 2, 337, 339, 5, 56, 29, 2, 338, 
-	336, 3, 2, 2, 2, 339, 342, 3, 2, 2, 2, 340, 338, 3, 2, 2, 2, 340, 341, 
-	3, 2, 2, 2, 341, 55, 3, 2, 2, 2, 342, 340, 3, 2, 2, 2, 343, 350, 5, 108, 
-	55, 2, 344, 345, 7, 60, 2, 2, 345, 346, 7, 95, 2, 2, 346, 347, 5, 80, 41, 
-	2, 347, 348, 7, 96, 2, 2, 348, 350, 3, 2, 2, 2, 349, 343, 3, 2, 2, 2, 349, 
-	344, 3, 2, 2, 2, 350, 57, 3, 2, 2, 2, 351, 352, 9, 3, 2, 2, 352, 59, 3, 
-	2, 2, 2, 353, 354, 7, 48, 2, 2, 354, 355, 7, 57, 2, 2, 355, 356, 5, 64, 
-	33, 2, 356, 61, 3, 2, 2, 2, 357, 361, 5, 78, 40, 2, 358, 360, 9, 4, 2, 
-	2, 359, 358, 3, 2, 2, 2, 360, 363, 3, 2, 2, 2, 361, 359, 3, 2, 2, 2, 361, 
-	362, 3, 2, 2, 2, 362, 63, 3, 2, 2, 2, 363, 361, 3, 2, 2, 2, 364, 369, 5, 
-	62, 32, 2, 365, 366, 7, 90, 2, 2, 366, 368, 5, 62, 32, 2, 367, 365, 3, 
-	2, 2, 2, 368, 371, 3, 2, 2, 2, 369, 367, 3, 2, 2, 2, 369, 370, 3, 2, 2, 
-	2, 370, 65, 3, 2, 2, 2, 371, 369, 3, 2, 2, 2, 372, 373, 7, 56, 2, 2, 373, 
-	374, 5, 68, 35, 2, 374, 67, 3, 2, 2, 2, 375, 376, 8, 35, 1, 2, 376, 377, 
-	7, 95, 2, 2, 377, 378, 5, 68, 35, 2, 378, 379, 7, 96, 2, 2, 379, 382, 3, 
-	2, 2, 2, 380, 382, 5, 72, 37, 2, 381, 375, 3, 2, 2, 2, 381, 380, 3, 2, 
-	2, 2, 382, 389, 3, 2, 2, 2, 383, 384, 12, 4, 2, 2, 384, 385, 5, 70, 36, 
-	2, 385, 386, 5, 68, 35, 5, 386, 388, 3, 2, 2, 2, 387, 383, 3, 2, 2, 2, 
-	388, 391, 3, 2, 2, 2, 389, 387, 3, 2, 2, 2, 389, 390, 3, 2, 2, 2, 390, 
+	94, 96, 98, 100, 102, 104, 106, 108, 110, 530, 2, 10, 3, 2, 43, 44, 4, 
+	2, 46, 47, 103, 104, 3, 2, 49, 50, 4, 2, 51, 51, 88, 88, 3, 2, 72, 78, 
+	4, 2, 65, 71, 106, 111, 3, 2, 97, 98, 12, 2, 3, 3, 7, 7, 9, 11, 15, 27, 
+	29, 32, 34, 38, 41, 55, 57, 60, 64, 78, 106, 112, 2, 612, 2, 112, 3, 2, 
+	2, 2, 4, 122, 3, 2, 2, 2, 6, 124, 3, 2, 2, 2, 8, 127, 3, 2, 2, 2, 10, 138, 
+	3, 2, 2, 2, 12, 153, 3, 2, 2, 2, 14, 161, 3, 2, 2, 2, 16, 170, 3, 2, 2, 
+	2, 18, 188, 3, 2, 2, 2, 20, 190, 3, 2, 2, 2, 22, 192, 3, 2, 2, 2, 24, 195, 
+	3, 2, 2, 2, 26, 218, 3, 2, 2, 2, 28, 221, 3, 2, 2, 2, 30, 229, 3, 2, 2, 
+	2, 32, 233, 3, 2, 2, 2, 34, 236, 3, 2, 2, 2, 36, 239, 3, 2, 2, 2, 38, 252, 
+	3, 2, 2, 2, 40, 282, 3, 2, 2, 2, 42, 292, 3, 2, 2, 2, 44, 300, 3, 2, 2, 
+	2, 46, 516, 3, 2, 2, 2, 48, 311, 3, 2, 2, 2, 50, 315, 3, 2, 2, 2, 52, 322, 
+	3, 2, 2, 2, 54, 335, 3, 2, 2, 2, 56, 349, 3, 2, 2, 2, 58, 351, 3, 2, 2, 
+	2, 60, 353, 3, 2, 2, 2, 62, 357, 3, 2, 2, 2, 64, 364, 3, 2, 2, 2, 66, 372, 
+	3, 2, 2, 2, 68, 381, 3, 2, 2, 2, 70, 392, 3, 2, 2, 2, 72, 394, 3, 2, 2, 
+	2, 74, 396, 3, 2, 2, 2, 76, 408, 3, 2, 2, 2, 78, 418, 3, 2, 2, 2, 80, 437, 
+	3, 2, 2, 2, 82, 440, 3, 2, 2, 2, 84, 442, 3, 2, 2, 2, 86, 449, 3, 2, 2, 
+	2, 88, 451, 3, 2, 2, 2, 90, 461, 3, 2, 2, 2, 92, 469, 3, 2, 2, 2, 94, 471, 
+	3, 2, 2, 2, 96, 476, 3, 2, 2, 2, 98, 481, 3, 2, 2, 2, 100, 485, 3, 2, 2, 
+	2, 102, 488, 3, 2, 2, 2, 104, 560, 3, 2, 2, 2, 106, 538, 3, 2, 2, 2, 108, 
+	496, 3, 2, 2, 2, 110, 508, 3, 2, 2, 2, 112, 113, 5, 4, 3, 2, 113, 114, 
+	7, 2, 2, 3, 114, 3, 3, 2, 2, 2, 115, 123, 5, 6, 4, 2, 116, 123, 5, 8, 5, 
+	2, 117, 123, 5, 10, 6, 2, 118, 123, 5, 12, 7, 2, 119, 123, 5, 14, 8, 2, 
+	120, 123, 5, 16, 9, 2, 121, 123, 5, 24, 13, 2, 122, 115, 3, 2, 2, 2, 122, 
+	116, 3, 2, 2, 2, 122, 117, 3, 2, 2, 2, 122, 118, 3, 2, 2, 2, 122, 119, 
+	3, 2, 2, 2, 122, 120, 3, 2, 2, 2, 122, 121, 3, 2, 2, 2, 123, 5, 3, 2, 2, 
+	2, 124, 125, 7, 17, 2, 2, 125, 126, 7, 19, 2, 2, 126, 7, 3, 2, 2, 2, 127, 
+	128, 7, 17, 2, 2, 128, 133, 7, 21, 2, 2, 129, 130, 7, 35, 2, 2, 130, 131, 
+	7, 20, 2, 2, 131, 132, 7, 81, 2, 2, 132, 134, 5, 18, 10, 2, 133, 129, 3, 
+	2, 2, 2, 133, 134, 3, 2, 2, 2, 134, 136, 3, 2, 2, 2, 135, 137, 5, 100, 
+	51, 2, 136, 135, 3, 2, 2, 2, 136, 137, 3, 2, 2, 2, 137, 9, 3, 2, 2, 2, 
+	138, 139, 7, 17, 2, 2, 139, 142, 7, 23, 2, 2, 140, 141, 7, 16, 2, 2, 141, 
+	143, 5, 22, 12, 2, 142, 140, 3, 2, 2, 2, 142, 143, 3, 2, 2, 2, 143, 148, 
+	3, 2, 2, 2, 144, 145, 7, 35, 2, 2, 145, 146, 7, 24, 2, 2, 146, 147, 7, 
+	81, 2, 2, 147, 149, 5, 18, 10, 2, 148, 144, 3, 2, 2, 2, 148, 149, 3, 2, 
+	2, 2, 149, 151, 3, 2, 2, 2, 150, 152, 5, 100, 51, 2, 151, 150, 3, 2, 2, 
+	2, 151, 152, 3, 2, 2, 2, 152, 11, 3, 2, 2, 2, 153, 569, 7, 17, 2, 2, 154, 
+	570, 7, 26, 2, 2, 155, 156, 7, 16, 2, 2, 156, 158, 5, 22, 12, 2, 157, 155, 
+	3, 2, 2, 2, 157, 158, 3, 2, 2, 2, 158, 159, 3, 2, 2, 2, 159, 160, 5, 34, 
+	18, 2, 160, 13, 3, 2, 2, 2, 161, 162, 7, 17, 2, 2, 162, 163, 7, 27, 2, 
+	2, 163, 166, 7, 29, 2, 2, 164, 165, 7, 16, 2, 2, 165, 167, 5, 22, 12, 2, 
+	166, 164, 3, 2, 2, 2, 166, 167, 3, 2, 2, 2, 167, 168, 3, 2, 2, 2, 168, 
+	169, 5, 34, 18, 2, 169, 566, 3, 2, 2, 2, 170, 171, 7, 17, 2, 2, 171, 172, 
+	7, 27, 2, 2, 172, 175, 7, 32, 2, 2, 173, 174, 7, 16, 2, 2, 174, 176, 5, 
+	22, 12, 2, 175, 173, 3, 2, 2, 2, 175, 176, 3, 2, 2, 2, 176, 177, 3, 2, 
+	2, 2, 177, 178, 5, 34, 18, 2, 178, 179, 7, 31, 2, 2, 179, 180, 7, 30, 2, 
+	2, 180, 181, 7, 81, 2, 2, 181, 183, 5, 20, 11, 2, 182, 184, 5, 36, 19, 
+	2, 183, 182, 3, 2, 2, 2, 183, 184, 3, 2, 2, 2, 184, 186, 3, 2, 2, 2, 185, 
+	187, 5, 100, 51, 2, 186, 185, 3, 2, 2, 2, 186, 187, 3, 2, 2, 2, 187, 17, 
+	3, 2, 2, 2, 188, 189, 5, 108, 55, 2, 189, 19, 3, 2, 2, 2, 190, 191, 5, 
+	108, 55, 2, 191, 21, 3, 2, 2, 2, 192, 193, 5, 108, 55, 2, 193, 23, 3, 2, 
+	2, 2, 194, 556, 7, 39, 2, 2, 195, 194, 3, 2, 2, 2, 195, 196, 3, 2, 2, 2, 
+	196, 197, 3, 2, 2, 2, 197, 200, 5, 26, 14, 2, 198, 199, 7, 16, 2, 2, 199, 
+	201, 5, 22, 12, 2, 200, 198, 3, 2, 2, 2, 200, 201, 3, 2, 2, 2, 201, 202, 
+	3, 2, 2, 2, 202, 204, 5, 34, 18, 2, 203, 205, 5, 36, 19, 2, 204, 203, 3, 
+	2, 2, 2, 204, 205, 3, 2, 2, 2, 205, 207, 3, 2, 2, 2, 206, 208, 5, 52, 27, 
+	2, 207, 206, 3, 2, 2, 2, 207, 208, 3, 2, 2, 2, 208, 210, 3, 2, 2, 2, 209, 
+	211, 5, 60, 31, 2, 210, 209, 3, 2, 2, 2, 210, 211, 3, 2, 2, 2, 211, 213, 
+	3, 2, 2, 2, 212, 214, 5, 100, 51, 2, 213, 212, 3, 2, 2, 2, 213, 214, 3, 
+	2, 2, 2, 214, 216, 3, 2, 2, 2, 215, 217, 7, 40, 2, 2, 216, 215, 3, 2, 2, 
+	2, 216, 217, 3, 2, 2, 2, 217, 25, 3, 2, 2, 2, 218, 219, 7, 41, 2, 2, 219, 
+	220, 5, 28, 15, 2, 220, 27, 3, 2, 2, 2, 221, 226, 5, 30, 16, 2, 222, 223, 
+	7, 90, 2, 2, 223, 225, 5, 30, 16, 2, 224, 222, 3, 2, 2, 2, 225, 228, 3, 
+	2, 2, 2, 226, 224, 3, 2, 2, 2, 226, 227, 3, 2, 2, 2, 227, 29, 3, 2, 2, 
+	2, 228, 226, 3, 2, 2, 2, 229, 231, 5, 78, 40, 2, 230, 232, 5, 32, 17, 2, 
+	231, 230, 3, 2, 2, 2, 231, 232, 3, 2, 2, 2, 232, 31, 3, 2, 2, 2, 233, 234, 
+	7, 42, 2, 2, 234, 235, 5, 108, 55, 2, 235, 33, 3, 2, 2, 2, 236, 237, 7, 
+	34, 2, 2, 237, 564, 5, 102, 52, 2, 238, 565, 5, 32, 17, 2, 239, 240, 7, 
+	35, 2, 2, 240, 241, 5, 38, 20, 2, 241, 37, 3, 2, 2, 2, 254, 255, 8, 21, 
+	1, 2, 255, 256, 7, 95, 2, 2, 256, 257, 5, 40, 21, 2, 257, 258, 7, 96, 2, 
+	2, 258, 283, 3, 2, 2, 2, 259, 268, 5, 104, 53, 2, 260, 269, 7, 81, 2, 2, 
+	261, 269, 7, 51, 2, 2, 262, 263, 7, 52, 2, 2, 263, 269, 7, 51, 2, 2, 264, 
+	269, 7, 88, 2, 2, 265, 269, 7, 89, 2, 2, 266, 269, 7, 82, 2, 2, 267, 269, 
+	7, 83, 2, 2, 268, 260, 3, 2, 2, 2, 268, 261, 3, 2, 2, 2, 268, 262, 3, 2, 
+	2, 2, 268, 264, 3, 2, 2, 2, 268, 265, 3, 2, 2, 2, 268, 266, 3, 2, 2, 2, 
+	268, 267, 3, 2, 2, 2, 269, 270, 3, 2, 2, 2, 270, 271, 5, 106, 54, 2, 271, 
+	283, 3, 2, 2, 2, 272, 276, 5, 104, 53, 2, 273, 277, 7, 62, 2, 2, 274, 275, 
+	7, 52, 2, 2, 275, 277, 7, 62, 2, 2, 276, 273, 3, 2, 2, 2, 276, 274, 3, 
+	2, 2, 2, 276, 573, 3, 2, 2, 2, 277, 278, 3, 2, 2, 2, 278, 279, 7, 95, 2, 
+	2, 279, 280, 5, 42, 22, 2, 280, 281, 7, 96, 2, 2, 281, 283, 3, 2, 2, 2, 
+	282, 254, 3, 2, 2, 2, 282, 259, 3, 2, 2, 2, 282, 272, 3, 2, 2, 2, 282, 
+	511, 3, 2, 2, 2, 282, 545, 3, 2, 2, 2, 283, 289, 3, 2, 2, 2, 284, 285, 
+	12, 3, 2, 2, 285, 286, 9, 2, 2, 2, 286, 288, 5, 40, 21, 4, 287, 284, 3, 
+	2, 2, 2, 288, 291, 3, 2, 2, 2, 289, 287, 3, 2, 2, 2, 289, 290, 3, 2, 2, 
+	2, 290, 41, 3, 2, 2, 2, 291, 289, 3, 2, 2, 2, 292, 297, 5, 106, 54, 2, 
+	293, 294, 7, 90, 2, 2, 294, 296, 5, 106, 54, 2, 295, 293, 3, 2, 2, 2, 296, 
+	299, 3, 2, 2, 2, 297, 295, 3, 2, 2, 2, 297, 298, 3, 2, 2, 2, 298, 43, 3, 
+	2, 2, 2, 299, 297, 3, 2, 2, 2, 300, 303, 5, 46, 24, 2, 301, 302, 7, 43, 
+	2, 2, 302, 304, 5, 46, 24, 2, 303, 301, 3, 2, 2, 2, 303, 304, 3, 2, 2, 
+	2, 304, 45, 3, 2, 2, 2, 305, 306, 7, 60, 2, 2, 306, 309, 5, 76, 39, 2, 
+	307, 310, 5, 48, 25, 2, 308, 310, 5, 108, 55, 2, 309, 307, 3, 2, 2, 2, 
+	309, 308, 3, 2, 2, 2, 310, 517, 3, 2, 2, 2, 311, 313, 5, 50, 26, 2, 312, 
+	314, 5, 80, 41, 2, 313, 312, 3, 2, 2, 2, 313, 314, 3, 2, 2, 2, 314, 49, 
+	3, 2, 2, 2, 315, 316, 7, 61, 2, 2, 316, 318, 7, 95, 2, 2, 317, 319, 5, 
+	88, 45, 2, 318, 317, 3, 2, 2, 2, 318, 319, 3, 2, 2, 2, 319, 320, 3, 2, 
+	2, 2, 320, 321, 7, 96, 2, 2, 321, 51, 3, 2, 2, 2, 322, 323, 7, 55, 2, 2, 
+	323, 324, 7, 57, 2, 2, 324, 552, 5, 54, 28, 2, 325, 326, 7, 45, 2, 2, 326, 
+	327, 7, 95, 2, 2, 327, 328, 5, 58, 30, 2, 328, 329, 7, 96, 2, 2, 329, 331, 
+	3, 2, 2, 2, 330, 325, 3, 2, 2, 2, 330, 331, 3, 2, 2, 2, 331, 333, 3, 2, 
+	2, 2, 332, 334, 5, 66, 34, 2, 333, 332, 3, 2, 2, 2, 333, 334, 3, 2, 2, 
+	2, 334, 53, 3, 2, 2, 2, 335, 340, 5, 56, 29, 2, 336, 337, 7, 90, 2, 2, 
+	337, 339, 5, 56, 29, 2, 338, 336, 3, 2, 2, 2, 339, 342, 3, 2, 2, 2, 340, 
+	338, 3, 2, 2, 2, 340, 341, 3, 2, 2, 2, 341, 55, 3, 2, 2, 2, 342, 340, 3, 
+	2, 2, 2, 343, 350, 5, 108, 55, 2, 344, 345, 7, 60, 2, 2, 345, 346, 7, 95, 
+	2, 2, 346, 347, 5, 80, 41, 2, 347, 348, 7, 96, 2, 2, 348, 350, 3, 2, 2, 
+	2, 550, 350, 7, 100, 2, 2, 349, 343, 3, 2, 2, 2, 349, 344, 3, 2, 2, 2, 
+	349, 550, 3, 2, 2, 2, 350, 57, 3, 2, 2, 2, 351, 352, 9, 3, 2, 2, 352, 59, 
+	3, 2, 2, 2, 353, 354, 7, 48, 2, 2, 354, 355, 7, 57, 2, 2, 355, 356, 5, 
+	64, 33, 2, 356, 61, 3, 2, 2, 2, 357, 361, 5, 78, 40, 2, 358, 360, 9, 4, 
+	2, 2, 359, 358, 3, 2, 2, 2, 360, 363, 3, 2, 2, 2, 361, 359, 3, 2, 2, 2, 
+	361, 362, 3, 2, 2, 2, 362, 63, 3, 2, 2, 2, 363, 361, 3, 2, 2, 2, 364, 369, 
+	5, 62, 32, 2, 365, 366, 7, 90, 2, 2, 366, 368, 5, 62, 32, 2, 367, 365, 
+	3, 2, 2, 2, 368, 371, 3, 2, 2, 2, 369, 367, 3, 2, 2, 2, 369, 370, 3, 2, 
+	2, 2, 370, 65, 3, 2, 2, 2, 371, 369, 3, 2, 2, 2, 372, 373, 7, 56, 2, 2, 
+	373, 374, 5, 68, 35, 2, 374, 67, 3, 2, 2, 2, 375, 376, 8, 35, 1, 2, 376, 
+	377, 7, 95, 2, 2, 377, 378, 5, 68, 35, 2, 378, 379, 7, 96, 2, 2, 379, 382, 
+	3, 2, 2, 2, 380, 382, 5, 72, 37, 2, 381, 375, 3, 2, 2, 2, 381, 380, 3, 
+	2, 2, 2, 382, 389, 3, 2, 2, 2, 383, 384, 12, 4, 2, 2, 384, 385, 5, 70, 
+	36, 2, 385, 386, 5, 68, 35, 5, 386, 388, 3, 2, 2, 2, 387, 383, 3, 2, 2, 
+	2, 388, 391, 3, 2, 2, 2, 389, 387, 3, 2, 2, 2, 389, 390, 3, 2, 2, 2, 390, 
 	69, 3, 2, 2, 2, 391, 389, 3, 2, 2, 2, 392, 393, 9, 2, 2, 2, 393, 71, 3, 
 	2, 2, 2, 394, 395, 5, 74, 38, 2, 395, 73, 3, 2, 2, 2, 396, 397, 5, 78, 
 	40, 2, 397, 398, 5, 76, 39, 2, 398, 399, 5, 78, 40, 2, 399, 75, 3, 2, 2, 
@@ -216,34 +220,64 @@ var parserATN = []uint16{
 	3, 2, 2, 2, 434, 435, 3, 2, 2, 2, 435, 79, 3, 2, 2, 2, 436, 434, 3, 2, 
 	2, 2, 437, 438, 5, 96, 49, 2, 438, 439, 5, 82, 42, 2, 439, 81, 3, 2, 2, 
 	2, 440, 441, 9, 6, 2, 2, 441, 83, 3, 2, 2, 2, 442, 443, 5, 86, 44, 2, 443, 
-	445, 7, 95, 2, 2, 444, 446, 5, 88, 45, 2, 445, 444, 3, 2, 2, 2, 445, 446, 
-	3, 2, 2, 2, 446, 447, 3, 2, 2, 2, 447, 448, 7, 96, 2, 2, 448, 85, 3, 2, 
-	2, 2, 449, 450, 9, 7, 2, 2, 450, 87, 3, 2, 2, 2, 451, 456, 5, 90, 46, 2, 
-	452, 453, 7, 90, 2, 2, 453, 455, 5, 90, 46, 2, 454, 452, 3, 2, 2, 2, 455, 
-	458, 3, 2, 2, 2, 456, 454, 3, 2, 2, 2, 456, 457, 3, 2, 2, 2, 457, 89, 3, 
-	2, 2, 2, 458, 456, 3, 2, 2, 2, 459, 462, 5, 78, 40, 2, 460, 462, 5, 40, 
-	21, 2, 461, 459, 3, 2, 2, 2, 461, 460, 3, 2, 2, 2, 462, 91, 3, 2, 2, 2, 
-	463, 465, 5, 108, 55, 2, 464, 466, 5, 94, 48, 2, 465, 464, 3, 2, 2, 2, 
-	465, 466, 3, 2, 2, 2, 466, 470, 3, 2, 2, 2, 467, 470, 5, 98, 50, 2, 468, 
-	470, 5, 96, 49, 2, 469, 463, 3, 2, 2, 2, 469, 467, 3, 2, 2, 2, 469, 468, 
-	3, 2, 2, 2, 470, 93, 3, 2, 2, 2, 471, 472, 7, 93, 2, 2, 472, 473, 5, 40, 
-	21, 2, 473, 474, 7, 94, 2, 2, 474, 95, 3, 2, 2, 2, 475, 477, 9, 8, 2, 2, 
-	476, 475, 3, 2, 2, 2, 476, 477, 3, 2, 2, 2, 477, 478, 3, 2, 2, 2, 478, 
-	479, 7, 103, 2, 2, 479, 97, 3, 2, 2, 2, 480, 482, 9, 8, 2, 2, 481, 480, 
-	3, 2, 2, 2, 481, 482, 3, 2, 2, 2, 482, 483, 3, 2, 2, 2, 483, 484, 7, 104, 
-	2, 2, 484, 99, 3, 2, 2, 2, 485, 486, 7, 36, 2, 2, 486, 487, 7, 103, 2, 
-	2, 487, 101, 3, 2, 2, 2, 488, 489, 5, 108, 55, 2, 489, 103, 3, 2, 2, 2, 
-	490, 491, 5, 108, 55, 2, 491, 105, 3, 2, 2, 2, 492, 493, 5, 108, 55, 2, 
-	493, 107, 3, 2, 2, 2, 494, 497, 7, 102, 2, 2, 495, 497, 5, 110, 56, 2, 
-	496, 494, 3, 2, 2, 2, 496, 495, 3, 2, 2, 2, 497, 505, 3, 2, 2, 2, 498, 
-	501, 7, 79, 2, 2, 499, 502, 7, 102, 2, 2, 500, 502, 5, 110, 56, 2, 501, 
-	499, 3, 2, 2, 2, 501, 500, 3, 2, 2, 2, 502, 504, 3, 2, 2, 2, 503, 498, 
-	3, 2, 2, 2, 504, 507, 3, 2, 2, 2, 505, 503, 3, 2, 2, 2, 505, 506, 3, 2, 
-	2, 2, 506, 109, 3, 2, 2, 2, 507, 505, 3, 2, 2, 2, 508, 509, 9, 9, 2, 2, 
-	509, 111, 3, 2, 2, 2, 55, 122, 133, 136, 142, 148, 151, 157, 166, 175, 
-	183, 186, 195, 200, 204, 207, 210, 213, 216, 226, 231, 250, 252, 268, 276, 
-	282, 289, 297, 303, 309, 313, 318, 330, 333, 340, 349, 361, 369, 381, 389, 
-	408, 418, 432, 434, 445, 456, 461, 465, 469, 476, 481, 496, 501, 505,
+	445, 7, 95, 2, 2, 445, 558, 3, 2, 2, 2, 444, 446, 5, 88, 45, 2, 445, 444, 
+	3, 2, 2, 2, 445, 446, 3, 2, 2, 2, 446, 447, 3, 2, 2, 2, 447, 448, 7, 96, 
+	2, 2, 448, 85, 3, 2, 2, 2, 449, 450, 9, 7, 2, 2, 450, 87, 3, 2, 2, 2, 451, 
+	456, 5, 90, 46, 2, 452, 453, 7, 90, 2, 2, 453, 455, 5, 90, 46, 2, 454, 
+	452, 3, 2, 2, 2, 455, 458, 3, 2, 2, 2, 456, 454, 3, 2, 2, 2, 456, 457, 
+	3, 2, 2, 2, 457, 89, 3, 2, 2, 2, 458, 456, 3, 2, 2, 2, 459, 462, 5, 78, 
+	40, 2, 460, 462, 5, 40, 21, 2, 461, 459, 3, 2, 2, 2, 461, 460, 3, 2, 2, 
+	2, 462, 91, 3, 2, 2, 2, 463, 465, 5, 108, 55, 2, 464, 466, 5, 94, 48, 2, 
+	465, 464, 3, 2, 2, 2, 465, 466, 3, 2, 2, 2, 466, 470, 3, 2, 2, 2, 467, 
+	470, 5, 98, 50, 2, 468, 470, 5, 96, 49, 2, 469, 463, 3, 2, 2, 2, 469, 467, 
+	3, 2, 2, 2, 469, 468, 3, 2, 2, 2, 470, 93, 3, 2, 2, 2, 471, 472, 7, 93, 
+	2, 2, 472, 473, 5, 40, 21, 2, 473, 474, 7, 94, 2, 2, 474, 95, 3, 2, 2, 
+	2, 475, 477, 9, 8, 2, 2, 476, 475, 3, 2, 2, 2, 476, 477, 3, 2, 2, 2, 477, 
+	478, 3, 2, 2, 2, 478, 479, 7, 103, 2, 2, 479, 97, 3, 2, 2, 2, 480, 482, 
+	9, 8, 2, 2, 481, 480, 3, 2, 2, 2, 481, 482, 3, 2, 2, 2, 482, 483, 3, 2, 
+	2, 2, 483, 484, 7, 104, 2, 2, 484, 99, 3, 2, 2, 2, 485, 486, 7, 36, 2, 
+	2, 486, 487, 7, 103, 2, 2, 487, 101, 3, 2, 2, 2, 488, 489, 5, 108, 55, 
+	2, 489, 103, 3, 2, 2, 2, 490, 491, 5, 108, 55, 2, 491, 561, 3, 2, 2, 2, 
+	492, 493, 5, 108, 55, 2, 493, 537, 3, 2, 2, 2, 494, 497, 7, 102, 2, 2, 
+	495, 497, 5, 110, 56, 2, 496, 494, 3, 2, 2, 2, 496, 495, 3, 2, 2, 2, 497, 
+	505, 3, 2, 2, 2, 498, 501, 7, 79, 2, 2, 499, 502, 7, 102, 2, 2, 500, 502, 
+	5, 110, 56, 2, 501, 499, 3, 2, 2, 2, 501, 500, 3, 2, 2, 2, 502, 504, 3, 
+	2, 2, 2, 503, 498, 3, 2, 2, 2, 504, 507, 3, 2, 2, 2, 505, 503, 3, 2, 2, 
+	2, 505, 506, 3, 2, 2, 2, 506, 109, 3, 2, 2, 2, 507, 505, 3, 2, 2, 2, 508, 
+	509, 9, 9, 2, 2, 509, 111, 3, 2, 2, 2, 511, 512, 7, 52, 2, 2, 512, 513, 
+	7, 95, 2, 2, 513, 514, 5, 40, 21, 2, 514, 515, 7, 96, 2, 2, 515, 283, 3, 
+	2, 2, 2, 516, 305, 3, 2, 2, 2, 516, 518, 3, 2, 2, 2, 517, 47, 3, 2, 2, 
+	2, 518, 519, 7, 60, 2, 2, 519, 520, 7, 62, 2, 2, 520, 521, 7, 95, 2, 2, 
+	521, 526, 5, 530, 57, 2, 522, 523, 7, 90, 2, 2, 523, 525, 5, 530, 57, 2, 
+	524, 522, 3, 2, 2, 2, 525, 528, 3, 2, 2, 2, 526, 524, 3, 2, 2, 2, 526, 
+	527, 3, 2, 2, 2, 527, 529, 3, 2, 2, 2, 528, 526, 3, 2, 2, 2, 529, 517, 
+	7, 96, 2, 2, 530, 532, 3, 2, 2, 2, 532, 533, 5, 108, 55, 2, 533, 534, 7, 
+	79, 2, 2, 534, 535, 7, 79, 2, 2, 535, 536, 5, 108, 55, 2, 536, 531, 3, 
+	2, 2, 2, 538, 492, 3, 2, 2, 2, 538, 539, 3, 2, 2, 2, 538, 540, 3, 2, 2, 
+	2, 539, 537, 5, 98, 50, 2, 540, 537, 5, 96, 49, 2, 537, 107, 3, 2, 2, 2, 
+	541, 547, 7, 86, 2, 2, 542, 547, 7, 87, 2, 2, 543, 547, 7, 84, 2, 2, 544, 
+	547, 7, 85, 2, 2, 545, 546, 7, 102, 2, 2, 546, 541, 3, 2, 2, 2, 546, 542, 
+	3, 2, 2, 2, 546, 543, 3, 2, 2, 2, 546, 544, 3, 2, 2, 2, 547, 548, 3, 2, 
+	2, 2, 548, 549, 5, 106, 54, 2, 549, 283, 3, 2, 2, 2, 552, 553, 3, 2, 2, 
+	2, 552, 551, 3, 2, 2, 2, 553, 554, 7, 102, 2, 2, 554, 551, 5, 80, 41, 2, 
+	551, 330, 3, 2, 2, 2, 556, 557, 3, 2, 2, 2, 556, 555, 3, 2, 2, 2, 557, 
+	555, 7, 102, 2, 2, 555, 196, 3, 2, 2, 2, 558, 559, 7, 102, 2, 2, 559, 446, 
+	5, 108, 55, 2, 560, 490, 3, 2, 2, 2, 560, 562, 3, 2, 2, 2, 560, 563, 3, 
+	2, 2, 2, 562, 561, 5, 98, 50, 2, 563, 561, 5, 96, 49, 2, 561, 105, 3, 2, 
+	2, 2, 564, 238, 3, 2, 2, 2, 564, 565, 3, 2, 2, 2, 565, 35, 3, 2, 2, 2, 
+	566, 568, 3, 2, 2, 2, 566, 567, 3, 2, 2, 2, 568, 567, 5, 100, 51, 2, 567, 
+	15, 3, 2, 2, 2, 569, 154, 3, 2, 2, 2, 569, 571, 3, 2, 2, 2, 571, 572, 7, 
+	25, 2, 2, 572, 570, 7, 29, 2, 2, 570, 157, 3, 2, 2, 2, 242, 248, 5, 40, 
+	21, 2, 243, 244, 7, 43, 2, 2, 244, 249, 5, 44, 23, 2, 248, 243, 3, 2, 2, 
+	2, 248, 249, 3, 2, 2, 2, 249, 253, 3, 2, 2, 2, 245, 250, 5, 44, 23, 2, 
+	246, 247, 7, 43, 2, 2, 247, 251, 5, 40, 21, 2, 250, 246, 3, 2, 2, 2, 250, 
+	251, 3, 2, 2, 2, 251, 253, 3, 2, 2, 2, 252, 242, 3, 2, 2, 2, 252, 245, 
+	3, 2, 2, 2, 253, 39, 3, 2, 2, 2, 573, 277, 7, 112, 2, 2, 66, 122, 133, 
+	136, 142, 148, 151, 157, 166, 175, 183, 186, 195, 200, 204, 207, 210, 213, 
+	216, 226, 231, 248, 250, 252, 268, 276, 282, 289, 297, 303, 309, 313, 318, 
+	330, 333, 340, 349, 361, 369, 381, 389, 408, 418, 432, 434, 445, 456, 461, 
+	465, 469, 476, 481, 496, 501, 505, 516, 526, 538, 546, 552, 556, 560, 564, 
+	566, 569,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -273,7 +307,7 @@ var symbolicNames = []string{
 	"T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", 
 	"T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", 
 	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
-	"L_DEC", "WS",
+	"L_DEC", "WS", "T_SAMPLE", "T_MAX_TIME", "T_MIN_TIME", "T_INCREASE", "T_NONNEGATIVE_DERIVATIVE", "T_DISTINCT_COUNT", "T_CONTAINS_ALL",
 }
 
 var ruleNames = []string{
@@ -287,7 +321,7 @@ var ruleNames = []string{
 	"binaryExpr", "binaryOperator", "fieldExpr", "durationLit", "intervalItem", 
 	"exprFunc", "funcName", "exprFuncParams", "funcParam", "exprAtom", "identFilter", 
 	"intNumber", "decNumber", "limitClause", "metricName", "tagKey", "tagValue", 
-	"ident", "nonReservedWords",
+	"ident", "nonReservedWords", "timeBucket",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -421,6 +455,13 @@ const (
 	SQLParserL_INT = 101
 	SQLParserL_DEC = 102
 	SQLParserWS = 103
+	SQLParserT_SAMPLE = 104
+	SQLParserT_MAX_TIME = 105
+	SQLParserT_MIN_TIME = 106
+	SQLParserT_INCREASE = 107
+	SQLParserT_NONNEGATIVE_DERIVATIVE = 108
+	SQLParserT_DISTINCT_COUNT = 109
+	SQLParserT_CONTAINS_ALL = 110
 )
 
 // SQLParser rules.
@@ -480,6 +521,7 @@ const (
 	SQLParserRULE_tagValue = 52
 	SQLParserRULE_ident = 53
 	SQLParserRULE_nonReservedWords = 54
+	SQLParserRULE_timeBucket = 55
 )
 
 // IStatementContext is an interface to support dynamic dispatch.
@@ -1345,6 +1387,14 @@ func (s *ShowFieldsStmtContext) T_FIELDS() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_FIELDS, 0)
 }
 
+func (s *ShowFieldsStmtContext) T_FIELD() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_FIELD, 0)
+}
+
+func (s *ShowFieldsStmtContext) T_KEYS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_KEYS, 0)
+}
+
 func (s *ShowFieldsStmtContext) FromClause() IFromClauseContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFromClauseContext)(nil)).Elem(), 0)
 
@@ -1420,9 +1470,31 @@ func (p *SQLParser) ShowFieldsStmt() (localctx IShowFieldsStmtContext) {
 		p.SetState(151)
 		p.Match(SQLParserT_SHOW)
 	}
-	{
-		p.SetState(152)
-		p.Match(SQLParserT_FIELDS)
+	p.SetState(567)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SQLParserT_FIELDS:
+		{
+			p.SetState(152)
+			p.Match(SQLParserT_FIELDS)
+		}
+
+
+	case SQLParserT_FIELD:
+		{
+			p.SetState(569)
+			p.Match(SQLParserT_FIELD)
+		}
+		{
+			p.SetState(570)
+			p.Match(SQLParserT_KEYS)
+		}
+
+
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 	p.SetState(155)
 	p.GetErrorHandler().Sync(p)
@@ -1511,6 +1583,16 @@ func (s *ShowTagKeysStmtContext) FromClause() IFromClauseContext {
 	return t.(IFromClauseContext)
 }
 
+func (s *ShowTagKeysStmtContext) LimitClause() ILimitClauseContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ILimitClauseContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILimitClauseContext)
+}
+
 func (s *ShowTagKeysStmtContext) T_ON() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_ON, 0)
 }
@@ -1604,6 +1686,18 @@ func (p *SQLParser) ShowTagKeysStmt() (localctx IShowTagKeysStmtContext) {
 		p.SetState(166)
 		p.FromClause()
 	}
+	p.SetState(564)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	if _la == SQLParserT_LIMIT {
+		{
+			p.SetState(566)
+			p.LimitClause()
+		}
+
+	}
 
 
 
@@ -2227,6 +2321,10 @@ func (s *QueryStmtContext) T_EXPLAIN() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_EXPLAIN, 0)
 }
 
+func (s *QueryStmtContext) L_ID() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_ID, 0)
+}
+
 func (s *QueryStmtContext) T_ON() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_ON, 0)
 }
@@ -2342,6 +2440,18 @@ func (p *SQLParser) QueryStmt() (localctx IQueryStmtContext) {
 			p.SetState(192)
 			p.Match(SQLParserT_EXPLAIN)
 		}
+		p.SetState(554)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+
+		if _la == SQLParserL_ID {
+			{
+				p.SetState(555)
+				p.Match(SQLParserL_ID)
+			}
+
+		}
 
 	}
 	{
@@ -2986,6 +3096,16 @@ func (s *FromClauseContext) MetricName() IMetricNameContext {
 	return t.(IMetricNameContext)
 }
 
+func (s *FromClauseContext) Alias() IAliasContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAliasContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAliasContext)
+}
+
 func (s *FromClauseContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -3013,6 +3133,8 @@ func (s *FromClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *SQLParser) FromClause() (localctx IFromClauseContext) {
 	localctx = NewFromClauseContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 32, SQLParserRULE_fromClause)
+	var _la int
+
 
 	defer func() {
 		p.ExitRule()
@@ -3039,6 +3161,18 @@ func (p *SQLParser) FromClause() (localctx IFromClauseContext) {
 		p.SetState(235)
 		p.MetricName()
 	}
+	p.SetState(562)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	if _la == SQLParserT_AS {
+		{
+			p.SetState(236)
+			p.Alias()
+		}
+
+	}
 
 
 
@@ -3268,35 +3402,35 @@ func (p *SQLParser) ConditionExpr() (localctx IConditionExprContext) {
 
 	p.SetState(250)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 21, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 22, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
 			p.SetState(240)
 			p.tagFilterExpr(0)
 		}
+		p.SetState(246)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 
 
-	case 2:
-		p.EnterOuterAlt(localctx, 2)
-		{
-			p.SetState(241)
-			p.tagFilterExpr(0)
-		}
-		{
-			p.SetState(242)
-			p.Match(SQLParserT_AND)
-		}
-		{
-			p.SetState(243)
-			p.TimeRangeExpr()
+		if _la == SQLParserT_AND {
+			{
+				p.SetState(241)
+				p.Match(SQLParserT_AND)
+			}
+			{
+				p.SetState(242)
+				p.TimeRangeExpr()
+			}
+
 		}
 
 
-	case 3:
-		p.EnterOuterAlt(localctx, 3)
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(245)
+			p.SetState(243)
 			p.TimeRangeExpr()
 		}
 		p.SetState(248)
@@ -3306,11 +3440,11 @@ func (p *SQLParser) ConditionExpr() (localctx IConditionExprContext) {
 
 		if _la == SQLParserT_AND {
 			{
-				p.SetState(246)
+				p.SetState(244)
 				p.Match(SQLParserT_AND)
 			}
 			{
-				p.SetState(247)
+				p.SetState(245)
 				p.tagFilterExpr(0)
 			}
 
@@ -3440,6 +3574,26 @@ func (s *TagFilterExprContext) T_NOTEQUAL2() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_NOTEQUAL2, 0)
 }
 
+func (s *TagFilterExprContext) T_LESS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LESS, 0)
+}
+
+func (s *TagFilterExprContext) T_LESSEQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LESSEQUAL, 0)
+}
+
+func (s *TagFilterExprContext) T_GREATER() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_GREATER, 0)
+}
+
+func (s *TagFilterExprContext) T_GREATEREQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_GREATEREQUAL, 0)
+}
+
+func (s *TagFilterExprContext) L_ID() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_ID, 0)
+}
+
 func (s *TagFilterExprContext) TagValueList() ITagValueListContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITagValueListContext)(nil)).Elem(), 0)
 
@@ -3454,6 +3608,10 @@ func (s *TagFilterExprContext) T_IN() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_IN, 0)
 }
 
+func (s *TagFilterExprContext) T_CONTAINS_ALL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CONTAINS_ALL, 0)
+}
+
 func (s *TagFilterExprContext) T_AND() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_AND, 0)
 }
@@ -3523,7 +3681,7 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 	p.EnterOuterAlt(localctx, 1)
 	p.SetState(280)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 24, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 25, p.GetParserRuleContext()) {
 	case 1:
 		{
 			p.SetState(253)
@@ -3602,6 +3760,7 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 
 
 
+
 		default:
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
@@ -3638,6 +3797,13 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 			}
 
 
+		case SQLParserT_CONTAINS_ALL:
+			{
+				p.SetState(571)
+				p.Match(SQLParserT_CONTAINS_ALL)
+			}
+
+
 
 		default:
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
@@ -3655,11 +3821,77 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
+
+	case 4:
+		{
+			p.SetState(509)
+			p.Match(SQLParserT_NOT)
+		}
+		{
+			p.SetState(510)
+			p.Match(SQLParserT_OPEN_P)
+		}
+		{
+			p.SetState(511)
+			p.tagFilterExpr(0)
+		}
+		{
+			p.SetState(512)
+			p.Match(SQLParserT_CLOSE_P)
+		}
+
+
+	case 5:
+		{
+			p.SetState(543)
+			p.Match(SQLParserL_ID)
+		}
+		p.SetState(544)
+		p.GetErrorHandler().Sync(p)
+
+		switch p.GetTokenStream().LA(1) {
+		case SQLParserT_LESS:
+			{
+				p.SetState(539)
+				p.Match(SQLParserT_LESS)
+			}
+
+
+		case SQLParserT_LESSEQUAL:
+			{
+				p.SetState(540)
+				p.Match(SQLParserT_LESSEQUAL)
+			}
+
+
+		case SQLParserT_GREATER:
+			{
+				p.SetState(541)
+				p.Match(SQLParserT_GREATER)
+			}
+
+
+		case SQLParserT_GREATEREQUAL:
+			{
+				p.SetState(542)
+				p.Match(SQLParserT_GREATEREQUAL)
+			}
+
+
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+		{
+			p.SetState(546)
+			p.TagValue()
+		}
+
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
 	p.SetState(287)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 25, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 26, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -3694,7 +3926,7 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 		}
 		p.SetState(289)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 25, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 26, p.GetParserRuleContext())
 	}
 
 
@@ -3968,7 +4200,7 @@ func (p *SQLParser) TimeRangeExpr() (localctx ITimeRangeExprContext) {
 	p.GetErrorHandler().Sync(p)
 
 
-	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 27, p.GetParserRuleContext()) == 1 {
+	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 28, p.GetParserRuleContext()) == 1 {
 		{
 			p.SetState(299)
 			p.Match(SQLParserT_AND)
@@ -4059,6 +4291,49 @@ func (s *TimeExprContext) Ident() IIdentContext {
 	return t.(IIdentContext)
 }
 
+func (s *TimeExprContext) T_IN() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_IN, 0)
+}
+
+func (s *TimeExprContext) T_OPEN_P() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_OPEN_P, 0)
+}
+
+func (s *TimeExprContext) AllTimeBucket() []ITimeBucketContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ITimeBucketContext)(nil)).Elem())
+	var tst = make([]ITimeBucketContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ITimeBucketContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TimeExprContext) TimeBucket(i int) ITimeBucketContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITimeBucketContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITimeBucketContext)
+}
+
+func (s *TimeExprContext) T_CLOSE_P() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CLOSE_P, 0)
+}
+
+func (s *TimeExprContext) AllT_COMMA() []antlr.TerminalNode {
+	return s.GetTokens(SQLParserT_COMMA)
+}
+
+func (s *TimeExprContext) T_COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(SQLParserT_COMMA, i)
+}
+
 func (s *TimeExprContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -4086,6 +4361,8 @@ func (s *TimeExprContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *SQLParser) TimeExpr() (localctx ITimeExprContext) {
 	localctx = NewTimeExprContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 44, SQLParserRULE_timeExpr)
+	var _la int
+
 
 	defer func() {
 		p.ExitRule()
@@ -4103,38 +4380,88 @@ func (p *SQLParser) TimeExpr() (localctx ITimeExprContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(303)
-		p.Match(SQLParserT_TIME)
-	}
-	{
-		p.SetState(304)
-		p.BinaryOperator()
-	}
-	p.SetState(307)
+	p.SetState(514)
 	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case SQLParserT_NOW:
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 54, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(305)
-			p.NowExpr()
+			p.SetState(303)
+			p.Match(SQLParserT_TIME)
 		}
+		{
+			p.SetState(304)
+			p.BinaryOperator()
+		}
+		p.SetState(307)
+		p.GetErrorHandler().Sync(p)
+
+		switch p.GetTokenStream().LA(1) {
+		case SQLParserT_NOW:
+			{
+				p.SetState(305)
+				p.NowExpr()
+			}
 
 
-	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID:
+		case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID, SQLParserT_SAMPLE, SQLParserT_MAX_TIME, SQLParserT_MIN_TIME, SQLParserT_INCREASE, SQLParserT_NONNEGATIVE_DERIVATIVE, SQLParserT_DISTINCT_COUNT, SQLParserT_CONTAINS_ALL:
+			{
+				p.SetState(306)
+				p.Ident()
+			}
+
+
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+
+
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(306)
-			p.Ident()
+			p.SetState(516)
+			p.Match(SQLParserT_TIME)
+		}
+		{
+			p.SetState(517)
+			p.Match(SQLParserT_IN)
 		}
+		{
+			p.SetState(518)
+			p.Match(SQLParserT_OPEN_P)
+		}
+		{
+			p.SetState(519)
+			p.TimeBucket()
+		}
+		p.SetState(524)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 
 
+		for _la == SQLParserT_COMMA {
+			{
+				p.SetState(520)
+				p.Match(SQLParserT_COMMA)
+			}
+			{
+				p.SetState(521)
+				p.TimeBucket()
+			}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-	}
 
+			p.SetState(526)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+		}
+		{
+			p.SetState(527)
+			p.Match(SQLParserT_CLOSE_P)
+		}
+
+	}
 
 
 	return localctx
@@ -4389,7 +4716,7 @@ func (p *SQLParser) NowFunc() (localctx INowFuncContext) {
 	_la = p.GetTokenStream().LA(1)
 
 
-	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserT_SUB - 96)) | (1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)))) != 0) {
+	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserT_SUB - 96)) | (1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)) | (1 << (SQLParserT_SAMPLE - 96)) | (1 << (SQLParserT_MAX_TIME - 96)) | (1 << (SQLParserT_MIN_TIME - 96)) | (1 << (SQLParserT_INCREASE - 96)) | (1 << (SQLParserT_NONNEGATIVE_DERIVATIVE - 96)) | (1 << (SQLParserT_DISTINCT_COUNT - 96)) | (1 << (SQLParserT_CONTAINS_ALL - 96)))) != 0) {
 		{
 			p.SetState(315)
 			p.ExprFuncParams()
@@ -4471,6 +4798,20 @@ func (s *GroupByClauseContext) T_OPEN_P() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_OPEN_P, 0)
 }
 
+func (s *GroupByClauseContext) L_ID() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_ID, 0)
+}
+
+func (s *GroupByClauseContext) DurationLit() IDurationLitContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDurationLitContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDurationLitContext)
+}
+
 func (s *GroupByClauseContext) FillOption() IFillOptionContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFillOptionContext)(nil)).Elem(), 0)
 
@@ -4554,6 +4895,22 @@ func (p *SQLParser) GroupByClause() (localctx IGroupByClauseContext) {
 		p.SetState(322)
 		p.GroupByKeys()
 	}
+	p.SetState(550)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	if _la == SQLParserL_ID {
+		{
+			p.SetState(551)
+			p.Match(SQLParserL_ID)
+		}
+		{
+			p.SetState(552)
+			p.DurationLit()
+		}
+
+	}
 	p.SetState(328)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
@@ -4814,6 +5171,10 @@ func (s *GroupByKeyContext) T_CLOSE_P() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_CLOSE_P, 0)
 }
 
+func (s *GroupByKeyContext) T_MUL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MUL, 0)
+}
+
 func (s *GroupByKeyContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -4860,7 +5221,7 @@ func (p *SQLParser) GroupByKey() (localctx IGroupByKeyContext) {
 
 	p.SetState(347)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 34, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 35, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
@@ -4888,6 +5249,14 @@ func (p *SQLParser) GroupByKey() (localctx IGroupByKeyContext) {
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
+
+	case 3:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(548)
+			p.Match(SQLParserT_MUL)
+		}
+
 	}
 
 
@@ -5686,7 +6055,7 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 	p.EnterOuterAlt(localctx, 1)
 	p.SetState(379)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 37, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 38, p.GetParserRuleContext()) {
 	case 1:
 		{
 			p.SetState(374)
@@ -5712,7 +6081,7 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
 	p.SetState(387)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 38, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 39, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -5740,7 +6109,7 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 		}
 		p.SetState(389)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 38, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 39, p.GetParserRuleContext())
 	}
 
 
@@ -6478,7 +6847,7 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 	p.EnterOuterAlt(localctx, 1)
 	p.SetState(416)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 40, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 41, p.GetParserRuleContext()) {
 	case 1:
 		{
 			p.SetState(409)
@@ -6518,7 +6887,7 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
 	p.SetState(432)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 42, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 43, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -6528,7 +6897,7 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 			_prevctx = localctx
 			p.SetState(430)
 			p.GetErrorHandler().Sync(p)
-			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 41, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 42, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
@@ -6605,7 +6974,7 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 		}
 		p.SetState(434)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 42, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 43, p.GetParserRuleContext())
 	}
 
 
@@ -6915,6 +7284,20 @@ func (s *ExprFuncContext) T_OPEN_P() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_OPEN_P, 0)
 }
 
+func (s *ExprFuncContext) L_ID() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_ID, 0)
+}
+
+func (s *ExprFuncContext) Ident() IIdentContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentContext)
+}
+
 func (s *ExprFuncContext) T_CLOSE_P() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_CLOSE_P, 0)
 }
@@ -6956,7 +7339,6 @@ func (s *ExprFuncContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *SQLParser) ExprFunc() (localctx IExprFuncContext) {
 	localctx = NewExprFuncContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 82, SQLParserRULE_exprFunc)
-	var _la int
 
 
 	defer func() {
@@ -6986,15 +7368,25 @@ func (p *SQLParser) ExprFunc() (localctx IExprFuncContext) {
 	}
 	p.SetState(443)
 	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 44, p.GetParserRuleContext()) {
+	case 1:
+		{
+			p.SetState(556)
+			p.Match(SQLParserL_ID)
+		}
+		{
+			p.SetState(557)
+			p.Ident()
+		}
 
 
-	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserT_SUB - 96)) | (1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)))) != 0) {
+	case 2:
 		{
 			p.SetState(442)
 			p.ExprFuncParams()
 		}
 
+
 	}
 	{
 		p.SetState(445)
@@ -7073,6 +7465,30 @@ func (s *FuncNameContext) T_HISTOGRAM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_HISTOGRAM, 0)
 }
 
+func (s *FuncNameContext) T_SAMPLE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SAMPLE, 0)
+}
+
+func (s *FuncNameContext) T_MAX_TIME() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MAX_TIME, 0)
+}
+
+func (s *FuncNameContext) T_MIN_TIME() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MIN_TIME, 0)
+}
+
+func (s *FuncNameContext) T_INCREASE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_INCREASE, 0)
+}
+
+func (s *FuncNameContext) T_NONNEGATIVE_DERIVATIVE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_NONNEGATIVE_DERIVATIVE, 0)
+}
+
+func (s *FuncNameContext) T_DISTINCT_COUNT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DISTINCT_COUNT, 0)
+}
+
 func (s *FuncNameContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -7124,7 +7540,7 @@ func (p *SQLParser) FuncName() (localctx IFuncNameContext) {
 		p.SetState(447)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((((_la - 63)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 63))) & ((1 << (SQLParserT_SUM - 63)) | (1 << (SQLParserT_MIN - 63)) | (1 << (SQLParserT_MAX - 63)) | (1 << (SQLParserT_COUNT - 63)) | (1 << (SQLParserT_AVG - 63)) | (1 << (SQLParserT_STDDEV - 63)) | (1 << (SQLParserT_HISTOGRAM - 63)))) != 0)) {
+		if !(((((_la - 63)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 63))) & ((1 << (SQLParserT_SUM - 63)) | (1 << (SQLParserT_MIN - 63)) | (1 << (SQLParserT_MAX - 63)) | (1 << (SQLParserT_COUNT - 63)) | (1 << (SQLParserT_AVG - 63)) | (1 << (SQLParserT_STDDEV - 63)) | (1 << (SQLParserT_HISTOGRAM - 63)))) != 0) || ((((_la - 104)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 104))) & ((1 << (SQLParserT_SAMPLE - 104)) | (1 << (SQLParserT_MAX_TIME - 104)) | (1 << (SQLParserT_MIN_TIME - 104)) | (1 << (SQLParserT_INCREASE - 104)) | (1 << (SQLParserT_NONNEGATIVE_DERIVATIVE - 104)) | (1 << (SQLParserT_DISTINCT_COUNT - 104)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -7389,7 +7805,7 @@ func (p *SQLParser) FuncParam() (localctx IFuncParamContext) {
 
 	p.SetState(459)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 45, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 46, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
@@ -7536,7 +7952,7 @@ func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 
 	p.SetState(467)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 47, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 48, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
@@ -7547,7 +7963,7 @@ func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 		p.GetErrorHandler().Sync(p)
 
 
-		if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 46, p.GetParserRuleContext()) == 1 {
+		if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 47, p.GetParserRuleContext()) == 1 {
 			{
 				p.SetState(462)
 				p.IdentFilter()
@@ -8211,6 +8627,26 @@ func (s *TagKeyContext) Ident() IIdentContext {
 	return t.(IIdentContext)
 }
 
+func (s *TagKeyContext) DecNumber() IDecNumberContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDecNumberContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDecNumberContext)
+}
+
+func (s *TagKeyContext) IntNumber() IIntNumberContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIntNumberContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIntNumberContext)
+}
+
 func (s *TagKeyContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -8255,12 +8691,33 @@ func (p *SQLParser) TagKey() (localctx ITagKeyContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(488)
-		p.Ident()
-	}
+	p.SetState(558)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 60, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(488)
+			p.Ident()
+		}
+
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(560)
+			p.DecNumber()
+		}
+
+
+	case 3:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(561)
+			p.IntNumber()
+		}
 
+	}
 
 
 	return localctx
@@ -8315,6 +8772,26 @@ func (s *TagValueContext) Ident() IIdentContext {
 	return t.(IIdentContext)
 }
 
+func (s *TagValueContext) DecNumber() IDecNumberContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDecNumberContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDecNumberContext)
+}
+
+func (s *TagValueContext) IntNumber() IIntNumberContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIntNumberContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIntNumberContext)
+}
+
 func (s *TagValueContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -8359,12 +8836,33 @@ func (p *SQLParser) TagValue() (localctx ITagValueContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(490)
-		p.Ident()
-	}
+	p.SetState(536)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 56, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(490)
+			p.Ident()
+		}
+
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(537)
+			p.DecNumber()
+		}
+
+
+	case 3:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(538)
+			p.IntNumber()
+		}
 
+	}
 
 
 	return localctx
@@ -8506,7 +9004,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 		}
 
 
-	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserT_SAMPLE, SQLParserT_MAX_TIME, SQLParserT_MIN_TIME, SQLParserT_INCREASE, SQLParserT_NONNEGATIVE_DERIVATIVE, SQLParserT_DISTINCT_COUNT, SQLParserT_CONTAINS_ALL:
 		{
 			p.SetState(493)
 			p.NonReservedWords()
@@ -8519,7 +9017,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 	}
 	p.SetState(503)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 52, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 53, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
@@ -8538,7 +9036,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 				}
 
 
-			case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+			case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserT_SAMPLE, SQLParserT_MAX_TIME, SQLParserT_MIN_TIME, SQLParserT_INCREASE, SQLParserT_NONNEGATIVE_DERIVATIVE, SQLParserT_DISTINCT_COUNT, SQLParserT_CONTAINS_ALL:
 				{
 					p.SetState(498)
 					p.NonReservedWords()
@@ -8554,7 +9052,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 		}
 		p.SetState(505)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 52, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 53, p.GetParserRuleContext())
 	}
 
 
@@ -8817,6 +9315,34 @@ func (s *NonReservedWordsContext) T_YEAR() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_YEAR, 0)
 }
 
+func (s *NonReservedWordsContext) T_SAMPLE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SAMPLE, 0)
+}
+
+func (s *NonReservedWordsContext) T_MAX_TIME() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MAX_TIME, 0)
+}
+
+func (s *NonReservedWordsContext) T_MIN_TIME() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MIN_TIME, 0)
+}
+
+func (s *NonReservedWordsContext) T_INCREASE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_INCREASE, 0)
+}
+
+func (s *NonReservedWordsContext) T_NONNEGATIVE_DERIVATIVE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_NONNEGATIVE_DERIVATIVE, 0)
+}
+
+func (s *NonReservedWordsContext) T_DISTINCT_COUNT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DISTINCT_COUNT, 0)
+}
+
+func (s *NonReservedWordsContext) T_CONTAINS_ALL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CONTAINS_ALL, 0)
+}
+
 func (s *NonReservedWordsContext) T_SUM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_SUM, 0)
 }
@@ -8896,7 +9422,7 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 		p.SetState(506)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0)) {
+		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0) || ((((_la - 104)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 104))) & ((1 << (SQLParserT_SAMPLE - 104)) | (1 << (SQLParserT_MAX_TIME - 104)) | (1 << (SQLParserT_MIN_TIME - 104)) | (1 << (SQLParserT_INCREASE - 104)) | (1 << (SQLParserT_NONNEGATIVE_DERIVATIVE - 104)) | (1 << (SQLParserT_DISTINCT_COUNT - 104)) | (1 << (SQLParserT_CONTAINS_ALL - 104)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -8910,6 +9436,143 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 }
 
 
+// ITimeBucketContext is an interface to support dynamic dispatch.
+type ITimeBucketContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsTimeBucketContext differentiates from other interfaces.
+	IsTimeBucketContext()
+}
+
+type TimeBucketContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyTimeBucketContext() *TimeBucketContext {
+	var p = new(TimeBucketContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_timeBucket
+	return p
+}
+
+func (*TimeBucketContext) IsTimeBucketContext() {}
+
+func NewTimeBucketContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TimeBucketContext {
+	var p = new(TimeBucketContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_timeBucket
+
+	return p
+}
+
+func (s *TimeBucketContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *TimeBucketContext) AllIdent() []IIdentContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IIdentContext)(nil)).Elem())
+	var tst = make([]IIdentContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IIdentContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TimeBucketContext) Ident(i int) IIdentContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentContext)
+}
+
+func (s *TimeBucketContext) AllT_DOT() []antlr.TerminalNode {
+	return s.GetTokens(SQLParserT_DOT)
+}
+
+func (s *TimeBucketContext) T_DOT(i int) antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DOT, i)
+}
+
+func (s *TimeBucketContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TimeBucketContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *TimeBucketContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterTimeBucket(s)
+	}
+}
+
+func (s *TimeBucketContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitTimeBucket(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) TimeBucket() (localctx ITimeBucketContext) {
+	localctx = NewTimeBucketContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 528, SQLParserRULE_timeBucket)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(530)
+		p.Ident()
+	}
+	{
+		p.SetState(531)
+		p.Match(SQLParserT_DOT)
+	}
+	{
+		p.SetState(532)
+		p.Match(SQLParserT_DOT)
+	}
+	{
+		p.SetState(533)
+		p.Ident()
+	}
+
+
+
+	return localctx
+}
+
+
 func (p *SQLParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
 	case 19:
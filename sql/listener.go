@@ -1,50 +1,55 @@
 package sql
 
 import (
+	"time"
+
 	"github.com/lindb/lindb/sql/grammar"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
 type listener struct {
 	*grammar.BaseSQLListener
-	stmt *queryStmtParse
+	stmt     *queryStmtParse
+	location *time.Location // zone for interpreting absolute time literals
+	config   ParserConfig
 
 	metaStmt *metaStmtParser
 }
 
 // EnterQueryStmt is called when production queryStmt is entered.
 func (l *listener) EnterQueryStmt(ctx *grammar.QueryStmtContext) {
-	l.stmt = newQueryStmtParse(ctx.T_EXPLAIN() != nil)
+	l.stmt = newQueryStmtParse(ctx.T_EXPLAIN() != nil, l.location, l.config)
+	l.stmt.visitExplain(ctx)
 }
 
 // EnterShowDatabaseStmt is called when production showDatabaseStmt is entered.
 func (l *listener) EnterShowDatabaseStmt(ctx *grammar.ShowDatabaseStmtContext) {
-	l.metaStmt = newMetaStmtParser(stmt.Database)
+	l.metaStmt = newMetaStmtParser(stmt.Database, l.config)
 }
 
 // EnterShowNameSpacesStmt is called when production showNameSpacesStmt is entered.
 func (l *listener) EnterShowNameSpacesStmt(ctx *grammar.ShowNameSpacesStmtContext) {
-	l.metaStmt = newMetaStmtParser(stmt.Namespace)
+	l.metaStmt = newMetaStmtParser(stmt.Namespace, l.config)
 }
 
 // EnterShowMeasurementsStmt is called when production showMeasurementsStmt is entered.
 func (l *listener) EnterShowMeasurementsStmt(ctx *grammar.ShowMeasurementsStmtContext) {
-	l.metaStmt = newMetaStmtParser(stmt.Metric)
+	l.metaStmt = newMetaStmtParser(stmt.Metric, l.config)
 }
 
 // EnterShowFieldsStmt is called when production showFieldsStmt is entered.
 func (l *listener) EnterShowFieldsStmt(ctx *grammar.ShowFieldsStmtContext) {
-	l.metaStmt = newMetaStmtParser(stmt.Field)
+	l.metaStmt = newMetaStmtParser(stmt.Field, l.config)
 }
 
 // EnterShowTagKeysStmt is called when production showTagKeysStmt is entered.
 func (l *listener) EnterShowTagKeysStmt(ctx *grammar.ShowTagKeysStmtContext) {
-	l.metaStmt = newMetaStmtParser(stmt.TagKey)
+	l.metaStmt = newMetaStmtParser(stmt.TagKey, l.config)
 }
 
 // EnterShowTagValuesStmt is called when production showTagValuesStmt is entered.
 func (l *listener) EnterShowTagValuesStmt(ctx *grammar.ShowTagValuesStmtContext) {
-	l.metaStmt = newMetaStmtParser(stmt.TagValue)
+	l.metaStmt = newMetaStmtParser(stmt.TagValue, l.config)
 }
 
 // EnterNamespace is called when production namespace is entered.
@@ -119,7 +124,7 @@ func (l *listener) EnterFuncName(ctx *grammar.FuncNameContext) {
 // ExitExprFunc is called when production exprFunc is exited.
 func (l *listener) ExitExprFunc(ctx *grammar.ExprFuncContext) {
 	if l.stmt != nil {
-		l.stmt.completeFuncExpr()
+		l.stmt.completeFuncExpr(ctx)
 	}
 }
 
@@ -185,6 +190,13 @@ func (l *listener) EnterTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 }
 
 // EnterGroupByClause is called when production groupByClause is entered.
+func (l *listener) EnterGroupByClause(ctx *grammar.GroupByClauseContext) {
+	if l.stmt != nil {
+		l.stmt.visitGroupByClause(ctx)
+	}
+}
+
+// EnterGroupByKey is called when production groupByKey is entered.
 func (l *listener) EnterGroupByKey(ctx *grammar.GroupByKeyContext) {
 	if l.stmt != nil {
 		l.stmt.visitGroupByKey(ctx)
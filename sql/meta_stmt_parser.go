@@ -17,12 +17,13 @@ type metaStmtParser struct {
 }
 
 // newMetaStmtParser creates a new metadata statement parser
-func newMetaStmtParser(metadataType stmt.MetadataType) *metaStmtParser {
+func newMetaStmtParser(metadataType stmt.MetadataType, cfg ParserConfig) *metaStmtParser {
 	return &metaStmtParser{
 		metadataType: metadataType,
 		baseStmtParser: baseStmtParser{
 			exprStack: collections.NewStack(),
 			namespace: constants.DefaultNamespace,
+			config:    cfg,
 		},
 	}
 }
@@ -32,6 +33,9 @@ func (s *metaStmtParser) build() (stmt.Statement, error) {
 	if s.err != nil {
 		return nil, s.err
 	}
+	if err := s.checkConditionDepth(); err != nil {
+		return nil, err
+	}
 	if s.limit <= 0 {
 		s.limit = 100
 	}
@@ -41,7 +45,7 @@ func (s *metaStmtParser) build() (stmt.Statement, error) {
 		Type:       s.metadataType,
 		TagKey:     s.tagKey,
 		Prefix:     s.prefix,
-		Condition:  s.condition,
+		Condition:  stmt.Simplify(s.condition),
 		Limit:      s.limit,
 	}, nil
 }
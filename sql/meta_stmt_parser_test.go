@@ -6,11 +6,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestMetaStmt_validation(t *testing.T) {
-	queryStmt := newMetaStmtParser(stmt.TagKey)
+	queryStmt := newMetaStmtParser(stmt.TagKey, ParserConfig{})
 	// case 1: stmt err
 	queryStmt.err = fmt.Errorf("err")
 	s, err := queryStmt.build()
@@ -69,6 +70,18 @@ func TestMetaStmt_ShowFields(t *testing.T) {
 	assert.Equal(t, "ns", query.Namespace)
 }
 
+func TestMetaStmt_ShowFieldKeys(t *testing.T) {
+	q, err := Parse("show field keys from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Metadata)
+	assert.Equal(t, stmt.Field, query.Type)
+	assert.Equal(t, "cpu", query.MetricName)
+	assert.Equal(t, constants.DefaultNamespace, query.Namespace)
+
+	_, err = Parse("show field from cpu")
+	assert.Error(t, err)
+}
+
 func TestMetaStmt_ShowTagKeys(t *testing.T) {
 	sql := "show tag keys on 'ns' from 'cpu' "
 	q, err := Parse(sql)
@@ -79,6 +92,37 @@ func TestMetaStmt_ShowTagKeys(t *testing.T) {
 	assert.Equal(t, "ns", query.Namespace)
 }
 
+func TestMetaStmt_ShowTagKeys_default(t *testing.T) {
+	q, err := Parse("show tag keys from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Metadata)
+	assert.Equal(t, stmt.TagKey, query.Type)
+	assert.Equal(t, "cpu", query.MetricName)
+	assert.Equal(t, constants.DefaultNamespace, query.Namespace)
+	assert.Equal(t, 100, query.Limit)
+}
+
+func TestMetaStmt_ShowTagKeys_limit(t *testing.T) {
+	q, err := Parse("show tag keys from cpu limit 10")
+	assert.NoError(t, err)
+	query := q.(*stmt.Metadata)
+	assert.Equal(t, stmt.TagKey, query.Type)
+	assert.Equal(t, "cpu", query.MetricName)
+	assert.Equal(t, 10, query.Limit)
+}
+
+func TestMetaStmt_ShowTagValues_limit(t *testing.T) {
+	q, err := Parse("show tag values from cpu with key = host limit 5")
+	assert.NoError(t, err)
+	query := q.(*stmt.Metadata)
+	assert.Equal(t, stmt.TagValue, query.Type)
+	assert.Equal(t, "cpu", query.MetricName)
+	assert.Equal(t, constants.DefaultNamespace, query.Namespace)
+	assert.Equal(t, "host", query.TagKey)
+	assert.Equal(t, 5, query.Limit)
+	assert.Nil(t, query.Condition)
+}
+
 func TestMetaStmt_ShowTagValues(t *testing.T) {
 	sql := "show tag values on 'ns' from 'cpu' with key = 'key1' where key1='value1' and key2='value2' limit 10"
 	q, err := Parse(sql)
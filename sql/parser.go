@@ -2,6 +2,7 @@ package sql
 
 import (
 	"errors"
+	"time"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 
@@ -16,11 +17,23 @@ var (
 )
 
 var log = logger.GetLogger("sql", "Parser")
-var errorHandle = &errorListener{}
+var errorHandle = &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
 var walker = antlr.ParseTreeWalkerDefault
 
 // Parse parses sql using the grammar of LinDB query language
 func Parse(sql string) (stmt stmt.Statement, err error) {
+	return ParseWithLocation(sql, time.Local)
+}
+
+// ParseWithLocation parses sql using the grammar of LinDB query language,
+// absolute time literals are interpreted in the given zone, nil means local zone.
+func ParseWithLocation(sql string, location *time.Location) (stmt stmt.Statement, err error) {
+	return ParseWithConfig(sql, location, ParserConfig{})
+}
+
+// ParseWithConfig parses sql using the grammar of LinDB query language based on parser config,
+// absolute time literals are interpreted in the given zone, nil means local zone.
+func ParseWithConfig(sql string, location *time.Location, cfg ParserConfig) (stmt stmt.Statement, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			switch x := r.(type) {
@@ -35,6 +48,25 @@ func Parse(sql string) (stmt stmt.Statement, err error) {
 			stmt = nil
 		}
 	}()
+	if location == nil {
+		location = time.Local
+	}
+	return parse(sql, location, cfg)
+}
+
+// parse parses sql, returns syntax error if sql is malformed,
+// other panic is not recovered, so that fuzz test can find the crash.
+func parse(sql string, location *time.Location, cfg ParserConfig) (stmt stmt.Statement, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			syntaxErr, ok := r.(*syntaxError)
+			if !ok {
+				panic(r)
+			}
+			stmt = nil
+			err = syntaxErr
+		}
+	}()
 
 	input := antlr.NewInputStream(sql)
 
@@ -52,7 +84,7 @@ func Parse(sql string) (stmt stmt.Statement, err error) {
 	ctx := parser.Statement()
 
 	// create sql listener
-	listener := listener{}
+	listener := listener{location: location, config: cfg}
 
 	walker.Walk(&listener, ctx)
 
@@ -0,0 +1,43 @@
+package sql
+
+import (
+	"time"
+)
+
+const (
+	// DefaultMaxExprDepth represents the default max depth of condition expression tree
+	DefaultMaxExprDepth = 64
+	// DefaultTimeRange represents the default look-back window of query which has no start time
+	DefaultTimeRange = time.Hour
+)
+
+// ParserConfig represents the config of parsing sql, the zero value uses the default values
+type ParserConfig struct {
+	// MaxExprDepth is the max depth of condition expression tree,
+	// protects the server from stack overflow by deeply nested condition, uses DefaultMaxExprDepth if <= 0.
+	MaxExprDepth int
+	// DefaultTimeRange is the look-back window of query which has no start time, so that the query
+	// doesn't scan all of history, uses DefaultTimeRange if <= 0.
+	DefaultTimeRange time.Duration
+	// MeasurementTimeRanges overrides the default time range for the measurement, ignores the window <= 0.
+	MeasurementTimeRanges map[string]time.Duration
+}
+
+// maxExprDepth returns the max depth of condition expression tree
+func (cfg ParserConfig) maxExprDepth() int {
+	if cfg.MaxExprDepth <= 0 {
+		return DefaultMaxExprDepth
+	}
+	return cfg.MaxExprDepth
+}
+
+// defaultTimeRange returns the default look-back window(millis) of the measurement
+func (cfg ParserConfig) defaultTimeRange(measurement string) int64 {
+	if window, ok := cfg.MeasurementTimeRanges[measurement]; ok && window > 0 {
+		return window.Milliseconds()
+	}
+	if cfg.DefaultTimeRange <= 0 {
+		return DefaultTimeRange.Milliseconds()
+	}
+	return cfg.DefaultTimeRange.Milliseconds()
+}
@@ -2,7 +2,9 @@ package sql
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/stretchr/testify/assert"
@@ -45,6 +47,155 @@ func TestParse_panic(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestParse_malformed(t *testing.T) {
+	cases := []string{
+		"",
+		"select",
+		// unterminated string
+		"select f from cpu where host='a",
+		"select f from cpu where host=\"a",
+		"select f from cpu where host in ('a','b",
+		"show tag values from cpu with key=host where host like 'a",
+		// unbalanced parentheses
+		"select f from cpu where (host='a'",
+		"select f from cpu where host='a')",
+		"select f from cpu where not (host='a' or host='b'",
+		"select sum(f from cpu",
+		"select (f+1 from cpu",
+		"select f from cpu group by time(1m",
+		// trailing operator
+		"select f from cpu where host='a' and",
+		"select f from cpu where host='a' or",
+		"select f from cpu where not",
+		"select f+ from cpu",
+		"select f* from cpu",
+		"select f from cpu where time>now()-",
+		"select f from cpu where time>",
+		"select f from cpu group by",
+		"select f from cpu limit",
+	}
+	for _, sql := range cases {
+		sql := sql
+		assert.NotPanics(t, func() {
+			q, err := parse(sql, time.Local, ParserConfig{})
+			assert.Error(t, err, sql)
+			assert.Nil(t, q, sql)
+		}, sql)
+	}
+}
+
+func TestParse_fullContextPrediction(t *testing.T) {
+	// ambiguous inputs make prediction fall back to full context, which reports to error listener,
+	// the listener without default implementation crashed with nil pointer.
+	cases := []string{
+		"select f from cpu where host='a' and time='20190410 00:00:00'",
+		"select f from cpu where time='x' and host='a'",
+		"select f from cpu where host='a' and time in ('a','b')",
+		"show tag values from cpu with key=host where host='a' and time='x'",
+	}
+	for _, sql := range cases {
+		sql := sql
+		assert.NotPanics(t, func() {
+			q, err := parse(sql, time.Local, ParserConfig{})
+			assert.NoError(t, err, sql)
+			assert.NotNil(t, q, sql)
+		}, sql)
+	}
+}
+
+func TestParse_longCondition(t *testing.T) {
+	// prediction of where clause costed exponential time with nested and/or chains, which hung the parser.
+	nested := "host='a'"
+	for i := 0; i < 30; i++ {
+		nested = strings.Repeat("host='a' and ", 30) + "(" + nested + ")"
+	}
+	cases := []string{
+		"select f from cpu where " + strings.Repeat("host='a' and ", 1000) + "host='a'",
+		"select f from cpu where " + strings.Repeat("host='a' or ", 1000) + "host='a'",
+		"select f from cpu where " + strings.Repeat("host='a' and ", 1000) + "time>now()-1h and time<now()",
+		"select f from cpu where time>now()-1h and " + strings.Repeat("host='a' and ", 1000) + "host='a'",
+		"select f from cpu where " + strings.Repeat("host='a' and ", 1000) + "host='a' and",
+		"select f from cpu where " + nested,
+		"select f from cpu where " + strings.Repeat("(", 10000) + "host='a'" + strings.Repeat(")", 10000),
+		"select f from cpu where " + strings.Repeat("(", 10000) + "host='a'",
+		"select f from cpu where " + strings.Repeat("not (", 10000) + "host='a'" + strings.Repeat(")", 10000),
+	}
+	for _, sql := range cases {
+		sql := sql
+		assert.NotPanics(t, func() {
+			q, err := parse(sql, time.Local, ParserConfig{})
+			assert.Error(t, err)
+			assert.Nil(t, q)
+		})
+	}
+}
+
+func TestParse_crashers(t *testing.T) {
+	// inputs which crashed or hung the parser, every one must return a query or an error
+	cases := []string{
+		"\x00",
+		"select \x00 from cpu",
+		"select f from cpu\x00",
+		"'",
+		"\"",
+		"`",
+		"select f from `cpu",
+		"select f from cpu where host='\\'",
+		"select f from cpu where host=\"\\\"",
+		"select f from cpu where host=''''",
+		"select f from cpu where host='\xff\xfe'",
+		"select f from cpu where 主机='北京'",
+		"select \xe4\xb8 from cpu",
+		"select f from cpu where time>now()-999999999999999999999h",
+		"select f from cpu where time>now()-1x",
+		"select f from cpu where time>-9223372036854775808",
+		"select f from cpu group by time(0s)",
+		"select f from cpu group by time(-1m)",
+		"select f from cpu group by time(99999999999999999999m)",
+		"select f from cpu limit 99999999999999999999",
+		"select f from cpu limit -1",
+		"select f from cpu where f>1e400",
+		"select f from cpu where f>1e",
+		"select 1e400 from cpu",
+		"select sum() from cpu",
+		"select sum(,) from cpu",
+		"select sum(f,) from cpu",
+		"select sum(sum(sum(f))) from cpu",
+		"select f as from cpu",
+		"select f from cpu where host in ()",
+		"select f from cpu where host in (,)",
+		"select f from cpu where host like ''",
+		"select f from cpu where host =~ '('",
+		"select f from cpu where host =~ '[' and host !~ '*'",
+		"select f from cpu where not not not host='a'",
+		"select f from cpu where ()",
+		"select f from cpu where time>now()-1h and time>now()-1h and time>now()-1h",
+		"select f from cpu group by host, host, host",
+		"select f from cpu order by",
+		"select f from cpu order by f desc desc",
+		"show tag values from cpu with key=",
+		"show tag values from cpu with key=host limit",
+		"show fields from",
+		"show",
+		"select " + strings.Repeat("f,", 10000) + "f from cpu",
+		"select " + strings.Repeat("(", 10000) + "f" + strings.Repeat(")", 10000) + " from cpu",
+		"select " + strings.Repeat("sum(", 10000) + "f" + strings.Repeat(")", 10000) + " from cpu",
+		"select " + strings.Repeat("f+", 10000) + "f from cpu",
+		"select f from cpu where " + strings.Repeat("host in ('a') and ", 1000) + "host='a'",
+	}
+	for _, sql := range cases {
+		sql := sql
+		assert.NotPanics(t, func() {
+			q, err := parse(sql, time.Local, ParserConfig{})
+			if err != nil {
+				assert.Nil(t, q, sql)
+			} else {
+				assert.NotNil(t, q, sql)
+			}
+		}, sql)
+	}
+}
+
 func BenchmarkSQLParse(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = Parse("select f from cpu " +
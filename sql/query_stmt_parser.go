@@ -2,8 +2,11 @@ package sql
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/constants"
@@ -18,30 +21,45 @@ import (
 type queryStmtParse struct {
 	baseStmtParser
 	explain bool
+	analyze bool
 
 	selectItems []stmt.Expr
 	fieldNames  map[string]struct{}
+	metricAlias string
+	selectTime  bool
 
-	startTime int64
-	endTime   int64
+	startTime   int64
+	endTime     int64
+	timeBuckets []timeutil.TimeRange
+	location    *time.Location // zone for interpreting absolute time literals
 
 	//orderByExpr stmt.Expr
 	//desc        bool
-	groupBy  []string
-	interval int64
-	fieldID  int
+	groupBy       []string
+	groupByAll    bool
+	interval      int64
+	window        int64
+	step          int64
+	fill          stmt.Fill
+	fieldID       int
+	countDistinct string
 }
 
 // newQueryStmtParse create a query statement parser
-func newQueryStmtParse(explain bool) *queryStmtParse {
+func newQueryStmtParse(explain bool, location *time.Location, cfg ParserConfig) *queryStmtParse {
+	if location == nil {
+		location = time.Local
+	}
 	return &queryStmtParse{
 		explain:    explain,
+		location:   location,
 		fieldNames: make(map[string]struct{}),
 		fieldID:    1,
 		baseStmtParser: baseStmtParser{
 			exprStack: collections.NewStack(),
 			namespace: constants.DefaultNamespace,
 			limit:     20,
+			config:    cfg,
 		},
 	}
 }
@@ -51,13 +69,19 @@ func (q *queryStmtParse) build() (stmt.Statement, error) {
 	if err := q.validation(); err != nil {
 		return nil, err
 	}
+	if err := q.checkConditionDepth(); err != nil {
+		return nil, err
+	}
 
 	query := &stmt.Query{}
 	query.Explain = q.explain
+	query.Analyze = q.analyze
 	query.Namespace = q.namespace
 	query.MetricName = q.metricName
+	query.MetricAlias = q.metricAlias
 	query.SelectItems = q.selectItems
-	query.Condition = q.condition
+	query.SelectTime = q.selectTime
+	query.Condition, query.Annotations = stmt.SimplifyWithAnnotations(q.condition)
 
 	fieldNames := make([]string, len(q.fieldNames))
 	idx := 0
@@ -70,20 +94,48 @@ func (q *queryStmtParse) build() (stmt.Statement, error) {
 	})
 	query.FieldNames = fieldNames
 
-	now := timeutil.Now()
-	query.TimeRange = timeutil.TimeRange{Start: q.startTime, End: q.endTime}
-	if query.TimeRange.Start <= 0 {
-		query.TimeRange.Start = now - timeutil.OneHour
-	}
-	if query.TimeRange.End <= 0 {
-		query.TimeRange.End = now
-	}
-	if query.TimeRange.End < query.TimeRange.Start {
-		return nil, fmt.Errorf("start time cannot be larger than end time")
+	if len(q.timeBuckets) > 0 {
+		if q.startTime > 0 || q.endTime > 0 {
+			return nil, fmt.Errorf("time buckets cannot be used with time comparison")
+		}
+		// merge overlapping time buckets, query time range covers all of them
+		query.TimeRanges = timeutil.MergeTimeRanges(q.timeBuckets)
+		query.TimeRange = timeutil.TimeRange{
+			Start: query.TimeRanges[0].Start,
+			End:   query.TimeRanges[len(query.TimeRanges)-1].End,
+		}
+	} else {
+		now := timeutil.Now()
+		query.TimeRange = timeutil.TimeRange{Start: q.startTime, End: q.endTime}
+		if query.TimeRange.End <= 0 {
+			query.TimeRange.End = now
+		}
+		if query.TimeRange.Start <= 0 {
+			// no start time, looks back the default time range of measurement from end time
+			query.TimeRange.Start = query.TimeRange.End - q.config.defaultTimeRange(q.metricName)
+		}
+		if query.TimeRange.End < query.TimeRange.Start {
+			return nil, fmt.Errorf("start time cannot be larger than end time")
+		}
 	}
 
+	if q.window > 0 {
+		if len(q.timeBuckets) > 0 {
+			return nil, fmt.Errorf("over window cannot be used with time buckets")
+		}
+		// only aggregates the points within the trailing window ending at the query's end time
+		query.Window = timeutil.Interval(q.window)
+		if start := query.TimeRange.End - q.window; start > query.TimeRange.Start {
+			query.TimeRange.Start = start
+		}
+	}
 	query.Interval = timeutil.Interval(q.interval)
+	query.Raw = q.interval == 0 && q.step == 0 && q.window == 0 && isBareFields(q.selectItems)
+	query.OutputInterval = timeutil.Interval(q.step)
+	query.Fill = q.fill
 	query.GroupBy = q.groupBy
+	query.GroupByAll = q.groupByAll
+	query.CountDistinct = q.countDistinct
 	query.Limit = q.limit
 	return query, nil
 }
@@ -96,23 +148,122 @@ func (q *queryStmtParse) validation() error {
 	if len(q.metricName) == 0 {
 		return fmt.Errorf("metric name cannot be empty")
 	}
-	if len(q.selectItems) == 0 {
+	if len(q.selectItems) == 0 && q.countDistinct == "" {
 		return fmt.Errorf("select fields cannbe be empty")
 	}
+	if q.selectTime {
+		// output name of select item cannot be time, which is the name of time column in result
+		for _, item := range q.selectItems {
+			selectItem, ok := item.(*stmt.SelectItem)
+			if !ok {
+				continue
+			}
+			name := selectItem.Alias
+			if name == "" {
+				name = selectItem.Expr.Rewrite()
+			}
+			if name == stmt.TimeColumn {
+				return fmt.Errorf("select item named time collides with time column, use an alias for it")
+			}
+		}
+	}
+	if q.countDistinct != "" {
+		if len(q.selectItems) > 0 {
+			return fmt.Errorf("count(distinct tag) cannot be used with other select fields")
+		}
+		if len(q.groupBy) > 0 || q.groupByAll {
+			return fmt.Errorf("count(distinct tag) cannot be used with group by")
+		}
+	}
 	return nil
 }
 
+// isBareFields returns if all select items are fields without function call or arithmetic
+func isBareFields(selectItems []stmt.Expr) bool {
+	if len(selectItems) == 0 {
+		return false
+	}
+	for _, item := range selectItems {
+		selectItem, ok := item.(*stmt.SelectItem)
+		if !ok {
+			return false
+		}
+		if _, ok := selectItem.Expr.(*stmt.FieldExpr); !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // resetExprStack resets expr stack for next parse fragment
 func (q *queryStmtParse) resetExprStack() {
 	q.exprStack = collections.NewStack()
 }
 
+// visitExplain visits when production query statement is entered, parses explain analyze
+func (q *queryStmtParse) visitExplain(ctx *grammar.QueryStmtContext) {
+	if ctx.L_ID() == nil {
+		return
+	}
+	// analyze is not a reserved word, so checks it here
+	if !strings.EqualFold(ctx.L_ID().GetText(), "analyze") {
+		q.err = fmt.Errorf("unknown explain option: %s, expect analyze", ctx.L_ID().GetText())
+		return
+	}
+	q.analyze = true
+}
+
+// visitGroupByClause visits when production groupBy clause is entered,
+// parses the trailing window(over duration) or the output step(step duration), and the fill option.
+func (q *queryStmtParse) visitGroupByClause(ctx *grammar.GroupByClauseContext) {
+	if ctx.FillOption() != nil {
+		q.visitFillOption(ctx.FillOption().(*grammar.FillOptionContext))
+	}
+	if ctx.L_ID() == nil {
+		return
+	}
+	// over/step are not reserved words, so checks them here
+	switch clause := ctx.L_ID().GetText(); {
+	case strings.EqualFold(clause, "over"):
+		q.window = q.parseDuration(ctx.DurationLit())
+		if q.window <= 0 && q.err == nil {
+			q.err = fmt.Errorf("over window must be positive")
+		}
+	case strings.EqualFold(clause, "step"):
+		q.step = q.parseDuration(ctx.DurationLit())
+		if q.step <= 0 && q.err == nil {
+			q.err = fmt.Errorf("output step must be positive")
+		}
+	default:
+		q.err = fmt.Errorf("unknown group by clause: %s, expect over or step", clause)
+	}
+}
+
+// visitFillOption visits the fill option of group by clause
+func (q *queryStmtParse) visitFillOption(ctx *grammar.FillOptionContext) {
+	switch {
+	case ctx.T_NULL() != nil:
+		q.fill = stmt.Fill{Type: stmt.FillNull}
+	case ctx.T_PREVIOUS() != nil:
+		q.fill = stmt.Fill{Type: stmt.FillPrevious}
+	default:
+		value, err := strconv.ParseFloat(ctx.GetText(), 64)
+		if err != nil {
+			q.err = err
+			return
+		}
+		q.fill = stmt.Fill{Type: stmt.FillValue, Value: value}
+	}
+}
+
 // visitGroupByKey visits when production groupBy key expression is entered
 func (q *queryStmtParse) visitGroupByKey(ctx *grammar.GroupByKeyContext) {
 	switch {
 	case ctx.Ident() != nil:
 		tagKey := strutil.GetStringValue(ctx.Ident().GetText())
 		q.groupBy = append(q.groupBy, tagKey)
+	case ctx.T_MUL() != nil:
+		q.groupByAll = true
 	case ctx.DurationLit() != nil:
 		q.interval = q.parseDuration(ctx.DurationLit())
 	}
@@ -126,11 +277,15 @@ func (q *queryStmtParse) visitTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 		if !ok {
 			continue
 		}
+		if timeExprCtx.T_IN() != nil {
+			q.visitTimeBuckets(timeExprCtx)
+			continue
+		}
 		var timestamp int64
 		var err error
 		switch {
 		case timeExprCtx.Ident() != nil:
-			timestamp, err = timeutil.ParseTimestamp(strutil.GetStringValue(timeExprCtx.Ident().GetText()))
+			timestamp, err = timeutil.ParseTimestampInLocation(strutil.GetStringValue(timeExprCtx.Ident().GetText()), q.location)
 		case timeExprCtx.NowExpr() != nil:
 			timestamp = timeutil.Now()
 			durationExpr, ok := timeExprCtx.NowExpr().(*grammar.NowExprContext)
@@ -159,6 +314,31 @@ func (q *queryStmtParse) visitTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 	}
 }
 
+// visitTimeBuckets visits the time buckets of time in expression, like time in ('20190410 00:00:00'..'20190410 01:00:00')
+func (q *queryStmtParse) visitTimeBuckets(ctx *grammar.TimeExprContext) {
+	for _, bucket := range ctx.AllTimeBucket() {
+		bucketCtx, ok := bucket.(*grammar.TimeBucketContext)
+		if !ok {
+			continue
+		}
+		start, err := timeutil.ParseTimestampInLocation(strutil.GetStringValue(bucketCtx.Ident(0).GetText()), q.location)
+		if err != nil {
+			q.err = err
+			return
+		}
+		end, err := timeutil.ParseTimestampInLocation(strutil.GetStringValue(bucketCtx.Ident(1).GetText()), q.location)
+		if err != nil {
+			q.err = err
+			return
+		}
+		if end < start {
+			q.err = fmt.Errorf("start time cannot be larger than end time")
+			return
+		}
+		q.timeBuckets = append(q.timeBuckets, timeutil.TimeRange{Start: start, End: end})
+	}
+}
+
 // parseDuration parses time duration from duration string
 func (q *queryStmtParse) parseDuration(ctx grammar.IDurationLitContext) int64 {
 	if ctx == nil {
@@ -217,11 +397,19 @@ func (q *queryStmtParse) visitFieldExpr(ctx *grammar.FieldExprContext) {
 		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.ADD})
 	case ctx.T_SUB() != nil:
 		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.SUB})
+	case ctx.DurationLit() != nil:
+		// duration param(like increase(f, 5m)) is converted to millis
+		q.setExprParam(&stmt.NumberLiteral{Val: float64(q.parseDuration(ctx.DurationLit()))})
 	}
 }
 
 // visitAlias visits when production alias expression is entered
 func (q *queryStmtParse) visitAlias(ctx *grammar.AliasContext) {
+	if _, ok := ctx.GetParent().(*grammar.FromClauseContext); ok {
+		// alias of metric name, lookup uses the real name, result reports the alias
+		q.metricAlias = strutil.GetStringValue(ctx.Ident().GetText())
+		return
+	}
 	if len(q.selectItems) == 0 {
 		return
 	}
@@ -255,15 +443,37 @@ func (q *queryStmtParse) visitFuncName(ctx *grammar.FuncNameContext) {
 		callExpr.FuncType = function.Stddev
 	case ctx.T_HISTOGRAM() != nil:
 		callExpr.FuncType = function.Histogram
+	case ctx.T_SAMPLE() != nil:
+		callExpr.FuncType = function.Sample
+	case ctx.T_MAX_TIME() != nil:
+		callExpr.FuncType = function.MaxTime
+	case ctx.T_MIN_TIME() != nil:
+		callExpr.FuncType = function.MinTime
+	case ctx.T_INCREASE() != nil:
+		callExpr.FuncType = function.Increase
+	case ctx.T_NONNEGATIVE_DERIVATIVE() != nil:
+		callExpr.FuncType = function.NonNegativeDerivative
+	case ctx.T_DISTINCT_COUNT() != nil:
+		callExpr.FuncType = function.DistinctCount
 	}
 }
 
 // completeFuncExpr completes a function call expression for select list
-func (q *queryStmtParse) completeFuncExpr() {
+func (q *queryStmtParse) completeFuncExpr(ctx *grammar.ExprFuncContext) {
 	cur := q.exprStack.Pop()
 	if cur != nil {
 		expr, ok := cur.(stmt.Expr)
+		if ok && ctx.L_ID() != nil {
+			if !strings.EqualFold(ctx.L_ID().GetText(), "all") {
+				q.visitCountDistinct(ctx, expr)
+				return
+			}
+			q.visitCountAll(ctx, expr)
+		}
 		if ok {
+			q.validateSampleExpr(expr)
+			q.validateIncreaseExpr(expr)
+			q.validateNonNegativeDerivativeExpr(expr)
 			q.setExprParam(expr)
 		}
 		if q.exprStack.Empty() {
@@ -272,10 +482,99 @@ func (q *queryStmtParse) completeFuncExpr() {
 	}
 }
 
+// visitCountDistinct visits count(distinct tag), which counts the distinct tag values of matched series
+func (q *queryStmtParse) visitCountDistinct(ctx *grammar.ExprFuncContext, expr stmt.Expr) {
+	// distinct is not a reserved word, so checks it here
+	if !strings.EqualFold(ctx.L_ID().GetText(), "distinct") {
+		q.err = fmt.Errorf("unknown function option: %s, expect distinct or all", ctx.L_ID().GetText())
+		return
+	}
+	callExpr, ok := expr.(*stmt.CallExpr)
+	if !ok || callExpr.FuncType != function.Count || !q.exprStack.Empty() {
+		q.err = fmt.Errorf("distinct only supports count(distinct tag) in select list")
+		return
+	}
+	q.countDistinct = strutil.GetStringValue(ctx.Ident().GetText())
+}
+
+// visitCountAll visits count(all field), which counts all data points of field includes the filled nulls
+func (q *queryStmtParse) visitCountAll(ctx *grammar.ExprFuncContext, expr stmt.Expr) {
+	callExpr, ok := expr.(*stmt.CallExpr)
+	if !ok || callExpr.FuncType != function.Count {
+		q.err = fmt.Errorf("all only supports count(all field)")
+		return
+	}
+	fieldName := strutil.GetStringValue(ctx.Ident().GetText())
+	callExpr.FuncType = function.CountAll
+	callExpr.Params = []stmt.Expr{&stmt.FieldExpr{Name: fieldName}}
+	q.fieldNames[fieldName] = struct{}{}
+}
+
+// validateSampleExpr validates sample(field, factor), factor must be a positive integer
+func (q *queryStmtParse) validateSampleExpr(expr stmt.Expr) {
+	callExpr, ok := expr.(*stmt.CallExpr)
+	if !ok || callExpr.FuncType != function.Sample {
+		return
+	}
+	if len(callExpr.Params) != 2 {
+		q.err = fmt.Errorf("sample function requires field and factor params")
+		return
+	}
+	factor, ok := callExpr.Params[1].(*stmt.NumberLiteral)
+	if !ok || factor.Val < 1 || factor.Val != math.Trunc(factor.Val) {
+		q.err = fmt.Errorf("sample factor must be a positive integer")
+	}
+}
+
+// validateIncreaseExpr validates increase(field, window), window must be a positive duration
+func (q *queryStmtParse) validateIncreaseExpr(expr stmt.Expr) {
+	callExpr, ok := expr.(*stmt.CallExpr)
+	if !ok || callExpr.FuncType != function.Increase {
+		return
+	}
+	if len(callExpr.Params) != 2 {
+		q.err = fmt.Errorf("increase function requires field and window params")
+		return
+	}
+	window, ok := callExpr.Params[1].(*stmt.NumberLiteral)
+	if !ok || window.Val <= 0 {
+		q.err = fmt.Errorf("increase window must be a positive duration, like 5m")
+	}
+}
+
+// validateNonNegativeDerivativeExpr validates nonnegative_derivative(field), only one field param
+func (q *queryStmtParse) validateNonNegativeDerivativeExpr(expr stmt.Expr) {
+	callExpr, ok := expr.(*stmt.CallExpr)
+	if !ok || callExpr.FuncType != function.NonNegativeDerivative {
+		return
+	}
+	if len(callExpr.Params) != 1 {
+		q.err = fmt.Errorf("nonnegative_derivative function requires only one field param")
+	}
+}
+
+// isTimeColumn checks if the atom is the time column of select list(select time, f from cpu),
+// only unquoted time as a select item is the time column, quoted one("time") is the field named time.
+func isTimeColumn(ctx *grammar.ExprAtomContext) bool {
+	if !strings.EqualFold(ctx.Ident().GetText(), stmt.TimeColumn) {
+		return false
+	}
+	fieldExpr, ok := ctx.GetParent().(*grammar.FieldExprContext)
+	if !ok {
+		return false
+	}
+	_, ok = fieldExpr.GetParent().(*grammar.FieldContext)
+	return ok
+}
+
 // visitExprAtom visits when production atom expr expression is entered
 func (q *queryStmtParse) visitExprAtom(ctx *grammar.ExprAtomContext) {
 	switch {
 	case ctx.Ident() != nil:
+		if isTimeColumn(ctx) {
+			q.selectTime = true
+			return
+		}
 		val := strutil.GetStringValue(ctx.Ident().GetText())
 		if q.exprStack.Empty() {
 			q.selectItems = append(q.selectItems, &stmt.SelectItem{Expr: &stmt.FieldExpr{Name: val}})
@@ -292,7 +591,11 @@ func (q *queryStmtParse) visitExprAtom(ctx *grammar.ExprAtomContext) {
 			valStr = ctx.IntNumber().GetText()
 		}
 
-		val, _ := strconv.ParseFloat(valStr, 64)
+		val, err := strconv.ParseFloat(valStr, 64)
+		if err != nil {
+			q.err = err
+			return
+		}
 		if !q.exprStack.Empty() {
 			q.setExprParam(&stmt.NumberLiteral{Val: val})
 		}
@@ -3,6 +3,7 @@ package sql
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -13,7 +14,7 @@ import (
 )
 
 func TestQueryStmt_validation(t *testing.T) {
-	queryStmt := newQueryStmtParse(false)
+	queryStmt := newQueryStmtParse(false, nil, ParserConfig{})
 	// case 1: stmt err
 	queryStmt.err = fmt.Errorf("err")
 	s, err := queryStmt.build()
@@ -62,12 +63,34 @@ func TestMetricName(t *testing.T) {
 	query := q.(*stmt.Query)
 	assert.Nil(t, err)
 	assert.Equal(t, "cpu", query.MetricName)
+	assert.Empty(t, query.MetricAlias)
 
 	sql = "select f "
 	_, err = Parse(sql)
 	assert.NotNil(t, err)
 }
 
+func TestMetricAlias(t *testing.T) {
+	q, err := Parse("select f as f1 from cpu_v2 as cpu where host='1.1.1.1'")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, "cpu_v2", query.MetricName)
+	assert.Equal(t, "cpu", query.MetricAlias)
+	assert.Equal(t, "cpu", query.ResultMetricName())
+	// alias of metric doesn't change the alias of select item
+	assert.Equal(t, "f1", query.SelectItems[0].(*stmt.SelectItem).Alias)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"}, query.Condition)
+
+	q, err = Parse("select f from 'cpu.v2' as 'cpu'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, "cpu.v2", query.MetricName)
+	assert.Equal(t, "cpu", query.MetricAlias)
+
+	_, err = Parse("select f from cpu_v2 as")
+	assert.Error(t, err)
+}
+
 func TestSingleSelectItem(t *testing.T) {
 	sql := "select f from memory"
 	q, err := Parse(sql)
@@ -117,6 +140,115 @@ func TestSelectFuncItem(t *testing.T) {
 	}, *selectItem)
 }
 
+func TestSampleFuncItem(t *testing.T) {
+	q, err := Parse("select sample(f, 10) from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"f"}, query.FieldNames)
+	selectItem := (query.SelectItems[0]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Sample,
+			Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}, &stmt.NumberLiteral{Val: 10}}},
+	}, *selectItem)
+
+	_, err = Parse("select sample(f) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select sample(f, 0) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select sample(f, 1.5) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select sample(f, g) from cpu")
+	assert.Error(t, err)
+	// sample is a non-reserved word
+	q, err = Parse("select sample from cpu where sample='1'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, []string{"sample"}, query.FieldNames)
+}
+
+func TestIncreaseFuncItem(t *testing.T) {
+	q, err := Parse("select increase(f, 5m) from cpu group by time(5m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"f"}, query.FieldNames)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Increase,
+			Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}, &stmt.NumberLiteral{Val: float64(5 * timeutil.OneMinute)}}},
+	}, *(query.SelectItems[0]).(*stmt.SelectItem))
+
+	_, err = Parse("select increase(f) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select increase(f, 0s) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select increase(f, g) from cpu")
+	assert.Error(t, err)
+	// increase is a non-reserved word
+	q, err = Parse("select increase from cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"increase"}, q.(*stmt.Query).FieldNames)
+}
+
+func TestNonNegativeDerivativeFuncItem(t *testing.T) {
+	q, err := Parse("select nonnegative_derivative(f) from cpu group by time(1m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"f"}, query.FieldNames)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.NonNegativeDerivative, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+	}, *(query.SelectItems[0]).(*stmt.SelectItem))
+
+	_, err = Parse("select nonnegative_derivative(f, 5m) from cpu")
+	assert.Error(t, err)
+	// nonnegative_derivative is a non-reserved word
+	q, err = Parse("select nonnegative_derivative from cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nonnegative_derivative"}, q.(*stmt.Query).FieldNames)
+}
+
+func TestDistinctCountFuncItem(t *testing.T) {
+	q, err := Parse("select distinct_count(userid) from events group by time(1m)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"userid"}, query.FieldNames)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.DistinctCount, Params: []stmt.Expr{&stmt.FieldExpr{Name: "userid"}}},
+	}, *(query.SelectItems[0]).(*stmt.SelectItem))
+	assert.Equal(t, "distinct_count(userid)", query.SelectItems[0].Rewrite())
+
+	q, err = Parse("select DISTINCT_COUNT(userid) as uv from events")
+	assert.NoError(t, err)
+	assert.Equal(t, "uv", q.(*stmt.Query).SelectItems[0].(*stmt.SelectItem).Alias)
+	// distinct_count is a non-reserved word
+	q, err = Parse("select distinct_count from events")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"distinct_count"}, q.(*stmt.Query).FieldNames)
+	// count is still a function
+	q, err = Parse("select count(userid) from events")
+	assert.NoError(t, err)
+	assert.Equal(t, function.Count, q.(*stmt.Query).SelectItems[0].(*stmt.SelectItem).Expr.(*stmt.CallExpr).FuncType)
+}
+
+func TestExtremumTimeFuncItem(t *testing.T) {
+	q, err := Parse("select max_time(f), min_time(f) from cpu group by time(1h)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"f"}, query.FieldNames)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.MaxTime, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+	}, *(query.SelectItems[0]).(*stmt.SelectItem))
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.MinTime, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+	}, *(query.SelectItems[1]).(*stmt.SelectItem))
+	// max_time is a non-reserved word
+	q, err = Parse("select max_time from cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"max_time"}, q.(*stmt.Query).FieldNames)
+	// max(f) still works
+	q, err = Parse("select max(f) from cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, function.Max, (q.(*stmt.Query).SelectItems[0]).(*stmt.SelectItem).Expr.(*stmt.CallExpr).FuncType)
+}
+
 func TestFieldExpression(t *testing.T) {
 	q, err := Parse("select f+100 from cpu")
 	query := q.(*stmt.Query)
@@ -309,6 +441,110 @@ func TestTimeRange(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTimeRange_subSecond(t *testing.T) {
+	q, err := ParseWithLocation("select f from cpu where time>='20190410 00:00:00.123' and time<='20190410 00:00:01.5'", time.UTC)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	base := time.Date(2019, 4, 10, 0, 0, 0, 0, time.UTC).UnixNano() / 1000000
+	assert.Equal(t, timeutil.TimeRange{Start: base + 123, End: base + 1500}, query.TimeRange)
+
+	q, err = ParseWithLocation("select f from cpu where time in ('2019-04-10 00:00:00.001'..'2019-04-10 00:00:00.999')", time.UTC)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, []timeutil.TimeRange{{Start: base + 1, End: base + 999}}, query.TimeRanges)
+}
+
+func TestTimeRange_RFC3339(t *testing.T) {
+	q, err := Parse("select f from cpu where time>'2019-04-10T00:00:00+08:00' and time<'2019-04-10T10:00:00Z'")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, time.Date(2019, 4, 9, 16, 0, 0, 0, time.UTC).UnixNano()/1000000, query.TimeRange.Start)
+	assert.Equal(t, time.Date(2019, 4, 10, 10, 0, 0, 0, time.UTC).UnixNano()/1000000, query.TimeRange.End)
+
+	// both formats coexist
+	q, err = Parse("select f from cpu where time>'20190410 00:00:00' and time<'2019-04-10T10:00:00-02:00'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	startTime, _ := timeutil.ParseTimestamp("20190410 00:00:00")
+	assert.Equal(t, startTime, query.TimeRange.Start)
+	assert.Equal(t, time.Date(2019, 4, 10, 12, 0, 0, 0, time.UTC).UnixNano()/1000000, query.TimeRange.End)
+}
+
+func TestTimeRangeWithLocation(t *testing.T) {
+	sql := "select f from cpu where time>'20190410 00:00:00' and time<'20190410 10:00:00'"
+	newYork := time.FixedZone("EDT", -4*3600)
+	shanghai := time.FixedZone("CST", 8*3600)
+	q1, err := ParseWithLocation(sql, newYork)
+	assert.NoError(t, err)
+	q2, err := ParseWithLocation(sql, shanghai)
+	assert.NoError(t, err)
+	range1 := q1.(*stmt.Query).TimeRange
+	range2 := q2.(*stmt.Query).TimeRange
+	assert.Equal(t, time.Date(2019, 4, 10, 0, 0, 0, 0, newYork).UnixNano()/1000000, range1.Start)
+	assert.Equal(t, time.Date(2019, 4, 10, 10, 0, 0, 0, newYork).UnixNano()/1000000, range1.End)
+	assert.Equal(t, time.Date(2019, 4, 10, 0, 0, 0, 0, shanghai).UnixNano()/1000000, range2.Start)
+	// same literal in different zones, time range differs by zone offset
+	assert.Equal(t, 12*timeutil.OneHour, range1.Start-range2.Start)
+	assert.Equal(t, 12*timeutil.OneHour, range1.End-range2.End)
+
+	// time buckets
+	sql = "select f from cpu where time in ('20190410 00:00:00'..'20190410 01:00:00')"
+	q1, err = ParseWithLocation(sql, newYork)
+	assert.NoError(t, err)
+	q2, err = ParseWithLocation(sql, shanghai)
+	assert.NoError(t, err)
+	assert.Equal(t, 12*timeutil.OneHour, q1.(*stmt.Query).TimeRanges[0].Start-q2.(*stmt.Query).TimeRanges[0].Start)
+
+	// nil location means local zone
+	q1, err = ParseWithLocation(sql, nil)
+	assert.NoError(t, err)
+	q2, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, q2, q1)
+}
+
+func TestTimeBuckets(t *testing.T) {
+	parse := func(timestamp string) int64 {
+		result, _ := timeutil.ParseTimestamp(timestamp)
+		return result
+	}
+	sql := "select f from cpu where host='a' and time in " +
+		"('20190410 00:00:00'..'20190410 01:00:00', '20190411 00:00:00'..'20190411 01:00:00')"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "a"}, query.Condition)
+	assert.Equal(t, []timeutil.TimeRange{
+		{Start: parse("20190410 00:00:00"), End: parse("20190410 01:00:00")},
+		{Start: parse("20190411 00:00:00"), End: parse("20190411 01:00:00")},
+	}, query.TimeRanges)
+	assert.Equal(t, timeutil.TimeRange{Start: parse("20190410 00:00:00"), End: parse("20190411 01:00:00")}, query.TimeRange)
+
+	// overlapping buckets are merged
+	sql = "select f from cpu where time in " +
+		"('20190411 00:30:00'..'20190411 02:00:00', '20190410 00:00:00'..'20190410 01:00:00', " +
+		"'20190411 00:00:00'..'20190411 01:00:00')"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, []timeutil.TimeRange{
+		{Start: parse("20190410 00:00:00"), End: parse("20190410 01:00:00")},
+		{Start: parse("20190411 00:00:00"), End: parse("20190411 02:00:00")},
+	}, query.TimeRanges)
+
+	// error for start > end
+	_, err = Parse("select f from cpu where time in ('20190410 01:00:00'..'20190410 00:00:00')")
+	assert.Error(t, err)
+	// error for bad timestamp
+	_, err = Parse("select f from cpu where time in ('20190410 01:00:00'..'abc')")
+	assert.Error(t, err)
+	_, err = Parse("select f from cpu where time in ('abc'..'20190410 01:00:00')")
+	assert.Error(t, err)
+	// error for using with time comparison
+	_, err = Parse("select f from cpu where time in ('20190410 00:00:00'..'20190410 01:00:00') and time>now()-1h")
+	assert.Error(t, err)
+}
+
 func TestInterval(t *testing.T) {
 	sql := "select f from cpu where region='sh'"
 	q, err := Parse(sql)
@@ -360,6 +596,177 @@ func TestGroupBy(t *testing.T) {
 	assert.Equal(t, 2, len(query.GroupBy))
 	assert.Equal(t, "host", query.GroupBy[0])
 	assert.Equal(t, "/data", query.GroupBy[1])
+	assert.False(t, query.GroupByAll)
+
+	// group by all tag keys
+	q, err = Parse("select f from disk group by *,time(1m)")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.True(t, query.GroupByAll)
+	assert.Empty(t, query.GroupBy)
+	assert.True(t, query.HasGroupBy())
+	assert.Equal(t, timeutil.Interval(timeutil.OneMinute), query.Interval)
+	q, err = Parse("select f from disk group by host,*")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.True(t, query.GroupByAll)
+	assert.Equal(t, []string{"host"}, query.GroupBy)
+}
+
+func TestExplainAnalyze(t *testing.T) {
+	q, err := Parse("explain select f from cpu")
+	assert.NoError(t, err)
+	assert.True(t, q.(*stmt.Query).Explain)
+	assert.False(t, q.(*stmt.Query).Analyze)
+	q, err = Parse("explain analyze select f from cpu")
+	assert.NoError(t, err)
+	assert.True(t, q.(*stmt.Query).Explain)
+	assert.True(t, q.(*stmt.Query).Analyze)
+	_, err = Parse("explain verbose select f from cpu")
+	assert.Error(t, err)
+}
+
+func TestGroupBy_Over(t *testing.T) {
+	// only aggregates the points in trailing window
+	q, err := Parse("select max(f) from up where time>'20190410 00:00:00' and time<'20190410 10:00:00' group by host over 5m")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	end, _ := timeutil.ParseTimestamp("20190410 10:00:00")
+	assert.Equal(t, []string{"host"}, query.GroupBy)
+	assert.Equal(t, timeutil.Interval(5*timeutil.OneMinute), query.Window)
+	assert.Equal(t, timeutil.TimeRange{Start: end - 5*timeutil.OneMinute, End: end}, query.TimeRange)
+	// window larger than time range
+	q, err = Parse("select max(f) from up where time>'20190410 09:58:00' and time<'20190410 10:00:00' group by host OVER 5m")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, timeutil.TimeRange{Start: end - 2*timeutil.OneMinute, End: end}, query.TimeRange)
+	// with group by time
+	q, err = Parse("select max(f) from up group by host,time(1m) over 10m fill(0)")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, 10*timeutil.OneMinute, query.TimeRange.End-query.TimeRange.Start)
+	assert.Equal(t, timeutil.Interval(timeutil.OneMinute), query.Interval)
+	// over is not reserved word
+	q, err = Parse("select max(f) from up group by over")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"over"}, q.(*stmt.Query).GroupBy)
+
+	_, err = Parse("select max(f) from up group by host last 5m")
+	assert.Error(t, err)
+	_, err = Parse("select max(f) from up group by host over 0m")
+	assert.Error(t, err)
+	_, err = Parse("select max(f) from up where time in ('20190410 00:00:00'..'20190410 01:00:00') group by host over 5m")
+	assert.Error(t, err)
+}
+
+func TestGroupBy_Step(t *testing.T) {
+	q, err := Parse("select max(f) from up group by host,time(5m) step 1m fill(previous)")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, timeutil.Interval(5*timeutil.OneMinute), query.Interval)
+	assert.Equal(t, timeutil.Interval(timeutil.OneMinute), query.OutputInterval)
+	assert.Equal(t, stmt.Fill{Type: stmt.FillPrevious}, query.Fill)
+	assert.True(t, query.NeedResample())
+
+	q, err = Parse("select max(f) from up group by time(1m) STEP 5m fill(1.5)")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, timeutil.Interval(5*timeutil.OneMinute), query.OutputInterval)
+	assert.Equal(t, stmt.Fill{Type: stmt.FillValue, Value: 1.5}, query.Fill)
+	// without step
+	q, err = Parse("select max(f) from up group by time(1m) fill(null)")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, timeutil.Interval(0), query.OutputInterval)
+	assert.Equal(t, stmt.Fill{Type: stmt.FillNull}, query.Fill)
+	assert.False(t, query.NeedResample())
+
+	_, err = Parse("select max(f) from up group by host step 0m")
+	assert.Error(t, err)
+}
+
+func TestRawQuery(t *testing.T) {
+	cases := map[string]bool{
+		"select f from cpu":                           true,
+		"select f,g as h from cpu where host='a'":     true,
+		"select f from cpu group by host":             true,
+		"select f from cpu group by time(1m)":         false,
+		"select f from cpu group by host over 5m":     false,
+		"select f from cpu group by host step 5m":     false,
+		"select sum(f) from cpu":                      false,
+		"select f, max(g) from cpu":                   false,
+		"select f+1 from cpu":                         false,
+		"select count(distinct host) from cpu":        false,
+		"select time, f from cpu where time>now()-1h": true,
+	}
+	for sql, raw := range cases {
+		q, err := Parse(sql)
+		assert.NoError(t, err, sql)
+		assert.Equal(t, raw, q.(*stmt.Query).Raw, sql)
+	}
+}
+
+func TestCountDistinct(t *testing.T) {
+	q, err := Parse("select count(distinct host) from cpu where region='sh'")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, "host", query.CountDistinct)
+	assert.Empty(t, query.SelectItems)
+	assert.Empty(t, query.FieldNames)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "region", Value: "sh"}, query.Condition)
+	q, err = Parse("select COUNT(DISTINCT host) from cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, "host", q.(*stmt.Query).CountDistinct)
+	// distinct is not reserved word
+	q, err = Parse("select count(distinct) from cpu")
+	assert.NoError(t, err)
+	assert.Empty(t, q.(*stmt.Query).CountDistinct)
+	assert.Equal(t, []string{"distinct"}, q.(*stmt.Query).FieldNames)
+	q, err = Parse("select count(distinct f) from distinct")
+	assert.NoError(t, err)
+	assert.Equal(t, "distinct", q.(*stmt.Query).MetricName)
+	q, err = Parse("select count(f) from cpu")
+	assert.NoError(t, err)
+	assert.Empty(t, q.(*stmt.Query).CountDistinct)
+	q, err = Parse("select count(f -1) from cpu")
+	assert.NoError(t, err)
+	assert.Empty(t, q.(*stmt.Query).CountDistinct)
+
+	_, err = Parse("select count(unique host) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select sum(distinct host) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select count(distinct host, zone) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select count(distinct 1) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select count(distinct host+1) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select max(count(distinct host)) from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select count(distinct host),f from cpu")
+	assert.Error(t, err)
+	_, err = Parse("select count(distinct host) from cpu group by zone")
+	assert.Error(t, err)
+}
+
+func TestCountAll(t *testing.T) {
+	q, err := Parse("select count(all f),count(f) from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []stmt.Expr{
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.CountAll, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Count, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+	}, query.SelectItems)
+	assert.Equal(t, []string{"f"}, query.FieldNames)
+	assert.Empty(t, query.CountDistinct)
+	// all is not reserved word
+	q, err = Parse("select count(all) from cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"all"}, q.(*stmt.Query).FieldNames)
+
+	_, err = Parse("select sum(all f) from cpu")
+	assert.Error(t, err)
 }
 
 func TestEmptyCondition(t *testing.T) {
@@ -370,6 +777,49 @@ func TestEmptyCondition(t *testing.T) {
 	assert.Nil(t, query.Condition)
 }
 
+func TestSimplifyCondition(t *testing.T) {
+	sql := "select f from cpu where 1=1 and host='web-01'"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "web-01"}, query.Condition)
+
+	sql = "select f from cpu where host='a' or host='a'"
+	q, _ = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "a"}, query.Condition)
+
+	sql = "select f from cpu where 1=1 or host='a'"
+	q, _ = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Nil(t, query.Condition)
+
+	sql = "select f from cpu where 1=0 and host='a'"
+	q, _ = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.BoolLiteral{Val: false}, query.Condition)
+
+	sql = "select f from cpu where 1.0=1 and host!=1"
+	q, _ = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: "host", Value: "1"}}, query.Condition)
+
+	// quoted or non-number literal isn't folded
+	for sql, expect := range map[string]stmt.Expr{
+		"select f from cpu where '200'='200'":   &stmt.EqualsExpr{Key: "200", Value: "200"},
+		"select f from cpu where inf=inf":       &stmt.EqualsExpr{Key: "inf", Value: "inf"},
+		"select f from cpu where nan='nan'":     &stmt.EqualsExpr{Key: "nan", Value: "nan"},
+		"select f from cpu where infinity=1":    &stmt.EqualsExpr{Key: "infinity", Value: "1"},
+		"select f from cpu where '1e3'=1000":    &stmt.EqualsExpr{Key: "1e3", Value: "1000"},
+		"select f from cpu where '0x1p-2'=0.25": &stmt.EqualsExpr{Key: "0x1p-2", Value: "0.25"},
+		"select f from cpu where 1='a'":         &stmt.EqualsExpr{Key: "1", Value: "a"},
+	} {
+		q, err = Parse(sql)
+		assert.NoError(t, err, sql)
+		assert.Equal(t, expect, q.(*stmt.Query).Condition, sql)
+	}
+}
+
 func TestEqualsExpr(t *testing.T) {
 	// equals
 	sql := "select f from cpu where ip='1.1.1.1'"
@@ -409,6 +859,40 @@ func TestLikeExpr(t *testing.T) {
 	assert.Equal(t, stmt.NotExpr{Expr: &stmt.LikeExpr{Key: "ip", Value: "1.1.%.1"}}, *notExpr)
 }
 
+func TestCompareExpr(t *testing.T) {
+	cases := map[string]stmt.Expr{
+		"select f from cpu where host>'b'":  &stmt.GreaterExpr{Key: "host", Value: "b"},
+		"select f from cpu where host>='b'": &stmt.GreaterEqualExpr{Key: "host", Value: "b"},
+		"select f from cpu where host<'b'":  &stmt.LessExpr{Key: "host", Value: "b"},
+		"select f from cpu where host<='b'": &stmt.LessEqualExpr{Key: "host", Value: "b"},
+	}
+	for sql, expr := range cases {
+		q, err := Parse(sql)
+		assert.NoError(t, err)
+		query := q.(*stmt.Query)
+		assert.Equal(t, expr, query.Condition)
+	}
+
+	sql := "select f from cpu where host>='b' and host<'d'"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left:     &stmt.GreaterEqualExpr{Key: "host", Value: "b"},
+		Operator: stmt.AND,
+		Right:    &stmt.LessExpr{Key: "host", Value: "d"},
+	}, query.Condition)
+
+	// time keyword is still parsed as time range
+	sql = "select f from cpu where host>'b' and time>'20190410 00:00:00' and time<'20190410 10:00:00'"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.GreaterExpr{Key: "host", Value: "b"}, query.Condition)
+	startTime, _ := timeutil.ParseTimestamp("20190410 00:00:00")
+	assert.Equal(t, startTime, query.TimeRange.Start)
+}
+
 func TestRegexExpr(t *testing.T) {
 	sql := "select f from cpu where ip=~'/1.1.*.1/'"
 	q, _ := Parse(sql)
@@ -445,6 +929,125 @@ func TestInExpr(t *testing.T) {
 	assert.Equal(t, stmt.NotExpr{Expr: &stmt.InExpr{Key: "ip", Values: []string{"1.1.1.1", "2.2.2.2"}}}, *notExpr)
 }
 
+func TestAllOfExpr(t *testing.T) {
+	q, err := Parse("select f from cpu where labels contains_all ('gpu','nvme')")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.AllOfExpr{Key: "labels", Values: []string{"gpu", "nvme"}}, query.Condition)
+
+	q, err = Parse("select f from cpu where host='a' and (labels contains_all (gpu) or labels in ('nvme'))")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left:     &stmt.EqualsExpr{Key: "host", Value: "a"},
+		Operator: stmt.AND,
+		Right: &stmt.ParenExpr{Expr: &stmt.BinaryExpr{
+			Left:     &stmt.AllOfExpr{Key: "labels", Values: []string{"gpu"}},
+			Operator: stmt.OR,
+			Right:    &stmt.InExpr{Key: "labels", Values: []string{"nvme"}},
+		}},
+	}, query.Condition)
+
+	// contains_all is not a reserved word
+	q, err = Parse("select contains_all from cpu where contains_all='a'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "contains_all", Value: "a"}, query.Condition)
+
+	// values cannot be empty
+	_, err = Parse("select f from cpu where labels contains_all ()")
+	assert.Error(t, err)
+	_, err = Parse("select f from cpu where labels contains_all 'gpu'")
+	assert.Error(t, err)
+}
+
+func TestInExpr_empty(t *testing.T) {
+	// empty in list is rejected at parse time
+	_, err := Parse("select f from cpu where ip in ()")
+	assert.Error(t, err)
+	_, err = Parse("select f from cpu where ip not in ()")
+	assert.Error(t, err)
+}
+
+func TestBooleanTagValue(t *testing.T) {
+	cases := map[string]stmt.Expr{
+		"select f from cpu where active=true":    &stmt.EqualsExpr{Key: "active", Value: "true"},
+		"select f from cpu where active='true'":  &stmt.EqualsExpr{Key: "active", Value: "true"},
+		"select f from cpu where active=TRUE":    &stmt.EqualsExpr{Key: "active", Value: "true"},
+		"select f from cpu where active=false":   &stmt.EqualsExpr{Key: "active", Value: "false"},
+		"select f from cpu where active='false'": &stmt.EqualsExpr{Key: "active", Value: "false"},
+		"select f from cpu where active!=False": &stmt.NotExpr{
+			Expr: &stmt.EqualsExpr{Key: "active", Value: "false"}},
+		"select f from cpu where active in (true,'false')": &stmt.InExpr{
+			Key: "active", Values: []string{"true", "false"}},
+		// quoted string keeps as is
+		"select f from cpu where active='TRUE'": &stmt.EqualsExpr{Key: "active", Value: "TRUE"},
+	}
+	for sql, expr := range cases {
+		q, err := Parse(sql)
+		assert.NoError(t, err, sql)
+		query := q.(*stmt.Query)
+		assert.Equal(t, expr, query.Condition, sql)
+	}
+}
+
+func TestNullTagValue(t *testing.T) {
+	cases := map[string]stmt.Expr{
+		"select f from cpu where host != null": &stmt.ExistsExpr{Key: "host"},
+		"select f from cpu where host <> NULL": &stmt.ExistsExpr{Key: "host"},
+		"select f from cpu where host = null": &stmt.NotExpr{
+			Expr: &stmt.ExistsExpr{Key: "host"}},
+		// double negation is simplified
+		"select f from cpu where not (host = null)": &stmt.ExistsExpr{Key: "host"},
+		"select f from cpu where host != null and ip = '1.1.1.1'": &stmt.BinaryExpr{
+			Left:     &stmt.ExistsExpr{Key: "host"},
+			Operator: stmt.AND,
+			Right:    &stmt.EqualsExpr{Key: "ip", Value: "1.1.1.1"},
+		},
+		// quoted string keeps as is
+		"select f from cpu where host = 'null'": &stmt.EqualsExpr{Key: "host", Value: "null"},
+		// only equals/not equals support null literal
+		"select f from cpu where host in (a, null)": &stmt.InExpr{Key: "host", Values: []string{"a", "null"}},
+	}
+	for sql, expr := range cases {
+		q, err := Parse(sql)
+		assert.NoError(t, err, sql)
+		query := q.(*stmt.Query)
+		assert.Equal(t, expr, query.Condition, sql)
+	}
+}
+
+func TestEscapedQuoteInString(t *testing.T) {
+	// single quote is escaped by doubling it, backslash is kept as is
+	cases := map[string]stmt.Expr{
+		"select f from cpu where host='it''s-web'": &stmt.EqualsExpr{Key: "host", Value: "it's-web"},
+		"select f from cpu where host=''''":        &stmt.EqualsExpr{Key: "host", Value: "'"},
+		`select f from cpu where host='a\b'`:       &stmt.EqualsExpr{Key: "host", Value: `a\b`},
+		"select f from cpu where host in ('it''s-web','b''''c', 'd')": &stmt.InExpr{
+			Key: "host", Values: []string{"it's-web", "b''c", "d"}},
+		"select f from cpu where host like 'it''s-%'": &stmt.LikeExpr{Key: "host", Value: "it's-%"},
+		`select f from cpu where host like 'it\%'`:    &stmt.LikeExpr{Key: "host", Value: `it\%`},
+	}
+	for sql, expr := range cases {
+		q, err := Parse(sql)
+		assert.NoError(t, err, sql)
+		query := q.(*stmt.Query)
+		assert.Equal(t, expr, query.Condition, sql)
+	}
+
+	q, err := Parse("select f from cpu where host='it''s-web' and path='/data'")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left:     &stmt.EqualsExpr{Key: "host", Value: "it's-web"},
+		Operator: stmt.AND,
+		Right:    &stmt.EqualsExpr{Key: "path", Value: "/data"},
+	}, query.Condition)
+
+	_, err = Parse("select f from cpu where host='it's-web'")
+	assert.Error(t, err)
+}
+
 func TestTagFilterBinary(t *testing.T) {
 	sql := "select f from cpu where ip in ('1.1.1.1','2.2.2.2') and path='/data'"
 	q, _ := Parse(sql)
@@ -499,10 +1102,231 @@ func TestTagFilterBinary(t *testing.T) {
 				Right:    &stmt.EqualsExpr{Key: "region", Value: "sh"},
 			}},
 			Operator: stmt.AND,
+			// or expression of equality on the same tag key is collapsed into in expr
+			Right: &stmt.ParenExpr{Expr: &stmt.InExpr{Key: "path", Values: []string{"/data", "/home"}}},
+		}, *expr)
+}
+
+func TestTagFilterNotParen(t *testing.T) {
+	sql := "select f from cpu where not (region='sh' or region='bj')"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t,
+		&stmt.NotExpr{Expr: &stmt.InExpr{Key: "region", Values: []string{"sh", "bj"}}},
+		query.Condition)
+
+	sql = "select f from cpu where not (region='sh' or ip='1.1.1.1')"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t,
+		&stmt.ParenExpr{Expr: &stmt.BinaryExpr{
+			Left:     &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: "region", Value: "sh"}},
+			Operator: stmt.AND,
+			Right:    &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: "ip", Value: "1.1.1.1"}},
+		}}, query.Condition)
+
+	sql = "select f from cpu where path='/data' and not (ip in ('1.1.1.1','2.2.2.2') and region!='sh')"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t,
+		&stmt.BinaryExpr{
+			Left:     &stmt.EqualsExpr{Key: "path", Value: "/data"},
+			Operator: stmt.AND,
 			Right: &stmt.ParenExpr{Expr: &stmt.BinaryExpr{
-				Left:     &stmt.EqualsExpr{Key: "path", Value: "/data"},
+				Left:     &stmt.NotExpr{Expr: &stmt.InExpr{Key: "ip", Values: []string{"1.1.1.1", "2.2.2.2"}}},
 				Operator: stmt.OR,
-				Right:    &stmt.EqualsExpr{Key: "path", Value: "/home"},
+				Right:    &stmt.EqualsExpr{Key: "region", Value: "sh"},
 			}},
-		}, *expr)
+		}, query.Condition)
+
+	sql = "select f from cpu where not (not (host='a'))"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "a"}, query.Condition)
+}
+
+func TestQueryStmt_max_expr_depth(t *testing.T) {
+	assert.Equal(t, DefaultMaxExprDepth, ParserConfig{}.maxExprDepth())
+
+	// nestedCondition builds n levels nested and/or condition, like ((host='0' and ip='0') or ip='1')
+	nestedCondition := func(n int) string {
+		condition := "host='0'"
+		for i := 0; i < n; i++ {
+			op := "and"
+			if i%2 == 1 {
+				op = "or"
+			}
+			condition = fmt.Sprintf("(%s %s ip='%d')", condition, op, i)
+		}
+		return condition
+	}
+	q, err := Parse("select f from cpu where " + nestedCondition(20))
+	assert.NoError(t, err)
+	assert.NotNil(t, q)
+
+	q, err = Parse("select f from cpu where " + nestedCondition(100))
+	assert.Error(t, err)
+	assert.Nil(t, q)
+	q, err = Parse("show tag values from cpu with key = host where " + nestedCondition(100))
+	assert.Error(t, err)
+	assert.Nil(t, q)
+
+	// increase the max depth
+	cfg := ParserConfig{MaxExprDepth: 1000}
+	q, err = ParseWithConfig("select f from cpu where "+nestedCondition(100), nil, cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, q)
+	q, err = ParseWithConfig("show tag values from cpu with key = host where "+nestedCondition(100), nil, cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, q)
+	// decrease the max depth
+	q, err = ParseWithConfig("select f from cpu where "+nestedCondition(20), nil, ParserConfig{MaxExprDepth: 10})
+	assert.Error(t, err)
+	assert.Nil(t, q)
+}
+
+func TestQueryStmt_numeric_literal(t *testing.T) {
+	cases := map[string]float64{
+		"1.5e3":  1500,
+		"2E-2":   0.02,
+		"1e+3":   1000,
+		".5e1":   5,
+		"-42":    -42,
+		"-1.5e3": -1500,
+		"+2e2":   200,
+	}
+	for literal, expect := range cases {
+		q, err := Parse("select f*" + literal + " from cpu")
+		assert.NoError(t, err, literal)
+		query := q.(*stmt.Query)
+		assert.Equal(t, &stmt.BinaryExpr{
+			Left:     &stmt.FieldExpr{Name: "f"},
+			Operator: stmt.MUL,
+			Right:    &stmt.NumberLiteral{Val: expect},
+		}, query.SelectItems[0].(*stmt.SelectItem).Expr, literal)
+	}
+	// negative literal in arithmetic expression
+	q, err := Parse("select f+-42 from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left:     &stmt.FieldExpr{Name: "f"},
+		Operator: stmt.ADD,
+		Right:    &stmt.NumberLiteral{Val: -42},
+	}, query.SelectItems[0].(*stmt.SelectItem).Expr)
+
+	// numeric literal as tag value
+	q, err = Parse("select f from cpu where delta=-42")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "delta", Value: "-42"}, query.Condition)
+	q, err = Parse("select f from cpu where threshold in (1.5e3, 2E-2, -42, 10, 'a')")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.InExpr{Key: "threshold", Values: []string{"1.5e3", "2E-2", "-42", "10", "a"}}, query.Condition)
+
+	// invalid literal
+	for _, sql := range []string{
+		"select f*1.2.3 from cpu",
+		"select f from cpu where delta=1.2.3",
+		"select f*1e from cpu",
+		"select f*1.e from cpu",
+	} {
+		q, err = Parse(sql)
+		assert.Error(t, err, sql)
+		assert.Nil(t, q, sql)
+	}
+}
+
+func TestQueryStmt_default_time_range(t *testing.T) {
+	// no time clause, looks back the global default
+	q, err := Parse("select f from cpu where host='1'")
+	assert.NoError(t, err)
+	timeRange := q.(*stmt.Query).TimeRange
+	assert.Equal(t, timeutil.OneHour, timeRange.End-timeRange.Start)
+
+	cfg := ParserConfig{
+		DefaultTimeRange:      2 * time.Hour,
+		MeasurementTimeRanges: map[string]time.Duration{"disk": 24 * time.Hour, "mem": 0},
+	}
+	q, err = ParseWithConfig("select f from cpu", nil, cfg)
+	assert.NoError(t, err)
+	timeRange = q.(*stmt.Query).TimeRange
+	assert.Equal(t, 2*timeutil.OneHour, timeRange.End-timeRange.Start)
+	// overrides per measurement
+	q, err = ParseWithConfig("select f from disk", nil, cfg)
+	assert.NoError(t, err)
+	timeRange = q.(*stmt.Query).TimeRange
+	assert.Equal(t, 24*timeutil.OneHour, timeRange.End-timeRange.Start)
+	// ignores invalid override
+	q, err = ParseWithConfig("select f from mem", nil, cfg)
+	assert.NoError(t, err)
+	timeRange = q.(*stmt.Query).TimeRange
+	assert.Equal(t, 2*timeutil.OneHour, timeRange.End-timeRange.Start)
+	// looks back from the given end time
+	q, err = ParseWithConfig("select f from disk where time<'20190410 10:00:00'", nil, cfg)
+	assert.NoError(t, err)
+	timeRange = q.(*stmt.Query).TimeRange
+	assert.Equal(t, 24*timeutil.OneHour, timeRange.End-timeRange.Start)
+	// start time given, no default
+	q, err = ParseWithConfig("select f from disk where time>now()-30m", nil, cfg)
+	assert.NoError(t, err)
+	timeRange = q.(*stmt.Query).TimeRange
+	assert.True(t, timeRange.End-timeRange.Start < timeutil.OneHour)
+
+	// invalid global window, uses default
+	q, err = ParseWithConfig("select f from disk", nil, ParserConfig{DefaultTimeRange: -time.Hour})
+	assert.NoError(t, err)
+	timeRange = q.(*stmt.Query).TimeRange
+	assert.Equal(t, timeutil.OneHour, timeRange.End-timeRange.Start)
+}
+
+func TestQueryStmt_duplicate_tag_key(t *testing.T) {
+	q, err := Parse("explain select f from cpu where host='a' and host='b'")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, &stmt.BoolLiteral{Val: false}, query.Condition)
+	assert.Len(t, query.Annotations, 1)
+
+	q, err = Parse("select f from cpu where host='a' or host='b'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, &stmt.InExpr{Key: "host", Values: []string{"a", "b"}}, query.Condition)
+	assert.Len(t, query.Annotations, 1)
+
+	q, err = Parse("select f from cpu where host='a' and ip='b'")
+	assert.NoError(t, err)
+	assert.Empty(t, q.(*stmt.Query).Annotations)
+}
+
+func TestQueryStmt_select_time(t *testing.T) {
+	q, err := Parse("select time, f from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.True(t, query.SelectTime)
+	assert.Equal(t, []stmt.Expr{&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "f"}}}, query.SelectItems)
+	assert.Equal(t, []string{"f"}, query.FieldNames)
+	// quoted time is the field named time
+	q, err = Parse(`select "time" from cpu`)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.False(t, query.SelectTime)
+	assert.Equal(t, []string{"time"}, query.FieldNames)
+	// time in expression is still field
+	q, err = Parse("select sum(time) from cpu")
+	assert.NoError(t, err)
+	assert.False(t, q.(*stmt.Query).SelectTime)
+	// field named time collides with time column
+	_, err = Parse(`select time, "time" from cpu`)
+	assert.Error(t, err)
+	q, err = Parse(`select time, "time" as t from cpu`)
+	assert.NoError(t, err)
+	assert.True(t, q.(*stmt.Query).SelectTime)
+	// time column only
+	_, err = Parse("select time from cpu")
+	assert.Error(t, err)
 }
@@ -0,0 +1,41 @@
+package stmt
+
+// Depth returns the max depth of the expression tree, leaf expression's depth is 1,
+// walks the tree by an explicit stack instead of recursion, so that it is safe for deeply nested expression.
+func Depth(expr Expr) int {
+	if expr == nil {
+		return 0
+	}
+	type node struct {
+		expr  Expr
+		depth int
+	}
+	maxDepth := 0
+	stack := []node{{expr: expr, depth: 1}}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n.depth > maxDepth {
+			maxDepth = n.depth
+		}
+		var children []Expr
+		switch e := n.expr.(type) {
+		case *SelectItem:
+			children = []Expr{e.Expr}
+		case *ParenExpr:
+			children = []Expr{e.Expr}
+		case *NotExpr:
+			children = []Expr{e.Expr}
+		case *BinaryExpr:
+			children = []Expr{e.Left, e.Right}
+		case *CallExpr:
+			children = e.Params
+		}
+		for _, child := range children {
+			if child != nil {
+				stack = append(stack, node{expr: child, depth: n.depth + 1})
+			}
+		}
+	}
+	return maxDepth
+}
@@ -0,0 +1,25 @@
+package stmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDepth(t *testing.T) {
+	assert.Equal(t, 0, Depth(nil))
+	assert.Equal(t, 1, Depth(&EqualsExpr{Key: "host", Value: "a"}))
+	assert.Equal(t, 4, Depth(&BinaryExpr{
+		Left:     &EqualsExpr{Key: "host", Value: "a"},
+		Right:    &ParenExpr{Expr: &NotExpr{Expr: &InExpr{Key: "ip", Values: []string{"1.1.1.1"}}}},
+		Operator: OR,
+	}))
+	assert.Equal(t, 3, Depth(&SelectItem{Expr: &CallExpr{Params: []Expr{&FieldExpr{Name: "f"}}}}))
+
+	// deeply nested expression
+	var expr Expr = &EqualsExpr{Key: "host", Value: "a"}
+	for i := 0; i < 10000; i++ {
+		expr = &ParenExpr{Expr: &BinaryExpr{Left: expr, Right: &EqualsExpr{Key: "host", Value: "b"}, Operator: AND}}
+	}
+	assert.Equal(t, 20001, Depth(expr))
+}
@@ -2,11 +2,14 @@ package stmt
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/strutil"
 )
 
 //go:generate mockgen -source ./expr.go -destination=./expr_mock.go -package=stmt
@@ -17,10 +20,21 @@ type exprData struct {
 	Expr json.RawMessage `json:"expr"`
 }
 
+var (
+	errEmptyTagKey    = errors.New("tag key cannot be empty")
+	errEmptyInValues  = errors.New("values of in expr cannot be empty")
+	errEmptyFieldName = errors.New("field name cannot be empty")
+	errEmptyExpr      = errors.New("expr cannot be empty")
+)
+
 // Expr represents a interface for all expression types
 type Expr interface {
 	// Rewrite rewrites the expr after parse
 	Rewrite() string
+	// Validate validates the expr before execution, validates all sub exprs for compound expr
+	Validate() error
+	// Equal returns if the expr is structurally equal to other expr
+	Equal(other Expr) bool
 }
 
 // TagFilter represents tag filter for searching time series
@@ -84,19 +98,31 @@ type innerBinaryExpr struct {
 	Operator BinaryOP        `json:"operator"`
 }
 
-// EqualsExpr represents an equals expression
+// EqualsExpr represents an equals expression,
+// literal is set by parser if both sides are number literals(like 1=1), then it's a constant predicate.
 type EqualsExpr struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Literal bool   `json:"literal,omitempty"`
 }
 
-// InExpr represents an in expression
+// InExpr represents an in expression,
+// empty values match nothing, so negated empty in expr matches all series of the tag key.
 type InExpr struct {
 	Key    string   `json:"key"`
 	Values []string `json:"values"`
 }
 
-// LikeExpr represents a like expression
+// AllOfExpr represents a contains all expression of multi-valued tag(like labels contains_all ('gpu','nvme')),
+// matches the series which have all the values, so it is the intersection of the series of each value,
+// unlike in expr which is the union.
+type AllOfExpr struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+// LikeExpr represents a like expression, value is a glob pattern which '*' matches any sequence of characters
+// (including empty), like 'a*b*c', other characters are matched literally.
 type LikeExpr struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
@@ -108,6 +134,37 @@ type RegexExpr struct {
 	Regexp string `json:"regexp"`
 }
 
+// GreaterExpr represents a greater than(>) expression of tag value,
+// tag values are compared lexicographically as strings(byte-wise), so numeric tag value should be zero-padded.
+type GreaterExpr struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// GreaterEqualExpr represents a greater than or equal(>=) expression of tag value, compared lexicographically
+type GreaterEqualExpr struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// LessExpr represents a less than(<) expression of tag value, compared lexicographically
+type LessExpr struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// LessEqualExpr represents a less than or equal(<=) expression of tag value, compared lexicographically
+type LessEqualExpr struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ExistsExpr represents a tag existence expression(like host != null), matches the series which has the tag,
+// so it is the series of the tag key, the negation(like host = null) matches the series of metric lacking the tag.
+type ExistsExpr struct {
+	Key string `json:"key"`
+}
+
 // NotExpr represents a not expression
 type NotExpr struct {
 	Expr Expr
@@ -165,6 +222,11 @@ func (e *InExpr) Rewrite() string {
 	return fmt.Sprintf("%s in (%s)", e.Key, strings.Join(e.Values, ","))
 }
 
+// Rewrite rewrites the all of expr after parse
+func (e *AllOfExpr) Rewrite() string {
+	return fmt.Sprintf("%s contains_all (%s)", e.Key, strings.Join(e.Values, ","))
+}
+
 // Rewrite rewrites the like expr after parse
 func (e *LikeExpr) Rewrite() string {
 	return fmt.Sprintf("%s like %s", e.Key, e.Value)
@@ -175,6 +237,293 @@ func (e *RegexExpr) Rewrite() string {
 	return fmt.Sprintf("%s=~%s", e.Key, e.Regexp)
 }
 
+// Rewrite rewrites the exists expr after parse
+func (e *ExistsExpr) Rewrite() string {
+	return fmt.Sprintf("%s!=null", e.Key)
+}
+
+// Rewrite rewrites the greater expr after parse
+func (e *GreaterExpr) Rewrite() string {
+	return fmt.Sprintf("%s>%s", e.Key, e.Value)
+}
+
+// Rewrite rewrites the greater equal expr after parse
+func (e *GreaterEqualExpr) Rewrite() string {
+	return fmt.Sprintf("%s>=%s", e.Key, e.Value)
+}
+
+// Rewrite rewrites the less expr after parse
+func (e *LessExpr) Rewrite() string {
+	return fmt.Sprintf("%s<%s", e.Key, e.Value)
+}
+
+// Rewrite rewrites the less equal expr after parse
+func (e *LessEqualExpr) Rewrite() string {
+	return fmt.Sprintf("%s<=%s", e.Key, e.Value)
+}
+
+// Validate validates the expr of select item
+func (e *SelectItem) Validate() error {
+	return validateExpr(e.Expr)
+}
+
+// Validate validates the field name
+func (e *FieldExpr) Validate() error {
+	if e.Name == "" {
+		return errEmptyFieldName
+	}
+	return nil
+}
+
+// Validate validates all params of call expr
+func (e *CallExpr) Validate() error {
+	for _, param := range e.Params {
+		if err := validateExpr(param); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the expr in paren
+func (e *ParenExpr) Validate() error {
+	return validateExpr(e.Expr)
+}
+
+// Validate always returns nil for number literal
+func (e *NumberLiteral) Validate() error {
+	return nil
+}
+
+// Validate validates both the left and right exprs
+func (e *BinaryExpr) Validate() error {
+	if err := validateExpr(e.Left); err != nil {
+		return err
+	}
+	return validateExpr(e.Right)
+}
+
+// Validate validates the negated expr
+func (e *NotExpr) Validate() error {
+	return validateExpr(e.Expr)
+}
+
+// Validate validates the tag key of equals expr
+func (e *EqualsExpr) Validate() error {
+	return validateTagKey(e.Key)
+}
+
+// Validate validates the tag key of in expr, empty values is valid which matches nothing
+func (e *InExpr) Validate() error {
+	return validateTagKey(e.Key)
+}
+
+// Validate validates the tag key and values of all of expr, values cannot be empty
+func (e *AllOfExpr) Validate() error {
+	if err := validateTagKey(e.Key); err != nil {
+		return err
+	}
+	if len(e.Values) == 0 {
+		return errEmptyInValues
+	}
+	return nil
+}
+
+// Validate validates the tag key of like expr
+func (e *LikeExpr) Validate() error {
+	return validateTagKey(e.Key)
+}
+
+// Validate validates the tag key of regex expr, checks the cost of pattern, then compiles the pattern
+func (e *RegexExpr) Validate() error {
+	if err := validateTagKey(e.Key); err != nil {
+		return err
+	}
+	// rejects the dangerous pattern before compilation
+	if err := strutil.CheckRegexCost(e.Regexp); err != nil {
+		return fmt.Errorf("invalid regexp of tag key: %s, err: %w", e.Key, err)
+	}
+	if _, err := regexp.Compile(e.Regexp); err != nil {
+		return fmt.Errorf("invalid regexp of tag key: %s, err: %v", e.Key, err)
+	}
+	return nil
+}
+
+// Validate validates the tag key of exists expr
+func (e *ExistsExpr) Validate() error {
+	return validateTagKey(e.Key)
+}
+
+// Validate validates the tag key of greater expr
+func (e *GreaterExpr) Validate() error {
+	return validateTagKey(e.Key)
+}
+
+// Validate validates the tag key of greater equal expr
+func (e *GreaterEqualExpr) Validate() error {
+	return validateTagKey(e.Key)
+}
+
+// Validate validates the tag key of less expr
+func (e *LessExpr) Validate() error {
+	return validateTagKey(e.Key)
+}
+
+// Validate validates the tag key of less equal expr
+func (e *LessEqualExpr) Validate() error {
+	return validateTagKey(e.Key)
+}
+
+// validateExpr validates the expr, expr cannot be nil
+func validateExpr(expr Expr) error {
+	if expr == nil {
+		return errEmptyExpr
+	}
+	return expr.Validate()
+}
+
+// validateTagKey validates the tag key of tag filter, tag key cannot be empty
+func validateTagKey(tagKey string) error {
+	if tagKey == "" {
+		return errEmptyTagKey
+	}
+	return nil
+}
+
+// Equal returns if other is select item with the same alias and expr
+func (e *SelectItem) Equal(other Expr) bool {
+	o, ok := other.(*SelectItem)
+	return ok && e.Alias == o.Alias && equalExpr(e.Expr, o.Expr)
+}
+
+// Equal returns if other is field expr with the same name
+func (e *FieldExpr) Equal(other Expr) bool {
+	o, ok := other.(*FieldExpr)
+	return ok && e.Name == o.Name
+}
+
+// Equal returns if other is call expr with the same function and params(in order)
+func (e *CallExpr) Equal(other Expr) bool {
+	o, ok := other.(*CallExpr)
+	if !ok || e.FuncType != o.FuncType || len(e.Params) != len(o.Params) {
+		return false
+	}
+	for idx, param := range e.Params {
+		if !equalExpr(param, o.Params[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns if other is paren expr with the same expr
+func (e *ParenExpr) Equal(other Expr) bool {
+	o, ok := other.(*ParenExpr)
+	return ok && equalExpr(e.Expr, o.Expr)
+}
+
+// Equal returns if other is number literal with the same value
+func (e *NumberLiteral) Equal(other Expr) bool {
+	o, ok := other.(*NumberLiteral)
+	return ok && e.Val == o.Val
+}
+
+// Equal returns if other is binary expr with the same operator and operands
+func (e *BinaryExpr) Equal(other Expr) bool {
+	o, ok := other.(*BinaryExpr)
+	return ok && e.Operator == o.Operator && equalExpr(e.Left, o.Left) && equalExpr(e.Right, o.Right)
+}
+
+// Equal returns if other is not expr with the same negated expr
+func (e *NotExpr) Equal(other Expr) bool {
+	o, ok := other.(*NotExpr)
+	return ok && equalExpr(e.Expr, o.Expr)
+}
+
+// Equal returns if other is equals expr with the same tag key and value
+func (e *EqualsExpr) Equal(other Expr) bool {
+	o, ok := other.(*EqualsExpr)
+	return ok && e.Key == o.Key && e.Value == o.Value
+}
+
+// Equal returns if other is in expr with the same tag key and values, the order of values is ignored
+func (e *InExpr) Equal(other Expr) bool {
+	o, ok := other.(*InExpr)
+	return ok && e.Key == o.Key && equalValues(e.Values, o.Values)
+}
+
+// Equal returns if other is all of expr with the same tag key and values, the order of values is ignored
+func (e *AllOfExpr) Equal(other Expr) bool {
+	o, ok := other.(*AllOfExpr)
+	return ok && e.Key == o.Key && equalValues(e.Values, o.Values)
+}
+
+// equalValues returns if the two value lists have the same distinct values, the order of values is ignored
+func equalValues(a, b []string) bool {
+	values := make(map[string]struct{}, len(a))
+	for _, value := range a {
+		values[value] = struct{}{}
+	}
+	otherValues := make(map[string]struct{}, len(b))
+	for _, value := range b {
+		if _, ok := values[value]; !ok {
+			return false
+		}
+		otherValues[value] = struct{}{}
+	}
+	return len(values) == len(otherValues)
+}
+
+// Equal returns if other is like expr with the same tag key and value
+func (e *LikeExpr) Equal(other Expr) bool {
+	o, ok := other.(*LikeExpr)
+	return ok && e.Key == o.Key && e.Value == o.Value
+}
+
+// Equal returns if other is regex expr with the same tag key and pattern
+func (e *RegexExpr) Equal(other Expr) bool {
+	o, ok := other.(*RegexExpr)
+	return ok && e.Key == o.Key && e.Regexp == o.Regexp
+}
+
+// Equal returns if other is exists expr with the same tag key
+func (e *ExistsExpr) Equal(other Expr) bool {
+	o, ok := other.(*ExistsExpr)
+	return ok && e.Key == o.Key
+}
+
+// Equal returns if other is greater expr with the same tag key and value
+func (e *GreaterExpr) Equal(other Expr) bool {
+	o, ok := other.(*GreaterExpr)
+	return ok && e.Key == o.Key && e.Value == o.Value
+}
+
+// Equal returns if other is greater equal expr with the same tag key and value
+func (e *GreaterEqualExpr) Equal(other Expr) bool {
+	o, ok := other.(*GreaterEqualExpr)
+	return ok && e.Key == o.Key && e.Value == o.Value
+}
+
+// Equal returns if other is less expr with the same tag key and value
+func (e *LessExpr) Equal(other Expr) bool {
+	o, ok := other.(*LessExpr)
+	return ok && e.Key == o.Key && e.Value == o.Value
+}
+
+// Equal returns if other is less equal expr with the same tag key and value
+func (e *LessEqualExpr) Equal(other Expr) bool {
+	o, ok := other.(*LessEqualExpr)
+	return ok && e.Key == o.Key && e.Value == o.Value
+}
+
+// equalExpr returns if the two exprs are structurally equal, nil only equals nil
+func equalExpr(a, b Expr) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(b)
+}
+
 // Marshal returns json of expr using custom json marshal
 func Marshal(expr Expr) []byte {
 	switch e := expr.(type) {
@@ -184,10 +533,24 @@ func Marshal(expr Expr) []byte {
 		return encoding.JSONMarshal(&exprData{Type: "like", Expr: encoding.JSONMarshal(expr)})
 	case *InExpr:
 		return encoding.JSONMarshal(&exprData{Type: "in", Expr: encoding.JSONMarshal(expr)})
+	case *AllOfExpr:
+		return encoding.JSONMarshal(&exprData{Type: "allOf", Expr: encoding.JSONMarshal(expr)})
 	case *EqualsExpr:
 		return encoding.JSONMarshal(&exprData{Type: "equals", Expr: encoding.JSONMarshal(expr)})
+	case *ExistsExpr:
+		return encoding.JSONMarshal(&exprData{Type: "exists", Expr: encoding.JSONMarshal(expr)})
+	case *GreaterExpr:
+		return encoding.JSONMarshal(&exprData{Type: "greater", Expr: encoding.JSONMarshal(expr)})
+	case *GreaterEqualExpr:
+		return encoding.JSONMarshal(&exprData{Type: "greaterEqual", Expr: encoding.JSONMarshal(expr)})
+	case *LessExpr:
+		return encoding.JSONMarshal(&exprData{Type: "less", Expr: encoding.JSONMarshal(expr)})
+	case *LessEqualExpr:
+		return encoding.JSONMarshal(&exprData{Type: "lessEqual", Expr: encoding.JSONMarshal(expr)})
 	case *NumberLiteral:
 		return encoding.JSONMarshal(&exprData{Type: "number", Expr: encoding.JSONMarshal(expr)})
+	case *BoolLiteral:
+		return encoding.JSONMarshal(&exprData{Type: "bool", Expr: encoding.JSONMarshal(expr)})
 	case *FieldExpr:
 		return encoding.JSONMarshal(&exprData{Type: "field", Expr: encoding.JSONMarshal(expr)})
 	case *NotExpr:
@@ -239,10 +602,24 @@ func Unmarshal(value []byte) (Expr, error) {
 		return unmarshal(&exprData, &LikeExpr{})
 	case "in":
 		return unmarshal(&exprData, &InExpr{})
+	case "allOf":
+		return unmarshal(&exprData, &AllOfExpr{})
 	case "equals":
 		return unmarshal(&exprData, &EqualsExpr{})
+	case "exists":
+		return unmarshal(&exprData, &ExistsExpr{})
+	case "greater":
+		return unmarshal(&exprData, &GreaterExpr{})
+	case "greaterEqual":
+		return unmarshal(&exprData, &GreaterEqualExpr{})
+	case "less":
+		return unmarshal(&exprData, &LessExpr{})
+	case "lessEqual":
+		return unmarshal(&exprData, &LessEqualExpr{})
 	case "number":
 		return unmarshal(&exprData, &NumberLiteral{})
+	case "bool":
+		return unmarshal(&exprData, &BoolLiteral{})
 	case field:
 		return unmarshal(&exprData, &FieldExpr{})
 	case "paren":
@@ -337,8 +714,26 @@ func (e *EqualsExpr) TagKey() string { return e.Key }
 // TagKey returns the in filter's tag key
 func (e *InExpr) TagKey() string { return e.Key }
 
+// TagKey returns the all of filter's tag key
+func (e *AllOfExpr) TagKey() string { return e.Key }
+
 // TagKey returns the like filter's tag key
 func (e *LikeExpr) TagKey() string { return e.Key }
 
 // TagKey returns the regex filter's tag key
 func (e *RegexExpr) TagKey() string { return e.Key }
+
+// TagKey returns the exists filter's tag key
+func (e *ExistsExpr) TagKey() string { return e.Key }
+
+// TagKey returns the greater filter's tag key
+func (e *GreaterExpr) TagKey() string { return e.Key }
+
+// TagKey returns the greater equal filter's tag key
+func (e *GreaterEqualExpr) TagKey() string { return e.Key }
+
+// TagKey returns the less filter's tag key
+func (e *LessExpr) TagKey() string { return e.Key }
+
+// TagKey returns the less equal filter's tag key
+func (e *LessEqualExpr) TagKey() string { return e.Key }
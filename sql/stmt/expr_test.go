@@ -1,11 +1,13 @@
 package stmt
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/strutil"
 )
 
 func TestExpr_Rewrite(t *testing.T) {
@@ -43,15 +45,140 @@ func TestExpr_Rewrite(t *testing.T) {
 
 	assert.Equal(t, "tagKey in (a,b,c)", (&InExpr{Key: "tagKey", Values: []string{"a", "b", "c"}}).Rewrite())
 	assert.Equal(t, "tagKey in ()", (&InExpr{Key: "tagKey"}).Rewrite())
+	assert.Equal(t, "tagKey contains_all (a,b)", (&AllOfExpr{Key: "tagKey", Values: []string{"a", "b"}}).Rewrite())
 
 	assert.Equal(t, "tagKey=~Regexp", (&RegexExpr{Key: "tagKey", Regexp: "Regexp"}).Rewrite())
+	assert.Equal(t, "tagKey!=null", (&ExistsExpr{Key: "tagKey"}).Rewrite())
+	assert.Equal(t, "not tagKey!=null", (&NotExpr{Expr: &ExistsExpr{Key: "tagKey"}}).Rewrite())
+	assert.Equal(t, "tagKey>tagValue", (&GreaterExpr{Key: "tagKey", Value: "tagValue"}).Rewrite())
+	assert.Equal(t, "tagKey>=tagValue", (&GreaterEqualExpr{Key: "tagKey", Value: "tagValue"}).Rewrite())
+	assert.Equal(t, "tagKey<tagValue", (&LessExpr{Key: "tagKey", Value: "tagValue"}).Rewrite())
+	assert.Equal(t, "tagKey<=tagValue", (&LessEqualExpr{Key: "tagKey", Value: "tagValue"}).Rewrite())
+
+	assert.Equal(t, "true", (&BoolLiteral{Val: true}).Rewrite())
+	assert.Equal(t, "false", (&BoolLiteral{Val: false}).Rewrite())
 }
 
 func TestTagFilter(t *testing.T) {
 	assert.Equal(t, "tagKey", (&EqualsExpr{Key: "tagKey", Value: "tagValue"}).TagKey())
 	assert.Equal(t, "tagKey", (&LikeExpr{Key: "tagKey", Value: "tagValue"}).TagKey())
 	assert.Equal(t, "tagKey", (&InExpr{Key: "tagKey", Values: []string{"a", "b", "c"}}).TagKey())
+	assert.Equal(t, "tagKey", (&AllOfExpr{Key: "tagKey", Values: []string{"a", "b"}}).TagKey())
 	assert.Equal(t, "tagKey", (&RegexExpr{Key: "tagKey", Regexp: "Regexp"}).TagKey())
+	assert.Equal(t, "tagKey", (&ExistsExpr{Key: "tagKey"}).TagKey())
+	assert.Equal(t, "tagKey", (&GreaterExpr{Key: "tagKey", Value: "tagValue"}).TagKey())
+	assert.Equal(t, "tagKey", (&GreaterEqualExpr{Key: "tagKey", Value: "tagValue"}).TagKey())
+	assert.Equal(t, "tagKey", (&LessExpr{Key: "tagKey", Value: "tagValue"}).TagKey())
+	assert.Equal(t, "tagKey", (&LessEqualExpr{Key: "tagKey", Value: "tagValue"}).TagKey())
+}
+
+func TestExpr_Validate(t *testing.T) {
+	// valid exprs
+	assert.NoError(t, (&EqualsExpr{Key: "host", Value: "a"}).Validate())
+	assert.NoError(t, (&InExpr{Key: "host", Values: []string{"a"}}).Validate())
+	assert.NoError(t, (&AllOfExpr{Key: "labels", Values: []string{"gpu"}}).Validate())
+	assert.NoError(t, (&LikeExpr{Key: "host", Value: "a*"}).Validate())
+	assert.NoError(t, (&RegexExpr{Key: "host", Regexp: "^a.*$"}).Validate())
+	assert.NoError(t, (&NumberLiteral{Val: 1}).Validate())
+	assert.NoError(t, (&BoolLiteral{Val: true}).Validate())
+	assert.NoError(t, (&SelectItem{Expr: &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}}).Validate())
+
+	// empty tag key
+	assert.Equal(t, errEmptyTagKey, (&EqualsExpr{Value: "a"}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&InExpr{Values: []string{"a"}}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&AllOfExpr{Values: []string{"a"}}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&LikeExpr{Value: "a"}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&RegexExpr{Regexp: "a"}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&ExistsExpr{}).Validate())
+	assert.NoError(t, (&ExistsExpr{Key: "host"}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&GreaterExpr{Value: "a"}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&GreaterEqualExpr{Value: "a"}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&LessExpr{Value: "a"}).Validate())
+	assert.Equal(t, errEmptyTagKey, (&LessEqualExpr{Value: "a"}).Validate())
+	// empty in values
+	assert.Equal(t, errEmptyInValues, (&AllOfExpr{Key: "labels"}).Validate())
+	// empty in values matches nothing
+	assert.NoError(t, (&InExpr{Key: "host"}).Validate())
+	assert.NoError(t, (&NotExpr{Expr: &InExpr{Key: "host"}}).Validate())
+	// invalid regexp
+	assert.Error(t, (&RegexExpr{Key: "host", Regexp: "a(b"}).Validate())
+	// pathological regexp is rejected before compilation
+	err := (&RegexExpr{Key: "host", Regexp: `(\w+\s?)*$`}).Validate()
+	assert.True(t, errors.Is(err, strutil.ErrRegexTooComplex))
+	// empty field name
+	assert.Equal(t, errEmptyFieldName, (&FieldExpr{}).Validate())
+
+	// validates sub exprs of compound expr
+	invalid := &AllOfExpr{Key: "labels"}
+	valid := &EqualsExpr{Key: "host", Value: "a"}
+	assert.Equal(t, errEmptyInValues, (&NotExpr{Expr: invalid}).Validate())
+	assert.Equal(t, errEmptyInValues, (&ParenExpr{Expr: invalid}).Validate())
+	assert.Equal(t, errEmptyInValues, (&BinaryExpr{Left: invalid, Operator: AND, Right: valid}).Validate())
+	assert.Equal(t, errEmptyInValues, (&BinaryExpr{Left: valid, Operator: OR, Right: invalid}).Validate())
+	assert.NoError(t, (&BinaryExpr{Left: valid, Operator: OR, Right: valid}).Validate())
+	assert.Equal(t, errEmptyFieldName, (&CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{}}}).Validate())
+	assert.Equal(t, errEmptyExpr, (&NotExpr{}).Validate())
+	assert.Equal(t, errEmptyExpr, (&SelectItem{}).Validate())
+}
+
+func TestExpr_Equal(t *testing.T) {
+	// in values is order-insensitive
+	assert.True(t, (&InExpr{Key: "host", Values: []string{"a", "b"}}).Equal(&InExpr{Key: "host", Values: []string{"b", "a"}}))
+	assert.True(t, (&InExpr{Key: "host", Values: []string{"a", "b"}}).Equal(&InExpr{Key: "host", Values: []string{"b", "a", "a"}}))
+	assert.False(t, (&InExpr{Key: "host", Values: []string{"a", "b"}}).Equal(&InExpr{Key: "host", Values: []string{"a"}}))
+	assert.False(t, (&InExpr{Key: "host", Values: []string{"a"}}).Equal(&InExpr{Key: "host", Values: []string{"a", "b"}}))
+	assert.False(t, (&InExpr{Key: "host", Values: []string{"a"}}).Equal(&InExpr{Key: "ip", Values: []string{"a"}}))
+	assert.False(t, (&InExpr{Key: "host", Values: []string{"a"}}).Equal(&EqualsExpr{Key: "host", Value: "a"}))
+	// all of values is order-insensitive, but all of is not in
+	assert.True(t, (&AllOfExpr{Key: "labels", Values: []string{"gpu", "nvme"}}).
+		Equal(&AllOfExpr{Key: "labels", Values: []string{"nvme", "gpu"}}))
+	assert.False(t, (&AllOfExpr{Key: "labels", Values: []string{"gpu", "nvme"}}).
+		Equal(&AllOfExpr{Key: "labels", Values: []string{"gpu"}}))
+	assert.False(t, (&AllOfExpr{Key: "labels", Values: []string{"gpu"}}).
+		Equal(&InExpr{Key: "labels", Values: []string{"gpu"}}))
+
+	// tag filters
+	assert.True(t, (&EqualsExpr{Key: "host", Value: "a"}).Equal(&EqualsExpr{Key: "host", Value: "a"}))
+	assert.False(t, (&EqualsExpr{Key: "host", Value: "a"}).Equal(&EqualsExpr{Key: "host", Value: "b"}))
+	assert.False(t, (&EqualsExpr{Key: "host", Value: "a"}).Equal(&LikeExpr{Key: "host", Value: "a"}))
+	assert.True(t, (&LikeExpr{Key: "host", Value: "a*"}).Equal(&LikeExpr{Key: "host", Value: "a*"}))
+	assert.True(t, (&RegexExpr{Key: "host", Regexp: "a*"}).Equal(&RegexExpr{Key: "host", Regexp: "a*"}))
+	assert.False(t, (&RegexExpr{Key: "host", Regexp: "a*"}).Equal(&RegexExpr{Key: "ip", Regexp: "a*"}))
+	assert.True(t, (&ExistsExpr{Key: "host"}).Equal(&ExistsExpr{Key: "host"}))
+	assert.False(t, (&ExistsExpr{Key: "host"}).Equal(&ExistsExpr{Key: "ip"}))
+	assert.True(t, (&GreaterExpr{Key: "host", Value: "a"}).Equal(&GreaterExpr{Key: "host", Value: "a"}))
+	assert.False(t, (&GreaterExpr{Key: "host", Value: "a"}).Equal(&GreaterEqualExpr{Key: "host", Value: "a"}))
+	assert.True(t, (&GreaterEqualExpr{Key: "host", Value: "a"}).Equal(&GreaterEqualExpr{Key: "host", Value: "a"}))
+	assert.True(t, (&LessExpr{Key: "host", Value: "a"}).Equal(&LessExpr{Key: "host", Value: "a"}))
+	assert.False(t, (&LessExpr{Key: "host", Value: "a"}).Equal(&LessEqualExpr{Key: "host", Value: "a"}))
+	assert.True(t, (&LessEqualExpr{Key: "host", Value: "a"}).Equal(&LessEqualExpr{Key: "host", Value: "a"}))
+
+	// compound exprs
+	hostA := &EqualsExpr{Key: "host", Value: "a"}
+	in := &InExpr{Key: "region", Values: []string{"sh", "bj"}}
+	assert.True(t, (&BinaryExpr{Left: hostA, Operator: AND, Right: in}).
+		Equal(&BinaryExpr{Left: &EqualsExpr{Key: "host", Value: "a"}, Operator: AND,
+			Right: &InExpr{Key: "region", Values: []string{"bj", "sh"}}}))
+	assert.False(t, (&BinaryExpr{Left: hostA, Operator: AND, Right: in}).Equal(&BinaryExpr{Left: hostA, Operator: OR, Right: in}))
+	assert.False(t, (&BinaryExpr{Left: hostA, Operator: AND, Right: in}).Equal(&BinaryExpr{Left: in, Operator: AND, Right: hostA}))
+	assert.True(t, (&NotExpr{Expr: hostA}).Equal(&NotExpr{Expr: &EqualsExpr{Key: "host", Value: "a"}}))
+	assert.False(t, (&NotExpr{Expr: hostA}).Equal(hostA))
+	assert.False(t, (&NotExpr{Expr: hostA}).Equal(&NotExpr{}))
+	assert.True(t, (&ParenExpr{Expr: hostA}).Equal(&ParenExpr{Expr: hostA}))
+	assert.False(t, (&ParenExpr{Expr: hostA}).Equal(&ParenExpr{Expr: in}))
+
+	// select items
+	sum := &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}
+	assert.True(t, sum.Equal(&CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}))
+	assert.False(t, sum.Equal(&CallExpr{FuncType: function.Max, Params: []Expr{&FieldExpr{Name: "f"}}}))
+	assert.False(t, sum.Equal(&CallExpr{FuncType: function.Sum}))
+	assert.False(t, sum.Equal(&CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "a"}}}))
+	assert.True(t, (&SelectItem{Expr: sum, Alias: "s"}).Equal(&SelectItem{Expr: sum, Alias: "s"}))
+	assert.False(t, (&SelectItem{Expr: sum, Alias: "s"}).Equal(&SelectItem{Expr: sum}))
+	assert.True(t, (&NumberLiteral{Val: 1}).Equal(&NumberLiteral{Val: 1}))
+	assert.False(t, (&NumberLiteral{Val: 1}).Equal(&NumberLiteral{Val: 2}))
+	assert.True(t, (&BoolLiteral{Val: true}).Equal(&BoolLiteral{Val: true}))
+	assert.False(t, (&BoolLiteral{Val: true}).Equal(&BoolLiteral{Val: false}))
 }
 
 func TestExpr_Marshal_Fail(t *testing.T) {
@@ -105,6 +232,22 @@ func TestLikeExpr_Marshal(t *testing.T) {
 	assert.Equal(t, *expr, *e)
 }
 
+func TestCompareExpr_Marshal(t *testing.T) {
+	for _, expr := range []Expr{
+		&GreaterExpr{Key: "tagKey", Value: "tagValue"},
+		&GreaterEqualExpr{Key: "tagKey", Value: "tagValue"},
+		&LessExpr{Key: "tagKey", Value: "tagValue"},
+		&LessEqualExpr{Key: "tagKey", Value: "tagValue"},
+		&ExistsExpr{Key: "tagKey"},
+		&NotExpr{Expr: &ExistsExpr{Key: "tagKey"}},
+	} {
+		data := Marshal(expr)
+		exprData, err := Unmarshal(data)
+		assert.NoError(t, err)
+		assert.Equal(t, expr, exprData)
+	}
+}
+
 func TestInExpr_Marshal(t *testing.T) {
 	expr := &InExpr{Key: "tagKey"}
 	data := Marshal(expr)
@@ -119,6 +262,15 @@ func TestInExpr_Marshal(t *testing.T) {
 	assert.Equal(t, *expr, *e)
 }
 
+func TestAllOfExpr_Marshal(t *testing.T) {
+	expr := &AllOfExpr{Key: "labels", Values: []string{"gpu", "nvme"}}
+	data := Marshal(expr)
+	exprData, err := Unmarshal(data)
+	assert.NoError(t, err)
+	e := exprData.(*AllOfExpr)
+	assert.Equal(t, *expr, *e)
+}
+
 func TestEqualsExpr_Marshal(t *testing.T) {
 	expr := &EqualsExpr{Key: "tagKey", Value: "tagValue"}
 	data := Marshal(expr)
@@ -137,6 +289,15 @@ func TestNotExpr_Marshal(t *testing.T) {
 	assert.Equal(t, *expr, *e)
 }
 
+func TestBoolLiteral_Marshal(t *testing.T) {
+	expr := &BoolLiteral{Val: true}
+	data := Marshal(expr)
+	exprData, err := Unmarshal(data)
+	assert.NoError(t, err)
+	e := exprData.(*BoolLiteral)
+	assert.Equal(t, *expr, *e)
+}
+
 func TestNumberLiteral_Marshal(t *testing.T) {
 	expr := &SelectItem{Expr: &NumberLiteral{Val: 19.0}}
 	data := Marshal(expr)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 // MetadataType represents metadata suggest type
@@ -46,8 +47,9 @@ type Metadata struct {
 	Type       MetadataType // metadata suggest type
 	TagKey     string
 	Prefix     string
-	Condition  Expr // tag filter condition expression
-	Limit      int  // result set limit
+	Condition  Expr               // tag filter condition expression
+	TimeRange  timeutil.TimeRange // only the series which have data in time range match if not empty
+	Limit      int                // result set limit
 }
 
 // innerMetadata represents a wrapper of metadata for json encoding
@@ -56,9 +58,10 @@ type innerMetadata struct {
 	MetricName string          `json:"metricName,omitempty"`
 	Type       MetadataType    `json:"type,omitempty"`
 	TagKey     string          `json:"tagKey,omitempty"`
-	Condition  json.RawMessage `json:"condition,omitempty"`
-	Prefix     string          `json:"prefix,omitempty"`
-	Limit      int             `json:"limit,omitempty"`
+	Condition  json.RawMessage    `json:"condition,omitempty"`
+	TimeRange  timeutil.TimeRange `json:"timeRange,omitempty"`
+	Prefix     string             `json:"prefix,omitempty"`
+	Limit      int                `json:"limit,omitempty"`
 }
 
 // MarshalJSON returns json data of query
@@ -67,6 +70,7 @@ func (q *Metadata) MarshalJSON() ([]byte, error) {
 		MetricName: q.MetricName,
 		Namespace:  q.Namespace,
 		Condition:  Marshal(q.Condition),
+		TimeRange:  q.TimeRange,
 		TagKey:     q.TagKey,
 		Type:       q.Type,
 		Prefix:     q.Prefix,
@@ -93,6 +97,7 @@ func (q *Metadata) UnmarshalJSON(value []byte) error {
 	q.Type = inner.Type
 	q.TagKey = inner.TagKey
 	q.Prefix = inner.Prefix
+	q.TimeRange = inner.TimeRange
 	q.Limit = inner.Limit
 	return nil
 }
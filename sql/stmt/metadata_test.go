@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestMetadataType_String(t *testing.T) {
@@ -36,9 +37,10 @@ func TestMetadata_MarshalJSON(t *testing.T) {
 				Right:    &EqualsExpr{Key: "path", Value: "/home"},
 			}},
 		},
-		TagKey: "tagKey",
-		Prefix: "prefix",
-		Limit:  100,
+		TagKey:    "tagKey",
+		Prefix:    "prefix",
+		TimeRange: timeutil.TimeRange{Start: 10, End: 20},
+		Limit:     100,
 	}
 
 	data := encoding.JSONMarshal(&query)
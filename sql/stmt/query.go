@@ -2,60 +2,160 @@ package stmt
 
 import (
 	"encoding/json"
+	"math"
 
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
+// FillType represents the type of filling the output step which has no data point
+type FillType uint8
+
+// Defines all types of filling the missing output step
+const (
+	// FillNull fills null value
+	FillNull FillType = iota
+	// FillPrevious fills the value of previous present step
+	FillPrevious
+	// FillValue fills the constant value
+	FillValue
+)
+
+// Fill represents the fill option of query(group by ... fill(null|previous|value))
+type Fill struct {
+	Type  FillType `json:"type,omitempty"`
+	Value float64  `json:"value,omitempty"` // constant value for FillValue
+}
+
+// TimeColumn is the reserved select target which outputs the timestamp of each point alongside the fields,
+// quotes it("time") for the field named time.
+const TimeColumn = "time"
+
 // Query represents search statement
 type Query struct {
 	Explain     bool     //  need explain query execute stat
+	Analyze     bool     // explain analyze, annotates the stat with actual matched series/cost of each tag filter
 	Namespace   string   // namespace
 	MetricName  string   // like table name
+	MetricAlias string   // alias of metric name(from cpu_v2 as cpu), reported as metric name of result
 	SelectItems []Expr   // select list, such as field, function call, math expression etc.
 	FieldNames  []string // select field names
+	SelectTime  bool     // select time column, outputs the timestamp of each point
 	Condition   Expr     // tag filter condition expression
+	Annotations []string // annotations of condition rewriting, like contradictory predicates, shown in explain
+
+	TimeRange  timeutil.TimeRange   // query time range
+	TimeRanges []timeutil.TimeRange // disjoint time ranges if query by time buckets, time range covers all of them
+	Interval   timeutil.Interval    // down sampling interval
+	Raw        bool                 // query the raw data points without down sampling, if select bare fields without interval
+	Window     timeutil.Interval    // trailing window(group by ... over), time range is narrowed to the window
 
-	TimeRange timeutil.TimeRange // query time range
-	Interval  timeutil.Interval  // down sampling interval
+	OutputInterval timeutil.Interval // output step(group by ... step), the result is resampled if not equals interval
+	Fill           Fill              // fill option of the output step which has no data point
 
-	GroupBy []string // group by tag keys
-	Limit   int      // num. of time series list for result
+	GroupBy       []string // group by tag keys
+	GroupByAll    bool     // group by all tag keys of metric(group by *), expanded by storage
+	CountDistinct string   // tag key of count(distinct tag), counts distinct tag values of matched series
+	Limit         int      // num. of time series list for result
+
+	SampleRate float64 // fraction of matched series to query(sample_series), 0 means no sampling
 }
 
 // HasGroupBy returns whether query has group by tag keys
 func (q *Query) HasGroupBy() bool {
-	return len(q.GroupBy) > 0
+	return len(q.GroupBy) > 0 || q.GroupByAll
+}
+
+// ResultMetricName returns the metric name reported by result, which is the alias if metric is aliased
+func (q *Query) ResultMetricName() string {
+	if q.MetricAlias != "" {
+		return q.MetricAlias
+	}
+	return q.MetricName
+}
+
+// CountDistinctRequest returns the tag value metadata request for count(distinct tag),
+// which resolves the distinct tag values of matched series which have data in query time range
+func (q *Query) CountDistinctRequest() *Metadata {
+	return &Metadata{
+		Namespace:  q.Namespace,
+		MetricName: q.MetricName,
+		Type:       TagValue,
+		TagKey:     q.CountDistinct,
+		Condition:  q.Condition,
+		TimeRange:  q.TimeRange,
+		Limit:      math.MaxInt32,
+	}
+}
+
+// NeedResample returns whether the aggregated result need be resampled to the output interval
+func (q *Query) NeedResample() bool {
+	return q.OutputInterval > 0 && q.OutputInterval != q.Interval
+}
+
+// GetTimeRanges returns the time ranges for data filtering,
+// returns the time buckets if query by time in, else returns the query time range
+func (q *Query) GetTimeRanges() []timeutil.TimeRange {
+	if len(q.TimeRanges) > 0 {
+		return q.TimeRanges
+	}
+	return []timeutil.TimeRange{q.TimeRange}
 }
 
 // innerQuery represents a wrapper of query for json encoding
 type innerQuery struct {
 	Explain     bool              `json:"Explain,omitempty"`
+	Analyze     bool              `json:"analyze,omitempty"`
 	Namespace   string            `json:"namespace,omitempty"`
 	MetricName  string            `json:"metricName,omitempty"`
+	MetricAlias string            `json:"metricAlias,omitempty"`
 	SelectItems []json.RawMessage `json:"selectItems,omitempty"`
 	FieldNames  []string          `json:"fieldNames,omitempty"`
+	SelectTime  bool              `json:"selectTime,omitempty"`
 	Condition   json.RawMessage   `json:"condition,omitempty"`
+	Annotations []string          `json:"annotations,omitempty"`
+
+	TimeRange  timeutil.TimeRange   `json:"timeRange,omitempty"`
+	TimeRanges []timeutil.TimeRange `json:"timeRanges,omitempty"`
+	Interval   timeutil.Interval    `json:"interval,omitempty"`
+	Raw        bool                 `json:"raw,omitempty"`
+	Window     timeutil.Interval    `json:"window,omitempty"`
+
+	OutputInterval timeutil.Interval `json:"outputInterval,omitempty"`
+	Fill           Fill              `json:"fill,omitempty"`
 
-	TimeRange timeutil.TimeRange `json:"timeRange,omitempty"`
-	Interval  timeutil.Interval  `json:"interval,omitempty"`
+	GroupBy       []string `json:"groupBy,omitempty"`
+	GroupByAll    bool     `json:"groupByAll,omitempty"`
+	CountDistinct string   `json:"countDistinct,omitempty"`
+	Limit         int      `json:"limit,omitempty"`
 
-	GroupBy []string `json:"groupBy,omitempty"`
-	Limit   int      `json:"limit,omitempty"`
+	SampleRate float64 `json:"sampleRate,omitempty"`
 }
 
 // MarshalJSON returns json data of query
 func (q *Query) MarshalJSON() ([]byte, error) {
 	inner := innerQuery{
-		Explain:    q.Explain,
-		MetricName: q.MetricName,
-		Namespace:  q.Namespace,
-		Condition:  Marshal(q.Condition),
-		FieldNames: q.FieldNames,
-		TimeRange:  q.TimeRange,
-		Interval:   q.Interval,
-		GroupBy:    q.GroupBy,
-		Limit:      q.Limit,
+		Explain:        q.Explain,
+		Analyze:        q.Analyze,
+		MetricName:     q.MetricName,
+		MetricAlias:    q.MetricAlias,
+		Namespace:      q.Namespace,
+		Condition:      Marshal(q.Condition),
+		Annotations:    q.Annotations,
+		FieldNames:     q.FieldNames,
+		SelectTime:     q.SelectTime,
+		TimeRange:      q.TimeRange,
+		TimeRanges:     q.TimeRanges,
+		Interval:       q.Interval,
+		Raw:            q.Raw,
+		Window:         q.Window,
+		OutputInterval: q.OutputInterval,
+		Fill:           q.Fill,
+		GroupBy:        q.GroupBy,
+		GroupByAll:     q.GroupByAll,
+		CountDistinct:  q.CountDistinct,
+		Limit:          q.Limit,
+		SampleRate:     q.SampleRate,
 	}
 	for _, item := range q.SelectItems {
 		inner.SelectItems = append(inner.SelectItems, Marshal(item))
@@ -85,13 +185,25 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 		selectItems = append(selectItems, selectItem)
 	}
 	q.Explain = inner.Explain
+	q.Analyze = inner.Analyze
 	q.MetricName = inner.MetricName
+	q.MetricAlias = inner.MetricAlias
 	q.Namespace = inner.Namespace
 	q.SelectItems = selectItems
+	q.Annotations = inner.Annotations
 	q.FieldNames = inner.FieldNames
+	q.SelectTime = inner.SelectTime
 	q.TimeRange = inner.TimeRange
+	q.TimeRanges = inner.TimeRanges
 	q.Interval = inner.Interval
+	q.Raw = inner.Raw
+	q.Window = inner.Window
+	q.OutputInterval = inner.OutputInterval
+	q.Fill = inner.Fill
 	q.GroupBy = inner.GroupBy
+	q.GroupByAll = inner.GroupByAll
+	q.CountDistinct = inner.CountDistinct
 	q.Limit = inner.Limit
+	q.SampleRate = inner.SampleRate
 	return nil
 }
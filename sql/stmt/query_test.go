@@ -12,8 +12,11 @@ import (
 
 func TestQuery_Marshal(t *testing.T) {
 	query := Query{
-		Namespace:  "ns",
-		MetricName: "test",
+		Explain:     true,
+		Analyze:     true,
+		Namespace:   "ns",
+		MetricName:  "test",
+		MetricAlias: "t",
 		SelectItems: []Expr{
 			&SelectItem{Expr: &FieldExpr{Name: "a"}},
 			&SelectItem{Expr: &FieldExpr{Name: "b"}},
@@ -46,10 +49,18 @@ func TestQuery_Marshal(t *testing.T) {
 				Right:    &EqualsExpr{Key: "path", Value: "/home"},
 			}},
 		},
-		TimeRange: timeutil.TimeRange{Start: 10, End: 30},
-		Interval:  1000,
-		GroupBy:   []string{"a", "b", "c"},
-		Limit:     100,
+		Annotations:    []string{"duplicate tag key: path"},
+		TimeRange:      timeutil.TimeRange{Start: 10, End: 30},
+		TimeRanges:     []timeutil.TimeRange{{Start: 10, End: 15}, {Start: 20, End: 30}},
+		Interval:       1000,
+		Window:         5000,
+		OutputInterval: 500,
+		Fill:           Fill{Type: FillValue, Value: 1.5},
+		GroupBy:        []string{"a", "b", "c"},
+		GroupByAll:     true,
+		CountDistinct:  "host",
+		Limit:          100,
+		SampleRate:     0.01,
 	}
 
 	data := encoding.JSONMarshal(&query)
@@ -60,6 +71,20 @@ func TestQuery_Marshal(t *testing.T) {
 	assert.True(t, query.HasGroupBy())
 }
 
+func TestQuery_CountDistinctRequest(t *testing.T) {
+	condition := &EqualsExpr{Key: "region", Value: "sh"}
+	query := &Query{Namespace: "ns", MetricName: "cpu", Condition: condition, CountDistinct: "host",
+		TimeRange: timeutil.TimeRange{Start: 10, End: 20}}
+	request := query.CountDistinctRequest()
+	assert.Equal(t, "ns", request.Namespace)
+	assert.Equal(t, "cpu", request.MetricName)
+	assert.Equal(t, TagValue, request.Type)
+	assert.Equal(t, "host", request.TagKey)
+	assert.Equal(t, condition, request.Condition)
+	assert.Equal(t, timeutil.TimeRange{Start: 10, End: 20}, request.TimeRange)
+	assert.True(t, request.Limit > 0)
+}
+
 func TestQuery_Marshal_Fail(t *testing.T) {
 	query := &Query{}
 	err := query.UnmarshalJSON([]byte{1, 2, 3})
@@ -69,3 +94,17 @@ func TestQuery_Marshal_Fail(t *testing.T) {
 	err = query.UnmarshalJSON([]byte("{\"selectItems\":[\"123\"]}"))
 	assert.NotNil(t, err)
 }
+
+func TestQuery_GetTimeRanges(t *testing.T) {
+	query := &Query{TimeRange: timeutil.TimeRange{Start: 10, End: 30}}
+	assert.Equal(t, []timeutil.TimeRange{{Start: 10, End: 30}}, query.GetTimeRanges())
+	query.TimeRanges = []timeutil.TimeRange{{Start: 10, End: 15}, {Start: 20, End: 30}}
+	assert.Equal(t, query.TimeRanges, query.GetTimeRanges())
+}
+
+func TestQuery_ResultMetricName(t *testing.T) {
+	query := &Query{MetricName: "cpu_v2"}
+	assert.Equal(t, "cpu_v2", query.ResultMetricName())
+	query.MetricAlias = "cpu"
+	assert.Equal(t, "cpu", query.ResultMetricName())
+}
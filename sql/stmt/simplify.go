@@ -0,0 +1,253 @@
+package stmt
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BoolLiteral represents a constant condition, which is the result of folding always-true/always-false predicates.
+type BoolLiteral struct {
+	Val bool `json:"val"`
+}
+
+// Rewrite rewrites the bool literal after parse
+func (e *BoolLiteral) Rewrite() string {
+	return strconv.FormatBool(e.Val)
+}
+
+// Equal returns if other is bool literal with the same value
+func (e *BoolLiteral) Equal(other Expr) bool {
+	o, ok := other.(*BoolLiteral)
+	return ok && e.Val == o.Val
+}
+
+// Validate always returns nil for bool literal
+func (e *BoolLiteral) Validate() error {
+	return nil
+}
+
+// Simplify simplifies the condition expression before series searching,
+// 1) folds constant predicates, like 1=1(always true) and 1=0(always false)
+// 2) removes duplicate predicates in the same and/or expression, like host='a' or host='a'
+// 3) pushes negation down to the tag filter by De Morgan, like not (a or b) => (not a and not b)
+// 4) collapses the or expression of equality predicates on the same tag key into in expr,
+//    like host='a' or host='b' => host in ('a','b')
+// 5) resolves the and expression of contradictory equality predicates on the same tag key to false,
+//    like host='a' and host='b'
+// returns nil if the condition is always true(no tag filter need),
+// returns false bool literal if the condition is always false(no series matched).
+func Simplify(condition Expr) Expr {
+	result, _ := SimplifyWithAnnotations(condition)
+	return result
+}
+
+// SimplifyWithAnnotations simplifies the condition expression like Simplify,
+// also returns the annotations of rewriting on duplicate tag keys for explaining the query.
+func SimplifyWithAnnotations(condition Expr) (Expr, []string) {
+	if condition == nil {
+		return nil, nil
+	}
+	s := &simplifier{}
+	result := s.simplify(condition)
+	if b, ok := result.(*BoolLiteral); ok && b.Val {
+		return nil, s.annotations
+	}
+	return result, s.annotations
+}
+
+// simplifier simplifies the condition expression, records the annotations of rewriting
+type simplifier struct {
+	annotations []string
+}
+
+// annotate records the annotation of rewriting
+func (s *simplifier) annotate(format string, args ...interface{}) {
+	s.annotations = append(s.annotations, fmt.Sprintf(format, args...))
+}
+
+// simplify simplifies the expr, recursion simplify for expr
+func (s *simplifier) simplify(expr Expr) Expr {
+	switch e := expr.(type) {
+	case *EqualsExpr:
+		if val, ok := foldEquals(e); ok {
+			return &BoolLiteral{Val: val}
+		}
+		return e
+	case *ParenExpr:
+		inner := s.simplify(e.Expr)
+		switch inner.(type) {
+		case *BoolLiteral, *ParenExpr:
+			return inner
+		}
+		return &ParenExpr{Expr: inner}
+	case *NotExpr:
+		return negate(s.simplify(e.Expr))
+	case *BinaryExpr:
+		if e.Operator != AND && e.Operator != OR {
+			return e
+		}
+		return s.simplifyBinary(e)
+	default:
+		return expr
+	}
+}
+
+// simplifyBinary simplifies the and/or binary expr,
+// flattens the same operator's operands, then drops the identity constants and duplicate predicates.
+func (s *simplifier) simplifyBinary(expr *BinaryExpr) Expr {
+	var operands []Expr
+	s.flattenOperands(expr, expr.Operator, &operands)
+
+	var result []Expr
+	for _, operand := range operands {
+		if b, ok := operand.(*BoolLiteral); ok {
+			// true short-circuits or, false short-circuits and
+			if b.Val == (expr.Operator == OR) {
+				return b
+			}
+			// true in and, false in or, just drop it
+			continue
+		}
+		if containsExpr(result, operand) {
+			// duplicate predicate, structurally equal(like in values with different order)
+			continue
+		}
+		result = append(result, operand)
+	}
+	if len(result) == 0 {
+		// all operands are identity constants
+		return &BoolLiteral{Val: expr.Operator == AND}
+	}
+	switch expr.Operator {
+	case OR:
+		if in, ok := collapseEquals(result); ok {
+			s.annotate("duplicate tag key: %s, collapses equality predicates into %s", in.Key, in.Rewrite())
+			return in
+		}
+	case AND:
+		if left, right, ok := findContradiction(result); ok {
+			s.annotate("duplicate tag key: %s, %s and %s are contradictory, no series matched",
+				left.Key, left.Rewrite(), right.Rewrite())
+			return &BoolLiteral{Val: false}
+		}
+	}
+	simplified := result[0]
+	for _, operand := range result[1:] {
+		simplified = &BinaryExpr{Left: simplified, Operator: expr.Operator, Right: operand}
+	}
+	return simplified
+}
+
+// containsExpr returns if the exprs contains the expr which is structurally equal to given expr
+func containsExpr(exprs []Expr, expr Expr) bool {
+	for _, e := range exprs {
+		if e.Equal(expr) {
+			return true
+		}
+	}
+	return false
+}
+
+// collapseEquals collapses the operands of or expression into in expr,
+// only if every operand is an equality predicate on the same tag key.
+func collapseEquals(operands []Expr) (*InExpr, bool) {
+	if len(operands) < 2 {
+		return nil, false
+	}
+	in := &InExpr{}
+	for _, operand := range operands {
+		equals, ok := operand.(*EqualsExpr)
+		if !ok {
+			return nil, false
+		}
+		if in.Key == "" {
+			in.Key = equals.Key
+		} else if in.Key != equals.Key {
+			return nil, false
+		}
+		in.Values = append(in.Values, equals.Value)
+	}
+	return in, true
+}
+
+// findContradiction finds the equality predicates on the same tag key with different values in the operands
+// of and expression, which cannot be matched by any series.
+func findContradiction(operands []Expr) (left, right *EqualsExpr, ok bool) {
+	equalities := make(map[string]*EqualsExpr)
+	for _, operand := range operands {
+		equals, ok := operand.(*EqualsExpr)
+		if !ok {
+			continue
+		}
+		if prev, exist := equalities[equals.Key]; exist && prev.Value != equals.Value {
+			return prev, equals, true
+		}
+		equalities[equals.Key] = equals
+	}
+	return nil, nil, false
+}
+
+// flattenOperands collects the simplified operands of the nested binary expr which has same operator,
+// keeps the parenthesized expr as one operand.
+func (s *simplifier) flattenOperands(expr Expr, operator BinaryOP, operands *[]Expr) {
+	if binary, ok := expr.(*BinaryExpr); ok && binary.Operator == operator {
+		s.flattenOperands(binary.Left, operator, operands)
+		s.flattenOperands(binary.Right, operator, operands)
+		return
+	}
+	*operands = append(*operands, s.simplify(expr))
+}
+
+// foldEquals folds the equals expr which compares two number literals, like 1=1,
+// only the expr marked as literal by parser is constant, tag key/value which looks like number is kept(like '200'='200').
+func foldEquals(expr *EqualsExpr) (val, ok bool) {
+	if !expr.Literal {
+		return false, false
+	}
+	left, err := strconv.ParseFloat(expr.Key, 64)
+	if err != nil {
+		return false, false
+	}
+	right, err := strconv.ParseFloat(expr.Value, 64)
+	if err != nil {
+		return false, false
+	}
+	return left == right, true
+}
+
+// negate negates the simplified expr, pushes negation down into the and/or expression by De Morgan,
+// so that negation is only applied on tag filter which can be resolved by and not operation.
+func negate(expr Expr) Expr {
+	switch e := expr.(type) {
+	case *BoolLiteral:
+		return &BoolLiteral{Val: !e.Val}
+	case *NotExpr:
+		// not (not a) => a
+		return e.Expr
+	case *ParenExpr:
+		return negate(e.Expr)
+	case *BinaryExpr:
+		if e.Operator == AND || e.Operator == OR {
+			return &ParenExpr{Expr: negateBinary(e, e.Operator)}
+		}
+	}
+	return &NotExpr{Expr: expr}
+}
+
+// negateBinary negates the and/or expression, not (a and b) => not a or not b, not (a or b) => not a and not b,
+// keeps the nested expression which has same operator flat.
+func negateBinary(expr Expr, operator BinaryOP) Expr {
+	binary, ok := expr.(*BinaryExpr)
+	if !ok || binary.Operator != operator {
+		return negate(expr)
+	}
+	negated := OR
+	if operator == OR {
+		negated = AND
+	}
+	return &BinaryExpr{
+		Left:     negateBinary(binary.Left, operator),
+		Operator: negated,
+		Right:    negateBinary(binary.Right, operator),
+	}
+}
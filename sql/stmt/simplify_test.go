@@ -0,0 +1,218 @@
+package stmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplify_Constant(t *testing.T) {
+	alwaysTrue := &EqualsExpr{Key: "1", Value: "1", Literal: true}
+	alwaysFalse := &EqualsExpr{Key: "1", Value: "0", Literal: true}
+	host := &EqualsExpr{Key: "host", Value: "web-01"}
+
+	assert.Nil(t, Simplify(nil))
+	assert.Equal(t, host, Simplify(host))
+	// 1=1 => true, no condition
+	assert.Nil(t, Simplify(alwaysTrue))
+	assert.Nil(t, Simplify(&ParenExpr{Expr: alwaysTrue}))
+	assert.Nil(t, Simplify(&NotExpr{Expr: alwaysFalse}))
+	assert.Nil(t, Simplify(&EqualsExpr{Key: "1.0", Value: "1", Literal: true}))
+	// 1=0 => false
+	assert.Equal(t, &BoolLiteral{Val: false}, Simplify(alwaysFalse))
+	assert.Equal(t, &BoolLiteral{Val: false}, Simplify(&NotExpr{Expr: alwaysTrue}))
+	// 1=1 and host='web-01' => host='web-01'
+	assert.Equal(t, host, Simplify(&BinaryExpr{Left: alwaysTrue, Operator: AND, Right: host}))
+	// 1=1 or host='web-01' => true
+	assert.Nil(t, Simplify(&BinaryExpr{Left: host, Operator: OR, Right: alwaysTrue}))
+	// 1=0 and host='web-01' => false
+	assert.Equal(t, &BoolLiteral{Val: false}, Simplify(&BinaryExpr{Left: host, Operator: AND, Right: alwaysFalse}))
+	// 1=0 or host='web-01' => host='web-01'
+	assert.Equal(t, host, Simplify(&BinaryExpr{Left: alwaysFalse, Operator: OR, Right: host}))
+	// 1=0 or 1=0 => false
+	assert.Equal(t, &BoolLiteral{Val: false}, Simplify(&BinaryExpr{Left: alwaysFalse, Operator: OR, Right: alwaysFalse}))
+	// (1=0 or host='web-01') and 1=1 => (host='web-01')
+	assert.Equal(t, &ParenExpr{Expr: host}, Simplify(&BinaryExpr{
+		Left:     &ParenExpr{Expr: &BinaryExpr{Left: alwaysFalse, Operator: OR, Right: host}},
+		Operator: AND,
+		Right:    alwaysTrue,
+	}))
+	// not (1=0 or host='web-01') => not host='web-01'
+	assert.Equal(t, &NotExpr{Expr: host}, Simplify(&NotExpr{
+		Expr: &ParenExpr{Expr: &BinaryExpr{Left: alwaysFalse, Operator: OR, Right: host}},
+	}))
+}
+
+func TestSimplify_NotLiteral(t *testing.T) {
+	// tag key/value which looks like number isn't folded without literal flag
+	for _, key := range []string{"inf", "nan", "infinity", "1e3", "0x1p-2", "200"} {
+		expr := &EqualsExpr{Key: key, Value: key}
+		assert.Equal(t, expr, Simplify(expr))
+	}
+	expr := &EqualsExpr{Key: "1", Value: "a", Literal: true}
+	assert.Equal(t, expr, Simplify(expr))
+}
+
+func TestSimplify_Duplicate(t *testing.T) {
+	hostA := &EqualsExpr{Key: "host", Value: "a"}
+	hostB := &EqualsExpr{Key: "host", Value: "b"}
+	region := &InExpr{Key: "region", Values: []string{"sh", "bj"}}
+
+	// host='a' or host='a' => host='a'
+	assert.Equal(t, hostA, Simplify(&BinaryExpr{Left: hostA, Operator: OR, Right: &EqualsExpr{Key: "host", Value: "a"}}))
+	// host='a' and host='a' => host='a'
+	assert.Equal(t, hostA, Simplify(&BinaryExpr{Left: hostA, Operator: AND, Right: hostA}))
+	// host='a' or host='b' or host='a' => host in (a,b)
+	assert.Equal(t, &InExpr{Key: "host", Values: []string{"a", "b"}},
+		Simplify(&BinaryExpr{
+			Left:     &BinaryExpr{Left: hostA, Operator: OR, Right: hostB},
+			Operator: OR,
+			Right:    hostA,
+		}))
+	// region in (sh,bj) and (host='a' or host='a') and region in (sh,bj) => region in (sh,bj) and (host='a')
+	assert.Equal(t, &BinaryExpr{Left: region, Operator: AND, Right: &ParenExpr{Expr: hostA}},
+		Simplify(&BinaryExpr{
+			Left: &BinaryExpr{
+				Left:     region,
+				Operator: AND,
+				Right:    &ParenExpr{Expr: &BinaryExpr{Left: hostA, Operator: OR, Right: hostA}},
+			},
+			Operator: AND,
+			Right:    region,
+		}))
+	// region in (sh,bj) and region in (bj,sh) => region in (sh,bj)
+	assert.Equal(t, region, Simplify(&BinaryExpr{
+		Left:     region,
+		Operator: AND,
+		Right:    &InExpr{Key: "region", Values: []string{"bj", "sh"}},
+	}))
+	// different operator keeps both
+	expr := &BinaryExpr{
+		Left:     &ParenExpr{Expr: &BinaryExpr{Left: hostA, Operator: OR, Right: region}},
+		Operator: AND,
+		Right:    hostA,
+	}
+	assert.Equal(t, expr, Simplify(expr))
+	// not expr
+	assert.Equal(t, &NotExpr{Expr: hostA}, Simplify(&BinaryExpr{
+		Left:     &NotExpr{Expr: hostA},
+		Operator: OR,
+		Right:    &NotExpr{Expr: hostA},
+	}))
+}
+
+func TestSimplify_CollapseEquals(t *testing.T) {
+	hostA := &EqualsExpr{Key: "host", Value: "a"}
+	hostB := &EqualsExpr{Key: "host", Value: "b"}
+	hostC := &EqualsExpr{Key: "host", Value: "c"}
+	ipA := &EqualsExpr{Key: "ip", Value: "a"}
+
+	// (host='a' or host='b' or host='c') => (host in (a,b,c))
+	assert.Equal(t, &ParenExpr{Expr: &InExpr{Key: "host", Values: []string{"a", "b", "c"}}},
+		Simplify(&ParenExpr{Expr: &BinaryExpr{
+			Left:     &BinaryExpr{Left: hostA, Operator: OR, Right: hostB},
+			Operator: OR,
+			Right:    hostC,
+		}}))
+	// not (host='a' or host='b') => not host in (a,b)
+	assert.Equal(t, &NotExpr{Expr: &InExpr{Key: "host", Values: []string{"a", "b"}}},
+		Simplify(&NotExpr{Expr: &ParenExpr{Expr: &BinaryExpr{Left: hostA, Operator: OR, Right: hostB}}}))
+	// different tag keys aren't collapsed
+	expr := &BinaryExpr{Left: &BinaryExpr{Left: hostA, Operator: OR, Right: ipA}, Operator: OR, Right: hostB}
+	assert.Equal(t, expr, Simplify(expr))
+	// non equality leaf isn't collapsed
+	expr = &BinaryExpr{Left: hostA, Operator: OR, Right: &LikeExpr{Key: "host", Value: "b*"}}
+	assert.Equal(t, expr, Simplify(expr))
+	expr = &BinaryExpr{Left: hostA, Operator: OR, Right: &NotExpr{Expr: hostB}}
+	assert.Equal(t, expr, Simplify(expr))
+	// and expression isn't collapsed
+	expr = &BinaryExpr{Left: hostA, Operator: AND, Right: &LikeExpr{Key: "host", Value: "b*"}}
+	assert.Equal(t, expr, Simplify(expr))
+
+	condition, annotations := SimplifyWithAnnotations(&BinaryExpr{Left: hostA, Operator: OR, Right: hostB})
+	assert.Equal(t, &InExpr{Key: "host", Values: []string{"a", "b"}}, condition)
+	assert.Equal(t, []string{"duplicate tag key: host, collapses equality predicates into host in (a,b)"}, annotations)
+}
+
+func TestSimplify_Contradiction(t *testing.T) {
+	hostA := &EqualsExpr{Key: "host", Value: "a"}
+	hostB := &EqualsExpr{Key: "host", Value: "b"}
+	ipA := &EqualsExpr{Key: "ip", Value: "a"}
+
+	// host='a' and host='b' => false
+	condition, annotations := SimplifyWithAnnotations(&BinaryExpr{Left: hostA, Operator: AND, Right: hostB})
+	assert.Equal(t, &BoolLiteral{Val: false}, condition)
+	assert.Equal(t, []string{"duplicate tag key: host, host=a and host=b are contradictory, no series matched"}, annotations)
+	// host='a' and ip='a' and host='b' => false
+	assert.Equal(t, &BoolLiteral{Val: false}, Simplify(&BinaryExpr{
+		Left:     &BinaryExpr{Left: hostA, Operator: AND, Right: ipA},
+		Operator: AND,
+		Right:    hostB,
+	}))
+	// (host='a' and host='b') or ip='a' => ip='a'
+	assert.Equal(t, ipA, Simplify(&BinaryExpr{
+		Left:     &ParenExpr{Expr: &BinaryExpr{Left: hostA, Operator: AND, Right: hostB}},
+		Operator: OR,
+		Right:    ipA,
+	}))
+	// same value isn't contradictory
+	condition, annotations = SimplifyWithAnnotations(&BinaryExpr{
+		Left:     hostA,
+		Operator: AND,
+		Right:    &EqualsExpr{Key: "host", Value: "a"},
+	})
+	assert.Equal(t, hostA, condition)
+	assert.Empty(t, annotations)
+	// different tag keys aren't contradictory
+	expr := &BinaryExpr{Left: hostA, Operator: AND, Right: ipA}
+	assert.Equal(t, expr, Simplify(expr))
+	// negation isn't contradictory
+	expr = &BinaryExpr{Left: hostA, Operator: AND, Right: &NotExpr{Expr: hostB}}
+	assert.Equal(t, expr, Simplify(expr))
+}
+
+func TestSimplify_Negate(t *testing.T) {
+	hostA := &EqualsExpr{Key: "host", Value: "a"}
+	hostB := &EqualsExpr{Key: "host", Value: "b"}
+	region := &InExpr{Key: "region", Values: []string{"sh", "bj"}}
+
+	// not (not host='a') => host='a'
+	assert.Equal(t, hostA, Simplify(&NotExpr{Expr: &ParenExpr{Expr: &NotExpr{Expr: hostA}}}))
+	// not (host='a' or ip='b') => (not host='a' and not ip='b')
+	ipB := &EqualsExpr{Key: "ip", Value: "b"}
+	assert.Equal(t, &ParenExpr{Expr: &BinaryExpr{
+		Left:     &NotExpr{Expr: hostA},
+		Operator: AND,
+		Right:    &NotExpr{Expr: ipB},
+	}}, Simplify(&NotExpr{Expr: &ParenExpr{Expr: &BinaryExpr{Left: hostA, Operator: OR, Right: ipB}}}))
+	// not (host='a' and host!='b' and region in (sh,bj)) => (not host='a' or host='b' or not region in (sh,bj))
+	assert.Equal(t, &ParenExpr{Expr: &BinaryExpr{
+		Left:     &BinaryExpr{Left: &NotExpr{Expr: hostA}, Operator: OR, Right: hostB},
+		Operator: OR,
+		Right:    &NotExpr{Expr: region},
+	}}, Simplify(&NotExpr{Expr: &ParenExpr{Expr: &BinaryExpr{
+		Left:     &BinaryExpr{Left: hostA, Operator: AND, Right: &NotExpr{Expr: hostB}},
+		Operator: AND,
+		Right:    region,
+	}}}))
+	// not (host='a' or (host='b' and region in (sh,bj))) => (not host='a' and (not host='b' or not region in (sh,bj)))
+	assert.Equal(t, &ParenExpr{Expr: &BinaryExpr{
+		Left:     &NotExpr{Expr: hostA},
+		Operator: AND,
+		Right: &ParenExpr{Expr: &BinaryExpr{
+			Left:     &NotExpr{Expr: hostB},
+			Operator: OR,
+			Right:    &NotExpr{Expr: region},
+		}},
+	}}, Simplify(&NotExpr{Expr: &ParenExpr{Expr: &BinaryExpr{
+		Left:     hostA,
+		Operator: OR,
+		Right:    &ParenExpr{Expr: &BinaryExpr{Left: hostB, Operator: AND, Right: region}},
+	}}}))
+}
+
+func TestSimplify_Other(t *testing.T) {
+	expr := &BinaryExpr{Left: &FieldExpr{Name: "a"}, Operator: ADD, Right: &NumberLiteral{Val: 1}}
+	assert.Equal(t, expr, Simplify(expr))
+	assert.Equal(t, &LikeExpr{Key: "host", Value: "a*"}, Simplify(&LikeExpr{Key: "host", Value: "a*"}))
+}
@@ -0,0 +1,129 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+// FieldAlias represents the field alias of metric, alias is equivalent to the field name when querying
+type FieldAlias struct {
+	Database   string `json:"database"`
+	Namespace  string `json:"namespace"`
+	MetricName string `json:"metricName"`
+	Alias      string `json:"alias"`
+	FieldName  string `json:"fieldName"`
+}
+
+// FieldAliasAPI represents the field alias admin rest api
+type FieldAliasAPI struct {
+	storageService service.StorageService
+}
+
+// NewFieldAliasAPI creates field alias api instance
+func NewFieldAliasAPI(storageService service.StorageService) *FieldAliasAPI {
+	return &FieldAliasAPI{
+		storageService: storageService,
+	}
+}
+
+// List returns the field aliases of the metric
+func (f *FieldAliasAPI) List(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, err := api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	metricName, err := api.GetParamsFromRequest("metric", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	editor, err := f.getFieldAliasEditor(databaseName)
+	if err != nil {
+		api.NotFound(w)
+		return
+	}
+	aliases, err := editor.GetFieldAliases(namespace, metricName)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, aliases)
+}
+
+// Save sets the field alias of the metric
+func (f *FieldAliasAPI) Save(w http.ResponseWriter, r *http.Request) {
+	fieldAlias := &FieldAlias{}
+	if err := api.GetJSONBodyFromRequest(r, fieldAlias); err != nil {
+		api.Error(w, err)
+		return
+	}
+	if fieldAlias.Namespace == "" {
+		fieldAlias.Namespace = constants.DefaultNamespace
+	}
+	editor, err := f.getFieldAliasEditor(fieldAlias.Database)
+	if err != nil {
+		api.NotFound(w)
+		return
+	}
+	if err := editor.SetFieldAlias(fieldAlias.Namespace, fieldAlias.MetricName,
+		field.Name(fieldAlias.Alias), field.Name(fieldAlias.FieldName)); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// Delete removes the field alias of the metric
+func (f *FieldAliasAPI) Delete(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, err := api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	metricName, err := api.GetParamsFromRequest("metric", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	alias, err := api.GetParamsFromRequest("alias", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	editor, err := f.getFieldAliasEditor(databaseName)
+	if err != nil {
+		api.NotFound(w)
+		return
+	}
+	if err := editor.RemoveFieldAlias(namespace, metricName, field.Name(alias)); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// getFieldAliasEditor returns the field alias editor of the database
+func (f *FieldAliasAPI) getFieldAliasEditor(databaseName string) (metadb.FieldAliasEditor, error) {
+	db, ok := f.storageService.GetDatabase(databaseName)
+	if !ok {
+		return nil, fmt.Errorf("database[%s] not found", databaseName)
+	}
+	return db.Metadata().MetadataDatabase(), nil
+}
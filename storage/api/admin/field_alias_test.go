@@ -0,0 +1,131 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestFieldAliasAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	db := tsdb.NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	storageService.EXPECT().GetDatabase("db").Return(db, true).AnyTimes()
+	storageService.EXPECT().GetDatabase("no-db").Return(nil, false).AnyTimes()
+
+	api := NewFieldAliasAPI(storageService)
+
+	// list: param err
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/database/field/alias?db=db",
+		HandlerFunc:    api.List,
+		ExpectHTTPCode: 500,
+	})
+	// list: db not found
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/database/field/alias?db=no-db&metric=cpu",
+		HandlerFunc:    api.List,
+		ExpectHTTPCode: 404,
+	})
+	// list: err
+	metadataDB.EXPECT().GetFieldAliases("default-ns", "cpu").Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/database/field/alias?db=db&metric=cpu",
+		HandlerFunc:    api.List,
+		ExpectHTTPCode: 500,
+	})
+	// list: ok
+	metadataDB.EXPECT().GetFieldAliases("ns", "cpu").Return(map[field.Name]field.Name{"usage": "usage_pct"}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/database/field/alias?db=db&ns=ns&metric=cpu",
+		HandlerFunc:    api.List,
+		ExpectHTTPCode: 200,
+		ExpectResponse: map[field.Name]field.Name{"usage": "usage_pct"},
+	})
+
+	// save: body err
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/field/alias",
+		RequestBody:    []byte{1, 2, 3},
+		HandlerFunc:    api.Save,
+		ExpectHTTPCode: 500,
+	})
+	// save: db not found
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/field/alias",
+		RequestBody:    FieldAlias{Database: "no-db", MetricName: "cpu", Alias: "usage", FieldName: "usage_pct"},
+		HandlerFunc:    api.Save,
+		ExpectHTTPCode: 404,
+	})
+	// save: err
+	metadataDB.EXPECT().SetFieldAlias("default-ns", "cpu", field.Name("usage"), field.Name("usage_pct")).
+		Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/field/alias",
+		RequestBody:    FieldAlias{Database: "db", MetricName: "cpu", Alias: "usage", FieldName: "usage_pct"},
+		HandlerFunc:    api.Save,
+		ExpectHTTPCode: 500,
+	})
+	// save: ok
+	metadataDB.EXPECT().SetFieldAlias("ns", "cpu", field.Name("usage"), field.Name("usage_pct")).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method: http.MethodPost,
+		URL:    "/database/field/alias",
+		RequestBody: FieldAlias{Database: "db", Namespace: "ns", MetricName: "cpu",
+			Alias: "usage", FieldName: "usage_pct"},
+		HandlerFunc:    api.Save,
+		ExpectHTTPCode: 204,
+	})
+
+	// delete: param err
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/database/field/alias?db=db&metric=cpu",
+		HandlerFunc:    api.Delete,
+		ExpectHTTPCode: 500,
+	})
+	// delete: db not found
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/database/field/alias?db=no-db&metric=cpu&alias=usage",
+		HandlerFunc:    api.Delete,
+		ExpectHTTPCode: 404,
+	})
+	// delete: err
+	metadataDB.EXPECT().RemoveFieldAlias("ns", "cpu", field.Name("usage")).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/database/field/alias?db=db&ns=ns&metric=cpu&alias=usage",
+		HandlerFunc:    api.Delete,
+		ExpectHTTPCode: 500,
+	})
+	// delete: ok
+	metadataDB.EXPECT().RemoveFieldAlias("ns", "cpu", field.Name("usage")).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/database/field/alias?db=db&ns=ns&metric=cpu&alias=usage",
+		HandlerFunc:    api.Delete,
+		ExpectHTTPCode: 204,
+	})
+}
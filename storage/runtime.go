@@ -13,6 +13,7 @@ import (
 	dto "github.com/prometheus/client_model/go"
 	promreporter "github.com/uber-go/tally/prometheus"
 
+	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
@@ -24,14 +25,17 @@ import (
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/server"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/strutil"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/query"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/rpc/proto/storage"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/storage/api/admin"
 	"github.com/lindb/lindb/storage/handler"
 	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
 )
 
 // srv represents all dependency services
@@ -107,6 +111,16 @@ func (r *runtime) Run() error {
 		return fmt.Errorf("cannot get server ip address, error:%s", err)
 	}
 
+	// the query limits(nan policy/index breaker/admission etc.) are passed to executor factory and task dispatcher
+	queryCfg := r.config.StorageBase.Query
+	if _, err := aggregation.ParseNaNPolicy(queryCfg.NaNPolicy); err != nil {
+		r.state = server.Failed
+		return err
+	}
+	metricsdata.SetReadAhead(queryCfg.ReadAheadBlocks, int64(queryCfg.ReadAheadBudget))
+	strutil.SetMaxRegexCost(queryCfg.MaxRegexCost)
+	strutil.SetRegexTimeout(queryCfg.RegexTimeout.Duration())
+
 	// build service dependency for storage server
 	if err := r.buildServiceDependency(); err != nil {
 		r.state = server.Failed
@@ -244,6 +258,11 @@ func (r *runtime) startHTTPServer() {
 	reporter := promreporter.NewReporter(promreporter.Options{})
 	router := mux.NewRouter().StrictSlash(true)
 	router.Handle("/metrics", reporter.HTTPHandler())
+	// add admin api
+	fieldAliasAPI := admin.NewFieldAliasAPI(r.srv.storageService)
+	router.HandleFunc("/database/field/alias", fieldAliasAPI.List).Methods(http.MethodGet)
+	router.HandleFunc("/database/field/alias", fieldAliasAPI.Save).Methods(http.MethodPost)
+	router.HandleFunc("/database/field/alias", fieldAliasAPI.Delete).Methods(http.MethodDelete)
 
 	r.httpServer = &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -278,7 +297,7 @@ func (r *runtime) startTCPServer() {
 func (r *runtime) bindRPCHandlers() {
 	//FIXME: (stone1100) need close
 	dispatcher := taskHandler.NewLeafTaskDispatcher(r.node, r.srv.storageService,
-		query.NewExecutorFactory(), r.factory.taskServer)
+		query.NewStorageExecutorFactory(r.config.StorageBase.Query), r.factory.taskServer, r.config.StorageBase.Query)
 
 	r.handler = &rpcHandler{
 		writer: handler.NewWriter(r.srv.storageService),
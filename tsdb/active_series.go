@@ -0,0 +1,57 @@
+package tsdb
+
+import (
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+)
+
+// GetSeriesIDsForTagInTimeRange returns the series ids of tag key which have data of any field in time range,
+// the series only has data out of time range is excluded, though it is in the index of tag key.
+func (s *shard) GetSeriesIDsForTagInTimeRange(metricID, tagKeyID uint32,
+	timeRange timeutil.TimeRange,
+) (*roaring.Bitmap, error) {
+	fields, err := s.metadata.MetadataDatabase().GetAllFieldsByMetricID(metricID)
+	if err != nil {
+		return nil, err
+	}
+	seriesIDs, err := s.indexDB.GetSeriesIDsForTag(tagKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if seriesIDs.IsEmpty() {
+		return seriesIDs, nil
+	}
+	return findSeriesIDsWithData(s.getDataFilters(timeRange), metricID, fields, seriesIDs, timeRange)
+}
+
+// findSeriesIDsWithData returns the series ids which have data of any field in data filters
+func findSeriesIDsWithData(
+	filters []flow.DataFilter,
+	metricID uint32,
+	fields []field.Meta,
+	seriesIDs *roaring.Bitmap,
+	timeRange timeutil.TimeRange,
+) (*roaring.Bitmap, error) {
+	result := roaring.New()
+	if len(fields) == 0 {
+		return result, nil
+	}
+	fieldIDs := make([]field.ID, len(fields))
+	for idx, f := range fields {
+		fieldIDs[idx] = f.ID
+	}
+	for _, filter := range filters {
+		resultSets, err := filter.Filter(metricID, fieldIDs, seriesIDs, timeRange)
+		if err != nil && err != constants.ErrNotFound {
+			return nil, err
+		}
+		for _, rs := range resultSets {
+			result.Or(rs.SeriesIDs())
+		}
+	}
+	return result, nil
+}
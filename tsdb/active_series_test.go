@@ -0,0 +1,97 @@
+package tsdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/memdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestShard_GetSeriesIDsForTagInTimeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fullRange := timeutil.TimeRange{Start: 0, End: 2 * timeutil.OneHour}
+	window := timeutil.TimeRange{Start: timeutil.OneHour, End: 2 * timeutil.OneHour}
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	family1 := NewMockDataFamily(ctrl)
+	family2 := NewMockDataFamily(ctrl)
+	mutable := memdb.NewMockMemoryDatabase(ctrl)
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().getDataFamilies(fullRange).Return([]DataFamily{family1, family2}).AnyTimes()
+	segment.EXPECT().getDataFamilies(window).Return([]DataFamily{family2}).AnyTimes()
+	s := &shard{
+		interval: timeutil.Interval(10 * timeutil.OneSecond),
+		segments: map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment},
+		mutable:  mutable,
+		metadata: metadata,
+		indexDB:  indexDB,
+	}
+
+	// case 1: get fields err
+	metadataDB.EXPECT().GetAllFieldsByMetricID(uint32(10)).Return(nil, fmt.Errorf("err"))
+	seriesIDs, err := s.GetSeriesIDsForTagInTimeRange(10, 1, window)
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 2: get series ids of tag err
+	metadataDB.EXPECT().GetAllFieldsByMetricID(uint32(10)).Return([]field.Meta{{ID: 1}}, nil).AnyTimes()
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(nil, fmt.Errorf("err"))
+	seriesIDs, err = s.GetSeriesIDsForTagInTimeRange(10, 1, window)
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 3: tag key has no series
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(roaring.New(), nil)
+	seriesIDs, err = s.GetSeriesIDsForTagInTimeRange(10, 1, window)
+	assert.NoError(t, err)
+	assert.True(t, seriesIDs.IsEmpty())
+
+	// series 1 only has data in the first hour, series 2 and 3 have data in the window
+	tagSeriesIDs := roaring.BitmapOf(1, 2, 3, 4)
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(tagSeriesIDs, nil).AnyTimes()
+	rs1 := flow.NewMockFilterResultSet(ctrl)
+	rs1.EXPECT().SeriesIDs().Return(roaring.BitmapOf(1, 2)).AnyTimes()
+	rs2 := flow.NewMockFilterResultSet(ctrl)
+	rs2.EXPECT().SeriesIDs().Return(roaring.BitmapOf(2)).AnyTimes()
+	memRS := flow.NewMockFilterResultSet(ctrl)
+	memRS.EXPECT().SeriesIDs().Return(roaring.BitmapOf(3)).AnyTimes()
+	family1.EXPECT().Filter(uint32(10), []field.ID{1}, tagSeriesIDs, fullRange).
+		Return([]flow.FilterResultSet{rs1}, nil).AnyTimes()
+	family2.EXPECT().Filter(uint32(10), []field.ID{1}, tagSeriesIDs, gomock.Any()).
+		Return([]flow.FilterResultSet{rs2}, nil).AnyTimes()
+	mutable.EXPECT().Filter(uint32(10), []field.ID{1}, tagSeriesIDs, gomock.Any()).
+		Return([]flow.FilterResultSet{memRS}, nil).AnyTimes()
+	// case 4: series 1 is active in the full range
+	seriesIDs, err = s.GetSeriesIDsForTagInTimeRange(10, 1, fullRange)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2, 3}, seriesIDs.ToArray())
+	// case 5: series 1 isn't active in the window
+	seriesIDs, err = s.GetSeriesIDsForTagInTimeRange(10, 1, window)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{2, 3}, seriesIDs.ToArray())
+	// case 6: filter err
+	mutable2 := memdb.NewMockMemoryDatabase(ctrl)
+	mutable2.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	s.mutable = mutable2
+	seriesIDs, err = s.GetSeriesIDsForTagInTimeRange(10, 1, window)
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 7: no data found
+	mutable2.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, constants.ErrNotFound)
+	s.segments = nil
+	seriesIDs, err = s.GetSeriesIDsForTagInTimeRange(10, 1, window)
+	assert.NoError(t, err)
+	assert.True(t, seriesIDs.IsEmpty())
+}
@@ -12,6 +12,7 @@ import (
 
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/pkg/concurrent"
 	"github.com/lindb/lindb/pkg/logger"
@@ -56,6 +57,8 @@ type Database interface {
 	io.Closer
 	// Metadata returns the metadata include metric/tag
 	Metadata() metadb.Metadata
+	// CodecRegistry returns the registry of value codec used when marshals field data
+	CodecRegistry() *aggregation.CodecRegistry
 	// FlushMeta flushes meta to disk
 	FlushMeta() error
 	// FLush flushes memory data of all shards to disk
@@ -82,6 +85,7 @@ type database struct {
 	metaStore    kv.Store        // underlying meta kv store
 	isFlushing   atomic.Bool     // restrict flusher concurrency
 
+	codecs       *aggregation.CodecRegistry // value codec registry of database
 	flushChecker DataFlushChecker
 }
 
@@ -95,6 +99,7 @@ func newDatabase(databaseName string, databasePath string, cfg *databaseConfig,
 		flushChecker: flushChecker,
 		config:       cfg,
 		numOfShards:  *atomic.NewInt32(0),
+		codecs:       aggregation.NewCodecRegistry(),
 		executorPool: &ExecutorPool{
 			Filtering: concurrent.NewPool(
 				databaseName+"-filtering-pool",
@@ -151,6 +156,11 @@ func (db *database) Metadata() metadb.Metadata {
 	return db.metadata
 }
 
+// CodecRegistry returns the registry of value codec used when marshals field data
+func (db *database) CodecRegistry() *aggregation.CodecRegistry {
+	return db.codecs
+}
+
 func (db *database) Name() string {
 	return db.name
 }
@@ -171,6 +181,11 @@ func (db *database) CreateShards(
 	if len(shardIDs) == 0 {
 		return fmt.Errorf("shardIDs list is empty")
 	}
+	if db.NumOfShards() > 0 && !db.config.Option.IsSameTagsHasher(option) {
+		// the series ids of existing shards are assigned by the groups of current tags hasher
+		return fmt.Errorf("cannot change tags hasher of database[%s] from [%s] to [%s] after database has data",
+			db.name, db.config.Option.TagsHasher, option.TagsHasher)
+	}
 	for _, shardID := range shardIDs {
 		_, ok := db.GetShard(shardID)
 		if ok {
@@ -13,6 +13,7 @@ import (
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/tsdb/metadb"
 )
 
@@ -88,6 +89,7 @@ func TestDatabase_New(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, db)
 	assert.NotNil(t, db.ExecutorPool())
+	assert.NotNil(t, db.CodecRegistry())
 	assert.Equal(t, option.DatabaseOption{Interval: "10s"}, db.GetOption())
 	assert.Equal(t, 3, db.NumOfShards())
 	kvStore.EXPECT().Close().Return(nil).AnyTimes() // include shard close
@@ -157,6 +159,35 @@ func TestDatabase_CreateShards(t *testing.T) {
 	db1 := db.(*database)
 	err = db1.createShard(1, option.DatabaseOption{})
 	assert.NoError(t, err)
+	// case 7: cannot change tags hasher after database has data
+	err = db.CreateShards(option.DatabaseOption{TagsHasher: "grouping:host"}, []int32{10})
+	assert.Error(t, err)
+	_, ok := db.GetShard(10)
+	assert.False(t, ok)
+	err = db.CreateShards(option.DatabaseOption{TagsHasher: tag.XXHasherName}, []int32{1})
+	assert.NoError(t, err)
+}
+
+func TestDatabase_CreateShards_TagsHasher(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		newShardFunc = newShard
+	}()
+	newShardFunc = func(db Database, shardID int32, shardPath string, option option.DatabaseOption) (s Shard, err error) {
+		return nil, nil
+	}
+	// database without shard can choose tags hasher
+	db, err := newDatabase("db", testPath, &databaseConfig{Option: option.DatabaseOption{Interval: "10s"}}, nil)
+	assert.NoError(t, err)
+	err = db.CreateShards(option.DatabaseOption{Interval: "10s", TagsHasher: "grouping:host"}, []int32{1})
+	assert.NoError(t, err)
+	assert.Equal(t, "grouping:host", db.GetOption().TagsHasher)
+	err = db.CreateShards(option.DatabaseOption{Interval: "10s", TagsHasher: "grouping: host"}, []int32{2})
+	assert.NoError(t, err)
+	err = db.CreateShards(option.DatabaseOption{Interval: "10s"}, []int32{3})
+	assert.Error(t, err)
+	assert.Equal(t, 2, db.NumOfShards())
 }
 
 func TestDatabase_Close(t *testing.T) {
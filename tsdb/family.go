@@ -95,6 +95,10 @@ func (f *dataFamily) Filter(metricID uint32, fieldIDs []field.ID,
 		if err != nil {
 			return nil, err
 		}
+		if !f.inTimeRange(r, timeRange) {
+			// skips the metric data outside the query time range, based on the slot range of block footer
+			continue
+		}
 		metricReaders = append(metricReaders, r)
 	}
 	if len(metricReaders) == 0 {
@@ -103,3 +107,13 @@ func (f *dataFamily) Filter(metricID uint32, fieldIDs []field.ID,
 	filter := newFilterFunc(f.timeRange.Start, snapShot, metricReaders)
 	return filter.Filter(fieldIDs, seriesIDs)
 }
+
+// inTimeRange checks if the slot range of metric data overlaps the query time range, the values are not decoded
+func (f *dataFamily) inTimeRange(reader metricsdata.Reader, timeRange timeutil.TimeRange) bool {
+	start, end := reader.GetTimeRange()
+	interval := f.interval.Int64()
+	return timeRange.Overlap(&timeutil.TimeRange{
+		Start: f.timeRange.Start + int64(start)*interval,
+		End:   f.timeRange.Start + int64(end)*interval,
+	})
+}
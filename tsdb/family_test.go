@@ -79,8 +79,11 @@ func TestDataFamily_Filter(t *testing.T) {
 	assert.Nil(t, rs)
 
 	// case 4: normal case
+	metricReader := metricsdata.NewMockReader(ctrl)
+	// slot range: 10+1*10s ~ 10+3*10s
+	metricReader.EXPECT().GetTimeRange().Return(uint16(1), uint16(3)).AnyTimes()
 	newReaderFunc = func(file string, buf []byte) (reader metricsdata.Reader, err error) {
-		return nil, nil
+		return metricReader, nil
 	}
 	filter := metricsdata.NewMockFilter(ctrl)
 	newFilterFunc = func(familyTime int64, snapshot version.Snapshot, readers []metricsdata.Reader) metricsdata.Filter {
@@ -89,6 +92,12 @@ func TestDataFamily_Filter(t *testing.T) {
 	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
 	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, true)
 	filter.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(nil, nil)
-	_, err = dataFamily.Filter(uint32(10), nil, nil, timeutil.TimeRange{})
+	_, err = dataFamily.Filter(uint32(10), nil, nil, timeutil.TimeRange{Start: 10, End: 20 * timeutil.OneSecond})
 	assert.NoError(t, err)
+	// case 5: metric data outside query time range, skips it without filtering
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
+	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, true)
+	rs, err = dataFamily.Filter(uint32(10), nil, nil, timeutil.TimeRange{Start: 40 * timeutil.OneSecond, End: 50 * timeutil.OneSecond})
+	assert.NoError(t, err)
+	assert.Nil(t, rs)
 }
@@ -39,8 +39,10 @@ func (event *mappingEvent) addSeriesID(metricID uint32, tagsHash uint64, seriesI
 		tagsHash: tagsHash,
 		seriesID: seriesID,
 	})
-	// set id sequence directly, because gen series id in order
-	e.metricIDSeq = seriesID
+	// series ids of groups are not generated in order, keeps the max series id as id sequence
+	if seriesID > e.metricIDSeq {
+		e.metricIDSeq = seriesID
+	}
 	event.pending++
 }
 
@@ -17,6 +17,9 @@ func TestMappingEvent(t *testing.T) {
 	assert.Len(t, e.events, 2)
 	assert.Equal(t, []seriesEvent{{seriesID: 100, tagsHash: 10}, {seriesID: 120, tagsHash: 20}}, e.events[1].events)
 	assert.Equal(t, uint32(120), e.events[1].metricIDSeq)
+	// series ids of groups are not in order, keeps the max series id
+	e.addSeriesID(1, 50, 110)
+	assert.Equal(t, uint32(120), e.events[1].metricIDSeq)
 	assert.Equal(t, []seriesEvent{{seriesID: 100, tagsHash: 30}, {seriesID: 200, tagsHash: 40}}, e.events[2].events)
 	assert.Equal(t, uint32(200), e.events[2].metricIDSeq)
 	assert.False(t, e.isEmpty())
@@ -3,6 +3,7 @@ package indexdb
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/metadb"
 	"github.com/lindb/lindb/tsdb/wal"
 )
@@ -136,9 +138,10 @@ func (db *indexDatabase) GetGroupingContext(tagKeyIDs []uint32, seriesIDs *roari
 }
 
 // GetOrCreateSeriesID gets series by tags hash, if not exist generate new series id in memory,
+// the series of same group(not 0) are assigned adjacent series ids,
 // if generate a new series id returns isCreate is true
 // if generate fail return err
-func (db *indexDatabase) GetOrCreateSeriesID(metricID uint32, tagsHash uint64,
+func (db *indexDatabase) GetOrCreateSeriesID(metricID uint32, tagsHash, group uint64,
 ) (seriesID uint32, isCreated bool, err error) {
 	db.rwMutex.Lock()
 	defer db.rwMutex.Unlock()
@@ -179,7 +182,7 @@ func (db *indexDatabase) GetOrCreateSeriesID(metricID uint32, tagsHash uint64,
 		return 0, false, err
 	}
 	// generate new series id
-	seriesID = metricIDMapping.GenSeriesID(tagsHash)
+	seriesID = metricIDMapping.GenSeriesID(tagsHash, group)
 
 	// append to wal
 	if err = db.seriesWAL.Append(metricID, tagsHash, seriesID); err != nil {
@@ -195,6 +198,14 @@ func (db *indexDatabase) GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueIDs
 	return db.index.GetSeriesIDsByTagValueIDs(tagKeyID, tagValueIDs)
 }
 
+// GetSeriesIDsForTagValues gets series ids which match all tag value ids of the tag keys
+func (db *indexDatabase) GetSeriesIDsForTagValues(
+	tagKeyIDs []uint32,
+	tagValueIDs []*roaring.Bitmap,
+) (*roaring.Bitmap, error) {
+	return db.index.GetSeriesIDsForTagValues(tagKeyIDs, tagValueIDs)
+}
+
 // GetSeriesIDsForTag gets series ids for spec metric's tag key
 func (db *indexDatabase) GetSeriesIDsForTag(tagKeyID uint32) (*roaring.Bitmap, error) {
 	return db.index.GetSeriesIDsForTag(tagKeyID)
@@ -228,6 +239,84 @@ func (db *indexDatabase) BuildInvertIndex(namespace, metricName string, tags map
 	buildInvertedIndexCounter.WithLabelValues(db.metadata.DatabaseName()).Inc()
 }
 
+// RenameTagKey migrates the inverted index of tag key(from) to tag key(to) under metric,
+// so that the query on tag key(to) includes the series tagged with tag key(from), without rewriting series data.
+// both tag keys must exist, if series has both tag keys, keeps the tag value of tag key(to).
+func (db *indexDatabase) RenameTagKey(metricID uint32, from, to string) error {
+	if from == to {
+		return nil
+	}
+	metadataDB := db.metadata.MetadataDatabase()
+	fromTagKeyID, err := metadataDB.GetTagKeyIDByMetricID(metricID, from)
+	if err != nil {
+		return fmt.Errorf("get tag key id for %s err: %w", from, err)
+	}
+	toTagKeyID, err := metadataDB.GetTagKeyIDByMetricID(metricID, to)
+	if err != nil {
+		return fmt.Errorf("get tag key id for %s err: %w", to, err)
+	}
+	if err := db.index.renameTagKey(fromTagKeyID, toTagKeyID); err != nil {
+		return err
+	}
+	indexLogger.Info("rename tag key for metric",
+		logger.String("db", db.path), logger.Uint32("metricID", metricID),
+		logger.String("from", from), logger.String("to", to))
+	return nil
+}
+
+// EstimateSeries estimates the upper bound of series under metric which match the tag filter condition,
+// based on the series of tag keys in condition, without matching tag values(e.g. evaluating regex).
+func (db *indexDatabase) EstimateSeries(namespace, metricName string, condition stmt.Expr) uint64 {
+	seriesIDs, err := db.GetSeriesIDsForMetric(namespace, metricName)
+	if err != nil {
+		return 0
+	}
+	total := seriesIDs.GetCardinality()
+	if condition == nil {
+		return total
+	}
+	return db.estimateCondition(namespace, metricName, condition, total)
+}
+
+// estimateCondition estimates the series which match the condition, total is the series of metric,
+// 1) tag filter => series of tag key
+// 2) and => min(left, right)
+// 3) or => min(left + right, total)
+// 4) not => total, because the series without tag key also match
+func (db *indexDatabase) estimateCondition(namespace, metricName string, condition stmt.Expr, total uint64) uint64 {
+	switch expr := condition.(type) {
+	case stmt.TagFilter:
+		tagKeyID, err := db.metadata.MetadataDatabase().GetTagKeyID(namespace, metricName, expr.TagKey())
+		if err != nil {
+			return 0
+		}
+		seriesIDs, err := db.index.GetSeriesIDsForTag(tagKeyID)
+		if err != nil {
+			return 0
+		}
+		return minSeries(seriesIDs.GetCardinality(), total)
+	case *stmt.ParenExpr:
+		return db.estimateCondition(namespace, metricName, expr.Expr, total)
+	case *stmt.BinaryExpr:
+		left := db.estimateCondition(namespace, metricName, expr.Left, total)
+		right := db.estimateCondition(namespace, metricName, expr.Right, total)
+		if expr.Operator == stmt.AND {
+			return minSeries(left, right)
+		}
+		return minSeries(left+right, total)
+	default:
+		return total
+	}
+}
+
+// minSeries returns the smaller num. of series
+func minSeries(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 // Flush flushes index data to disk
 func (db *indexDatabase) Flush() error {
 	if err := db.seriesWAL.Sync(); err != nil {
@@ -2,6 +2,7 @@ package indexdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -11,9 +12,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/metadb"
 	"github.com/lindb/lindb/tsdb/wal"
 )
@@ -136,7 +139,7 @@ func TestIndexDatabase_series_Recovery_err(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, db)
 	for i := 0; i < 11000; i++ {
-		_, isCreated, err := db.GetOrCreateSeriesID(1, uint64(i))
+		_, isCreated, err := db.GetOrCreateSeriesID(1, uint64(i), 0)
 		assert.NoError(t, err)
 		assert.True(t, isCreated)
 	}
@@ -160,7 +163,7 @@ func TestIndexDatabase_series_Recovery_err(t *testing.T) {
 	assert.NotNil(t, db)
 
 	for i := 0; i < 100; i++ {
-		_, isCreated, err := db.GetOrCreateSeriesID(1, uint64(1000000+i))
+		_, isCreated, err := db.GetOrCreateSeriesID(1, uint64(1000000+i), 0)
 		assert.NoError(t, err)
 		assert.True(t, isCreated)
 	}
@@ -189,17 +192,17 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
 	assert.NoError(t, err)
 	// case 1: generate new series id and create new metric id mapping
-	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 10)
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 10, 0)
 	assert.NoError(t, err)
 	assert.True(t, isCreated)
 	assert.Equal(t, uint32(1), seriesID)
 	// case 2: get series id from memory
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 10)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 10, 0)
 	assert.NoError(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(1), seriesID)
 	// case 3: generate new series id from memory
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20, 0)
 	assert.NoError(t, err)
 	assert.True(t, isCreated)
 	assert.Equal(t, uint32(2), seriesID)
@@ -211,12 +214,12 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
 	assert.NoError(t, err)
 	// case 4: get series id from backend
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20, 0)
 	assert.NoError(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(2), seriesID)
 	// case 5: gen series id, id sequence reset from backend
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30, 0)
 	assert.NoError(t, err)
 	assert.True(t, isCreated)
 	assert.Equal(t, uint32(3), seriesID)
@@ -226,13 +229,13 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	oldWAL := db1.seriesWAL
 	db1.seriesWAL = mockSeriesWAl
 	mockSeriesWAl.EXPECT().Append(uint32(1), uint64(50), uint32(4)).Return(fmt.Errorf("err"))
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 50)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 50, 0)
 	assert.Error(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(0), seriesID)
 	// add use series id => 4
 	db1.seriesWAL = oldWAL
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 50)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 50, 0)
 	assert.NoError(t, err)
 	assert.True(t, isCreated)
 	assert.Equal(t, uint32(4), seriesID)
@@ -242,6 +245,43 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIndexDatabase_GetOrCreateSeriesID_hasher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	hasher := tag.NewGroupingHasher("host")
+	tagsList := []map[string]string{
+		{"host": "a", "disk": "/data"},
+		{"host": "b", "disk": "/data"},
+		{"host": "a", "disk": "/home"},
+		{"disk": "/tmp"},
+	}
+	// series of same host are assigned adjacent series ids, series without group are assigned by sequence
+	for idx, expect := range []uint32{1, 257, 2, 513} {
+		tags := tagsList[idx]
+		seriesID, isCreated, err := db.GetOrCreateSeriesID(1, hasher.Hash(tags), hasher.Group(tags))
+		assert.NoError(t, err)
+		assert.True(t, isCreated)
+		assert.Equal(t, expect, seriesID)
+	}
+	// same tag set returns the same series id
+	tags := map[string]string{"disk": "/home", "host": "a"}
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, hasher.Hash(tags), hasher.Group(tags))
+	assert.NoError(t, err)
+	assert.False(t, isCreated)
+	assert.Equal(t, uint32(2), seriesID)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
 func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -264,7 +304,7 @@ func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	assert.NoError(t, err)
 	// case 1: load metric mapping err
 	backend.EXPECT().loadMetricIDMapping(uint32(1)).Return(nil, fmt.Errorf("err"))
-	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 30)
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 30, 0)
 	assert.Error(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(0), seriesID)
@@ -272,7 +312,7 @@ func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	// case 2: load series err
 	backend.EXPECT().loadMetricIDMapping(uint32(1)).Return(newMetricIDMapping(1, 0), nil)
 	backend.EXPECT().getSeriesID(uint32(1), uint64(30)).Return(uint32(0), fmt.Errorf("err"))
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30, 0)
 	assert.Error(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(0), seriesID)
@@ -334,6 +374,12 @@ func TestIndexDatabase_GetSeriesIDs(t *testing.T) {
 	seriesIDs, err = db.GetSeriesIDsByTagValueIDs(1, roaring.BitmapOf(1, 2, 3))
 	assert.NoError(t, err)
 	assert.NotNil(t, seriesIDs)
+	// case 2.1: get series ids by multi tag value ids
+	index.EXPECT().GetSeriesIDsForTagValues([]uint32{1, 2}, []*roaring.Bitmap{roaring.BitmapOf(1), roaring.BitmapOf(2)}).
+		Return(roaring.BitmapOf(2), nil)
+	seriesIDs, err = db.GetSeriesIDsForTagValues([]uint32{1, 2}, []*roaring.Bitmap{roaring.BitmapOf(1), roaring.BitmapOf(2)})
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(2), seriesIDs)
 	// case 3: get tags err
 	metaDB.EXPECT().GetAllTagKeys(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	seriesIDs, err = db.GetSeriesIDsForMetric("ns", "name")
@@ -356,6 +402,104 @@ func TestIndexDatabase_GetSeriesIDs(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIndexDatabase_EstimateSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	index := NewMockInvertedIndex(ctrl)
+	metaDB := metadb.NewMockMetadataDatabase(ctrl)
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test")
+	meta.EXPECT().MetadataDatabase().Return(metaDB).AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	db2 := db.(*indexDatabase)
+	db2.index = index
+	db2.metadata = meta
+
+	metaDB.EXPECT().GetAllTagKeys("ns", "cpu").Return([]tag.Meta{{ID: 1}, {ID: 2}}, nil).AnyTimes()
+	index.EXPECT().GetSeriesIDsForTags([]uint32{1, 2}).Return(roaring.BitmapOf(1, 2, 3, 4, 5, 6), nil).AnyTimes()
+	metaDB.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(1), nil).AnyTimes()
+	metaDB.EXPECT().GetTagKeyID("ns", "cpu", "zone").Return(uint32(2), nil).AnyTimes()
+	metaDB.EXPECT().GetTagKeyID("ns", "cpu", "ip").Return(uint32(0), constants.ErrNotFound).AnyTimes()
+	index.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(roaring.BitmapOf(1, 2, 3, 4), nil).AnyTimes()
+	index.EXPECT().GetSeriesIDsForTag(uint32(2)).Return(roaring.BitmapOf(5, 6), nil).AnyTimes()
+
+	host := &stmt.RegexExpr{Key: "host", Regexp: "a.*"}
+	zone := &stmt.EqualsExpr{Key: "zone", Value: "sh"}
+	cases := []struct {
+		name      string
+		condition stmt.Expr
+		series    uint64
+	}{
+		{name: "no condition", series: 6},
+		{name: "tag filter", condition: host, series: 4},
+		{name: "tag key not exist", condition: &stmt.EqualsExpr{Key: "ip", Value: "1.1.1.1"}},
+		{name: "and", condition: &stmt.BinaryExpr{Left: host, Operator: stmt.AND, Right: zone}, series: 2},
+		{name: "or", condition: &stmt.ParenExpr{Expr: &stmt.BinaryExpr{Left: host, Operator: stmt.OR, Right: zone}}, series: 6},
+		{name: "not", condition: &stmt.NotExpr{Expr: zone}, series: 6},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.series, db.EstimateSeries("ns", "cpu", tt.condition))
+		})
+	}
+	// metric not exist
+	metaDB.EXPECT().GetAllTagKeys("ns", "mem").Return(nil, constants.ErrNotFound)
+	assert.Zero(t, db.EstimateSeries("ns", "mem", host))
+
+	index.EXPECT().Flush().Return(nil)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_RenameTagKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	index := NewMockInvertedIndex(ctrl)
+	metaDB := metadb.NewMockMetadataDatabase(ctrl)
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test")
+	meta.EXPECT().MetadataDatabase().Return(metaDB).AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	db2 := db.(*indexDatabase)
+	db2.index = index
+
+	// case 1: same tag key
+	assert.NoError(t, db.RenameTagKey(10, "host", "host"))
+	// case 2: from tag key not exist
+	metaDB.EXPECT().GetTagKeyIDByMetricID(uint32(10), "hostname").Return(uint32(0), constants.ErrNotFound)
+	err = db.RenameTagKey(10, "hostname", "host")
+	assert.True(t, errors.Is(err, constants.ErrNotFound))
+	// case 3: to tag key not exist
+	metaDB.EXPECT().GetTagKeyIDByMetricID(uint32(10), "hostname").Return(uint32(2), nil).AnyTimes()
+	metaDB.EXPECT().GetTagKeyIDByMetricID(uint32(10), "host").Return(uint32(0), constants.ErrNotFound)
+	err = db.RenameTagKey(10, "hostname", "host")
+	assert.True(t, errors.Is(err, constants.ErrNotFound))
+	// case 4: rename err
+	metaDB.EXPECT().GetTagKeyIDByMetricID(uint32(10), "host").Return(uint32(1), nil).AnyTimes()
+	index.EXPECT().renameTagKey(uint32(2), uint32(1)).Return(fmt.Errorf("err"))
+	err = db.RenameTagKey(10, "hostname", "host")
+	assert.Error(t, err)
+	// case 5: rename success
+	index.EXPECT().renameTagKey(uint32(2), uint32(1)).Return(nil)
+	err = db.RenameTagKey(10, "hostname", "host")
+	assert.NoError(t, err)
+
+	index.EXPECT().Flush().Return(nil)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
 func TestIndexDatabase_Close(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
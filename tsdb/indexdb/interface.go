@@ -5,6 +5,7 @@ import (
 
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 //go:generate mockgen -source ./interface.go -destination=./interface_mock.go -package=indexdb
@@ -27,12 +28,20 @@ type IndexDatabase interface {
 	series.TagValueSuggester
 	series.Filter
 	// GetOrCreateSeriesID gets series by tags hash, if not exist generate new series id in memory,
+	// the series of same group(not 0) are assigned adjacent series ids,
 	// if generate a new series id returns isCreate is true
 	// if generate fail return err
-	GetOrCreateSeriesID(metricID uint32, tagsHash uint64) (seriesID uint32, isCreated bool, err error)
+	GetOrCreateSeriesID(metricID uint32, tagsHash, group uint64) (seriesID uint32, isCreated bool, err error)
 	// BuildInvertIndex builds the inverted index for tag value => series ids,
 	// the tags is considered as a empty key-value pair while tags is nil.
 	BuildInvertIndex(namespace, metricName string, tags map[string]string, seriesID uint32)
+	// RenameTagKey migrates the inverted index of tag key(from) to tag key(to) under metric,
+	// so that the query on tag key(to) includes the series tagged with tag key(from), without rewriting series data.
+	// both tag keys must exist, if series has both tag keys, keeps the tag value of tag key(to).
+	RenameTagKey(metricID uint32, from, to string) error
+	// EstimateSeries estimates the upper bound of series under metric which match the tag filter condition,
+	// based on the series of tag keys in condition, without matching tag values.
+	EstimateSeries(namespace, metricName string, condition stmt.Expr) uint64
 	// Flush flushes index data to disk
 	Flush() error
 }
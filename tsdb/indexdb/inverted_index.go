@@ -1,6 +1,7 @@
 package indexdb
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/lindb/roaring"
@@ -52,6 +53,8 @@ func init() {
 type InvertedIndex interface {
 	// GetSeriesIDsByTagValueIDs gets series ids by tag value ids for spec metric's tag key
 	GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error)
+	// GetSeriesIDsForTagValues gets series ids which match all tag value ids of the tag keys
+	GetSeriesIDsForTagValues(tagKeyIDs []uint32, tagValueIDs []*roaring.Bitmap) (*roaring.Bitmap, error)
 	// GetSeriesIDsForTag gets series ids for spec metric's tag key
 	GetSeriesIDsForTag(tagKeyID uint32) (*roaring.Bitmap, error)
 	// GetSeriesIDsForTags gets series ids for spec metric's tag keys
@@ -61,6 +64,9 @@ type InvertedIndex interface {
 	// buildInvertIndex builds the inverted index for tag value => series ids,
 	// the tags is considered as a empty key-value pair while tags is nil.
 	buildInvertIndex(namespace, metricName string, tags map[string]string, seriesID uint32)
+	// renameTagKey copies the inverted index of tag key(from) to tag key(to) with the same tag values,
+	// the series which already has tag key(to) keeps its own tag value.
+	renameTagKey(fromTagKeyID, toTagKeyID uint32) error
 	// Flush flushes the inverted-index of tag value id=>series ids under tag key
 	Flush() error
 }
@@ -73,6 +79,8 @@ type invertedIndex struct {
 	mutable   *TagIndexStore
 	immutable *TagIndexStore
 
+	tagSeriesCache *tagSeriesCache // caches the series ids of tag key for negation
+
 	rwMutex sync.RWMutex
 }
 
@@ -82,11 +90,60 @@ func newInvertedIndex(metadata metadb.Metadata, forwardFamily kv.Family, inverte
 		forwardFamily:  forwardFamily,
 		metadata:       metadata,
 		mutable:        NewTagIndexStore(),
+		tagSeriesCache: newTagSeriesCache(),
 	}
 }
 
 // FindSeriesIDsByExpr finds series ids by tag filter expr
 func (index *invertedIndex) GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
+	// get kv store snapshot
+	snapshot := index.invertedFamily.GetSnapshot()
+	defer snapshot.Close()
+	return index.getSeriesIDsByTagValueIDs(tagKeyID, tagValueIDs, snapshot)
+}
+
+// GetSeriesIDsForTagValues gets series ids which match all tag value ids of the tag keys,
+// resolves and intersects each tag's series ids using one kv store snapshot.
+func (index *invertedIndex) GetSeriesIDsForTagValues(
+	tagKeyIDs []uint32,
+	tagValueIDs []*roaring.Bitmap,
+) (*roaring.Bitmap, error) {
+	if len(tagKeyIDs) != len(tagValueIDs) {
+		return nil, fmt.Errorf("tag key ids length(%d) not match tag value ids length(%d)",
+			len(tagKeyIDs), len(tagValueIDs))
+	}
+	// get kv store snapshot
+	snapshot := index.invertedFamily.GetSnapshot()
+	defer snapshot.Close()
+
+	var result *roaring.Bitmap
+	for idx, tagKeyID := range tagKeyIDs {
+		seriesIDs, err := index.getSeriesIDsByTagValueIDs(tagKeyID, tagValueIDs[idx], snapshot)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = seriesIDs
+		} else {
+			result.And(seriesIDs)
+		}
+		if result.IsEmpty() {
+			// no series matches, skip other tags
+			break
+		}
+	}
+	if result == nil {
+		return roaring.New(), nil
+	}
+	return result, nil
+}
+
+// getSeriesIDsByTagValueIDs gets series ids by tag value ids and kv snapshot
+func (index *invertedIndex) getSeriesIDsByTagValueIDs(
+	tagKeyID uint32,
+	tagValueIDs *roaring.Bitmap,
+	snapshot version.Snapshot,
+) (*roaring.Bitmap, error) {
 	result := roaring.New()
 	// read data from mem
 	index.loadSeriesIDsInMem(tagKeyID, func(tagIndex TagIndex) {
@@ -97,7 +154,7 @@ func (index *invertedIndex) GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueI
 	})
 
 	// read data from kv store
-	if err := index.loadSeriesIDsInKV(tagKeyID, func(reader invertedindex.InvertedReader) error {
+	if err := index.loadSeriesIDsInKV(tagKeyID, snapshot, func(reader invertedindex.InvertedReader) error {
 		seriesIDs, err := reader.GetSeriesIDsByTagValueIDs(tagKeyID, tagValueIDs)
 		if err != nil {
 			return err
@@ -110,12 +167,22 @@ func (index *invertedIndex) GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueI
 	return result, nil
 }
 
-// GetSeriesIDsForTag get series ids by tagKeyId
+// GetSeriesIDsForTag get series ids by tagKeyId,
+// the series ids are cached with a short ttl for repeated negations on the same tag key.
 func (index *invertedIndex) GetSeriesIDsForTag(tagKeyID uint32) (*roaring.Bitmap, error) {
+	seriesIDs, version, ok := index.tagSeriesCache.get(tagKeyID)
+	if ok {
+		return seriesIDs, nil
+	}
 	// get snapshot for getting data
 	snapshot := index.forwardFamily.GetSnapshot()
 	defer snapshot.Close()
-	return index.getSeriesIDsForTag(tagKeyID, snapshot)
+	seriesIDs, err := index.getSeriesIDsForTag(tagKeyID, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	index.tagSeriesCache.put(tagKeyID, version, seriesIDs)
+	return seriesIDs, nil
 }
 
 // getSeriesIDsForTag get series ids by tagKeyId and kv snapshot
@@ -251,9 +318,75 @@ func (index *invertedIndex) buildInvertIndex(namespace, metricName string, tags
 			continue
 		}
 		tagIndex.buildInvertedIndex(tagValueID, seriesID)
+		// new series of tag key, the cached series ids are stale
+		index.tagSeriesCache.invalidate(tagKeyID)
 	}
 }
 
+// renameTagKey copies the inverted index of tag key(from) to tag key(to) with the same tag values,
+// so that the query on tag key(to) includes the series tagged with tag key(from), series data isn't rewritten.
+// collision policy: if series has both tag keys, keeps the tag value of tag key(to), ignores the tag value of tag key(from).
+func (index *invertedIndex) renameTagKey(fromTagKeyID, toTagKeyID uint32) error {
+	tagMetadata := index.metadata.TagMetadata()
+	tagValueIDs, err := tagMetadata.GetTagValueIDsForTag(fromTagKeyID)
+	if err != nil {
+		return err
+	}
+	if tagValueIDs.IsEmpty() {
+		return nil
+	}
+	tagValues := make(map[uint32]string)
+	if err := tagMetadata.CollectTagValues(fromTagKeyID, tagValueIDs.Clone(), tagValues); err != nil {
+		return err
+	}
+	existSeriesIDs, err := index.GetSeriesIDsForTag(toTagKeyID)
+	if err != nil {
+		return err
+	}
+	// get kv store snapshot
+	snapshot := index.invertedFamily.GetSnapshot()
+	defer snapshot.Close()
+
+	renamed := make(map[uint32]*roaring.Bitmap)
+	for tagValueID, tagValue := range tagValues {
+		seriesIDs, err := index.getSeriesIDsByTagValueIDs(fromTagKeyID, roaring.BitmapOf(tagValueID), snapshot)
+		if err != nil {
+			return err
+		}
+		// series which has both tag keys keeps its own tag value
+		seriesIDs.AndNot(existSeriesIDs)
+		if seriesIDs.IsEmpty() {
+			continue
+		}
+		toTagValueID, err := tagMetadata.GenTagValueID(toTagKeyID, tagValue)
+		if err != nil {
+			return err
+		}
+		renamed[toTagValueID] = seriesIDs
+	}
+	if len(renamed) == 0 {
+		return nil
+	}
+
+	index.rwMutex.Lock()
+	defer index.rwMutex.Unlock()
+
+	tagIndex, ok := index.mutable.Get(toTagKeyID)
+	if !ok {
+		tagIndex = newTagIndex()
+		index.mutable.Put(toTagKeyID, tagIndex)
+	}
+	for tagValueID, seriesIDs := range renamed {
+		it := seriesIDs.Iterator()
+		for it.HasNext() {
+			tagIndex.buildInvertedIndex(tagValueID, it.Next())
+		}
+	}
+	// new series of tag key, the cached series ids are stale
+	index.tagSeriesCache.invalidate(toTagKeyID)
+	return nil
+}
+
 // Flush flushes the inverted-index of tag value id=>series ids under tag key
 func (index *invertedIndex) Flush() error {
 	if !index.checkFlush() {
@@ -305,11 +438,12 @@ func (index *invertedIndex) checkFlush() bool {
 }
 
 // loadTagValueIDsInKV loads series ids in kv store
-func (index *invertedIndex) loadSeriesIDsInKV(tagKeyID uint32, fn func(reader invertedindex.InvertedReader) error) error {
+func (index *invertedIndex) loadSeriesIDsInKV(
+	tagKeyID uint32,
+	snapshot version.Snapshot,
+	fn func(reader invertedindex.InvertedReader) error,
+) error {
 	// try get tag key id from kv store
-	snapshot := index.invertedFamily.GetSnapshot()
-	defer snapshot.Close()
-
 	readers, err := snapshot.FindReaders(tagKeyID)
 	if err != nil {
 		// find table.Reader err, return it
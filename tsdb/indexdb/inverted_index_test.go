@@ -3,6 +3,7 @@ package indexdb
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/lindb/roaring"
@@ -93,6 +94,106 @@ func TestInvertedIndex_buildInvertIndex(t *testing.T) {
 	assert.Equal(t, roaring.BitmapOf(10, 200, 3000), seriesIDs)
 }
 
+func TestInvertedIndex_GetSeriesIDsForTagValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newInvertedReaderFunc = invertedindex.NewInvertedReader
+		ctrl.Finish()
+	}()
+	reader := invertedindex.NewMockInvertedReader(ctrl)
+	newInvertedReaderFunc = func(readers []table.Reader) invertedindex.InvertedReader {
+		return reader
+	}
+
+	index := prepareInvertedIndex(ctrl)
+	family := kv.NewMockFamily(ctrl)
+	idx := index.(*invertedIndex)
+	idx.invertedFamily = family
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	family.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	// iterative version: resolves each tag, then intersects
+	iterative := func(tagKeyIDs []uint32, tagValueIDs []*roaring.Bitmap) *roaring.Bitmap {
+		result, err := index.GetSeriesIDsByTagValueIDs(tagKeyIDs[0], tagValueIDs[0])
+		assert.NoError(t, err)
+		for i := 1; i < len(tagKeyIDs); i++ {
+			seriesIDs, err := index.GetSeriesIDsByTagValueIDs(tagKeyIDs[i], tagValueIDs[i])
+			assert.NoError(t, err)
+			result.And(seriesIDs)
+		}
+		return result
+	}
+	cases := []struct {
+		tagKeyIDs   []uint32
+		tagValueIDs []*roaring.Bitmap
+		expect      *roaring.Bitmap
+	}{
+		// host='1.1.1.1' and zone='sh'
+		{[]uint32{1, 2}, []*roaring.Bitmap{roaring.BitmapOf(1), roaring.BitmapOf(1)}, roaring.BitmapOf(1)},
+		// host='1.1.1.1' and zone='bj'
+		{[]uint32{1, 2}, []*roaring.Bitmap{roaring.BitmapOf(1), roaring.BitmapOf(2)}, roaring.BitmapOf(2)},
+		// zone='sh' and zone='bj'
+		{[]uint32{2, 2}, []*roaring.Bitmap{roaring.BitmapOf(1), roaring.BitmapOf(2)}, roaring.New()},
+		// host='1.1.1.1' and tag key not exist
+		{[]uint32{1, 4}, []*roaring.Bitmap{roaring.BitmapOf(1), roaring.BitmapOf(1)}, roaring.New()},
+		// single tag
+		{[]uint32{1}, []*roaring.Bitmap{roaring.BitmapOf(1)}, roaring.BitmapOf(1, 2)},
+	}
+	for _, c := range cases {
+		seriesIDs, err := index.GetSeriesIDsForTagValues(c.tagKeyIDs, c.tagValueIDs)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expect.ToArray(), seriesIDs.ToArray())
+		assert.Equal(t, iterative(c.tagKeyIDs, c.tagValueIDs).ToArray(), seriesIDs.ToArray())
+	}
+
+	// case: empty tags
+	seriesIDs, err := index.GetSeriesIDsForTagValues(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.New(), seriesIDs)
+	// case: length not match
+	seriesIDs, err = index.GetSeriesIDsForTagValues([]uint32{1, 2}, []*roaring.Bitmap{roaring.BitmapOf(1)})
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+}
+
+func TestInvertedIndex_GetSeriesIDsForTagValues_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newInvertedReaderFunc = invertedindex.NewInvertedReader
+		ctrl.Finish()
+	}()
+	reader := invertedindex.NewMockInvertedReader(ctrl)
+	newInvertedReaderFunc = func(readers []table.Reader) invertedindex.InvertedReader {
+		return reader
+	}
+
+	index := prepareInvertedIndex(ctrl)
+	family := kv.NewMockFamily(ctrl)
+	idx := index.(*invertedIndex)
+	idx.invertedFamily = family
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	// takes only one snapshot for all tags
+	family.EXPECT().GetSnapshot().Return(snapshot).Times(2)
+
+	// case 1: get readers err
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	seriesIDs, err := index.GetSeriesIDsForTagValues([]uint32{1, 2}, []*roaring.Bitmap{roaring.BitmapOf(1), roaring.BitmapOf(1)})
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 2: reader get data err on second tag
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{table.NewMockReader(ctrl)}, nil).AnyTimes()
+	gomock.InOrder(
+		reader.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(roaring.BitmapOf(1, 2, 3), nil),
+		reader.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), gomock.Any()).Return(nil, fmt.Errorf("err")),
+	)
+	seriesIDs, err = index.GetSeriesIDsForTagValues([]uint32{1, 2}, []*roaring.Bitmap{roaring.BitmapOf(1), roaring.BitmapOf(1)})
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+}
+
 func TestInvertedIndex_GetSeriesIDsForTags(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -157,6 +258,133 @@ func TestInvertedIndex_GetSeriesIDsForTag(t *testing.T) {
 	assert.Equal(t, roaring.BitmapOf(1, 2, 3), seriesIDs)
 }
 
+func TestInvertedIndex_GetSeriesIDsForTag_cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		tagSeriesCacheTTL = 30 * time.Second
+		ctrl.Finish()
+	}()
+
+	index := prepareInvertedIndex(ctrl)
+	family := kv.NewMockFamily(ctrl)
+	idx := index.(*invertedIndex)
+	idx.forwardFamily = family
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+
+	// case 1: load err, not cached
+	family.EXPECT().GetSnapshot().Return(snapshot)
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	seriesIDs, err := index.GetSeriesIDsForTag(2)
+	assert.Error(t, err)
+	assert.Nil(t, seriesIDs)
+	// case 2: second negation on the same tag hits the cache, only loads once
+	family.EXPECT().GetSnapshot().Return(snapshot)
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil)
+	seriesIDs, err = index.GetSeriesIDsForTag(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, seriesIDs.ToArray())
+	// returns copy of cached series ids
+	seriesIDs.Add(100)
+	seriesIDs, err = index.GetSeriesIDsForTag(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, seriesIDs.ToArray())
+	// case 3: new series of tag ingested, invalidates the cache
+	idx.metadata.TagMetadata().(*metadb.MockTagMetadata).EXPECT().GenTagValueID(uint32(2), "gz").Return(uint32(3), nil)
+	index.buildInvertIndex("ns", "name", map[string]string{"zone": "gz"}, 4)
+	family.EXPECT().GetSnapshot().Return(snapshot)
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil)
+	seriesIDs, err = index.GetSeriesIDsForTag(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2, 4}, seriesIDs.ToArray())
+	// case 4: cache expired
+	tagSeriesCacheTTL = 0
+	idx.tagSeriesCache.invalidate(2)
+	family.EXPECT().GetSnapshot().Return(snapshot).Times(2)
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil).Times(2)
+	_, _ = index.GetSeriesIDsForTag(2)
+	seriesIDs, err = index.GetSeriesIDsForTag(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2, 4}, seriesIDs.ToArray())
+}
+
+func TestInvertedIndex_renameTagKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := prepareInvertedIndex(ctrl)
+	idx := index.(*invertedIndex)
+	forwardFamily := kv.NewMockFamily(ctrl)
+	invertedFamily := kv.NewMockFamily(ctrl)
+	idx.forwardFamily = forwardFamily
+	idx.invertedFamily = invertedFamily
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return(nil, nil).AnyTimes()
+	forwardFamily.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	invertedFamily.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	metadataDB := idx.metadata.MetadataDatabase().(*metadb.MockMetadataDatabase)
+	tagMetadata := idx.metadata.TagMetadata().(*metadb.MockTagMetadata)
+	// series 2 has both host and hostname
+	metadataDB.EXPECT().GenTagKeyID(gomock.Any(), gomock.Any(), "hostname").Return(uint32(3), nil)
+	tagMetadata.EXPECT().GenTagValueID(uint32(3), "2.2.2.2").Return(uint32(1), nil)
+	index.buildInvertIndex("ns", "name", map[string]string{"hostname": "2.2.2.2"}, 2)
+
+	// case 1: get tag value ids err
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(1)).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, index.renameTagKey(1, 3))
+	// case 2: tag key hasn't tag values
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(1)).Return(roaring.New(), nil)
+	assert.NoError(t, index.renameTagKey(1, 3))
+	// case 3: collect tag values err
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(1)).Return(roaring.BitmapOf(1), nil).AnyTimes()
+	tagMetadata.EXPECT().CollectTagValues(uint32(1), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, index.renameTagKey(1, 3))
+	collect := func(tagKeyID uint32, tagValueIDs *roaring.Bitmap, tagValues map[uint32]string) error {
+		tagValues[1] = "1.1.1.1"
+		return nil
+	}
+	tagMetadata.EXPECT().CollectTagValues(uint32(1), gomock.Any(), gomock.Any()).DoAndReturn(collect).AnyTimes()
+	// case 4: gen tag value id err
+	tagMetadata.EXPECT().GenTagValueID(uint32(3), "1.1.1.1").Return(uint32(0), fmt.Errorf("err"))
+	assert.Error(t, index.renameTagKey(1, 3))
+	// case 5: rename host => hostname
+	tagMetadata.EXPECT().GenTagValueID(uint32(3), "1.1.1.1").Return(uint32(2), nil)
+	assert.NoError(t, index.renameTagKey(1, 3))
+	seriesIDs, err := index.GetSeriesIDsForTag(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, seriesIDs.ToArray())
+	// series 1 renamed
+	seriesIDs, err = index.GetSeriesIDsByTagValueIDs(3, roaring.BitmapOf(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1}, seriesIDs.ToArray())
+	// series 2 keeps its own tag value
+	seriesIDs, err = index.GetSeriesIDsByTagValueIDs(3, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{2}, seriesIDs.ToArray())
+	// old tag key is kept
+	seriesIDs, err = index.GetSeriesIDsByTagValueIDs(1, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, seriesIDs.ToArray())
+	// case 6: all series already have new tag key
+	assert.NoError(t, index.renameTagKey(1, 3))
+}
+
+func TestTagSeriesCache_stale(t *testing.T) {
+	cache := newTagSeriesCache()
+	_, v, ok := cache.get(1)
+	assert.False(t, ok)
+	// new series ingested during loading, doesn't cache the stale series ids
+	cache.invalidate(1)
+	cache.put(1, v, roaring.BitmapOf(1))
+	_, v, ok = cache.get(1)
+	assert.False(t, ok)
+	cache.put(1, v, roaring.BitmapOf(1, 2))
+	seriesIDs, _, ok := cache.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, []uint32{1, 2}, seriesIDs.ToArray())
+}
+
 func TestInvertedIndex_GetGroupingContext(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
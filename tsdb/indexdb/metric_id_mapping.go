@@ -13,8 +13,9 @@ type MetricIDMapping interface {
 	GetMetricID() uint32
 	// GetSeriesID gets series id by tags hash, if exist return true
 	GetSeriesID(tagsHash uint64) (seriesID uint32, ok bool)
-	// GenSeriesID generates series id by tags hash, then cache new series id
-	GenSeriesID(tagsHash uint64) (seriesID uint32)
+	// GenSeriesID generates series id by tags hash, then cache new series id,
+	// the series of same group(not 0) are assigned adjacent series ids from the id range of group.
+	GenSeriesID(tagsHash, group uint64) (seriesID uint32)
 	// RemoveSeriesID removes series id by tags hash
 	RemoveSeriesID(tagsHash uint64)
 	// AddSeriesID adds the series id init cache
@@ -25,12 +26,21 @@ type MetricIDMapping interface {
 	GetMaxSeriesIDsLimit() uint32
 }
 
+// seriesIDRangeSize represents the num. of series ids reserved for a group at a time
+const seriesIDRangeSize = 256
+
+// seriesIDRange represents the reserved series ids of a group, the ids in [next, end] are available
+type seriesIDRange struct {
+	next, end uint32
+}
+
 // metricIDMapping implements MetricIDMapping interface
 type metricIDMapping struct {
 	metricID uint32
 	// forwardIndex for storing a mapping from tag-hash to the seriesID,
 	// purpose of this index is used for fast writing
 	hash2SeriesID     map[uint64]uint32
+	group2Range       map[uint64]*seriesIDRange // group => reserved series ids, not persisted
 	idSequence        atomic.Uint32
 	maxSeriesIDsLimit atomic.Uint32 // maximum number of combinations of series ids
 }
@@ -40,6 +50,7 @@ func newMetricIDMapping(metricID, sequence uint32) MetricIDMapping {
 	return &metricIDMapping{
 		metricID:          metricID,
 		hash2SeriesID:     make(map[uint64]uint32),
+		group2Range:       make(map[uint64]*seriesIDRange),
 		idSequence:        *atomic.NewUint32(sequence), // first value is 1
 		maxSeriesIDsLimit: *atomic.NewUint32(constants.DefaultMaxSeriesIDsCount),
 	}
@@ -61,8 +72,15 @@ func (mim *metricIDMapping) AddSeriesID(tagsHash uint64, seriesID uint32) {
 	mim.hash2SeriesID[tagsHash] = seriesID
 }
 
-// GenSeriesID generates series id by tags hash, then cache new series id
-func (mim *metricIDMapping) GenSeriesID(tagsHash uint64) (seriesID uint32) {
+// GenSeriesID generates series id by tags hash, then cache new series id,
+// the series of same group(not 0) are assigned adjacent series ids from the id range of group.
+func (mim *metricIDMapping) GenSeriesID(tagsHash, group uint64) (seriesID uint32) {
+	if group != 0 {
+		if seriesID, ok := mim.genGroupedSeriesID(group); ok {
+			mim.hash2SeriesID[tagsHash] = seriesID
+			return seriesID
+		}
+	}
 	// generate new series id
 	if mim.maxSeriesIDsLimit.Load() == mim.idSequence.Load() {
 		//FIXME too many series id, use max limit????
@@ -75,6 +93,24 @@ func (mim *metricIDMapping) GenSeriesID(tagsHash uint64) (seriesID uint32) {
 	return seriesID
 }
 
+// genGroupedSeriesID generates series id from the id range of group, reserves a new range if the range is used up,
+// returns false if no more series ids can be reserved under max series ids limit.
+func (mim *metricIDMapping) genGroupedSeriesID(group uint64) (seriesID uint32, ok bool) {
+	idRange, ok := mim.group2Range[group]
+	if !ok || idRange.next > idRange.end {
+		sequence := mim.idSequence.Load()
+		limit := mim.maxSeriesIDsLimit.Load()
+		if sequence >= limit || limit-sequence < seriesIDRangeSize {
+			return 0, false
+		}
+		idRange = &seriesIDRange{next: sequence + 1, end: mim.idSequence.Add(seriesIDRangeSize)}
+		mim.group2Range[group] = idRange
+	}
+	seriesID = idRange.next
+	idRange.next++
+	return seriesID, true
+}
+
 // RemoveSeriesID removes series id by tags hash
 func (mim *metricIDMapping) RemoveSeriesID(tagsHash uint64) {
 	seriesID, ok := mim.hash2SeriesID[tagsHash]
@@ -18,7 +18,7 @@ func TestMetricIDMapping_GetOrCreateSeriesID(t *testing.T) {
 	seriesID, ok := idMapping.GetSeriesID(100)
 	assert.False(t, ok)
 	assert.Equal(t, uint32(0), seriesID)
-	seriesID = idMapping.GenSeriesID(100)
+	seriesID = idMapping.GenSeriesID(100, 0)
 	assert.Equal(t, uint32(1), seriesID)
 	// get exist series id
 	seriesID, ok = idMapping.GetSeriesID(100)
@@ -34,21 +34,44 @@ func TestMetricIDMapping_GetOrCreateSeriesID(t *testing.T) {
 
 func TestMetricIDMapping_SetMaxTagsLimit(t *testing.T) {
 	idMapping := newMetricIDMapping(10, 0)
-	seriesID := idMapping.GenSeriesID(100)
+	seriesID := idMapping.GenSeriesID(100, 0)
 	assert.Equal(t, uint32(1), seriesID)
 	assert.Equal(t, uint32(constants.DefaultMaxSeriesIDsCount), idMapping.GetMaxSeriesIDsLimit())
 	idMapping.SetMaxSeriesIDsLimit(2)
-	_ = idMapping.GenSeriesID(102)
-	seriesID = idMapping.GenSeriesID(1020)
+	_ = idMapping.GenSeriesID(102, 0)
+	seriesID = idMapping.GenSeriesID(1020, 0)
 	assert.Equal(t, uint32(2), seriesID)
 }
 
 func TestMetricIDMapping_RemoveSeriesID(t *testing.T) {
 	idMapping := newMetricIDMapping(10, 0)
-	seriesID := idMapping.GenSeriesID(100)
+	seriesID := idMapping.GenSeriesID(100, 0)
 	assert.Equal(t, uint32(1), seriesID)
 	idMapping.RemoveSeriesID(100)
-	seriesID = idMapping.GenSeriesID(100)
+	seriesID = idMapping.GenSeriesID(100, 0)
 	assert.Equal(t, uint32(1), seriesID)
 	idMapping.RemoveSeriesID(1200)
 }
+
+func TestMetricIDMapping_GenSeriesID_group(t *testing.T) {
+	idMapping := newMetricIDMapping(10, 0)
+	assert.Equal(t, uint32(1), idMapping.GenSeriesID(100, 1))
+	assert.Equal(t, uint32(seriesIDRangeSize+1), idMapping.GenSeriesID(101, 2))
+	assert.Equal(t, uint32(2), idMapping.GenSeriesID(102, 1))
+	// not grouped series id is generated after the reserved ranges
+	assert.Equal(t, uint32(2*seriesIDRangeSize+1), idMapping.GenSeriesID(103, 0))
+	seriesID, ok := idMapping.GetSeriesID(102)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), seriesID)
+	// range of group is used up, reserves a new range
+	for i := 3; i <= seriesIDRangeSize; i++ {
+		assert.Equal(t, uint32(i), idMapping.GenSeriesID(uint64(1000+i), 1))
+	}
+	assert.Equal(t, uint32(2*seriesIDRangeSize+2), idMapping.GenSeriesID(2000, 1))
+
+	// no more range can be reserved under max series ids limit, generates by sequence
+	idMapping = newMetricIDMapping(10, 0)
+	idMapping.SetMaxSeriesIDsLimit(seriesIDRangeSize + 1)
+	assert.Equal(t, uint32(1), idMapping.GenSeriesID(100, 1))
+	assert.Equal(t, uint32(seriesIDRangeSize+1), idMapping.GenSeriesID(101, 2))
+}
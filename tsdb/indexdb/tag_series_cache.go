@@ -0,0 +1,118 @@
+package indexdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// for testing
+var (
+	// tagSeriesCacheTTL represents how long the series ids of tag key are cached
+	tagSeriesCacheTTL = 30 * time.Second
+)
+
+// tagSeriesCacheEntry represents the cached series ids of tag key, seriesIDs is nil if the series ids are loading,
+// version is increased when the tag key is invalidated, avoids caching stale series ids loaded before invalidation.
+type tagSeriesCacheEntry struct {
+	seriesIDs *roaring.Bitmap
+	version   uint64
+	expireAt  int64
+}
+
+// tagSeriesCache caches the series ids of tag key(the universe of tag for negation) with a short ttl,
+// so that the repeated negations on high-cardinality tag reuse it, the entry of tag key is invalidated
+// when new series of the tag key are ingested. the expired entries are swept when puts series ids,
+// so the cache only keeps the tag keys queried recently.
+type tagSeriesCache struct {
+	entries     map[uint32]*tagSeriesCacheEntry
+	nextSweepAt int64 // next time for sweeping expired entries
+	mutex       sync.RWMutex
+}
+
+// newTagSeriesCache creates the series ids cache of tag key
+func newTagSeriesCache() *tagSeriesCache {
+	return &tagSeriesCache{
+		entries: make(map[uint32]*tagSeriesCacheEntry),
+	}
+}
+
+// get returns a copy of cached series ids of tag key if not expired,
+// else tracks the loading of tag key, returns the invalidation version for putting the series ids after loading.
+func (c *tagSeriesCache) get(tagKeyID uint32) (seriesIDs *roaring.Bitmap, version uint64, ok bool) {
+	now := timeutil.Now()
+	c.mutex.RLock()
+	entry, exist := c.entries[tagKeyID]
+	if exist && entry.seriesIDs != nil && entry.expireAt > now {
+		seriesIDs, version = entry.seriesIDs.Clone(), entry.version
+		c.mutex.RUnlock()
+		return seriesIDs, version, true
+	}
+	c.mutex.RUnlock()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, exist = c.entries[tagKeyID]
+	if !exist {
+		entry = &tagSeriesCacheEntry{}
+		c.entries[tagKeyID] = entry
+	}
+	if entry.seriesIDs != nil && entry.expireAt > now {
+		return entry.seriesIDs.Clone(), entry.version, true
+	}
+	// loading entry is swept if series ids are not put in time
+	entry.seriesIDs = nil
+	entry.expireAt = now + tagSeriesCacheTTL.Milliseconds()
+	return nil, entry.version, false
+}
+
+// put caches a copy of series ids of tag key, ignores it if the tag key is invalidated after loading
+func (c *tagSeriesCache) put(tagKeyID uint32, version uint64, seriesIDs *roaring.Bitmap) {
+	now := timeutil.Now()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.sweep(now)
+	entry, ok := c.entries[tagKeyID]
+	if !ok || entry.version != version {
+		return
+	}
+	entry.seriesIDs = seriesIDs.Clone()
+	entry.expireAt = now + tagSeriesCacheTTL.Milliseconds()
+}
+
+// sweep removes the expired entries, runs at most once per ttl
+func (c *tagSeriesCache) sweep(now int64) {
+	if now < c.nextSweepAt {
+		return
+	}
+	c.nextSweepAt = now + tagSeriesCacheTTL.Milliseconds()
+	for tagKeyID, entry := range c.entries {
+		if entry.expireAt <= now {
+			delete(c.entries, tagKeyID)
+		}
+	}
+}
+
+// invalidate removes the cached series ids of tag key when new series of the tag key are ingested,
+// it is called for each new series when building index, so only locks exclusively if the tag key is cached or loading.
+func (c *tagSeriesCache) invalidate(tagKeyID uint32) {
+	c.mutex.RLock()
+	_, ok := c.entries[tagKeyID]
+	c.mutex.RUnlock()
+	if !ok {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entry, ok := c.entries[tagKeyID]; ok {
+		entry.seriesIDs = nil
+		entry.version++
+	}
+}
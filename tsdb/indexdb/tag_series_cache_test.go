@@ -0,0 +1,74 @@
+package indexdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagSeriesCache_get_put(t *testing.T) {
+	cache := newTagSeriesCache()
+	seriesIDs, version, ok := cache.get(1)
+	assert.False(t, ok)
+	assert.Nil(t, seriesIDs)
+	cache.put(1, version, roaring.BitmapOf(1, 2))
+	seriesIDs, _, ok = cache.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, []uint32{1, 2}, seriesIDs.ToArray())
+	// put without loading is ignored
+	cache.put(2, 0, roaring.BitmapOf(1))
+	_, _, ok = cache.get(2)
+	assert.False(t, ok)
+}
+
+func TestTagSeriesCache_invalidate(t *testing.T) {
+	cache := newTagSeriesCache()
+	// tag key not cached, nothing tracked
+	cache.invalidate(1)
+	assert.Empty(t, cache.entries)
+
+	// invalidated while loading, stale series ids are not cached
+	_, version, _ := cache.get(1)
+	cache.invalidate(1)
+	cache.put(1, version, roaring.BitmapOf(1))
+	_, version, ok := cache.get(1)
+	assert.False(t, ok)
+	cache.put(1, version, roaring.BitmapOf(1, 2))
+	seriesIDs, _, ok := cache.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, []uint32{1, 2}, seriesIDs.ToArray())
+	// invalidates cached series ids
+	cache.invalidate(1)
+	_, _, ok = cache.get(1)
+	assert.False(t, ok)
+}
+
+func TestTagSeriesCache_sweep(t *testing.T) {
+	defer func() {
+		tagSeriesCacheTTL = 30 * time.Second
+	}()
+	tagSeriesCacheTTL = 0
+	cache := newTagSeriesCache()
+	for tagKeyID := uint32(0); tagKeyID < 10; tagKeyID++ {
+		_, version, _ := cache.get(tagKeyID)
+		cache.put(tagKeyID, version, roaring.BitmapOf(1))
+	}
+	// expired entries are swept when puts series ids
+	assert.Empty(t, cache.entries)
+
+	tagSeriesCacheTTL = time.Hour
+	_, version, _ := cache.get(1)
+	cache.put(1, version, roaring.BitmapOf(1))
+	cache.entries[1].expireAt = 0
+	// sweeps at most once per ttl
+	_, version, _ = cache.get(2)
+	cache.put(2, version, roaring.BitmapOf(1))
+	assert.Len(t, cache.entries, 2)
+	cache.nextSweepAt = 0
+	_, version, _ = cache.get(3)
+	cache.put(3, version, roaring.BitmapOf(1))
+	assert.Len(t, cache.entries, 2)
+	assert.NotContains(t, cache.entries, uint32(1))
+}
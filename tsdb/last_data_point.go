@@ -0,0 +1,255 @@
+package tsdb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/concurrent"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// DataPoint represents the data point of series with absolute timestamp
+type DataPoint struct {
+	Time  int64
+	Value float64
+}
+
+// getLastDataPoint returns the latest data point of field for each series in time range.
+// the memory databases(immutable/mutable) are loaded first because they may contain data of any family time,
+// then the data families are loaded from newest to oldest, only the series whose latest data point
+// may be in the data family are loaded, so the older data families are skipped once all series are found.
+// NOTICE: if the same timestamp exists in multi data filters, the value of latter data filter is used,
+// the memory database is always newer than the data family.
+func getLastDataPoint(
+	memFilters []flow.DataFilter,
+	families []DataFamily,
+	interval int64,
+	metricID uint32,
+	seriesIDs *roaring.Bitmap,
+	fieldID field.ID,
+	timeRange timeutil.TimeRange,
+) (map[uint32]DataPoint, error) {
+	if seriesIDs == nil || seriesIDs.IsEmpty() {
+		return nil, nil
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].TimeRange().Start > families[j].TimeRange().Start
+	})
+	memPoints, err := loadLastDataPoint(memFilters, interval, metricID, seriesIDs, fieldID, timeRange)
+	if err != nil {
+		return nil, err
+	}
+	filePoints := make(map[uint32]DataPoint)
+	for _, family := range families {
+		familyTimeRange := family.TimeRange()
+		// finds the series whose latest data point may be in current data family
+		remaining := roaring.New()
+		it := seriesIDs.Iterator()
+		for it.HasNext() {
+			seriesID := it.Next()
+			if _, ok := filePoints[seriesID]; ok {
+				continue
+			}
+			if p, ok := memPoints[seriesID]; ok && p.Time >= familyTimeRange.End {
+				continue
+			}
+			remaining.Add(seriesID)
+		}
+		if remaining.IsEmpty() {
+			break
+		}
+		points, err := loadLastDataPoint([]flow.DataFilter{family}, interval, metricID, remaining, fieldID, familyTimeRange)
+		if err != nil {
+			return nil, err
+		}
+		for seriesID, p := range points {
+			filePoints[seriesID] = p
+		}
+	}
+	for seriesID, p := range memPoints {
+		if fp, ok := filePoints[seriesID]; !ok || p.Time >= fp.Time {
+			filePoints[seriesID] = p
+		}
+	}
+	if len(filePoints) == 0 {
+		return nil, nil
+	}
+	return filePoints, nil
+}
+
+// loadLastDataPoint loads the latest data point of field for each series from data filters in order
+func loadLastDataPoint(
+	filters []flow.DataFilter,
+	interval int64,
+	metricID uint32,
+	seriesIDs *roaring.Bitmap,
+	fieldID field.ID,
+	timeRange timeutil.TimeRange,
+) (map[uint32]DataPoint, error) {
+	fieldIDs := []field.ID{fieldID}
+	var resultSets []flow.FilterResultSet
+	for _, filter := range filters {
+		rs, err := filter.Filter(metricID, fieldIDs, seriesIDs, timeRange)
+		if err != nil && err != constants.ErrNotFound {
+			return nil, err
+		}
+		resultSets = append(resultSets, rs...)
+	}
+	if len(resultSets) == 0 {
+		return nil, nil
+	}
+
+	collector := newLastDataPointCollector(interval)
+	queryFlow := &lastDataPointFlow{collector: collector}
+	highKeys := seriesIDs.GetHighKeys()
+	for idx, highKey := range highKeys {
+		container := seriesIDs.GetContainerAtIndex(idx)
+		var scanners []flow.Scanner
+		for _, rs := range resultSets {
+			if scanner := rs.Load(queryFlow, fieldIDs, highKey, container); scanner != nil {
+				scanners = append(scanners, scanner)
+			}
+		}
+		hk := uint32(highKey) << 16
+		it := container.PeekableIterator()
+		for it.HasNext() {
+			lowSeriesID := it.Next()
+			collector.seriesID = encoding.ValueWithHighLowBits(hk, lowSeriesID)
+			for _, scanner := range scanners {
+				scanner.Scan(lowSeriesID)
+			}
+		}
+		for _, scanner := range scanners {
+			_ = scanner.Close()
+		}
+	}
+	return collector.points, nil
+}
+
+// lastDataPointCollector collects the latest data point of each series,
+// implements aggregation.SeriesAggregator interface for loading data from storage.
+type lastDataPointCollector struct {
+	interval int64
+	seriesID uint32               // current scanning series id
+	points   map[uint32]DataPoint // series id => latest data point
+}
+
+// newLastDataPointCollector creates the latest data point collector
+func newLastDataPointCollector(interval int64) *lastDataPointCollector {
+	return &lastDataPointCollector{
+		interval: interval,
+		points:   make(map[uint32]DataPoint),
+	}
+}
+
+// FieldName returns empty field name
+func (c *lastDataPointCollector) FieldName() field.Name {
+	return ""
+}
+
+// GetFieldType returns unknown field type
+func (c *lastDataPointCollector) GetFieldType() field.Type {
+	return field.Unknown
+}
+
+// SetFieldType does nothing
+func (c *lastDataPointCollector) SetFieldType(_ field.Type) {}
+
+// GetAggregateBlock returns the block which converts the time slot of family into timestamp
+func (c *lastDataPointCollector) GetAggregateBlock(familyTime int64) (series.Block, bool) {
+	return &lastDataPointBlock{collector: c, familyTime: familyTime}, true
+}
+
+// ResultSet returns nil, the result is kept by collector
+func (c *lastDataPointCollector) ResultSet() series.Iterator {
+	return nil
+}
+
+// Merge returns error, because the collected data are consumed when scanning storage, no partial state to merge
+func (c *lastDataPointCollector) Merge(_ aggregation.SeriesAggregator) error {
+	return fmt.Errorf("lastDataPointCollector not support merge")
+}
+
+// Reset does nothing
+func (c *lastDataPointCollector) Reset() {}
+
+// lastDataPointBlock implements series.Block interface, keeps the latest data point of current scanning series.
+type lastDataPointBlock struct {
+	collector  *lastDataPointCollector
+	familyTime int64
+}
+
+// Append keeps the data point if it is not older than the collected one, always returns false.
+func (b *lastDataPointBlock) Append(slot int, value float64) bool {
+	c := b.collector
+	timestamp := b.familyTime + int64(slot)*c.interval
+	if p, ok := c.points[c.seriesID]; !ok || timestamp >= p.Time {
+		c.points[c.seriesID] = DataPoint{Time: timestamp, Value: value}
+	}
+	return false
+}
+
+// Clear does nothing, because the data point is kept by series id in collector
+func (b *lastDataPointBlock) Clear() {}
+
+// lastDataPointContainer implements aggregation.ContainerAggregator interface,
+// the latest data point collector is the only field aggregator.
+type lastDataPointContainer struct {
+	collector *lastDataPointCollector
+}
+
+// GetFieldAggregates returns the collector as the only field aggregator
+func (c *lastDataPointContainer) GetFieldAggregates() aggregation.FieldAggregates {
+	return aggregation.FieldAggregates{c.collector}
+}
+
+// Aggregate does nothing, the latest data point is kept by series id in collector
+func (c *lastDataPointContainer) Aggregate() {}
+
+// ResultSet returns nil, the result is kept by collector
+func (c *lastDataPointContainer) ResultSet(_ string) series.GroupedIterator {
+	return nil
+}
+
+// Reset does nothing
+func (c *lastDataPointContainer) Reset() {}
+
+// lastDataPointFlow implements flow.StorageQueryFlow interface for loading the latest data point,
+// only returns the latest data point collector as aggregator, others do nothing.
+type lastDataPointFlow struct {
+	collector *lastDataPointCollector
+}
+
+// Prepare does nothing
+func (f *lastDataPointFlow) Prepare(_ aggregation.AggregatorSpecs) {}
+
+// Filtering does nothing
+func (f *lastDataPointFlow) Filtering(_ concurrent.Task) {}
+
+// Grouping does nothing
+func (f *lastDataPointFlow) Grouping(_ concurrent.Task) {}
+
+// Scanner does nothing
+func (f *lastDataPointFlow) Scanner(_ concurrent.Task) {}
+
+// Reduce does nothing
+func (f *lastDataPointFlow) Reduce(_ string, _ aggregation.ContainerAggregator) {}
+
+// ReduceTagValues does nothing
+func (f *lastDataPointFlow) ReduceTagValues(_ int, _ map[uint32]string) {}
+
+// GetAggregator returns the container of latest data point collector
+func (f *lastDataPointFlow) GetAggregator(_ uint16) aggregation.ContainerAggregator {
+	return &lastDataPointContainer{collector: f.collector}
+}
+
+// Complete does nothing
+func (f *lastDataPointFlow) Complete(_ error) {}
@@ -0,0 +1,121 @@
+package tsdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/memdb"
+)
+
+func TestShard_GetLastDataPoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	oldFamilyTime, _ := timeutil.ParseTimestamp("20190728 10:00:00")
+	newFamilyTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	seriesIDs := roaring.BitmapOf(1, 2, 3, 4)
+
+	// mock older segment's family, newer segment's family and memory storage
+	oldFamily := NewMockDataFamily(ctrl)
+	oldFamily.EXPECT().TimeRange().Return(timeutil.TimeRange{
+		Start: oldFamilyTime, End: oldFamilyTime + timeutil.OneHour - 1}).AnyTimes()
+	newFamily := NewMockDataFamily(ctrl)
+	newFamily.EXPECT().TimeRange().Return(timeutil.TimeRange{
+		Start: newFamilyTime, End: newFamilyTime + timeutil.OneHour - 1}).AnyTimes()
+	immutable := memdb.NewMockMemoryDatabase(ctrl)
+	mutable := memdb.NewMockMemoryDatabase(ctrl)
+	s := &shard{
+		interval:  timeutil.Interval(10 * timeutil.OneSecond),
+		segments:  map[timeutil.IntervalType]IntervalSegment{},
+		immutable: immutable,
+		mutable:   mutable,
+	}
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{oldFamily, newFamily}).AnyTimes()
+	s.segments[timeutil.Day] = segment
+
+	// series 1: latest point in newer segment, also exists in older segment
+	// series 2: only exists in older segment
+	// series 3: latest point in memory, older point in newer segment
+	// series 4: no data
+	newRS := mockLastDataPointResultSet(ctrl, newFamilyTime, map[uint16][]point{
+		1: {{slot: 3, value: 3}, {slot: 10, value: 10}},
+		3: {{slot: 1, value: 1}},
+	})
+	oldRS := mockLastDataPointResultSet(ctrl, oldFamilyTime, map[uint16][]point{
+		1: {{slot: 100, value: 100}},
+		2: {{slot: 5, value: 5}, {slot: 8, value: 8}},
+	})
+	memRS := mockLastDataPointResultSet(ctrl, newFamilyTime+timeutil.OneHour, map[uint16][]point{
+		3: {{slot: 2, value: 20}},
+	})
+	immutable.EXPECT().Filter(uint32(10), []field.ID{5}, seriesIDs, gomock.Any()).
+		Return(nil, constants.ErrNotFound)
+	mutable.EXPECT().Filter(uint32(10), []field.ID{5}, seriesIDs, gomock.Any()).
+		Return([]flow.FilterResultSet{memRS}, nil)
+	// series 3 is found in memory and newer than family, only loads series 1,2,4
+	newFamily.EXPECT().Filter(uint32(10), []field.ID{5}, roaring.BitmapOf(1, 2, 4), gomock.Any()).
+		Return([]flow.FilterResultSet{newRS}, nil)
+	// series 1 is found in newer family, doesn't read it in older family
+	oldFamily.EXPECT().Filter(uint32(10), []field.ID{5}, roaring.BitmapOf(2, 4), gomock.Any()).
+		Return([]flow.FilterResultSet{oldRS}, nil)
+
+	result, err := s.GetLastDataPoint(10, seriesIDs, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]DataPoint{
+		1: {Time: newFamilyTime + 10*10*timeutil.OneSecond, Value: 10},
+		2: {Time: oldFamilyTime + 8*10*timeutil.OneSecond, Value: 8},
+		3: {Time: newFamilyTime + timeutil.OneHour + 2*10*timeutil.OneSecond, Value: 20},
+	}, result)
+
+	// filter failure
+	mutable.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("err"))
+	s.immutable = nil
+	result, err = s.GetLastDataPoint(10, seriesIDs, 5)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mutable.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	newFamily.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("err"))
+	result, err = s.GetLastDataPoint(10, seriesIDs, 5)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	// empty series ids
+	result, err = s.GetLastDataPoint(10, roaring.New(), 5)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	// no data found
+	s.segments = nil
+	mutable.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	result, err = s.GetLastDataPoint(10, seriesIDs, 5)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+// mockLastDataPointResultSet mocks the filter result set, which appends the points of field by low series id
+func mockLastDataPointResultSet(ctrl *gomock.Controller, familyTime int64,
+	data map[uint16][]point) flow.FilterResultSet {
+	rs := flow.NewMockFilterResultSet(ctrl)
+	rs.EXPECT().Load(gomock.Any(), []field.ID{5}, uint16(0), gomock.Any()).DoAndReturn(
+		func(queryFlow flow.StorageQueryFlow, _ []field.ID, highKey uint16, _ roaring.Container) flow.Scanner {
+			block, _ := queryFlow.GetAggregator(highKey).GetFieldAggregates()[0].GetAggregateBlock(familyTime)
+			scanner := flow.NewMockScanner(ctrl)
+			scanner.EXPECT().Scan(gomock.Any()).DoAndReturn(func(lowSeriesID uint16) {
+				for _, p := range data[lowSeriesID] {
+					block.Append(p.slot, p.value)
+				}
+			}).AnyTimes()
+			scanner.EXPECT().Close().Return(nil)
+			return scanner
+		})
+	return rs
+}
@@ -1,6 +1,7 @@
 package memdb
 
 import (
+	"fmt"
 	"io"
 	"sort"
 	"sync"
@@ -47,12 +48,28 @@ var (
 		},
 		[]string{"db"},
 	)
+	slotCollisionRejectCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mem_slot_collision_reject",
+			Help: "Reject data points which land in the time slot already has a value.",
+		},
+		[]string{"db"},
+	)
+	fieldTypeConflictCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mem_field_type_conflict",
+			Help: "Write data points whose field type conflicts with the existing field.",
+		},
+		[]string{"db", "policy"},
+	)
 )
 
 func init() {
 	monitoring.StorageRegistry.MustRegister(getUnknownFieldTypeCounter)
 	monitoring.StorageRegistry.MustRegister(generateFieldIDFailCounter)
 	monitoring.StorageRegistry.MustRegister(writeDataPointCounter)
+	monitoring.StorageRegistry.MustRegister(slotCollisionRejectCounter)
+	monitoring.StorageRegistry.MustRegister(fieldTypeConflictCounter)
 }
 
 type familyID uint8
@@ -87,6 +104,10 @@ type MemoryDatabaseCfg struct {
 	Interval timeutil.Interval
 	Metadata metadb.Metadata
 	TempPath string
+	// merge policy of field when two values land in the same time slot, default aggregate by field type
+	MergePolicies map[field.Name]field.MergePolicy
+	// conflict policy of metric when field is written as another field type, default strict
+	ConflictPolicies map[string]field.ConflictPolicy
 }
 
 // flushContext holds the context for flushing
@@ -132,6 +153,9 @@ type memoryDatabase struct {
 	interval timeutil.Interval // time interval of rollup
 	metadata metadb.Metadata   // metadata for assign metric id/field id
 
+	mergePolicies    map[field.Name]field.MergePolicy // field name => merge policy
+	conflictPolicies map[string]field.ConflictPolicy  // metric name => field type conflict policy
+
 	mStores *MetricBucketStore // metric id => mStoreINTF
 	buf     DataPointBuffer
 
@@ -145,6 +169,7 @@ type memoryDatabase struct {
 	writeDataPointCounter      prometheus.Counter
 	generateFieldIDFailCounter prometheus.Counter
 	getUnknownFieldTypeCounter prometheus.Counter
+	slotCollisionRejectCounter prometheus.Counter
 }
 
 // NewMemoryDatabase returns a new MemoryDatabase.
@@ -157,12 +182,15 @@ func NewMemoryDatabase(cfg MemoryDatabaseCfg) (MemoryDatabase, error) {
 		name:                       cfg.Name,
 		interval:                   cfg.Interval,
 		metadata:                   cfg.Metadata,
+		mergePolicies:              cfg.MergePolicies,
+		conflictPolicies:           cfg.ConflictPolicies,
 		buf:                        buf,
 		mStores:                    NewMetricBucketStore(),
 		allocSize:                  *atomic.NewInt32(0),
 		writeDataPointCounter:      writeDataPointCounter.WithLabelValues(cfg.Name),
 		generateFieldIDFailCounter: generateFieldIDFailCounter.WithLabelValues(cfg.Name),
 		getUnknownFieldTypeCounter: getUnknownFieldTypeCounter.WithLabelValues(cfg.Name),
+		slotCollisionRejectCounter: slotCollisionRejectCounter.WithLabelValues(cfg.Name),
 	}, err
 }
 
@@ -189,7 +217,10 @@ func (md *memoryDatabase) CompleteWrite() {
 	md.writeCondition.Done()
 }
 
-// Write writes metric-point to database.
+// Write writes metric-point to database,
+// returns field.ErrSlotCollision if the field with reject merge policy already has a value in the time slot,
+// returns series.ErrWrongFieldType if the field type conflicts with the existing field and cannot be resolved
+// by the conflict policy of metric, the other fields of the point are still written.
 func (md *memoryDatabase) Write(
 	namespace, metricName string,
 	metricID, seriesID uint32,
@@ -211,6 +242,7 @@ func (md *memoryDatabase) Write(
 
 	tStore, size := mStore.GetOrCreateTStore(seriesID)
 	written := false
+	var collisionErr, conflictErr error
 
 	for _, f := range fields {
 		fieldType := getFieldType(f)
@@ -219,18 +251,31 @@ func (md *memoryDatabase) Write(
 			continue
 		}
 		fieldID, err := md.metadata.MetadataDatabase().GenFieldID(namespace, metricName, field.Name(f.Name), fieldType)
+		if err == series.ErrWrongFieldType {
+			fieldID, fieldType, err = md.resolveFieldConflict(namespace, metricName, field.Name(f.Name), fieldType)
+			if err != nil {
+				conflictErr = err
+				continue
+			}
+		}
 		if err != nil {
 			md.generateFieldIDFailCounter.Inc()
 			continue
 		}
-		md.writeDataPointCounter.Inc()
+		policy := md.mergePolicies[field.Name(f.Name)]
 		pStore, ok := tStore.GetFStore(fID, fieldID)
+		if ok && policy == field.RejectMerge && pStore.HasValue(slotIndex) {
+			md.slotCollisionRejectCounter.Inc()
+			collisionErr = field.ErrSlotCollision
+			continue
+		}
+		md.writeDataPointCounter.Inc()
 		if !ok {
 			buf, err := md.buf.AllocPage()
 			if err != nil {
 				return err
 			}
-			pStore = newFieldStore(buf, fID, fieldID)
+			pStore = newFieldStore(buf, fID, fieldID, policy)
 			size += tStore.InsertFStore(pStore)
 		}
 		size += pStore.Write(fieldType, slotIndex, f.Value)
@@ -243,7 +288,38 @@ func (md *memoryDatabase) Write(
 		mStore.SetTimestamp(fi, slotIndex)
 	}
 	md.allocSize.Add(int32(size))
-	return nil
+	if collisionErr != nil {
+		return collisionErr
+	}
+	return conflictErr
+}
+
+// resolveFieldConflict resolves the field whose type conflicts with the existing field by the conflict policy of metric,
+// returns the field id/type for writing, returns series.ErrWrongFieldType if the write is rejected.
+func (md *memoryDatabase) resolveFieldConflict(namespace, metricName string,
+	fieldName field.Name, fieldType field.Type,
+) (field.ID, field.Type, error) {
+	policy := md.conflictPolicies[metricName]
+	fieldTypeConflictCounter.WithLabelValues(md.name, policy.String()).Inc()
+
+	metadataDB := md.metadata.MetadataDatabase()
+	switch policy {
+	case field.CoerceConflict:
+		f, err := metadataDB.GetField(namespace, metricName, fieldName)
+		if err != nil {
+			return 0, 0, err
+		}
+		if field.CanCoerce(fieldType, f.Type) {
+			return f.ID, f.Type, nil
+		}
+	case field.VersionConflict:
+		fieldID, err := metadataDB.GenFieldID(namespace, metricName, field.VersionedName(fieldName, fieldType), fieldType)
+		if err != nil {
+			return 0, 0, err
+		}
+		return fieldID, fieldType, nil
+	}
+	return 0, 0, fmt.Errorf("%w, field: %s, type: %s", series.ErrWrongFieldType, fieldName, fieldType)
 }
 
 // Families returns the families in memory which has not been flushed yet.
@@ -1,6 +1,7 @@
 package memdb
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/timeutil"
 	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/tsdb/metadb"
 	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
@@ -196,6 +198,136 @@ func TestMemoryDatabase_Write_err(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMemoryDatabase_Write_slot_collision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMetadata := metadb.NewMockMetadata(ctrl)
+	mockMetadataDatabase := metadb.NewMockMetadataDatabase(ctrl)
+	mockMetadata.EXPECT().MetadataDatabase().Return(mockMetadataDatabase).AnyTimes()
+	mockMetadataDatabase.EXPECT().GenFieldID("ns", "test1", field.Name("f1"), field.SumField).Return(field.ID(1), nil).AnyTimes()
+	mockMetadataDatabase.EXPECT().GenFieldID("ns", "test1", field.Name("f2"), field.SumField).Return(field.ID(2), nil).AnyTimes()
+	dbCfg := cfg
+	dbCfg.Metadata = mockMetadata
+	dbCfg.MergePolicies = map[field.Name]field.MergePolicy{"f1": field.RejectMerge, "f2": field.LastWriteWins}
+	mdINTF, err := NewMemoryDatabase(dbCfg)
+	assert.NoError(t, err)
+	md := mdINTF.(*memoryDatabase)
+	defer func() {
+		_ = md.Close()
+	}()
+
+	fields := []*pb.Field{
+		{Name: "f1", Type: pb.FieldType_Sum, Value: 10.0},
+		{Name: "f2", Type: pb.FieldType_Sum, Value: 10.0},
+	}
+	err = md.Write("ns", "test1", uint32(1), uint32(10), 1564300800000, fields)
+	assert.NoError(t, err)
+	// same time slot, f1 is rejected, f2 is overwritten
+	fields[0].Value = 20.0
+	fields[1].Value = 20.0
+	err = md.Write("ns", "test1", uint32(1), uint32(10), 1564300800000, fields)
+	assert.Equal(t, field.ErrSlotCollision, err)
+	mStore, _ := md.mStores.Get(1)
+	tStore, _ := mStore.GetOrCreateTStore(10)
+	fStore, ok := tStore.GetFStore(0, 1)
+	assert.True(t, ok)
+	value, _ := fStore.(*fieldStore).getCurrentValue(0, 0)
+	assert.Equal(t, 10.0, value)
+	fStore, ok = tStore.GetFStore(0, 2)
+	assert.True(t, ok)
+	value, _ = fStore.(*fieldStore).getCurrentValue(0, 0)
+	assert.Equal(t, 20.0, value)
+	// other time slot
+	err = md.Write("ns", "test1", uint32(1), uint32(10), 1564300800000+10*timeutil.OneSecond, fields)
+	assert.NoError(t, err)
+}
+
+func TestMemoryDatabase_Write_field_type_conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMetadata := metadb.NewMockMetadata(ctrl)
+	mockMetadataDatabase := metadb.NewMockMetadataDatabase(ctrl)
+	mockMetadata.EXPECT().MetadataDatabase().Return(mockMetadataDatabase).AnyTimes()
+	// f1 is sum field, f2 is histogram field, written as gauge
+	for _, metricName := range []string{"strict", "coerce", "version"} {
+		mockMetadataDatabase.EXPECT().GenFieldID("ns", metricName, field.Name("f1"), field.SumField).
+			Return(field.ID(1), nil).AnyTimes()
+		mockMetadataDatabase.EXPECT().GenFieldID("ns", metricName, field.Name("f1"), field.GaugeField).
+			Return(field.ID(0), series.ErrWrongFieldType).AnyTimes()
+		mockMetadataDatabase.EXPECT().GenFieldID("ns", metricName, field.Name("f2"), field.GaugeField).
+			Return(field.ID(0), series.ErrWrongFieldType).AnyTimes()
+	}
+	dbCfg := cfg
+	dbCfg.Metadata = mockMetadata
+	dbCfg.ConflictPolicies = map[string]field.ConflictPolicy{"coerce": field.CoerceConflict, "version": field.VersionConflict}
+	mdINTF, err := NewMemoryDatabase(dbCfg)
+	assert.NoError(t, err)
+	md := mdINTF.(*memoryDatabase)
+	defer func() {
+		_ = md.Close()
+	}()
+	getValue := func(metricID uint32, fieldID field.ID) (float64, bool) {
+		mStore, _ := md.mStores.Get(metricID)
+		tStore, _ := mStore.GetOrCreateTStore(10)
+		fStore, ok := tStore.GetFStore(0, fieldID)
+		if !ok {
+			return 0, false
+		}
+		return fStore.(*fieldStore).getCurrentValue(0, 0)
+	}
+	write := func(metricID uint32, metricName string, fields ...*pb.Field) error {
+		return md.Write("ns", metricName, metricID, uint32(10), 1564300800000, fields)
+	}
+
+	// case 1: strict, rejects the conflicting write, other fields are still written
+	err = write(1, "strict",
+		&pb.Field{Name: "f1", Type: pb.FieldType_Gauge, Value: 10.0},
+		&pb.Field{Name: "f1", Type: pb.FieldType_Sum, Value: 5.0})
+	assert.True(t, errors.Is(err, series.ErrWrongFieldType))
+	value, ok := getValue(1, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, value)
+
+	// case 2: coerce, writes gauge value as existing sum field
+	mockMetadataDatabase.EXPECT().GetField("ns", "coerce", field.Name("f1")).
+		Return(field.Meta{ID: 1, Type: field.SumField, Name: "f1"}, nil)
+	err = write(2, "coerce", &pb.Field{Name: "f1", Type: pb.FieldType_Gauge, Value: 10.0})
+	assert.NoError(t, err)
+	err = write(2, "coerce", &pb.Field{Name: "f1", Type: pb.FieldType_Sum, Value: 5.0})
+	assert.NoError(t, err)
+	value, ok = getValue(2, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 15.0, value)
+	// cannot coerce to histogram
+	mockMetadataDatabase.EXPECT().GetField("ns", "coerce", field.Name("f2")).
+		Return(field.Meta{ID: 2, Type: field.HistogramField, Name: "f2"}, nil)
+	err = write(2, "coerce", &pb.Field{Name: "f2", Type: pb.FieldType_Gauge, Value: 10.0})
+	assert.True(t, errors.Is(err, series.ErrWrongFieldType))
+	// get field err
+	mockMetadataDatabase.EXPECT().GetField("ns", "coerce", field.Name("f2")).
+		Return(field.Meta{}, fmt.Errorf("err"))
+	err = write(2, "coerce", &pb.Field{Name: "f2", Type: pb.FieldType_Gauge, Value: 10.0})
+	assert.Error(t, err)
+
+	// case 3: version, writes gauge value into versioned field
+	mockMetadataDatabase.EXPECT().GenFieldID("ns", "version", field.Name("f1_gauge"), field.GaugeField).
+		Return(field.ID(3), nil)
+	err = write(3, "version", &pb.Field{Name: "f1", Type: pb.FieldType_Gauge, Value: 10.0})
+	assert.NoError(t, err)
+	value, ok = getValue(3, 3)
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, value)
+	_, ok = getValue(3, 1)
+	assert.False(t, ok)
+	// gen versioned field err
+	mockMetadataDatabase.EXPECT().GenFieldID("ns", "version", field.Name("f2_gauge"), field.GaugeField).
+		Return(field.ID(0), fmt.Errorf("err"))
+	err = write(3, "version", &pb.Field{Name: "f2", Type: pb.FieldType_Gauge, Value: 10.0})
+	assert.Error(t, err)
+}
+
 func TestMemoryDatabase_FlushFamilyTo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -63,8 +63,10 @@ type fStoreINTF interface {
 	GetFieldID() field.ID
 	// Write writes the field data into current buffer, returns the written size.
 	// if time slot out of current time window, need compress time window then resets the current buffer
-	// if has same time slot in current buffer, need do rollup operation by field type
+	// if has same time slot in current buffer, need do merge operation by merge policy
 	Write(fieldType field.Type, slotIndex uint16, value float64) (writtenSize int)
+	// HasValue checks if the time slot already has a value in current buffer or compress data
+	HasValue(slotIndex uint16) bool
 	// FlushFieldTo flushes field store data into kv store, need align slot range in metric level
 	FlushFieldTo(tableFlusher metricsdata.Flusher, fieldMeta field.Meta, flushCtx flushContext)
 	// Load loads field store data based on query time range, then appends data into series block
@@ -73,16 +75,18 @@ type fStoreINTF interface {
 
 // fieldStore implements fStoreINTF interface
 type fieldStore struct {
-	buf      []byte // current write buffer, accept write data
-	compress []byte // immutable compress data
+	buf      []byte            // current write buffer, accept write data
+	compress []byte            // immutable compress data
+	policy   field.MergePolicy // merge policy for the values of same time slot
 }
 
-// newFieldStore creates a new field store
-func newFieldStore(buf []byte, familyID familyID, fieldID field.ID) fStoreINTF {
+// newFieldStore creates a new field store with merge policy
+func newFieldStore(buf []byte, familyID familyID, fieldID field.ID, policy field.MergePolicy) fStoreINTF {
 	buf[familyOffset] = byte(familyID)
 	stream.PutUint16(buf, fieldOffset, uint16(fieldID))
 	return &fieldStore{
-		buf: buf,
+		buf:    buf,
+		policy: policy,
 	}
 }
 
@@ -104,7 +108,7 @@ func (fs *fieldStore) GetFieldID() field.ID {
 
 // Write writes the field data into current buffer, returns the written size.
 // if time slot out of current time window, need compress time window then resets the current buffer
-// if has same time slot in current buffer, need do rollup operation by field type
+// if has same time slot in current buffer, need do merge operation by merge policy
 func (fs *fieldStore) Write(fieldType field.Type, slotIndex uint16, value float64) (writtenSize int) {
 	if fs.buf[markOffset+1] == 0 {
 		// no data written before
@@ -129,7 +133,7 @@ func (fs *fieldStore) Write(fieldType field.Type, slotIndex uint16, value float6
 		// has same point of same time slot
 		aggFunc := fieldType.GetAggFunc()
 		oldValue := math.Float64frombits(binary.LittleEndian.Uint64(fs.buf[pos:]))
-		value = aggFunc.Aggregate(oldValue, value)
+		value = fs.policy.Merge(aggFunc, oldValue, value)
 	} else {
 		// new data for time slot
 		fs.buf[endOffset] = byte(delta)
@@ -141,6 +145,31 @@ func (fs *fieldStore) Write(fieldType field.Type, slotIndex uint16, value float6
 	return writtenSize
 }
 
+// HasValue checks if the time slot already has a value in current buffer or compress data
+func (fs *fieldStore) HasValue(slotIndex uint16) bool {
+	if fs.buf[markOffset+1] != 0 {
+		if _, ok := fs.getCurrentValue(fs.getStart(), slotIndex); ok {
+			return true
+		}
+	}
+	if len(fs.compress) == 0 {
+		return false
+	}
+	tsd := encoding.GetTSDDecoder()
+	defer encoding.ReleaseTSDDecoder(tsd)
+	tsd.Reset(fs.compress)
+	if slotIndex < tsd.StartTime() || slotIndex > tsd.EndTime() {
+		return false
+	}
+	// time slot and value are read in order
+	for i := tsd.StartTime(); i < slotIndex; i++ {
+		if tsd.HasValueWithSlot(i) {
+			_ = tsd.Value()
+		}
+	}
+	return tsd.HasValueWithSlot(slotIndex)
+}
+
 // FlushFieldTo flushes field store data into kv store, need align slot range in metric level
 func (fs *fieldStore) FlushFieldTo(tableFlusher metricsdata.Flusher, fieldMeta field.Meta, flushCtx flushContext) {
 	aggFunc := fieldMeta.Type.GetAggFunc()
@@ -247,7 +276,7 @@ func (fs *fieldStore) merge(
 		case hasNewValue && hasOldValue:
 			// merge and compress
 			encode.AppendTime(bit.One)
-			encode.AppendValue(math.Float64bits(aggFunc.Aggregate(newValue, oldValue)))
+			encode.AppendValue(math.Float64bits(fs.policy.Merge(aggFunc, oldValue, newValue)))
 		case !hasNewValue && hasOldValue:
 			// compress old value
 			encode.AppendTime(bit.One)
@@ -304,7 +333,7 @@ func (fs *fieldStore) Load(
 			value = newValue
 		case hasNewValue && hasOldValue:
 			// merge data from new and old
-			value = aggFunc.Aggregate(newValue, oldValue)
+			value = fs.policy.Merge(aggFunc, oldValue, newValue)
 		case !hasNewValue && hasOldValue:
 			// get old value from compress data
 			value = oldValue
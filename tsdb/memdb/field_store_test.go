@@ -20,7 +20,7 @@ var encodeFunc = encoding.NewTSDEncoder
 func TestFieldStore_New(t *testing.T) {
 	buf := make([]byte, pageSize)
 
-	store := newFieldStore(buf, familyID(12), field.ID(1))
+	store := newFieldStore(buf, familyID(12), field.ID(1), field.AggregateMerge)
 	assert.NotNil(t, store)
 	assert.Equal(t, familyID(12), store.GetFamilyID())
 	assert.Equal(t, field.ID(1), store.GetFieldID())
@@ -37,7 +37,7 @@ func TestFieldStore_Write(t *testing.T) {
 	defer ctrl.Finish()
 
 	buf := make([]byte, pageSize)
-	store := newFieldStore(buf, familyID(12), field.ID(1))
+	store := newFieldStore(buf, familyID(12), field.ID(1), field.AggregateMerge)
 	assert.NotNil(t, store)
 	s := store.(*fieldStore)
 
@@ -130,7 +130,7 @@ func TestFieldStore_Write(t *testing.T) {
 
 func TestFieldStore_Write2(t *testing.T) {
 	buf := make([]byte, pageSize)
-	store := newFieldStore(buf, familyID(12), field.ID(1))
+	store := newFieldStore(buf, familyID(12), field.ID(1), field.AggregateMerge)
 	s := store.(*fieldStore)
 	writtenSize := store.Write(field.SumField, 10, 178)
 	assert.Equal(t, valueSize+headLen, writtenSize)
@@ -160,7 +160,7 @@ func TestFieldStore_Write_Compact_err(t *testing.T) {
 	}
 
 	buf := make([]byte, pageSize)
-	store := newFieldStore(buf, familyID(12), field.ID(1))
+	store := newFieldStore(buf, familyID(12), field.ID(1), field.AggregateMerge)
 	assert.NotNil(t, store)
 	s := store.(*fieldStore)
 
@@ -190,7 +190,7 @@ func TestFieldStore_FlushFieldTo(t *testing.T) {
 	flusher := metricsdata.NewMockFlusher(ctrl)
 
 	buf := make([]byte, pageSize)
-	store := newFieldStore(buf, familyID(12), field.ID(2))
+	store := newFieldStore(buf, familyID(12), field.ID(2), field.AggregateMerge)
 	_ = store.Write(field.SumField, 10, 10.1)
 	_ = store.Write(field.SumField, 5, 5.1)
 
@@ -226,3 +226,48 @@ func mockFlushData() []byte {
 	d, _ := encode.BytesWithoutTime()
 	return d
 }
+
+func TestFieldStore_MergePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cases := []struct {
+		policy field.MergePolicy
+		expect float64
+	}{
+		{policy: field.AggregateMerge, expect: 5},
+		{policy: field.LastWriteWins, expect: 3},
+		{policy: field.SumMerge, expect: 8},
+		{policy: field.RejectMerge, expect: 5},
+	}
+	for _, c := range cases {
+		// collision in current write buffer
+		store := newFieldStore(make([]byte, pageSize), familyID(12), field.ID(1), c.policy)
+		s := store.(*fieldStore)
+		assert.False(t, store.HasValue(10))
+		_ = store.Write(field.MaxField, 10, 5)
+		assert.True(t, store.HasValue(10))
+		_ = store.Write(field.MaxField, 10, 3)
+		value, ok := s.getCurrentValue(10, 10)
+		assert.True(t, ok)
+		assert.InDelta(t, c.expect, value, 0, c.policy.String())
+
+		// collision between current write buffer and compress data
+		store = newFieldStore(make([]byte, pageSize), familyID(12), field.ID(1), c.policy)
+		_ = store.Write(field.MaxField, 10, 5)
+		_ = store.Write(field.MaxField, 100, 100) // compact slot 10
+		_ = store.Write(field.MaxField, 11, 11)   // compact slot 100
+		assert.True(t, store.HasValue(10))
+		assert.True(t, store.HasValue(100))
+		assert.False(t, store.HasValue(50))
+		assert.False(t, store.HasValue(200))
+		_ = store.Write(field.MaxField, 10, 3)
+		block := series.NewMockBlock(ctrl)
+		gomock.InOrder(
+			block.EXPECT().Append(10, c.expect).Return(false),
+			block.EXPECT().Append(11, 11.0).Return(false),
+			block.EXPECT().Append(100, 100.0).Return(true),
+		)
+		store.Load(field.MaxField, block, &memScanContext{tsd: encoding.GetTSDDecoder()})
+	}
+}
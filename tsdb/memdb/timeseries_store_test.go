@@ -16,7 +16,7 @@ func TestTimeSeriesStore_GetOrCreateFStore(t *testing.T) {
 	f, ok := tStore.GetFStore(1, 10)
 	assert.Nil(t, f)
 	assert.False(t, ok)
-	tStore.InsertFStore(newFieldStore(make([]byte, pageSize), 1, 10))
+	tStore.InsertFStore(newFieldStore(make([]byte, pageSize), 1, 10, field.AggregateMerge))
 	// get field store
 	f, ok = tStore.GetFStore(1, 10)
 	assert.NotNil(t, f)
@@ -26,8 +26,8 @@ func TestTimeSeriesStore_GetOrCreateFStore(t *testing.T) {
 	assert.Nil(t, f)
 	assert.False(t, ok)
 	for i := 1; i < 10; i++ {
-		tStore.InsertFStore(newFieldStore(make([]byte, pageSize), familyID(1*i), field.ID(10*i)))
-		tStore.InsertFStore(newFieldStore(make([]byte, pageSize), 1, 10))
+		tStore.InsertFStore(newFieldStore(make([]byte, pageSize), familyID(1*i), field.ID(10*i), field.AggregateMerge))
+		tStore.InsertFStore(newFieldStore(make([]byte, pageSize), 1, 10, field.AggregateMerge))
 		f, ok = tStore.GetFStore(1, 10)
 		assert.NotNil(t, f)
 		assert.True(t, ok)
@@ -69,7 +69,7 @@ func TestTimeSeriesStore_scan(t *testing.T) {
 	tStore := tStoreInterface.(*timeSeriesStore)
 
 	for i := 0; i < 10; i++ {
-		fStore := newFieldStore(make([]byte, pageSize), familyID(i), field.ID(i*10))
+		fStore := newFieldStore(make([]byte, pageSize), familyID(i), field.ID(i*10), field.AggregateMerge)
 		tStore.InsertFStore(fStore)
 		fStore.Write(field.SumField, uint16(i), 10.1)
 	}
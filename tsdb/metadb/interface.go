@@ -31,12 +31,34 @@ type IDGetter interface {
 	GetMetricID(namespace, metricName string) (metricID uint32, err error)
 	// GetTagKeyID gets the tag key id by namespace/metric name/tag key key, if not exist return series.ErrNotFound
 	GetTagKeyID(namespace, metricName, tagKey string) (tagKeyID uint32, err error)
+	// GetTagKeyIDByMetricID gets the tag key id by metric id/tag key, if not exist return series.ErrNotFound
+	GetTagKeyIDByMetricID(metricID uint32, tagKey string) (tagKeyID uint32, err error)
 	// GetAllTagKeys returns the all tag keys by namespace/metric name, if not exist return series.ErrNotFound
 	GetAllTagKeys(namespace, metricName string) (tags []tag.Meta, err error)
 	// GetField gets the field meta by namespace/metric name/field name, if not exist return series.ErrNotFound
 	GetField(namespace, metricName string, fieldName field.Name) (field field.Meta, err error)
 	// GetAllFields returns the  all fields by namespace/metric name, if not exist return series.ErrNotFound
 	GetAllFields(namespace, metricName string) (fields []field.Meta, err error)
+	// GetAllFieldsByMetricID returns the all fields by metric id, if not exist return series.ErrNotFound
+	GetAllFieldsByMetricID(metricID uint32) (fields []field.Meta, err error)
+	// GetFieldMeta gets the field meta by metric id/field id for reconstructing field meta when decodes field data,
+	// if metric not exist return constants.ErrMetricNotFound, if field not exist return constants.ErrFieldNotFound
+	GetFieldMeta(metricID uint32, fieldID field.ID) (f field.Meta, err error)
+	// GetAliasedFields gets the field metas which are equivalent to the field name by field alias,
+	// the aliased field is first if exist, if not exist return series.ErrNotFound
+	GetAliasedFields(namespace, metricName string, fieldName field.Name) (fields []field.Meta, err error)
+}
+
+// FieldAliasEditor represents the field alias editing ability, field alias makes the renamed field
+// equivalent to the old field name, so that the data of both names can be merged when querying.
+type FieldAliasEditor interface {
+	// SetFieldAlias sets the alias of the field name
+	SetFieldAlias(namespace, metricName string, alias, fieldName field.Name) error
+	// RemoveFieldAlias removes the field alias
+	RemoveFieldAlias(namespace, metricName string, alias field.Name) error
+	// GetFieldAliases returns the field alias map(key: alias, value: field name),
+	// if metric not exist return series.ErrNotFound
+	GetFieldAliases(namespace, metricName string) (aliases map[field.Name]field.Name, err error)
 }
 
 // Metadata represents all metadata of tsdb, like metric/tag metadata
@@ -47,6 +69,8 @@ type Metadata interface {
 	MetadataDatabase() MetadataDatabase
 	// TagMetadata returns the tag metadata
 	TagMetadata() TagMetadata
+	// SchemaCache returns the measurement level schema cache for query
+	SchemaCache() SchemaCache
 	// Flush flushes the metadata to disk
 	Flush() error
 }
@@ -56,10 +80,16 @@ type MetadataDatabase interface {
 	io.Closer
 	IDGetter
 	IDGenerator
+	FieldAliasEditor
 	series.MetricMetaSuggester
 
+	// GetSchemaVersion returns the schema version of metric, the version increases when field/tag key/field alias changed
+	GetSchemaVersion(namespace, metricName string) uint64
 	// SuggestNamespace suggests the namespace by namespace's prefix
 	SuggestNamespace(prefix string, limit int) (namespaces []string, err error)
+	// GetMetricNames returns the metric names of namespace sorted alphabetically, at most limit names,
+	// returns empty slice if no metric.
+	GetMetricNames(namespace string, limit int) (metricNames []string, err error)
 	// Sync syncs the pending metadata update event
 	Sync() error
 }
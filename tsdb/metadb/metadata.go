@@ -11,6 +11,7 @@ type metadata struct {
 	databaseName     string // database name
 	metadataDatabase MetadataDatabase
 	tagMetadata      TagMetadata
+	schemaCache      SchemaCache
 }
 
 // NewMetadata creates a metadata
@@ -23,6 +24,7 @@ func NewMetadata(ctx context.Context, databaseName, parent string, tagFamily kv.
 		metadataDatabase: db,
 		databaseName:     databaseName,
 		tagMetadata:      NewTagMetadata(databaseName, tagFamily),
+		schemaCache:      NewSchemaCache(db),
 	}, nil
 }
 
@@ -41,6 +43,11 @@ func (m *metadata) TagMetadata() TagMetadata {
 	return m.tagMetadata
 }
 
+// SchemaCache returns the measurement level schema cache for query
+func (m *metadata) SchemaCache() SchemaCache {
+	return m.schemaCache
+}
+
 // Close closes the metadata backend storage
 func (m *metadata) Close() error {
 	if err := m.metadataDatabase.Close(); err != nil {
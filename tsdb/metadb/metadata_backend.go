@@ -34,6 +34,7 @@ var (
 	metricBucketName = []byte("m")
 	tagBucketName    = []byte("t")
 	fieldBucketName  = []byte("f")
+	aliasBucketName  = []byte("a")
 )
 
 // MetadataBackend represents the metadata backend storage
@@ -74,6 +75,13 @@ type MetadataBackend interface {
 	// saveMetadata saves the pending metadata include namespace/metric metadata
 	saveMetadata(event *metadataUpdateEvent) error
 
+	// getFieldAliases returns the field alias map(key: alias, value: field name) by metric id
+	getFieldAliases(metricID uint32) (aliases map[field.Name]field.Name, err error)
+	// saveFieldAlias saves the field alias of metric
+	saveFieldAlias(metricID uint32, alias, fieldName field.Name) error
+	// removeFieldAlias removes the field alias of metric
+	removeFieldAlias(metricID uint32, alias field.Name) error
+
 	// sync syncs bbolt.DB file data
 	sync() error
 }
@@ -208,6 +216,7 @@ func (mb *metadataBackend) getMetricMetadata(metricID uint32) (metadata MetricMe
 	var fieldIDSeq int32
 	var tags []tag.Meta
 	var fields []field.Meta
+	var aliases map[field.Name]field.Name
 	binary.LittleEndian.PutUint32(scratch[:], metricID)
 	err = mb.db.View(func(tx *bbolt.Tx) error {
 		metricBucket := tx.Bucket(metricBucketName).Bucket(scratch[:])
@@ -218,6 +227,7 @@ func (mb *metadataBackend) getMetricMetadata(metricID uint32) (metadata MetricMe
 		fBucket := metricBucket.Bucket(fieldBucketName)
 		fieldIDSeq = int32(fBucket.Sequence())
 		fields = loadFields(fBucket)
+		aliases = loadFieldAliases(metricBucket.Bucket(aliasBucketName))
 		return nil
 	})
 	if err != nil {
@@ -227,6 +237,9 @@ func (mb *metadataBackend) getMetricMetadata(metricID uint32) (metadata MetricMe
 	metadata = newMetricMetadata(metricID, fieldIDSeq)
 	// initialize fields and tags
 	metadata.initialize(fields, tags)
+	for alias, fieldName := range aliases {
+		metadata.setFieldAlias(alias, fieldName)
+	}
 	return
 }
 
@@ -331,6 +344,61 @@ func (mb *metadataBackend) saveMetadata(event *metadataUpdateEvent) (err error)
 	return
 }
 
+// getFieldAliases returns the field alias map(key: alias, value: field name) by metric id
+func (mb *metadataBackend) getFieldAliases(metricID uint32) (aliases map[field.Name]field.Name, err error) {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	err = mb.db.View(func(tx *bbolt.Tx) error {
+		metricBucket := tx.Bucket(metricBucketName).Bucket(scratch[:])
+		if metricBucket == nil {
+			return constants.ErrNotFound
+		}
+		aliases = loadFieldAliases(metricBucket.Bucket(aliasBucketName))
+		return nil
+	})
+	return
+}
+
+// saveFieldAlias saves the field alias of metric,
+// creates the metric bucket if metric metadata not flushed yet.
+func (mb *metadataBackend) saveFieldAlias(metricID uint32, alias, fieldName field.Name) error {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	return mb.db.Update(func(tx *bbolt.Tx) error {
+		metricBucket, err := tx.Bucket(metricBucketName).CreateBucketIfNotExists(scratch[:])
+		if err != nil {
+			return err
+		}
+		for _, name := range [][]byte{fieldBucketName, tagBucketName} {
+			if _, err := metricBucket.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		aliasBucket, err := metricBucket.CreateBucketIfNotExists(aliasBucketName)
+		if err != nil {
+			return err
+		}
+		return aliasBucket.Put([]byte(alias), []byte(fieldName))
+	})
+}
+
+// removeFieldAlias removes the field alias of metric
+func (mb *metadataBackend) removeFieldAlias(metricID uint32, alias field.Name) error {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	return mb.db.Update(func(tx *bbolt.Tx) error {
+		metricBucket := tx.Bucket(metricBucketName).Bucket(scratch[:])
+		if metricBucket == nil {
+			return nil
+		}
+		aliasBucket := metricBucket.Bucket(aliasBucketName)
+		if aliasBucket == nil {
+			return nil
+		}
+		return aliasBucket.Delete([]byte(alias))
+	})
+}
+
 // sync syncs the bbolt.DB file data
 func (mb *metadataBackend) sync() error {
 	return mb.db.Sync()
@@ -354,6 +422,19 @@ func loadFields(fieldBucket *bbolt.Bucket) (fields []field.Meta) {
 	return
 }
 
+// loadFieldAliases loads the field aliases from alias bucket, alias bucket not exist if no alias
+func loadFieldAliases(aliasBucket *bbolt.Bucket) map[field.Name]field.Name {
+	aliases := make(map[field.Name]field.Name)
+	if aliasBucket == nil {
+		return aliases
+	}
+	cursor := aliasBucket.Cursor()
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		aliases[field.Name(k)] = field.Name(v)
+	}
+	return aliases
+}
+
 // loadTagKeys loads the tag keys from tag key bucket
 func loadTagKeys(tagKeyBucket *bbolt.Bucket) (tags []tag.Meta) {
 	cursor := tagKeyBucket.Cursor()
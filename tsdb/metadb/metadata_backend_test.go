@@ -392,3 +392,42 @@ func mockMetadataEvent() *metadataUpdateEvent {
 
 	return e
 }
+
+func TestMetadataBackend_fieldAlias(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	db := mockMetadataBackend(t)
+	aliases, err := db.getFieldAliases(2)
+	assert.NoError(t, err)
+	assert.Empty(t, aliases)
+	_, err = db.getFieldAliases(99)
+	assert.Equal(t, constants.ErrNotFound, err)
+
+	assert.NoError(t, db.saveFieldAlias(2, "f5", "f3"))
+	assert.NoError(t, db.saveFieldAlias(2, "f6", "f3"))
+	aliases, err = db.getFieldAliases(2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[field.Name]field.Name{"f5": "f3", "f6": "f3"}, aliases)
+	// load aliases with metric metadata
+	metadata, err := db.getMetricMetadata(2)
+	assert.NoError(t, err)
+	assert.Equal(t, aliases, metadata.getFieldAliases())
+
+	assert.NoError(t, db.removeFieldAlias(2, "f6"))
+	aliases, err = db.getFieldAliases(2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[field.Name]field.Name{"f5": "f3"}, aliases)
+	// remove not exist alias
+	assert.NoError(t, db.removeFieldAlias(99, "f6"))
+	assert.NoError(t, db.removeFieldAlias(1, "f6"))
+
+	// save alias before metric metadata flushed, then save fields
+	assert.NoError(t, db.saveFieldAlias(100, "f1", "f2"))
+	event := newMetadataUpdateEvent()
+	event.addField(100, field.Meta{ID: 1, Name: "f2", Type: field.SumField})
+	assert.NoError(t, db.saveMetadata(event))
+	metadata, err = db.getMetricMetadata(100)
+	assert.NoError(t, err)
+	assert.Equal(t, []field.Meta{{ID: 1, Name: "f2", Type: field.SumField}}, metadata.getAliasedFields("f1"))
+}
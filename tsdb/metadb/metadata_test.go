@@ -20,6 +20,7 @@ func TestNewMetadata(t *testing.T) {
 	metadata1, err := NewMetadata(context.TODO(), "test", testPath, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, metadata1.TagMetadata())
+	assert.NotNil(t, metadata1.SchemaCache())
 	assert.NotNil(t, metadata1.MetadataDatabase())
 	assert.Equal(t, "test", metadata1.DatabaseName())
 	metadata2, err := NewMetadata(context.TODO(), "test", testPath, nil)
@@ -83,6 +83,8 @@ type metadataDatabase struct {
 	cancel       context.CancelFunc
 	backend      MetadataBackend
 	metrics      map[string]MetricMetadata // metadata cache(key: namespace + metric-name, value: metric metadata)
+	// schema versions(key: namespace + metric-name, value: version), increased when field/tag key/field alias changed
+	schemaVersions map[string]uint64
 
 	metaWAL wal.MetricMetaWAL
 
@@ -122,6 +124,8 @@ func NewMetadataDatabase(ctx context.Context, databaseName, parent string) (Meta
 		metrics:      make(map[string]MetricMetadata),
 		metaWAL:      metaWAL,
 		syncInterval: syncInterval,
+
+		schemaVersions: make(map[string]uint64),
 	}
 	// meta recovery
 	mdb.metaRecovery()
@@ -145,6 +149,22 @@ func (mdb *metadataDatabase) SuggestMetricName(namespace, prefix string, limit i
 	return mdb.backend.suggestMetricName(namespace, prefix, limit)
 }
 
+// GetMetricNames returns the metric names of namespace sorted alphabetically, at most limit names,
+// returns empty slice if no metric.
+func (mdb *metadataDatabase) GetMetricNames(namespace string, limit int) (metricNames []string, err error) {
+	if limit > 0 {
+		// metric names are stored in order by backend, lists them with empty prefix
+		metricNames, err = mdb.backend.suggestMetricName(namespace, "", limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if metricNames == nil {
+		metricNames = []string{}
+	}
+	return metricNames, nil
+}
+
 // GetMetricID gets the metric id by namespace and metric name, if not exist return constants.ErrNotFound
 func (mdb *metadataDatabase) GetMetricID(namespace, metricName string) (metricID uint32, err error) {
 	mdb.rwMux.RLock()
@@ -161,6 +181,19 @@ func (mdb *metadataDatabase) GetMetricID(namespace, metricName string) (metricID
 	return mdb.backend.getMetricID(namespace, metricName)
 }
 
+// GetSchemaVersion returns the schema version of metric, the version increases when field/tag key/field alias changed
+func (mdb *metadataDatabase) GetSchemaVersion(namespace, metricName string) uint64 {
+	mdb.rwMux.RLock()
+	defer mdb.rwMux.RUnlock()
+	return mdb.schemaVersions[namespace+metricName]
+}
+
+// incSchemaVersion increases the schema version of metric,
+// !!!!! NOTICE: must hold the write lock
+func (mdb *metadataDatabase) incSchemaVersion(namespace, metricName string) {
+	mdb.schemaVersions[namespace+metricName]++
+}
+
 // GetTagKeyID gets the tag key id by namespace/metric name/tag key key, if not exist return constants.ErrNotFound
 func (mdb *metadataDatabase) GetTagKeyID(namespace, metricName string, tagKey string) (tagKeyID uint32, err error) {
 	key := namespace + metricName
@@ -185,6 +218,24 @@ func (mdb *metadataDatabase) GetTagKeyID(namespace, metricName string, tagKey st
 	return mdb.backend.getTagKeyID(metricID, tagKey)
 }
 
+// GetTagKeyIDByMetricID gets the tag key id by metric id/tag key, if not exist return constants.ErrNotFound
+func (mdb *metadataDatabase) GetTagKeyIDByMetricID(metricID uint32, tagKey string) (tagKeyID uint32, err error) {
+	mdb.rwMux.RLock()
+	for _, metricMetadata := range mdb.metrics {
+		if metricMetadata.getMetricID() == metricID {
+			tagKeyID, ok := metricMetadata.getTagKeyID(tagKey)
+			mdb.rwMux.RUnlock()
+			if ok {
+				return tagKeyID, nil
+			}
+			return 0, constants.ErrNotFound
+		}
+	}
+	mdb.rwMux.RUnlock()
+
+	return mdb.backend.getTagKeyID(metricID, tagKey)
+}
+
 // GetAllTagKeys returns the all tag keys by namespace/metric name, if not exist return constants.ErrNotFound
 func (mdb *metadataDatabase) GetAllTagKeys(namespace, metricName string) (tags []tag.Meta, err error) {
 	key := namespace + metricName
@@ -215,6 +266,10 @@ func (mdb *metadataDatabase) GetField(namespace, metricName string, fieldName fi
 		if ok {
 			return f, nil
 		}
+		// try resolve the field by alias
+		if fields := metricMetadata.getAliasedFields(fieldName); len(fields) > 0 {
+			return fields[0], nil
+		}
 		return field.Meta{}, constants.ErrNotFound
 	}
 	mdb.rwMux.RUnlock()
@@ -224,7 +279,112 @@ func (mdb *metadataDatabase) GetField(namespace, metricName string, fieldName fi
 	}
 
 	// read from db
-	return mdb.backend.getField(metricID, fieldName)
+	f, err = mdb.backend.getField(metricID, fieldName)
+	if err != constants.ErrNotFound {
+		return f, err
+	}
+	// try resolve the field by alias
+	aliases, err := mdb.backend.getFieldAliases(metricID)
+	if err != nil {
+		return field.Meta{}, err
+	}
+	if name, ok := aliases[fieldName]; ok {
+		return mdb.backend.getField(metricID, name)
+	}
+	return field.Meta{}, constants.ErrNotFound
+}
+
+// GetAliasedFields gets the field metas which are equivalent to the field name by field alias,
+// the aliased field is first if exist, if not exist return constants.ErrNotFound
+func (mdb *metadataDatabase) GetAliasedFields(namespace, metricName string,
+	fieldName field.Name,
+) (fields []field.Meta, err error) {
+	key := namespace + metricName
+	mdb.rwMux.RLock()
+	metricMetadata, ok := mdb.metrics[key]
+	mdb.rwMux.RUnlock()
+	if !ok {
+		mdb.rwMux.Lock()
+		metricMetadata, err = mdb.loadMetricMetadata(namespace, metricName)
+		mdb.rwMux.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mdb.rwMux.RLock()
+	defer mdb.rwMux.RUnlock()
+	fields = metricMetadata.getAliasedFields(fieldName)
+	if len(fields) == 0 {
+		return nil, constants.ErrNotFound
+	}
+	return fields, nil
+}
+
+// SetFieldAlias sets the alias of the field name, persists the alias into backend storage
+func (mdb *metadataDatabase) SetFieldAlias(namespace, metricName string, alias, fieldName field.Name) error {
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	metricMetadata, err := mdb.loadMetricMetadata(namespace, metricName)
+	if err != nil {
+		return err
+	}
+	if err := metricMetadata.checkFieldAlias(alias, fieldName); err != nil {
+		return err
+	}
+	if err := mdb.backend.saveFieldAlias(metricMetadata.getMetricID(), alias, fieldName); err != nil {
+		return err
+	}
+	metricMetadata.setFieldAlias(alias, fieldName)
+	mdb.incSchemaVersion(namespace, metricName)
+	return nil
+}
+
+// RemoveFieldAlias removes the field alias from backend storage
+func (mdb *metadataDatabase) RemoveFieldAlias(namespace, metricName string, alias field.Name) error {
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	metricMetadata, err := mdb.loadMetricMetadata(namespace, metricName)
+	if err != nil {
+		return err
+	}
+	if err := mdb.backend.removeFieldAlias(metricMetadata.getMetricID(), alias); err != nil {
+		return err
+	}
+	metricMetadata.removeFieldAlias(alias)
+	mdb.incSchemaVersion(namespace, metricName)
+	return nil
+}
+
+// GetFieldAliases returns the field alias map(key: alias, value: field name),
+// if metric not exist return constants.ErrNotFound
+func (mdb *metadataDatabase) GetFieldAliases(namespace, metricName string) (aliases map[field.Name]field.Name, err error) {
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	metricMetadata, err := mdb.loadMetricMetadata(namespace, metricName)
+	if err != nil {
+		return nil, err
+	}
+	return metricMetadata.getFieldAliases(), nil
+}
+
+// loadMetricMetadata returns the metric metadata from memory, if not exist loads it from backend storage,
+// !!!!! NOTICE: must hold the write lock
+func (mdb *metadataDatabase) loadMetricMetadata(namespace, metricName string) (MetricMetadata, error) {
+	key := namespace + metricName
+	metricMetadata, ok := mdb.metrics[key]
+	if ok {
+		return metricMetadata, nil
+	}
+	metricMetadata, err := mdb.backend.loadMetricMetadata(namespace, metricName)
+	if err != nil {
+		return nil, err
+	}
+	mdb.metrics[key] = metricMetadata
+	return metricMetadata, nil
 }
 
 func (mdb *metadataDatabase) GetAllFields(namespace, metricName string) (fields []field.Meta, err error) {
@@ -243,6 +403,54 @@ func (mdb *metadataDatabase) GetAllFields(namespace, metricName string) (fields
 	return mdb.backend.getAllFields(metricID)
 }
 
+// GetAllFieldsByMetricID returns the all fields by metric id, if not exist return constants.ErrNotFound
+func (mdb *metadataDatabase) GetAllFieldsByMetricID(metricID uint32) (fields []field.Meta, err error) {
+	mdb.rwMux.RLock()
+	for _, metricMetadata := range mdb.metrics {
+		if metricMetadata.getMetricID() == metricID {
+			fields = metricMetadata.getAllFields()
+			mdb.rwMux.RUnlock()
+			return fields, nil
+		}
+	}
+	mdb.rwMux.RUnlock()
+
+	return mdb.backend.getAllFields(metricID)
+}
+
+// GetFieldMeta gets the field meta by metric id/field id for reconstructing field meta when decodes field data,
+// if metric not exist return constants.ErrMetricNotFound, if field not exist return constants.ErrFieldNotFound
+func (mdb *metadataDatabase) GetFieldMeta(metricID uint32, fieldID field.ID) (f field.Meta, err error) {
+	var fields []field.Meta
+	found := false
+	mdb.rwMux.RLock()
+	//FIXME use metric id index???
+	for _, metricMetadata := range mdb.metrics {
+		if metricMetadata.getMetricID() == metricID {
+			fields = metricMetadata.getAllFields()
+			found = true
+			break
+		}
+	}
+	mdb.rwMux.RUnlock()
+	if !found {
+		// read from db
+		fields, err = mdb.backend.getAllFields(metricID)
+		if err == constants.ErrNotFound {
+			return field.Meta{}, constants.ErrMetricNotFound
+		}
+		if err != nil {
+			return field.Meta{}, err
+		}
+	}
+	for _, fieldMeta := range fields {
+		if fieldMeta.ID == fieldID {
+			return fieldMeta, nil
+		}
+	}
+	return field.Meta{}, constants.ErrFieldNotFound
+}
+
 // GenMetricID generates the metric id in the memory.
 // 1) get metric id from memory if exist, if not exist goto 2
 // 2) get metric metadata from backend storage, if not exist need create new metric metadata
@@ -330,6 +538,7 @@ func (mdb *metadataDatabase) GenFieldID(namespace, metricName string,
 		Type: fieldType,
 		Name: fieldName,
 	})
+	mdb.incSchemaVersion(namespace, metricName)
 
 	genFieldIDCounter.WithLabelValues(mdb.databaseName).Inc()
 
@@ -364,6 +573,7 @@ func (mdb *metadataDatabase) GenTagKeyID(namespace, metricName, tagKey string) (
 	}
 
 	metricMetadata.createTagKey(tagKey, tagKeyID)
+	mdb.incSchemaVersion(namespace, metricName)
 
 	genMetricIDCounter.WithLabelValues(mdb.databaseName).Inc()
 	return
@@ -91,6 +91,44 @@ func TestMetadataDatabase_SuggestNamespace(t *testing.T) {
 	_ = db.Close()
 }
 
+func TestMetadataDatabase_GetMetricNames(t *testing.T) {
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		backend, err = newMetadataBackend(parent)
+		if err != nil {
+			return nil, err
+		}
+		event := newMetadataUpdateEvent()
+		event.addMetric("ns", "memory", 1)
+		event.addMetric("ns", "cpu", 2)
+		event.addMetric("ns", "disk", 3)
+		event.addMetric("other-ns", "jvm", 4)
+		return backend, backend.saveMetadata(event)
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+
+	metricNames, err := db.GetMetricNames("ns", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "disk", "memory"}, metricNames)
+	metricNames, err = db.GetMetricNames("ns", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "disk"}, metricNames)
+	metricNames, err = db.GetMetricNames("ns", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, metricNames)
+	// no metric in namespace
+	metricNames, err = db.GetMetricNames("not-exist-ns", 10)
+	assert.NoError(t, err)
+	assert.NotNil(t, metricNames)
+	assert.Empty(t, metricNames)
+
+	_ = db.Close()
+}
+
 func TestMetadataDatabase_SuggestMetricName(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -281,6 +319,7 @@ func TestMetadataDatabase_GetField(t *testing.T) {
 
 	// case 2: from memory not exist
 	meta.EXPECT().getField(field.Name("f1")).Return(field.Meta{}, false)
+	meta.EXPECT().getAliasedFields(field.Name("f1")).Return(nil)
 	f, err = db.GetField("ns-1", "name1", "f1")
 	assert.Equal(t, constants.ErrNotFound, err)
 	assert.Equal(t, field.Meta{}, f)
@@ -322,6 +361,122 @@ func TestMetadataDatabase_GetField(t *testing.T) {
 	_ = db.Close()
 }
 
+func TestMetadataDatabase_GetTagKeyIDByMetricID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+	mockBackend := NewMockMetadataBackend(ctrl)
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		return mockBackend, nil
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	meta := NewMockMetricMetadata(ctrl)
+	mockBackend.EXPECT().loadMetricMetadata("ns-1", "name1").Return(meta, nil)
+	meta.EXPECT().getMetricID().Return(uint32(1)).AnyTimes()
+	_, err = db.GenMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+
+	// case 1: from memory
+	meta.EXPECT().getTagKeyID("host").Return(uint32(5), true)
+	tagKeyID, err := db.GetTagKeyIDByMetricID(1, "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(5), tagKeyID)
+	// case 2: tag key not exist in memory
+	meta.EXPECT().getTagKeyID("ip").Return(uint32(0), false)
+	_, err = db.GetTagKeyIDByMetricID(1, "ip")
+	assert.Equal(t, constants.ErrNotFound, err)
+	// case 3: from backend
+	mockBackend.EXPECT().getTagKeyID(uint32(10), "host").Return(uint32(8), nil)
+	tagKeyID, err = db.GetTagKeyIDByMetricID(10, "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(8), tagKeyID)
+}
+
+func TestMetadataDatabase_GetAllFieldsByMetricID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+	mockBackend := NewMockMetadataBackend(ctrl)
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		return mockBackend, nil
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	meta := NewMockMetricMetadata(ctrl)
+	mockBackend.EXPECT().loadMetricMetadata("ns-1", "name1").Return(meta, nil)
+	meta.EXPECT().getMetricID().Return(uint32(1)).AnyTimes()
+	_, err = db.GenMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+
+	// case 1: from memory
+	meta.EXPECT().getAllFields().Return([]field.Meta{{ID: 1, Name: "f"}})
+	fields, err := db.GetAllFieldsByMetricID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []field.Meta{{ID: 1, Name: "f"}}, fields)
+	// case 2: from backend
+	mockBackend.EXPECT().getAllFields(uint32(10)).Return(nil, constants.ErrNotFound)
+	_, err = db.GetAllFieldsByMetricID(10)
+	assert.Equal(t, constants.ErrNotFound, err)
+}
+
+func TestMetadataDatabase_GetFieldMeta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+	mockBackend := NewMockMetadataBackend(ctrl)
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		return mockBackend, nil
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	meta := NewMockMetricMetadata(ctrl)
+	mockBackend.EXPECT().loadMetricMetadata("ns-1", "name1").Return(meta, nil)
+	meta.EXPECT().getMetricID().Return(uint32(1)).AnyTimes()
+	_, err = db.GenMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+
+	// case 1: from memory
+	meta.EXPECT().getAllFields().Return([]field.Meta{{ID: 1, Name: "f1", Type: field.SumField}}).Times(2)
+	f, err := db.GetFieldMeta(1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Meta{ID: 1, Name: "f1", Type: field.SumField}, f)
+	// case 2: field id not exist
+	f, err = db.GetFieldMeta(1, 2)
+	assert.Equal(t, constants.ErrFieldNotFound, err)
+	assert.Equal(t, field.Meta{}, f)
+	// case 3: backend, metric not exist
+	mockBackend.EXPECT().getAllFields(uint32(10)).Return(nil, constants.ErrNotFound)
+	f, err = db.GetFieldMeta(10, 1)
+	assert.Equal(t, constants.ErrMetricNotFound, err)
+	assert.Equal(t, field.Meta{}, f)
+	// case 4: backend err
+	mockBackend.EXPECT().getAllFields(uint32(10)).Return(nil, fmt.Errorf("err"))
+	_, err = db.GetFieldMeta(10, 1)
+	assert.Error(t, err)
+	// case 5: backend exist
+	mockBackend.EXPECT().getAllFields(uint32(10)).Return([]field.Meta{{ID: 3, Name: "f3", Type: field.GaugeField}}, nil)
+	f, err = db.GetFieldMeta(10, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, field.Meta{ID: 3, Name: "f3", Type: field.GaugeField}, f)
+
+	mockBackend.EXPECT().saveMetadata(gomock.Any()).AnyTimes()
+	mockBackend.EXPECT().Close().Return(nil)
+	_ = db.Close()
+}
+
 func TestMetadataDatabase_GenMetricID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -818,3 +973,139 @@ func newMockMetadataDatabase(t *testing.T) MetadataDatabase {
 
 	return db
 }
+
+func TestMetadataDatabase_FieldAlias(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	_, err = db.GenMetricID("ns", "cpu")
+	assert.NoError(t, err)
+	oldID, err := db.GenFieldID("ns", "cpu", "usage", field.SumField)
+	assert.NoError(t, err)
+	newID, err := db.GenFieldID("ns", "cpu", "usage_pct", field.SumField)
+	assert.NoError(t, err)
+
+	// metric not exist
+	err = db.SetFieldAlias("ns", "no-metric", "usage", "usage_pct")
+	assert.Equal(t, constants.ErrNotFound, err)
+	err = db.SetFieldAlias("ns", "cpu", "usage", "usage")
+	assert.Equal(t, series.ErrInvalidFieldAlias, err)
+	err = db.SetFieldAlias("ns", "cpu", "usage", "usage_pct")
+	assert.NoError(t, err)
+	err = db.SetFieldAlias("ns", "cpu", "usage_old", "usage_pct")
+	assert.NoError(t, err)
+	aliases, err := db.GetFieldAliases("ns", "cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, map[field.Name]field.Name{"usage": "usage_pct", "usage_old": "usage_pct"}, aliases)
+
+	fields, err := db.GetAliasedFields("ns", "cpu", "usage")
+	assert.NoError(t, err)
+	assert.Equal(t, []field.ID{newID, oldID}, []field.ID{fields[0].ID, fields[1].ID})
+	f, err := db.GetField("ns", "cpu", "usage_old")
+	assert.NoError(t, err)
+	assert.Equal(t, newID, f.ID)
+	_, err = db.GetAliasedFields("ns", "cpu", "no-f")
+	assert.Equal(t, constants.ErrNotFound, err)
+
+	err = db.RemoveFieldAlias("ns", "cpu", "usage_old")
+	assert.NoError(t, err)
+	err = db.RemoveFieldAlias("ns", "no-metric", "usage_old")
+	assert.Equal(t, constants.ErrNotFound, err)
+	_, err = db.GetFieldAliases("ns", "no-metric")
+	assert.Equal(t, constants.ErrNotFound, err)
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// reopen, field aliases are persisted
+	db, err = NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	// resolve field by alias from backend
+	f, err = db.GetField("ns", "cpu", "usage_pct")
+	assert.NoError(t, err)
+	assert.Equal(t, newID, f.ID)
+	_, err = db.GetField("ns", "cpu", "usage_old")
+	assert.Equal(t, constants.ErrNotFound, err)
+	fields, err = db.GetAliasedFields("ns", "cpu", "usage_pct")
+	assert.NoError(t, err)
+	assert.Equal(t, []field.ID{newID, oldID}, []field.ID{fields[0].ID, fields[1].ID})
+	aliases, err = db.GetFieldAliases("ns", "cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, map[field.Name]field.Name{"usage": "usage_pct"}, aliases)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestMetadataDatabase_FieldAlias_backend_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	backend := NewMockMetadataBackend(ctrl)
+	metadata := newMetricMetadata(1, 0)
+	metadata.initialize([]field.Meta{{ID: 1, Name: "f", Type: field.SumField}}, nil)
+	db := &metadataDatabase{
+		backend: backend,
+		metrics: map[string]MetricMetadata{"nscpu": metadata},
+	}
+	backend.EXPECT().saveFieldAlias(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, db.SetFieldAlias("ns", "cpu", "f1", "f"))
+	backend.EXPECT().removeFieldAlias(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, db.RemoveFieldAlias("ns", "cpu", "f1"))
+
+	// get field by alias from backend
+	db.metrics = make(map[string]MetricMetadata)
+	backend.EXPECT().getMetricID(gomock.Any(), gomock.Any()).Return(uint32(1), nil).AnyTimes()
+	backend.EXPECT().getField(gomock.Any(), gomock.Any()).Return(field.Meta{}, constants.ErrNotFound)
+	backend.EXPECT().getFieldAliases(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	_, err := db.GetField("ns", "cpu", "f1")
+	assert.Error(t, err)
+	backend.EXPECT().loadMetricMetadata(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	_, err = db.GetAliasedFields("ns", "cpu", "f1")
+	assert.Error(t, err)
+}
+
+func TestMetadataDatabase_SchemaVersion(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	cache := NewSchemaCache(db)
+	_, err = db.GenMetricID("ns", "cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), db.GetSchemaVersion("ns", "cpu"))
+	// new field/tag key bumps schema version
+	_, err = db.GenFieldID("ns", "cpu", "f", field.SumField)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), db.GetSchemaVersion("ns", "cpu"))
+	_, err = db.GenFieldID("ns", "cpu", "f", field.SumField)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), db.GetSchemaVersion("ns", "cpu"))
+	_, err = db.GenTagKeyID("ns", "cpu", "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), db.GetSchemaVersion("ns", "cpu"))
+
+	fields, err := cache.GetAliasedFields("ns", "cpu", "f")
+	assert.NoError(t, err)
+	assert.Len(t, fields, 1)
+	// field alias bumps schema version, cached fields is invalidated
+	_, err = db.GenFieldID("ns", "cpu", "f1", field.SumField)
+	assert.NoError(t, err)
+	assert.NoError(t, db.SetFieldAlias("ns", "cpu", "f", "f1"))
+	assert.Equal(t, uint64(4), db.GetSchemaVersion("ns", "cpu"))
+	fields, err = cache.GetAliasedFields("ns", "cpu", "f")
+	assert.NoError(t, err)
+	assert.Len(t, fields, 2)
+	assert.NoError(t, db.RemoveFieldAlias("ns", "cpu", "f"))
+	assert.Equal(t, uint64(5), db.GetSchemaVersion("ns", "cpu"))
+	fields, err = cache.GetAliasedFields("ns", "cpu", "f")
+	assert.NoError(t, err)
+	assert.Len(t, fields, 1)
+	assert.Equal(t, uint64(0), db.GetSchemaVersion("ns", "no-metric"))
+	assert.NoError(t, db.Close())
+}
@@ -1,6 +1,8 @@
 package metadb
 
 import (
+	"sort"
+
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/constants"
@@ -39,6 +41,18 @@ type MetricMetadata interface {
 	addField(f field.Meta)
 	// createTagKey creates the tag key
 	createTagKey(tagKey string, tagKeyID uint32)
+
+	// getFieldAliases returns the field alias map(key: alias, value: field name) of the metric
+	getFieldAliases() map[field.Name]field.Name
+	// checkFieldAlias checks if the alias can be set to the field name
+	checkFieldAlias(alias, fieldName field.Name) error
+	// setFieldAlias sets the field alias, makes the alias equivalent to the field name
+	setFieldAlias(alias, fieldName field.Name)
+	// removeFieldAlias removes the field alias
+	removeFieldAlias(alias field.Name)
+	// getAliasedFields returns the field metas which are equivalent to the field name,
+	// includes the field name self and all its aliases, the aliased field is first if exist
+	getAliasedFields(fieldName field.Name) (fields []field.Meta)
 }
 
 // metricMetadata implements MetricMetadata interface
@@ -47,12 +61,14 @@ type metricMetadata struct {
 	fieldIDSeq atomic.Int32
 	fields     []field.Meta
 	tagKeys    []tag.Meta
+	aliases    map[field.Name]field.Name // alias => field name
 }
 
 // newMetricMetadata creates the metric metadata with metric id and field id assign sequence
 func newMetricMetadata(metricID uint32, fieldIDSeq int32) MetricMetadata {
 	mm := &metricMetadata{
 		metricID: metricID,
+		aliases:  make(map[field.Name]field.Name),
 	}
 	mm.fieldIDSeq.Store(fieldIDSeq)
 	return mm
@@ -136,3 +152,65 @@ func (mm *metricMetadata) checkTagKeyCount() error {
 func (mm *metricMetadata) createTagKey(tagKey string, tagKeyID uint32) {
 	mm.tagKeys = append(mm.tagKeys, tag.Meta{ID: tagKeyID, Key: tagKey})
 }
+
+// getFieldAliases returns the field alias map(key: alias, value: field name) of the metric
+func (mm *metricMetadata) getFieldAliases() map[field.Name]field.Name {
+	aliases := make(map[field.Name]field.Name, len(mm.aliases))
+	for alias, fieldName := range mm.aliases {
+		aliases[alias] = fieldName
+	}
+	return aliases
+}
+
+// checkFieldAlias checks if the alias can be set to the field name,
+// alias chain is not allowed, because the alias resolution is only one level.
+func (mm *metricMetadata) checkFieldAlias(alias, fieldName field.Name) error {
+	if alias == "" || fieldName == "" || alias == fieldName {
+		return series.ErrInvalidFieldAlias
+	}
+	// field name cannot be an alias of other field
+	if _, ok := mm.aliases[fieldName]; ok {
+		return series.ErrInvalidFieldAlias
+	}
+	// alias cannot be aliased by other alias
+	for _, name := range mm.aliases {
+		if name == alias {
+			return series.ErrInvalidFieldAlias
+		}
+	}
+	return nil
+}
+
+// setFieldAlias sets the field alias, makes the alias equivalent to the field name
+func (mm *metricMetadata) setFieldAlias(alias, fieldName field.Name) {
+	mm.aliases[alias] = fieldName
+}
+
+// removeFieldAlias removes the field alias
+func (mm *metricMetadata) removeFieldAlias(alias field.Name) {
+	delete(mm.aliases, alias)
+}
+
+// getAliasedFields returns the field metas which are equivalent to the field name,
+// includes the field name self and all its aliases, the aliased field is first if exist
+func (mm *metricMetadata) getAliasedFields(fieldName field.Name) (fields []field.Meta) {
+	if name, ok := mm.aliases[fieldName]; ok {
+		fieldName = name
+	}
+	for alias, name := range mm.aliases {
+		if name != fieldName {
+			continue
+		}
+		if f, ok := mm.getField(alias); ok {
+			fields = append(fields, f)
+		}
+	}
+	// keep the order of aliases stable
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].ID < fields[j].ID
+	})
+	if f, ok := mm.getField(fieldName); ok {
+		fields = append([]field.Meta{f}, fields...)
+	}
+	return fields
+}
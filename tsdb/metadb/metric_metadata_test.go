@@ -107,3 +107,40 @@ func TestMetricMetadata_rollback(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, field.ID(2), fieldID)
 }
+
+func TestMetricMetadata_fieldAlias(t *testing.T) {
+	mm := newMetricMetadata(1, 0)
+	mm.initialize([]field.Meta{
+		{ID: 1, Name: "usage", Type: field.SumField},
+		{ID: 2, Name: "usage_pct", Type: field.SumField},
+		{ID: 3, Name: "usage_rate", Type: field.SumField},
+	}, nil)
+	assert.Empty(t, mm.getFieldAliases())
+	assert.Equal(t, []field.Meta{{ID: 1, Name: "usage", Type: field.SumField}}, mm.getAliasedFields("usage"))
+	assert.Empty(t, mm.getAliasedFields("no-f"))
+
+	assert.Equal(t, series.ErrInvalidFieldAlias, mm.checkFieldAlias("", "usage_pct"))
+	assert.Equal(t, series.ErrInvalidFieldAlias, mm.checkFieldAlias("usage", "usage"))
+	assert.NoError(t, mm.checkFieldAlias("usage", "usage_pct"))
+	mm.setFieldAlias("usage", "usage_pct")
+	mm.setFieldAlias("usage_rate", "usage_pct")
+	mm.setFieldAlias("usage_old", "usage_pct")
+	// alias chain
+	assert.Equal(t, series.ErrInvalidFieldAlias, mm.checkFieldAlias("usage_pct", "f"))
+	assert.Equal(t, series.ErrInvalidFieldAlias, mm.checkFieldAlias("f", "usage"))
+
+	expect := []field.Meta{
+		{ID: 2, Name: "usage_pct", Type: field.SumField},
+		{ID: 1, Name: "usage", Type: field.SumField},
+		{ID: 3, Name: "usage_rate", Type: field.SumField},
+	}
+	assert.Equal(t, expect, mm.getAliasedFields("usage_pct"))
+	assert.Equal(t, expect, mm.getAliasedFields("usage"))
+	assert.Equal(t, map[field.Name]field.Name{
+		"usage": "usage_pct", "usage_rate": "usage_pct", "usage_old": "usage_pct",
+	}, mm.getFieldAliases())
+
+	mm.removeFieldAlias("usage_rate")
+	assert.Equal(t, expect[:2], mm.getAliasedFields("usage"))
+	assert.Equal(t, []field.Meta{{ID: 3, Name: "usage_rate", Type: field.SumField}}, mm.getAliasedFields("usage_rate"))
+}
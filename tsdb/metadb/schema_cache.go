@@ -0,0 +1,136 @@
+package metadb
+
+import (
+	"sync"
+
+	"github.com/lindb/lindb/series/field"
+)
+
+//go:generate mockgen -source ./schema_cache.go -destination=./schema_cache_mock.go -package=metadb
+
+// maxSchemaReadRetries represents the max retries when schema changed during reading metadata
+const maxSchemaReadRetries = 3
+
+// SchemaCache represents the measurement level schema cache(field metas/tag keys) for query,
+// the cached schema is invalidated when the schema version of metric changed.
+type SchemaCache interface {
+	// GetAliasedFields gets the field metas which are equivalent to the field name by field alias,
+	// if not exist return constants.ErrNotFound
+	GetAliasedFields(namespace, metricName string, fieldName field.Name) (fields []field.Meta, err error)
+	// GetTagKeyID gets the tag key id by namespace/metric name/tag key key, if not exist return constants.ErrNotFound
+	GetTagKeyID(namespace, metricName, tagKey string) (tagKeyID uint32, err error)
+}
+
+// measurementSchema represents the cached schema of metric with schema version
+type measurementSchema struct {
+	version uint64
+	fields  map[field.Name][]field.Meta
+	tagKeys map[string]uint32
+}
+
+// newMeasurementSchema creates the empty measurement schema with schema version
+func newMeasurementSchema(version uint64) *measurementSchema {
+	return &measurementSchema{
+		version: version,
+		fields:  make(map[field.Name][]field.Meta),
+		tagKeys: make(map[string]uint32),
+	}
+}
+
+// schemaCache implements SchemaCache interface
+type schemaCache struct {
+	db      MetadataDatabase
+	schemas map[string]*measurementSchema // key: namespace + metric-name
+
+	rwMux sync.RWMutex
+}
+
+// NewSchemaCache creates the schema cache based on metadata database
+func NewSchemaCache(db MetadataDatabase) SchemaCache {
+	return &schemaCache{
+		db:      db,
+		schemas: make(map[string]*measurementSchema),
+	}
+}
+
+// GetAliasedFields gets the field metas which are equivalent to the field name by field alias,
+// if not exist return constants.ErrNotFound
+func (c *schemaCache) GetAliasedFields(namespace, metricName string, fieldName field.Name) (fields []field.Meta, err error) {
+	err = c.load(namespace, metricName,
+		func(schema *measurementSchema) (ok bool) {
+			fields, ok = schema.fields[fieldName]
+			return
+		},
+		func() (err error) {
+			fields, err = c.db.GetAliasedFields(namespace, metricName, fieldName)
+			return
+		},
+		func(schema *measurementSchema) {
+			schema.fields[fieldName] = fields
+		})
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// GetTagKeyID gets the tag key id by namespace/metric name/tag key key, if not exist return constants.ErrNotFound
+func (c *schemaCache) GetTagKeyID(namespace, metricName, tagKey string) (tagKeyID uint32, err error) {
+	err = c.load(namespace, metricName,
+		func(schema *measurementSchema) (ok bool) {
+			tagKeyID, ok = schema.tagKeys[tagKey]
+			return
+		},
+		func() (err error) {
+			tagKeyID, err = c.db.GetTagKeyID(namespace, metricName, tagKey)
+			return
+		},
+		func(schema *measurementSchema) {
+			schema.tagKeys[tagKey] = tagKeyID
+		})
+	if err != nil {
+		return 0, err
+	}
+	return tagKeyID, nil
+}
+
+// load gets the schema item from cache if schema version not changed, else reads it from metadata database,
+// if schema changed during reading, the read result maybe stale, retries it.
+func (c *schemaCache) load(namespace, metricName string,
+	get func(schema *measurementSchema) bool,
+	read func() error,
+	put func(schema *measurementSchema),
+) error {
+	key := namespace + metricName
+	for i := 0; i < maxSchemaReadRetries; i++ {
+		version := c.db.GetSchemaVersion(namespace, metricName)
+		c.rwMux.RLock()
+		schema, ok := c.schemas[key]
+		if ok && schema.version == version && get(schema) {
+			c.rwMux.RUnlock()
+			return nil
+		}
+		c.rwMux.RUnlock()
+
+		if err := read(); err != nil {
+			return err
+		}
+		if c.db.GetSchemaVersion(namespace, metricName) != version {
+			// schema changed during reading, retry
+			continue
+		}
+		c.rwMux.Lock()
+		schema, ok = c.schemas[key]
+		if !ok || schema.version < version {
+			schema = newMeasurementSchema(version)
+			c.schemas[key] = schema
+		}
+		if schema.version == version {
+			put(schema)
+		}
+		c.rwMux.Unlock()
+		return nil
+	}
+	// schema changes frequently, returns the latest read result without caching
+	return read()
+}
@@ -0,0 +1,115 @@
+package metadb
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestSchemaCache_GetAliasedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockMetadataDatabase(ctrl)
+	cache := NewSchemaCache(db)
+	f1 := []field.Meta{{ID: 1, Name: "f", Type: field.SumField}}
+	f2 := []field.Meta{{ID: 2, Name: "f1", Type: field.SumField}, {ID: 1, Name: "f", Type: field.SumField}}
+
+	// case 1: read from metadata database, then hit cache
+	db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(1)).Times(3)
+	db.EXPECT().GetAliasedFields("ns", "cpu", field.Name("f")).Return(f1, nil)
+	fields, err := cache.GetAliasedFields("ns", "cpu", "f")
+	assert.NoError(t, err)
+	assert.Equal(t, f1, fields)
+	fields, err = cache.GetAliasedFields("ns", "cpu", "f")
+	assert.NoError(t, err)
+	assert.Equal(t, f1, fields)
+
+	// case 2: schema bump invalidates cached entry
+	db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(2)).Times(3)
+	db.EXPECT().GetAliasedFields("ns", "cpu", field.Name("f")).Return(f2, nil)
+	fields, err = cache.GetAliasedFields("ns", "cpu", "f")
+	assert.NoError(t, err)
+	assert.Equal(t, f2, fields)
+	fields, err = cache.GetAliasedFields("ns", "cpu", "f")
+	assert.NoError(t, err)
+	assert.Equal(t, f2, fields)
+
+	// case 3: not found isn't cached
+	db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(2)).Times(2)
+	db.EXPECT().GetAliasedFields("ns", "cpu", field.Name("f3")).Return(nil, constants.ErrNotFound).Times(2)
+	fields, err = cache.GetAliasedFields("ns", "cpu", "f3")
+	assert.Equal(t, constants.ErrNotFound, err)
+	assert.Nil(t, fields)
+	_, err = cache.GetAliasedFields("ns", "cpu", "f3")
+	assert.Equal(t, constants.ErrNotFound, err)
+}
+
+func TestSchemaCache_GetTagKeyID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockMetadataDatabase(ctrl)
+	cache := NewSchemaCache(db)
+
+	db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(1)).Times(3)
+	db.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(10), nil)
+	tagKeyID, err := cache.GetTagKeyID("ns", "cpu", "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), tagKeyID)
+	tagKeyID, err = cache.GetTagKeyID("ns", "cpu", "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), tagKeyID)
+
+	db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(1))
+	db.EXPECT().GetTagKeyID("ns", "cpu", "ip").Return(uint32(0), constants.ErrNotFound)
+	tagKeyID, err = cache.GetTagKeyID("ns", "cpu", "ip")
+	assert.Equal(t, constants.ErrNotFound, err)
+	assert.Equal(t, uint32(0), tagKeyID)
+}
+
+func TestSchemaCache_schema_changed_when_reading(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockMetadataDatabase(ctrl)
+	cache := NewSchemaCache(db)
+
+	// case 1: schema changed during reading, retry and read again
+	gomock.InOrder(
+		db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(1)),
+		db.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(10), nil),
+		db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(2)),
+		db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(2)),
+		db.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(11), nil),
+		db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(2)),
+	)
+	tagKeyID, err := cache.GetTagKeyID("ns", "cpu", "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(11), tagKeyID)
+	db.EXPECT().GetSchemaVersion("ns", "cpu").Return(uint64(2))
+	tagKeyID, err = cache.GetTagKeyID("ns", "cpu", "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(11), tagKeyID)
+
+	// case 2: schema changes frequently, return latest read result without caching
+	version := uint64(3)
+	db.EXPECT().GetSchemaVersion("ns", "cpu").DoAndReturn(func(_, _ string) uint64 {
+		version++
+		return version
+	}).Times(2 * maxSchemaReadRetries)
+	db.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(12), nil).Times(maxSchemaReadRetries + 1)
+	tagKeyID, err = cache.GetTagKeyID("ns", "cpu", "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(12), tagKeyID)
+	db.EXPECT().GetSchemaVersion("ns", "cpu").Return(version)
+	db.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(12), nil)
+	db.EXPECT().GetSchemaVersion("ns", "cpu").Return(version)
+	tagKeyID, err = cache.GetTagKeyID("ns", "cpu", "host")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(12), tagKeyID)
+}
@@ -1,9 +1,6 @@
 package metadb
 
 import (
-	"regexp"
-	"strings"
-
 	"github.com/lindb/roaring"
 	"go.uber.org/atomic"
 
@@ -34,12 +31,14 @@ type TagEntry interface {
 type tagEntry struct {
 	tagValueSeq atomic.Uint32
 	tagValues   map[string]uint32
+	matcher     TagMatcher
 }
 
-// newTagEntry creates tag entry with tag value id auto sequence
+// newTagEntry creates tag entry with tag value id auto sequence, uses current tag matcher for matching tag values
 func newTagEntry(tagValueSeq uint32) TagEntry {
 	t := &tagEntry{
 		tagValues: make(map[string]uint32),
+		matcher:   GetTagMatcher(),
 	}
 	t.tagValueSeq.Store(tagValueSeq)
 	return t
@@ -80,112 +79,33 @@ func (t *tagEntry) getTagValueIDs() *roaring.Bitmap {
 	return tagValueIDs
 }
 
-// findSeriesIDsByExpr finds tag value ids by tag filter expr
+// findSeriesIDsByExpr finds tag value ids by tag filter expr, delegates to the tag matcher
 func (t *tagEntry) findSeriesIDsByExpr(expr stmt.TagFilter) *roaring.Bitmap {
 	switch expression := expr.(type) {
 	case *stmt.EqualsExpr:
-		return t.findSeriesIDsByEqual(expression.Value)
+		return t.matcher.MatchEqual(t.tagValues, expression.Value)
 	case *stmt.InExpr:
-		return t.findSeriesIDsByIn(expression)
+		return t.matcher.MatchIn(t.tagValues, expression.Values)
+	case *stmt.AllOfExpr:
+		// tag value ids of all values, series ids of them are intersected by series search
+		return t.matcher.MatchIn(t.tagValues, expression.Values)
 	case *stmt.LikeExpr:
-		return t.findSeriesIDsByLike(expression)
+		return t.matcher.MatchLike(t.tagValues, expression.Value)
 	case *stmt.RegexExpr:
-		return t.findSeriesIDsByRegex(expression)
+		return t.matcher.MatchRegex(t.tagValues, expression.Regexp)
+	case *stmt.GreaterExpr:
+		return t.matcher.MatchCompare(t.tagValues, Greater, expression.Value)
+	case *stmt.GreaterEqualExpr:
+		return t.matcher.MatchCompare(t.tagValues, GreaterEqual, expression.Value)
+	case *stmt.LessExpr:
+		return t.matcher.MatchCompare(t.tagValues, Less, expression.Value)
+	case *stmt.LessEqualExpr:
+		return t.matcher.MatchCompare(t.tagValues, LessEqual, expression.Value)
 	}
 	metaLogger.Warn("expr type is not tag filter when find tag value ids by expr")
 	return nil
 }
 
-// findSeriesIDsByEqual finds tag value ids by tag value - equal
-func (t *tagEntry) findSeriesIDsByEqual(value string) *roaring.Bitmap {
-	tagValueID, ok := t.tagValues[value]
-	if !ok {
-		return nil
-	}
-	return roaring.BitmapOf(tagValueID)
-}
-
-// findSeriesIDsByIn finds series ids by tag value - in
-func (t *tagEntry) findSeriesIDsByIn(expr *stmt.InExpr) *roaring.Bitmap {
-	union := roaring.New()
-	for _, value := range expr.Values {
-		tagValueID, ok := t.tagValues[value]
-		if !ok {
-			continue
-		}
-		union.Add(tagValueID)
-	}
-	return union
-}
-
-// findSeriesIDsByLike finds tag values ids by tag value - like
-// case 1: value is empty, return nil
-// case 2: value is "*", return all tag value ids
-// case 3: value is "*xxx*", do contains
-// case 4: value is "*xxx", do suffix
-// case 5: value is "xxx*", do prefix
-// case 6: value is "xxx", do equal
-func (t *tagEntry) findSeriesIDsByLike(expr *stmt.LikeExpr) *roaring.Bitmap {
-	likeTo := expr.Value
-	length := len(likeTo)
-	if length == 0 {
-		return nil
-	}
-	if likeTo == "*" {
-		return t.getTagValueIDs()
-	}
-	result := roaring.New()
-	prefix := strings.HasPrefix(likeTo, "*")
-	suffix := strings.HasSuffix(likeTo, "*")
-	switch {
-	case prefix && suffix:
-		like := likeTo[1 : length-1]
-		for value, tagValueID := range t.tagValues {
-			if strings.Contains(value, like) {
-				result.Add(tagValueID)
-			}
-		}
-	case prefix:
-		like := likeTo[1:]
-		for value, tagValueID := range t.tagValues {
-			if strings.HasSuffix(value, like) {
-				result.Add(tagValueID)
-			}
-		}
-	case suffix:
-		like := likeTo[:length-1]
-		for value, tagValueID := range t.tagValues {
-			if strings.HasPrefix(value, like) {
-				result.Add(tagValueID)
-			}
-		}
-	default:
-		// like == equal
-		return t.findSeriesIDsByEqual(likeTo)
-	}
-	return result
-}
-
-// findSeriesIDsByRegex finds tag value ids by tag value - regex
-func (t *tagEntry) findSeriesIDsByRegex(expr *stmt.RegexExpr) *roaring.Bitmap {
-	pattern, err := regexp.Compile(expr.Regexp)
-	if err != nil {
-		return nil
-	}
-	// the regex pattern is regarded as a prefix string + pattern
-	literalPrefix, _ := pattern.LiteralPrefix()
-	result := roaring.New()
-	for value, tagValueID := range t.tagValues {
-		if !strings.HasPrefix(value, literalPrefix) {
-			continue
-		}
-		if pattern.MatchString(value) {
-			result.Add(tagValueID)
-		}
-	}
-	return result
-}
-
 // collectTagValues collects the tag values by tag value ids,
 func (t *tagEntry) collectTagValues(tagValueIDs *roaring.Bitmap, tagValues map[uint32]string) {
 	for value, tagValueID := range t.tagValues {
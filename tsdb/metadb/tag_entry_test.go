@@ -7,6 +7,7 @@ import (
 	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
@@ -42,6 +43,23 @@ func TestTagEntry_findSeriesIDsByEquals(t *testing.T) {
 	assert.Equal(t, roaring.BitmapOf(5), tagIndex.findSeriesIDsByExpr(&stmt.EqualsExpr{Key: "host", Value: "bc"}))
 }
 
+func TestTagEntry_findSeriesIDsByBoolean(t *testing.T) {
+	tagIndex := newTagEntry(0)
+	tagIndex.addTagValue("true", 1)
+	tagIndex.addTagValue("false", 2)
+	// quoted and unquoted boolean literals resolve identically
+	for _, value := range []string{"true", "'true'", "TRUE", "false", "'false'"} {
+		q, err := sql.Parse("select f from cpu where active=" + value)
+		assert.NoError(t, err)
+		expr := q.(*stmt.Query).Condition.(stmt.TagFilter)
+		expect := roaring.BitmapOf(1)
+		if expr.(*stmt.EqualsExpr).Value == "false" {
+			expect = roaring.BitmapOf(2)
+		}
+		assert.Equal(t, expect, tagIndex.findSeriesIDsByExpr(expr), value)
+	}
+}
+
 func TestTagEntry_findSeriesIDsByLike(t *testing.T) {
 	tagIndex := prepareTagEntry()
 
@@ -67,6 +85,12 @@ func TestTagEntry_findSeriesIDsByIn(t *testing.T) {
 	assert.Equal(t, roaring.BitmapOf(3, 5, 8), tagIndex.findSeriesIDsByExpr(&stmt.InExpr{Key: "host", Values: []string{"b", "bc", "bcd", "ahi"}}))
 }
 
+func TestTagEntry_findSeriesIDsByAllOf(t *testing.T) {
+	tagIndex := prepareTagEntry()
+	// all-of returns ids of existed values, intersection is done by series search
+	assert.Equal(t, roaring.BitmapOf(3, 5), tagIndex.findSeriesIDsByExpr(&stmt.AllOfExpr{Key: "host", Values: []string{"b", "bc", "ahi"}}))
+}
+
 func TestTagEntry_findSeriesIDsByExpr_not_tagFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -88,6 +112,17 @@ func TestTagEntry_findSeriesIDsByRegex(t *testing.T) {
 	assert.Equal(t, roaring.New(), tagIndex.findSeriesIDsByExpr(&stmt.RegexExpr{Key: "host", Regexp: `22+`}))
 }
 
+func TestTagEntry_findSeriesIDsByCompare(t *testing.T) {
+	tagIndex := prepareTagEntry()
+	assert.Equal(t, roaring.BitmapOf(4, 5, 6, 7, 8), tagIndex.findSeriesIDsByExpr(&stmt.GreaterExpr{Key: "host", Value: "b"}))
+	assert.Equal(t, roaring.BitmapOf(3, 4, 5, 6, 7, 8),
+		tagIndex.findSeriesIDsByExpr(&stmt.GreaterEqualExpr{Key: "host", Value: "b"}))
+	assert.Equal(t, roaring.BitmapOf(1, 2), tagIndex.findSeriesIDsByExpr(&stmt.LessExpr{Key: "host", Value: "b"}))
+	assert.Equal(t, roaring.BitmapOf(1, 2, 3), tagIndex.findSeriesIDsByExpr(&stmt.LessEqualExpr{Key: "host", Value: "b"}))
+	// no tag value less than "a"
+	assert.Equal(t, roaring.New(), tagIndex.findSeriesIDsByExpr(&stmt.LessExpr{Key: "host", Value: "a"}))
+}
+
 func TestTagEntry_collectTagValues(t *testing.T) {
 	tagIndex := prepareTagEntry()
 	tagValueIDs := roaring.BitmapOf(1, 2, 3, 100)
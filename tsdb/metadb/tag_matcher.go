@@ -0,0 +1,216 @@
+package metadb
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/strutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+//go:generate mockgen -source ./tag_matcher.go -destination=./tag_matcher_mock.go -package metadb
+
+// TagMatcher represents the matching backend which finds tag value ids from the tag values(tag value=>id) under tag key,
+// different deployments can plug in different matching semantics(like trie-based prefix, FST-based) by SetTagMatcher.
+type TagMatcher interface {
+	// MatchEqual finds tag value id which tag value equals the value, returns nil if not found
+	MatchEqual(tagValues map[string]uint32, value string) *roaring.Bitmap
+	// MatchLike finds tag value ids which tag value is like the pattern(supports * wildcard)
+	MatchLike(tagValues map[string]uint32, pattern string) *roaring.Bitmap
+	// MatchRegex finds tag value ids which tag value matches the regexp,
+	// returns nil if regexp is invalid or evaluation exceeds the regex timeout
+	MatchRegex(tagValues map[string]uint32, regex string) *roaring.Bitmap
+	// MatchIn finds tag value ids which tag value is in the values
+	MatchIn(tagValues map[string]uint32, values []string) *roaring.Bitmap
+	// MatchCompare finds tag value ids which tag value satisfies the relational operator with the value,
+	// tag values are compared lexicographically(byte-wise)
+	MatchCompare(tagValues map[string]uint32, op CompareOp, value string) *roaring.Bitmap
+}
+
+// IsPushDown checks if the tag filter expr can be resolved by tag index lookup(exact/prefix match) directly,
+// else it needs scanning all tag values under the tag key as residual filter,
+// like regex without literal prefix or like with leading wildcard.
+func IsPushDown(expr stmt.TagFilter) bool {
+	switch expression := expr.(type) {
+	case *stmt.LikeExpr:
+		return !strings.HasPrefix(expression.Value, "*")
+	case *stmt.RegexExpr:
+		pattern, err := regexp.Compile(expression.Regexp)
+		if err != nil {
+			return false
+		}
+		literalPrefix, _ := pattern.LiteralPrefix()
+		return literalPrefix != ""
+	default:
+		return true
+	}
+}
+
+// CompareOp represents the relational operator of tag value
+type CompareOp uint8
+
+// Defines all relational operators of tag value
+const (
+	Greater CompareOp = iota + 1
+	GreaterEqual
+	Less
+	LessEqual
+)
+
+// Compare returns if the tag value satisfies the relational operator with the value, compares lexicographically
+func (op CompareOp) Compare(tagValue, value string) bool {
+	switch op {
+	case Greater:
+		return tagValue > value
+	case GreaterEqual:
+		return tagValue >= value
+	case Less:
+		return tagValue < value
+	case LessEqual:
+		return tagValue <= value
+	}
+	return false
+}
+
+var (
+	tagMatcher      TagMatcher = NewDefaultTagMatcher()
+	tagMatcherMutex sync.RWMutex
+)
+
+// SetTagMatcher sets the tag matcher which is used by the tag entry created after setting,
+// resets to default tag matcher if matcher is nil.
+func SetTagMatcher(matcher TagMatcher) {
+	if matcher == nil {
+		matcher = NewDefaultTagMatcher()
+	}
+	tagMatcherMutex.Lock()
+	tagMatcher = matcher
+	tagMatcherMutex.Unlock()
+}
+
+// GetTagMatcher returns the current tag matcher
+func GetTagMatcher() TagMatcher {
+	tagMatcherMutex.RLock()
+	defer tagMatcherMutex.RUnlock()
+	return tagMatcher
+}
+
+// defaultTagMatcher implements TagMatcher interface, matches by traversing all tag values
+type defaultTagMatcher struct{}
+
+// NewDefaultTagMatcher creates the default tag matcher
+func NewDefaultTagMatcher() TagMatcher {
+	return &defaultTagMatcher{}
+}
+
+// MatchEqual finds tag value ids by tag value - equal
+func (m *defaultTagMatcher) MatchEqual(tagValues map[string]uint32, value string) *roaring.Bitmap {
+	tagValueID, ok := tagValues[value]
+	if !ok {
+		return nil
+	}
+	return roaring.BitmapOf(tagValueID)
+}
+
+// MatchIn finds tag value ids by tag value - in
+func (m *defaultTagMatcher) MatchIn(tagValues map[string]uint32, values []string) *roaring.Bitmap {
+	union := roaring.New()
+	for _, value := range values {
+		tagValueID, ok := tagValues[value]
+		if !ok {
+			continue
+		}
+		union.Add(tagValueID)
+	}
+	return union
+}
+
+// MatchLike finds tag values ids by tag value - like, adjacent wildcards(**) are same as one
+// case 1: value is empty, return nil
+// case 2: value is "*", return all tag value ids
+// case 3: value is "xx*yy"(has inner wildcard), do glob matching
+// case 4: value is "*xxx*", do contains
+// case 5: value is "*xxx", do suffix
+// case 6: value is "xxx*", do prefix
+// case 7: value is "xxx", do equal
+func (m *defaultTagMatcher) MatchLike(tagValues map[string]uint32, pattern string) *roaring.Bitmap {
+	if len(pattern) == 0 {
+		return nil
+	}
+	result := roaring.New()
+	if strutil.HasInnerWildcard(pattern) {
+		for value, tagValueID := range tagValues {
+			if strutil.GlobMatch(pattern, value) {
+				result.Add(tagValueID)
+			}
+		}
+		return result
+	}
+	like := strings.Trim(pattern, "*")
+	prefix := strings.HasPrefix(pattern, "*")
+	suffix := strings.HasSuffix(pattern, "*")
+	switch {
+	case prefix && suffix:
+		for value, tagValueID := range tagValues {
+			if strings.Contains(value, like) {
+				result.Add(tagValueID)
+			}
+		}
+	case prefix:
+		for value, tagValueID := range tagValues {
+			if strings.HasSuffix(value, like) {
+				result.Add(tagValueID)
+			}
+		}
+	case suffix:
+		for value, tagValueID := range tagValues {
+			if strings.HasPrefix(value, like) {
+				result.Add(tagValueID)
+			}
+		}
+	default:
+		// like == equal
+		return m.MatchEqual(tagValues, pattern)
+	}
+	return result
+}
+
+// MatchRegex finds tag value ids by tag value - regex, returns nil if evaluation exceeds the regex timeout
+func (m *defaultTagMatcher) MatchRegex(tagValues map[string]uint32, regex string) *roaring.Bitmap {
+	pattern, err := regexp.Compile(regex)
+	if err != nil {
+		return nil
+	}
+	// the regex pattern is regarded as a prefix string + pattern
+	literalPrefix, _ := pattern.LiteralPrefix()
+	result := roaring.New()
+	deadline := strutil.NewRegexDeadline()
+	for value, tagValueID := range tagValues {
+		if !strings.HasPrefix(value, literalPrefix) {
+			continue
+		}
+		if deadline.Exceeded() {
+			metaLogger.Warn("regex evaluation timeout", logger.String("regex", regex))
+			return nil
+		}
+		if pattern.MatchString(value) {
+			result.Add(tagValueID)
+		}
+	}
+	return result
+}
+
+// MatchCompare finds tag value ids by tag value - relational operator(>, >=, <, <=)
+func (m *defaultTagMatcher) MatchCompare(tagValues map[string]uint32, op CompareOp, value string) *roaring.Bitmap {
+	result := roaring.New()
+	for tagValue, tagValueID := range tagValues {
+		if op.Compare(tagValue, value) {
+			result.Add(tagValueID)
+		}
+	}
+	return result
+}
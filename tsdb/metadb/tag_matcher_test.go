@@ -0,0 +1,130 @@
+package metadb
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/strutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestTagMatcher_SetTagMatcher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		SetTagMatcher(nil)
+		ctrl.Finish()
+	}()
+
+	assert.IsType(t, &defaultTagMatcher{}, GetTagMatcher())
+	matcher := NewMockTagMatcher(ctrl)
+	SetTagMatcher(matcher)
+	assert.Equal(t, matcher, GetTagMatcher())
+	// tag entry uses the current tag matcher
+	assert.Equal(t, matcher, newTagEntry(0).(*tagEntry).matcher)
+	// reset to default
+	SetTagMatcher(nil)
+	assert.IsType(t, &defaultTagMatcher{}, GetTagMatcher())
+}
+
+func TestTagMatcher_delegate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	matcher := NewMockTagMatcher(ctrl)
+	tagIndex := &tagEntry{tagValues: map[string]uint32{"a": 1, "b": 2}, matcher: matcher}
+	tagValues := tagIndex.getTagValues()
+
+	matcher.EXPECT().MatchEqual(tagValues, "a").Return(roaring.BitmapOf(1))
+	assert.Equal(t, roaring.BitmapOf(1), tagIndex.findSeriesIDsByExpr(&stmt.EqualsExpr{Key: "host", Value: "a"}))
+	matcher.EXPECT().MatchIn(tagValues, []string{"a", "b"}).Return(roaring.BitmapOf(1, 2))
+	assert.Equal(t, roaring.BitmapOf(1, 2),
+		tagIndex.findSeriesIDsByExpr(&stmt.InExpr{Key: "host", Values: []string{"a", "b"}}))
+	matcher.EXPECT().MatchLike(tagValues, "b*").Return(roaring.BitmapOf(2))
+	assert.Equal(t, roaring.BitmapOf(2), tagIndex.findSeriesIDsByExpr(&stmt.LikeExpr{Key: "host", Value: "b*"}))
+	matcher.EXPECT().MatchRegex(tagValues, "a+").Return(roaring.BitmapOf(1))
+	assert.Equal(t, roaring.BitmapOf(1), tagIndex.findSeriesIDsByExpr(&stmt.RegexExpr{Key: "host", Regexp: "a+"}))
+	matcher.EXPECT().MatchCompare(tagValues, GreaterEqual, "b").Return(roaring.BitmapOf(2))
+	assert.Equal(t, roaring.BitmapOf(2), tagIndex.findSeriesIDsByExpr(&stmt.GreaterEqualExpr{Key: "host", Value: "b"}))
+}
+
+func TestDefaultTagMatcher(t *testing.T) {
+	matcher := NewDefaultTagMatcher()
+	tagValues := map[string]uint32{"a": 1, "abc": 2, "b": 3}
+	assert.Nil(t, matcher.MatchEqual(tagValues, "c"))
+	assert.Equal(t, roaring.BitmapOf(2), matcher.MatchEqual(tagValues, "abc"))
+	assert.Equal(t, roaring.BitmapOf(1, 3), matcher.MatchIn(tagValues, []string{"a", "b", "c"}))
+	assert.Nil(t, matcher.MatchLike(tagValues, ""))
+	assert.Equal(t, roaring.BitmapOf(1, 2, 3), matcher.MatchLike(tagValues, "*"))
+	assert.Equal(t, roaring.BitmapOf(1, 2), matcher.MatchLike(tagValues, "a*"))
+	assert.Nil(t, matcher.MatchRegex(tagValues, "a++"))
+	assert.Equal(t, roaring.BitmapOf(2), matcher.MatchRegex(tagValues, "ab.+"))
+	assert.Equal(t, roaring.BitmapOf(2, 3), matcher.MatchCompare(tagValues, Greater, "a"))
+	assert.Equal(t, roaring.BitmapOf(1, 2), matcher.MatchCompare(tagValues, LessEqual, "abc"))
+	assert.Equal(t, roaring.New(), matcher.MatchCompare(tagValues, CompareOp(0), "a"))
+}
+
+func TestDefaultTagMatcher_MatchLike(t *testing.T) {
+	matcher := NewDefaultTagMatcher()
+	tagValues := map[string]uint32{"abc": 1, "axbyc": 2, "acb": 3, "aaa": 4, "aa": 5, "a": 6, "abcd": 7, "c": 8}
+	cases := []struct {
+		pattern string
+		expect  *roaring.Bitmap
+	}{
+		{"a*b*c", roaring.BitmapOf(1, 2)},
+		{"a**c", roaring.BitmapOf(1, 2)},
+		{"a*a", roaring.BitmapOf(4, 5)},
+		{"*a*a*", roaring.BitmapOf(4, 5)},
+		{"*b*", roaring.BitmapOf(1, 2, 3, 7)},
+		{"**c", roaring.BitmapOf(1, 2, 8)},
+		{"a**", roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7)},
+		{"**", roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7, 8)},
+		{"*", roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7, 8)},
+		{"x*y", roaring.New()},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expect.ToArray(), matcher.MatchLike(tagValues, c.pattern).ToArray(), "pattern: %s", c.pattern)
+	}
+}
+
+func TestDefaultTagMatcher_MatchRegex_timeout(t *testing.T) {
+	defer strutil.SetRegexTimeout(strutil.DefaultRegexTimeout)
+
+	tagValues := make(map[string]uint32)
+	for i := 0; i < 1000; i++ {
+		tagValues["host"+strconv.Itoa(i)] = uint32(i)
+	}
+	matcher := NewDefaultTagMatcher()
+	assert.Equal(t, uint64(1000), matcher.MatchRegex(tagValues, "host[0-9]+").GetCardinality())
+	// evaluation exceeds the regex timeout
+	strutil.SetRegexTimeout(time.Nanosecond)
+	assert.Nil(t, matcher.MatchRegex(tagValues, "host[0-9]+"))
+}
+
+func TestIsPushDown(t *testing.T) {
+	assert.True(t, IsPushDown(&stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"}))
+	assert.True(t, IsPushDown(&stmt.InExpr{Key: "host", Values: []string{"a", "b"}}))
+	assert.True(t, IsPushDown(&stmt.GreaterExpr{Key: "host", Value: "a"}))
+	assert.True(t, IsPushDown(&stmt.LikeExpr{Key: "host", Value: "web*"}))
+	assert.False(t, IsPushDown(&stmt.LikeExpr{Key: "host", Value: "*web"}))
+	assert.True(t, IsPushDown(&stmt.RegexExpr{Key: "host", Regexp: "web-.*"}))
+	assert.False(t, IsPushDown(&stmt.RegexExpr{Key: "host", Regexp: ".*-web"}))
+	assert.False(t, IsPushDown(&stmt.RegexExpr{Key: "host", Regexp: "[a-z"}))
+}
+
+func TestCompareOp_Compare(t *testing.T) {
+	assert.True(t, Greater.Compare("b", "a"))
+	assert.False(t, Greater.Compare("a", "a"))
+	assert.True(t, GreaterEqual.Compare("a", "a"))
+	assert.True(t, Less.Compare("a", "b"))
+	assert.False(t, Less.Compare("a", "a"))
+	assert.True(t, LessEqual.Compare("a", "a"))
+	// lexicographic comparison, numbers should be zero-padded
+	assert.True(t, Less.Compare("10", "9"))
+	assert.True(t, Greater.Compare("10", "09"))
+	assert.False(t, CompareOp(0).Compare("a", "b"))
+}
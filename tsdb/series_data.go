@@ -0,0 +1,263 @@
+package tsdb
+
+import (
+	"fmt"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/concurrent"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// getSeriesData loads the data of series from data filters(data families/memory databases) in order,
+// returns the field iterators of each series id, the field iterators are aligned with the given fields,
+// nil if the field has no data, the series without any data is skipped.
+// NOTICE: the time slot of field iterator is based on the start time of time range(truncated by interval),
+// if the same time slot exists in multi data filters, the value of latter data filter is used.
+// the value codec of each field is picked from the codec registry of database.
+func getSeriesData(
+	filters []flow.DataFilter,
+	interval int64,
+	metricID uint32,
+	seriesIDs *roaring.Bitmap,
+	fields field.Metas,
+	timeRange timeutil.TimeRange,
+	codecs *aggregation.CodecRegistry,
+) (map[uint32][]series.FieldIterator, error) {
+	if seriesIDs == nil || seriesIDs.IsEmpty() || len(fields) == 0 {
+		return nil, nil
+	}
+	fieldIDs := make([]field.ID, len(fields))
+	for idx, f := range fields {
+		fieldIDs[idx] = f.ID
+	}
+	var resultSets []flow.FilterResultSet
+	for _, filter := range filters {
+		rs, err := filter.Filter(metricID, fieldIDs, seriesIDs, timeRange)
+		if err != nil {
+			return nil, err
+		}
+		resultSets = append(resultSets, rs...)
+	}
+	if len(resultSets) == 0 {
+		return nil, nil
+	}
+
+	fieldCodecs := make([]encoding.CodecID, len(fields))
+	for idx, f := range fields {
+		fieldCodecs[idx] = codecs.GetCodec(metricID, f.Name, f.Type)
+	}
+	collector := newSeriesDataCollector(interval, fields, fieldCodecs, timeRange)
+	queryFlow := &seriesDataFlow{collector: collector}
+	highKeys := seriesIDs.GetHighKeys()
+	for idx, highKey := range highKeys {
+		container := seriesIDs.GetContainerAtIndex(idx)
+		var scanners []flow.Scanner
+		for _, rs := range resultSets {
+			if scanner := rs.Load(queryFlow, fieldIDs, highKey, container); scanner != nil {
+				scanners = append(scanners, scanner)
+			}
+		}
+		hk := uint32(highKey) << 16
+		it := container.PeekableIterator()
+		for it.HasNext() {
+			lowSeriesID := it.Next()
+			collector.seriesID = encoding.ValueWithHighLowBits(hk, lowSeriesID)
+			for _, scanner := range scanners {
+				scanner.Scan(lowSeriesID)
+			}
+		}
+		for _, scanner := range scanners {
+			_ = scanner.Close()
+		}
+	}
+	return collector.resultSet(), nil
+}
+
+// seriesDataCollector collects the data points of each series for all fields,
+// implements aggregation.ContainerAggregator interface for loading data from storage.
+type seriesDataCollector struct {
+	interval  int64
+	baseTime  int64
+	timeRange timeutil.TimeRange
+	capacity  int
+	fields    field.Metas
+	codecs    []encoding.CodecID // value codecs of fields
+
+	aggregates aggregation.FieldAggregates
+	seriesID   uint32                              // current scanning series id
+	values     map[uint32][]collections.FloatArray // series id => values of fields
+}
+
+// newSeriesDataCollector creates the series data collector
+func newSeriesDataCollector(interval int64, fields field.Metas, codecs []encoding.CodecID,
+	timeRange timeutil.TimeRange,
+) *seriesDataCollector {
+	baseTime := timeRange.Start / interval * interval
+	c := &seriesDataCollector{
+		interval:  interval,
+		baseTime:  baseTime,
+		timeRange: timeRange,
+		capacity:  int((timeRange.End-baseTime)/interval) + 1,
+		fields:    fields,
+		codecs:    codecs,
+		values:    make(map[uint32][]collections.FloatArray),
+	}
+	c.aggregates = make(aggregation.FieldAggregates, len(fields))
+	for idx := range fields {
+		c.aggregates[idx] = &fieldDataCollector{collector: c, fieldIdx: idx}
+	}
+	return c
+}
+
+// GetFieldAggregates returns the field data collectors which are aligned with fields.
+func (c *seriesDataCollector) GetFieldAggregates() aggregation.FieldAggregates {
+	return c.aggregates
+}
+
+// Aggregate does nothing, the data points are kept by series id in collector
+func (c *seriesDataCollector) Aggregate() {}
+
+// ResultSet returns nil, the result set of each series is built by resultSet
+func (c *seriesDataCollector) ResultSet(_ string) series.GroupedIterator {
+	return nil
+}
+
+// Reset does nothing
+func (c *seriesDataCollector) Reset() {}
+
+// append appends the data point of field into current scanning series
+func (c *seriesDataCollector) append(fieldIdx int, timestamp int64, value float64) {
+	values, ok := c.values[c.seriesID]
+	if !ok {
+		values = make([]collections.FloatArray, len(c.fields))
+		c.values[c.seriesID] = values
+	}
+	if values[fieldIdx] == nil {
+		values[fieldIdx] = collections.NewFloatArray(c.capacity)
+	}
+	values[fieldIdx].SetValue(int((timestamp-c.baseTime)/c.interval), value)
+}
+
+// resultSet returns the field iterators of each series
+func (c *seriesDataCollector) resultSet() map[uint32][]series.FieldIterator {
+	result := make(map[uint32][]series.FieldIterator, len(c.values))
+	for seriesID, values := range c.values {
+		its := make([]series.FieldIterator, len(c.fields))
+		for idx, fieldValues := range values {
+			if fieldValues == nil {
+				continue
+			}
+			var aggType field.AggType
+			if aggFunc := c.fields[idx].Type.GetAggFunc(); aggFunc != nil {
+				aggType = aggFunc.AggType()
+			}
+			its[idx] = aggregation.NewTypedFieldIterator(0, aggType, c.codecs[idx], fieldValues,
+				c.baseTime, c.interval, c.timeRange)
+		}
+		result[seriesID] = its
+	}
+	return result
+}
+
+// fieldDataCollector collects the data points of one field,
+// implements aggregation.SeriesAggregator interface for loading data from storage.
+type fieldDataCollector struct {
+	collector *seriesDataCollector
+	fieldIdx  int
+}
+
+// FieldName returns field name
+func (c *fieldDataCollector) FieldName() field.Name {
+	return c.collector.fields[c.fieldIdx].Name
+}
+
+// GetFieldType returns field type
+func (c *fieldDataCollector) GetFieldType() field.Type {
+	return c.collector.fields[c.fieldIdx].Type
+}
+
+// SetFieldType does nothing, field type is from given field meta
+func (c *fieldDataCollector) SetFieldType(_ field.Type) {}
+
+// GetAggregateBlock returns the block which converts the time slot of family into timestamp
+func (c *fieldDataCollector) GetAggregateBlock(familyTime int64) (series.Block, bool) {
+	return &fieldDataBlock{collector: c.collector, fieldIdx: c.fieldIdx, familyTime: familyTime}, true
+}
+
+// ResultSet returns nil, the result set is built by series data collector
+func (c *fieldDataCollector) ResultSet() series.Iterator {
+	return nil
+}
+
+// Merge returns error, because the collected data are consumed when scanning storage, no partial state to merge
+func (c *fieldDataCollector) Merge(_ aggregation.SeriesAggregator) error {
+	return fmt.Errorf("fieldDataCollector not support merge")
+}
+
+// Reset does nothing
+func (c *fieldDataCollector) Reset() {}
+
+// fieldDataBlock implements series.Block interface,
+// appends the data point in query time range into series data collector.
+type fieldDataBlock struct {
+	collector  *seriesDataCollector
+	fieldIdx   int
+	familyTime int64
+}
+
+// Append appends time slot and value into collector, returns true if time slot is after query time range.
+// the points before the start of query time range are clipped by field iterator of result set.
+func (b *fieldDataBlock) Append(slot int, value float64) bool {
+	timestamp := b.familyTime + int64(slot)*b.collector.interval
+	switch {
+	case timestamp > b.collector.timeRange.End:
+		return true
+	case timestamp < b.collector.baseTime:
+		return false
+	default:
+		b.collector.append(b.fieldIdx, timestamp, value)
+		return false
+	}
+}
+
+// Clear does nothing, because the data points are kept by series id in collector
+func (b *fieldDataBlock) Clear() {}
+
+// seriesDataFlow implements flow.StorageQueryFlow interface for loading series data,
+// only returns the series data collector as aggregator, others do nothing.
+type seriesDataFlow struct {
+	collector *seriesDataCollector
+}
+
+// Prepare does nothing
+func (f *seriesDataFlow) Prepare(_ aggregation.AggregatorSpecs) {}
+
+// Filtering does nothing
+func (f *seriesDataFlow) Filtering(_ concurrent.Task) {}
+
+// Grouping does nothing
+func (f *seriesDataFlow) Grouping(_ concurrent.Task) {}
+
+// Scanner does nothing
+func (f *seriesDataFlow) Scanner(_ concurrent.Task) {}
+
+// Reduce does nothing
+func (f *seriesDataFlow) Reduce(_ string, _ aggregation.ContainerAggregator) {}
+
+// ReduceTagValues does nothing
+func (f *seriesDataFlow) ReduceTagValues(_ int, _ map[uint32]string) {}
+
+// GetAggregator returns the series data collector
+func (f *seriesDataFlow) GetAggregator(_ uint16) aggregation.ContainerAggregator {
+	return f.collector
+}
+
+// Complete does nothing
+func (f *seriesDataFlow) Complete(_ error) {}
@@ -0,0 +1,288 @@
+package tsdb
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/bit"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/memdb"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+func TestShard_GetSeriesData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	familyTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	fields := field.Metas{
+		{ID: 1, Name: "f1", Type: field.SumField},
+		{ID: 2, Name: "f2", Type: field.MaxField},
+	}
+	// query time range: 10:00:30 ~ 10:02:00, interval: 10s
+	timeRange := timeutil.TimeRange{Start: familyTime + 30*timeutil.OneSecond, End: familyTime + 2*timeutil.OneMinute}
+	seriesIDs := roaring.BitmapOf(1, 2, 3)
+
+	// mock file storage and memory storage
+	family := NewMockDataFamily(ctrl)
+	immutable := memdb.NewMockMemoryDatabase(ctrl)
+	mutable := memdb.NewMockMemoryDatabase(ctrl)
+	s := &shard{
+		interval:  timeutil.Interval(10 * timeutil.OneSecond),
+		segments:  map[timeutil.IntervalType]IntervalSegment{},
+		immutable: immutable,
+		mutable:   mutable,
+	}
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().getDataFamilies(timeRange).Return([]DataFamily{family}).AnyTimes()
+	s.segments[timeutil.Day] = segment
+
+	fileRS := mockSeriesDataResultSet(ctrl, familyTime, map[uint16][][]point{
+		1: {{{slot: 0, value: 1}, {slot: 3, value: 3}, {slot: 5, value: 5}, {slot: 20, value: 20}}, nil},
+		2: {nil, {{slot: 6, value: 6}}},
+	})
+	memRS := mockSeriesDataResultSet(ctrl, familyTime, map[uint16][][]point{
+		// overwrites the value of same slot in file
+		1: {{{slot: 5, value: 50}, {slot: 12, value: 12}}, nil},
+	})
+	family.EXPECT().Filter(uint32(10), []field.ID{1, 2}, seriesIDs, timeRange).
+		Return([]flow.FilterResultSet{fileRS}, nil).AnyTimes()
+	immutable.EXPECT().Filter(uint32(10), []field.ID{1, 2}, seriesIDs, timeRange).
+		Return(nil, nil).AnyTimes()
+	mutable.EXPECT().Filter(uint32(10), []field.ID{1, 2}, seriesIDs, timeRange).
+		Return([]flow.FilterResultSet{memRS}, nil).AnyTimes()
+
+	result, err := s.GetSeriesData(10, seriesIDs, fields, timeRange)
+	assert.NoError(t, err)
+	// series 3 has no data
+	assert.Len(t, result, 2)
+	// slot 0 is before query time range, slot 20 is after query time range
+	assertSeriesData(t, result[1][0], field.Sum, map[int]float64{0: 3, 2: 50, 9: 12})
+	assert.Nil(t, result[1][1])
+	assert.Nil(t, result[2][0])
+	assertSeriesData(t, result[2][1], field.Max, map[int]float64{3: 6})
+
+	// filter failure
+	mutable2 := memdb.NewMockMemoryDatabase(ctrl)
+	mutable2.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("err"))
+	s.mutable = mutable2
+	result, err = s.GetSeriesData(10, seriesIDs, fields, timeRange)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	// empty series ids/fields
+	result, err = s.GetSeriesData(10, roaring.New(), fields, timeRange)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	result, err = s.GetSeriesData(10, seriesIDs, nil, timeRange)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	// no data found
+	s.immutable = nil
+	s.segments = nil
+	mutable2.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	result, err = s.GetSeriesData(10, seriesIDs, fields, timeRange)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestSeriesDataCollector_clip_by_time_range(t *testing.T) {
+	familyTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	fields := field.Metas{{ID: 1, Name: "f1", Type: field.SumField}}
+	// query time range: 10:00:35 ~ 10:01:00, interval: 10s, base time: 10:00:30
+	timeRange := timeutil.TimeRange{Start: familyTime + 35*timeutil.OneSecond, End: familyTime + timeutil.OneMinute}
+	collector := newSeriesDataCollector(10*timeutil.OneSecond, fields, []encoding.CodecID{encoding.XORCodec}, timeRange)
+	collector.seriesID = 1
+	block, ok := collector.GetFieldAggregates()[0].GetAggregateBlock(familyTime)
+	assert.True(t, ok)
+	assert.False(t, block.Append(2, 2))
+	// slot 3 is after base time, but before query time range
+	assert.False(t, block.Append(3, 3))
+	assert.False(t, block.Append(4, 4))
+	assert.False(t, block.Append(6, 6))
+	assert.True(t, block.Append(7, 7))
+	result := collector.resultSet()
+	assert.Len(t, result, 1)
+	assertSeriesData(t, result[1][0], field.Sum, map[int]float64{1: 4, 3: 6})
+}
+
+func TestShard_GetSeriesData_skip_by_time_range(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newReaderFunc = metricsdata.NewReader
+		ctrl.Finish()
+	}()
+
+	familyTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	fields := field.Metas{{ID: 1, Name: "f1", Type: field.SumField}}
+	// query time range: 10:00:30 ~ 10:02:00(slot 3 ~ 12), interval: 10s
+	timeRange := timeutil.TimeRange{Start: familyTime + 30*timeutil.OneSecond, End: familyTime + 2*timeutil.OneMinute}
+	interval := timeutil.Interval(10 * timeutil.OneSecond)
+
+	// counts the loading of each metric block
+	loads := make(map[string]int)
+	newReaderFunc = func(path string, buf []byte) (metricsdata.Reader, error) {
+		r, err := metricsdata.NewReader(path, buf)
+		if err != nil {
+			return nil, err
+		}
+		return &loadCountReader{Reader: r, loads: loads}, nil
+	}
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	inRange := table.NewMockReader(ctrl)
+	inRange.EXPECT().Path().Return("1.sst").AnyTimes()
+	inRange.EXPECT().Get(uint32(10)).Return(mockSeriesDataBlock(3, []float64{3, 4, 5}), true)
+	outOfRange := table.NewMockReader(ctrl)
+	outOfRange.EXPECT().Path().Return("2.sst").AnyTimes()
+	outOfRange.EXPECT().Get(uint32(10)).Return(mockSeriesDataBlock(20, []float64{20, 21}), true)
+	snapshot.EXPECT().FindReaders(uint32(10)).Return([]table.Reader{inRange, outOfRange}, nil)
+	kvFamily := kv.NewMockFamily(ctrl)
+	kvFamily.EXPECT().GetSnapshot().Return(snapshot)
+	family := newDataFamily(interval, timeutil.TimeRange{Start: familyTime, End: familyTime + timeutil.OneHour}, kvFamily)
+
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().getDataFamilies(timeRange).Return([]DataFamily{family})
+	s := &shard{
+		interval: interval,
+		segments: map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment},
+	}
+	result, err := s.GetSeriesData(10, roaring.BitmapOf(1), fields, timeRange)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assertSeriesData(t, result[1][0], field.Sum, map[int]float64{0: 3, 1: 4, 2: 5})
+	// metric block outside query time range is skipped based on the slot range of block footer
+	assert.Equal(t, map[string]int{"1.sst": 1}, loads)
+}
+
+func TestShard_GetSeriesData_codec(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	familyTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+	fields := field.Metas{
+		{ID: 1, Name: "f1", Type: field.GaugeField},
+		{ID: 2, Name: "f2", Type: field.GaugeField},
+	}
+	timeRange := timeutil.TimeRange{Start: familyTime, End: familyTime + 20*timeutil.OneMinute}
+	// constant values which are compressed by rle codec
+	var points []point
+	for slot := 0; slot < 100; slot++ {
+		points = append(points, point{slot: slot, value: 5})
+	}
+	data := map[uint16][][]point{1: {points, points}}
+	family := NewMockDataFamily(ctrl)
+	family.EXPECT().Filter(uint32(10), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]flow.FilterResultSet{mockSeriesDataResultSet(ctrl, familyTime, data)}, nil)
+	family.EXPECT().Filter(uint32(11), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]flow.FilterResultSet{mockSeriesDataResultSet(ctrl, familyTime, data)}, nil)
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().getDataFamilies(timeRange).Return([]DataFamily{family}).AnyTimes()
+	s := &shard{
+		interval: timeutil.Interval(10 * timeutil.OneSecond),
+		segments: map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment},
+		codecs:   aggregation.NewCodecRegistry(),
+	}
+	s.codecs.RegisterFieldCodec(10, "f1", encoding.RLECodec)
+
+	assertCodec := func(it series.FieldIterator, codec encoding.CodecID) {
+		data, err := it.MarshalBinary()
+		assert.NoError(t, err)
+		_, blocks, err := series.UnmarshalFieldBlocks(data)
+		assert.NoError(t, err)
+		assert.Equal(t, codec, encoding.NewTSDDecoder(blocks[0]).Codec())
+	}
+	result, err := s.GetSeriesData(10, roaring.BitmapOf(1), fields, timeRange)
+	assert.NoError(t, err)
+	assertCodec(result[1][0], encoding.RLECodec)
+	assertCodec(result[1][1], encoding.XORCodec)
+	// codec of field is scoped by metric
+	result, err = s.GetSeriesData(11, roaring.BitmapOf(1), fields, timeRange)
+	assert.NoError(t, err)
+	assertCodec(result[1][0], encoding.XORCodec)
+	assertCodec(result[1][1], encoding.XORCodec)
+}
+
+// loadCountReader counts the loading of metric block
+type loadCountReader struct {
+	metricsdata.Reader
+	loads map[string]int
+}
+
+func (r *loadCountReader) Load(queryFlow flow.StorageQueryFlow, familyTime int64, fieldIDs []field.ID,
+	highKey uint16, seriesID roaring.Container) flow.Scanner {
+	r.loads[r.Path()]++
+	return r.Reader.Load(queryFlow, familyTime, fieldIDs, highKey, seriesID)
+}
+
+// mockSeriesDataBlock mocks the metric block which has the values of series 1 from start slot
+func mockSeriesDataBlock(start uint16, values []float64) []byte {
+	nopKVFlusher := kv.NewNopFlusher()
+	flusher := metricsdata.NewFlusher(nopKVFlusher)
+	flusher.FlushFieldMetas(field.Metas{{ID: 1, Type: field.SumField}})
+	encoder := encoding.NewTSDEncoder(start)
+	for _, value := range values {
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(math.Float64bits(value))
+	}
+	data, _ := encoder.BytesWithoutTime()
+	flusher.FlushField(data)
+	flusher.FlushSeries(1)
+	_ = flusher.FlushMetric(10, start, start+uint16(len(values))-1)
+	return nopKVFlusher.Bytes()
+}
+
+type point struct {
+	slot  int
+	value float64
+}
+
+// mockSeriesDataResultSet mocks the filter result set, which appends the points of each field by low series id
+func mockSeriesDataResultSet(ctrl *gomock.Controller, familyTime int64,
+	data map[uint16][][]point) flow.FilterResultSet {
+	rs := flow.NewMockFilterResultSet(ctrl)
+	rs.EXPECT().Load(gomock.Any(), []field.ID{1, 2}, uint16(0), gomock.Any()).DoAndReturn(
+		func(queryFlow flow.StorageQueryFlow, fieldIDs []field.ID, highKey uint16, _ roaring.Container) flow.Scanner {
+			fieldAggs := queryFlow.GetAggregator(highKey).GetFieldAggregates()
+			blocks := make([]series.Block, len(fieldIDs))
+			for idx := range fieldIDs {
+				blocks[idx], _ = fieldAggs[idx].GetAggregateBlock(familyTime)
+			}
+			scanner := flow.NewMockScanner(ctrl)
+			scanner.EXPECT().Scan(gomock.Any()).DoAndReturn(func(lowSeriesID uint16) {
+				for idx, points := range data[lowSeriesID] {
+					for _, p := range points {
+						if blocks[idx].Append(p.slot, p.value) {
+							break
+						}
+					}
+				}
+			}).AnyTimes()
+			scanner.EXPECT().Close().Return(nil)
+			return scanner
+		})
+	return rs
+}
+
+func assertSeriesData(t *testing.T, it series.FieldIterator, aggType field.AggType, expect map[int]float64) {
+	assert.Equal(t, aggType, it.AggType())
+	result := make(map[int]float64)
+	for it.HasNext() {
+		slot, value := it.Next()
+		result[slot] = value
+	}
+	assert.Equal(t, expect, result)
+}
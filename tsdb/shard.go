@@ -8,10 +8,13 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/lindb/roaring"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/monitoring"
 	"github.com/lindb/lindb/pkg/logger"
@@ -19,6 +22,9 @@ import (
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/replication"
 	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/tsdb/indexdb"
 	"github.com/lindb/lindb/tsdb/memdb"
 	"github.com/lindb/lindb/tsdb/metadb"
@@ -94,6 +100,20 @@ type Shard interface {
 	MemoryDatabase() memdb.MemoryDatabase
 	// IndexDatabase returns the index-database
 	IndexDatabase() indexdb.IndexDatabase
+	// GetSeriesData returns the field iterators of each series in time range, the iterators are aligned with fields,
+	// nil if the field has no data, the series without any data is skipped,
+	// the time slot of field iterator is based on the start time of time range(truncated by shard's interval).
+	GetSeriesData(metricID uint32, seriesIDs *roaring.Bitmap, fields field.Metas,
+		timeRange timeutil.TimeRange) (map[uint32][]series.FieldIterator, error)
+	// GetLastDataPoint returns the latest data point(absolute timestamp and value) of field for each series,
+	// only the newest data family which contains the series is read, the series without any data is skipped.
+	GetLastDataPoint(metricID uint32, seriesIDs *roaring.Bitmap, fieldID field.ID) (map[uint32]DataPoint, error)
+	// GetSeriesIDsForTagInTimeRange returns the series ids of tag key which have data in time range,
+	// the time range can be a sub-range(window) of query time range, like the universe of negation for windowed query.
+	GetSeriesIDsForTagInTimeRange(metricID, tagKeyID uint32, timeRange timeutil.TimeRange) (*roaring.Bitmap, error)
+	// FindTopTagValues returns the k tag values of tag key with the most series which have data in time range,
+	// ranked by series count in descending order.
+	FindTopTagValues(metricID uint32, tagKey string, k int, timeRange timeutil.TimeRange) ([]TagValueCount, error)
 	// Write writes the metric-point into memory-database.
 	Write(metric *pb.Metric) error
 	// GetOrCreateSequence gets the replica sequence by given remote peer if exist, else creates a new sequence
@@ -131,6 +151,9 @@ type shard struct {
 
 	indexDB  indexdb.IndexDatabase
 	metadata metadb.Metadata
+	codecs   *aggregation.CodecRegistry // value codec registry of database
+	// tags hasher for grouping the series when assigning series id
+	tagsHasher tag.Hasher
 	// write accept time range
 	interval timeutil.Interval
 	ahead    timeutil.Interval
@@ -183,6 +206,8 @@ func newShard(
 		option:           option,
 		sequence:         replicaSequence,
 		metadata:         db.Metadata(),
+		codecs:           db.CodecRegistry(),
+		tagsHasher:       option.GetTagsHasher(),
 		interval:         interval,
 		segments:         make(map[timeutil.IntervalType]IntervalSegment),
 		isFlushing:       *atomic.NewBool(false),
@@ -255,6 +280,55 @@ func (s *shard) GetDataFamilies(intervalType timeutil.IntervalType, timeRange ti
 	return nil
 }
 
+// GetSeriesData returns the field iterators of each series in time range,
+// loads the data from data families and memory databases(immutable/mutable).
+func (s *shard) GetSeriesData(metricID uint32, seriesIDs *roaring.Bitmap, fields field.Metas,
+	timeRange timeutil.TimeRange,
+) (map[uint32][]series.FieldIterator, error) {
+	return getSeriesData(s.getDataFilters(timeRange), s.interval.Int64(), metricID, seriesIDs, fields, timeRange, s.codecs)
+}
+
+// getDataFilters returns the data filters in time range, data families first, then memory databases(immutable/mutable)
+func (s *shard) getDataFilters(timeRange timeutil.TimeRange) []flow.DataFilter {
+	var filters []flow.DataFilter
+	for _, family := range s.GetDataFamilies(s.interval.Type(), timeRange) {
+		filters = append(filters, family)
+	}
+	s.rwMutex.RLock()
+	if s.immutable != nil {
+		filters = append(filters, s.immutable)
+	}
+	if s.mutable != nil {
+		filters = append(filters, s.mutable)
+	}
+	s.rwMutex.RUnlock()
+	return filters
+}
+
+// GetLastDataPoint returns the latest data point of field for each series,
+// loads the data from memory databases(immutable/mutable) and data families from newest to oldest.
+func (s *shard) GetLastDataPoint(metricID uint32, seriesIDs *roaring.Bitmap,
+	fieldID field.ID,
+) (map[uint32]DataPoint, error) {
+	// the latest data point is limited by write ahead
+	ahead := s.ahead.Int64()
+	if ahead <= 0 {
+		ahead = timeutil.OneDay
+	}
+	timeRange := timeutil.TimeRange{Start: 0, End: timeutil.Now() + ahead}
+	var memFilters []flow.DataFilter
+	s.rwMutex.RLock()
+	if s.immutable != nil {
+		memFilters = append(memFilters, s.immutable)
+	}
+	if s.mutable != nil {
+		memFilters = append(memFilters, s.mutable)
+	}
+	s.rwMutex.RUnlock()
+	return getLastDataPoint(memFilters, s.GetDataFamilies(s.interval.Type(), timeRange), s.interval.Int64(),
+		metricID, seriesIDs, fieldID, timeRange)
+}
+
 // MemoryDatabase returns memory database
 func (s *shard) MemoryDatabase() memdb.MemoryDatabase {
 	var memDB memdb.MemoryDatabase
@@ -297,7 +371,7 @@ func (s *shard) Write(metric *pb.Metric) (err error) {
 		// if metric without tags, uses default series id(0)
 		seriesID = constants.SeriesIDWithoutTags
 	} else {
-		seriesID, isCreated, err = s.indexDB.GetOrCreateSeriesID(metricID, metric.TagsHash)
+		seriesID, isCreated, err = s.indexDB.GetOrCreateSeriesID(metricID, metric.TagsHash, s.tagsHasher.Group(metric.Tags))
 		if err != nil {
 			return err
 		}
@@ -480,6 +554,9 @@ func (s *shard) createMemoryDatabase() (memdb.MemoryDatabase, error) {
 		Interval: s.interval,
 		Metadata: s.metadata,
 		TempPath: filepath.Join(s.path, filepath.Join(tempDir, fmt.Sprintf("%d", timeutil.Now()))),
+
+		MergePolicies:    s.option.GetMergePolicies(),
+		ConflictPolicies: s.option.GetConflictPolicies(),
 	})
 }
 
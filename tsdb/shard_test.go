@@ -15,6 +15,7 @@ import (
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/tsdb/indexdb"
 	"github.com/lindb/lindb/tsdb/memdb"
 	"github.com/lindb/lindb/tsdb/metadb"
@@ -41,6 +42,7 @@ func TestShard_New(t *testing.T) {
 	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
 	db.EXPECT().Name().Return("db").AnyTimes()
 	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	db.EXPECT().CodecRegistry().Return(nil).AnyTimes()
 	// case 1: database option err
 	thisShard, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{})
 	assert.Error(t, err)
@@ -145,6 +147,7 @@ func TestShard_GetDataFamilies(t *testing.T) {
 	meta.EXPECT().DatabaseName().Return("test")
 	db.EXPECT().Name().Return("test-db").AnyTimes()
 	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	db.EXPECT().CodecRegistry().Return(nil).AnyTimes()
 	s, _ := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
 	assert.Nil(t, s.GetDataFamilies(timeutil.Month, timeutil.TimeRange{}))
 	assert.Nil(t, s.GetDataFamilies(timeutil.Day, timeutil.TimeRange{}))
@@ -167,6 +170,7 @@ func TestShard_Write(t *testing.T) {
 	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
 	db.EXPECT().Name().Return("test-db").AnyTimes()
 	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	db.EXPECT().CodecRegistry().Return(nil).AnyTimes()
 
 	mockMemDB := memdb.NewMockMemoryDatabase(ctrl)
 	mockMemDB.EXPECT().AcquireWrite().AnyTimes()
@@ -220,7 +224,7 @@ func TestShard_Write(t *testing.T) {
 	}))
 	// case 7: gen series id err
 	metadataDB.EXPECT().GenMetricID(constants.DefaultNamespace, "test").Return(uint32(10), nil).AnyTimes()
-	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10)).Return(uint32(0), false, fmt.Errorf("err"))
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10), uint64(0)).Return(uint32(0), false, fmt.Errorf("err"))
 	assert.Error(t, shardINTF.Write(&pb.Metric{
 		Name:      "test",
 		Timestamp: timeutil.Now(),
@@ -232,7 +236,7 @@ func TestShard_Write(t *testing.T) {
 		}},
 	}))
 	// case 7: get old series id
-	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10)).Return(uint32(10), false, nil)
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10), uint64(0)).Return(uint32(10), false, nil)
 	assert.NoError(t, shardINTF.Write(&pb.Metric{
 		Name:      "test",
 		Timestamp: timeutil.Now(),
@@ -244,7 +248,7 @@ func TestShard_Write(t *testing.T) {
 		}},
 	}))
 	// case 8: create new series id
-	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10)).Return(uint32(10), true, nil)
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10), uint64(0)).Return(uint32(10), true, nil)
 	indexDB.EXPECT().BuildInvertIndex(constants.DefaultNamespace, "test", map[string]string{"ip": "1.1.1.1"}, uint32(10))
 	assert.NoError(t, shardINTF.Write(&pb.Metric{
 		Name:      "test",
@@ -256,7 +260,22 @@ func TestShard_Write(t *testing.T) {
 			Value: 1.0,
 		}},
 	}))
-	// case 9: write metric without tags
+	// case 9: series of same group are assigned adjacent series ids
+	shardIns.tagsHasher = tag.NewGroupingHasher("ip")
+	group := shardIns.tagsHasher.Group(map[string]string{"ip": "1.1.1.1"})
+	assert.NotZero(t, group)
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10), group).Return(uint32(10), false, nil)
+	assert.NoError(t, shardINTF.Write(&pb.Metric{
+		Name:      "test",
+		Timestamp: timeutil.Now(),
+		TagsHash:  10,
+		Tags:      map[string]string{"ip": "1.1.1.1"},
+		Fields: []*pb.Field{{
+			Name:  "f1",
+			Value: 1.0,
+		}},
+	}))
+	// case 10: write metric without tags
 	assert.NoError(t, shardINTF.Write(&pb.Metric{
 		Name:      "test",
 		Timestamp: timeutil.Now(),
@@ -288,6 +307,7 @@ func TestShard_Close(t *testing.T) {
 	db := NewMockDatabase(ctrl)
 	db.EXPECT().Name().Return("test-db").AnyTimes()
 	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	db.EXPECT().CodecRegistry().Return(nil).AnyTimes()
 	s, _ := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
 	index := indexdb.NewMockIndexDatabase(ctrl)
 	s1 := s.(*shard)
@@ -421,6 +441,7 @@ func mockShard(ctrl *gomock.Controller) *shard {
 	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
 	db.EXPECT().Name().Return("test-db").AnyTimes()
 	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	db.EXPECT().CodecRegistry().Return(nil).AnyTimes()
 	s, _ := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
 	s1 := s.(*shard)
 	return s1
@@ -12,11 +12,13 @@ import (
 type FieldReader interface {
 	// slotRange returns the time slot range of metric level
 	slotRange() (start, end uint16)
+	// tsdVersion returns the format version of tsd block
+	tsdVersion() byte
 	// getFieldData returns the field data by field id,
 	// if reader is completed, return nil, if found data returns field data else returns nil
 	getFieldData(fieldID field.ID) []byte
 	// reset resets the field data for reading
-	reset(buf []byte, position int, start, end uint16)
+	reset(buf []byte, position int, start, end uint16, version byte)
 	// close closes the reader
 	close()
 }
@@ -24,6 +26,7 @@ type FieldReader interface {
 // fieldReader implements FieldReader
 type fieldReader struct {
 	start, end   uint16
+	version      byte
 	seriesData   []byte
 	fieldOffsets *encoding.FixedOffsetDecoder
 	fieldIndexes map[field.ID]int
@@ -33,20 +36,21 @@ type fieldReader struct {
 }
 
 // newFieldReader creates the field reader
-func newFieldReader(fieldIndexes map[field.ID]int, buf []byte, position int, start, end uint16) FieldReader {
+func newFieldReader(fieldIndexes map[field.ID]int, buf []byte, position int, start, end uint16, version byte) FieldReader {
 	r := &fieldReader{
 		fieldIndexes: fieldIndexes,
 		fieldCount:   len(fieldIndexes),
 	}
-	r.reset(buf, position, start, end)
+	r.reset(buf, position, start, end, version)
 	return r
 }
 
 // reset resets the field data for reading
-func (r *fieldReader) reset(buf []byte, position int, start, end uint16) {
+func (r *fieldReader) reset(buf []byte, position int, start, end uint16, version byte) {
 	r.completed = false
 	r.start = start
 	r.end = end
+	r.version = version
 	if r.fieldCount == 1 {
 		r.seriesData = buf
 		return
@@ -62,6 +66,11 @@ func (r *fieldReader) slotRange() (start, end uint16) {
 	return r.start, r.end
 }
 
+// tsdVersion returns the format version of tsd block
+func (r *fieldReader) tsdVersion() byte {
+	return r.version
+}
+
 // getFieldData returns the field data by field id,
 // if reader is completed, return nil, if found data returns field data else returns nil
 func (r *fieldReader) getFieldData(fieldID field.ID) []byte {
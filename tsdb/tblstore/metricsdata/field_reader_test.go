@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/series/field"
 )
 
@@ -16,7 +17,7 @@ func TestField_read(t *testing.T) {
 	assert.NotNil(t, r)
 	scanner := newDataScanner(r)
 	seriesPos := scanner.scan(0, 1)
-	fReader := newFieldReader(scanner.fieldIndexes(), block, seriesPos, 5, 5)
+	fReader := newFieldReader(scanner.fieldIndexes(), block, seriesPos, 5, 5, encoding.TSDVersion)
 	start, end := fReader.slotRange()
 	assert.Equal(t, uint16(5), start)
 	assert.Equal(t, uint16(5), end)
@@ -37,7 +38,7 @@ func TestField_read(t *testing.T) {
 	data = fReader.getFieldData(10)
 	assert.Nil(t, data)
 	// case 6: no fields
-	fReader = newFieldReader(scanner.fieldIndexes(), []byte{0, 0, 0}, 0, 5, 5)
+	fReader = newFieldReader(scanner.fieldIndexes(), []byte{0, 0, 0}, 0, 5, 5, encoding.TSDVersion)
 	data = fReader.getFieldData(10)
 	assert.Nil(t, data)
 }
@@ -49,7 +50,7 @@ func TestFieldReader_close(t *testing.T) {
 	assert.NotNil(t, r)
 	scanner := newDataScanner(r)
 	seriesPos := scanner.scan(0, 1)
-	fReader := newFieldReader(scanner.fieldIndexes(), block, seriesPos, 5, 5)
+	fReader := newFieldReader(scanner.fieldIndexes(), block, seriesPos, 5, 5, encoding.TSDVersion)
 	fReader.close()
 	data := fReader.getFieldData(2)
 	assert.Nil(t, data)
@@ -62,7 +63,7 @@ func TestFieldReader_reset(t *testing.T) {
 	assert.NotNil(t, r)
 	scanner := newDataScanner(r)
 	seriesPos := scanner.scan(0, 1)
-	fReader := newFieldReader(scanner.fieldIndexes(), block, seriesPos, 5, 5)
+	fReader := newFieldReader(scanner.fieldIndexes(), block, seriesPos, 5, 5, encoding.TSDVersion)
 	start, end := fReader.slotRange()
 	assert.Equal(t, uint16(5), start)
 	assert.Equal(t, uint16(5), end)
@@ -83,7 +84,7 @@ func TestFieldReader_reset(t *testing.T) {
 	block = nopKVFlusher.Bytes()
 
 	// reset value
-	fReader.reset(block, seriesPos, 15, 15)
+	fReader.reset(block, seriesPos, 15, 15, encoding.TSDVersion)
 	start, end = fReader.slotRange()
 	assert.Equal(t, uint16(15), start)
 	assert.Equal(t, uint16(15), end)
@@ -97,7 +98,7 @@ func TestFieldReader_read_one_field(t *testing.T) {
 	assert.NotNil(t, r)
 	scanner := newDataScanner(r)
 	seriesPos := scanner.scan(0, 1)
-	fReader := newFieldReader(scanner.fieldIndexes(), block, seriesPos, 5, 5)
+	fReader := newFieldReader(scanner.fieldIndexes(), block, seriesPos, 5, 5, encoding.TSDVersion)
 	start, end := fReader.slotRange()
 	assert.Equal(t, uint16(5), start)
 	assert.Equal(t, uint16(5), end)
@@ -29,6 +29,9 @@ type Flusher interface {
 	FlushFieldMetas(fieldMetas field.Metas)
 	// FlushField writes a compressed field data to writer.
 	FlushField(data []byte)
+	// WriteRawFieldBlock writes the field block in raw block verbatim without re-encoding,
+	// returns error if the version or checksum of raw block not match.
+	WriteRawFieldBlock(block []byte) error
 	// FlushSeries writes a full series, this will be called after writing all fields of this entry.
 	FlushSeries(seriesID uint32)
 	// FlushMetric writes a full metric-block, this will be called after writing all entries of this metric.
@@ -100,6 +103,17 @@ func (w *flusher) FlushField(data []byte) {
 	}
 }
 
+// WriteRawFieldBlock writes the field block in raw block verbatim without re-encoding,
+// returns error if the version or checksum of raw block not match.
+func (w *flusher) WriteRawFieldBlock(block []byte) error {
+	data, err := parseRawBlock(block)
+	if err != nil {
+		return err
+	}
+	w.FlushField(data)
+	return nil
+}
+
 // FlushSeries writes a full series, this will be called after writing all fields of this entry.
 // 1. only one field: series data = field data
 // 2. mutli-fields: series data = field offsets + fields data
@@ -195,6 +209,8 @@ func (w *flusher) FlushMetric(metricID uint32, start, end uint16) error {
 		// write field-type
 		w.writer.PutByte(byte(fm.Type))
 	}
+	// write format version of tsd block after field metas, the metric block before tsd version 2 has no version
+	w.writer.PutByte(encoding.TSDVersion)
 	// write series ids bitmap
 	seriesIDsBlock, err := encoding.BitmapMarshal(w.seriesIDs)
 	if err != nil {
@@ -83,10 +83,11 @@ func (m *merger) Merge(key uint32, values [][]byte) ([]byte, error) {
 				seriesPos := scanner.scan(highKey, lowSeriesID)
 				if seriesPos >= 0 {
 					start, end := scanner.slotRange()
+					version := scanner.tsdVersion()
 					if fieldReaders[blockIdx] == nil {
-						fieldReaders[blockIdx] = newFieldReader(scanner.fieldIndexes(), values[blockIdx], seriesPos, start, end)
+						fieldReaders[blockIdx] = newFieldReader(scanner.fieldIndexes(), values[blockIdx], seriesPos, start, end, version)
 					} else {
-						fieldReaders[blockIdx].reset(values[blockIdx], seriesPos, start, end)
+						fieldReaders[blockIdx].reset(values[blockIdx], seriesPos, start, end, version)
 					}
 				}
 			}
@@ -13,6 +13,7 @@ type metricScanner struct {
 	fieldAggs     []*fieldAggregator
 	lowContainer  roaring.Container
 	seriesOffsets *encoding.FixedOffsetDecoder
+	readAhead     *readAhead // nil if read-ahead is disabled
 
 	tsd *encoding.TSDDecoder
 }
@@ -22,12 +23,14 @@ func newMetricScanner(reader Reader,
 	fieldAggs []*fieldAggregator,
 	lowContainer roaring.Container,
 	seriesOffsets *encoding.FixedOffsetDecoder,
+	readAhead *readAhead,
 ) flow.Scanner {
 	return &metricScanner{
 		reader:        reader,
 		fieldAggs:     fieldAggs,
 		lowContainer:  lowContainer,
 		seriesOffsets: seriesOffsets,
+		readAhead:     readAhead,
 		tsd:           encoding.GetTSDDecoder(),
 	}
 }
@@ -42,12 +45,19 @@ func (s *metricScanner) Scan(lowSeriesID uint16) {
 	idx := s.lowContainer.Rank(lowSeriesID)
 	// scan the data and aggregate the values
 	seriesPos, _ := s.seriesOffsets.Get(idx - 1)
+	if s.readAhead != nil {
+		// prefetch next series blocks while decoding current one
+		s.readAhead.advance(idx - 1)
+	}
 	// read series data and agg it
 	s.reader.readSeriesData(seriesPos, s.tsd, s.fieldAggs)
 }
 
 // Close closes the resource of file storage metric scanner.
 func (s *metricScanner) Close() error {
+	if s.readAhead != nil {
+		s.readAhead.close()
+	}
 	encoding.ReleaseTSDDecoder(s.tsd)
 	return nil
 }
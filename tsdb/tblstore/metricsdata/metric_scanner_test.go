@@ -11,7 +11,7 @@ import (
 )
 
 func TestMetricScanner_Close(t *testing.T) {
-	s := newMetricScanner(nil, nil, nil, nil)
+	s := newMetricScanner(nil, nil, nil, nil, nil)
 	err := s.Close()
 	assert.NoError(t, err)
 }
@@ -21,7 +21,7 @@ func TestMetricScanner_Next(t *testing.T) {
 	defer ctrl.Finish()
 
 	// case 1: series id not exist
-	s := newMetricScanner(nil, []*fieldAggregator{nil}, roaring.BitmapOf(10).GetContainer(0), nil)
+	s := newMetricScanner(nil, []*fieldAggregator{nil}, roaring.BitmapOf(10).GetContainer(0), nil, nil)
 	s.Scan(1)
 	// case 2: read series data
 	r := NewMockReader(ctrl)
@@ -30,6 +30,6 @@ func TestMetricScanner_Next(t *testing.T) {
 	encoder.Add(100)
 	data := encoder.MarshalBinary()
 	seriesOffsets := encoding.NewFixedOffsetDecoder(data)
-	s = newMetricScanner(r, []*fieldAggregator{nil}, roaring.BitmapOf(10).GetContainer(0), seriesOffsets)
+	s = newMetricScanner(r, []*fieldAggregator{nil}, roaring.BitmapOf(10).GetContainer(0), seriesOffsets, nil)
 	s.Scan(10)
 }
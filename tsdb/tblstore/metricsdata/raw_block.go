@@ -0,0 +1,45 @@
+package metricsdata
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+// RawBlockVersion represents the version of field block encoding(format version of tsd block) in raw block,
+// the raw block with different version is rejected when writes it, because it maybe cannot be decoded.
+const RawBlockVersion = encoding.TSDVersion
+
+// rawBlockHeaderSize is the size of raw block header(version + crc32 checksum)
+const rawBlockHeaderSize = 1 + 4
+
+// ErrBadRawBlock represents the raw block is invalid(too short or checksum mismatch)
+var ErrBadRawBlock = errors.New("bad raw field block")
+
+// newRawBlock wraps the field block verbatim into raw block,
+// format: version(1 byte) + crc32 checksum of field block(4 bytes) + field block
+func newRawBlock(version byte, data []byte) []byte {
+	block := make([]byte, rawBlockHeaderSize+len(data))
+	block[0] = version
+	stream.PutUint32(block, 1, crc32.ChecksumIEEE(data))
+	copy(block[rawBlockHeaderSize:], data)
+	return block
+}
+
+// parseRawBlock validates the version and checksum of raw block, then returns the field block
+func parseRawBlock(block []byte) ([]byte, error) {
+	if len(block) <= rawBlockHeaderSize {
+		return nil, ErrBadRawBlock
+	}
+	if block[0] != RawBlockVersion {
+		return nil, fmt.Errorf("raw field block version not match, expect: %d, actual: %d", RawBlockVersion, block[0])
+	}
+	data := block[rawBlockHeaderSize:]
+	if stream.ReadUint32(block, 1) != crc32.ChecksumIEEE(data) {
+		return nil, ErrBadRawBlock
+	}
+	return data, nil
+}
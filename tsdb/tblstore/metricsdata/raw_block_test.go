@@ -0,0 +1,148 @@
+package metricsdata
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/bit"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series/field"
+)
+
+var rawBlockFields = field.Metas{
+	{ID: 2, Type: field.SumField},
+	{ID: 10, Type: field.MinField},
+	{ID: 30, Type: field.SumField},
+}
+
+// mockRawFieldData mocks the field data, empty if value is 0
+func mockRawFieldData(value int) []byte {
+	if value == 0 {
+		return nil
+	}
+	encoder := encoding.NewTSDEncoder(5)
+	for i := 0; i < 10; i++ {
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(math.Float64bits(float64(value * i)))
+	}
+	data, _ := encoder.BytesWithoutTime()
+	return data
+}
+
+// mockRawMetricBlock mocks the metric block, series 1/4096 have all fields, series 4097 has no field 10
+func mockRawMetricBlock(fields field.Metas) (Reader, []uint32) {
+	nopKVFlusher := kv.NewNopFlusher()
+	flusher := NewFlusher(nopKVFlusher)
+	flusher.FlushFieldMetas(fields)
+	seriesIDs := []uint32{1, 4096, 4097}
+	for idx, seriesID := range seriesIDs {
+		for fIdx := range fields {
+			value := idx*10 + fIdx + 1
+			if seriesID == 4097 && fIdx == 1 {
+				value = 0
+			}
+			flusher.FlushField(mockRawFieldData(value))
+		}
+		flusher.FlushSeries(seriesID)
+	}
+	_ = flusher.FlushMetric(10, 5, 14)
+	r, _ := NewReader("1.sst", nopKVFlusher.Bytes())
+	return r, seriesIDs
+}
+
+func assertFieldBlockEqual(t *testing.T, expect, actual []byte) {
+	d1 := encoding.NewTSDDecoder(expect)
+	d2 := encoding.NewTSDDecoder(actual)
+	d1.ResetWithTimeRange(expect, 5, 14)
+	d2.ResetWithTimeRange(actual, 5, 14)
+	for d1.Next() {
+		assert.True(t, d2.Next())
+		assert.Equal(t, d1.HasValue(), d2.HasValue())
+		if d1.HasValue() {
+			assert.Equal(t, d1.Slot(), d2.Slot())
+			assert.Equal(t, d1.Value(), d2.Value())
+		}
+	}
+	assert.False(t, d2.Next())
+}
+
+func TestRawFieldBlock_copy(t *testing.T) {
+	for _, fields := range []field.Metas{rawBlockFields, rawBlockFields[:1]} {
+		src, seriesIDs := mockRawMetricBlock(fields)
+		// copies field blocks to replica
+		nopKVFlusher := kv.NewNopFlusher()
+		flusher := NewFlusher(nopKVFlusher)
+		flusher.FlushFieldMetas(fields)
+		for _, seriesID := range seriesIDs {
+			for _, f := range fields {
+				block, err := src.ReadRawFieldBlock(seriesID, f.ID)
+				if err == constants.ErrNotFound {
+					flusher.FlushField(nil)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.NoError(t, flusher.WriteRawFieldBlock(block))
+			}
+			flusher.FlushSeries(seriesID)
+		}
+		assert.NoError(t, flusher.FlushMetric(10, 5, 14))
+		replica, err := NewReader("2.sst", nopKVFlusher.Bytes())
+		assert.NoError(t, err)
+
+		for idx, seriesID := range seriesIDs {
+			for fIdx, f := range fields {
+				block1, err1 := src.ReadRawFieldBlock(seriesID, f.ID)
+				block2, err2 := replica.ReadRawFieldBlock(seriesID, f.ID)
+				assert.Equal(t, err1, err2)
+				// copied verbatim
+				assert.Equal(t, block1, block2)
+				if err1 != nil {
+					continue
+				}
+				data, err := parseRawBlock(block2)
+				assert.NoError(t, err)
+				expect := mockRawFieldData(idx*10 + fIdx + 1)
+				assert.Equal(t, expect, data)
+				assertFieldBlockEqual(t, expect, data)
+			}
+		}
+	}
+}
+
+func TestReader_ReadRawFieldBlock_not_found(t *testing.T) {
+	r, _ := mockRawMetricBlock(rawBlockFields)
+	// field not found
+	_, err := r.ReadRawFieldBlock(1, 100)
+	assert.Equal(t, constants.ErrNotFound, err)
+	// high key not found
+	_, err = r.ReadRawFieldBlock(65536, 2)
+	assert.Equal(t, constants.ErrNotFound, err)
+	// low key not found
+	_, err = r.ReadRawFieldBlock(2, 2)
+	assert.Equal(t, constants.ErrNotFound, err)
+	// field no data
+	_, err = r.ReadRawFieldBlock(4097, 10)
+	assert.Equal(t, constants.ErrNotFound, err)
+}
+
+func TestFlusher_WriteRawFieldBlock_fail(t *testing.T) {
+	flusher := NewFlusher(kv.NewNopFlusher())
+	flusher.FlushFieldMetas(rawBlockFields)
+	block := newRawBlock(RawBlockVersion, mockRawFieldData(1))
+
+	// version not match
+	block[0] = RawBlockVersion + 1
+	assert.Error(t, flusher.WriteRawFieldBlock(block))
+	block[0] = RawBlockVersion
+	// checksum not match
+	block[len(block)-1]++
+	assert.Equal(t, ErrBadRawBlock, flusher.WriteRawFieldBlock(block))
+	block[len(block)-1]--
+	// too short
+	assert.Equal(t, ErrBadRawBlock, flusher.WriteRawFieldBlock(block[:rawBlockHeaderSize]))
+	assert.NoError(t, flusher.WriteRawFieldBlock(block))
+}
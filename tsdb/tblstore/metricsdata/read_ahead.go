@@ -0,0 +1,143 @@
+package metricsdata
+
+import (
+	"sync"
+
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+// DefaultReadAheadBlocks represents the default num. of series blocks prefetched ahead of the scanning one
+const DefaultReadAheadBlocks = 4
+
+// DefaultReadAheadBudget represents the default max bytes of series blocks prefetched ahead of the scanning one
+const DefaultReadAheadBudget = 1024 * 1024
+
+// pageSize is the granularity of touching the mapped file when prefetches series blocks
+const pageSize = 4096
+
+// for testing
+var (
+	prefetchFunc = prefetch
+)
+
+var (
+	readAheadBlocks = atomic.NewInt32(DefaultReadAheadBlocks)
+	readAheadBudget = atomic.NewInt64(DefaultReadAheadBudget)
+	// prefetchSink keeps the touched bytes alive, so that the page touching isn't optimized out
+	prefetchSink atomic.Uint32
+)
+
+// SetReadAhead sets the depth(num. of series blocks) and the memory budget(bytes) of read-ahead
+// for sequential scan, blocks <= 0 or budget <= 0 means disable read-ahead
+func SetReadAhead(blocks int, budget int64) {
+	if blocks < 0 {
+		blocks = 0
+	}
+	if budget < 0 {
+		budget = 0
+	}
+	readAheadBlocks.Store(int32(blocks))
+	readAheadBudget.Store(budget)
+}
+
+// readAhead prefetches the series blocks after the scanning one in background,
+// so that the page faults of mapped file(I/O) are overlapped with decoding/aggregating(CPU).
+// series of container are stored in order of series id, which is the order of scanning.
+type readAhead struct {
+	data          []byte // file data, series blocks of container end with the low offsets block
+	seriesOffsets *encoding.FixedOffsetDecoder
+	dataEnd       int // end position of the last series block
+	count         int // num. of series blocks
+	depth         int
+	budget        int
+	next          int // index of the next series block to prefetch
+
+	running atomic.Bool
+	wait    sync.WaitGroup
+}
+
+// newReadAhead creates a read-ahead for the series blocks of container,
+// returns nil if read-ahead is disabled or container has only one series.
+func newReadAhead(data []byte, seriesOffsets *encoding.FixedOffsetDecoder, dataEnd, count int) *readAhead {
+	depth := int(readAheadBlocks.Load())
+	budget := int(readAheadBudget.Load())
+	if depth <= 0 || budget <= 0 || count <= 1 {
+		return nil
+	}
+	return &readAhead{
+		data:          data,
+		seriesOffsets: seriesOffsets,
+		dataEnd:       dataEnd,
+		count:         count,
+		depth:         depth,
+		budget:        budget,
+	}
+}
+
+// advance prefetches the series blocks after current one asynchronously,
+// at most depth blocks and budget bytes ahead of current one, skips if last prefetch is still running.
+func (ra *readAhead) advance(current int) {
+	if ra.next <= current {
+		ra.next = current + 1
+	}
+	if ra.next >= ra.count || ra.running.Load() {
+		return
+	}
+	currentEnd := ra.blockEnd(current)
+	limit := current + ra.depth
+	if limit >= ra.count {
+		limit = ra.count - 1
+	}
+	last := ra.next - 1
+	for last < limit && ra.blockEnd(last+1)-currentEnd <= ra.budget {
+		last++
+	}
+	if last < ra.next {
+		// next block exceeds the budget, waits for scanning closer
+		return
+	}
+	start, ok := ra.seriesOffsets.Get(ra.next)
+	end := ra.blockEnd(last)
+	ra.next = last + 1
+	if !ok || start >= end || end > len(ra.data) {
+		return
+	}
+	ra.running.Store(true)
+	ra.wait.Add(1)
+	go func() {
+		defer func() {
+			ra.running.Store(false)
+			ra.wait.Done()
+		}()
+		prefetchFunc(ra.data[start:end])
+	}()
+}
+
+// blockEnd returns the end position of series block by index
+func (ra *readAhead) blockEnd(idx int) int {
+	if idx+1 < ra.count {
+		if next, ok := ra.seriesOffsets.Get(idx + 1); ok {
+			return next
+		}
+	}
+	return ra.dataEnd
+}
+
+// close waits for the running prefetch, must be called before the mapped file is released
+func (ra *readAhead) close() {
+	ra.wait.Wait()
+}
+
+// prefetch touches one byte of each page, so that the page faults are taken by the background goroutine
+func prefetch(data []byte) {
+	var sum byte
+	for i := 0; i < len(data); i += pageSize {
+		sum += data[i]
+	}
+	if len(data) > 0 {
+		sum += data[len(data)-1]
+	}
+	prefetchSink.Store(uint32(sum))
+}
@@ -0,0 +1,166 @@
+package metricsdata
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/bit"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestSetReadAhead(t *testing.T) {
+	defer SetReadAhead(DefaultReadAheadBlocks, DefaultReadAheadBudget)
+
+	SetReadAhead(-1, -1)
+	assert.Equal(t, int32(0), readAheadBlocks.Load())
+	assert.Equal(t, int64(0), readAheadBudget.Load())
+	// disabled
+	assert.Nil(t, newReadAhead(nil, nil, 0, 10))
+	SetReadAhead(2, 100)
+	assert.NotNil(t, newReadAhead(nil, nil, 0, 10))
+	// container has only one series
+	assert.Nil(t, newReadAhead(nil, nil, 0, 1))
+}
+
+func TestReadAhead_advance(t *testing.T) {
+	defer func() {
+		prefetchFunc = prefetch
+		SetReadAhead(DefaultReadAheadBlocks, DefaultReadAheadBudget)
+	}()
+	var lock sync.Mutex
+	var prefetched [][2]int
+	data := make([]byte, 100)
+	prefetchFunc = func(block []byte) {
+		lock.Lock()
+		defer lock.Unlock()
+		start := cap(data) - cap(block)
+		prefetched = append(prefetched, [2]int{start, start + len(block)})
+	}
+	// 5 series blocks, each 10 bytes, ends at 50
+	encoder := encoding.NewFixedOffsetEncoder()
+	for i := 0; i < 5; i++ {
+		encoder.Add(i * 10)
+	}
+	seriesOffsets := encoding.NewFixedOffsetDecoder(encoder.MarshalBinary())
+
+	// case 1: bounded by depth
+	SetReadAhead(2, 100)
+	ra := newReadAhead(data, seriesOffsets, 50, 5)
+	ra.advance(0)
+	ra.close()
+	assert.Equal(t, [][2]int{{10, 30}}, prefetched)
+	// block 1,2 prefetched, prefetch block 3
+	ra.advance(1)
+	ra.close()
+	assert.Equal(t, [][2]int{{10, 30}, {30, 40}}, prefetched)
+	// skip series, prefetch from the block after current
+	ra.advance(3)
+	ra.close()
+	assert.Equal(t, [][2]int{{10, 30}, {30, 40}, {40, 50}}, prefetched)
+	// no more block
+	ra.advance(4)
+	ra.close()
+	assert.Len(t, prefetched, 3)
+
+	// case 2: bounded by budget
+	prefetched = nil
+	SetReadAhead(10, 25)
+	ra = newReadAhead(data, seriesOffsets, 50, 5)
+	ra.advance(0)
+	ra.close()
+	assert.Equal(t, [][2]int{{10, 30}}, prefetched)
+	// case 3: next block exceeds budget
+	prefetched = nil
+	SetReadAhead(10, 5)
+	ra = newReadAhead(data, seriesOffsets, 50, 5)
+	ra.advance(0)
+	ra.close()
+	assert.Empty(t, prefetched)
+	// case 4: last prefetch is running
+	SetReadAhead(2, 100)
+	ra = newReadAhead(data, seriesOffsets, 50, 5)
+	ra.running.Store(true)
+	ra.advance(0)
+	assert.Equal(t, 1, ra.next)
+	// case 5: bad offsets
+	SetReadAhead(2, 1000)
+	ra = newReadAhead(data, seriesOffsets, 200, 5)
+	ra.advance(3)
+	ra.close()
+	assert.Empty(t, prefetched)
+}
+
+func TestReadAhead_prefetch(t *testing.T) {
+	data := make([]byte, 3*pageSize)
+	data[0] = 1
+	data[pageSize] = 2
+	data[len(data)-1] = 3
+	prefetch(data)
+	assert.Equal(t, uint32(6), prefetchSink.Load())
+	prefetch(nil)
+	assert.Equal(t, uint32(0), prefetchSink.Load())
+}
+
+// nopBlock discards the values, so that the benchmark measures scan only
+type nopBlock struct{}
+
+func (b nopBlock) Append(slot int, value float64) bool { return false }
+func (b nopBlock) Clear()                              {}
+
+func BenchmarkMetricScanner_readAhead(b *testing.B) {
+	defer SetReadAhead(DefaultReadAheadBlocks, DefaultReadAheadBudget)
+
+	// 512 long series, each has 4096 points
+	const seriesCount = 512
+	nopKVFlusher := kv.NewNopFlusher()
+	flusher := NewFlusher(nopKVFlusher)
+	flusher.FlushFieldMetas(field.Metas{{ID: 1, Type: field.SumField}})
+	for j := 0; j < seriesCount; j++ {
+		encoder := encoding.NewTSDEncoder(0)
+		for i := 0; i < 4096; i++ {
+			encoder.AppendTime(bit.One)
+			encoder.AppendValue(math.Float64bits(float64(i * j)))
+		}
+		data, _ := encoder.BytesWithoutTime()
+		flusher.FlushField(data)
+		flusher.FlushSeries(uint32(j))
+	}
+	_ = flusher.FlushMetric(uint32(10), 0, 4095)
+	r, err := NewReader("1.sst", nopKVFlusher.Bytes())
+	if err != nil {
+		b.Fatal(err)
+	}
+	rr := r.(*reader)
+	rr.readFieldIndexes = []int{0}
+	fieldAggs := []*fieldAggregator{newFieldAggregator(rr.fields[0], nopBlock{})}
+	container := rr.seriesIDs.GetContainerAtIndex(0)
+	offset, _ := rr.highOffsets.Get(0)
+	seriesOffsets := encoding.NewFixedOffsetDecoder(rr.buf[offset:])
+
+	run := func(b *testing.B, blocks int) {
+		SetReadAhead(blocks, DefaultReadAheadBudget)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			ra := newReadAhead(rr.buf, seriesOffsets, offset, container.GetCardinality())
+			scanner := newMetricScanner(r, fieldAggs, container, seriesOffsets, ra)
+			for lowSeriesID := 0; lowSeriesID < seriesCount; lowSeriesID++ {
+				scanner.Scan(uint16(lowSeriesID))
+			}
+			_ = scanner.Close()
+		}
+	}
+	b.Run("no-read-ahead", func(b *testing.B) {
+		run(b, 0)
+	})
+	b.Run("read-ahead-4", func(b *testing.B) {
+		run(b, 4)
+	})
+	b.Run("read-ahead-16", func(b *testing.B) {
+		run(b, 16)
+	})
+}
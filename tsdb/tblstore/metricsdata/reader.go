@@ -7,6 +7,7 @@ import (
 	"github.com/lindb/roaring"
 
 	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/stream"
@@ -42,6 +43,9 @@ type Reader interface {
 	GetTimeRange() (start, end uint16)
 	// Load loads the data from sst file, then returns the file metric scanner.
 	Load(queryFlow flow.StorageQueryFlow, familyTime int64, fieldIDs []field.ID, highKey uint16, seriesID roaring.Container) flow.Scanner
+	// ReadRawFieldBlock returns the raw block which wraps the field block of series verbatim without decoding,
+	// used for copying the field block to replica, if not found returns constants.ErrNotFound.
+	ReadRawFieldBlock(seriesID uint32, fieldID field.ID) ([]byte, error)
 	// readSeriesData reads series data from file by given position.
 	readSeriesData(position int, tsd *encoding.TSDDecoder, fieldAggs []*fieldAggregator)
 }
@@ -50,14 +54,23 @@ type Reader interface {
 type fieldAggregator struct {
 	fieldMeta field.Meta
 	block     series.Block
+	predicate encoding.ValuePredicate // value filter pushed down into decoder, nil if no filter
 }
 
-// newFieldAggregator creates a field aggregator
+// newFieldAggregator creates a field aggregator,
+// if block has value filter, pushes the filter down into decoder so that non-qualifying points are skipped.
 func newFieldAggregator(fieldMeta field.Meta, block series.Block) *fieldAggregator {
-	return &fieldAggregator{
+	agg := &fieldAggregator{
 		fieldMeta: fieldMeta,
 		block:     block,
 	}
+	if b, filter, ok := series.UnwrapFilteredBlock(block); ok {
+		agg.block = b
+		agg.predicate = func(value uint64) bool {
+			return filter.Match(math.Float64frombits(value))
+		}
+	}
+	return agg
 }
 
 // reader implements Reader interface that reads metric block
@@ -69,6 +82,7 @@ type reader struct {
 	fields        field.Metas
 	crc32CheckSum uint32
 	start, end    uint16
+	tsdVersion    byte // format version of tsd block
 
 	readFieldIndexes []int // read field indexes be used when query metric data
 }
@@ -152,7 +166,75 @@ func (r *reader) Load(queryFlow flow.StorageQueryFlow,
 		return nil
 	}
 	// must use lowContainer from store, because get series index based on container
-	return newMetricScanner(r, fieldAggs, lowContainer, seriesOffsets)
+	ra := newReadAhead(r.buf, seriesOffsets, offset, lowContainer.GetCardinality())
+	return newMetricScanner(r, fieldAggs, lowContainer, seriesOffsets, ra)
+}
+
+// ReadRawFieldBlock returns the raw block which wraps the field block of series verbatim without decoding,
+// used for copying the field block to replica, if not found returns constants.ErrNotFound.
+func (r *reader) ReadRawFieldBlock(seriesID uint32, fieldID field.ID) ([]byte, error) {
+	fieldIdx := -1
+	for idx, f := range r.fields {
+		if f.ID == fieldID {
+			fieldIdx = idx
+			break
+		}
+	}
+	highIdx := r.seriesIDs.GetContainerIndex(encoding.HighBits(seriesID))
+	if fieldIdx < 0 || highIdx < 0 {
+		return nil, constants.ErrNotFound
+	}
+	container := r.seriesIDs.GetContainerAtIndex(highIdx)
+	lowSeriesID := encoding.LowBits(seriesID)
+	if !container.Contains(lowSeriesID) {
+		return nil, constants.ErrNotFound
+	}
+	lowIdx := container.Rank(lowSeriesID) - 1
+	lowOffsetsPos, ok := r.highOffsets.Get(highIdx)
+	if !ok {
+		return nil, constants.ErrNotFound
+	}
+	seriesOffsets := encoding.NewFixedOffsetDecoder(r.buf[lowOffsetsPos:])
+	start, ok := seriesOffsets.Get(lowIdx)
+	if !ok {
+		return nil, constants.ErrNotFound
+	}
+	// series data ends with the next series data or the low offsets block of container
+	end := lowOffsetsPos
+	if lowIdx+1 < container.GetCardinality() {
+		if next, ok := seriesOffsets.Get(lowIdx + 1); ok {
+			end = next
+		}
+	}
+	if start > end || end > len(r.buf) {
+		return nil, ErrBadRawBlock
+	}
+	data := r.buf[start:end]
+	fieldCount := r.fields.Len()
+	if fieldCount > 1 {
+		// series data = field offsets + fields data, field data ends with the next present field
+		fieldOffsets := encoding.NewFixedOffsetDecoder(data)
+		fieldsData := data[fieldOffsets.Header()+fieldCount*fieldOffsets.ValueWidth():]
+		offset, ok := fieldOffsets.Get(fieldIdx)
+		if !ok {
+			return nil, constants.ErrNotFound
+		}
+		fieldEnd := len(fieldsData)
+		for idx := fieldIdx + 1; idx < fieldCount; idx++ {
+			if next, ok := fieldOffsets.Get(idx); ok {
+				fieldEnd = next
+				break
+			}
+		}
+		if offset > fieldEnd || fieldEnd > len(fieldsData) {
+			return nil, ErrBadRawBlock
+		}
+		data = fieldsData[offset:fieldEnd]
+	}
+	if len(data) == 0 {
+		return nil, constants.ErrNotFound
+	}
+	return newRawBlock(r.tsdVersion, data), nil
 }
 
 // readSeriesData reads series data from file by given position.
@@ -160,9 +242,9 @@ func (r *reader) readSeriesData(position int, tsd *encoding.TSDDecoder, fieldAgg
 	fieldCount := r.fields.Len()
 	if fieldCount == 1 {
 		// metric has one field, just read the data
-		tsd.ResetWithTimeRange(r.buf[position:], r.start, r.end)
+		tsd.ResetWithVersion(r.buf[position:], r.start, r.end, r.tsdVersion)
 		// read field data
-		r.readField(fieldAggs[0].block, tsd)
+		r.readField(fieldAggs[0], tsd)
 		return
 	}
 	// read data for mutli-fields
@@ -172,23 +254,23 @@ func (r *reader) readSeriesData(position int, tsd *encoding.TSDDecoder, fieldAgg
 	for i, idx := range r.readFieldIndexes {
 		offset, ok := fieldOffsets.Get(idx)
 		if ok {
-			tsd.ResetWithTimeRange(fieldsData[offset:], r.start, r.end)
+			tsd.ResetWithVersion(fieldsData[offset:], r.start, r.end, r.tsdVersion)
 			// read field data
-			r.readField(fieldAggs[i].block, tsd)
+			r.readField(fieldAggs[i], tsd)
 		}
 	}
 }
 
 // readField reads field data and aggregates it
-func (r *reader) readField(block series.Block, tsd *encoding.TSDDecoder) {
-	for tsd.Next() {
-		if tsd.HasValue() {
-			timeSlot := tsd.Slot()
-			val := tsd.Value()
-
-			if block.Append(int(timeSlot), math.Float64frombits(val)) {
-				return
-			}
+func (r *reader) readField(fieldAgg *fieldAggregator, tsd *encoding.TSDDecoder) {
+	tsd.SetPredicate(fieldAgg.predicate)
+	for {
+		timeSlot, val, ok := tsd.NextMatched()
+		if !ok {
+			return
+		}
+		if fieldAgg.block.Append(int(timeSlot), math.Float64frombits(val)) {
+			return
 		}
 	}
 }
@@ -224,6 +306,11 @@ func (r *reader) initReader() error {
 		}
 		offset += 2
 	}
+	// read format version of tsd block if exist
+	r.tsdVersion = encoding.TSDVersion1
+	if offset < seriesIDsStartPos {
+		r.tsdVersion = r.buf[offset]
+	}
 
 	// read series ids
 	seriesIDs := roaring.New()
@@ -286,6 +373,11 @@ func (s *dataScanner) slotRange() (start, end uint16) {
 	return s.reader.GetTimeRange()
 }
 
+// tsdVersion returns the format version of tsd block in current sst file
+func (s *dataScanner) tsdVersion() byte {
+	return s.reader.tsdVersion
+}
+
 // scan scans the data and returns series position if series id exist, else returns -1
 func (s *dataScanner) scan(highKey, lowSeriesID uint16) int {
 	if s.highKey < highKey {
@@ -1,7 +1,9 @@
 package metricsdata
 
 import (
+	"bytes"
 	"fmt"
+	"hash/crc32"
 	"math"
 	"testing"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/pkg/bit"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 )
@@ -137,6 +140,115 @@ func TestReader_Load(t *testing.T) {
 	assert.NoError(t, err)
 	scanner = r.Load(qFlow, 10, []field.ID{2}, 10, roaring.BitmapOf(4096, 8192).GetContainer(0))
 	assert.Nil(t, scanner)
+	// case 8: value filter pushed down into decoder, value 0 of slot 5 is filtered out
+	gomock.InOrder(
+		qFlow.EXPECT().GetAggregator(uint16(0)).Return(cAgg),
+		cAgg.EXPECT().GetFieldAggregates().Return(aggregation.FieldAggregates{sAgg1, sAgg2, nil}),
+		sAgg1.EXPECT().GetAggregateBlock(int64(10)).Return(series.NewFilteredBlock(block1, greaterThan(0)), true),
+	)
+	r, err = NewReader("1.sst", mockMetricBlockForOneField())
+	assert.NoError(t, err)
+	scanner = r.Load(qFlow, 10, []field.ID{2}, 0, roaring.BitmapOf(4096, 8192).GetContainer(0))
+	scanner.Scan(4096)
+	scanner.Scan(8192)
+	// case 9: value filter pushed down into decoder, value matched
+	gomock.InOrder(
+		qFlow.EXPECT().GetAggregator(uint16(0)).Return(cAgg),
+		cAgg.EXPECT().GetFieldAggregates().Return(aggregation.FieldAggregates{sAgg1, sAgg2, nil}),
+		sAgg1.EXPECT().GetAggregateBlock(int64(10)).Return(series.NewFilteredBlock(block1, greaterThan(-1)), true),
+		block1.EXPECT().Append(5, 0.0).Times(2),
+	)
+	r, err = NewReader("1.sst", mockMetricBlockForOneField())
+	assert.NoError(t, err)
+	scanner = r.Load(qFlow, 10, []field.ID{2}, 0, roaring.BitmapOf(4096, 8192).GetContainer(0))
+	scanner.Scan(4096)
+	scanner.Scan(8192)
+}
+
+func TestReader_Load_old_tsd_version(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	qFlow := flow.NewMockStorageQueryFlow(ctrl)
+	cAgg := aggregation.NewMockContainerAggregator(ctrl)
+	sAgg := aggregation.NewMockSeriesAggregator(ctrl)
+	block := series.NewMockBlock(ctrl)
+
+	r, err := NewReader("1.sst", mockMetricBlockForOneField())
+	assert.NoError(t, err)
+	assert.Equal(t, encoding.TSDVersion, r.(*reader).tsdVersion)
+
+	// metric block flushed before tsd version 2, tsd block has no codec id
+	r, err = NewReader("1.sst", mockMetricBlockOfTSDVersion1())
+	assert.NoError(t, err)
+	assert.Equal(t, encoding.TSDVersion1, r.(*reader).tsdVersion)
+	gomock.InOrder(
+		qFlow.EXPECT().GetAggregator(uint16(0)).Return(cAgg),
+		cAgg.EXPECT().GetFieldAggregates().Return(aggregation.FieldAggregates{sAgg}),
+		sAgg.EXPECT().GetAggregateBlock(int64(10)).Return(block, true),
+		block.EXPECT().Append(5, 10.0),
+		block.EXPECT().Append(6, 20.0),
+		block.EXPECT().Append(8, 40.0),
+	)
+	scanner := r.Load(qFlow, 10, []field.ID{2}, 0, roaring.BitmapOf(4096).GetContainer(0))
+	scanner.Scan(4096)
+
+	// merge rewrites the old block with current tsd version
+	data, err := NewMerger().Merge(10, [][]byte{mockMetricBlockOfTSDVersion1()})
+	assert.NoError(t, err)
+	r, err = NewReader("1.sst", data)
+	assert.NoError(t, err)
+	assert.Equal(t, encoding.TSDVersion, r.(*reader).tsdVersion)
+	gomock.InOrder(
+		qFlow.EXPECT().GetAggregator(uint16(0)).Return(cAgg),
+		cAgg.EXPECT().GetFieldAggregates().Return(aggregation.FieldAggregates{sAgg}),
+		sAgg.EXPECT().GetAggregateBlock(int64(10)).Return(block, true),
+		block.EXPECT().Append(5, 10.0),
+		block.EXPECT().Append(6, 20.0),
+		block.EXPECT().Append(8, 40.0),
+	)
+	scanner = r.Load(qFlow, 10, []field.ID{2}, 0, roaring.BitmapOf(4096).GetContainer(0))
+	scanner.Scan(4096)
+}
+
+// mockMetricBlockOfTSDVersion1 mocks the metric block flushed before tsd version 2,
+// which has no tsd version after field metas and no codec id in tsd block.
+func mockMetricBlockOfTSDVersion1() []byte {
+	var buf bytes.Buffer
+	writer := bit.NewWriter(&buf)
+	values := encoding.NewXOREncoder(writer)
+	for _, v := range []float64{10, 20, 0, 40} {
+		if v == 0 {
+			_ = writer.WriteBit(bit.Zero)
+			continue
+		}
+		_ = writer.WriteBit(bit.One)
+		_ = values.Write(math.Float64bits(v))
+	}
+	_ = writer.Flush()
+
+	nopKVFlusher := kv.NewNopFlusher()
+	flusher := NewFlusher(nopKVFlusher)
+	flusher.FlushFieldMetas(field.Metas{{ID: 2, Type: field.SumField}})
+	flusher.FlushField(buf.Bytes())
+	flusher.FlushSeries(4096)
+	_ = flusher.FlushMetric(uint32(10), 5, 8)
+	block := nopKVFlusher.Bytes()
+
+	// removes the tsd version after field metas, then fixes the positions in footer
+	footerPos := len(block) - dataFooterSize
+	versionPos := int(stream.ReadUint32(block, footerPos+4)) + 1 + 2
+	block = append(block[:versionPos:versionPos], block[versionPos+1:]...)
+	footerPos--
+	stream.PutUint32(block, footerPos+8, stream.ReadUint32(block, footerPos+8)-1)
+	stream.PutUint32(block, footerPos+12, stream.ReadUint32(block, footerPos+12)-1)
+	stream.PutUint32(block, footerPos+16, crc32.ChecksumIEEE(block[:footerPos+16]))
+	return block
+}
+
+type greaterThan float64
+
+func (f greaterThan) Match(value float64) bool {
+	return value > float64(f)
 }
 
 func TestReader_scan(t *testing.T) {
@@ -51,8 +51,8 @@ func (sm *seriesMerger) merge(mergeCtx *mergerContext,
 					streams[idx] = encoding.GetTSDDecoder()
 				}
 				oldStart, oldEnd := reader.slotRange()
-				// reset tsd data
-				streams[idx].ResetWithTimeRange(fieldData, oldStart, oldEnd)
+				// reset tsd data, the old block is decoded by its format version
+				streams[idx].ResetWithVersion(fieldData, oldStart, oldEnd, reader.tsdVersion())
 			}
 		}
 		// merge field data
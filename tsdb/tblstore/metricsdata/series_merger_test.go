@@ -30,8 +30,10 @@ func TestSeriesMerger_compact_merge(t *testing.T) {
 	// case 1: merge success and rollup
 	reader1.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
 	reader1.EXPECT().slotRange().Return(uint16(10), uint16(10))
+	reader1.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	reader2.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
 	reader2.EXPECT().slotRange().Return(uint16(10), uint16(10))
+	reader2.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	var result []byte
 	flusher.EXPECT().FlushField(gomock.Any()).DoAndReturn(func(data []byte) {
 		result = data
@@ -59,8 +61,10 @@ func TestSeriesMerger_compact_merge(t *testing.T) {
 	// case 2: merge success with diff slot range
 	reader1.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
 	reader1.EXPECT().slotRange().Return(uint16(10), uint16(10))
+	reader1.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	reader2.EXPECT().getFieldData(gomock.Any()).Return(mockField(12))
 	reader2.EXPECT().slotRange().Return(uint16(12), uint16(12))
+	reader2.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	flusher.EXPECT().FlushField(gomock.Any()).DoAndReturn(func(data []byte) {
 		result = data
 	})
@@ -87,8 +91,10 @@ func TestSeriesMerger_compact_merge(t *testing.T) {
 	encodeStream2 := encoding.NewMockTSDEncoder(ctrl)
 	reader1.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
 	reader1.EXPECT().slotRange().Return(uint16(10), uint16(10))
+	reader1.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	reader2.EXPECT().getFieldData(gomock.Any()).Return(mockField(12))
 	reader2.EXPECT().slotRange().Return(uint16(12), uint16(12))
+	reader2.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	encodeStream2.EXPECT().AppendTime(gomock.Any()).AnyTimes()
 	encodeStream2.EXPECT().AppendValue(gomock.Any()).AnyTimes()
 	encodeStream2.EXPECT().BytesWithoutTime().Return(nil, fmt.Errorf("err"))
@@ -121,8 +127,10 @@ func TestSeriesMerger_rollup_merge(t *testing.T) {
 	// case 1: merge success and rollup
 	reader1.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
 	reader1.EXPECT().slotRange().Return(uint16(10), uint16(10))
+	reader1.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	reader2.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
 	reader2.EXPECT().slotRange().Return(uint16(12), uint16(12))
+	reader2.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	var result []byte
 	flusher.EXPECT().FlushField(gomock.Any()).DoAndReturn(func(data []byte) {
 		result = data
@@ -151,8 +159,10 @@ func TestSeriesMerger_rollup_merge(t *testing.T) {
 	// case 2: merge success and rollup
 	reader1.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
 	reader1.EXPECT().slotRange().Return(uint16(10), uint16(10))
+	reader1.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	reader2.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
 	reader2.EXPECT().slotRange().Return(uint16(182), uint16(182))
+	reader2.EXPECT().tsdVersion().Return(encoding.TSDVersion)
 	flusher.EXPECT().FlushField(gomock.Any()).DoAndReturn(func(data []byte) {
 		result = data
 	})
@@ -36,8 +36,11 @@ type TagKeyMeta interface {
 	// FindTagValueIDsByLike finds tagValueIDs like tagValue,
 	// 3 cases: *sdb, ts*, *sd*
 	FindTagValueIDsByLike(tagValue string) (tagValueIDs []uint32)
-	// FindTagValueIDsByRegex finds tagValueIDs by regex pattern,
+	// FindTagValueIDsByRegex finds tagValueIDs by regex pattern, returns nil if evaluation exceeds the regex timeout
 	FindTagValueIDsByRegex(tagValuePattern string) (tagValueIDs []uint32)
+	// FindTagValueIDsByCompare finds tagValueIDs by scanning the sorted tag values,
+	// the tag value matches if compare returns true(relational operators like >, >=, <, <=)
+	FindTagValueIDsByCompare(compare func(tagValue []byte) bool) (tagValueIDs []uint32)
 }
 
 const (
@@ -271,13 +274,27 @@ func (meta *tagKeyMeta) PrefixIterator(tagValuePrefix []byte) (*trie.PrefixItera
 func (meta *tagKeyMeta) FindTagValueIDsByLike(tagValue string) (tagValueIDs []uint32) {
 	hashPrefix := strings.HasPrefix(tagValue, "*")
 	hasSuffix := strings.HasSuffix(tagValue, "*")
-	tagValueSlice := strutil.String2ByteSlice(tagValue)
+	// adjacent wildcards(**) at both ends are same as one
+	tagValueSlice := strutil.String2ByteSlice(strings.Trim(tagValue, "*"))
 	switch {
 	case tagValue == "":
 		break
+	// has inner *, like a*b*c, iterates the literal prefix before first *, then does glob matching
+	case strutil.HasInnerWildcard(tagValue):
+		literalPrefix := tagValue[:strings.IndexByte(tagValue, '*')]
+		itr, err := meta.PrefixIterator(strutil.String2ByteSlice(literalPrefix))
+		if err != nil {
+			return nil
+		}
+		for itr.Valid() {
+			if strutil.GlobMatch(tagValue, strutil.ByteSlice2String(itr.Key())) {
+				tagValueIDs = append(tagValueIDs, encoding.ByteSlice2Uint32(itr.Value()))
+			}
+			itr.Next()
+		}
 	// only endswith *
 	case !hashPrefix && hasSuffix:
-		itr, err := meta.PrefixIterator(tagValueSlice[:len(tagValueSlice)-1])
+		itr, err := meta.PrefixIterator(tagValueSlice)
 		if err != nil {
 			return nil
 		}
@@ -287,26 +304,24 @@ func (meta *tagKeyMeta) FindTagValueIDsByLike(tagValue string) (tagValueIDs []ui
 		}
 	// only startswith *
 	case hashPrefix && !hasSuffix:
-		suffix := tagValueSlice[1:]
 		itr, err := meta.PrefixIterator(nil)
 		if err != nil {
 			return nil
 		}
 		for itr.Valid() {
-			if bytes.HasSuffix(itr.Key(), suffix) {
+			if bytes.HasSuffix(itr.Key(), tagValueSlice) {
 				tagValueIDs = append(tagValueIDs, encoding.ByteSlice2Uint32(itr.Value()))
 			}
 			itr.Next()
 		}
 	// startswith and endswith *
 	case hashPrefix && hasSuffix:
-		middle := tagValueSlice[1 : len(tagValueSlice)-1]
 		itr, err := meta.PrefixIterator(nil)
 		if err != nil {
 			return nil
 		}
 		for itr.Valid() {
-			if bytes.Contains(itr.Key(), middle) {
+			if bytes.Contains(itr.Key(), tagValueSlice) {
 				tagValueIDs = append(tagValueIDs, encoding.ByteSlice2Uint32(itr.Value()))
 			}
 			itr.Next()
@@ -328,7 +343,12 @@ func (meta *tagKeyMeta) FindTagValueIDsByRegex(tagValuePattern string) (tagValue
 	if err != nil {
 		return nil
 	}
+	deadline := strutil.NewRegexDeadline()
 	for itr.Valid() {
+		if deadline.Exceeded() {
+			// evaluation exceeds the regex timeout
+			return nil
+		}
 		if rp.Match(itr.Key()) {
 			tagValueIDs = append(tagValueIDs, encoding.ByteSlice2Uint32(itr.Value()))
 		}
@@ -336,3 +356,17 @@ func (meta *tagKeyMeta) FindTagValueIDsByRegex(tagValuePattern string) (tagValue
 	}
 	return tagValueIDs
 }
+
+func (meta *tagKeyMeta) FindTagValueIDsByCompare(compare func(tagValue []byte) bool) (tagValueIDs []uint32) {
+	itr, err := meta.PrefixIterator(nil)
+	if err != nil {
+		return nil
+	}
+	for itr.Valid() {
+		if compare(itr.Key()) {
+			tagValueIDs = append(tagValueIDs, encoding.ByteSlice2Uint32(itr.Value()))
+		}
+		itr.Next()
+	}
+	return tagValueIDs
+}
@@ -2,6 +2,7 @@ package tagkeymeta
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"testing"
 
@@ -143,6 +144,36 @@ func TestTagKeyMeta_FindTagValueIDsByLike(t *testing.T) {
 	// case5: nil search
 	assert.Len(t, meta.FindTagValueIDsByLike(""), 0)
 
+	// case6: inner wildcards, resolves exactly the matched tag values
+	expectIDs := func(match func(a, b, c, d int) bool) []uint32 {
+		var ids []uint32
+		for a := 1; a <= 10; a++ {
+			for b := 1; b <= 10; b++ {
+				for c := 1; c <= 10; c++ {
+					for d := 1; d <= 10; d++ {
+						if match(a, b, c, d) {
+							ids = append(ids, uint32((a-1)*1000+(b-1)*100+(c-1)*10+d-1))
+						}
+					}
+				}
+			}
+		}
+		return ids
+	}
+	sorted := func(ids []uint32) []uint32 {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}
+	assert.Equal(t, expectIDs(func(a, b, c, d int) bool { return a == 10 && d == 10 }),
+		sorted(meta.FindTagValueIDsByLike("10.*.*.10")))
+	assert.Equal(t, expectIDs(func(a, b, c, d int) bool { return b == 2 && d == 3 }),
+		sorted(meta.FindTagValueIDsByLike("*.2.*.3")))
+	assert.Equal(t, expectIDs(func(a, b, c, d int) bool { return a == 1 && c == 5 }),
+		sorted(meta.FindTagValueIDsByLike("1.**.5.*")))
+	// adjacent wildcards at both ends
+	assert.Len(t, meta.FindTagValueIDsByLike("**"), 10000)
+	assert.Len(t, meta.FindTagValueIDsByLike("**.1.1.1"), 10)
+
 }
 
 func TestTagKeyMeta_FindTagValueIDsByRegex(t *testing.T) {
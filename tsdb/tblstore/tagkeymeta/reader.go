@@ -1,6 +1,8 @@
 package tagkeymeta
 
 import (
+	"bytes"
+
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
@@ -104,10 +106,14 @@ func (r *tagReader) FindValueIDsByExprForTagKeyID(tagID uint32, expr stmt.TagFil
 			tagValueIDs.AddMany(tagKeyMeta.FindTagValueID(expression.Value))
 		case *stmt.InExpr:
 			tagValueIDs.AddMany(tagKeyMeta.FindTagValueIDs(expression.Values))
+		case *stmt.AllOfExpr:
+			tagValueIDs.AddMany(tagKeyMeta.FindTagValueIDs(expression.Values))
 		case *stmt.LikeExpr:
 			tagValueIDs.AddMany(tagKeyMeta.FindTagValueIDsByLike(expression.Value))
 		case *stmt.RegexExpr:
 			tagValueIDs.AddMany(tagKeyMeta.FindTagValueIDsByRegex(expression.Regexp))
+		case *stmt.GreaterExpr, *stmt.GreaterEqualExpr, *stmt.LessExpr, *stmt.LessEqualExpr:
+			tagValueIDs.AddMany(tagKeyMeta.FindTagValueIDsByCompare(newTagValueCompare(expression)))
 		default:
 			return nil, constants.ErrNotFound
 		}
@@ -118,6 +124,26 @@ func (r *tagReader) FindValueIDsByExprForTagKeyID(tagID uint32, expr stmt.TagFil
 	return tagValueIDs, nil
 }
 
+// newTagValueCompare returns the compare function of relational tag filter,
+// tag values are compared lexicographically(byte-wise).
+func newTagValueCompare(expr stmt.TagFilter) func(tagValue []byte) bool {
+	switch e := expr.(type) {
+	case *stmt.GreaterExpr:
+		value := strutil.String2ByteSlice(e.Value)
+		return func(tagValue []byte) bool { return bytes.Compare(tagValue, value) > 0 }
+	case *stmt.GreaterEqualExpr:
+		value := strutil.String2ByteSlice(e.Value)
+		return func(tagValue []byte) bool { return bytes.Compare(tagValue, value) >= 0 }
+	case *stmt.LessExpr:
+		value := strutil.String2ByteSlice(e.Value)
+		return func(tagValue []byte) bool { return bytes.Compare(tagValue, value) < 0 }
+	case *stmt.LessEqualExpr:
+		value := strutil.String2ByteSlice(e.Value)
+		return func(tagValue []byte) bool { return bytes.Compare(tagValue, value) <= 0 }
+	}
+	return func(tagValue []byte) bool { return false }
+}
+
 // GetTagValueIDsForTagKeyID get tag value ids for spec metric's tag key id
 func (r *tagReader) GetTagValueIDsForTagKeyID(tagID uint32) (*roaring.Bitmap, error) {
 	tagKeyMetas := r.filterTagKeyMetas(tagID)
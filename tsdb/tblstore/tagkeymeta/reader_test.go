@@ -324,3 +324,27 @@ func TestReader_CollectTagValues(t *testing.T) {
 	err = mockReader.CollectTagValues(19, roaring.BitmapOf(1, 2, 3), map[uint32]string{})
 	assert.NoError(t, err)
 }
+
+func TestReader_FindSeriesIDsByExprForTagID_CompareExpr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	reader := mockTagReader(ctrl)
+
+	idSet, err := reader.FindValueIDsByExprForTagKeyID(20, &stmt.GreaterExpr{Key: "zone", Value: "nj"})
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(2), idSet)
+	idSet, err = reader.FindValueIDsByExprForTagKeyID(20, &stmt.GreaterEqualExpr{Key: "zone", Value: "nj"})
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1, 2), idSet)
+	idSet, err = reader.FindValueIDsByExprForTagKeyID(20, &stmt.LessExpr{Key: "zone", Value: "nj"})
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(3), idSet)
+	idSet, err = reader.FindValueIDsByExprForTagKeyID(20, &stmt.LessEqualExpr{Key: "zone", Value: "nj"})
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1, 3), idSet)
+
+	// find not existed zone
+	_, err = reader.FindValueIDsByExprForTagKeyID(20, &stmt.LessExpr{Key: "zone", Value: "bj"})
+	assert.Error(t, err)
+	assert.False(t, newTagValueCompare(&stmt.EqualsExpr{Key: "zone", Value: "bj"})([]byte("bj")))
+}
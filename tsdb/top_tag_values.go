@@ -0,0 +1,91 @@
+package tsdb
+
+import (
+	"sort"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// TagValueCount represents the tag value with the num. of series which have the tag value
+type TagValueCount struct {
+	Value string
+	Count uint64
+}
+
+// tagValueIDCount represents the tag value id with the num. of series for ranking
+type tagValueIDCount struct {
+	tagValueID uint32
+	count      uint64
+}
+
+// FindTopTagValues returns the k tag values of tag key with the most series which have data in time range,
+// ranked by the cardinality of series ids of each tag value in descending order,
+// if the series count is the same, the tag value created earlier(smaller tag value id) is first.
+func (s *shard) FindTopTagValues(metricID uint32, tagKey string, k int,
+	timeRange timeutil.TimeRange,
+) ([]TagValueCount, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	tagKeyID, err := s.metadata.MetadataDatabase().GetTagKeyIDByMetricID(metricID, tagKey)
+	if err != nil {
+		return nil, err
+	}
+	// only counts the series which have data in time range
+	seriesIDs, err := s.GetSeriesIDsForTagInTimeRange(metricID, tagKeyID, timeRange)
+	if err != nil {
+		return nil, err
+	}
+	if seriesIDs.IsEmpty() {
+		return nil, nil
+	}
+	tagMetadata := s.metadata.TagMetadata()
+	tagValueIDs, err := tagMetadata.GetTagValueIDsForTag(tagKeyID)
+	if err != nil {
+		return nil, err
+	}
+	var counts []tagValueIDCount
+	it := tagValueIDs.Iterator()
+	for it.HasNext() {
+		tagValueID := it.Next()
+		tagValueSeriesIDs, err := s.indexDB.GetSeriesIDsByTagValueIDs(tagKeyID, roaring.BitmapOf(tagValueID))
+		if err != nil {
+			return nil, err
+		}
+		count := tagValueSeriesIDs.AndCardinality(seriesIDs)
+		if count > 0 {
+			counts = append(counts, tagValueIDCount{tagValueID: tagValueID, count: count})
+		}
+	}
+	if len(counts) == 0 {
+		return nil, nil
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].count == counts[j].count {
+			return counts[i].tagValueID < counts[j].tagValueID
+		}
+		return counts[i].count > counts[j].count
+	})
+	if len(counts) > k {
+		counts = counts[:k]
+	}
+	topTagValueIDs := roaring.New()
+	for _, c := range counts {
+		topTagValueIDs.Add(c.tagValueID)
+	}
+	tagValues := make(map[uint32]string)
+	if err := tagMetadata.CollectTagValues(tagKeyID, topTagValueIDs, tagValues); err != nil {
+		return nil, err
+	}
+	result := make([]TagValueCount, 0, len(counts))
+	for _, c := range counts {
+		tagValue, ok := tagValues[c.tagValueID]
+		if !ok {
+			continue
+		}
+		result = append(result, TagValueCount{Value: tagValue, Count: c.count})
+	}
+	return result, nil
+}
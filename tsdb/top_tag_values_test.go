@@ -0,0 +1,133 @@
+package tsdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/memdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestShard_FindTopTagValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeRange := timeutil.TimeRange{Start: 10, End: 100}
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	tagMetadata := metadb.NewMockTagMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMetadata).AnyTimes()
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	family := NewMockDataFamily(ctrl)
+	mutable := memdb.NewMockMemoryDatabase(ctrl)
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().getDataFamilies(timeRange).Return([]DataFamily{family}).AnyTimes()
+	s := &shard{
+		interval: timeutil.Interval(10 * timeutil.OneSecond),
+		segments: map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment},
+		mutable:  mutable,
+		metadata: metadata,
+		indexDB:  indexDB,
+	}
+
+	// case 1: k <= 0
+	result, err := s.FindTopTagValues(10, "host", 0, timeRange)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	// case 2: tag key not found
+	metadataDB.EXPECT().GetTagKeyIDByMetricID(uint32(10), "ip").Return(uint32(0), constants.ErrNotFound)
+	result, err = s.FindTopTagValues(10, "ip", 2, timeRange)
+	assert.Equal(t, constants.ErrNotFound, err)
+	assert.Nil(t, result)
+	metadataDB.EXPECT().GetTagKeyIDByMetricID(uint32(10), "host").Return(uint32(1), nil).AnyTimes()
+	// case 3: get fields err
+	metadataDB.EXPECT().GetAllFieldsByMetricID(uint32(10)).Return(nil, fmt.Errorf("err"))
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	metadataDB.EXPECT().GetAllFieldsByMetricID(uint32(10)).Return([]field.Meta{{ID: 1}, {ID: 2}}, nil).AnyTimes()
+	// case 4: get series ids err
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(nil, fmt.Errorf("err"))
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	// case 5: no series
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(roaring.New(), nil)
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	seriesIDs := roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7)
+	indexDB.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(seriesIDs, nil).AnyTimes()
+	// case 6: filter data err
+	family.EXPECT().Filter(uint32(10), []field.ID{1, 2}, seriesIDs, timeRange).Return(nil, fmt.Errorf("err"))
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	// case 7: no series has data in time range
+	family.EXPECT().Filter(uint32(10), []field.ID{1, 2}, seriesIDs, timeRange).Return(nil, constants.ErrNotFound)
+	mutable.EXPECT().Filter(uint32(10), []field.ID{1, 2}, seriesIDs, timeRange).Return(nil, constants.ErrNotFound)
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	// series 7 has no data in time range
+	fileRS := flow.NewMockFilterResultSet(ctrl)
+	fileRS.EXPECT().SeriesIDs().Return(roaring.BitmapOf(1, 2, 3, 4)).AnyTimes()
+	memRS := flow.NewMockFilterResultSet(ctrl)
+	memRS.EXPECT().SeriesIDs().Return(roaring.BitmapOf(3, 5, 6)).AnyTimes()
+	family.EXPECT().Filter(uint32(10), []field.ID{1, 2}, seriesIDs, timeRange).
+		Return([]flow.FilterResultSet{fileRS}, nil).AnyTimes()
+	mutable.EXPECT().Filter(uint32(10), []field.ID{1, 2}, seriesIDs, timeRange).
+		Return([]flow.FilterResultSet{memRS}, nil).AnyTimes()
+	// case 8: get tag value ids err
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(1)).Return(nil, fmt.Errorf("err"))
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(1)).Return(roaring.BitmapOf(1, 2, 3, 4), nil).AnyTimes()
+	// case 9: get series ids of tag value err
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(1)).Return(nil, fmt.Errorf("err"))
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	// host a => 1,2,3, host b => 4, host c => 5,6, host d => 7
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(1)).Return(roaring.BitmapOf(1, 2, 3), nil).AnyTimes()
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(2)).Return(roaring.BitmapOf(4), nil).AnyTimes()
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(3)).Return(roaring.BitmapOf(5, 6), nil).AnyTimes()
+	indexDB.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), roaring.BitmapOf(4)).Return(roaring.BitmapOf(7), nil).AnyTimes()
+	collect := func(tagKeyID uint32, tagValueIDs *roaring.Bitmap, tagValues map[uint32]string) error {
+		names := map[uint32]string{1: "a", 2: "b", 3: "c", 4: "d"}
+		it := tagValueIDs.Iterator()
+		for it.HasNext() {
+			tagValueID := it.Next()
+			tagValues[tagValueID] = names[tagValueID]
+		}
+		return nil
+	}
+	// case 10: collect tag values err
+	tagMetadata.EXPECT().CollectTagValues(uint32(1), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	tagMetadata.EXPECT().CollectTagValues(uint32(1), gomock.Any(), gomock.Any()).DoAndReturn(collect).AnyTimes()
+	// case 11: top 2 tag values
+	result, err = s.FindTopTagValues(10, "host", 2, timeRange)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagValueCount{{Value: "a", Count: 3}, {Value: "c", Count: 2}}, result)
+	// case 12: k > num. of tag values, tag value without data in time range is ignored
+	result, err = s.FindTopTagValues(10, "host", 10, timeRange)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagValueCount{{Value: "a", Count: 3}, {Value: "c", Count: 2}, {Value: "b", Count: 1}}, result)
+}